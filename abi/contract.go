@@ -0,0 +1,75 @@
+package abi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Interface is an ARC-4 interface description: a named set of methods
+type Interface struct {
+	Name    string   `json:"name"`
+	Desc    string   `json:"desc,omitempty"`
+	Methods []Method `json:"methods"`
+}
+
+// ContractNetworkInfo holds a contract's deployment on one network
+type ContractNetworkInfo struct {
+	AppID uint64 `json:"appID"`
+}
+
+// Contract is an ARC-4 contract description: the methods of an application,
+// and the applications implementing it on each network
+type Contract struct {
+	Name string `json:"name"`
+	Desc string `json:"desc,omitempty"`
+
+	// Networks maps the base64 genesis hash of a network to the contract's deployment there
+	Networks map[string]ContractNetworkInfo `json:"networks,omitempty"`
+
+	Methods []Method `json:"methods"`
+
+	// Events are the ARC-28 events the contract emits
+	Events []Event `json:"events,omitempty"`
+}
+
+// GetMethodByName returns the interface's method with the given name or signature
+func (i Interface) GetMethodByName(name string) (Method, error) {
+	return getMethodByName(i.Methods, name)
+}
+
+// GetMethodByName returns the contract's method with the given name or signature
+func (c Contract) GetMethodByName(name string) (Method, error) {
+	return getMethodByName(c.Methods, name)
+}
+
+// getMethodByName finds a method by its signature, or by its name if no other
+// method has the same name
+func getMethodByName(methods []Method, name string) (Method, error) {
+	if strings.Contains(name, "(") {
+		for _, method := range methods {
+			if method.GetSignature() == name {
+				return method, nil
+			}
+		}
+		return Method{}, fmt.Errorf("found no method with signature %s", name)
+	}
+
+	var matches []Method
+	for _, method := range methods {
+		if method.Name == name {
+			matches = append(matches, method)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return Method{}, fmt.Errorf("found no method named %s", name)
+	case 1:
+		return matches[0], nil
+	default:
+		signatures := make([]string, len(matches))
+		for i, method := range matches {
+			signatures[i] = method.GetSignature()
+		}
+		return Method{}, fmt.Errorf("found %d methods named %s, use one of the signatures %s", len(matches), name, strings.Join(signatures, ", "))
+	}
+}
@@ -0,0 +1,408 @@
+package abi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// Encode encodes value as the ABI type t.
+//
+// Values are accepted as follows:
+//   - Uint, Ufixed: any Go integer type (if not negative) or *big.Int; the
+//     Ufixed value is the integer value scaled by 10^precision
+//   - Byte: byte
+//   - Bool: bool
+//   - Address: types.Address, [32]byte or a 32 byte []byte
+//   - String: string
+//   - ArrayStatic, ArrayDynamic, Tuple: a slice or array of element values,
+//     e.g. []interface{}, or []byte for byte arrays
+func (t Type) Encode(value interface{}) ([]byte, error) {
+	switch t.kind {
+	case Uint, Ufixed:
+		return encodeInt(value, t.bitSize)
+	case Byte:
+		b, ok := value.(byte)
+		if !ok {
+			return nil, fmt.Errorf("cannot encode %T as byte", value)
+		}
+		return []byte{b}, nil
+	case Bool:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot encode %T as bool", value)
+		}
+		if b {
+			return []byte{0x80}, nil
+		}
+		return []byte{0x00}, nil
+	case Address:
+		switch v := value.(type) {
+		case types.Address:
+			return append([]byte{}, v[:]...), nil
+		case [addressByteSize]byte:
+			return append([]byte{}, v[:]...), nil
+		case []byte:
+			if len(v) != addressByteSize {
+				return nil, fmt.Errorf("cannot encode %d bytes as address", len(v))
+			}
+			return append([]byte{}, v...), nil
+		default:
+			return nil, fmt.Errorf("cannot encode %T as address", value)
+		}
+	case String:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot encode %T as string", value)
+		}
+		if len(s) > maxTupleLength {
+			return nil, fmt.Errorf("string is too long to encode: %d bytes", len(s))
+		}
+		encoded := make([]byte, lengthEncodeByteSize, lengthEncodeByteSize+len(s))
+		binary.BigEndian.PutUint16(encoded, uint16(len(s)))
+		return append(encoded, s...), nil
+	case ArrayStatic:
+		values, err := toValueSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != int(t.staticLength) {
+			return nil, fmt.Errorf("cannot encode %d values as %s", len(values), t)
+		}
+		return encodeTuple(values, repeatType(t.childTypes[0], len(values)))
+	case ArrayDynamic:
+		values, err := toValueSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) > maxTupleLength {
+			return nil, fmt.Errorf("array is too long to encode: %d values", len(values))
+		}
+		elems, err := encodeTuple(values, repeatType(t.childTypes[0], len(values)))
+		if err != nil {
+			return nil, err
+		}
+		encoded := make([]byte, lengthEncodeByteSize, lengthEncodeByteSize+len(elems))
+		binary.BigEndian.PutUint16(encoded, uint16(len(values)))
+		return append(encoded, elems...), nil
+	case Tuple:
+		values, err := toValueSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		return encodeTuple(values, t.childTypes)
+	default:
+		return nil, fmt.Errorf("cannot encode value as invalid type")
+	}
+}
+
+// Decode decodes encoded as the ABI type t.
+//
+// Values are returned as follows:
+//   - Uint, Ufixed: uint8, uint16, uint32 or uint64 for sizes up to 8, 16,
+//     32 and 64 bits respectively, and *big.Int for larger sizes
+//   - Byte: byte
+//   - Bool: bool
+//   - Address: types.Address
+//   - String: string
+//   - ArrayStatic, ArrayDynamic, Tuple: []interface{} of element values
+func (t Type) Decode(encoded []byte) (interface{}, error) {
+	switch t.kind {
+	case Uint, Ufixed:
+		if len(encoded) != int(t.bitSize/8) {
+			return nil, fmt.Errorf("cannot decode %d bytes as %s", len(encoded), t)
+		}
+		return decodeInt(encoded, t.bitSize), nil
+	case Byte:
+		if len(encoded) != 1 {
+			return nil, fmt.Errorf("cannot decode %d bytes as byte", len(encoded))
+		}
+		return encoded[0], nil
+	case Bool:
+		if len(encoded) != 1 {
+			return nil, fmt.Errorf("cannot decode %d bytes as bool", len(encoded))
+		}
+		switch encoded[0] {
+		case 0x80:
+			return true, nil
+		case 0x00:
+			return false, nil
+		default:
+			return nil, fmt.Errorf("cannot decode 0x%02x as bool", encoded[0])
+		}
+	case Address:
+		if len(encoded) != addressByteSize {
+			return nil, fmt.Errorf("cannot decode %d bytes as address", len(encoded))
+		}
+		var addr types.Address
+		copy(addr[:], encoded)
+		return addr, nil
+	case String:
+		length, content, err := splitLength(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if len(content) != length {
+			return nil, fmt.Errorf("string length %d does not match its %d encoded bytes", length, len(content))
+		}
+		return string(content), nil
+	case ArrayStatic:
+		return decodeTuple(encoded, repeatType(t.childTypes[0], int(t.staticLength)))
+	case ArrayDynamic:
+		length, content, err := splitLength(encoded)
+		if err != nil {
+			return nil, err
+		}
+		return decodeTuple(content, repeatType(t.childTypes[0], length))
+	case Tuple:
+		return decodeTuple(encoded, t.childTypes)
+	default:
+		return nil, fmt.Errorf("cannot decode value as invalid type")
+	}
+}
+
+// encodeInt encodes a non-negative integer in bitSize bits, big-endian
+func encodeInt(value interface{}, bitSize uint16) ([]byte, error) {
+	var n *big.Int
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("cannot encode nil *big.Int")
+		}
+		n = v
+	case uint:
+		n = new(big.Int).SetUint64(uint64(v))
+	case uint8:
+		n = new(big.Int).SetUint64(uint64(v))
+	case uint16:
+		n = new(big.Int).SetUint64(uint64(v))
+	case uint32:
+		n = new(big.Int).SetUint64(uint64(v))
+	case uint64:
+		n = new(big.Int).SetUint64(v)
+	case int:
+		n = big.NewInt(int64(v))
+	case int8:
+		n = big.NewInt(int64(v))
+	case int16:
+		n = big.NewInt(int64(v))
+	case int32:
+		n = big.NewInt(int64(v))
+	case int64:
+		n = big.NewInt(v)
+	default:
+		return nil, fmt.Errorf("cannot encode %T as an integer", value)
+	}
+	if n.Sign() < 0 {
+		return nil, fmt.Errorf("cannot encode negative integer %s", n)
+	}
+	if n.BitLen() > int(bitSize) {
+		return nil, fmt.Errorf("integer %s does not fit in %d bits", n, bitSize)
+	}
+	encoded := make([]byte, bitSize/8)
+	b := n.Bytes()
+	copy(encoded[len(encoded)-len(b):], b)
+	return encoded, nil
+}
+
+// decodeInt decodes a big-endian integer of bitSize bits into the smallest
+// fitting unsigned integer type, or a *big.Int if it is wider than 64 bits
+func decodeInt(encoded []byte, bitSize uint16) interface{} {
+	if bitSize > 64 {
+		return new(big.Int).SetBytes(encoded)
+	}
+	var v uint64
+	for _, b := range encoded {
+		v = v<<8 | uint64(b)
+	}
+	switch {
+	case bitSize <= 8:
+		return uint8(v)
+	case bitSize <= 16:
+		return uint16(v)
+	case bitSize <= 32:
+		return uint32(v)
+	default:
+		return v
+	}
+}
+
+// encodeTuple encodes values as a tuple of childTypes: the heads of all
+// elements, static elements inline and offsets to dynamic ones, followed by
+// the encodings of the dynamic elements
+func encodeTuple(values []interface{}, childTypes []Type) ([]byte, error) {
+	if len(values) != len(childTypes) {
+		return nil, fmt.Errorf("cannot encode %d values as a tuple of %d elements", len(values), len(childTypes))
+	}
+
+	heads := make([][]byte, len(childTypes))
+	tails := make([][]byte, len(childTypes))
+	isDynamic := make([]bool, len(childTypes))
+	for i := 0; i < len(childTypes); i++ {
+		switch {
+		case childTypes[i].IsDynamic():
+			tail, err := childTypes[i].Encode(values[i])
+			if err != nil {
+				return nil, err
+			}
+			isDynamic[i] = true
+			heads[i] = make([]byte, lengthEncodeByteSize)
+			tails[i] = tail
+		case childTypes[i].kind == Bool:
+			run := boolRunLength(childTypes, i)
+			var packed byte
+			for j := 0; j < run; j++ {
+				b, ok := values[i+j].(bool)
+				if !ok {
+					return nil, fmt.Errorf("cannot encode %T as bool", values[i+j])
+				}
+				if b {
+					packed |= 0x80 >> uint(j)
+				}
+			}
+			heads[i] = []byte{packed}
+			i += run - 1
+		default:
+			head, err := childTypes[i].Encode(values[i])
+			if err != nil {
+				return nil, err
+			}
+			heads[i] = head
+		}
+	}
+
+	headLength := 0
+	for _, head := range heads {
+		headLength += len(head)
+	}
+	offset := headLength
+	for i := range childTypes {
+		if !isDynamic[i] {
+			continue
+		}
+		if offset > maxTupleLength {
+			return nil, fmt.Errorf("tuple encoding is too long: offset %d", offset)
+		}
+		binary.BigEndian.PutUint16(heads[i], uint16(offset))
+		offset += len(tails[i])
+	}
+
+	encoded := make([]byte, 0, offset)
+	for _, head := range heads {
+		encoded = append(encoded, head...)
+	}
+	for _, tail := range tails {
+		encoded = append(encoded, tail...)
+	}
+	return encoded, nil
+}
+
+// decodeTuple decodes encoded as a tuple of childTypes
+func decodeTuple(encoded []byte, childTypes []Type) ([]interface{}, error) {
+	partitions := make([][]byte, len(childTypes))
+	var dynamicIndexes, dynamicOffsets []int
+
+	pos := 0
+	for i := 0; i < len(childTypes); i++ {
+		switch {
+		case childTypes[i].IsDynamic():
+			if len(encoded)-pos < lengthEncodeByteSize {
+				return nil, fmt.Errorf("ran out of bytes decoding tuple head")
+			}
+			dynamicIndexes = append(dynamicIndexes, i)
+			dynamicOffsets = append(dynamicOffsets, int(binary.BigEndian.Uint16(encoded[pos:])))
+			pos += lengthEncodeByteSize
+		case childTypes[i].kind == Bool:
+			if pos >= len(encoded) {
+				return nil, fmt.Errorf("ran out of bytes decoding tuple head")
+			}
+			run := boolRunLength(childTypes, i)
+			packed := encoded[pos]
+			if packed&(0xff>>uint(run)) != 0 {
+				return nil, fmt.Errorf("unused bits of packed bools are not zero")
+			}
+			for j := 0; j < run; j++ {
+				if packed&(0x80>>uint(j)) != 0 {
+					partitions[i+j] = []byte{0x80}
+				} else {
+					partitions[i+j] = []byte{0x00}
+				}
+			}
+			pos++
+			i += run - 1
+		default:
+			size, err := childTypes[i].ByteLen()
+			if err != nil {
+				return nil, err
+			}
+			if len(encoded)-pos < size {
+				return nil, fmt.Errorf("ran out of bytes decoding tuple head")
+			}
+			partitions[i] = encoded[pos : pos+size]
+			pos += size
+		}
+	}
+
+	if len(dynamicIndexes) == 0 && pos != len(encoded) {
+		return nil, fmt.Errorf("%d extra bytes after tuple", len(encoded)-pos)
+	}
+	for k, i := range dynamicIndexes {
+		start := dynamicOffsets[k]
+		end := len(encoded)
+		if k+1 < len(dynamicOffsets) {
+			end = dynamicOffsets[k+1]
+		}
+		if k == 0 && start != pos {
+			return nil, fmt.Errorf("first dynamic element offset %d does not follow the tuple head", start)
+		}
+		if start > end || end > len(encoded) {
+			return nil, fmt.Errorf("invalid dynamic element offset %d", start)
+		}
+		partitions[i] = encoded[start:end]
+	}
+
+	values := make([]interface{}, len(childTypes))
+	for i, child := range childTypes {
+		value, err := child.Decode(partitions[i])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// splitLength splits the uint16 length prefix of a dynamic value from its content
+func splitLength(encoded []byte) (int, []byte, error) {
+	if len(encoded) < lengthEncodeByteSize {
+		return 0, nil, fmt.Errorf("ran out of bytes decoding length prefix")
+	}
+	return int(binary.BigEndian.Uint16(encoded)), encoded[lengthEncodeByteSize:], nil
+}
+
+// toValueSlice converts a slice or array of any element type to []interface{}
+func toValueSlice(value interface{}) ([]interface{}, error) {
+	if values, ok := value.([]interface{}); ok {
+		return values, nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot encode %T as an array or tuple", value)
+	}
+	values := make([]interface{}, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values, nil
+}
+
+func repeatType(t Type, count int) []Type {
+	childTypes := make([]Type, count)
+	for i := range childTypes {
+		childTypes[i] = t
+	}
+	return childTypes
+}
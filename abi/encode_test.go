@@ -0,0 +1,126 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	maxUint512 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 512), big.NewInt(1))
+	var addr types.Address
+	for i := range addr {
+		addr[i] = byte(i)
+	}
+
+	cases := []struct {
+		typ     string
+		value   interface{}
+		decoded interface{}
+		hex     string
+	}{
+		{"uint64", uint64(1), uint64(1), "0000000000000001"},
+		{"uint8", 255, uint8(255), "ff"},
+		{"uint24", uint32(1 << 20), uint32(1 << 20), "100000"},
+		{"uint512", maxUint512, maxUint512, hexRepeat("ff", 64)},
+		{"ufixed32x2", uint32(12345), uint32(12345), "00003039"},
+		{"byte", byte(7), byte(7), "07"},
+		{"bool", true, true, "80"},
+		{"bool", false, false, "00"},
+		{"address", addr, addr, hex.EncodeToString(addr[:])},
+		{"string", "asdf", "asdf", "000461736466"},
+		{"bool[3]", []bool{true, true, false}, []interface{}{true, true, false}, "c0"},
+		{"uint16[]", []uint16{1, 2}, []interface{}{uint16(1), uint16(2)}, "000200010002"},
+		{"byte[]", []byte{1, 2, 3}, []interface{}{byte(1), byte(2), byte(3)}, "0003010203"},
+		{"string[2]", []string{"a", "bc"}, []interface{}{"a", "bc"}, "0004000700016100026263"},
+		{
+			"(string,bool,bool,bool,bool,string)",
+			[]interface{}{"AB", true, false, true, false, "DE"},
+			[]interface{}{"AB", true, false, true, false, "DE"},
+			"0005a000090002414200024445",
+		},
+		{
+			"(uint16,(byte,bool[]),bool)",
+			[]interface{}{uint16(3), []interface{}{byte(1), []bool{true}}, true},
+			[]interface{}{uint16(3), []interface{}{byte(1), []interface{}{true}}, true},
+			"0003000580" + "010003" + "000180",
+		},
+		{"()", []interface{}{}, []interface{}{}, ""},
+	}
+	for _, c := range cases {
+		typ, err := TypeOf(c.typ)
+		require.NoError(t, err)
+
+		encoded, err := typ.Encode(c.value)
+		require.NoError(t, err, c.typ)
+		require.Equal(t, c.hex, hex.EncodeToString(encoded), c.typ)
+
+		decoded, err := typ.Decode(encoded)
+		require.NoError(t, err, c.typ)
+		require.Equal(t, c.decoded, decoded, c.typ)
+	}
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	cases := []struct {
+		typ   string
+		value interface{}
+	}{
+		{"uint8", 256},
+		{"uint64", -1},
+		{"uint64", "1"},
+		{"uint64", new(big.Int).Lsh(big.NewInt(1), 64)},
+		{"bool", 1},
+		{"byte", 1},
+		{"address", []byte{1, 2}},
+		{"string", []byte("asdf")},
+		{"uint8[2]", []uint8{1}},
+		{"(uint8,bool)", []interface{}{uint8(1)}},
+		{"(uint8,bool)", []interface{}{uint8(1), 1}},
+		{"uint8[]", 1},
+	}
+	for _, c := range cases {
+		typ, err := TypeOf(c.typ)
+		require.NoError(t, err)
+		_, err = typ.Encode(c.value)
+		require.Error(t, err, "%s %v", c.typ, c.value)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	cases := []struct {
+		typ string
+		hex string
+	}{
+		{"uint64", "00"},
+		{"bool", "01"},
+		{"bool[2]", "e0"},
+		{"address", "00"},
+		{"string", "0004616263"},
+		{"string", "00"},
+		{"uint16[]", "00020001"},
+		{"(uint8,uint8)", "010203"},
+		{"(string,string)", "0005000600016100016200"},
+		{"(string,string)", "000400040001610001620000"},
+	}
+	for _, c := range cases {
+		typ, err := TypeOf(c.typ)
+		require.NoError(t, err)
+		encoded, err := hex.DecodeString(c.hex)
+		require.NoError(t, err)
+		_, err = typ.Decode(encoded)
+		require.Error(t, err, "%s %s", c.typ, c.hex)
+	}
+}
+
+func hexRepeat(s string, count int) string {
+	result := ""
+	for i := 0; i < count; i++ {
+		result += s
+	}
+	return result
+}
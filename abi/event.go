@@ -0,0 +1,180 @@
+package abi
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"fmt"
+	"strings"
+)
+
+// EventArg is an argument of an event, as described in an ARC-28 event
+// description
+type EventArg struct {
+	// Name is the name of the argument, optional
+	Name string `json:"name,omitempty"`
+
+	// Type is an ABI type
+	Type string `json:"type"`
+
+	// Desc is a description of the argument, optional
+	Desc string `json:"desc,omitempty"`
+}
+
+// Event is an ARC-28 event description. An application emits an event by
+// logging the event's selector followed by its arguments, encoded as an ABI
+// tuple.
+type Event struct {
+	Name string     `json:"name"`
+	Desc string     `json:"desc,omitempty"`
+	Args []EventArg `json:"args"`
+}
+
+// EventFromSignature parses an event signature such as
+// "Transfer(address,address,uint64)"
+func EventFromSignature(signature string) (Event, error) {
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return Event{}, fmt.Errorf("invalid event signature: %s", signature)
+	}
+	argTypes, err := parseTupleContent(signature[open+1 : len(signature)-1])
+	if err != nil {
+		return Event{}, err
+	}
+	event := Event{Name: signature[:open], Args: make([]EventArg, len(argTypes))}
+	for i, argType := range argTypes {
+		if _, err := TypeOf(argType); err != nil {
+			return Event{}, err
+		}
+		event.Args[i].Type = argType
+	}
+	return event, nil
+}
+
+// GetSignature returns the event's signature, e.g.
+// "Transfer(address,address,uint64)"
+func (e Event) GetSignature() string {
+	argTypes := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		argTypes[i] = arg.Type
+	}
+	return e.Name + "(" + strings.Join(argTypes, ",") + ")"
+}
+
+// GetSelector returns the event's 4 byte selector, the first 4 bytes of the
+// SHA-512/256 hash of its signature, which starts the logs emitting it
+func (e Event) GetSelector() []byte {
+	hash := sha512.Sum512_256([]byte(e.GetSignature()))
+	return hash[:4]
+}
+
+// argsType returns the tuple type the event's arguments are encoded as
+func (e Event) argsType() (Type, error) {
+	argTypes := make([]Type, len(e.Args))
+	for i, arg := range e.Args {
+		t, err := TypeOf(arg.Type)
+		if err != nil {
+			return Type{}, err
+		}
+		argTypes[i] = t
+	}
+	return MakeTupleType(argTypes)
+}
+
+// Encode returns the log emitting the event with the given argument values
+func (e Event) Encode(values ...interface{}) ([]byte, error) {
+	t, err := e.argsType()
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := t.Encode(values)
+	if err != nil {
+		return nil, err
+	}
+	return append(e.GetSelector(), encoded...), nil
+}
+
+// Decode decodes the argument values of the event from a log emitting it, as
+// Type.Decode does
+func (e Event) Decode(log []byte) ([]interface{}, error) {
+	if len(log) < 4 || !bytes.Equal(log[:4], e.GetSelector()) {
+		return nil, fmt.Errorf("log is not a %s event", e.GetSignature())
+	}
+	t, err := e.argsType()
+	if err != nil {
+		return nil, err
+	}
+	values, err := t.Decode(log[4:])
+	if err != nil {
+		return nil, fmt.Errorf("%s event: %v", e.GetSignature(), err)
+	}
+	return values.([]interface{}), nil
+}
+
+// DecodedEvent is an event decoded from a log
+type DecodedEvent struct {
+	// Event is the event emitted
+	Event Event
+
+	// Values are the values of the event's arguments, in order
+	Values []interface{}
+}
+
+// Arg returns the value of the argument with the given name
+func (d DecodedEvent) Arg(name string) (interface{}, bool) {
+	for i, arg := range d.Event.Args {
+		if arg.Name == name {
+			return d.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// DecodeEvents decodes the logs emitting any of the given events, skipping
+// other logs, such as method return values. An error is returned if a log
+// has the selector of an event but does not decode as it.
+func DecodeEvents(events []Event, logs [][]byte) ([]DecodedEvent, error) {
+	var decoded []DecodedEvent
+	for _, log := range logs {
+		if len(log) < 4 {
+			continue
+		}
+		for _, event := range events {
+			if !bytes.Equal(log[:4], event.GetSelector()) {
+				continue
+			}
+			values, err := event.Decode(log)
+			if err != nil {
+				return nil, err
+			}
+			decoded = append(decoded, DecodedEvent{Event: event, Values: values})
+			break
+		}
+	}
+	return decoded, nil
+}
+
+// AllEvents returns the events of the contract: those it declares, followed
+// by those declared by its methods and not already listed
+func (c Contract) AllEvents() []Event {
+	var events []Event
+	seen := make(map[string]bool)
+	add := func(list []Event) {
+		for _, event := range list {
+			if signature := event.GetSignature(); !seen[signature] {
+				seen[signature] = true
+				events = append(events, event)
+			}
+		}
+	}
+	add(c.Events)
+	for _, method := range c.Methods {
+		add(method.Events)
+	}
+	return events
+}
+
+// DecodeEvents decodes the logs emitting any of the contract's events, as
+// DecodeEvents does
+func (c Contract) DecodeEvents(logs [][]byte) ([]DecodedEvent, error) {
+	return DecodeEvents(c.AllEvents(), logs)
+}
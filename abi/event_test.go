@@ -0,0 +1,91 @@
+package abi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvent(t *testing.T) {
+	event, err := EventFromSignature("Swapped(uint64,uint64)")
+	require.NoError(t, err)
+	require.Equal(t, "Swapped", event.Name)
+	require.Equal(t, []EventArg{{Type: "uint64"}, {Type: "uint64"}}, event.Args)
+	require.Equal(t, "Swapped(uint64,uint64)", event.GetSignature())
+	require.Equal(t, "1ccbd925", hex.EncodeToString(event.GetSelector()))
+
+	log, err := event.Encode(uint64(1), uint64(2))
+	require.NoError(t, err)
+	require.Equal(t, "1ccbd925"+"0000000000000001"+"0000000000000002", hex.EncodeToString(log))
+	values, err := event.Decode(log)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint64(1), uint64(2)}, values)
+
+	_, err = event.Decode(log[:12])
+	require.Error(t, err)
+	_, err = event.Decode(append([]byte{0, 0, 0, 0}, log[4:]...))
+	require.Error(t, err)
+
+	event, err = EventFromSignature("Empty()")
+	require.NoError(t, err)
+	require.Empty(t, event.Args)
+	log, err = event.Encode()
+	require.NoError(t, err)
+	values, err = event.Decode(log)
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	invalid := []string{"", "Swapped", "(uint64)", "Swapped(uint64", "Swapped(uint65)", "Swapped(uint64)void"}
+	for _, signature := range invalid {
+		_, err := EventFromSignature(signature)
+		require.Error(t, err, signature)
+	}
+}
+
+func TestContractDecodeEvents(t *testing.T) {
+	var contract Contract
+	err := json.Unmarshal([]byte(`{
+		"name": "pool",
+		"methods": [{
+			"name": "swap",
+			"args": [{"type": "uint64"}],
+			"returns": {"type": "uint64"},
+			"events": [{"name": "Swapped", "args": [{"name": "in", "type": "uint64"}, {"name": "out", "type": "uint64"}]}]
+		}],
+		"events": [
+			{"name": "Deposited", "args": [{"name": "from", "type": "address"}, {"name": "memo", "type": "string"}]},
+			{"name": "Swapped", "args": [{"name": "in", "type": "uint64"}, {"name": "out", "type": "uint64"}]}
+		]
+	}`), &contract)
+	require.NoError(t, err)
+	require.Len(t, contract.AllEvents(), 2)
+
+	swapped := contract.Events[1]
+	deposited := contract.Events[0]
+	swappedLog, err := swapped.Encode(uint64(10), uint64(9))
+	require.NoError(t, err)
+	var from [32]byte
+	from[0] = 1
+	depositedLog, err := deposited.Encode(from, "hi")
+	require.NoError(t, err)
+	returnLog := append([]byte{0x15, 0x1f, 0x7c, 0x75}, swappedLog[4:]...)
+
+	decoded, err := contract.DecodeEvents([][]byte{depositedLog, {1}, returnLog, swappedLog})
+	require.NoError(t, err)
+	require.Len(t, decoded, 2)
+	require.Equal(t, "Deposited", decoded[0].Event.Name)
+	memo, ok := decoded[0].Arg("memo")
+	require.True(t, ok)
+	require.Equal(t, "hi", memo)
+	require.Equal(t, "Swapped", decoded[1].Event.Name)
+	out, ok := decoded[1].Arg("out")
+	require.True(t, ok)
+	require.Equal(t, uint64(9), out)
+	_, ok = decoded[1].Arg("fee")
+	require.False(t, ok)
+
+	_, err = contract.DecodeEvents([][]byte{swappedLog[:8]})
+	require.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package abi
+
+import (
+	"crypto/sha512"
+	"fmt"
+	"strings"
+)
+
+// Transaction argument types: a method argument of one of these types is a
+// transaction in the group immediately preceding the method call
+const (
+	// AnyTransactionType is a transaction of any type
+	AnyTransactionType = "txn"
+	// PaymentTransactionType is a payment transaction
+	PaymentTransactionType = "pay"
+	// KeyRegistrationTransactionType is a key registration transaction
+	KeyRegistrationTransactionType = "keyreg"
+	// AssetConfigTransactionType is an asset configuration transaction
+	AssetConfigTransactionType = "acfg"
+	// AssetTransferTransactionType is an asset transfer transaction
+	AssetTransferTransactionType = "axfer"
+	// AssetFreezeTransactionType is an asset freeze transaction
+	AssetFreezeTransactionType = "afrz"
+	// ApplicationCallTransactionType is an application call transaction
+	ApplicationCallTransactionType = "appl"
+)
+
+// Reference argument types: a method argument of one of these types is passed
+// as an index into the corresponding foreign array of the application call
+const (
+	// AccountReferenceType is an account, encoded as an index into the accounts array
+	AccountReferenceType = "account"
+	// AssetReferenceType is an asset, encoded as an index into the foreign assets array
+	AssetReferenceType = "asset"
+	// ApplicationReferenceType is an application, encoded as an index into the foreign apps array
+	ApplicationReferenceType = "application"
+)
+
+// VoidReturnType is the return type of methods that do not return a value
+const VoidReturnType = "void"
+
+// IsTransactionType reports whether typeStr is a transaction argument type
+func IsTransactionType(typeStr string) bool {
+	switch typeStr {
+	case AnyTransactionType, PaymentTransactionType, KeyRegistrationTransactionType, AssetConfigTransactionType,
+		AssetTransferTransactionType, AssetFreezeTransactionType, ApplicationCallTransactionType:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsReferenceType reports whether typeStr is a reference argument type
+func IsReferenceType(typeStr string) bool {
+	switch typeStr {
+	case AccountReferenceType, AssetReferenceType, ApplicationReferenceType:
+		return true
+	default:
+		return false
+	}
+}
+
+// Arg is a method argument, as described in an ARC-4 method description
+type Arg struct {
+	// Name is the name of the argument, optional
+	Name string `json:"name,omitempty"`
+
+	// Type is an ABI type, a transaction type or a reference type
+	Type string `json:"type"`
+
+	// Desc is a description of the argument, optional
+	Desc string `json:"desc,omitempty"`
+}
+
+// Return is a method's return value, as described in an ARC-4 method description
+type Return struct {
+	// Type is an ABI type or VoidReturnType
+	Type string `json:"type"`
+
+	// Desc is a description of the return value, optional
+	Desc string `json:"desc,omitempty"`
+}
+
+// Method is an ARC-4 smart contract method description
+type Method struct {
+	Name    string `json:"name"`
+	Desc    string `json:"desc,omitempty"`
+	Args    []Arg  `json:"args"`
+	Returns Return `json:"returns"`
+
+	// Events are the ARC-28 events the method may emit
+	Events []Event `json:"events,omitempty"`
+}
+
+// MethodFromSignature parses a method signature such as "add(uint64,uint64)uint128"
+func MethodFromSignature(signature string) (Method, error) {
+	open := strings.Index(signature, "(")
+	if open <= 0 {
+		return Method{}, fmt.Errorf("invalid method signature: %s", signature)
+	}
+
+	// find the parenthesis closing the argument list
+	depth := 0
+	closing := -1
+	for i := open; i < len(signature); i++ {
+		if signature[i] == '(' {
+			depth++
+		} else if signature[i] == ')' {
+			depth--
+			if depth == 0 {
+				closing = i
+				break
+			}
+		}
+	}
+	if closing < 0 {
+		return Method{}, fmt.Errorf("invalid method signature: %s", signature)
+	}
+
+	argTypes, err := parseTupleContent(signature[open+1 : closing])
+	if err != nil {
+		return Method{}, err
+	}
+	method := Method{
+		Name:    signature[:open],
+		Args:    make([]Arg, len(argTypes)),
+		Returns: Return{Type: signature[closing+1:]},
+	}
+	for i, argType := range argTypes {
+		if !IsTransactionType(argType) && !IsReferenceType(argType) {
+			if _, err := TypeOf(argType); err != nil {
+				return Method{}, err
+			}
+		}
+		method.Args[i].Type = argType
+	}
+	if method.Returns.Type != VoidReturnType {
+		if _, err := TypeOf(method.Returns.Type); err != nil {
+			return Method{}, err
+		}
+	}
+	return method, nil
+}
+
+// GetSignature returns the method's signature, e.g. "add(uint64,uint64)uint128"
+func (m Method) GetSignature() string {
+	argTypes := make([]string, len(m.Args))
+	for i, arg := range m.Args {
+		argTypes[i] = arg.Type
+	}
+	return m.Name + "(" + strings.Join(argTypes, ",") + ")" + m.Returns.Type
+}
+
+// GetSelector returns the method's 4 byte selector, the first 4 bytes of the
+// SHA-512/256 hash of its signature
+func (m Method) GetSelector() []byte {
+	hash := sha512.Sum512_256([]byte(m.GetSignature()))
+	return hash[:4]
+}
+
+// GetTxCount returns the number of transactions needed to call the method:
+// the application call itself and one for each transaction argument
+func (m Method) GetTxCount() int {
+	count := 1
+	for _, arg := range m.Args {
+		if IsTransactionType(arg.Type) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,41 @@
+package abi
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodFromSignature(t *testing.T) {
+	method, err := MethodFromSignature("add(uint64,uint64)uint128")
+	require.NoError(t, err)
+	require.Equal(t, "add", method.Name)
+	require.Equal(t, []Arg{{Type: "uint64"}, {Type: "uint64"}}, method.Args)
+	require.Equal(t, "uint128", method.Returns.Type)
+	require.Equal(t, "add(uint64,uint64)uint128", method.GetSignature())
+	require.Equal(t, "8aa3b61f", hex.EncodeToString(method.GetSelector()))
+	require.Equal(t, 1, method.GetTxCount())
+
+	method, err = MethodFromSignature("swap(pay,axfer,(uint64,address)[],account,asset,application)void")
+	require.NoError(t, err)
+	require.Len(t, method.Args, 6)
+	require.Equal(t, "(uint64,address)[]", method.Args[2].Type)
+	require.Equal(t, VoidReturnType, method.Returns.Type)
+	require.Equal(t, 3, method.GetTxCount())
+	require.Equal(t, "swap(pay,axfer,(uint64,address)[],account,asset,application)void", method.GetSignature())
+
+	method, err = MethodFromSignature("noargs()(bool,string)")
+	require.NoError(t, err)
+	require.Empty(t, method.Args)
+	require.Equal(t, "(bool,string)", method.Returns.Type)
+
+	invalid := []string{
+		"", "add", "(uint64)void", "add(uint64", "add(uint64)", "add(uint65)void",
+		"add(uint64,)void", "add(uint64)pay", "add(uint64)uint64)",
+	}
+	for _, signature := range invalid {
+		_, err := MethodFromSignature(signature)
+		require.Error(t, err, signature)
+	}
+}
@@ -0,0 +1,353 @@
+// Package abi implements the ARC-4 application binary interface: the types
+// used to encode smart contract method arguments and return values, and the
+// method signatures and selectors used to call them.
+package abi
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of an ABI type, e.g. Uint or Tuple
+type Kind int
+
+const (
+	// InvalidKind is the kind of the zero Type
+	InvalidKind Kind = iota
+	// Uint is an N bit unsigned integer, uint<N>
+	Uint
+	// Byte is an alias of uint8, byte
+	Byte
+	// Ufixed is an N bit unsigned fixed point decimal with M digits of precision, ufixed<N>x<M>
+	Ufixed
+	// Bool is a boolean, packed 8 to a byte in arrays and tuples
+	Bool
+	// ArrayStatic is a fixed length array, T[N]
+	ArrayStatic
+	// Address is a 32 byte Algorand address, encoded as byte[32]
+	Address
+	// ArrayDynamic is a variable length array, T[]
+	ArrayDynamic
+	// String is a UTF-8 string, encoded as byte[]
+	String
+	// Tuple is a sequence of values of possibly different types, (T1,T2,...,TN)
+	Tuple
+)
+
+const (
+	addressByteSize      = 32
+	lengthEncodeByteSize = 2
+	maxUintSize          = 512
+	maxPrecision         = 160
+	maxTupleLength       = 1<<16 - 1
+)
+
+var (
+	uintPattern   = regexp.MustCompile(`^uint([1-9][0-9]*)$`)
+	ufixedPattern = regexp.MustCompile(`^ufixed([1-9][0-9]*)x([1-9][0-9]*)$`)
+	lengthPattern = regexp.MustCompile(`^(0|[1-9][0-9]*)$`)
+)
+
+// Type is an ARC-4 ABI type. Use TypeOf to parse one from its string form, or
+// the Make functions to construct one.
+type Type struct {
+	kind       Kind
+	childTypes []Type
+
+	// bitSize is the size of Uint and Ufixed types
+	bitSize uint16
+	// precision is the number of decimal digits of Ufixed types
+	precision uint16
+	// staticLength is the number of elements of ArrayStatic types
+	staticLength uint16
+}
+
+// MakeUintType makes a uint<size> type. size must be a multiple of 8 between 8 and 512.
+func MakeUintType(size uint16) (Type, error) {
+	if size%8 != 0 || size < 8 || size > maxUintSize {
+		return Type{}, fmt.Errorf("unsupported uint type bitSize: %d", size)
+	}
+	return Type{kind: Uint, bitSize: size}, nil
+}
+
+// MakeByteType makes the byte type
+func MakeByteType() Type {
+	return Type{kind: Byte}
+}
+
+// MakeUfixedType makes a ufixed<size>x<precision> type. size must be a
+// multiple of 8 between 8 and 512, and precision between 1 and 160.
+func MakeUfixedType(size uint16, precision uint16) (Type, error) {
+	if size%8 != 0 || size < 8 || size > maxUintSize {
+		return Type{}, fmt.Errorf("unsupported ufixed type bitSize: %d", size)
+	}
+	if precision < 1 || precision > maxPrecision {
+		return Type{}, fmt.Errorf("unsupported ufixed type precision: %d", precision)
+	}
+	return Type{kind: Ufixed, bitSize: size, precision: precision}, nil
+}
+
+// MakeBoolType makes the bool type
+func MakeBoolType() Type {
+	return Type{kind: Bool}
+}
+
+// MakeStaticArrayType makes a static array type elem[length]
+func MakeStaticArrayType(elem Type, length uint16) Type {
+	return Type{kind: ArrayStatic, childTypes: []Type{elem}, staticLength: length}
+}
+
+// MakeAddressType makes the address type
+func MakeAddressType() Type {
+	return Type{kind: Address}
+}
+
+// MakeDynamicArrayType makes a dynamic array type elem[]
+func MakeDynamicArrayType(elem Type) Type {
+	return Type{kind: ArrayDynamic, childTypes: []Type{elem}}
+}
+
+// MakeStringType makes the string type
+func MakeStringType() Type {
+	return Type{kind: String}
+}
+
+// MakeTupleType makes a tuple type of the given element types
+func MakeTupleType(elems []Type) (Type, error) {
+	if len(elems) > maxTupleLength {
+		return Type{}, fmt.Errorf("tuple has too many elements: %d", len(elems))
+	}
+	childTypes := make([]Type, len(elems))
+	copy(childTypes, elems)
+	return Type{kind: Tuple, childTypes: childTypes}, nil
+}
+
+// TypeOf parses an ABI type from its string form, e.g. "uint64" or "(uint64,address[])"
+func TypeOf(str string) (Type, error) {
+	switch {
+	case strings.HasSuffix(str, "[]"):
+		elem, err := TypeOf(str[:len(str)-2])
+		if err != nil {
+			return Type{}, err
+		}
+		return MakeDynamicArrayType(elem), nil
+	case strings.HasSuffix(str, "]"):
+		open := strings.LastIndex(str, "[")
+		if open < 0 {
+			return Type{}, fmt.Errorf("ill formed static array type: %s", str)
+		}
+		lengthStr := str[open+1 : len(str)-1]
+		if !lengthPattern.MatchString(lengthStr) {
+			return Type{}, fmt.Errorf("ill formed static array length: %s", str)
+		}
+		length, err := strconv.ParseUint(lengthStr, 10, 16)
+		if err != nil {
+			return Type{}, fmt.Errorf("static array length out of range: %s", str)
+		}
+		elem, err := TypeOf(str[:open])
+		if err != nil {
+			return Type{}, err
+		}
+		return MakeStaticArrayType(elem, uint16(length)), nil
+	case strings.HasPrefix(str, "uint"):
+		matches := uintPattern.FindStringSubmatch(str)
+		if matches == nil {
+			return Type{}, fmt.Errorf("ill formed uint type: %s", str)
+		}
+		size, err := strconv.ParseUint(matches[1], 10, 16)
+		if err != nil {
+			return Type{}, fmt.Errorf("ill formed uint type: %s", str)
+		}
+		return MakeUintType(uint16(size))
+	case strings.HasPrefix(str, "ufixed"):
+		matches := ufixedPattern.FindStringSubmatch(str)
+		if matches == nil {
+			return Type{}, fmt.Errorf("ill formed ufixed type: %s", str)
+		}
+		size, err := strconv.ParseUint(matches[1], 10, 16)
+		if err != nil {
+			return Type{}, fmt.Errorf("ill formed ufixed type: %s", str)
+		}
+		precision, err := strconv.ParseUint(matches[2], 10, 16)
+		if err != nil {
+			return Type{}, fmt.Errorf("ill formed ufixed type: %s", str)
+		}
+		return MakeUfixedType(uint16(size), uint16(precision))
+	case str == "byte":
+		return MakeByteType(), nil
+	case str == "bool":
+		return MakeBoolType(), nil
+	case str == "address":
+		return MakeAddressType(), nil
+	case str == "string":
+		return MakeStringType(), nil
+	case len(str) >= 2 && str[0] == '(' && str[len(str)-1] == ')':
+		elemStrs, err := parseTupleContent(str[1 : len(str)-1])
+		if err != nil {
+			return Type{}, err
+		}
+		elems := make([]Type, len(elemStrs))
+		for i, elemStr := range elemStrs {
+			elems[i], err = TypeOf(elemStr)
+			if err != nil {
+				return Type{}, err
+			}
+		}
+		return MakeTupleType(elems)
+	default:
+		return Type{}, fmt.Errorf("cannot convert string %q to an ABI type", str)
+	}
+}
+
+// parseTupleContent splits the content of a tuple type string, without the
+// enclosing parentheses, into the strings of its element types
+func parseTupleContent(str string) ([]string, error) {
+	if str == "" {
+		return []string{}, nil
+	}
+	var elems []string
+	depth := 0
+	start := 0
+	for i, c := range str {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in tuple type: (%s)", str)
+			}
+		case ',':
+			if depth == 0 {
+				elems = append(elems, str[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in tuple type: (%s)", str)
+	}
+	elems = append(elems, str[start:])
+	for _, elem := range elems {
+		if elem == "" {
+			return nil, fmt.Errorf("empty element in tuple type: (%s)", str)
+		}
+	}
+	return elems, nil
+}
+
+// Kind returns the kind of the type
+func (t Type) Kind() Kind {
+	return t.kind
+}
+
+// String returns the canonical string form of the type, as used in method signatures
+func (t Type) String() string {
+	switch t.kind {
+	case Uint:
+		return "uint" + strconv.Itoa(int(t.bitSize))
+	case Byte:
+		return "byte"
+	case Ufixed:
+		return "ufixed" + strconv.Itoa(int(t.bitSize)) + "x" + strconv.Itoa(int(t.precision))
+	case Bool:
+		return "bool"
+	case ArrayStatic:
+		return t.childTypes[0].String() + "[" + strconv.Itoa(int(t.staticLength)) + "]"
+	case Address:
+		return "address"
+	case ArrayDynamic:
+		return t.childTypes[0].String() + "[]"
+	case String:
+		return "string"
+	case Tuple:
+		elems := make([]string, len(t.childTypes))
+		for i, child := range t.childTypes {
+			elems[i] = child.String()
+		}
+		return "(" + strings.Join(elems, ",") + ")"
+	default:
+		return "<invalid>"
+	}
+}
+
+// Equal reports whether t and other are the same type
+func (t Type) Equal(other Type) bool {
+	if t.kind != other.kind || t.bitSize != other.bitSize || t.precision != other.precision ||
+		t.staticLength != other.staticLength || len(t.childTypes) != len(other.childTypes) {
+		return false
+	}
+	for i := range t.childTypes {
+		if !t.childTypes[i].Equal(other.childTypes[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsDynamic reports whether the encoded length of values of the type varies
+func (t Type) IsDynamic() bool {
+	switch t.kind {
+	case ArrayDynamic, String:
+		return true
+	case ArrayStatic, Tuple:
+		for _, child := range t.childTypes {
+			if child.IsDynamic() {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// ByteLen returns the encoded length of values of a static type
+func (t Type) ByteLen() (int, error) {
+	switch t.kind {
+	case Uint, Ufixed:
+		return int(t.bitSize / 8), nil
+	case Byte, Bool:
+		return 1, nil
+	case Address:
+		return addressByteSize, nil
+	case ArrayStatic:
+		if t.childTypes[0].kind == Bool {
+			return (int(t.staticLength) + 7) / 8, nil
+		}
+		elemLen, err := t.childTypes[0].ByteLen()
+		if err != nil {
+			return 0, err
+		}
+		return int(t.staticLength) * elemLen, nil
+	case Tuple:
+		size := 0
+		for i := 0; i < len(t.childTypes); i++ {
+			if t.childTypes[i].kind == Bool {
+				// consecutive bools are packed into one byte
+				i += boolRunLength(t.childTypes, i) - 1
+				size++
+				continue
+			}
+			elemLen, err := t.childTypes[i].ByteLen()
+			if err != nil {
+				return 0, err
+			}
+			size += elemLen
+		}
+		return size, nil
+	default:
+		return 0, fmt.Errorf("%s is a dynamic type", t)
+	}
+}
+
+// boolRunLength returns how many bools, at most 8, start at index in types
+func boolRunLength(types []Type, index int) int {
+	run := 0
+	for index+run < len(types) && run < 8 && types[index+run].kind == Bool {
+		run++
+	}
+	return run
+}
@@ -0,0 +1,85 @@
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeOf(t *testing.T) {
+	uint64Type, err := MakeUintType(64)
+	require.NoError(t, err)
+	ufixedType, err := MakeUfixedType(128, 10)
+	require.NoError(t, err)
+	tupleType, err := MakeTupleType([]Type{uint64Type, MakeDynamicArrayType(MakeAddressType())})
+	require.NoError(t, err)
+	emptyTuple, err := MakeTupleType(nil)
+	require.NoError(t, err)
+	nestedTuple, err := MakeTupleType([]Type{tupleType, MakeBoolType(), emptyTuple})
+	require.NoError(t, err)
+
+	cases := []struct {
+		str      string
+		expected Type
+	}{
+		{"uint64", uint64Type},
+		{"ufixed128x10", ufixedType},
+		{"byte", MakeByteType()},
+		{"bool", MakeBoolType()},
+		{"address", MakeAddressType()},
+		{"string", MakeStringType()},
+		{"byte[32]", MakeStaticArrayType(MakeByteType(), 32)},
+		{"bool[0]", MakeStaticArrayType(MakeBoolType(), 0)},
+		{"string[]", MakeDynamicArrayType(MakeStringType())},
+		{"uint64[2][]", MakeDynamicArrayType(MakeStaticArrayType(uint64Type, 2))},
+		{"()", emptyTuple},
+		{"(uint64,address[])", tupleType},
+		{"(uint64,address[])[3]", MakeStaticArrayType(tupleType, 3)},
+		{"((uint64,address[]),bool,())", nestedTuple},
+	}
+	for _, c := range cases {
+		parsed, err := TypeOf(c.str)
+		require.NoError(t, err, c.str)
+		require.True(t, c.expected.Equal(parsed), c.str)
+		require.Equal(t, c.str, parsed.String())
+	}
+
+	invalid := []string{
+		"", "uint", "uint0", "uint7", "uint520", "uint064", "int64", "ufixed64", "ufixed64x0",
+		"ufixed64x161", "ufixed8x", "byte[", "byte]", "byte[01]", "byte[-1]", "byte[65536]",
+		"(uint64", "uint64)", "(uint64,)", "(,uint64)", "(uint64))(", "bytes", "Address",
+	}
+	for _, str := range invalid {
+		_, err := TypeOf(str)
+		require.Error(t, err, str)
+	}
+}
+
+func TestByteLen(t *testing.T) {
+	cases := map[string]int{
+		"uint8":                   1,
+		"uint256":                 32,
+		"ufixed64x2":              8,
+		"address":                 32,
+		"bool[9]":                 2,
+		"byte[4][2]":              8,
+		"(bool,bool,uint16,bool)": 4,
+		"(bool,bool,bool,bool,bool,bool,bool,bool,bool)": 2,
+	}
+	for str, expected := range cases {
+		typ, err := TypeOf(str)
+		require.NoError(t, err)
+		require.False(t, typ.IsDynamic(), str)
+		size, err := typ.ByteLen()
+		require.NoError(t, err)
+		require.Equal(t, expected, size, str)
+	}
+
+	for _, str := range []string{"string", "byte[]", "(uint64,string)", "string[2]"} {
+		typ, err := TypeOf(str)
+		require.NoError(t, err)
+		require.True(t, typ.IsDynamic(), str)
+		_, err = typ.ByteLen()
+		require.Error(t, err, str)
+	}
+}
@@ -0,0 +1,174 @@
+// Package appspec parses application specifications, the application.json
+// files of ARC-32 and the ARC-56 app specs produced by smart contract
+// compilers, into a Contract describing the application's methods, state
+// schema and programs.
+package appspec
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/abi"
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// Actions lists the on-completion actions with which a method, or a bare
+// call without a method, may be used
+type Actions struct {
+	// Create are the actions allowed when creating the application
+	Create []types.OnCompletion
+
+	// Call are the actions allowed when calling an existing application
+	Call []types.OnCompletion
+}
+
+// AllowsCreate reports whether an application may be created with onComplete
+func (a Actions) AllowsCreate(onComplete types.OnCompletion) bool {
+	return containsOnCompletion(a.Create, onComplete)
+}
+
+// AllowsCall reports whether an existing application may be called with onComplete
+func (a Actions) AllowsCall(onComplete types.OnCompletion) bool {
+	return containsOnCompletion(a.Call, onComplete)
+}
+
+// Method is an ABI method of an application with the actions it may be called with
+type Method struct {
+	abi.Method
+
+	Actions Actions
+
+	// ReadOnly is set for methods that do not change the application's state
+	ReadOnly bool
+}
+
+// Contract is an application described by an app spec
+type Contract struct {
+	Name string
+	Desc string
+
+	Methods []Method
+
+	// BareActions are the actions allowed for calls without a method
+	BareActions Actions
+
+	GlobalSchema types.StateSchema
+	LocalSchema  types.StateSchema
+
+	// ApprovalSource and ClearSource are the TEAL source of the programs
+	ApprovalSource string
+	ClearSource    string
+
+	// ApprovalProgram and ClearProgram are the compiled programs, if the
+	// app spec includes them
+	ApprovalProgram []byte
+	ClearProgram    []byte
+
+	// Networks maps the base64 genesis hash of a network to the ID of the
+	// application deployed there
+	Networks map[string]uint64
+}
+
+// Parse parses an ARC-32 or ARC-56 app spec, detecting which one it is
+func Parse(data []byte) (Contract, error) {
+	var fields map[string]interface{}
+	if err := json.LenientDecode(data, &fields); err != nil {
+		return Contract{}, fmt.Errorf("could not decode app spec: %v", err)
+	}
+	_, hasHints := fields["hints"]
+	_, hasContract := fields["contract"]
+	_, hasArcs := fields["arcs"]
+	_, hasBareActions := fields["bareActions"]
+	switch {
+	case hasArcs || hasBareActions:
+		return ParseARC56(data)
+	case hasHints || hasContract:
+		return ParseARC32(data)
+	default:
+		return Contract{}, fmt.Errorf("app spec is neither an ARC-32 nor an ARC-56 spec")
+	}
+}
+
+// GetMethod returns the method with the given name, or signature if several
+// methods share the name
+func (c Contract) GetMethod(name string) (Method, error) {
+	abiMethod, err := c.ABIContract().GetMethodByName(name)
+	if err != nil {
+		return Method{}, err
+	}
+	signature := abiMethod.GetSignature()
+	for _, method := range c.Methods {
+		if method.GetSignature() == signature {
+			return method, nil
+		}
+	}
+	return Method{}, fmt.Errorf("found no method with signature %s", signature)
+}
+
+// ABIContract returns the ARC-4 contract description of the application
+func (c Contract) ABIContract() abi.Contract {
+	contract := abi.Contract{
+		Name:    c.Name,
+		Desc:    c.Desc,
+		Methods: make([]abi.Method, len(c.Methods)),
+	}
+	for i, method := range c.Methods {
+		contract.Methods[i] = method.Method
+	}
+	if len(c.Networks) > 0 {
+		contract.Networks = make(map[string]abi.ContractNetworkInfo, len(c.Networks))
+		for genesisHash, appID := range c.Networks {
+			contract.Networks[genesisHash] = abi.ContractNetworkInfo{AppID: appID}
+		}
+	}
+	return contract
+}
+
+// AppID returns the ID of the application on the network with genesisHash, if
+// the app spec lists one
+func (c Contract) AppID(genesisHash []byte) (uint64, bool) {
+	appID, ok := c.Networks[base64.StdEncoding.EncodeToString(genesisHash)]
+	return appID, ok
+}
+
+// Programs returns the application's compiled approval and clear programs,
+// compiling the TEAL source with compile if the app spec does not include them
+func (c Contract) Programs(compile func(source string) ([]byte, error)) (approval, clear []byte, err error) {
+	if len(c.ApprovalProgram) > 0 && len(c.ClearProgram) > 0 {
+		return c.ApprovalProgram, c.ClearProgram, nil
+	}
+	if c.ApprovalSource == "" || c.ClearSource == "" {
+		return nil, nil, fmt.Errorf("app spec has no programs")
+	}
+	if compile == nil {
+		return nil, nil, fmt.Errorf("app spec has no compiled programs and no compiler was given")
+	}
+	approval, err = compile(c.ApprovalSource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not compile approval program: %v", err)
+	}
+	clear, err = compile(c.ClearSource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not compile clear program: %v", err)
+	}
+	return approval, clear, nil
+}
+
+func containsOnCompletion(list []types.OnCompletion, onComplete types.OnCompletion) bool {
+	for _, oc := range list {
+		if oc == onComplete {
+			return true
+		}
+	}
+	return false
+}
+
+func checkMethods(methods []Method) error {
+	for _, method := range methods {
+		if _, err := abi.MethodFromSignature(method.GetSignature()); err != nil {
+			return fmt.Errorf("invalid method %s: %v", method.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package appspec
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+var approvalSource = "#pragma version 8\nint 1\nreturn\n"
+var clearSource = "#pragma version 8\nint 1\n"
+
+var testARC32Spec = `{
+  "hints": {
+    "create(string)void": {"call_config": {"no_op": "CREATE"}},
+    "hello(string)string": {"read_only": true, "structs": {}, "call_config": {"no_op": "CALL", "opt_in": "ALL"}},
+    "hello(uint64)string": {"call_config": {"no_op": "CALL"}}
+  },
+  "source": {"approval": "` + base64.StdEncoding.EncodeToString([]byte(approvalSource)) + `", "clear": "` + base64.StdEncoding.EncodeToString([]byte(clearSource)) + `"},
+  "state": {"global": {"num_byte_slices": 1, "num_uints": 2}, "local": {"num_byte_slices": 0, "num_uints": 1}},
+  "schema": {"global": {"declared": {"greeting": {"type": "bytes", "key": "greeting"}}, "reserved": {}}, "local": {"declared": {}, "reserved": {}}},
+  "contract": {
+    "name": "HelloWorld",
+    "desc": "Says hello",
+    "methods": [
+      {"name": "create", "args": [{"type": "string", "name": "greeting"}], "returns": {"type": "void"}},
+      {"name": "hello", "args": [{"type": "string", "name": "name"}], "returns": {"type": "string"}, "desc": "Greets name"},
+      {"name": "hello", "args": [{"type": "uint64", "name": "id"}], "returns": {"type": "string"}},
+      {"name": "bye", "args": [], "returns": {"type": "void"}}
+    ],
+    "networks": {"SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI=": {"appID": 1234}}
+  },
+  "bare_call_config": {"delete_application": "CALL", "opt_in": "NEVER"}
+}`
+
+var testARC56Spec = `{
+  "arcs": [4, 56],
+  "name": "Calculator",
+  "structs": {},
+  "methods": [
+    {"name": "add", "args": [{"type": "uint64", "name": "a"}, {"type": "uint64", "name": "b"}], "returns": {"type": "uint128"},
+     "actions": {"create": [], "call": ["NoOp"]}, "readonly": true, "events": [], "recommendations": {}},
+    {"name": "createApplication", "args": [], "returns": {"type": "void"}, "actions": {"create": ["NoOp"], "call": []}}
+  ],
+  "state": {"schema": {"global": {"ints": 3, "bytes": 1}, "local": {"ints": 0, "bytes": 2}}, "keys": {}, "maps": {}},
+  "bareActions": {"create": [], "call": ["OptIn", "CloseOut"]},
+  "source": {"approval": "` + base64.StdEncoding.EncodeToString([]byte(approvalSource)) + `", "clear": "` + base64.StdEncoding.EncodeToString([]byte(clearSource)) + `"},
+  "byteCode": {"approval": "CIEBQw==", "clear": "CIEB"},
+  "networks": {}
+}`
+
+func TestParseARC32(t *testing.T) {
+	contract, err := Parse([]byte(testARC32Spec))
+	require.NoError(t, err)
+
+	require.Equal(t, "HelloWorld", contract.Name)
+	require.Equal(t, "Says hello", contract.Desc)
+	require.Equal(t, types.StateSchema{NumUint: 2, NumByteSlice: 1}, contract.GlobalSchema)
+	require.Equal(t, types.StateSchema{NumUint: 1}, contract.LocalSchema)
+	require.Equal(t, approvalSource, contract.ApprovalSource)
+	require.Equal(t, clearSource, contract.ClearSource)
+	require.Empty(t, contract.ApprovalProgram)
+	require.Equal(t, Actions{Call: []types.OnCompletion{types.DeleteApplicationOC}}, contract.BareActions)
+
+	require.Len(t, contract.Methods, 4)
+	create, err := contract.GetMethod("create")
+	require.NoError(t, err)
+	require.Equal(t, Actions{Create: []types.OnCompletion{types.NoOpOC}}, create.Actions)
+	require.Equal(t, "greeting", create.Args[0].Name)
+
+	_, err = contract.GetMethod("hello")
+	require.Error(t, err)
+	hello, err := contract.GetMethod("hello(string)string")
+	require.NoError(t, err)
+	require.True(t, hello.ReadOnly)
+	require.Equal(t, "Greets name", hello.Desc)
+	require.True(t, hello.Actions.AllowsCreate(types.OptInOC))
+	require.True(t, hello.Actions.AllowsCall(types.NoOpOC))
+	require.False(t, hello.Actions.AllowsCreate(types.NoOpOC))
+
+	// no hint, so the method may be called with NoOp
+	bye, err := contract.GetMethod("bye")
+	require.NoError(t, err)
+	require.Equal(t, Actions{Call: []types.OnCompletion{types.NoOpOC}}, bye.Actions)
+
+	genesisHash, err := base64.StdEncoding.DecodeString("SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI=")
+	require.NoError(t, err)
+	appID, ok := contract.AppID(genesisHash)
+	require.True(t, ok)
+	require.Equal(t, uint64(1234), appID)
+	require.Equal(t, uint64(1234), contract.ABIContract().Networks["SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI="].AppID)
+
+	_, _, err = contract.Programs(nil)
+	require.Error(t, err)
+	approval, clear, err := contract.Programs(func(source string) ([]byte, error) {
+		return []byte(fmt.Sprintf("compiled %d", len(source))), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("compiled %d", len(approvalSource)), string(approval))
+	require.Equal(t, fmt.Sprintf("compiled %d", len(clearSource)), string(clear))
+}
+
+func TestParseARC56(t *testing.T) {
+	contract, err := Parse([]byte(testARC56Spec))
+	require.NoError(t, err)
+
+	require.Equal(t, "Calculator", contract.Name)
+	require.Equal(t, types.StateSchema{NumUint: 3, NumByteSlice: 1}, contract.GlobalSchema)
+	require.Equal(t, types.StateSchema{NumByteSlice: 2}, contract.LocalSchema)
+	require.Equal(t, approvalSource, contract.ApprovalSource)
+	require.Equal(t, Actions{Call: []types.OnCompletion{types.OptInOC, types.CloseOutOC}}, contract.BareActions)
+
+	add, err := contract.GetMethod("add")
+	require.NoError(t, err)
+	require.Equal(t, "add(uint64,uint64)uint128", add.GetSignature())
+	require.True(t, add.ReadOnly)
+	require.Equal(t, Actions{Call: []types.OnCompletion{types.NoOpOC}}, add.Actions)
+
+	// compiled programs are used as is
+	approval, clear, err := contract.Programs(nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x08, 0x81, 0x01, 0x43}, approval)
+	require.Equal(t, []byte{0x08, 0x81, 0x01}, clear)
+
+	_, ok := contract.AppID(make([]byte, 32))
+	require.False(t, ok)
+}
+
+func TestParseInvalid(t *testing.T) {
+	invalid := []string{
+		`{}`,
+		`[]`,
+		`{"contract": {"name": "x", "methods": [{"name": "m", "args": [{"type": "uint7"}], "returns": {"type": "void"}}]}}`,
+		`{"contract": {"name": "x", "methods": []}, "bare_call_config": {"no_op": "SOMETIMES"}}`,
+		`{"contract": {"name": "x", "methods": []}, "bare_call_config": {"noop": "CALL"}}`,
+		`{"arcs": [56], "name": "x", "methods": [], "bareActions": {"create": ["Noop"], "call": []}}`,
+	}
+	for _, spec := range invalid {
+		_, err := Parse([]byte(spec))
+		require.Error(t, err, spec)
+	}
+}
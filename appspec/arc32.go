@@ -0,0 +1,132 @@
+package appspec
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/abi"
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// arc32OnCompletions are the on-completion names of ARC-32 call configs,
+// indexed by types.OnCompletion
+var arc32OnCompletions = []string{
+	"no_op",
+	"opt_in",
+	"close_out",
+	"clear_state",
+	"update_application",
+	"delete_application",
+}
+
+// arc32Spec is an ARC-32 application.json
+type arc32Spec struct {
+	Hints          map[string]arc32Hint `json:"hints"`
+	Source         arc32Source          `json:"source"`
+	State          arc32State           `json:"state"`
+	Contract       abi.Contract         `json:"contract"`
+	BareCallConfig map[string]string    `json:"bare_call_config"`
+}
+
+type arc32Hint struct {
+	CallConfig map[string]string `json:"call_config"`
+	ReadOnly   bool              `json:"read_only"`
+}
+
+// arc32Source holds the base64 encoded TEAL source of the programs
+type arc32Source struct {
+	Approval []byte `json:"approval"`
+	Clear    []byte `json:"clear"`
+}
+
+type arc32State struct {
+	Global arc32Schema `json:"global"`
+	Local  arc32Schema `json:"local"`
+}
+
+type arc32Schema struct {
+	NumUints      uint64 `json:"num_uints"`
+	NumByteSlices uint64 `json:"num_byte_slices"`
+}
+
+// ParseARC32 parses an ARC-32 application.json
+func ParseARC32(data []byte) (Contract, error) {
+	var spec arc32Spec
+	if err := json.LenientDecode(data, &spec); err != nil {
+		return Contract{}, fmt.Errorf("could not decode ARC-32 app spec: %v", err)
+	}
+
+	contract := Contract{
+		Name:           spec.Contract.Name,
+		Desc:           spec.Contract.Desc,
+		Methods:        make([]Method, len(spec.Contract.Methods)),
+		GlobalSchema:   types.StateSchema{NumUint: spec.State.Global.NumUints, NumByteSlice: spec.State.Global.NumByteSlices},
+		LocalSchema:    types.StateSchema{NumUint: spec.State.Local.NumUints, NumByteSlice: spec.State.Local.NumByteSlices},
+		ApprovalSource: string(spec.Source.Approval),
+		ClearSource:    string(spec.Source.Clear),
+	}
+
+	var err error
+	contract.BareActions, err = arc32Actions(spec.BareCallConfig)
+	if err != nil {
+		return Contract{}, err
+	}
+	for i, abiMethod := range spec.Contract.Methods {
+		method := Method{Method: abiMethod}
+		hint, ok := spec.Hints[abiMethod.GetSignature()]
+		if ok && len(hint.CallConfig) > 0 {
+			method.Actions, err = arc32Actions(hint.CallConfig)
+			if err != nil {
+				return Contract{}, err
+			}
+		} else {
+			// methods without a call config may be called with NoOp
+			method.Actions.Call = []types.OnCompletion{types.NoOpOC}
+		}
+		method.ReadOnly = hint.ReadOnly
+		contract.Methods[i] = method
+	}
+	if err := checkMethods(contract.Methods); err != nil {
+		return Contract{}, err
+	}
+
+	if len(spec.Contract.Networks) > 0 {
+		contract.Networks = make(map[string]uint64, len(spec.Contract.Networks))
+		for genesisHash, network := range spec.Contract.Networks {
+			contract.Networks[genesisHash] = network.AppID
+		}
+	}
+	return contract, nil
+}
+
+// arc32Actions converts an ARC-32 call config, which maps on-completion names
+// to one of NEVER, CALL, CREATE or ALL, to Actions
+func arc32Actions(callConfig map[string]string) (Actions, error) {
+	for name := range callConfig {
+		known := false
+		for _, ocName := range arc32OnCompletions {
+			known = known || name == ocName
+		}
+		if !known {
+			return Actions{}, fmt.Errorf("unknown on-completion %s in call config", name)
+		}
+	}
+
+	var actions Actions
+	for i, name := range arc32OnCompletions {
+		oc := types.OnCompletion(i)
+		switch callConfig[name] {
+		case "", "NEVER":
+		case "CALL":
+			actions.Call = append(actions.Call, oc)
+		case "CREATE":
+			actions.Create = append(actions.Create, oc)
+		case "ALL":
+			actions.Create = append(actions.Create, oc)
+			actions.Call = append(actions.Call, oc)
+		default:
+			return Actions{}, fmt.Errorf("invalid call config %s for %s", callConfig[name], name)
+		}
+	}
+	return actions, nil
+}
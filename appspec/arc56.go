@@ -0,0 +1,136 @@
+package appspec
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/abi"
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// arc56OnCompletions maps the on-completion names of ARC-56 actions
+var arc56OnCompletions = map[string]types.OnCompletion{
+	"NoOp":              types.NoOpOC,
+	"OptIn":             types.OptInOC,
+	"CloseOut":          types.CloseOutOC,
+	"ClearState":        types.ClearStateOC,
+	"UpdateApplication": types.UpdateApplicationOC,
+	"DeleteApplication": types.DeleteApplicationOC,
+}
+
+// arc56Spec is an ARC-56 app spec
+type arc56Spec struct {
+	Arcs        []uint64                           `json:"arcs"`
+	Name        string                             `json:"name"`
+	Desc        string                             `json:"desc"`
+	Networks    map[string]abi.ContractNetworkInfo `json:"networks"`
+	Methods     []arc56Method                      `json:"methods"`
+	State       arc56State                         `json:"state"`
+	BareActions arc56Actions                       `json:"bareActions"`
+	Source      arc56Programs                      `json:"source"`
+	ByteCode    arc56Programs                      `json:"byteCode"`
+}
+
+type arc56Method struct {
+	Name     string       `json:"name"`
+	Desc     string       `json:"desc"`
+	Args     []abi.Arg    `json:"args"`
+	Returns  abi.Return   `json:"returns"`
+	Actions  arc56Actions `json:"actions"`
+	Readonly bool         `json:"readonly"`
+}
+
+type arc56Actions struct {
+	Create []string `json:"create"`
+	Call   []string `json:"call"`
+}
+
+type arc56State struct {
+	Schema struct {
+		Global arc56Schema `json:"global"`
+		Local  arc56Schema `json:"local"`
+	} `json:"schema"`
+}
+
+type arc56Schema struct {
+	Ints  uint64 `json:"ints"`
+	Bytes uint64 `json:"bytes"`
+}
+
+// arc56Programs holds base64 encoded programs, TEAL source or compiled
+type arc56Programs struct {
+	Approval []byte `json:"approval"`
+	Clear    []byte `json:"clear"`
+}
+
+// ParseARC56 parses an ARC-56 app spec
+func ParseARC56(data []byte) (Contract, error) {
+	var spec arc56Spec
+	if err := json.LenientDecode(data, &spec); err != nil {
+		return Contract{}, fmt.Errorf("could not decode ARC-56 app spec: %v", err)
+	}
+
+	contract := Contract{
+		Name:            spec.Name,
+		Desc:            spec.Desc,
+		Methods:         make([]Method, len(spec.Methods)),
+		GlobalSchema:    types.StateSchema{NumUint: spec.State.Schema.Global.Ints, NumByteSlice: spec.State.Schema.Global.Bytes},
+		LocalSchema:     types.StateSchema{NumUint: spec.State.Schema.Local.Ints, NumByteSlice: spec.State.Schema.Local.Bytes},
+		ApprovalSource:  string(spec.Source.Approval),
+		ClearSource:     string(spec.Source.Clear),
+		ApprovalProgram: spec.ByteCode.Approval,
+		ClearProgram:    spec.ByteCode.Clear,
+	}
+
+	var err error
+	contract.BareActions, err = spec.BareActions.toActions()
+	if err != nil {
+		return Contract{}, err
+	}
+	for i, m := range spec.Methods {
+		method := Method{
+			Method: abi.Method{
+				Name:    m.Name,
+				Desc:    m.Desc,
+				Args:    m.Args,
+				Returns: m.Returns,
+			},
+			ReadOnly: m.Readonly,
+		}
+		method.Actions, err = m.Actions.toActions()
+		if err != nil {
+			return Contract{}, err
+		}
+		contract.Methods[i] = method
+	}
+	if err := checkMethods(contract.Methods); err != nil {
+		return Contract{}, err
+	}
+
+	if len(spec.Networks) > 0 {
+		contract.Networks = make(map[string]uint64, len(spec.Networks))
+		for genesisHash, network := range spec.Networks {
+			contract.Networks[genesisHash] = network.AppID
+		}
+	}
+	return contract, nil
+}
+
+func (a arc56Actions) toActions() (Actions, error) {
+	var actions Actions
+	for _, name := range a.Create {
+		oc, ok := arc56OnCompletions[name]
+		if !ok {
+			return Actions{}, fmt.Errorf("unknown on-completion %s in create actions", name)
+		}
+		actions.Create = append(actions.Create, oc)
+	}
+	for _, name := range a.Call {
+		oc, ok := arc56OnCompletions[name]
+		if !ok {
+			return Actions{}, fmt.Errorf("unknown on-completion %s in call actions", name)
+		}
+		actions.Call = append(actions.Call, oc)
+	}
+	return actions, nil
+}
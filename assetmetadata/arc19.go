@@ -0,0 +1,299 @@
+// Package assetmetadata implements the asset metadata conventions of the
+// Algorand ARCs: ARC-3 metadata files and the metadata hashes committing to
+// them, ARC-19 template URLs, which point an asset at an IPFS CID stored in its
+// reserve address so that the metadata can be updated, ARC-69 metadata, stored
+// in the notes of asset configuration transactions, and the resolution of
+// asset URLs to fetchable ones.
+package assetmetadata
+
+import (
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// TemplateScheme is the scheme of ARC-19 template URLs
+const TemplateScheme = "template-ipfs://"
+
+// IPFSScheme is the scheme of IPFS URLs
+const IPFSScheme = "ipfs://"
+
+// DefaultIPFSGateway is the gateway ResolveAssetURL uses if none is given
+const DefaultIPFSGateway = "https://ipfs.io/ipfs/"
+
+// sha256Multihash is the multihash code of sha2-256
+const sha256Multihash = 0x12
+
+// codecs are the multicodec codes of the content types a CID may have
+var codecs = map[string]uint64{
+	"raw":      0x55,
+	"dag-pb":   0x70,
+	"dag-cbor": 0x71,
+	"json":     0x0200,
+}
+
+// cidBase32 is the encoding of CIDv1 after the multibase prefix "b"
+var cidBase32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// templatePattern matches the template of an ARC-19 URL
+var templatePattern = regexp.MustCompile(`^\{ipfscid:([0-9]+):([a-z0-9-]+):([a-z]+):([a-z0-9-]+)\}`)
+
+// CID is an IPFS content identifier whose content is hashed with sha2-256,
+// the only hash ARC-19 supports
+type CID struct {
+	// Version is 0 or 1; version 0 CIDs have the dag-pb codec
+	Version int
+
+	// Codec is the multicodec name of the content type, e.g. "raw" or
+	// "dag-pb"
+	Codec string
+
+	// Digest is the sha2-256 digest of the content
+	Digest [32]byte
+}
+
+// ParseCID parses a version 0 CID or a base32 version 1 CID
+func ParseCID(cid string) (CID, error) {
+	if strings.HasPrefix(cid, "Qm") {
+		multihash, err := base58Decode(cid)
+		if err != nil {
+			return CID{}, fmt.Errorf("invalid CID %s: %v", cid, err)
+		}
+		var c CID
+		if len(multihash) != 34 || multihash[0] != sha256Multihash || multihash[1] != 32 {
+			return CID{}, fmt.Errorf("invalid CID %s: not a sha2-256 multihash", cid)
+		}
+		c.Codec = "dag-pb"
+		copy(c.Digest[:], multihash[2:])
+		return c, nil
+	}
+
+	if !strings.HasPrefix(cid, "b") {
+		return CID{}, fmt.Errorf("invalid CID %s: only base32 version 1 CIDs are supported", cid)
+	}
+	decoded, err := cidBase32.DecodeString(strings.ToUpper(cid[1:]))
+	if err != nil {
+		return CID{}, fmt.Errorf("invalid CID %s: %v", cid, err)
+	}
+	version, n := binary.Uvarint(decoded)
+	if n <= 0 || version != 1 {
+		return CID{}, fmt.Errorf("invalid CID %s: unsupported version", cid)
+	}
+	decoded = decoded[n:]
+	code, n := binary.Uvarint(decoded)
+	if n <= 0 {
+		return CID{}, fmt.Errorf("invalid CID %s: missing codec", cid)
+	}
+	decoded = decoded[n:]
+	c := CID{Version: 1}
+	for name, known := range codecs {
+		if known == code {
+			c.Codec = name
+		}
+	}
+	if c.Codec == "" {
+		return CID{}, fmt.Errorf("invalid CID %s: unsupported codec 0x%x", cid, code)
+	}
+	if len(decoded) != 34 || decoded[0] != sha256Multihash || decoded[1] != 32 {
+		return CID{}, fmt.Errorf("invalid CID %s: not a sha2-256 multihash", cid)
+	}
+	copy(c.Digest[:], decoded[2:])
+	return c, nil
+}
+
+// CIDFromReserve returns the CID stored in an ARC-19 asset's reserve address,
+// which is the CID's digest
+func CIDFromReserve(reserve types.Address, version int, codec string) CID {
+	return CID{Version: version, Codec: codec, Digest: reserve}
+}
+
+// Reserve returns the reserve address of an ARC-19 asset whose metadata is at
+// the CID
+func (c CID) Reserve() types.Address {
+	return types.Address(c.Digest)
+}
+
+// Encode returns the text form of the CID: base58 for version 0 and base32 for
+// version 1
+func (c CID) Encode() (string, error) {
+	multihash := append([]byte{sha256Multihash, 32}, c.Digest[:]...)
+	switch c.Version {
+	case 0:
+		if c.Codec != "dag-pb" {
+			return "", fmt.Errorf("version 0 CIDs have the dag-pb codec, not %s", c.Codec)
+		}
+		return base58Encode(multihash), nil
+	case 1:
+		code, ok := codecs[c.Codec]
+		if !ok {
+			return "", fmt.Errorf("unsupported codec: %s", c.Codec)
+		}
+		buf := make([]byte, 2*binary.MaxVarintLen64)
+		n := binary.PutUvarint(buf, 1)
+		n += binary.PutUvarint(buf[n:], code)
+		return "b" + strings.ToLower(cidBase32.EncodeToString(append(buf[:n], multihash...))), nil
+	default:
+		return "", fmt.Errorf("unsupported CID version: %d", c.Version)
+	}
+}
+
+// TemplateURL is an ARC-19 template URL,
+// "template-ipfs://{ipfscid:<version>:<codec>:reserve:sha2-256}<suffix>",
+// which resolves to the IPFS URL of the CID stored in the asset's reserve
+// address, so that the asset's metadata can be changed by changing its
+// reserve address
+type TemplateURL struct {
+	// Version is the version of the CID
+	Version int
+
+	// Codec is the multicodec name of the content type of the CID
+	Codec string
+
+	// Suffix follows the CID in the resolved URL, e.g. a path within a
+	// directory or "#arc3"
+	Suffix string
+}
+
+// TemplateURLFromCID returns the template URL resolving to cid, followed by
+// suffix, for the asset whose reserve address is cid.Reserve()
+func TemplateURLFromCID(cid CID, suffix string) TemplateURL {
+	return TemplateURL{Version: cid.Version, Codec: cid.Codec, Suffix: suffix}
+}
+
+// ParseTemplateURL parses an ARC-19 template URL
+func ParseTemplateURL(url string) (TemplateURL, error) {
+	if !strings.HasPrefix(url, TemplateScheme) {
+		return TemplateURL{}, fmt.Errorf("not an ARC-19 template URL: %s", url)
+	}
+	rest := url[len(TemplateScheme):]
+	match := templatePattern.FindStringSubmatch(rest)
+	if match == nil {
+		return TemplateURL{}, fmt.Errorf("invalid ARC-19 template: %s", url)
+	}
+	version, err := strconv.Atoi(match[1])
+	if err != nil || version > 1 {
+		return TemplateURL{}, fmt.Errorf("unsupported CID version in ARC-19 template: %s", url)
+	}
+	if _, ok := codecs[match[2]]; !ok {
+		return TemplateURL{}, fmt.Errorf("unsupported codec in ARC-19 template: %s", url)
+	}
+	if version == 0 && match[2] != "dag-pb" {
+		return TemplateURL{}, fmt.Errorf("version 0 CIDs have the dag-pb codec: %s", url)
+	}
+	if match[3] != "reserve" {
+		return TemplateURL{}, fmt.Errorf("unsupported field in ARC-19 template: %s", url)
+	}
+	if match[4] != "sha2-256" {
+		return TemplateURL{}, fmt.Errorf("unsupported hash type in ARC-19 template: %s", url)
+	}
+	return TemplateURL{Version: version, Codec: match[2], Suffix: rest[len(match[0]):]}, nil
+}
+
+// String returns the template URL
+func (t TemplateURL) String() string {
+	return fmt.Sprintf("%s{ipfscid:%d:%s:reserve:sha2-256}%s", TemplateScheme, t.Version, t.Codec, t.Suffix)
+}
+
+// Resolve returns the IPFS URL the template URL resolves to for an asset with
+// the given reserve address
+func (t TemplateURL) Resolve(reserve types.Address) (string, error) {
+	cid, err := CIDFromReserve(reserve, t.Version, t.Codec).Encode()
+	if err != nil {
+		return "", err
+	}
+	return IPFSScheme + cid + t.Suffix, nil
+}
+
+// ResolveAssetURL returns a URL the metadata or media of an asset can be
+// fetched from, given the asset's URL and reserve address: ARC-19 template
+// URLs are resolved, IPFS URLs are rewritten to go through the given IPFS
+// gateway, or DefaultIPFSGateway if it is empty, and the "#arc3" fragment
+// marking ARC-3 URLs is dropped. Other URLs are returned unchanged.
+func ResolveAssetURL(url string, reserve types.Address, gateway string) (string, error) {
+	if strings.HasPrefix(url, TemplateScheme) {
+		template, err := ParseTemplateURL(url)
+		if err != nil {
+			return "", err
+		}
+		if url, err = template.Resolve(reserve); err != nil {
+			return "", err
+		}
+	}
+	url = strings.TrimSuffix(url, "#arc3")
+	if strings.HasPrefix(url, IPFSScheme) {
+		if gateway == "" {
+			gateway = DefaultIPFSGateway
+		}
+		if !strings.HasSuffix(gateway, "/") {
+			gateway += "/"
+		}
+		url = gateway + url[len(IPFSScheme):]
+	}
+	return url, nil
+}
+
+// base58Alphabet is the alphabet of base58btc, the encoding of version 0 CIDs
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Encode(b []byte) string {
+	// digits are the base 58 digits of b, least significant first
+	var digits []byte
+	for _, x := range b {
+		carry := int(x)
+		for i := range digits {
+			carry += int(digits[i]) << 8
+			digits[i] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+	}
+	var encoded []byte
+	for _, x := range b {
+		if x != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i := len(digits) - 1; i >= 0; i-- {
+		encoded = append(encoded, base58Alphabet[digits[i]])
+	}
+	return string(encoded)
+}
+
+func base58Decode(s string) ([]byte, error) {
+	// decoded is the bytes of s, least significant first
+	var decoded []byte
+	for _, c := range []byte(s) {
+		carry := strings.IndexByte(base58Alphabet, c)
+		if carry < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", c)
+		}
+		for i := range decoded {
+			carry += int(decoded[i]) * 58
+			decoded[i] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			decoded = append(decoded, byte(carry))
+			carry >>= 8
+		}
+	}
+	for _, c := range []byte(s) {
+		if c != base58Alphabet[0] {
+			break
+		}
+		decoded = append(decoded, 0)
+	}
+	for i, j := 0, len(decoded)-1; i < j; i, j = i+1, j-1 {
+		decoded[i], decoded[j] = decoded[j], decoded[i]
+	}
+	return decoded, nil
+}
@@ -0,0 +1,93 @@
+package assetmetadata
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestCID(t *testing.T) {
+	digest := sha256.Sum256([]byte("hello arc19"))
+	reserve, err := types.DecodeAddress("JP6UYDGNOUA3A6LKNJOSBI62G23OP6IVOZIYJHKZRJGVMCNXZVA4YCHYJQ")
+	require.NoError(t, err)
+
+	cases := []struct {
+		cid     CID
+		encoded string
+	}{
+		{CID{Version: 0, Codec: "dag-pb", Digest: digest}, "QmTTHJbBta6xPHYABf9qRL4CKsyrBntSgiPdufKWLXf5Ha"},
+		{CID{Version: 1, Codec: "raw", Digest: digest}, "bafkreicl7vgaztlvagyhs2tkluqkhwrww3t7sflwkgcj2wmkjvlatn6nie"},
+		{CID{Version: 1, Codec: "json", Digest: digest}, "bagaaierajp6uydgnoua3a6lknjosbi62g23op6ivoziyjhkzrjgvmcnxzvaq"},
+	}
+	for _, c := range cases {
+		encoded, err := c.cid.Encode()
+		require.NoError(t, err)
+		require.Equal(t, c.encoded, encoded)
+		parsed, err := ParseCID(c.encoded)
+		require.NoError(t, err)
+		require.Equal(t, c.cid, parsed)
+		require.Equal(t, reserve, parsed.Reserve())
+		require.Equal(t, c.cid, CIDFromReserve(reserve, c.cid.Version, c.cid.Codec))
+	}
+
+	_, err = CID{Version: 0, Codec: "raw"}.Encode()
+	require.Error(t, err)
+	_, err = CID{Version: 1, Codec: "unknown"}.Encode()
+	require.Error(t, err)
+	_, err = CID{Version: 2, Codec: "raw"}.Encode()
+	require.Error(t, err)
+
+	invalid := []string{
+		"", "Qm0", "QmTTHJbBta6xPHYABf9qRL4CKsyrBntSgiPdufKWLXf5H", "zb2rhe5P4gXftAwvA4eXQ5HJwsER2owDyS9sKaQRRVQPn93bA",
+		"bafkreicl7vgaztlvagyhs2tkluqkhwrww3t7sflwkgcj2wmkjvlatn6ni", "bafkqaaa",
+	}
+	for _, cid := range invalid {
+		_, err := ParseCID(cid)
+		require.Error(t, err, cid)
+	}
+}
+
+func TestTemplateURL(t *testing.T) {
+	digest := sha256.Sum256([]byte("hello arc19"))
+	cid := CID{Version: 1, Codec: "raw", Digest: digest}
+	template := TemplateURLFromCID(cid, "#arc3")
+	url := template.String()
+	require.Equal(t, "template-ipfs://{ipfscid:1:raw:reserve:sha2-256}#arc3", url)
+	require.True(t, len(url) <= types.AssetURLMaxLen)
+
+	parsed, err := ParseTemplateURL(url)
+	require.NoError(t, err)
+	require.Equal(t, template, parsed)
+	resolved, err := parsed.Resolve(cid.Reserve())
+	require.NoError(t, err)
+	require.Equal(t, "ipfs://bafkreicl7vgaztlvagyhs2tkluqkhwrww3t7sflwkgcj2wmkjvlatn6nie#arc3", resolved)
+
+	resolved, err = ResolveAssetURL(url, cid.Reserve(), "")
+	require.NoError(t, err)
+	require.Equal(t, "https://ipfs.io/ipfs/bafkreicl7vgaztlvagyhs2tkluqkhwrww3t7sflwkgcj2wmkjvlatn6nie", resolved)
+	resolved, err = ResolveAssetURL("template-ipfs://{ipfscid:0:dag-pb:reserve:sha2-256}/metadata.json", cid.Reserve(), "https://gateway.example")
+	require.NoError(t, err)
+	require.Equal(t, "https://gateway.example/QmTTHJbBta6xPHYABf9qRL4CKsyrBntSgiPdufKWLXf5Ha/metadata.json", resolved)
+	resolved, err = ResolveAssetURL("https://example.com/asset.json", cid.Reserve(), "")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/asset.json", resolved)
+
+	invalid := []string{
+		"ipfs://{ipfscid:1:raw:reserve:sha2-256}",
+		"template-ipfs://ipfscid:1:raw:reserve:sha2-256",
+		"template-ipfs://{ipfscid:2:raw:reserve:sha2-256}",
+		"template-ipfs://{ipfscid:0:raw:reserve:sha2-256}",
+		"template-ipfs://{ipfscid:1:unknown:reserve:sha2-256}",
+		"template-ipfs://{ipfscid:1:raw:manager:sha2-256}",
+		"template-ipfs://{ipfscid:1:raw:reserve:sha3-256}",
+	}
+	for _, url := range invalid {
+		_, err := ParseTemplateURL(url)
+		require.Error(t, err, url)
+	}
+	_, err = ResolveAssetURL(invalid[2], cid.Reserve(), "")
+	require.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package assetmetadata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// ARC-3 domain separation prefixes of the metadata hash of assets with extra
+// metadata
+var (
+	arc3JSONPrefix = []byte("arc0003/amj")
+	arc3HashPrefix = []byte("arc0003/am")
+)
+
+// ARC3Metadata is the JSON metadata file of an ARC-3 asset, whose URL points
+// at it and whose metadata hash commits to it
+type ARC3Metadata struct {
+	Name string `json:"name,omitempty"`
+
+	// Decimals must be the asset's decimals if set
+	Decimals *uint32 `json:"decimals,omitempty"`
+
+	Description string `json:"description,omitempty"`
+
+	Image          string `json:"image,omitempty"`
+	ImageIntegrity string `json:"image_integrity,omitempty"`
+	ImageMimetype  string `json:"image_mimetype,omitempty"`
+
+	BackgroundColor string `json:"background_color,omitempty"`
+
+	ExternalURL          string `json:"external_url,omitempty"`
+	ExternalURLIntegrity string `json:"external_url_integrity,omitempty"`
+	ExternalURLMimetype  string `json:"external_url_mimetype,omitempty"`
+
+	AnimationURL          string `json:"animation_url,omitempty"`
+	AnimationURLIntegrity string `json:"animation_url_integrity,omitempty"`
+	AnimationURLMimetype  string `json:"animation_url_mimetype,omitempty"`
+
+	// Properties are arbitrary properties of the asset
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	// ExtraMetadata is base64 encoded metadata the metadata hash commits to
+	// along with the file, e.g. to bind the metadata to data only the asset's
+	// creator knows
+	ExtraMetadata string `json:"extra_metadata,omitempty"`
+}
+
+// DecodeARC3 decodes the JSON metadata file of an ARC-3 asset
+func DecodeARC3(metadataJSON []byte) (ARC3Metadata, error) {
+	var metadata ARC3Metadata
+	if err := json.DecodeStd(metadataJSON, &metadata); err != nil {
+		return ARC3Metadata{}, fmt.Errorf("invalid ARC-3 metadata: %v", err)
+	}
+	return metadata, nil
+}
+
+// IsARC3 returns whether an asset with the given name and URL follows ARC-3:
+// its name is "arc3" or ends with "@arc3", or its URL ends with "#arc3"
+func IsARC3(assetName, url string) bool {
+	return assetName == "arc3" || strings.HasSuffix(assetName, "@arc3") || strings.HasSuffix(url, "#arc3")
+}
+
+// ARC3MetadataHash returns the metadata hash of an ARC-3 asset given its JSON
+// metadata file: the SHA-256 digest of the file, or, if the file has extra
+// metadata e, SHA-512/256("arc0003/am" || SHA-512/256("arc0003/amj" || file) || e)
+func ARC3MetadataHash(metadataJSON []byte) ([types.AssetMetadataHashLen]byte, error) {
+	metadata, err := DecodeARC3(metadataJSON)
+	if err != nil {
+		return [types.AssetMetadataHashLen]byte{}, err
+	}
+	if metadata.ExtraMetadata == "" {
+		return sha256.Sum256(metadataJSON), nil
+	}
+	extra, err := base64.StdEncoding.DecodeString(metadata.ExtraMetadata)
+	if err != nil {
+		return [types.AssetMetadataHashLen]byte{}, fmt.Errorf("invalid ARC-3 extra metadata: %v", err)
+	}
+	jsonHash := sha512.Sum512_256(append(append([]byte{}, arc3JSONPrefix...), metadataJSON...))
+	toHash := append(append(append([]byte{}, arc3HashPrefix...), jsonHash[:]...), extra...)
+	return sha512.Sum512_256(toHash), nil
+}
+
+// ValidateARC3Asset checks that an asset follows ARC-3 with the given JSON
+// metadata file: that its name or URL marks it as ARC-3, that its metadata
+// hash commits to the file and that its decimals match the file's, if set
+func ValidateARC3Asset(params types.AssetParams, metadataJSON []byte) error {
+	if !IsARC3(params.AssetName, params.URL) {
+		return fmt.Errorf("asset %q is not marked as ARC-3 by its name or URL", params.AssetName)
+	}
+	metadata, err := DecodeARC3(metadataJSON)
+	if err != nil {
+		return err
+	}
+	if metadata.Decimals != nil && *metadata.Decimals != params.Decimals {
+		return fmt.Errorf("ARC-3 metadata decimals %d differ from asset decimals %d", *metadata.Decimals, params.Decimals)
+	}
+	hash, err := ARC3MetadataHash(metadataJSON)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash[:], params.MetadataHash[:]) {
+		return fmt.Errorf("asset metadata hash does not commit to the ARC-3 metadata")
+	}
+	return nil
+}
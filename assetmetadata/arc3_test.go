@@ -0,0 +1,50 @@
+package assetmetadata
+
+import (
+	"encoding/hex"
+	stdjson "encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestARC3MetadataHash(t *testing.T) {
+	metadataJSON := []byte(`{"name":"My Song","decimals":0,"image":"ipfs://bafkreicl7vgaztlvagyhs2tkluqkhwrww3t7sflwkgcj2wmkjvlatn6nie"}`)
+	hash, err := ARC3MetadataHash(metadataJSON)
+	require.NoError(t, err)
+	require.Equal(t, "9589927fd164b49a4768e2c94b84053165ca626717f07174302d7314cefb4501", hex.EncodeToString(hash[:]))
+
+	params := types.AssetParams{AssetName: "My Song@arc3", URL: "ipfs://cid", MetadataHash: hash}
+	require.NoError(t, ValidateARC3Asset(params, metadataJSON))
+	params.Decimals = 2
+	require.Error(t, ValidateARC3Asset(params, metadataJSON))
+	params.Decimals = 0
+	params.AssetName = "My Song"
+	require.Error(t, ValidateARC3Asset(params, metadataJSON))
+	params.URL = "ipfs://cid#arc3"
+	require.NoError(t, ValidateARC3Asset(params, metadataJSON))
+	params.MetadataHash[0]++
+	require.Error(t, ValidateARC3Asset(params, metadataJSON))
+
+	withExtra := []byte(`{"name":"My Song","extra_metadata":"c2VjcmV0"}`)
+	hash, err = ARC3MetadataHash(withExtra)
+	require.NoError(t, err)
+	require.Equal(t, "f7cba663be41e95518187685c7b7924f05e2caa1f5d0f4dd0ddbe1782caa8b09", hex.EncodeToString(hash[:]))
+
+	_, err = ARC3MetadataHash([]byte(`{"name":"My Song","extra_metadata":"not base64!"}`))
+	require.Error(t, err)
+	_, err = ARC3MetadataHash([]byte(`not json`))
+	require.Error(t, err)
+
+	// integers above 2^53 keep their precision in properties
+	metadata, err := DecodeARC3([]byte(`{"name":"My Song","properties":{"supply":9007199254740993}}`))
+	require.NoError(t, err)
+	require.Equal(t, stdjson.Number("9007199254740993"), metadata.Properties["supply"])
+	_, err = DecodeARC3([]byte(`{"name":"My Song"} trailing`))
+	require.Error(t, err)
+
+	require.True(t, IsARC3("arc3", ""))
+	require.False(t, IsARC3("arc3 coin", "https://example.com"))
+}
@@ -0,0 +1,89 @@
+package assetmetadata
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// ARC69Standard is the standard field of ARC-69 metadata
+const ARC69Standard = "arc69"
+
+// ARC69Metadata is the ARC-69 metadata of an asset, stored as JSON in the note
+// of its latest asset configuration transaction
+type ARC69Metadata struct {
+	// Standard is always "arc69"
+	Standard string `json:"standard"`
+
+	Description string `json:"description,omitempty"`
+
+	// ExternalURL is a URL with more information about the asset
+	ExternalURL string `json:"external_url,omitempty"`
+
+	// MediaURL is the URL of the asset's media, if it differs from the
+	// asset's URL
+	MediaURL string `json:"media_url,omitempty"`
+
+	// MimeType is the type of the asset's media, e.g. "image/png"
+	MimeType string `json:"mime_type,omitempty"`
+
+	// Properties are arbitrary properties of the asset
+	Properties map[string]interface{} `json:"properties,omitempty"`
+
+	// Attributes are the asset's traits, in the format of marketplaces
+	Attributes []ARC69Attribute `json:"attributes,omitempty"`
+}
+
+// ARC69Attribute is a trait of an asset
+type ARC69Attribute struct {
+	TraitType string      `json:"trait_type"`
+	Value     interface{} `json:"value"`
+}
+
+// EncodeARC69 returns the note of an asset configuration transaction setting
+// the ARC-69 metadata of an asset. Standard is set if empty. An error is
+// returned if the metadata does not fit in a note.
+func EncodeARC69(metadata ARC69Metadata) ([]byte, error) {
+	if metadata.Standard == "" {
+		metadata.Standard = ARC69Standard
+	}
+	if metadata.Standard != ARC69Standard {
+		return nil, fmt.Errorf("ARC-69 metadata has standard %q", metadata.Standard)
+	}
+	note, err := stdjson.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+	if len(note) > types.MaxTxnNoteBytes {
+		return nil, fmt.Errorf("ARC-69 metadata too long: %d > %d", len(note), types.MaxTxnNoteBytes)
+	}
+	return note, nil
+}
+
+// DecodeARC69 decodes the ARC-69 metadata in the note of an asset
+// configuration transaction, returning an error if the note is not ARC-69
+// metadata
+func DecodeARC69(note []byte) (ARC69Metadata, error) {
+	var metadata ARC69Metadata
+	if err := json.DecodeStd(note, &metadata); err != nil {
+		return ARC69Metadata{}, fmt.Errorf("note is not ARC-69 metadata: %v", err)
+	}
+	if metadata.Standard != ARC69Standard {
+		return ARC69Metadata{}, fmt.Errorf("note is not ARC-69 metadata: standard is %q", metadata.Standard)
+	}
+	return metadata, nil
+}
+
+// LatestARC69 returns the current ARC-69 metadata of an asset given the notes
+// of its asset configuration transactions, oldest first: the metadata in the
+// latest note holding any. It returns false if no note holds metadata.
+func LatestARC69(notes [][]byte) (ARC69Metadata, bool) {
+	for i := len(notes) - 1; i >= 0; i-- {
+		if metadata, err := DecodeARC69(notes[i]); err == nil {
+			return metadata, true
+		}
+	}
+	return ARC69Metadata{}, false
+}
@@ -0,0 +1,40 @@
+package assetmetadata
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestARC69(t *testing.T) {
+	metadata := ARC69Metadata{
+		Description: "a test asset",
+		MediaURL:    "ipfs://bafkreicl7vgaztlvagyhs2tkluqkhwrww3t7sflwkgcj2wmkjvlatn6nie",
+		MimeType:    "image/png",
+		Attributes:  []ARC69Attribute{{TraitType: "color", Value: "blue"}},
+	}
+	note, err := EncodeARC69(metadata)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(note), `{"standard":"arc69","description":"a test asset"`))
+
+	decoded, err := DecodeARC69(note)
+	require.NoError(t, err)
+	metadata.Standard = ARC69Standard
+	require.Equal(t, metadata, decoded)
+
+	_, err = DecodeARC69([]byte(`{"standard":"arc3"}`))
+	require.Error(t, err)
+	_, err = DecodeARC69([]byte("hello"))
+	require.Error(t, err)
+	_, err = EncodeARC69(ARC69Metadata{Standard: "arc3"})
+	require.Error(t, err)
+	_, err = EncodeARC69(ARC69Metadata{Description: strings.Repeat("a", 1024)})
+	require.Error(t, err)
+
+	latest, ok := LatestARC69([][]byte{note, []byte(`{"standard":"arc69","description":"updated"}`), []byte("not metadata")})
+	require.True(t, ok)
+	require.Equal(t, "updated", latest.Description)
+	_, ok = LatestARC69([][]byte{[]byte("not metadata")})
+	require.False(t, ok)
+}
@@ -2,6 +2,7 @@ package algod
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,9 @@ import (
 	"strings"
 
 	"github.com/google/go-querystring/query"
+
+	"github.com/algorand/go-algorand-sdk/client/retry"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
 )
 
 const (
@@ -39,8 +43,47 @@ type Header struct {
 // Client manages the REST interface for a calling user.
 type Client struct {
 	serverURL url.URL
+	apiHeader string
 	apiToken  string
 	headers   []*Header
+	retry     retry.Policy
+	http      *http.Client
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithHTTPClient makes a Client send its requests with httpClient, e.g. to
+// use a custom transport, proxy or TLS configuration
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.http = httpClient
+	}
+}
+
+// WithHeaders adds headers to each request of a Client, e.g. the X-API-Key
+// of a hosted node provider
+func WithHeaders(headers ...*Header) ClientOption {
+	return func(c *Client) {
+		c.headers = append(c.headers, headers...)
+	}
+}
+
+// WithTokenHeader sets the name of the header carrying a Client's API token,
+// instead of X-Algo-API-Token, e.g. "X-API-Key" for node providers that
+// expect it there, or "" not to send the token
+func WithTokenHeader(apiHeader string) ClientOption {
+	return func(c *Client) {
+		c.apiHeader = apiHeader
+	}
+}
+
+// WithRetryPolicy makes a Client retry requests as policy says, e.g. those
+// rejected by a rate limited node, see retry.DefaultPolicy
+func WithRetryPolicy(policy retry.Policy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
 }
 
 // MakeClient is the factory for constructing a Client for a given endpoint.
@@ -52,6 +95,7 @@ func MakeClient(address string, apiToken string) (c Client, err error) {
 
 	c = Client{
 		serverURL: *url,
+		apiHeader: authHeader,
 		apiToken:  apiToken,
 	}
 	return
@@ -69,8 +113,22 @@ func MakeClientWithHeaders(address string, apiToken string, headers []*Header) (
 	return
 }
 
+// MakeClientWithOptions is the factory for constructing a Client for a given endpoint, configured by options.
+func MakeClientWithOptions(address string, apiToken string, options ...ClientOption) (c Client, err error) {
+	c, err = MakeClient(address, apiToken)
+	if err != nil {
+		return
+	}
+
+	for _, option := range options {
+		option(&c)
+	}
+
+	return
+}
+
 // extractError checks if the response signifies an error (for now, StatusCode != 200).
-// If so, it returns the error.
+// If so, it returns the error, a *common.HTTPError as the v2 clients return.
 // Otherwise, it returns nil.
 func extractError(resp *http.Response) error {
 	if resp.StatusCode == 200 {
@@ -78,7 +136,7 @@ func extractError(resp *http.Response) error {
 	}
 
 	errorBuf, _ := ioutil.ReadAll(resp.Body) // ignore returned error
-	return fmt.Errorf("HTTP %v: %s", resp.Status, errorBuf)
+	return &common.HTTPError{Status: resp.StatusCode, Body: errorBuf}
 }
 
 // stripTransaction gets a transaction of the form "tx-XXXXXXXX" and truncates the "tx-" part, if it starts with "tx-"
@@ -101,7 +159,7 @@ func mergeRawQueries(q1, q2 string) string {
 }
 
 // submitForm is a helper used for submitting (ex.) GETs and POSTs to the server
-func (client Client) submitForm(response interface{}, path string, request interface{}, requestMethod string, encodeJSON bool, headers []*Header) error {
+func (client Client) submitForm(ctx context.Context, response interface{}, path string, request interface{}, requestMethod string, encodeJSON bool, headers []*Header) error {
 	var err error
 	queryURL := client.serverURL
 
@@ -112,16 +170,15 @@ func (client Client) submitForm(response interface{}, path string, request inter
 		queryURL.Path += path
 	}
 
-	var req *http.Request
-	var body io.Reader
+	var reqBytes []byte
 
 	if request != nil {
 		if rawRequestPaths[path] {
-			reqBytes, ok := request.([]byte)
+			var ok bool
+			reqBytes, ok = request.([]byte)
 			if !ok {
 				return fmt.Errorf("couldn't decode raw request as bytes")
 			}
-			body = bytes.NewBuffer(reqBytes)
 		} else {
 			v, err := query.Values(request)
 			if err != nil {
@@ -130,33 +187,46 @@ func (client Client) submitForm(response interface{}, path string, request inter
 
 			queryURL.RawQuery = mergeRawQueries(queryURL.RawQuery, v.Encode())
 			if encodeJSON {
-				jsonValue, _ := json.Marshal(request)
-				body = bytes.NewBuffer(jsonValue)
+				reqBytes, _ = json.Marshal(request)
 			}
 		}
 	}
 
-	req, err = http.NewRequest(requestMethod, queryURL.String(), body)
-	if err != nil {
-		return err
-	}
+	// a new request is made for each attempt, so that its body can be resent
+	newRequest := func() (*http.Request, error) {
+		var body io.Reader
+		if reqBytes != nil {
+			body = bytes.NewReader(reqBytes)
+		}
+		req, err := http.NewRequest(requestMethod, queryURL.String(), body)
+		if err != nil {
+			return nil, err
+		}
 
-	// If we add another endpoint that does not require auth, we should add a
-	// requiresAuth argument to submitForm rather than checking here
-	if path != healthCheckEndpoint {
-		req.Header.Set(authHeader, client.apiToken)
-	}
-	// Add the client headers.
-	for _, header := range client.headers {
-		req.Header.Add(header.Key, header.Value)
-	}
-	// Add the request headers.
-	for _, header := range headers {
-		req.Header.Add(header.Key, header.Value)
+		// If we add another endpoint that does not require auth, we should add a
+		// requiresAuth argument to submitForm rather than checking here
+		if path != healthCheckEndpoint && client.apiHeader != "" {
+			req.Header.Set(client.apiHeader, client.apiToken)
+		}
+		// Add the client headers.
+		for _, header := range client.headers {
+			req.Header.Add(header.Key, header.Value)
+		}
+		// Add the request headers, which replace client headers of the same name.
+		for _, header := range headers {
+			req.Header.Del(header.Key)
+		}
+		for _, header := range headers {
+			req.Header.Add(header.Key, header.Value)
+		}
+		return req, nil
 	}
 
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
+	httpClient := client.http
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	resp, err := client.retry.Do(ctx, httpClient, newRequest)
 
 	if err != nil {
 		return err
@@ -170,27 +240,29 @@ func (client Client) submitForm(response interface{}, path string, request inter
 	}
 
 	dec := json.NewDecoder(resp.Body)
+	// responses may hold integers above 2^53, which float64 would round
+	dec.UseNumber()
 	return dec.Decode(&response)
 }
 
 // get performs a GET request to the specific path against the server
-func (client Client) get(response interface{}, path string, request interface{}, headers []*Header) error {
-	return client.submitForm(response, path, request, "GET", false /* encodeJSON */, headers)
+func (client Client) get(ctx context.Context, response interface{}, path string, request interface{}, headers []*Header) error {
+	return client.submitForm(ctx, response, path, request, "GET", false /* encodeJSON */, headers)
 }
 
 // post sends a POST request to the given path with the given request object.
 // No query parameters will be sent if request is nil.
 // response must be a pointer to an object as post writes the response there.
-func (client Client) post(response interface{}, path string, request interface{}, headers []*Header) error {
-	return client.submitForm(response, path, request, "POST", true /* encodeJSON */, headers)
+func (client Client) post(ctx context.Context, response interface{}, path string, request interface{}, headers []*Header) error {
+	return client.submitForm(ctx, response, path, request, "POST", true /* encodeJSON */, headers)
 }
 
 // as post, but with MethodPut
-func (client Client) put(response interface{}, path string, request interface{}, headers []*Header) error {
-	return client.submitForm(response, path, request, "PUT", true /* encodeJSON */, headers)
+func (client Client) put(ctx context.Context, response interface{}, path string, request interface{}, headers []*Header) error {
+	return client.submitForm(ctx, response, path, request, "PUT", true /* encodeJSON */, headers)
 }
 
 // as post, but with MethodPatch
-func (client Client) patch(response interface{}, path string, request interface{}, headers []*Header) error {
-	return client.submitForm(response, path, request, "PATCH", true /* encodeJSON */, headers)
+func (client Client) patch(ctx context.Context, response interface{}, path string, request interface{}, headers []*Header) error {
+	return client.submitForm(ctx, response, path, request, "PATCH", true /* encodeJSON */, headers)
 }
@@ -11,21 +11,21 @@ import (
 
 // Status retrieves the StatusResponse from the running node
 // the StatusResponse includes data like the consensus version and current round
-func (client Client) Status(headers ...*Header) (response models.NodeStatus, err error) {
-	err = client.get(&response, "/status", nil, headers)
+func (client Client) Status(ctx context.Context, headers ...*Header) (response models.NodeStatus, err error) {
+	err = client.get(ctx, &response, "/status", nil, headers)
 	return
 }
 
 // HealthCheck does a health check on the the potentially running node,
 // returning an error if the API is down
-func (client Client) HealthCheck(headers ...*Header) error {
-	return client.get(nil, "/health", nil, headers)
+func (client Client) HealthCheck(ctx context.Context, headers ...*Header) error {
+	return client.get(ctx, nil, "/health", nil, headers)
 }
 
 // StatusAfterBlock waits for a block to occur then returns the StatusResponse after that block
 // blocks on the node end
-func (client Client) StatusAfterBlock(blockNum uint64, headers ...*Header) (response models.NodeStatus, err error) {
-	err = client.get(&response, fmt.Sprintf("/status/wait-for-block-after/%d", blockNum), nil, headers)
+func (client Client) StatusAfterBlock(ctx context.Context, blockNum uint64, headers ...*Header) (response models.NodeStatus, err error) {
+	err = client.get(ctx, &response, fmt.Sprintf("/status/wait-for-block-after/%d", blockNum), nil, headers)
 	return
 }
 
@@ -35,21 +35,21 @@ type pendingTransactionsParams struct {
 
 // GetPendingTransactions asks algod for a snapshot of current pending txns on the node, bounded by maxTxns.
 // If maxTxns = 0, fetches as many transactions as possible.
-func (client Client) GetPendingTransactions(maxTxns uint64, headers ...*Header) (response models.PendingTransactions, err error) {
-	err = client.get(&response, fmt.Sprintf("/transactions/pending"), pendingTransactionsParams{maxTxns}, headers)
+func (client Client) GetPendingTransactions(ctx context.Context, maxTxns uint64, headers ...*Header) (response models.PendingTransactions, err error) {
+	err = client.get(ctx, &response, fmt.Sprintf("/transactions/pending"), pendingTransactionsParams{maxTxns}, headers)
 	return
 }
 
 // Versions retrieves the VersionResponse from the running node
 // the VersionResponse includes data like version number and genesis ID
-func (client Client) Versions(headers ...*Header) (response models.Version, err error) {
-	err = client.get(&response, "/versions", nil, headers)
+func (client Client) Versions(ctx context.Context, headers ...*Header) (response models.Version, err error) {
+	err = client.get(ctx, &response, "/versions", nil, headers)
 	return
 }
 
 // LedgerSupply gets the supply details for the specified node's Ledger
-func (client Client) LedgerSupply(headers ...*Header) (response models.Supply, err error) {
-	err = client.get(&response, "/ledger/supply", nil, headers)
+func (client Client) LedgerSupply(ctx context.Context, headers ...*Header) (response models.Supply, err error) {
+	err = client.get(ctx, &response, "/ledger/supply", nil, headers)
 	return
 }
 
@@ -63,44 +63,44 @@ type transactionsByAddrParams struct {
 
 // TransactionsByAddr returns all transactions for a PK [addr] in the [first,
 // last] rounds range.
-func (client Client) TransactionsByAddr(addr string, first, last uint64, headers ...*Header) (response models.TransactionList, err error) {
+func (client Client) TransactionsByAddr(ctx context.Context, addr string, first, last uint64, headers ...*Header) (response models.TransactionList, err error) {
 	params := transactionsByAddrParams{FirstRound: first, LastRound: last}
-	err = client.get(&response, fmt.Sprintf("/account/%s/transactions", addr), params, headers)
+	err = client.get(ctx, &response, fmt.Sprintf("/account/%s/transactions", addr), params, headers)
 	return
 }
 
 // TransactionsByAddrLimit returns the last [limit] number of transaction for a PK [addr].
-func (client Client) TransactionsByAddrLimit(addr string, limit uint64, headers ...*Header) (response models.TransactionList, err error) {
+func (client Client) TransactionsByAddrLimit(ctx context.Context, addr string, limit uint64, headers ...*Header) (response models.TransactionList, err error) {
 	params := transactionsByAddrParams{Max: limit}
-	err = client.get(&response, fmt.Sprintf("/account/%s/transactions", addr), params, headers)
+	err = client.get(ctx, &response, fmt.Sprintf("/account/%s/transactions", addr), params, headers)
 	return
 }
 
 // TransactionsByAddrForDate returns all transactions for a PK [addr] in the [first,
 // last] date range. Dates are of the form "2006-01-02".
-func (client Client) TransactionsByAddrForDate(addr string, first, last string, headers ...*Header) (response models.TransactionList, err error) {
+func (client Client) TransactionsByAddrForDate(ctx context.Context, addr string, first, last string, headers ...*Header) (response models.TransactionList, err error) {
 	params := transactionsByAddrParams{FromDate: first, ToDate: last}
-	err = client.get(&response, fmt.Sprintf("/account/%s/transactions", addr), params, headers)
+	err = client.get(ctx, &response, fmt.Sprintf("/account/%s/transactions", addr), params, headers)
 	return
 }
 
 // AccountInformation also gets the AccountInformationResponse associated with the passed address
-func (client Client) AccountInformation(address string, headers ...*Header) (response models.Account, err error) {
-	err = client.get(&response, fmt.Sprintf("/account/%s", address), nil, headers)
+func (client Client) AccountInformation(ctx context.Context, address string, headers ...*Header) (response models.Account, err error) {
+	err = client.get(ctx, &response, fmt.Sprintf("/account/%s", address), nil, headers)
 	return
 }
 
 // AssetInformation also gets the AssetInformationResponse associated with the passed asset creator and index
-func (client Client) AssetInformation(index uint64, headers ...*Header) (response models.AssetParams, err error) {
-	err = client.get(&response, fmt.Sprintf("/asset/%d", index), nil, headers)
+func (client Client) AssetInformation(ctx context.Context, index uint64, headers ...*Header) (response models.AssetParams, err error) {
+	err = client.get(ctx, &response, fmt.Sprintf("/asset/%d", index), nil, headers)
 	return
 }
 
 // TransactionInformation gets information about a specific transaction involving a specific account
 // it will only return information about transactions submitted to the node queried
-func (client Client) TransactionInformation(accountAddress, transactionID string, headers ...*Header) (response models.Transaction, err error) {
+func (client Client) TransactionInformation(ctx context.Context, accountAddress, transactionID string, headers ...*Header) (response models.Transaction, err error) {
 	transactionID = stripTransaction(transactionID)
-	err = client.get(&response, fmt.Sprintf("/account/%s/transaction/%s", accountAddress, transactionID), nil, headers)
+	err = client.get(ctx, &response, fmt.Sprintf("/account/%s/transaction/%s", accountAddress, transactionID), nil, headers)
 	return
 }
 
@@ -113,41 +113,41 @@ func (client Client) TransactionInformation(accountAddress, transactionID string
 //
 // Or the transaction may have happened sufficiently long ago that the
 // node no longer remembers it, and this will return an error.
-func (client Client) PendingTransactionInformation(transactionID string, headers ...*Header) (response models.Transaction, err error) {
+func (client Client) PendingTransactionInformation(ctx context.Context, transactionID string, headers ...*Header) (response models.Transaction, err error) {
 	transactionID = stripTransaction(transactionID)
-	err = client.get(&response, fmt.Sprintf("/transactions/pending/%s", transactionID), nil, headers)
+	err = client.get(ctx, &response, fmt.Sprintf("/transactions/pending/%s", transactionID), nil, headers)
 	return
 }
 
 // TransactionByID gets a transaction by its ID. Works only if the indexer is enabled on the node
 // being queried.
-func (client Client) TransactionByID(transactionID string, headers ...*Header) (response models.Transaction, err error) {
+func (client Client) TransactionByID(ctx context.Context, transactionID string, headers ...*Header) (response models.Transaction, err error) {
 	transactionID = stripTransaction(transactionID)
-	err = client.get(&response, fmt.Sprintf("/transaction/%s", transactionID), nil, headers)
+	err = client.get(ctx, &response, fmt.Sprintf("/transaction/%s", transactionID), nil, headers)
 	return
 }
 
 // SuggestedFee gets the recommended transaction fee from the node
-func (client Client) SuggestedFee(headers ...*Header) (response models.TransactionFee, err error) {
-	err = client.get(&response, "/transactions/fee", nil, headers)
+func (client Client) SuggestedFee(ctx context.Context, headers ...*Header) (response models.TransactionFee, err error) {
+	err = client.get(ctx, &response, "/transactions/fee", nil, headers)
 	return
 }
 
 // SuggestedParams gets the suggested transaction parameters
-func (client Client) SuggestedParams(headers ...*Header) (response models.TransactionParams, err error) {
-	err = client.get(&response, "/transactions/params", nil, headers)
+func (client Client) SuggestedParams(ctx context.Context, headers ...*Header) (response models.TransactionParams, err error) {
+	err = client.get(ctx, &response, "/transactions/params", nil, headers)
 	return
 }
 
 // SendRawTransaction gets the bytes of a SignedTxn and broadcasts it to the network
-func (client Client) SendRawTransaction(stx []byte, headers ...*Header) (response models.TransactionID, err error) {
-	err = client.post(&response, "/transactions", stx, headers)
+func (client Client) SendRawTransaction(ctx context.Context, stx []byte, headers ...*Header) (response models.TransactionID, err error) {
+	err = client.post(ctx, &response, "/transactions", stx, headers)
 	return
 }
 
 // Block gets the block info for the given round
-func (client Client) Block(round uint64, headers ...*Header) (response models.Block, err error) {
-	err = client.get(&response, fmt.Sprintf("/block/%d", round), nil, headers)
+func (client Client) Block(ctx context.Context, round uint64, headers ...*Header) (response models.Block, err error) {
+	err = client.get(ctx, &response, fmt.Sprintf("/block/%d", round), nil, headers)
 	return
 }
 
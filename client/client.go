@@ -0,0 +1,162 @@
+// Package client defines the interfaces of the algod, indexer and kmd clients
+// that the SDK's helpers, such as future.WaitForConfirmation and
+// future.AtomicTransactionComposer, take instead of the concrete clients, so
+// that applications can give them a mock or a client wrapped by middleware,
+// e.g. one logging or counting requests.
+//
+// A wrapper typically embeds the concrete client, or the interface, and
+// overrides some of its methods. The requests of algod and indexer clients
+// are sent over HTTP, so a mock of them returns requests of a concrete client
+// of a fake server, such as those of the client/v2/mock package.
+package client
+
+import (
+	"context"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/client/kmd"
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// AlgodClient is the interface of *algod.Client, the algod v2 client
+type AlgodClient interface {
+	HealthCheck() *algod.HealthCheck
+	Ready() *algod.Ready
+	GetGenesis() *algod.GetGenesis
+	Versions() *algod.Versions
+	Status() *algod.Status
+	StatusAfterBlock(round uint64) *algod.StatusAfterBlock
+	Supply() *algod.Supply
+	AccountInformation(address string) *algod.AccountInformation
+	GetAssetByID(assetIndex uint64) *algod.GetAssetByID
+	GetApplicationByID(applicationIndex uint64) *algod.GetApplicationByID
+	GetApplicationBoxByName(applicationIndex uint64, name []byte) *algod.GetApplicationBoxByName
+	GetApplicationBoxes(applicationIndex uint64) *algod.GetApplicationBoxes
+	Block(round uint64) *algod.Block
+	BlockRaw(round uint64) *algod.BlockRaw
+	GetTransactionProof(round uint64, txid string) *algod.GetTransactionProof
+	PendingTransactions() *algod.PendingTransactions
+	PendingTransactionsByAddress(address string) *algod.PendingTransactionsByAddress
+	PendingTransactionInformation(txid string) *algod.PendingTransactionInformation
+	SendRawTransaction(rawtxn []byte) *algod.SendRawTransaction
+	SendTransactionGroup(stxs [][]byte) *algod.SendTransactionGroup
+	SuggestedParams() *algod.SuggestedParams
+	TealCompile(source []byte) *algod.TealCompile
+	TealDisassemble(program []byte) *algod.TealDisassemble
+	TealDryrun(request models.DryrunRequest) *algod.TealDryrun
+	SimulateTransaction(request models.SimulateRequest) *algod.SimulateTransaction
+	GetStateProof(round uint64) *algod.GetStateProof
+	GetLightBlockHeaderProof(round uint64) *algod.GetLightBlockHeaderProof
+	SetSyncRound(round uint64) *algod.SetSyncRound
+	GetSyncRound() *algod.GetSyncRound
+	UnsetSyncRound() *algod.UnsetSyncRound
+	GetLedgerStateDelta(round uint64) *algod.GetLedgerStateDelta
+	GetTransactionGroupLedgerStateDeltasForRound(round uint64) *algod.GetTransactionGroupLedgerStateDeltasForRound
+	GetLedgerStateDeltaForTransactionGroup(id string) *algod.GetLedgerStateDeltaForTransactionGroup
+	GetParticipationKeys() *algod.GetParticipationKeys
+	GetParticipationKeyByID(participationID string) *algod.GetParticipationKeyByID
+	AddParticipationKey(keyFile []byte) *algod.AddParticipationKey
+	AppendKeys(participationID string, keys []byte) *algod.AppendKeys
+	DeleteParticipationKeyByID(participationID string) *algod.DeleteParticipationKeyByID
+	GenerateParticipationKeys(address string, first, last uint64) *algod.GenerateParticipationKeys
+	StartCatchup(catchpoint string) *algod.StartCatchup
+	AbortCatchup(catchpoint string) *algod.AbortCatchup
+	Shutdown() *algod.Shutdown
+}
+
+// IndexerClient is the interface of *indexer.Client, the indexer v2 client
+type IndexerClient interface {
+	HealthCheck() *indexer.HealthCheck
+	SearchAccounts() *indexer.SearchAccounts
+	LookupAccountByID(address string) *indexer.LookupAccountByID
+	LookupAccountTransactions(address string) *indexer.LookupAccountTransactions
+	SearchForAssets() *indexer.SearchForAssets
+	LookupAssetByID(assetID uint64) *indexer.LookupAssetByID
+	LookupAssetBalances(assetID uint64) *indexer.LookupAssetBalances
+	LookupAssetTransactions(assetID uint64) *indexer.LookupAssetTransactions
+	SearchForApplications() *indexer.SearchForApplications
+	LookupApplicationByID(applicationID uint64) *indexer.LookupApplicationByID
+	SearchForApplicationBoxes(applicationID uint64) *indexer.SearchForApplicationBoxes
+	LookupApplicationBoxByIDAndName(applicationID uint64, name []byte) *indexer.LookupApplicationBoxByIDAndName
+	SearchForTransactions() *indexer.SearchForTransactions
+	LookupTransaction(txid string) *indexer.LookupTransaction
+	LookupBlock(round uint64) *indexer.LookupBlock
+}
+
+// KmdClient is the interface of kmd.Client, the kmd client
+type KmdClient interface {
+	DoV1Request(ctx context.Context, req kmd.APIV1Request, resp kmd.APIV1Response) error
+	Version(ctx context.Context) (kmd.VersionsResponse, error)
+	ListWallets(ctx context.Context) (kmd.ListWalletsResponse, error)
+	CreateWallet(ctx context.Context, walletName, walletPassword, walletDriverName string, walletMDK types.MasterDerivationKey) (kmd.CreateWalletResponse, error)
+	InitWalletHandle(ctx context.Context, walletID, walletPassword string) (kmd.InitWalletHandleResponse, error)
+	ReleaseWalletHandle(ctx context.Context, walletHandle string) (kmd.ReleaseWalletHandleResponse, error)
+	RenewWalletHandle(ctx context.Context, walletHandle string) (kmd.RenewWalletHandleResponse, error)
+	RenameWallet(ctx context.Context, walletID, walletPassword, newWalletName string) (kmd.RenameWalletResponse, error)
+	GetWallet(ctx context.Context, walletHandle string) (kmd.GetWalletResponse, error)
+	ExportMasterDerivationKey(ctx context.Context, walletHandle, walletPassword string) (kmd.ExportMasterDerivationKeyResponse, error)
+	ImportKey(ctx context.Context, walletHandle string, secretKey ed25519.PrivateKey) (kmd.ImportKeyResponse, error)
+	ExportKey(ctx context.Context, walletHandle, walletPassword, addr string) (kmd.ExportKeyResponse, error)
+	GenerateKey(ctx context.Context, walletHandle string) (kmd.GenerateKeyResponse, error)
+	DeleteKey(ctx context.Context, walletHandle, walletPassword, addr string) (kmd.DeleteKeyResponse, error)
+	ListKeys(ctx context.Context, walletHandle string) (kmd.ListKeysResponse, error)
+	SignTransaction(ctx context.Context, walletHandle, walletPassword string, tx types.Transaction) (kmd.SignTransactionResponse, error)
+	SignTransactionWithSpecificPublicKey(ctx context.Context, walletHandle, walletPassword string, tx types.Transaction, pk ed25519.PublicKey) (kmd.SignTransactionResponse, error)
+	SignProgram(ctx context.Context, walletHandle, walletPassword, addr string, program []byte) (kmd.SignProgramResponse, error)
+	ListMultisig(ctx context.Context, walletHandle string) (kmd.ListMultisigResponse, error)
+	ImportMultisig(ctx context.Context, walletHandle string, version, threshold uint8, pks []ed25519.PublicKey) (kmd.ImportMultisigResponse, error)
+	ExportMultisig(ctx context.Context, walletHandle, walletPassword, addr string) (kmd.ExportMultisigResponse, error)
+	DeleteMultisig(ctx context.Context, walletHandle, walletPassword, addr string) (kmd.DeleteMultisigResponse, error)
+	MultisigSignTransaction(ctx context.Context, walletHandle, walletPassword string, tx types.Transaction, pk ed25519.PublicKey, partial types.MultisigSig) (kmd.SignMultisigTransactionResponse, error)
+	MultisigSignProgram(ctx context.Context, walletHandle, walletPassword, addr string, program []byte, pk ed25519.PublicKey, partial types.MultisigSig) (kmd.SignProgramMultisigResponse, error)
+}
+
+// ConfirmationClient is the part of an algod client that
+// future.WaitForConfirmationWith uses. Unlike AlgodClient, its methods return
+// the results of requests rather than requests, so that it can be implemented
+// in memory, without an HTTP server. A transaction the node has not seen yet
+// is reported as a *common.HTTPError with a 404 status, as algod reports it.
+type ConfirmationClient interface {
+	Status(ctx context.Context) (models.NodeStatusResponse, error)
+	StatusAfterBlock(ctx context.Context, round uint64) (models.NodeStatusResponse, error)
+	PendingTransactionInformation(ctx context.Context, txid string) (models.PendingTransactionInfoResponse, error)
+}
+
+// AlgodResults is a ConfirmationClient doing the requests of an AlgodClient,
+// with the given headers
+type AlgodResults struct {
+	Client  AlgodClient
+	Headers []*common.Header
+}
+
+// Status returns the status of the node
+func (r AlgodResults) Status(ctx context.Context) (models.NodeStatusResponse, error) {
+	return r.Client.Status().Do(ctx, r.Headers...)
+}
+
+// StatusAfterBlock waits for the node to reach the round after round and
+// returns its status
+func (r AlgodResults) StatusAfterBlock(ctx context.Context, round uint64) (models.NodeStatusResponse, error) {
+	return r.Client.StatusAfterBlock(round).Do(ctx, r.Headers...)
+}
+
+// PendingTransactionInformation returns the pool status of the transaction
+// txid, or the round it was confirmed in
+func (r AlgodResults) PendingTransactionInformation(ctx context.Context, txid string) (models.PendingTransactionInfoResponse, error) {
+	txInfo, _, err := r.Client.PendingTransactionInformation(txid).Do(ctx, r.Headers...)
+	return txInfo, err
+}
+
+// the concrete clients implement the interfaces
+var (
+	_ AlgodClient   = (*algod.Client)(nil)
+	_ IndexerClient = (*indexer.Client)(nil)
+	_ KmdClient     = kmd.Client{}
+
+	_ ConfirmationClient = AlgodResults{}
+)
@@ -2,10 +2,12 @@ package kmd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/algorand/go-algorand-sdk/client/retry"
 	"github.com/algorand/go-algorand-sdk/encoding/json"
 )
 
@@ -19,6 +21,40 @@ type Client struct {
 	httpClient http.Client
 	apiToken   string
 	address    string
+	headers    []*Header
+	retry      retry.Policy
+}
+
+// Header is a struct for custom headers.
+type Header struct {
+	Key   string
+	Value string
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithHTTPClient makes a Client send its requests with httpClient, instead of
+// a client with a two minute timeout
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = *httpClient
+	}
+}
+
+// WithHeaders adds headers to each request of a Client
+func WithHeaders(headers ...*Header) ClientOption {
+	return func(c *Client) {
+		c.headers = append(c.headers, headers...)
+	}
+}
+
+// WithRetryPolicy makes a Client retry requests as policy says, see
+// retry.DefaultPolicy
+func WithRetryPolicy(policy retry.Policy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
 }
 
 func makeHTTPClient() http.Client {
@@ -38,8 +74,21 @@ func MakeClient(address string, apiToken string) (Client, error) {
 	return kcl, nil
 }
 
+// MakeClientWithOptions instantiates a Client for the given address and
+// apiToken, configured by options
+func MakeClientWithOptions(address string, apiToken string, options ...ClientOption) (Client, error) {
+	kcl, err := MakeClient(address, apiToken)
+	if err != nil {
+		return Client{}, err
+	}
+	for _, option := range options {
+		option(&kcl)
+	}
+	return kcl, nil
+}
+
 // DoV1Request accepts a request from kmdapi/requests and
-func (kcl Client) DoV1Request(req APIV1Request, resp APIV1Response) error {
+func (kcl Client) DoV1Request(ctx context.Context, req APIV1Request, resp APIV1Response) error {
 	var body []byte
 
 	// Get the path and method for this request type
@@ -51,16 +100,23 @@ func (kcl Client) DoV1Request(req APIV1Request, resp APIV1Response) error {
 	// Encode the request
 	body = json.Encode(req)
 	fullPath := fmt.Sprintf("%s/%s", kcl.address, reqPath)
-	hreq, err := http.NewRequest(reqMethod, fullPath, bytes.NewReader(body))
-	if err != nil {
-		return err
-	}
+	newRequest := func() (*http.Request, error) {
+		hreq, err := http.NewRequest(reqMethod, fullPath, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
 
-	// Add the auth token
-	hreq.Header.Add(kmdTokenHeader, kcl.apiToken)
+		// Add the auth token
+		hreq.Header.Add(kmdTokenHeader, kcl.apiToken)
+		// Add the client headers.
+		for _, header := range kcl.headers {
+			hreq.Header.Add(header.Key, header.Value)
+		}
+		return hreq, nil
+	}
 
 	// Send the request
-	hresp, err := kcl.httpClient.Do(hreq)
+	hresp, err := kcl.retry.Do(ctx, &kcl.httpClient, newRequest)
 	if err != nil {
 		return err
 	}
@@ -131,6 +187,9 @@ func getPathAndMethod(req APIV1Request) (reqPath string, reqMethod string, err e
 	case SignTransactionRequest:
 		reqPath = "v1/transaction/sign"
 		reqMethod = "POST"
+	case SignProgramRequest:
+		reqPath = "v1/program/sign"
+		reqMethod = "POST"
 	case ListMultisigRequest:
 		reqPath = "v1/multisig/list"
 		reqMethod = "POST"
@@ -146,6 +205,9 @@ func getPathAndMethod(req APIV1Request) (reqPath string, reqMethod string, err e
 	case SignMultisigTransactionRequest:
 		reqPath = "v1/multisig/sign"
 		reqMethod = "POST"
+	case SignProgramMultisigRequest:
+		reqPath = "v1/multisig/signprogram"
+		reqMethod = "POST"
 	}
 	return
 }
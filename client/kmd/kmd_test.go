@@ -0,0 +1,77 @@
+package kmd
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestMultisigSignProgram(t *testing.T) {
+	accounts := []crypto.Account{crypto.GenerateAccount(), crypto.GenerateAccount(), crypto.GenerateAccount()}
+	ma, err := crypto.MultisigAccountWithParams(1, 2, []types.Address{accounts[0].Address, accounts[1].Address, accounts[2].Address})
+	require.NoError(t, err)
+	msigAddr, err := ma.Address()
+	require.NoError(t, err)
+	program := []byte{1, 32, 1, 1, 34}
+
+	// the fake kmd holds the first two keys of the multisig
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/multisig/signprogram", r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		var req SignProgramMultisigRequest
+		require.NoError(t, json.LenientDecode(body, &req))
+		require.Equal(t, msigAddr.String(), req.Address)
+
+		var sk crypto.SecretKey
+		for _, account := range accounts[:2] {
+			if string(account.PublicKey) == string(req.PublicKey) {
+				sk = account.PrivateKey
+			}
+		}
+		if sk == nil {
+			w.Write(json.Encode(SignProgramMultisigResponse{APIV1ResponseEnvelope: APIV1ResponseEnvelope{Error: true, Message: "key not found"}}))
+			return
+		}
+		lsig := types.LogicSig{Logic: req.Program, Msig: req.PartialMsig}
+		if len(req.PartialMsig.Subsigs) == 0 {
+			lsig, err = crypto.MakeLogicSig(req.Program, nil, sk, ma)
+		} else {
+			err = crypto.AppendMultisigToLogicSig(&lsig, sk)
+		}
+		require.NoError(t, err)
+		w.Write(json.Encode(SignProgramMultisigResponse{Multisig: msgpack.Encode(lsig.Msig)}))
+	}))
+	defer server.Close()
+
+	client, err := MakeClient(server.URL, "token")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	resp, err := client.MultisigSignProgram(ctx, "handle", "password", msigAddr.String(), program, accounts[0].PublicKey, types.MultisigSig{})
+	require.NoError(t, err)
+	lsig, err := MakeLogicSigFromMultisig(program, nil, resp.Multisig)
+	require.NoError(t, err)
+	require.False(t, crypto.VerifyLogicSig(lsig, msigAddr))
+
+	resp, err = client.MultisigSignProgram(ctx, "handle", "password", msigAddr.String(), program, accounts[1].PublicKey, lsig.Msig)
+	require.NoError(t, err)
+	lsig, err = MakeLogicSigFromMultisig(program, nil, resp.Multisig)
+	require.NoError(t, err)
+	require.True(t, crypto.VerifyLogicSig(lsig, msigAddr))
+
+	_, err = client.MultisigSignProgram(ctx, "handle", "password", msigAddr.String(), program, accounts[2].PublicKey, lsig.Msig)
+	require.Error(t, err)
+
+	_, err = MakeLogicSigFromMultisig(program, nil, []byte("not msgpack"))
+	require.Error(t, err)
+}
@@ -123,6 +123,12 @@ type SignTransactionResponse struct {
 	SignedTransaction []byte `json:"signed_transaction"`
 }
 
+// SignProgramResponse is the response to `POST /v1/program/sign`
+type SignProgramResponse struct {
+	APIV1ResponseEnvelope
+	Signature []byte `json:"sig"`
+}
+
 // ListMultisigResponse is the response to `POST /v1/multisig/list`
 type ListMultisigResponse struct {
 	APIV1ResponseEnvelope
@@ -153,3 +159,9 @@ type SignMultisigTransactionResponse struct {
 	APIV1ResponseEnvelope
 	Multisig []byte `json:"multisig"`
 }
+
+// SignProgramMultisigResponse is the response to `POST /v1/multisig/signprogram`
+type SignProgramMultisigResponse struct {
+	APIV1ResponseEnvelope
+	Multisig []byte `json:"multisig"`
+}
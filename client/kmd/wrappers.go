@@ -1,6 +1,9 @@
 package kmd
 
 import (
+	"context"
+	"fmt"
+
 	"golang.org/x/crypto/ed25519"
 
 	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
@@ -9,18 +12,18 @@ import (
 
 // Version returns a VersionResponse containing a list of kmd API versions
 // supported by this running kmd instance.
-func (kcl Client) Version() (resp VersionsResponse, err error) {
+func (kcl Client) Version(ctx context.Context) (resp VersionsResponse, err error) {
 	req := VersionsRequest{}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
 // ListWallets returns a ListWalletsResponse containing the list of wallets
 // known to kmd. Using a wallet ID returned from this endpoint, you can
 // initialize a wallet handle with client.InitWalletHandle
-func (kcl Client) ListWallets() (resp ListWalletsResponse, err error) {
+func (kcl Client) ListWallets(ctx context.Context) (resp ListWalletsResponse, err error) {
 	req := ListWalletsRequest{}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
@@ -28,14 +31,14 @@ func (kcl Client) ListWallets() (resp ListWalletsResponse, err error) {
 // and master derivation key. If the master derivation key is blank, one is
 // generated internally to kmd. CreateWallet returns a CreateWalletResponse
 // containing information about the new wallet.
-func (kcl Client) CreateWallet(walletName, walletPassword, walletDriverName string, walletMDK types.MasterDerivationKey) (resp CreateWalletResponse, err error) {
+func (kcl Client) CreateWallet(ctx context.Context, walletName, walletPassword, walletDriverName string, walletMDK types.MasterDerivationKey) (resp CreateWalletResponse, err error) {
 	req := CreateWalletRequest{
 		WalletName:          walletName,
 		WalletDriverName:    walletDriverName,
 		WalletPassword:      walletPassword,
 		MasterDerivationKey: walletMDK,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
@@ -46,22 +49,22 @@ func (kcl Client) CreateWallet(walletName, walletPassword, walletDriverName stri
 // configurable number of seconds, and must be renewed periodically with
 // RenewWalletHandle. It is good practice to call ReleaseWalletHandle when
 // you're done interacting with this wallet.
-func (kcl Client) InitWalletHandle(walletID, walletPassword string) (resp InitWalletHandleResponse, err error) {
+func (kcl Client) InitWalletHandle(ctx context.Context, walletID, walletPassword string) (resp InitWalletHandleResponse, err error) {
 	req := InitWalletHandleRequest{
 		WalletID:       walletID,
 		WalletPassword: walletPassword,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
 // ReleaseWalletHandle invalidates the passed wallet handle token, making
 // it unusable for subsequent wallet operations.
-func (kcl Client) ReleaseWalletHandle(walletHandle string) (resp ReleaseWalletHandleResponse, err error) {
+func (kcl Client) ReleaseWalletHandle(ctx context.Context, walletHandle string) (resp ReleaseWalletHandleResponse, err error) {
 	req := ReleaseWalletHandleRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
@@ -69,33 +72,33 @@ func (kcl Client) ReleaseWalletHandle(walletHandle string) (resp ReleaseWalletHa
 // the expiration time to some number of seconds in the future. It returns a
 // RenewWalletHandleResponse containing the walletHandle and the number of
 // seconds until expiration
-func (kcl Client) RenewWalletHandle(walletHandle string) (resp RenewWalletHandleResponse, err error) {
+func (kcl Client) RenewWalletHandle(ctx context.Context, walletHandle string) (resp RenewWalletHandleResponse, err error) {
 	req := RenewWalletHandleRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
 // RenameWallet accepts a wallet ID, wallet password, and a new wallet name,
 // and renames the underlying wallet.
-func (kcl Client) RenameWallet(walletID, walletPassword, newWalletName string) (resp RenameWalletResponse, err error) {
+func (kcl Client) RenameWallet(ctx context.Context, walletID, walletPassword, newWalletName string) (resp RenameWalletResponse, err error) {
 	req := RenameWalletRequest{
 		WalletID:       walletID,
 		WalletPassword: walletPassword,
 		NewWalletName:  newWalletName,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
 // GetWallet accepts a wallet handle and returns high level information about
 // this wallet in a GetWalletResponse.
-func (kcl Client) GetWallet(walletHandle string) (resp GetWalletResponse, err error) {
+func (kcl Client) GetWallet(ctx context.Context, walletHandle string) (resp GetWalletResponse, err error) {
 	req := GetWalletRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
@@ -105,49 +108,49 @@ func (kcl Client) GetWallet(walletHandle string) (resp GetWalletResponse, err er
 // order to recover the keys generated by this wallet. The master derivation
 // key can be encoded as a sequence of words using the mnemonic library, and
 // displayed to the user as a backup phrase.
-func (kcl Client) ExportMasterDerivationKey(walletHandle, walletPassword string) (resp ExportMasterDerivationKeyResponse, err error) {
+func (kcl Client) ExportMasterDerivationKey(ctx context.Context, walletHandle, walletPassword string) (resp ExportMasterDerivationKeyResponse, err error) {
 	req := ExportMasterDerivationKeyRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
 // ImportKey accepts a wallet handle and an ed25519 private key, and imports
 // the key into the wallet. It returns an ImportKeyResponse containing the
 // address corresponding to this private key.
-func (kcl Client) ImportKey(walletHandle string, secretKey ed25519.PrivateKey) (resp ImportKeyResponse, err error) {
+func (kcl Client) ImportKey(ctx context.Context, walletHandle string, secretKey ed25519.PrivateKey) (resp ImportKeyResponse, err error) {
 	req := ImportKeyRequest{
 		WalletHandleToken: walletHandle,
 		PrivateKey:        secretKey,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
 // ExportKey accepts a wallet handle, wallet password, and address, and returns
 // an ExportKeyResponse containing the ed25519 private key corresponding to the
 // address stored in the wallet.
-func (kcl Client) ExportKey(walletHandle, walletPassword, addr string) (resp ExportKeyResponse, err error) {
+func (kcl Client) ExportKey(ctx context.Context, walletHandle, walletPassword, addr string) (resp ExportKeyResponse, err error) {
 	req := ExportKeyRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Address:           addr,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
 // GenerateKey accepts a wallet handle, and then generates the next key in the
 // wallet using its internal master derivation key. Two wallets with the same
 // master derivation key will generate the same sequence of keys.
-func (kcl Client) GenerateKey(walletHandle string) (resp GenerateKeyResponse, err error) {
+func (kcl Client) GenerateKey(ctx context.Context, walletHandle string) (resp GenerateKeyResponse, err error) {
 	req := GenerateKeyRequest{
 		WalletHandleToken: walletHandle,
 		DisplayMnemonic:   false,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
@@ -157,23 +160,23 @@ func (kcl Client) GenerateKey(walletHandle string) (resp GenerateKeyResponse, er
 // the same key will not be generated again. However, if a wallet is recovered
 // using the master derivation key, a key generated in this way can be
 // recovered.
-func (kcl Client) DeleteKey(walletHandle, walletPassword, addr string) (resp DeleteKeyResponse, err error) {
+func (kcl Client) DeleteKey(ctx context.Context, walletHandle, walletPassword, addr string) (resp DeleteKeyResponse, err error) {
 	req := DeleteKeyRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Address:           addr,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
 // ListKeys accepts a wallet handle and returns a ListKeysResponse containing
 // all of the addresses for which this wallet contains secret keys.
-func (kcl Client) ListKeys(walletHandle string) (resp ListKeysResponse, err error) {
+func (kcl Client) ListKeys(ctx context.Context, walletHandle string) (resp ListKeysResponse, err error) {
 	req := ListKeysRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
@@ -181,14 +184,59 @@ func (kcl Client) ListKeys(walletHandle string) (resp ListKeysResponse, err erro
 // and returns and SignTransactionResponse containing an encoded, signed
 // transaction. The transaction is signed using the key corresponding to the
 // Sender field.
-func (kcl Client) SignTransaction(walletHandle, walletPassword string, tx types.Transaction) (resp SignTransactionResponse, err error) {
+func (kcl Client) SignTransaction(ctx context.Context, walletHandle, walletPassword string, tx types.Transaction) (resp SignTransactionResponse, err error) {
+	txBytes := msgpack.Encode(tx)
+	req := SignTransactionRequest{
+		WalletHandleToken: walletHandle,
+		WalletPassword:    walletPassword,
+		Transaction:       txBytes,
+	}
+	err = kcl.DoV1Request(ctx, req, &resp)
+	return
+}
+
+// SignTransactionWithSpecificPublicKey accepts a wallet handle, wallet password,
+// transaction, and public key, and returns a SignTransactionResponse containing
+// an encoded, signed transaction. The transaction is signed using the key
+// corresponding to pk rather than the Sender field, as needed when the sender's
+// authorized key differs from its address.
+func (kcl Client) SignTransactionWithSpecificPublicKey(ctx context.Context, walletHandle, walletPassword string, tx types.Transaction, pk ed25519.PublicKey) (resp SignTransactionResponse, err error) {
 	txBytes := msgpack.Encode(tx)
 	req := SignTransactionRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Transaction:       txBytes,
+		PublicKey:         pk,
+	}
+	err = kcl.DoV1Request(ctx, req, &resp)
+	return
+}
+
+// SignProgram accepts a wallet handle, wallet password, address, and TEAL
+// program, and returns a SignProgramResponse containing the signature of the
+// program by the key corresponding to addr. Together with the program this
+// signature forms a delegated LogicSig, see MakeLogicSigFromSignature.
+func (kcl Client) SignProgram(ctx context.Context, walletHandle, walletPassword, addr string, program []byte) (resp SignProgramResponse, err error) {
+	req := SignProgramRequest{
+		WalletHandleToken: walletHandle,
+		WalletPassword:    walletPassword,
+		Address:           addr,
+		Program:           program,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
+	return
+}
+
+// MakeLogicSigFromSignature builds a delegated LogicSig from a program and the
+// signature returned by SignProgram
+func MakeLogicSigFromSignature(program []byte, args [][]byte, signature []byte) (lsig types.LogicSig, err error) {
+	if len(signature) != len(lsig.Sig) {
+		err = fmt.Errorf("signature has length %d, expected %d", len(signature), len(lsig.Sig))
+		return
+	}
+	lsig.Logic = program
+	lsig.Args = args
+	copy(lsig.Sig[:], signature)
 	return
 }
 
@@ -197,11 +245,11 @@ func (kcl Client) SignTransaction(walletHandle, walletPassword string, tx types.
 // A preimage is the information needed to reconstruct this multisig address,
 // including multisig version information, threshold information, and a list
 // of public keys.
-func (kcl Client) ListMultisig(walletHandle string) (resp ListMultisigResponse, err error) {
+func (kcl Client) ListMultisig(ctx context.Context, walletHandle string) (resp ListMultisigResponse, err error) {
 	req := ListMultisigRequest{
 		WalletHandleToken: walletHandle,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
@@ -209,14 +257,14 @@ func (kcl Client) ListMultisig(walletHandle string) (resp ListMultisigResponse,
 // generate a multisig address. It derives this address, and stores all of the
 // information within the wallet. It returns a ImportMultisigResponse with the
 // derived address.
-func (kcl Client) ImportMultisig(walletHandle string, version, threshold uint8, pks []ed25519.PublicKey) (resp ImportMultisigResponse, err error) {
+func (kcl Client) ImportMultisig(ctx context.Context, walletHandle string, version, threshold uint8, pks []ed25519.PublicKey) (resp ImportMultisigResponse, err error) {
 	req := ImportMultisigRequest{
 		WalletHandleToken: walletHandle,
 		Version:           version,
 		Threshold:         threshold,
 		PKs:               pks,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
@@ -225,25 +273,25 @@ func (kcl Client) ImportMultisig(walletHandle string, version, threshold uint8,
 // multisig preimage. The preimage contains all of the information necessary
 // to derive the multisig address, including version, threshold, and a list of
 // public keys.
-func (kcl Client) ExportMultisig(walletHandle, walletPassword, addr string) (resp ExportMultisigResponse, err error) {
+func (kcl Client) ExportMultisig(ctx context.Context, walletHandle, walletPassword, addr string) (resp ExportMultisigResponse, err error) {
 	req := ExportMultisigRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Address:           addr,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
 // DeleteMultisig accepts a wallet handle, wallet password, and address, and deletes
 // the information about this multisig address from the wallet.
-func (kcl Client) DeleteMultisig(walletHandle, walletPassword, addr string) (resp DeleteMultisigResponse, err error) {
+func (kcl Client) DeleteMultisig(ctx context.Context, walletHandle, walletPassword, addr string) (resp DeleteMultisigResponse, err error) {
 	req := DeleteMultisigRequest{
 		WalletHandleToken: walletHandle,
 		WalletPassword:    walletPassword,
 		Address:           addr,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
 	return
 }
 
@@ -252,7 +300,7 @@ func (kcl Client) DeleteMultisig(walletHandle, walletPassword, addr string) (res
 // MultisigSig. It looks up the secret key corresponding to the public key, and
 // returns a SignMultisigTransactionResponse containing a MultisigSig with a
 // signature by the secret key included.
-func (kcl Client) MultisigSignTransaction(walletHandle, walletPassword string, tx types.Transaction, pk ed25519.PublicKey, partial types.MultisigSig) (resp SignMultisigTransactionResponse, err error) {
+func (kcl Client) MultisigSignTransaction(ctx context.Context, walletHandle, walletPassword string, tx types.Transaction, pk ed25519.PublicKey, partial types.MultisigSig) (resp SignMultisigTransactionResponse, err error) {
 	txBytes := msgpack.Encode(tx)
 	req := SignMultisigTransactionRequest{
 		WalletHandleToken: walletHandle,
@@ -261,6 +309,41 @@ func (kcl Client) MultisigSignTransaction(walletHandle, walletPassword string, t
 		PublicKey:         pk,
 		PartialMsig:       partial,
 	}
-	err = kcl.DoV1Request(req, &resp)
+	err = kcl.DoV1Request(ctx, req, &resp)
+	return
+}
+
+// MultisigSignProgram accepts a wallet handle, wallet password, multisig
+// address, TEAL program, public key (*not* an address) of one of the
+// multisig's keys, and an optional partial MultisigSig. It looks up the secret
+// key corresponding to the public key, and returns a
+// SignProgramMultisigResponse containing a msgpack encoded MultisigSig of the
+// program with a signature by the secret key included. Once enough keys have
+// signed, the program and the MultisigSig form a delegated LogicSig of the
+// multisig account, see MakeLogicSigFromMultisig.
+func (kcl Client) MultisigSignProgram(ctx context.Context, walletHandle, walletPassword, addr string, program []byte, pk ed25519.PublicKey, partial types.MultisigSig) (resp SignProgramMultisigResponse, err error) {
+	req := SignProgramMultisigRequest{
+		WalletHandleToken: walletHandle,
+		WalletPassword:    walletPassword,
+		Address:           addr,
+		Program:           program,
+		PublicKey:         pk,
+		PartialMsig:       partial,
+	}
+	err = kcl.DoV1Request(ctx, req, &resp)
+	return
+}
+
+// MakeLogicSigFromMultisig builds a delegated LogicSig from a program and the
+// msgpack encoded MultisigSig returned by MultisigSignProgram. Its Msig can be
+// passed as the partial MultisigSig of the next signer.
+func MakeLogicSigFromMultisig(program []byte, args [][]byte, multisig []byte) (lsig types.LogicSig, err error) {
+	var msig types.MultisigSig
+	if err = msgpack.Decode(multisig, &msig); err != nil {
+		return
+	}
+	lsig.Logic = program
+	lsig.Args = args
+	lsig.Msig = msig
 	return
 }
@@ -0,0 +1,189 @@
+// Package retry implements the retry policies of the REST clients: requests
+// that fail because the server is overloaded, rate limited or unreachable are
+// retried after an exponentially growing, jittered backoff.
+package retry
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Policy says which requests are retried, how many times and how long to wait
+// between attempts. The zero Policy does not retry.
+type Policy struct {
+	// MaxAttempts is the number of times a request is sent, including the
+	// first; 0 and 1 do not retry
+	MaxAttempts int
+
+	// InitialBackoff is the wait before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait between attempts, including waits asked for by
+	// the server with a Retry-After header, or 0 not to cap it
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor the wait grows by after each retry, or 0 for 2
+	Multiplier float64
+
+	// Jitter is the fraction, between 0 and 1, by which each wait is randomly
+	// lengthened or shortened, so that clients do not retry in lockstep
+	Jitter float64
+
+	// RetryOn says whether a response with the given status code is retried,
+	// or nil to retry 429 Too Many Requests and 5xx server errors other than
+	// 501 Not Implemented. Requests of idempotent methods that fail without a
+	// response, e.g. because the connection was refused, are always retried.
+	//
+	// Requests of other methods, such as the POST submitting a transaction,
+	// may have been acted on by a server that failed to answer them, so they
+	// are only retried on 429 Too Many Requests and 503 Service Unavailable,
+	// which are answered before acting on a request, unless their method is
+	// in RetryMethods.
+	RetryOn func(statusCode int) bool
+
+	// RetryMethods are the methods, besides the idempotent GET, HEAD, PUT,
+	// DELETE, OPTIONS and TRACE, whose requests are retried on any failure,
+	// e.g. "POST" for a node behind a proxy that is known to reject requests
+	// without forwarding them
+	RetryMethods []string
+}
+
+// DefaultPolicy returns a policy sending requests up to 4 times, waiting about
+// 0.5, 1 and 2 seconds between attempts
+func DefaultPolicy() Policy {
+	return Policy{
+		MaxAttempts:    4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// retryable is the default RetryOn
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests ||
+		statusCode >= 500 && statusCode != http.StatusNotImplemented
+}
+
+// onlyBeforeActing is whether a response with the given status code says the
+// server did not act on the request, so that any request can be retried
+func onlyBeforeActing(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// idempotent is whether sending a request of the given method more than once
+// has the same effect as sending it once
+func (p Policy) idempotent(method string) bool {
+	switch method {
+	case "", http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	for _, m := range p.RetryMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff returns the wait before the given retry, counting from 1, without
+// jitter
+func (p Policy) Backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	backoff := float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	if backoff > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(backoff)
+}
+
+// jitter randomly lengthens or shortens d by up to the policy's Jitter
+func (p Policy) jitter(d time.Duration) time.Duration {
+	if p.Jitter <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) * (1 + p.Jitter*(2*rand.Float64()-1)))
+}
+
+// Do sends the request made by newRequest with httpClient, making a new
+// request for each attempt so that its body can be sent again, until a
+// response is not retried by the policy, see RetryOn, or its attempts are used
+// up. The last
+// response or error is returned, unless ctx is done while waiting to retry.
+func (p Policy) Do(ctx context.Context, httpClient *http.Client, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	retryOn := p.RetryOn
+	if retryOn == nil {
+		retryOn = retryable
+	}
+	for attempt := 1; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := httpClient.Do(req.WithContext(ctx))
+		if attempt >= p.MaxAttempts || ctx.Err() != nil {
+			return resp, err
+		}
+		idempotent := p.idempotent(req.Method)
+		if err != nil && !idempotent {
+			return resp, err
+		}
+		if err == nil && (!retryOn(resp.StatusCode) || !idempotent && !onlyBeforeActing(resp.StatusCode)) {
+			return resp, nil
+		}
+
+		wait := p.jitter(p.Backoff(attempt))
+		if resp != nil {
+			if after, ok := retryAfter(resp); ok {
+				wait = after
+				if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+					wait = p.MaxBackoff
+				}
+			}
+			// drain the body so the connection can be reused
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// retryAfter returns the wait asked for by a Retry-After header, in seconds
+// or as an HTTP date
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	header := resp.Header.Get("Retry-After")
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
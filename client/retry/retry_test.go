@@ -0,0 +1,131 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoff(t *testing.T) {
+	p := Policy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	require.Equal(t, time.Second, p.Backoff(1))
+	require.Equal(t, 2*time.Second, p.Backoff(2))
+	require.Equal(t, 4*time.Second, p.Backoff(3))
+	require.Equal(t, 5*time.Second, p.Backoff(4))
+
+	p = Policy{InitialBackoff: time.Second, Multiplier: 3, Jitter: 0.5}
+	require.Equal(t, 9*time.Second, p.Backoff(3))
+	for i := 0; i < 100; i++ {
+		d := p.jitter(time.Second)
+		require.True(t, d >= 500*time.Millisecond && d <= 1500*time.Millisecond)
+	}
+}
+
+func TestDo(t *testing.T) {
+	var statuses []int
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		status := statuses[0]
+		statuses = statuses[1:]
+		if status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "0")
+		}
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+	method := "POST"
+	newRequest := func() (*http.Request, error) {
+		return http.NewRequest(method, server.URL, bytes.NewReader([]byte("body")))
+	}
+	p := Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	// overloaded and rate limited requests are retried with their body
+	statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}
+	resp, err := p.Do(context.Background(), http.DefaultClient, newRequest)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, []string{"body", "body", "body"}, bodies)
+
+	// a POST the server may have acted on is not sent again
+	bodies = nil
+	statuses = []int{http.StatusInternalServerError, http.StatusOK}
+	resp, err = p.Do(context.Background(), http.DefaultClient, newRequest)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	require.Equal(t, []string{"body"}, bodies)
+
+	// unless the policy retries POSTs
+	statuses = []int{http.StatusInternalServerError, http.StatusOK}
+	resp, err = Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryMethods: []string{"POST"}}.Do(context.Background(), http.DefaultClient, newRequest)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// idempotent requests are retried until the attempts are used up
+	method = "GET"
+	statuses = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusOK}
+	resp, err = p.Do(context.Background(), http.DefaultClient, newRequest)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	require.Len(t, statuses, 1)
+
+	// other errors are not retried
+	statuses = []int{http.StatusBadRequest, http.StatusOK}
+	resp, err = p.Do(context.Background(), http.DefaultClient, newRequest)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// nor is anything by the zero policy
+	statuses = []int{http.StatusServiceUnavailable, http.StatusOK}
+	resp, err = Policy{}.Do(context.Background(), http.DefaultClient, newRequest)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	// waits end when the context is done
+	statuses = []int{http.StatusServiceUnavailable, http.StatusOK}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = Policy{MaxAttempts: 2, InitialBackoff: time.Minute}.Do(ctx, http.DefaultClient, newRequest)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestDoConnectionError(t *testing.T) {
+	// a server that closes each connection without answering
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	defer server.Close()
+	p := Policy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	newRequest := func() (*http.Request, error) {
+		return http.NewRequest("POST", server.URL, bytes.NewReader([]byte("body")))
+	}
+	_, err := p.Do(context.Background(), http.DefaultClient, newRequest)
+	require.Error(t, err)
+	require.Equal(t, 1, requests)
+
+	requests = 0
+	newRequest = func() (*http.Request, error) {
+		return http.NewRequest("GET", server.URL, nil)
+	}
+	_, err = p.Do(context.Background(), http.DefaultClient, newRequest)
+	require.Error(t, err)
+	require.Equal(t, 3, requests)
+}
@@ -0,0 +1,97 @@
+package algod
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// AccountInformation gets the account information of an address
+type AccountInformation struct {
+	c       *Client
+	address string
+	p       formatParams
+}
+
+// Format sets the encoding of the response, FormatJSON by default; the
+// response decodes into the same model either way
+func (s *AccountInformation) Format(format ResponseFormat) *AccountInformation {
+	s.p.Format = format
+	return s
+}
+
+// Do performs the HTTP request
+func (s *AccountInformation) Do(ctx context.Context, headers ...*common.Header) (response models.Account, err error) {
+	err = s.c.getFormatted(ctx, &response, fmt.Sprintf("/v2/accounts/%s", common.EscapeParams(s.address)...), s.p, headers)
+	return
+}
+
+// GetAssetByID gets the parameters of an asset
+type GetAssetByID struct {
+	c          *Client
+	assetIndex uint64
+}
+
+// Do performs the HTTP request
+func (s *GetAssetByID) Do(ctx context.Context, headers ...*common.Header) (response models.Asset, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/assets/%d", s.assetIndex), nil, headers)
+	return
+}
+
+// GetApplicationByID gets the parameters of an application
+type GetApplicationByID struct {
+	c                *Client
+	applicationIndex uint64
+}
+
+// Do performs the HTTP request
+func (s *GetApplicationByID) Do(ctx context.Context, headers ...*common.Header) (response models.Application, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/applications/%d", s.applicationIndex), nil, headers)
+	return
+}
+
+// boxParams are the query parameters of the box endpoints
+type boxParams struct {
+	Name string `url:"name,omitempty"`
+	Max  uint64 `url:"max,omitempty"`
+}
+
+// boxName encodes a box name as the box endpoints expect it
+func boxName(name []byte) string {
+	return "b64:" + base64.StdEncoding.EncodeToString(name)
+}
+
+// GetApplicationBoxByName gets a box of an application, with its value
+type GetApplicationBoxByName struct {
+	c                *Client
+	applicationIndex uint64
+	p                boxParams
+}
+
+// Do performs the HTTP request
+func (s *GetApplicationBoxByName) Do(ctx context.Context, headers ...*common.Header) (response models.Box, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/applications/%d/box", s.applicationIndex), s.p, headers)
+	return
+}
+
+// GetApplicationBoxes gets the names of the boxes of an application
+type GetApplicationBoxes struct {
+	c                *Client
+	applicationIndex uint64
+	p                boxParams
+}
+
+// Max truncates the number of box names returned. If max is 0, all are returned.
+func (s *GetApplicationBoxes) Max(max uint64) *GetApplicationBoxes {
+	s.p.Max = max
+	return s
+}
+
+// Do performs the HTTP request
+func (s *GetApplicationBoxes) Do(ctx context.Context, headers ...*common.Header) (response models.BoxesResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/applications/%d/boxes", s.applicationIndex), s.p, headers)
+	return
+}
@@ -0,0 +1,344 @@
+// Package algod is a client for the algod v2 REST API.
+//
+// Each endpoint is exposed as a request builder: optional parameters are set
+// with chained methods and the request is sent by Do, which takes a
+// context.Context so that requests can be cancelled or given a deadline:
+//
+//	status, err := client.StatusAfterBlock(round).Do(ctx)
+//
+// A Client is safe for concurrent use by multiple goroutines, but the request
+// builders it returns are not: make one for each request. Services sending
+// many transactions concurrently should size the Client's connection pool
+// with common.WithConnectionPool.
+package algod
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+)
+
+const authHeader = "X-Algo-API-Token"
+
+// Client manages the REST interface for a calling user.
+type Client common.Client
+
+// get performs a GET request to the specific path against the server
+func (c *Client) get(ctx context.Context, response interface{}, path string, params interface{}, headers []*common.Header) error {
+	return (*common.Client)(c).Get(ctx, response, path, params, headers)
+}
+
+// getRaw performs a GET request to the specific path against the server and returns the raw body bytes
+func (c *Client) getRaw(ctx context.Context, path string, params interface{}, headers []*common.Header) ([]byte, error) {
+	return (*common.Client)(c).GetRaw(ctx, path, params, headers)
+}
+
+// getMsgpack performs a GET request and decodes the msgpack response into response
+func (c *Client) getMsgpack(ctx context.Context, response interface{}, path string, params interface{}, headers []*common.Header) error {
+	body, err := c.getRaw(ctx, path, params, headers)
+	if err != nil {
+		return err
+	}
+	return msgpack.LenientDecode(body, response)
+}
+
+// ResponseFormat is the encoding of a response, for the endpoints that can
+// respond in either JSON or msgpack
+type ResponseFormat string
+
+const (
+	// FormatJSON is the default encoding of responses
+	FormatJSON ResponseFormat = "json"
+
+	// FormatMsgpack encodes responses in msgpack, whose integers keep their
+	// precision through tools that read JSON numbers as float64
+	FormatMsgpack ResponseFormat = "msgpack"
+)
+
+// formatParams are the query parameters of endpoints that can respond in
+// msgpack
+type formatParams struct {
+	Format ResponseFormat `url:"format,omitempty"`
+}
+
+// getFormatted performs a GET request, decoding the response into response
+// from the format requested by params
+func (c *Client) getFormatted(ctx context.Context, response interface{}, path string, params formatParams, headers []*common.Header) error {
+	if params.Format == FormatMsgpack {
+		return c.getMsgpack(ctx, response, path, params, headers)
+	}
+	return c.get(ctx, response, path, params, headers)
+}
+
+// post sends a POST request to the given path with the given body
+func (c *Client) post(ctx context.Context, response interface{}, path string, params interface{}, headers []*common.Header, body interface{}) error {
+	return (*common.Client)(c).Post(ctx, response, path, params, headers, body)
+}
+
+// delete sends a DELETE request to the given path
+func (c *Client) delete(ctx context.Context, response interface{}, path string, params interface{}, headers []*common.Header) error {
+	return (*common.Client)(c).Delete(ctx, response, path, params, headers)
+}
+
+// postMsgpack sends a POST request with the given body and decodes the msgpack response into response
+func (c *Client) postMsgpack(ctx context.Context, response interface{}, path string, params interface{}, headers []*common.Header, body interface{}) error {
+	respBody, err := (*common.Client)(c).PostRaw(ctx, path, params, headers, body)
+	if err != nil {
+		return err
+	}
+	return msgpack.LenientDecode(respBody, response)
+}
+
+// MakeClient is the factory for constructing a Client for a given endpoint.
+func MakeClient(address string, apiToken string) (c *Client, err error) {
+	commonClient, err := common.MakeClient(address, authHeader, apiToken)
+	c = (*Client)(commonClient)
+	return
+}
+
+// MakeClientWithHeaders is the factory for constructing a Client for a given endpoint with additional user defined headers.
+func MakeClientWithHeaders(address string, apiToken string, headers []*common.Header) (c *Client, err error) {
+	commonClient, err := common.MakeClientWithHeaders(address, authHeader, apiToken, headers)
+	c = (*Client)(commonClient)
+	return
+}
+
+// MakeClientWithOptions is the factory for constructing a Client for a given endpoint, configured by options,
+// e.g. common.WithRetryPolicy.
+func MakeClientWithOptions(address string, apiToken string, options ...common.ClientOption) (c *Client, err error) {
+	commonClient, err := common.MakeClientWithOptions(address, authHeader, apiToken, options...)
+	c = (*Client)(commonClient)
+	return
+}
+
+// HealthCheck returns OK if healthy
+func (c *Client) HealthCheck() *HealthCheck {
+	return &HealthCheck{c: c}
+}
+
+// Ready checks that the node is caught up and ready to serve requests
+func (c *Client) Ready() *Ready {
+	return &Ready{c: c}
+}
+
+// GetGenesis gets the genesis file of the node's network
+func (c *Client) GetGenesis() *GetGenesis {
+	return &GetGenesis{c: c}
+}
+
+// Versions retrieves the supported API versions, binary build versions, and genesis information
+func (c *Client) Versions() *Versions {
+	return &Versions{c: c}
+}
+
+// Status gets the current node status
+func (c *Client) Status() *Status {
+	return &Status{c: c}
+}
+
+// StatusAfterBlock waits for a block to appear after round and returns the node's status at the time
+func (c *Client) StatusAfterBlock(round uint64) *StatusAfterBlock {
+	return &StatusAfterBlock{c: c, round: round}
+}
+
+// ConsensusParams gets the parameters of the consensus version the node will
+// evaluate new transactions under
+func (c *Client) ConsensusParams() *ConsensusParams {
+	return &ConsensusParams{c: c}
+}
+
+// Supply gets the current supply reported by the ledger
+func (c *Client) Supply() *Supply {
+	return &Supply{c: c}
+}
+
+// AccountInformation gets the account information of address
+func (c *Client) AccountInformation(address string) *AccountInformation {
+	return &AccountInformation{c: c, address: address}
+}
+
+// GetAssetByID gets the parameters of asset assetIndex
+func (c *Client) GetAssetByID(assetIndex uint64) *GetAssetByID {
+	return &GetAssetByID{c: c, assetIndex: assetIndex}
+}
+
+// GetApplicationByID gets the parameters of application applicationIndex
+func (c *Client) GetApplicationByID(applicationIndex uint64) *GetApplicationByID {
+	return &GetApplicationByID{c: c, applicationIndex: applicationIndex}
+}
+
+// GetApplicationBoxByName gets the box of application applicationIndex named name
+func (c *Client) GetApplicationBoxByName(applicationIndex uint64, name []byte) *GetApplicationBoxByName {
+	return &GetApplicationBoxByName{c: c, applicationIndex: applicationIndex, p: boxParams{Name: boxName(name)}}
+}
+
+// GetApplicationBoxes gets the names of the boxes of application applicationIndex
+func (c *Client) GetApplicationBoxes(applicationIndex uint64) *GetApplicationBoxes {
+	return &GetApplicationBoxes{c: c, applicationIndex: applicationIndex}
+}
+
+// Block gets the block for the given round
+func (c *Client) Block(round uint64) *Block {
+	return &Block{c: c, round: round}
+}
+
+// BlockRaw gets the msgpack encoded block for the given round, which decodes
+// into a types.EncodedBlockCert
+func (c *Client) BlockRaw(round uint64) *BlockRaw {
+	return &BlockRaw{c: c, round: round}
+}
+
+// GetTransactionProof gets the proof that the transaction with ID txid is
+// committed to by the header of the block of the given round
+func (c *Client) GetTransactionProof(round uint64, txid string) *GetTransactionProof {
+	return &GetTransactionProof{c: c, round: round, txid: txid}
+}
+
+// PendingTransactions gets a snapshot of the transactions in the node's pool
+func (c *Client) PendingTransactions() *PendingTransactions {
+	return &PendingTransactions{c: c}
+}
+
+// PendingTransactionsByAddress gets the pending transactions sent by or to address
+func (c *Client) PendingTransactionsByAddress(address string) *PendingTransactionsByAddress {
+	return &PendingTransactionsByAddress{c: c, address: address}
+}
+
+// PendingTransactionInformation gets information about a recently submitted transaction
+func (c *Client) PendingTransactionInformation(txid string) *PendingTransactionInformation {
+	return &PendingTransactionInformation{c: c, txid: txid}
+}
+
+// SendRawTransaction broadcasts raw signed transaction bytes, either a single
+// transaction or a concatenated group, to the network
+func (c *Client) SendRawTransaction(rawtxn []byte) *SendRawTransaction {
+	return &SendRawTransaction{c: c, rawtxn: rawtxn}
+}
+
+// SendTransactionGroup broadcasts a group of signed transactions, each given
+// as its own encoding, after checking that they form a valid group
+func (c *Client) SendTransactionGroup(stxs [][]byte) *SendTransactionGroup {
+	return &SendTransactionGroup{c: c, stxs: stxs}
+}
+
+// SuggestedParams gets the parameters for constructing a new transaction
+func (c *Client) SuggestedParams() *SuggestedParams {
+	return &SuggestedParams{c: c}
+}
+
+// TealCompile compiles TEAL source to program bytes
+func (c *Client) TealCompile(source []byte) *TealCompile {
+	return &TealCompile{c: c, source: source}
+}
+
+// TealDisassemble disassembles program bytes to TEAL source
+func (c *Client) TealDisassemble(program []byte) *TealDisassemble {
+	return &TealDisassemble{c: c, program: program}
+}
+
+// TealDryrun runs transactions against the ledger state in request, without submitting them
+func (c *Client) TealDryrun(request models.DryrunRequest) *TealDryrun {
+	return &TealDryrun{c: c, request: request}
+}
+
+// SimulateTransaction simulates transaction groups as they would be evaluated by the network
+func (c *Client) SimulateTransaction(request models.SimulateRequest) *SimulateTransaction {
+	return &SimulateTransaction{c: c, request: request}
+}
+
+// GetStateProof gets the state proof attesting to the given round
+func (c *Client) GetStateProof(round uint64) *GetStateProof {
+	return &GetStateProof{c: c, round: round}
+}
+
+// GetLightBlockHeaderProof gets the proof that the light block header of the
+// given round is committed to by the state proof attesting to it
+func (c *Client) GetLightBlockHeaderProof(round uint64) *GetLightBlockHeaderProof {
+	return &GetLightBlockHeaderProof{c: c, round: round}
+}
+
+// SetSyncRound sets the round a node in follower mode keeps its ledger at
+func (c *Client) SetSyncRound(round uint64) *SetSyncRound {
+	return &SetSyncRound{c: c, round: round}
+}
+
+// GetSyncRound gets the sync round of a node in follower mode
+func (c *Client) GetSyncRound() *GetSyncRound {
+	return &GetSyncRound{c: c}
+}
+
+// UnsetSyncRound unsets the sync round of a node in follower mode
+func (c *Client) UnsetSyncRound() *UnsetSyncRound {
+	return &UnsetSyncRound{c: c}
+}
+
+// GetLedgerStateDelta gets the changes the block of the given round made to
+// the ledger
+func (c *Client) GetLedgerStateDelta(round uint64) *GetLedgerStateDelta {
+	return &GetLedgerStateDelta{c: c, round: round}
+}
+
+// GetTransactionGroupLedgerStateDeltasForRound gets the changes each
+// transaction group of the given round made to the ledger
+func (c *Client) GetTransactionGroupLedgerStateDeltasForRound(round uint64) *GetTransactionGroupLedgerStateDeltasForRound {
+	return &GetTransactionGroupLedgerStateDeltasForRound{c: c, round: round}
+}
+
+// GetLedgerStateDeltaForTransactionGroup gets the changes the transaction
+// group with the given ID, or with a transaction of the given ID, made to the
+// ledger
+func (c *Client) GetLedgerStateDeltaForTransactionGroup(id string) *GetLedgerStateDeltaForTransactionGroup {
+	return &GetLedgerStateDeltaForTransactionGroup{c: c, id: id}
+}
+
+// GetParticipationKeys lists the participation keys installed on the node. The
+// participation requests need the node's admin API token.
+func (c *Client) GetParticipationKeys() *GetParticipationKeys {
+	return &GetParticipationKeys{c: c}
+}
+
+// GetParticipationKeyByID gets the participation key with the given ID
+func (c *Client) GetParticipationKeyByID(participationID string) *GetParticipationKeyByID {
+	return &GetParticipationKeyByID{c: c, id: participationID}
+}
+
+// AddParticipationKey installs the participation key of a participation key
+// file on the node
+func (c *Client) AddParticipationKey(keyFile []byte) *AddParticipationKey {
+	return &AddParticipationKey{c: c, keyFile: keyFile}
+}
+
+// AppendKeys adds msgpack encoded ephemeral voting keys to the participation
+// key with the given ID
+func (c *Client) AppendKeys(participationID string, keys []byte) *AppendKeys {
+	return &AppendKeys{c: c, id: participationID, keys: keys}
+}
+
+// DeleteParticipationKeyByID deletes the participation key with the given ID
+func (c *Client) DeleteParticipationKeyByID(participationID string) *DeleteParticipationKeyByID {
+	return &DeleteParticipationKeyByID{c: c, id: participationID}
+}
+
+// GenerateParticipationKeys makes the node generate and install a
+// participation key for address, valid from round first to round last
+func (c *Client) GenerateParticipationKeys(address string, first, last uint64) *GenerateParticipationKeys {
+	return &GenerateParticipationKeys{c: c, address: address, p: generateParticipationKeysParams{First: first, Last: last}}
+}
+
+// StartCatchup makes the node catch up fast to the given catchpoint. The
+// catchup and shutdown requests need the node's admin API token.
+func (c *Client) StartCatchup(catchpoint string) *StartCatchup {
+	return &StartCatchup{c: c, catchpoint: catchpoint}
+}
+
+// AbortCatchup stops the node's catchup to the given catchpoint
+func (c *Client) AbortCatchup(catchpoint string) *AbortCatchup {
+	return &AbortCatchup{c: c, catchpoint: catchpoint}
+}
+
+// Shutdown makes the node shut down
+func (c *Client) Shutdown() *Shutdown {
+	return &Shutdown{c: c}
+}
@@ -0,0 +1,523 @@
+package algod
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/logic"
+	"github.com/algorand/go-algorand-sdk/protocol"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestResponseFormat(t *testing.T) {
+	const addr = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	// above 2^53, so a float64 would round it
+	account := models.Account{
+		Address: addr,
+		Amount:  1<<53 + 1,
+		Assets:  []models.AssetHolding{{AssetID: 7, Amount: 1<<64 - 1}},
+		Status:  "Offline",
+	}
+	var formats []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := r.URL.Query().Get("format")
+		formats = append(formats, format)
+		switch r.URL.Path {
+		case "/v2/accounts/" + addr:
+			if format == "msgpack" {
+				w.Write(msgpack.Encode(account))
+			} else {
+				w.Write(json.Encode(account))
+			}
+		case "/v2/blocks/5":
+			block := models.BlockResponse{Block: map[string]interface{}{"rnd": uint64(5)}}
+			if format == "msgpack" {
+				w.Write(msgpack.Encode(block))
+			} else {
+				w.Write(json.Encode(block))
+			}
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	for _, format := range []ResponseFormat{FormatJSON, FormatMsgpack} {
+		got, err := client.AccountInformation(addr).Format(format).Do(context.Background())
+		require.NoError(t, err, format)
+		require.Equal(t, account, got, format)
+	}
+	got, err := client.AccountInformation(addr).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, account, got)
+
+	block, err := client.Block(5).Format(FormatMsgpack).Do(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 5, block.Block["rnd"])
+
+	require.Equal(t, []string{"json", "msgpack", "", "msgpack"}, formats)
+}
+
+func TestSendTransactionGroup(t *testing.T) {
+	account := crypto.GenerateAccount()
+	sender := account.Address.String()
+	gh := make([]byte, 32)
+	var txns []types.Transaction
+	for amount := uint64(1); amount <= 3; amount++ {
+		txn, err := transaction.MakePaymentTxnWithFlatFee(sender, sender, 1000, amount, 1, 100, nil, "", "", gh)
+		require.NoError(t, err)
+		txns = append(txns, txn)
+	}
+	grouped, err := transaction.AssignGroupID(txns, "")
+	require.NoError(t, err)
+	sign := func(txns []types.Transaction) [][]byte {
+		var stxs [][]byte
+		for _, txn := range txns {
+			_, stx, err := crypto.SignTransaction(account.PrivateKey, txn)
+			require.NoError(t, err)
+			stxs = append(stxs, stx)
+		}
+		return stxs
+	}
+	stxs := sign(grouped)
+
+	var sent [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/transactions", r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		sent = append(sent, body)
+		w.Write([]byte(`{"txId":"` + crypto.GetTxID(grouped[0]) + `"}`))
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	txid, err := client.SendTransactionGroup(stxs).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, crypto.GetTxID(grouped[0]), txid)
+	require.Equal(t, [][]byte{bytes.Join(stxs, nil)}, sent)
+
+	// a single ungrouped transaction
+	_, err = client.SendTransactionGroup(sign(txns[:1])).Do(context.Background())
+	require.NoError(t, err)
+
+	invalid := map[string][][]byte{
+		"empty":         nil,
+		"reordered":     {stxs[1], stxs[0], stxs[2]},
+		"incomplete":    stxs[:2],
+		"ungrouped":     sign(txns),
+		"mixed":         {stxs[0], stxs[1], sign(txns[2:])[0]},
+		"concatenated":  {bytes.Join(stxs[:2], nil), stxs[2]},
+		"not a txn":     {[]byte("hello")},
+		"too many txns": make([][]byte, types.MaxTxGroupSize+1),
+	}
+	for name, group := range invalid {
+		_, err := client.SendTransactionGroup(group).Do(context.Background())
+		require.Error(t, err, name)
+	}
+	require.Len(t, sent, 2)
+}
+
+func TestPreflight(t *testing.T) {
+	// pc 0 on line 0, pc 1 on line 1, pc 4 on line 17 and pc 5 on line 16
+	sm, err := logic.DecodeSourceMap([]byte(`{"version":3,"sources":[],"names":[],"mappings":"AAAA;AACA;;;AAgBA;AADA"}`))
+	require.NoError(t, err)
+	program := []byte{0x06, 0x20, 0x01, 0x00, 0x22, 0x22}
+	lsigAddress := crypto.AddressFromProgram(program)
+
+	account := crypto.GenerateAccount()
+	txn, err := transaction.MakePaymentTxnWithFlatFee(account.Address.String(), account.Address.String(), 1000, 1, 1, 100, nil, "", "", make([]byte, 32))
+	require.NoError(t, err)
+	_, stx, err := crypto.SignTransaction(account.PrivateKey, txn)
+	require.NoError(t, err)
+	lsigStx := msgpack.Encode(types.SignedTxn{Txn: txn, Lsig: types.LogicSig{Logic: program}})
+
+	var failure models.SimulateTransactionGroupResult
+	var simulated []models.SimulateRequest
+	sent := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/transactions/simulate":
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			var request models.SimulateRequest
+			require.NoError(t, msgpack.Decode(body, &request))
+			simulated = append(simulated, request)
+			w.Write(msgpack.Encode(models.SimulateResponse{TxnGroups: []models.SimulateTransactionGroupResult{failure}}))
+		case "/v2/transactions":
+			sent++
+			w.Write([]byte(`{"txId":"` + crypto.GetTxID(txn) + `"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	preflight := common.Preflight{
+		SourceMaps:         map[uint64]logic.SourceMap{1002: sm},
+		LogicSigSourceMaps: map[string]logic.SourceMap{lsigAddress.String(): sm},
+	}
+	client, err := MakeClientWithOptions(server.URL, "", common.WithPreflight(preflight))
+	require.NoError(t, err)
+
+	// transactions that would succeed are simulated, then sent
+	txid, err := client.SendRawTransaction(append(stx, lsigStx...)).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, crypto.GetTxID(txn), txid)
+	require.Equal(t, 1, sent)
+	require.Len(t, simulated, 1)
+	require.Len(t, simulated[0].TxnGroups, 1)
+	require.Len(t, simulated[0].TxnGroups[0].Txns, 2)
+	require.Equal(t, program, simulated[0].TxnGroups[0].Txns[1].Lsig.Logic)
+
+	failures := []struct {
+		name     string
+		failure  models.SimulateTransactionGroupResult
+		expected PreflightError
+	}{
+		{
+			name: "app",
+			failure: models.SimulateTransactionGroupResult{
+				FailedAt:       []uint64{0},
+				FailureMessage: "transaction A: logic eval error: assert failed pc=4. Details: app=1002, pc=4, opcodes=intc_0",
+			},
+			expected: PreflightError{AppID: 1002, PC: 4, Line: 17},
+		},
+		{
+			name: "app without source map",
+			failure: models.SimulateTransactionGroupResult{
+				FailedAt:       []uint64{0, 1},
+				FailureMessage: "transaction A: logic eval error: assert failed pc=4. Details: app=1003, pc=4, opcodes=intc_0",
+			},
+			expected: PreflightError{AppID: 1003, PC: 4, Line: -1},
+		},
+		{
+			name: "logic signature",
+			failure: models.SimulateTransactionGroupResult{
+				FailedAt:       []uint64{1},
+				FailureMessage: "transaction B: rejected by logic err=assert failed pc=5. Details: pc=5, opcodes=intc_0",
+			},
+			expected: PreflightError{PC: 5, Line: 16},
+		},
+		{
+			name: "not a program",
+			failure: models.SimulateTransactionGroupResult{
+				FailedAt:       []uint64{0},
+				FailureMessage: "transaction A: overspend",
+			},
+			expected: PreflightError{PC: -1, Line: -1},
+		},
+	}
+	for _, test := range failures {
+		failure = test.failure
+		_, err := client.SendRawTransaction(append(stx, lsigStx...)).Do(context.Background())
+		require.Error(t, err, test.name)
+		preflightErr, ok := err.(*PreflightError)
+		require.True(t, ok, test.name)
+		expected := test.expected
+		expected.Message = test.failure.FailureMessage
+		expected.FailedAt = test.failure.FailedAt
+		require.Equal(t, expected, *preflightErr, test.name)
+	}
+	require.Equal(t, 1, sent)
+	require.Contains(t, (&PreflightError{Message: "x", Line: 16}).Error(), "line 17")
+	message := failures[0].failure.FailureMessage
+	require.Equal(t, &common.LogicEvalError{PC: 4, Msg: message}, (&PreflightError{Message: message}).Unwrap())
+
+	// without the option, transactions are sent without simulating them
+	client, err = MakeClient(server.URL, "")
+	require.NoError(t, err)
+	_, err = client.SendRawTransaction(stx).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+	require.Len(t, simulated, 1+len(failures))
+}
+
+func TestValidation(t *testing.T) {
+	account := crypto.GenerateAccount()
+	makeStx := func(fee, firstRound, lastRound uint64) []byte {
+		txn, err := transaction.MakePaymentTxnWithFlatFee(account.Address.String(), account.Address.String(), fee, 1, firstRound, lastRound, nil, "", "", bytes.Repeat([]byte{1}, 32))
+		require.NoError(t, err)
+		// the constructor raises fees below the minimum, which are tested here
+		txn.Fee = types.MicroAlgos(fee)
+		_, stx, err := crypto.SignTransaction(account.PrivateKey, txn)
+		require.NoError(t, err)
+		return stx
+	}
+
+	sent := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/status":
+			w.Write(json.Encode(models.NodeStatusResponse{LastRound: 50, LastVersion: protocol.Future, NextVersion: protocol.Future, NextVersionRound: 51}))
+		case "/v2/transactions":
+			sent++
+			w.Write([]byte(`{"txId":"A"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := MakeClientWithOptions(server.URL, "", common.WithValidation())
+	require.NoError(t, err)
+	_, err = client.SendRawTransaction(makeStx(1000, 1, 100)).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+
+	// a group pays the minimum fee of its transactions together
+	_, err = client.SendRawTransaction(append(makeStx(2000, 1, 100), makeStx(0, 1, 100)...)).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+
+	invalid := map[string][]byte{
+		"low fee":     makeStx(999, 1, 100),
+		"low fees":    append(makeStx(1000, 1, 100), makeStx(0, 1, 100)...),
+		"long window": makeStx(1000, 1, 2000),
+		"expired":     makeStx(1000, 1, 50),
+	}
+	for name, raw := range invalid {
+		_, err = client.SendRawTransaction(raw).Do(context.Background())
+		require.Error(t, err, name)
+	}
+	require.Equal(t, 2, sent)
+
+	// the node's last round is reported with the expired round
+	_, err = client.SendRawTransaction(invalid["expired"]).Do(context.Background())
+	require.EqualError(t, err, "transaction 0: last valid round 50 has passed, the node is at round 50")
+
+	// without the option, the node is left to reject them
+	client, err = MakeClient(server.URL, "")
+	require.NoError(t, err)
+	_, err = client.SendRawTransaction(invalid["low fee"]).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, sent)
+}
+
+func TestParticipationKeys(t *testing.T) {
+	type request struct {
+		method, path, query string
+		body                []byte
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests = append(requests, request{r.Method, r.URL.Path, r.URL.RawQuery, body})
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/v2/participation":
+			w.Write([]byte(`[{"address":"A","id":"K1","key":{"vote-first-valid":10,"vote-last-valid":1000,"vote-key-dilution":32},"effective-first-valid":12,"last-vote":20}]`))
+		case r.Method == "POST" && r.URL.Path == "/v2/participation":
+			w.Write([]byte(`{"partId":"K2"}`))
+		case r.URL.Path == "/v2/participation/generate/A":
+			w.Write([]byte(`"generating"`))
+		case r.Method == "DELETE":
+		default:
+			w.Write([]byte(`{"address":"A","id":"K1","key":{"vote-last-valid":2000}}`))
+		}
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	keys, err := client.GetParticipationKeys().Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, "K1", keys[0].ID)
+	require.Equal(t, uint64(1000), keys[0].Key.VoteLastValid)
+	require.Equal(t, uint64(12), keys[0].EffectiveFirstValid)
+	require.Equal(t, uint64(20), keys[0].LastVote)
+
+	key, err := client.GetParticipationKeyByID("K1").Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "A", key.Address)
+
+	partID, err := client.AddParticipationKey([]byte("keyfile")).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "K2", partID)
+
+	key, err = client.AppendKeys("K1", []byte("keys")).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2000), key.Key.VoteLastValid)
+
+	require.NoError(t, client.DeleteParticipationKeyByID("K1").Do(ctx))
+	require.NoError(t, client.GenerateParticipationKeys("A", 100, 3100).Dilution(55).Do(ctx))
+
+	require.Equal(t, []request{
+		{"GET", "/v2/participation", "", []byte{}},
+		{"GET", "/v2/participation/K1", "", []byte{}},
+		{"POST", "/v2/participation", "", []byte("keyfile")},
+		{"POST", "/v2/participation/K1", "", []byte("keys")},
+		{"DELETE", "/v2/participation/K1", "", []byte{}},
+		{"POST", "/v2/participation/generate/A", "dilution=55&first=100&last=3100", []byte{}},
+	}, requests)
+}
+
+func TestNodeAdministration(t *testing.T) {
+	const catchpoint = "30000#ABCD"
+	ready := false
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		switch r.URL.Path {
+		case "/ready":
+			if !ready {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		case "/genesis":
+			w.Write([]byte(`{"id":"v1","network":"testnet"}`))
+		case "/v2/catchup/" + catchpoint:
+			if r.Method == "POST" {
+				w.WriteHeader(http.StatusCreated)
+			}
+			w.Write([]byte(`{"catchup-message":"` + catchpoint + `"}`))
+		case "/v2/status":
+			w.Write([]byte(`{"catchpoint":"` + catchpoint + `","catchpoint-total-accounts":10,"catchpoint-processed-accounts":4,"last-round":5}`))
+		case "/v2/shutdown":
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	err = client.Ready().Do(ctx)
+	require.Error(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, err.(*common.HTTPError).Status)
+	ready = true
+	require.NoError(t, client.Ready().Do(ctx))
+
+	genesis, err := client.GetGenesis().Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":"v1","network":"testnet"}`, genesis)
+
+	started, err := client.StartCatchup(catchpoint).Min(1000).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, catchpoint, started.CatchupMessage)
+	status, err := client.Status().Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, catchpoint, status.Catchpoint)
+	require.Equal(t, uint64(4), status.CatchpointProcessedAccounts)
+	require.Equal(t, uint64(10), status.CatchpointTotalAccounts)
+	aborted, err := client.AbortCatchup(catchpoint).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, catchpoint, aborted.CatchupMessage)
+
+	require.NoError(t, client.Shutdown().Timeout(5).Do(ctx))
+
+	require.Equal(t, []string{
+		"GET /ready?", "GET /ready?", "GET /genesis?",
+		"POST /v2/catchup/30000%23ABCD?min=1000",
+		"GET /v2/status?",
+		"DELETE /v2/catchup/30000%23ABCD?",
+		"POST /v2/shutdown?timeout=5",
+	}, requests)
+}
+
+func TestRequests(t *testing.T) {
+	const addr = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	const txid = "TXID/1"
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath()+"?"+r.URL.RawQuery)
+		require.Equal(t, "token", r.Header.Get(authHeader))
+		require.Equal(t, []string{"request"}, r.Header["X-Trace"])
+		switch {
+		case r.URL.Path == "/v2/assets/404":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"asset does not exist"}`))
+		case r.URL.Query().Get("format") == "msgpack":
+			w.Write(msgpack.Encode(struct{}{}))
+		case r.URL.Path != "/health":
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+	client, err := MakeClientWithOptions(server.URL, "token", common.WithHeaders(&common.Header{Key: "X-Trace", Value: "client"}))
+	require.NoError(t, err)
+	ctx := context.Background()
+	header := &common.Header{Key: "X-Trace", Value: "request"}
+
+	require.NoError(t, client.HealthCheck().Do(ctx, header))
+	_, err = client.Status().Do(ctx, header)
+	require.NoError(t, err)
+	_, err = client.StatusAfterBlock(5).Do(ctx, header)
+	require.NoError(t, err)
+	_, err = client.AccountInformation(addr).Format(FormatMsgpack).Do(ctx, header)
+	require.NoError(t, err)
+	_, err = client.GetApplicationBoxes(7).Max(3).Do(ctx, header)
+	require.NoError(t, err)
+	_, err = client.Block(5).Do(ctx, header)
+	require.NoError(t, err)
+	_, err = client.GetTransactionProof(5, txid).HashType("sha256").Do(ctx, header)
+	require.NoError(t, err)
+	_, _, err = client.PendingTransactions().Max(3).Do(ctx, header)
+	require.NoError(t, err)
+	_, _, err = client.PendingTransactionsByAddress(addr).Max(2).Do(ctx, header)
+	require.NoError(t, err)
+	_, _, err = client.PendingTransactionInformation(txid).Do(ctx, header)
+	require.NoError(t, err)
+	_, err = client.TealCompile([]byte("int 1")).Sourcemap(true).Do(ctx, header)
+	require.NoError(t, err)
+
+	_, err = client.GetAssetByID(404).Do(ctx, header)
+	httpErr, ok := err.(*common.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusNotFound, httpErr.Status)
+	require.Equal(t, "asset does not exist", httpErr.Message())
+
+	require.Equal(t, []string{
+		"GET /health?",
+		"GET /v2/status?",
+		"GET /v2/status/wait-for-block-after/5?",
+		"GET /v2/accounts/" + addr + "?format=msgpack",
+		"GET /v2/applications/7/boxes?max=3",
+		"GET /v2/blocks/5?",
+		"GET /v2/blocks/5/transactions/TXID%2F1/proof?hashtype=sha256",
+		"GET /v2/transactions/pending?format=msgpack&max=3",
+		"GET /v2/accounts/" + addr + "/transactions/pending?format=msgpack&max=2",
+		"GET /v2/transactions/pending/TXID%2F1?format=msgpack",
+		"POST /v2/teal/compile?sourcemap=true",
+		"GET /v2/assets/404?",
+	}, requests)
+}
+
+func TestSyncRound(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		// setting and unsetting the sync round answer with an empty body
+		if r.Method == "GET" {
+			w.Write([]byte(`{"round":7}`))
+		}
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, client.SetSyncRound(7).Do(ctx))
+	sync, err := client.GetSyncRound().Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), sync.Round)
+	require.NoError(t, client.UnsetSyncRound().Do(ctx))
+	require.Equal(t, []string{"POST /v2/ledger/sync/7", "GET /v2/ledger/sync", "DELETE /v2/ledger/sync"}, requests)
+}
@@ -0,0 +1,70 @@
+package algod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// Block gets the block for a round
+type Block struct {
+	c     *Client
+	round uint64
+	p     formatParams
+}
+
+// Format sets the encoding of the response, FormatJSON by default; the
+// response decodes into the same model either way, though the block's byte
+// fields are base64 strings in JSON and []byte in msgpack
+func (s *Block) Format(format ResponseFormat) *Block {
+	s.p.Format = format
+	return s
+}
+
+// Do performs the HTTP request
+func (s *Block) Do(ctx context.Context, headers ...*common.Header) (response models.BlockResponse, err error) {
+	err = s.c.getFormatted(ctx, &response, fmt.Sprintf("/v2/blocks/%d", s.round), s.p, headers)
+	return
+}
+
+// BlockRaw gets the msgpack encoded block for a round, with its certificate,
+// which decodes into a types.EncodedBlockCert
+type BlockRaw struct {
+	c     *Client
+	round uint64
+}
+
+// Do performs the HTTP request
+func (s *BlockRaw) Do(ctx context.Context, headers ...*common.Header) (response []byte, err error) {
+	return s.c.getRaw(ctx, fmt.Sprintf("/v2/blocks/%d", s.round), formatParams{Format: FormatMsgpack}, headers)
+}
+
+// transactionProofParams are the query parameters of the transaction proof endpoint
+type transactionProofParams struct {
+	HashType string `url:"hashtype,omitempty"`
+}
+
+// GetTransactionProof gets the proof that a transaction is committed to by
+// the header of the block it was confirmed in
+type GetTransactionProof struct {
+	c     *Client
+	round uint64
+	txid  string
+	p     transactionProofParams
+}
+
+// HashType sets the hash of the commitment to prove against: sha512_256, the
+// default, for the block header's NativeSha512_256Commitment, or sha256 for
+// its Sha256Commitment
+func (s *GetTransactionProof) HashType(hashType string) *GetTransactionProof {
+	s.p.HashType = hashType
+	return s
+}
+
+// Do performs the HTTP request
+func (s *GetTransactionProof) Do(ctx context.Context, headers ...*common.Header) (response models.TransactionProofResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/blocks/%s/transactions/%s/proof", common.EscapeParams(s.round, s.txid)...), s.p, headers)
+	return
+}
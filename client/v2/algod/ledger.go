@@ -0,0 +1,84 @@
+package algod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// SetSyncRound sets the round a node in follower mode keeps its ledger at:
+// the node catches up to it and then waits, keeping the state deltas of the
+// rounds from it on, until the sync round is moved forward
+type SetSyncRound struct {
+	c     *Client
+	round uint64
+}
+
+// Do performs the HTTP request
+func (s *SetSyncRound) Do(ctx context.Context, headers ...*common.Header) error {
+	return s.c.post(ctx, nil, fmt.Sprintf("/v2/ledger/sync/%d", s.round), nil, headers, nil)
+}
+
+// GetSyncRound gets the sync round of a node in follower mode
+type GetSyncRound struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *GetSyncRound) Do(ctx context.Context, headers ...*common.Header) (response models.GetSyncRoundResponse, err error) {
+	err = s.c.get(ctx, &response, "/v2/ledger/sync", nil, headers)
+	return
+}
+
+// UnsetSyncRound unsets the sync round of a node in follower mode, so that it
+// follows the network without waiting
+type UnsetSyncRound struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *UnsetSyncRound) Do(ctx context.Context, headers ...*common.Header) error {
+	return s.c.delete(ctx, nil, "/v2/ledger/sync", nil, headers)
+}
+
+// GetLedgerStateDelta gets the changes the block of a round made to the
+// ledger, which a node in follower mode keeps from its sync round on
+type GetLedgerStateDelta struct {
+	c     *Client
+	round uint64
+}
+
+// Do performs the HTTP request
+func (s *GetLedgerStateDelta) Do(ctx context.Context, headers ...*common.Header) (response types.LedgerStateDelta, err error) {
+	err = s.c.getMsgpack(ctx, &response, fmt.Sprintf("/v2/deltas/%d", s.round), formatParams{Format: FormatMsgpack}, headers)
+	return
+}
+
+// GetTransactionGroupLedgerStateDeltasForRound gets the changes each
+// transaction group of a round made to the ledger
+type GetTransactionGroupLedgerStateDeltasForRound struct {
+	c     *Client
+	round uint64
+}
+
+// Do performs the HTTP request
+func (s *GetTransactionGroupLedgerStateDeltasForRound) Do(ctx context.Context, headers ...*common.Header) (response models.TransactionGroupLedgerStateDeltasForRoundResponse, err error) {
+	err = s.c.getMsgpack(ctx, &response, fmt.Sprintf("/v2/deltas/%d/txn/group", s.round), formatParams{Format: FormatMsgpack}, headers)
+	return
+}
+
+// GetLedgerStateDeltaForTransactionGroup gets the changes a transaction group
+// made to the ledger, by the ID of one of its transactions or by its group ID
+type GetLedgerStateDeltaForTransactionGroup struct {
+	c  *Client
+	id string
+}
+
+// Do performs the HTTP request
+func (s *GetLedgerStateDeltaForTransactionGroup) Do(ctx context.Context, headers ...*common.Header) (response types.LedgerStateDelta, err error) {
+	err = s.c.getMsgpack(ctx, &response, fmt.Sprintf("/v2/deltas/txn/group/%s", common.EscapeParams(s.id)...), formatParams{Format: FormatMsgpack}, headers)
+	return
+}
@@ -0,0 +1,71 @@
+package algod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// startCatchupParams are the query parameters of StartCatchup
+type startCatchupParams struct {
+	Min uint64 `url:"min,omitempty"`
+}
+
+// StartCatchup makes the node catch up fast to a catchpoint, a label such as
+// "30000#ABCD..." naming a round and the ledger state at it, downloading the
+// state instead of replaying each block. Status reports its progress.
+type StartCatchup struct {
+	c          *Client
+	catchpoint string
+	p          startCatchupParams
+}
+
+// Min makes the node catch up only if it is at least min rounds behind the
+// catchpoint, so that a node nearly caught up keeps replaying blocks
+func (s *StartCatchup) Min(min uint64) *StartCatchup {
+	s.p.Min = min
+	return s
+}
+
+// Do performs the HTTP request
+func (s *StartCatchup) Do(ctx context.Context, headers ...*common.Header) (response models.CatchpointStartResponse, err error) {
+	err = s.c.post(ctx, &response, fmt.Sprintf("/v2/catchup/%s", common.EscapeParams(s.catchpoint)...), s.p, headers, nil)
+	return
+}
+
+// AbortCatchup stops the node's catchup to a catchpoint
+type AbortCatchup struct {
+	c          *Client
+	catchpoint string
+}
+
+// Do performs the HTTP request
+func (s *AbortCatchup) Do(ctx context.Context, headers ...*common.Header) (response models.CatchpointAbortResponse, err error) {
+	err = s.c.delete(ctx, &response, fmt.Sprintf("/v2/catchup/%s", common.EscapeParams(s.catchpoint)...), nil, headers)
+	return
+}
+
+// shutdownParams are the query parameters of Shutdown
+type shutdownParams struct {
+	Timeout uint64 `url:"timeout,omitempty"`
+}
+
+// Shutdown makes the node shut down
+type Shutdown struct {
+	c *Client
+	p shutdownParams
+}
+
+// Timeout makes the node wait the given number of seconds before shutting
+// down, so that the response is sent first
+func (s *Shutdown) Timeout(seconds uint64) *Shutdown {
+	s.p.Timeout = seconds
+	return s
+}
+
+// Do performs the HTTP request
+func (s *Shutdown) Do(ctx context.Context, headers ...*common.Header) error {
+	return s.c.post(ctx, nil, "/v2/shutdown", s.p, headers, nil)
+}
@@ -0,0 +1,102 @@
+package algod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// GetParticipationKeys lists the participation keys installed on the node
+type GetParticipationKeys struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *GetParticipationKeys) Do(ctx context.Context, headers ...*common.Header) (response []models.ParticipationKey, err error) {
+	err = s.c.get(ctx, &response, "/v2/participation", nil, headers)
+	return
+}
+
+// GetParticipationKeyByID gets a participation key installed on the node
+type GetParticipationKeyByID struct {
+	c  *Client
+	id string
+}
+
+// Do performs the HTTP request
+func (s *GetParticipationKeyByID) Do(ctx context.Context, headers ...*common.Header) (response models.ParticipationKey, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/participation/%s", common.EscapeParams(s.id)...), nil, headers)
+	return
+}
+
+// AddParticipationKey installs a participation key on the node, from the
+// contents of a participation key file, such as those goal and algokey write
+type AddParticipationKey struct {
+	c       *Client
+	keyFile []byte
+}
+
+// Do performs the HTTP request, returning the ID of the installed key
+func (s *AddParticipationKey) Do(ctx context.Context, headers ...*common.Header) (partID string, err error) {
+	var response models.PostParticipationResponse
+	err = s.c.post(ctx, &response, "/v2/participation", nil, headers, s.keyFile)
+	partID = response.PartID
+	return
+}
+
+// AppendKeys adds the ephemeral voting keys of later rounds to a participation
+// key installed on the node
+type AppendKeys struct {
+	c    *Client
+	id   string
+	keys []byte
+}
+
+// Do performs the HTTP request
+func (s *AppendKeys) Do(ctx context.Context, headers ...*common.Header) (response models.ParticipationKey, err error) {
+	err = s.c.post(ctx, &response, fmt.Sprintf("/v2/participation/%s", common.EscapeParams(s.id)...), nil, headers, s.keys)
+	return
+}
+
+// DeleteParticipationKeyByID deletes a participation key from the node
+type DeleteParticipationKeyByID struct {
+	c  *Client
+	id string
+}
+
+// Do performs the HTTP request
+func (s *DeleteParticipationKeyByID) Do(ctx context.Context, headers ...*common.Header) error {
+	return s.c.delete(ctx, nil, fmt.Sprintf("/v2/participation/%s", common.EscapeParams(s.id)...), nil, headers)
+}
+
+// generateParticipationKeysParams are the query parameters of
+// GenerateParticipationKeys
+type generateParticipationKeysParams struct {
+	Dilution uint64 `url:"dilution,omitempty"`
+	First    uint64 `url:"first"`
+	Last     uint64 `url:"last"`
+}
+
+// GenerateParticipationKeys makes the node generate and install a
+// participation key for an account, valid from a first round to a last round
+type GenerateParticipationKeys struct {
+	c       *Client
+	address string
+	p       generateParticipationKeysParams
+}
+
+// Dilution sets the number of rounds of each batch of ephemeral voting keys;
+// by default the node uses the square root of the key's number of rounds
+func (s *GenerateParticipationKeys) Dilution(dilution uint64) *GenerateParticipationKeys {
+	s.p.Dilution = dilution
+	return s
+}
+
+// Do performs the HTTP request. The node generates the key in the background,
+// which can take minutes, and lists it among GetParticipationKeys once done.
+func (s *GenerateParticipationKeys) Do(ctx context.Context, headers ...*common.Header) error {
+	var response string
+	return s.c.post(ctx, &response, fmt.Sprintf("/v2/participation/generate/%s", common.EscapeParams(s.address)...), s.p, headers, nil)
+}
@@ -0,0 +1,106 @@
+package algod
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/logic"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// PreflightError is returned by SendRawTransaction, instead of sending the
+// transactions, when a client configured with common.WithPreflight simulates
+// them and finds they would fail
+type PreflightError struct {
+	// Message is the failure message of the simulation
+	Message string
+
+	// FailedAt is the path to the transaction that failed: its index in the
+	// group, followed by the indexes of any inner transactions leading to it
+	FailedAt []uint64
+
+	// AppID is the application whose program failed, or 0 if no application
+	// program failed, e.g. if a logic signature rejected the transaction
+	AppID uint64
+
+	// PC is the program counter the program failed at, or -1 if no program
+	// failed
+	PC int
+
+	// Line is the line of the program's TEAL source the program failed at,
+	// counted from 0 as in logic.SourceMap, or -1 if no source map of the
+	// program was given
+	Line int
+}
+
+func (e *PreflightError) Error() string {
+	if e.Line >= 0 {
+		return fmt.Sprintf("preflight simulation failed at line %d of the TEAL source: %s", e.Line+1, e.Message)
+	}
+	return fmt.Sprintf("preflight simulation failed: %s", e.Message)
+}
+
+// Unwrap returns the reason the transactions would fail, as
+// common.ParseNodeError does, e.g. a *common.LogicEvalError
+func (e *PreflightError) Unwrap() error {
+	return common.ParseNodeError(e.Message)
+}
+
+var failureAppRegexp = regexp.MustCompile(`\bapp=(\d+)`)
+
+// preflight simulates the concatenated signed transactions of rawtxn,
+// returning a *PreflightError if they would fail
+func (c *Client) preflight(ctx context.Context, preflight common.Preflight, rawtxn []byte, headers []*common.Header) error {
+	stxs, err := decodeSignedTxns(rawtxn)
+	if err != nil {
+		return err
+	}
+
+	request := models.SimulateRequest{
+		TxnGroups: []models.SimulateRequestTransactionGroup{{Txns: stxs}},
+	}
+	response, err := c.SimulateTransaction(request).Do(ctx, headers...)
+	if err != nil {
+		return fmt.Errorf("preflight simulation: %v", err)
+	}
+	if len(response.TxnGroups) == 0 || response.TxnGroups[0].FailureMessage == "" {
+		return nil
+	}
+	result := response.TxnGroups[0]
+	return preflightError(preflight, stxs, result.FailureMessage, result.FailedAt)
+}
+
+// preflightError decodes the failure message of a simulation of stxs, looking
+// up the source line of the failing program in the preflight's source maps
+func preflightError(preflight common.Preflight, stxs []types.SignedTxn, message string, failedAt []uint64) *PreflightError {
+	e := &PreflightError{Message: message, FailedAt: failedAt, PC: -1, Line: -1}
+	if logicErr, ok := common.ParseNodeError(message).(*common.LogicEvalError); ok {
+		e.PC = logicErr.PC
+	}
+	if app := failureAppRegexp.FindStringSubmatch(message); app != nil {
+		e.AppID, _ = strconv.ParseUint(app[1], 10, 64)
+	}
+	if e.PC < 0 {
+		return e
+	}
+
+	var sm logic.SourceMap
+	var ok bool
+	if e.AppID != 0 {
+		sm, ok = preflight.SourceMaps[e.AppID]
+	} else if len(failedAt) > 0 && failedAt[0] < uint64(len(stxs)) && len(stxs[failedAt[0]].Lsig.Logic) > 0 {
+		address := crypto.AddressFromProgram(stxs[failedAt[0]].Lsig.Logic).String()
+		sm, ok = preflight.LogicSigSourceMaps[address]
+	}
+	if ok {
+		if line, found := sm.PcToLine(e.PC); found {
+			e.Line = line
+		}
+	}
+	return e
+}
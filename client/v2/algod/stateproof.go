@@ -0,0 +1,34 @@
+package algod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// GetStateProof gets the state proof attesting to a round
+type GetStateProof struct {
+	c     *Client
+	round uint64
+}
+
+// Do performs the HTTP request
+func (s *GetStateProof) Do(ctx context.Context, headers ...*common.Header) (response models.StateProof, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/stateproofs/%d", s.round), nil, headers)
+	return
+}
+
+// GetLightBlockHeaderProof gets the proof that the light block header of a
+// round is committed to by the state proof attesting to it
+type GetLightBlockHeaderProof struct {
+	c     *Client
+	round uint64
+}
+
+// Do performs the HTTP request
+func (s *GetLightBlockHeaderProof) Do(ctx context.Context, headers ...*common.Header) (response models.LightBlockHeaderProof, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/blocks/%d/lightheader/proof", s.round), nil, headers)
+	return
+}
@@ -0,0 +1,107 @@
+package algod
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/protocol"
+)
+
+// HealthCheck checks that the node is up
+type HealthCheck struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *HealthCheck) Do(ctx context.Context, headers ...*common.Header) error {
+	return s.c.get(ctx, nil, "/health", nil, headers)
+}
+
+// Ready checks that the node is ready to serve requests: caught up with the
+// network and not catching up from a catchpoint
+type Ready struct {
+	c *Client
+}
+
+// Do performs the HTTP request, returning an error, a *common.HTTPError with
+// status 503, if the node is not ready
+func (s *Ready) Do(ctx context.Context, headers ...*common.Header) error {
+	return s.c.get(ctx, nil, "/ready", nil, headers)
+}
+
+// GetGenesis gets the genesis file of the node's network
+type GetGenesis struct {
+	c *Client
+}
+
+// Do performs the HTTP request, returning the JSON genesis file
+func (s *GetGenesis) Do(ctx context.Context, headers ...*common.Header) (response string, err error) {
+	err = s.c.get(ctx, &response, "/genesis", nil, headers)
+	return
+}
+
+// Versions retrieves the supported API versions, binary build versions, and genesis information
+type Versions struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *Versions) Do(ctx context.Context, headers ...*common.Header) (response models.Version, err error) {
+	err = s.c.get(ctx, &response, "/versions", nil, headers)
+	return
+}
+
+// Status gets the current node status
+type Status struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *Status) Do(ctx context.Context, headers ...*common.Header) (response models.NodeStatusResponse, err error) {
+	err = s.c.get(ctx, &response, "/v2/status", nil, headers)
+	return
+}
+
+// StatusAfterBlock waits for a block to appear after the given round and returns
+// the node's status at that time. The node holds the request open until then,
+// so callers will usually want a context with a deadline.
+type StatusAfterBlock struct {
+	c     *Client
+	round uint64
+}
+
+// Do performs the HTTP request
+func (s *StatusAfterBlock) Do(ctx context.Context, headers ...*common.Header) (response models.NodeStatusResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/status/wait-for-block-after/%d", s.round), nil, headers)
+	return
+}
+
+// ConsensusParams gets the parameters of the consensus version the node will
+// evaluate new transactions under, from its status, as protocol.ForStatus
+// selects them
+type ConsensusParams struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *ConsensusParams) Do(ctx context.Context, headers ...*common.Header) (params protocol.Params, err error) {
+	var status models.NodeStatusResponse
+	if status, err = s.c.Status().Do(ctx, headers...); err != nil {
+		return
+	}
+	params = protocol.ForStatus(status)
+	return
+}
+
+// Supply gets the current supply reported by the ledger
+type Supply struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *Supply) Do(ctx context.Context, headers ...*common.Header) (response models.SupplyResponse, err error) {
+	err = s.c.get(ctx, &response, "/v2/ledger/supply", nil, headers)
+	return
+}
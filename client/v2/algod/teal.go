@@ -0,0 +1,61 @@
+package algod
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+)
+
+// tealCompileParams are the query parameters of the compile endpoint
+type tealCompileParams struct {
+	Sourcemap bool `url:"sourcemap,omitempty"`
+}
+
+// TealCompile compiles TEAL source to program bytes. The node must have
+// EnableDeveloperAPI set in its config.
+type TealCompile struct {
+	c      *Client
+	source []byte
+	p      tealCompileParams
+}
+
+// Sourcemap also returns a source map of the program, see logic.DecodeSourceMap
+func (s *TealCompile) Sourcemap(sourcemap bool) *TealCompile {
+	s.p.Sourcemap = sourcemap
+	return s
+}
+
+// Do performs the HTTP request
+func (s *TealCompile) Do(ctx context.Context, headers ...*common.Header) (response models.CompileResponse, err error) {
+	err = s.c.post(ctx, &response, "/v2/teal/compile", s.p, headers, s.source)
+	return
+}
+
+// TealDisassemble disassembles program bytes to TEAL source. The node must
+// have EnableDeveloperAPI set in its config.
+type TealDisassemble struct {
+	c       *Client
+	program []byte
+}
+
+// Do performs the HTTP request
+func (s *TealDisassemble) Do(ctx context.Context, headers ...*common.Header) (response models.DisassembleResponse, err error) {
+	err = s.c.post(ctx, &response, "/v2/teal/disassemble", nil, headers, s.program)
+	return
+}
+
+// TealDryrun runs transactions against the given ledger state without
+// submitting them, tracing the programs they run. The node must have
+// EnableDeveloperAPI set in its config.
+type TealDryrun struct {
+	c       *Client
+	request models.DryrunRequest
+}
+
+// Do performs the HTTP request
+func (s *TealDryrun) Do(ctx context.Context, headers ...*common.Header) (response models.DryrunResponse, err error) {
+	err = s.c.post(ctx, &response, "/v2/teal/dryrun", nil, headers, msgpack.Encode(s.request))
+	return
+}
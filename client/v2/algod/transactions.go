@@ -0,0 +1,208 @@
+package algod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// pendingTransactionsParams are the query parameters of the pending transaction
+// endpoints; responses are requested as msgpack so that signed transactions
+// decode directly into types.SignedTxn
+type pendingTransactionsParams struct {
+	Format ResponseFormat `url:"format,omitempty"`
+	Max    uint64         `url:"max,omitempty"`
+}
+
+// PendingTransactions gets a snapshot of the transactions in the node's pool
+type PendingTransactions struct {
+	c *Client
+	p pendingTransactionsParams
+}
+
+// Max truncates the number of transactions returned. If max is 0, all are returned.
+func (s *PendingTransactions) Max(max uint64) *PendingTransactions {
+	s.p.Max = max
+	return s
+}
+
+// Do performs the HTTP request
+func (s *PendingTransactions) Do(ctx context.Context, headers ...*common.Header) (total uint64, topTransactions []types.SignedTxn, err error) {
+	s.p.Format = FormatMsgpack
+	var response models.PendingTransactionsResponse
+	err = s.c.getMsgpack(ctx, &response, "/v2/transactions/pending", s.p, headers)
+	return response.TotalTransactions, response.TopTransactions, err
+}
+
+// PendingTransactionsByAddress gets the pending transactions sent by or to an address
+type PendingTransactionsByAddress struct {
+	c       *Client
+	address string
+	p       pendingTransactionsParams
+}
+
+// Max truncates the number of transactions returned. If max is 0, all are returned.
+func (s *PendingTransactionsByAddress) Max(max uint64) *PendingTransactionsByAddress {
+	s.p.Max = max
+	return s
+}
+
+// Do performs the HTTP request
+func (s *PendingTransactionsByAddress) Do(ctx context.Context, headers ...*common.Header) (total uint64, topTransactions []types.SignedTxn, err error) {
+	s.p.Format = FormatMsgpack
+	var response models.PendingTransactionsResponse
+	err = s.c.getMsgpack(ctx, &response, fmt.Sprintf("/v2/accounts/%s/transactions/pending", common.EscapeParams(s.address)...), s.p, headers)
+	return response.TotalTransactions, response.TopTransactions, err
+}
+
+// PendingTransactionInformation gets information about a recently submitted transaction
+type PendingTransactionInformation struct {
+	c    *Client
+	txid string
+	p    pendingTransactionsParams
+}
+
+// Do performs the HTTP request
+func (s *PendingTransactionInformation) Do(ctx context.Context, headers ...*common.Header) (response models.PendingTransactionInfoResponse, stxn types.SignedTxn, err error) {
+	s.p.Format = FormatMsgpack
+	err = s.c.getMsgpack(ctx, &response, fmt.Sprintf("/v2/transactions/pending/%s", common.EscapeParams(s.txid)...), s.p, headers)
+	stxn = response.Transaction
+	return
+}
+
+// SendRawTransaction broadcasts raw signed transaction bytes to the network
+type SendRawTransaction struct {
+	c      *Client
+	rawtxn []byte
+}
+
+// Do performs the HTTP request, returning the ID of the first transaction. If
+// the client was made with common.WithValidation, the transactions are first
+// checked with transaction.ValidateGroup, and an error describing the first
+// problem is returned instead of sending them. If the client was made with
+// common.WithPreflight, the transactions are simulated first, and a
+// *PreflightError is returned instead of sending them if they would fail.
+func (s *SendRawTransaction) Do(ctx context.Context, headers ...*common.Header) (txid string, err error) {
+	if (*common.Client)(s.c).Validation() {
+		if err = s.c.validate(ctx, s.rawtxn, headers); err != nil {
+			return "", err
+		}
+	}
+	if preflight := (*common.Client)(s.c).Preflight(); preflight != nil {
+		if err = s.c.preflight(ctx, *preflight, s.rawtxn, headers); err != nil {
+			return "", err
+		}
+	}
+	var response models.PostTransactionsResponse
+	err = s.c.post(ctx, &response, "/v2/transactions", nil, headers, s.rawtxn)
+	txid = response.TxID
+	return
+}
+
+// SendTransactionGroup broadcasts a group of signed transactions in one request
+type SendTransactionGroup struct {
+	c    *Client
+	stxs [][]byte
+}
+
+// Do checks the group, then performs the HTTP request, returning the ID of the
+// first transaction. Each element of the group must encode a single signed
+// transaction. A group of several transactions must fit in types.MaxTxGroupSize
+// transactions and in the bytes of a block, and each transaction must carry the
+// group ID computed from all of them, so that a group assembled from the wrong
+// transactions, or in the wrong order, is rejected before it is sent.
+func (s *SendTransactionGroup) Do(ctx context.Context, headers ...*common.Header) (txid string, err error) {
+	if err = checkGroup(s.stxs); err != nil {
+		return "", err
+	}
+	return s.c.SendRawTransaction(bytes.Join(s.stxs, nil)).Do(ctx, headers...)
+}
+
+// checkGroup checks that stxs encode a valid group of signed transactions
+func checkGroup(stxs [][]byte) error {
+	if len(stxs) == 0 {
+		return fmt.Errorf("group has no transactions")
+	}
+	if len(stxs) > types.MaxTxGroupSize {
+		return fmt.Errorf("group of %d transactions is larger than the maximum of %d", len(stxs), types.MaxTxGroupSize)
+	}
+	size := 0
+	txns := make([]types.Transaction, len(stxs))
+	for i, stxBytes := range stxs {
+		stx, _, err := crypto.DecodeSignedTransaction(stxBytes)
+		if err != nil {
+			return fmt.Errorf("transaction %d: %v", i, err)
+		}
+		txns[i] = stx.Txn
+		size += len(stxBytes)
+	}
+	if size > types.MaxTxnBytesPerBlock {
+		return fmt.Errorf("group of %d bytes is longer than the %d bytes of a block", size, types.MaxTxnBytesPerBlock)
+	}
+
+	gid := txns[0].Group
+	if len(txns) == 1 && gid == (types.Digest{}) {
+		return nil
+	}
+	for i := range txns {
+		if txns[i].Group != gid {
+			return fmt.Errorf("transaction %d has a different group ID than transaction 0", i)
+		}
+		txns[i].Group = types.Digest{}
+	}
+	computed, err := crypto.ComputeGroupID(txns)
+	if err != nil {
+		return err
+	}
+	if computed != gid {
+		return fmt.Errorf("group ID does not match the transactions of the group")
+	}
+	return nil
+}
+
+// simulateParams are the query parameters of the simulate endpoint; responses
+// are requested as msgpack so that signed transactions decode directly into
+// types.SignedTxn
+type simulateParams struct {
+	Format ResponseFormat `url:"format,omitempty"`
+}
+
+// SimulateTransaction simulates transaction groups against the latest ledger
+// without submitting them, optionally tracing the programs they run
+type SimulateTransaction struct {
+	c       *Client
+	request models.SimulateRequest
+}
+
+// Do performs the HTTP request
+func (s *SimulateTransaction) Do(ctx context.Context, headers ...*common.Header) (response models.SimulateResponse, err error) {
+	err = s.c.postMsgpack(ctx, &response, "/v2/transactions/simulate", simulateParams{Format: FormatMsgpack}, headers, msgpack.Encode(s.request))
+	return
+}
+
+// SuggestedParams gets the parameters for constructing a new transaction
+type SuggestedParams struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *SuggestedParams) Do(ctx context.Context, headers ...*common.Header) (params types.SuggestedParams, err error) {
+	var response models.TransactionParametersResponse
+	err = s.c.get(ctx, &response, "/v2/transactions/params", nil, headers)
+	params = types.SuggestedParams{
+		Fee:              types.MicroAlgos(response.Fee),
+		GenesisID:        response.GenesisID,
+		GenesisHash:      response.GenesisHash,
+		FirstRoundValid:  types.Round(response.LastRound),
+		LastRoundValid:   types.Round(response.LastRound + 1000),
+		ConsensusVersion: response.ConsensusVersion,
+		MinFee:           response.MinFee,
+	}
+	return
+}
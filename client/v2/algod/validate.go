@@ -0,0 +1,56 @@
+package algod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/protocol"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// decodeSignedTxns decodes the concatenated signed transactions of rawtxn
+func decodeSignedTxns(rawtxn []byte) ([]types.SignedTxn, error) {
+	var stxs []types.SignedTxn
+	r := bytes.NewReader(rawtxn)
+	dec := msgpack.NewDecoder(r)
+	for r.Len() > 0 {
+		var stx types.SignedTxn
+		if err := dec.Decode(&stx); err != nil {
+			return nil, fmt.Errorf("transaction %d: %v", len(stxs), err)
+		}
+		stxs = append(stxs, stx)
+	}
+	return stxs, nil
+}
+
+// validate checks the concatenated signed transactions of rawtxn with
+// transaction.ValidateGroup, against the consensus parameters of the node's
+// protocol version, and checks that none has expired
+func (c *Client) validate(ctx context.Context, rawtxn []byte, headers []*common.Header) error {
+	stxs, err := decodeSignedTxns(rawtxn)
+	if err != nil {
+		return err
+	}
+	status, err := c.Status().Do(ctx, headers...)
+	if err != nil {
+		return fmt.Errorf("validation: %v", err)
+	}
+
+	txns := make([]types.Transaction, len(stxs))
+	for i, stx := range stxs {
+		txns[i] = stx.Txn
+	}
+	if err = transaction.ValidateGroup(txns, protocol.ForStatus(status)); err != nil {
+		return err
+	}
+	for i, txn := range txns {
+		if transaction.RoundsUntilExpiry(status, txn) == 0 {
+			return fmt.Errorf("transaction %d: last valid round %d has passed, the node is at round %d", i, txn.LastValid, status.LastRound)
+		}
+	}
+	return nil
+}
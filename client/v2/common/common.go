@@ -0,0 +1,434 @@
+// Package common provides the HTTP plumbing shared by the v2 REST clients
+// (algod and indexer). Every request takes a context.Context so that long polls
+// can be cancelled and callers can enforce per-request deadlines.
+package common
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/go-querystring/query"
+
+	"github.com/algorand/go-algorand-sdk/client/retry"
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/logic"
+)
+
+// Header is a struct for custom headers.
+type Header struct {
+	Key   string
+	Value string
+}
+
+// Client manages the REST interface for a calling user. A Client is safe for
+// concurrent use by multiple goroutines: its options are set when it is made
+// and not changed afterwards, and its requests share the connections of its
+// http.Client, see WithConnectionPool.
+type Client struct {
+	serverURL url.URL
+	apiHeader string
+	apiToken  string
+	headers   []*Header
+	retry     retry.Policy
+	http      *http.Client
+	preflight *Preflight
+	validate  bool
+}
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithHTTPClient makes a Client send its requests with httpClient, e.g. to
+// use a custom transport, proxy or TLS configuration
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.http = httpClient
+	}
+}
+
+// ConnectionPool configures the HTTP connections a Client keeps open to its
+// node, see WithConnectionPool. Zero fields keep the settings of
+// http.DefaultTransport.
+type ConnectionPool struct {
+	// MaxConnsPerHost limits the connections to the node, whether in use or
+	// idle; requests beyond it wait for a connection to be free
+	MaxConnsPerHost int
+
+	// MaxIdleConnsPerHost is how many idle connections to the node are kept
+	// open for reuse. http.DefaultTransport keeps 2, so a client sending many
+	// requests concurrently keeps opening new connections.
+	MaxIdleConnsPerHost int
+
+	// IdleConnTimeout is how long an idle connection is kept open
+	IdleConnTimeout time.Duration
+
+	// KeepAlive is the interval of the TCP keep-alive probes that keep idle
+	// connections from being dropped by the network
+	KeepAlive time.Duration
+
+	// DisableHTTP2 makes the Client use HTTP/1.1 with nodes served over TLS,
+	// rather than multiplexing its requests over an HTTP/2 connection
+	DisableHTTP2 bool
+}
+
+// transport returns an http.Transport with the settings of
+// http.DefaultTransport, configured as pool says. The transport is built
+// field by field, rather than with Transport.Clone, which Go 1.12 lacks.
+func (pool ConnectionPool) transport() *http.Transport {
+	keepAlive := 30 * time.Second
+	if pool.KeepAlive > 0 {
+		keepAlive = pool.KeepAlive
+	}
+	t := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: keepAlive}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	if pool.MaxConnsPerHost > 0 {
+		t.MaxConnsPerHost = pool.MaxConnsPerHost
+	}
+	if pool.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = pool.MaxIdleConnsPerHost
+		if t.MaxIdleConns < pool.MaxIdleConnsPerHost {
+			t.MaxIdleConns = pool.MaxIdleConnsPerHost
+		}
+	}
+	if pool.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = pool.IdleConnTimeout
+	}
+	if pool.DisableHTTP2 {
+		// a non-nil TLSNextProto map keeps the transport from upgrading
+		// connections to HTTP/2
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	} else {
+		attemptHTTP2(t)
+	}
+	return t
+}
+
+// WithConnectionPool makes a Client send its requests over a connection pool
+// of its own, configured by pool, e.g. to keep enough connections open to a
+// node that the Client sends thousands of transactions per second to. It
+// replaces the http.Client of an earlier WithHTTPClient; to configure the
+// pool of a custom http.Client, set its Transport instead.
+func WithConnectionPool(pool ConnectionPool) ClientOption {
+	return func(c *Client) {
+		c.http = &http.Client{Transport: pool.transport()}
+	}
+}
+
+// WithHeaders adds headers to each request of a Client, e.g. the X-API-Key
+// of a hosted node provider
+func WithHeaders(headers ...*Header) ClientOption {
+	return func(c *Client) {
+		c.headers = append(c.headers, headers...)
+	}
+}
+
+// WithTokenHeader sets the name of the header carrying a Client's API token,
+// e.g. "X-API-Key" for node providers that expect it there, or "" not to send
+// the token
+func WithTokenHeader(apiHeader string) ClientOption {
+	return func(c *Client) {
+		c.apiHeader = apiHeader
+	}
+}
+
+// WithRetryPolicy makes a Client retry requests as policy says, e.g. those
+// rejected by a rate limited node, see retry.DefaultPolicy
+func WithRetryPolicy(policy retry.Policy) ClientOption {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// Preflight configures the simulation an algod Client runs before sending
+// transactions, see WithPreflight
+type Preflight struct {
+	// SourceMaps are the source maps of applications' approval programs, by
+	// application ID, used to report the source line an application failed at
+	SourceMaps map[uint64]logic.SourceMap
+
+	// LogicSigSourceMaps are the source maps of logic signatures, by the
+	// address of their program, used to report the source line a logic
+	// signature failed at
+	LogicSigSourceMaps map[string]logic.SourceMap
+}
+
+// WithPreflight makes an algod Client simulate the transactions it sends
+// before sending them, returning why they would fail instead of sending
+// transactions the node would reject
+func WithPreflight(preflight Preflight) ClientOption {
+	return func(c *Client) {
+		c.preflight = &preflight
+	}
+}
+
+// Preflight returns the preflight configured by WithPreflight, or nil if
+// transactions are sent without simulating them first
+func (client *Client) Preflight() *Preflight {
+	return client.preflight
+}
+
+// WithValidation makes an algod Client check the transactions it sends
+// against the consensus parameters of the node's protocol version before
+// sending them, returning why the node would reject them, such as a low fee or
+// an expired validity window, without sending them
+func WithValidation() ClientOption {
+	return func(c *Client) {
+		c.validate = true
+	}
+}
+
+// Validation returns whether transactions are validated before they are sent,
+// see WithValidation
+func (client *Client) Validation() bool {
+	return client.validate
+}
+
+// MakeClient is the factory for constructing a Client for a given endpoint.
+// apiHeader is the name of the header carrying apiToken, e.g. "X-Algo-API-Token".
+func MakeClient(address string, apiHeader, apiToken string) (c *Client, err error) {
+	url, err := url.Parse(address)
+	if err != nil {
+		return
+	}
+
+	c = &Client{
+		serverURL: *url,
+		apiHeader: apiHeader,
+		apiToken:  apiToken,
+	}
+	return
+}
+
+// MakeClientWithHeaders is the factory for constructing a Client for a given endpoint with additional user defined headers.
+func MakeClientWithHeaders(address string, apiHeader, apiToken string, headers []*Header) (c *Client, err error) {
+	c, err = MakeClient(address, apiHeader, apiToken)
+	if err != nil {
+		return
+	}
+
+	c.headers = append(c.headers, headers...)
+
+	return
+}
+
+// MakeClientWithOptions is the factory for constructing a Client for a given endpoint, configured by options.
+func MakeClientWithOptions(address string, apiHeader, apiToken string, options ...ClientOption) (c *Client, err error) {
+	c, err = MakeClient(address, apiHeader, apiToken)
+	if err != nil {
+		return
+	}
+
+	for _, option := range options {
+		option(c)
+	}
+
+	return
+}
+
+// extractError checks if the response signifies an error, a status other
+// than 2xx, e.g. 201 when a catchup is started.
+// If so, it returns the error, an *HTTPError.
+// Otherwise, it returns nil.
+func extractError(code int, errorBuf []byte) error {
+	if code >= 200 && code < 300 {
+		return nil
+	}
+	return &HTTPError{Status: code, Body: errorBuf}
+}
+
+// mergeRawQueries merges two raw queries, appending an "&" if both are non-empty
+func mergeRawQueries(q1, q2 string) string {
+	if q1 == "" {
+		return q2
+	} else if q2 == "" {
+		return q1
+	} else {
+		return q1 + "&" + q2
+	}
+}
+
+// submitFormRaw is a helper used for submitting (ex.) GETs and POSTs to the server
+func (client *Client) submitFormRaw(ctx context.Context, path string, params interface{}, requestMethod string, headers []*Header, body interface{}) (resp *http.Response, err error) {
+	// path is already escaped, see EscapeParams
+	unescapedPath, err := url.PathUnescape(path)
+	if err != nil {
+		return nil, err
+	}
+	queryURL := client.serverURL
+	queryURL.RawPath = queryURL.EscapedPath() + path
+	queryURL.Path += unescapedPath
+
+	var v url.Values
+
+	if params != nil {
+		v, err = query.Values(params)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// bodies are sent as-is, e.g. msgpack encoded signed transactions
+	var reqBytes []byte
+	if body != nil {
+		var ok bool
+		reqBytes, ok = body.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("couldn't decode raw body as bytes")
+		}
+	}
+
+	queryURL.RawQuery = mergeRawQueries(queryURL.RawQuery, v.Encode())
+
+	// a new request is made for each attempt, so that its body can be resent
+	newRequest := func() (*http.Request, error) {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(reqBytes)
+		}
+		req, err := http.NewRequest(requestMethod, queryURL.String(), bodyReader)
+		if err != nil {
+			return nil, err
+		}
+
+		if bodyReader != nil {
+			req.Header.Set("Content-Type", "application/x-binary")
+		}
+		// Supply the client token.
+		if client.apiHeader != "" {
+			req.Header.Set(client.apiHeader, client.apiToken)
+		}
+		// Add the client headers.
+		for _, header := range client.headers {
+			req.Header.Add(header.Key, header.Value)
+		}
+		// Add the request headers, which replace client headers of the same name.
+		for _, header := range headers {
+			req.Header.Del(header.Key)
+		}
+		for _, header := range headers {
+			req.Header.Add(header.Key, header.Value)
+		}
+		return req, nil
+	}
+
+	httpClient := client.http
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+	return client.retry.Do(ctx, httpClient, newRequest)
+}
+
+// submitForm sends a request and decodes the JSON response into response
+func (client *Client) submitForm(ctx context.Context, response interface{}, path string, params interface{}, requestMethod string, headers []*Header, body interface{}) error {
+	resp, err := client.submitFormRaw(ctx, path, params, requestMethod, headers, body)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	var bodyBytes []byte
+	bodyBytes, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %+v", err)
+	}
+
+	responseErr := extractError(resp.StatusCode, bodyBytes)
+
+	// The caller wants a string
+	if strResponse, ok := response.(*string); ok {
+		*strResponse = string(bodyBytes)
+		return responseErr
+	}
+
+	// The caller wants no response, e.g. from an endpoint with an empty body
+	if response == nil {
+		return responseErr
+	}
+
+	// Attempt to unmarshal a response regardless of whether or not there was an error.
+	err = json.LenientDecode(bodyBytes, response)
+	if responseErr != nil {
+		// Even if there was an unmarshal error, return the HTTP error first if there was one.
+		return responseErr
+	}
+	return err
+}
+
+// Get performs a GET request to the specific path against the server
+func (client *Client) Get(ctx context.Context, response interface{}, path string, params interface{}, headers []*Header) error {
+	return client.submitForm(ctx, response, path, params, "GET", headers, nil)
+}
+
+// GetRaw performs a GET request to the specific path against the server and returns the raw body bytes.
+func (client *Client) GetRaw(ctx context.Context, path string, params interface{}, headers []*Header) (response []byte, err error) {
+	var resp *http.Response
+	resp, err = client.submitFormRaw(ctx, path, params, "GET", headers, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var bodyBytes []byte
+	bodyBytes, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %+v", err)
+	}
+
+	return bodyBytes, extractError(resp.StatusCode, bodyBytes)
+}
+
+// Post sends a POST request to the given path with the given body.
+// response must be a pointer to an object as post writes the response there.
+func (client *Client) Post(ctx context.Context, response interface{}, path string, params interface{}, headers []*Header, body interface{}) error {
+	return client.submitForm(ctx, response, path, params, "POST", headers, body)
+}
+
+// PostRaw sends a POST request to the given path with the given body and returns the raw response body bytes.
+func (client *Client) PostRaw(ctx context.Context, path string, params interface{}, headers []*Header, body interface{}) (response []byte, err error) {
+	var resp *http.Response
+	resp, err = client.submitFormRaw(ctx, path, params, "POST", headers, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var bodyBytes []byte
+	bodyBytes, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %+v", err)
+	}
+
+	return bodyBytes, extractError(resp.StatusCode, bodyBytes)
+}
+
+// Delete sends a DELETE request to the given path.
+func (client *Client) Delete(ctx context.Context, response interface{}, path string, params interface{}, headers []*Header) error {
+	return client.submitForm(ctx, response, path, params, "DELETE", headers, nil)
+}
+
+// EscapeParams url-escapes each string parameter, so caller supplied
+// identifiers cannot change the path of a request
+func EscapeParams(params ...interface{}) []interface{} {
+	paramsStr := make([]interface{}, len(params))
+	for i, param := range params {
+		switch v := param.(type) {
+		case string:
+			paramsStr[i] = url.PathEscape(v)
+		default:
+			paramsStr[i] = fmt.Sprintf("%v", v)
+		}
+	}
+	return paramsStr
+}
@@ -0,0 +1,198 @@
+package common
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// countingTransport counts the requests sent through it
+type countingTransport struct {
+	requests int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.requests++
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientOptions(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	client, err := MakeClientWithOptions(server.URL, "X-Algo-API-Token", "token",
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithTokenHeader("X-API-Key"),
+		WithHeaders(&Header{"X-Provider", "default"}, &Header{"X-Other", "other"}))
+	require.NoError(t, err)
+
+	var response map[string]interface{}
+	require.NoError(t, client.Get(context.Background(), &response, "/v2/status", nil, nil))
+	require.Equal(t, 1, transport.requests)
+	require.Equal(t, "token", got.Get("X-API-Key"))
+	require.Empty(t, got.Get("X-Algo-API-Token"))
+	require.Equal(t, []string{"default"}, got["X-Provider"])
+
+	// request headers replace client headers of the same name
+	require.NoError(t, client.Get(context.Background(), &response, "/v2/status", nil, []*Header{{"X-Provider", "override"}}))
+	require.Equal(t, 2, transport.requests)
+	require.Equal(t, []string{"override"}, got["X-Provider"])
+	require.Equal(t, "other", got.Get("X-Other"))
+}
+
+func TestConnectionPool(t *testing.T) {
+	var mu sync.Mutex
+	conns := 0
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	pool := ConnectionPool{MaxConnsPerHost: 4, MaxIdleConnsPerHost: 4, IdleConnTimeout: time.Minute, KeepAlive: time.Minute}
+	client, err := MakeClientWithOptions(server.URL, "X-Algo-API-Token", "token", WithConnectionPool(pool))
+	require.NoError(t, err)
+
+	// the client is shared by goroutines, whose requests reuse its connections
+	var wg sync.WaitGroup
+	errs := make(chan error, 50*20)
+	for g := 0; g < 50; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				var response map[string]interface{}
+				errs <- client.Get(context.Background(), &response, "/v2/status", nil, nil)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	mu.Lock()
+	require.True(t, conns <= 4, "%d connections opened", conns)
+	mu.Unlock()
+
+	transport := pool.transport()
+	require.Equal(t, 4, transport.MaxIdleConnsPerHost)
+	require.Equal(t, 100, transport.MaxIdleConns)
+	require.Equal(t, time.Minute, transport.IdleConnTimeout)
+	require.Equal(t, 10*time.Second, transport.TLSHandshakeTimeout)
+	require.NotNil(t, transport.Proxy)
+	require.Nil(t, transport.TLSNextProto)
+	pool.DisableHTTP2 = true
+	transport = pool.transport()
+	require.NotNil(t, transport.TLSNextProto)
+	require.Empty(t, transport.TLSNextProto)
+}
+
+func TestSubmitForm(t *testing.T) {
+	var method, uri, contentType string
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, uri, contentType = r.Method, r.RequestURI, r.Header.Get("Content-Type")
+		body, _ = ioutil.ReadAll(r.Body)
+		w.Write([]byte(`{"round":5}`))
+	}))
+	defer server.Close()
+	// the path and query of the address are kept, e.g. those of a proxy
+	client, err := MakeClient(server.URL+"/algod?network=testnet", "", "")
+	require.NoError(t, err)
+	ctx := context.Background()
+	params := struct {
+		Max    uint64 `url:"max,omitempty"`
+		Format string `url:"format,omitempty"`
+	}{Max: 3}
+
+	var response struct {
+		Round uint64 `json:"round"`
+	}
+	require.NoError(t, client.Get(ctx, &response, "/v2/accounts/"+EscapeParams("A B")[0].(string), params, nil))
+	require.Equal(t, "GET", method)
+	require.Equal(t, "/algod/v2/accounts/A%20B?network=testnet&max=3", uri)
+	require.Equal(t, uint64(5), response.Round)
+
+	// a nil response ignores the body
+	require.NoError(t, client.Post(ctx, nil, "/v2/transactions", nil, nil, []byte{1, 2}))
+	require.Equal(t, "POST", method)
+	require.Equal(t, "/algod/v2/transactions?network=testnet", uri)
+	require.Equal(t, "application/x-binary", contentType)
+	require.Equal(t, []byte{1, 2}, body)
+
+	var raw string
+	require.NoError(t, client.Delete(ctx, &raw, "/v2/catchup/1", nil, nil))
+	require.Equal(t, "DELETE", method)
+	require.Equal(t, `{"round":5}`, raw)
+
+	require.EqualError(t, client.Post(ctx, nil, "/v2/transactions", nil, nil, "not bytes"), "couldn't decode raw body as bytes")
+}
+
+func TestHTTPError(t *testing.T) {
+	status := http.StatusBadRequest
+	body := `{"message":"TransactionPool.Remember: transaction A: logic eval error: assert failed pc=7. Details: app=5, pc=7, opcodes=assert"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "", "")
+	require.NoError(t, err)
+
+	var response struct{}
+	err = client.Get(context.Background(), &response, "/v2/status", nil, nil)
+	httpErr, ok := err.(*HTTPError)
+	require.True(t, ok)
+	require.Equal(t, http.StatusBadRequest, httpErr.Status)
+	require.Equal(t, body, string(httpErr.Body))
+	require.Equal(t, "HTTP 400: "+body, httpErr.Error())
+	require.Equal(t, "TransactionPool.Remember: transaction A: logic eval error: assert failed pc=7. Details: app=5, pc=7, opcodes=assert", httpErr.Message())
+	require.Equal(t, &LogicEvalError{PC: 7, Msg: httpErr.Message()}, httpErr.Unwrap())
+
+	var badRequest BadRequest
+	require.True(t, httpErr.As(&badRequest))
+	require.Equal(t, BadRequest(httpErr.Error()), badRequest)
+	notFound := NotFound("unchanged")
+	require.False(t, httpErr.As(&notFound))
+	require.Equal(t, NotFound("unchanged"), notFound)
+
+	status, body = http.StatusNotFound, "not found"
+	err = client.Get(context.Background(), &response, "/v2/status", nil, nil)
+	httpErr, ok = err.(*HTTPError)
+	require.True(t, ok)
+	require.Equal(t, "not found", httpErr.Message())
+	require.Nil(t, httpErr.Unwrap())
+	require.True(t, httpErr.As(&notFound))
+	require.Equal(t, NotFound(httpErr.Error()), notFound)
+	badRequest = BadRequest("unchanged")
+	require.False(t, httpErr.As(&badRequest))
+	require.Equal(t, BadRequest("unchanged"), badRequest)
+}
+
+func TestParseNodeError(t *testing.T) {
+	require.Equal(t, ErrOverspend, ParseNodeError("TransactionPool.Remember: transaction A: overspend (account B, data {...}, tried to spend {1000})"))
+	require.Equal(t, &LogicEvalError{PC: 5, Msg: "transaction A: rejected by logic err=assert failed pc=5. Details: pc=5, opcodes=assert"},
+		ParseNodeError("transaction A: rejected by logic err=assert failed pc=5. Details: pc=5, opcodes=assert"))
+	require.Equal(t, &LogicEvalError{PC: -1, Msg: "logic eval error: program too long"}, ParseNodeError("logic eval error: program too long"))
+	require.Nil(t, ParseNodeError("txn dead: round 10 outside of 1--5"))
+}
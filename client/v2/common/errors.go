@@ -0,0 +1,144 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+)
+
+// HTTPError is returned when the server answers a request with a status other
+// than 200. It unwraps to the reason the node rejected the request, if known,
+// such as ErrOverspend or a *LogicEvalError.
+type HTTPError struct {
+	// Status is the HTTP status code of the response
+	Status int
+
+	// Body is the body of the response
+	Body []byte
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP %v: %s", e.Status, e.Body)
+}
+
+// Message returns the message of the error, the "message" of the JSON body
+// algod and indexer answer errors with, or the whole body otherwise
+func (e *HTTPError) Message() string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.LenientDecode(e.Body, &body); err == nil && body.Message != "" {
+		return body.Message
+	}
+	return string(e.Body)
+}
+
+// Unwrap returns the reason the node rejected the request, as ParseNodeError
+// does, or nil
+func (e *HTTPError) Unwrap() error {
+	if e.Status != 400 {
+		return nil
+	}
+	return ParseNodeError(e.Message())
+}
+
+// As makes errors.As match the errors returned for a status before HTTPError,
+// such as NotFound
+func (e *HTTPError) As(target interface{}) bool {
+	switch target := target.(type) {
+	case *BadRequest:
+		if e.Status != 400 {
+			return false
+		}
+		*target = BadRequest(e.Error())
+	case *InvalidToken:
+		if e.Status != 401 {
+			return false
+		}
+		*target = InvalidToken(e.Error())
+	case *NotFound:
+		if e.Status != 404 {
+			return false
+		}
+		*target = NotFound(e.Error())
+	case *InternalError:
+		if e.Status != 500 {
+			return false
+		}
+		*target = InternalError(e.Error())
+	default:
+		return false
+	}
+	return true
+}
+
+// BadRequest is returned when the server rejects a request as malformed (HTTP 400)
+type BadRequest string
+
+func (e BadRequest) Error() string {
+	return string(e)
+}
+
+// InvalidToken is returned when the API token is missing or wrong (HTTP 401)
+type InvalidToken string
+
+func (e InvalidToken) Error() string {
+	return string(e)
+}
+
+// NotFound is returned when the requested object does not exist (HTTP 404)
+type NotFound string
+
+func (e NotFound) Error() string {
+	return string(e)
+}
+
+// InternalError is returned when the server failed to handle the request (HTTP 500)
+type InternalError string
+
+func (e InternalError) Error() string {
+	return string(e)
+}
+
+// ErrOverspend is the reason a transaction is rejected when its sender cannot
+// pay its amount and fee while keeping its minimum balance
+var ErrOverspend = errors.New("overspend")
+
+// LogicEvalError is the reason a transaction is rejected when a program
+// approving it, of an application or a logic signature, fails
+type LogicEvalError struct {
+	// PC is the program counter the program failed at, or -1 if unknown
+	PC int
+
+	// Msg is the message of the node rejecting the transaction
+	Msg string
+}
+
+func (e *LogicEvalError) Error() string {
+	return e.Msg
+}
+
+var nodeErrorPCRegexp = regexp.MustCompile(`\bpc=(\d+)`)
+
+// ParseNodeError returns the reason for a node's error message, as returned
+// when it rejects a transaction or simulates a failing one: ErrOverspend, a
+// *LogicEvalError, or nil if the message is of another kind
+func ParseNodeError(message string) error {
+	switch {
+	case strings.Contains(message, "logic eval error") || strings.Contains(message, "rejected by logic"):
+		e := &LogicEvalError{PC: -1, Msg: message}
+		// the details of logic errors end the message, so the last pc is the
+		// one the program failed at
+		if pcs := nodeErrorPCRegexp.FindAllStringSubmatch(message, -1); len(pcs) > 0 {
+			e.PC, _ = strconv.Atoi(pcs[len(pcs)-1][1])
+		}
+		return e
+	case strings.Contains(message, "overspend"):
+		return ErrOverspend
+	}
+	return nil
+}
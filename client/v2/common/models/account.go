@@ -0,0 +1,164 @@
+package models
+
+// Account information at a given round
+type Account struct {
+	// Address is the account public key
+	Address string `json:"address" codec:"address"`
+
+	// Amount is the total number of MicroAlgos in the account
+	Amount uint64 `json:"amount" codec:"amount"`
+
+	// AmountWithoutPendingRewards specifies the amount of MicroAlgos in the
+	// account, without the pending rewards
+	AmountWithoutPendingRewards uint64 `json:"amount-without-pending-rewards" codec:"amount-without-pending-rewards"`
+
+	// AppsLocalState is the application local data stored in this account
+	AppsLocalState []ApplicationLocalState `json:"apps-local-state,omitempty" codec:"apps-local-state,omitempty"`
+
+	// AppsTotalExtraPages is the count of extra program pages of applications created by this account
+	AppsTotalExtraPages uint64 `json:"apps-total-extra-pages,omitempty" codec:"apps-total-extra-pages,omitempty"`
+
+	// AppsTotalSchema is the sum of all of the local schemas and global schemas in this account
+	AppsTotalSchema ApplicationStateSchema `json:"apps-total-schema,omitempty" codec:"apps-total-schema,omitempty"`
+
+	// Assets are the assets held by this account
+	Assets []AssetHolding `json:"assets,omitempty" codec:"assets,omitempty"`
+
+	// AuthAddr is the address against which signing should be checked, if the account was rekeyed
+	AuthAddr string `json:"auth-addr,omitempty" codec:"auth-addr,omitempty"`
+
+	// ClosedAtRound is the round during which this account was most recently closed (indexer only)
+	ClosedAtRound uint64 `json:"closed-at-round,omitempty" codec:"closed-at-round,omitempty"`
+
+	// CreatedAtRound is the round during which this account first appeared in a transaction (indexer only)
+	CreatedAtRound uint64 `json:"created-at-round,omitempty" codec:"created-at-round,omitempty"`
+
+	// CreatedApps are the parameters of applications created by this account
+	CreatedApps []Application `json:"created-apps,omitempty" codec:"created-apps,omitempty"`
+
+	// CreatedAssets are the parameters of assets created by this account
+	CreatedAssets []Asset `json:"created-assets,omitempty" codec:"created-assets,omitempty"`
+
+	// Deleted indicates whether the account is currently closed (indexer only)
+	Deleted bool `json:"deleted,omitempty" codec:"deleted,omitempty"`
+
+	// MinBalance is the minimum balance the account must hold, as reported by the node
+	MinBalance uint64 `json:"min-balance,omitempty" codec:"min-balance,omitempty"`
+
+	// Participation holds the account's participation keys, if any
+	Participation AccountParticipation `json:"participation,omitempty" codec:"participation,omitempty"`
+
+	// PendingRewards specifies the amount of MicroAlgos of pending rewards in this account
+	PendingRewards uint64 `json:"pending-rewards" codec:"pending-rewards"`
+
+	// RewardBase is used as part of the rewards computation
+	RewardBase uint64 `json:"reward-base,omitempty" codec:"reward-base,omitempty"`
+
+	// Rewards is the total rewards of MicroAlgos the account has received,
+	// including pending rewards
+	Rewards uint64 `json:"rewards" codec:"rewards"`
+
+	// Round is the round for which this information is relevant
+	Round uint64 `json:"round" codec:"round"`
+
+	// SigType indicates what type of signature is used by this account:
+	// sig, msig or lsig
+	SigType string `json:"sig-type,omitempty" codec:"sig-type,omitempty"`
+
+	// Status is the delegation status of the account's MicroAlgos:
+	// Offline, Online or NotParticipating
+	Status string `json:"status" codec:"status"`
+
+	// TotalAppsOptedIn is the number of applications this account is opted in to
+	TotalAppsOptedIn uint64 `json:"total-apps-opted-in,omitempty" codec:"total-apps-opted-in,omitempty"`
+
+	// TotalAssetsOptedIn is the number of assets this account holds
+	TotalAssetsOptedIn uint64 `json:"total-assets-opted-in,omitempty" codec:"total-assets-opted-in,omitempty"`
+
+	// TotalBoxBytes is the total size of the keys and values of the boxes of
+	// the application this account is the address of
+	TotalBoxBytes uint64 `json:"total-box-bytes,omitempty" codec:"total-box-bytes,omitempty"`
+
+	// TotalBoxes is the number of boxes of the application this account is the
+	// address of
+	TotalBoxes uint64 `json:"total-boxes,omitempty" codec:"total-boxes,omitempty"`
+
+	// TotalCreatedApps is the number of applications this account created
+	TotalCreatedApps uint64 `json:"total-created-apps,omitempty" codec:"total-created-apps,omitempty"`
+
+	// TotalCreatedAssets is the number of assets this account created
+	TotalCreatedAssets uint64 `json:"total-created-assets,omitempty" codec:"total-created-assets,omitempty"`
+}
+
+// AssetHolding returns the account's holding of the asset, if it holds it
+func (a Account) AssetHolding(assetID uint64) (AssetHolding, bool) {
+	for _, holding := range a.Assets {
+		if holding.AssetID == assetID {
+			return holding, true
+		}
+	}
+	return AssetHolding{}, false
+}
+
+// AppLocalState returns the account's local state in the application, if it
+// is opted in to it
+func (a Account) AppLocalState(appID uint64) (ApplicationLocalState, bool) {
+	for _, state := range a.AppsLocalState {
+		if state.ID == appID {
+			return state, true
+		}
+	}
+	return ApplicationLocalState{}, false
+}
+
+// CreatedAsset returns the parameters of an asset the account created
+func (a Account) CreatedAsset(assetID uint64) (AssetParams, bool) {
+	for _, asset := range a.CreatedAssets {
+		if asset.Index == assetID {
+			return asset.Params, true
+		}
+	}
+	return AssetParams{}, false
+}
+
+// CreatedApp returns the parameters of an application the account created
+func (a Account) CreatedApp(appID uint64) (ApplicationParams, bool) {
+	for _, app := range a.CreatedApps {
+		if app.ID == appID {
+			return app.Params, true
+		}
+	}
+	return ApplicationParams{}, false
+}
+
+// SpendableBalance returns the number of MicroAlgos the account can spend
+// without going below its minimum balance, as computed by ComputeMinBalance,
+// or 0 if it is already below it
+func (a Account) SpendableBalance() uint64 {
+	minBalance := ComputeMinBalance(a)
+	if a.Amount <= minBalance {
+		return 0
+	}
+	return a.Amount - minBalance
+}
+
+// AccountParticipation describes the parameters used by an account in consensus
+type AccountParticipation struct {
+	// SelectionParticipationKey is the root participation public key (if any)
+	SelectionParticipationKey []byte `json:"selection-participation-key" codec:"selection-participation-key"`
+
+	// StateProofKey is the root of the state proof key (if any)
+	StateProofKey []byte `json:"state-proof-key,omitempty" codec:"state-proof-key,omitempty"`
+
+	// VoteFirstValid is the first round for which this participation is valid
+	VoteFirstValid uint64 `json:"vote-first-valid" codec:"vote-first-valid"`
+
+	// VoteKeyDilution is the number of subkeys in each batch of participation keys
+	VoteKeyDilution uint64 `json:"vote-key-dilution" codec:"vote-key-dilution"`
+
+	// VoteLastValid is the last round for which this participation is valid
+	VoteLastValid uint64 `json:"vote-last-valid" codec:"vote-last-valid"`
+
+	// VoteParticipationKey is the root participation public key (if any)
+	VoteParticipationKey []byte `json:"vote-participation-key" codec:"vote-participation-key"`
+}
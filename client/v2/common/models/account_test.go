@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeMinBalance(t *testing.T) {
+	require.Equal(t, uint64(100000), ComputeMinBalance(Account{}))
+
+	account := Account{
+		Amount:              1000000,
+		Assets:              []AssetHolding{{AssetID: 1, Amount: 5}, {AssetID: 2, IsFrozen: true}},
+		CreatedApps:         []Application{{ID: 10}},
+		AppsTotalExtraPages: 1,
+		AppsLocalState:      []ApplicationLocalState{{ID: 11}},
+		AppsTotalSchema:     ApplicationStateSchema{NumUint: 2, NumByteSlice: 1},
+		TotalBoxes:          3,
+		TotalBoxBytes:       100,
+	}
+	const expected = 100000 + 2*100000 + 2*100000 + 100000 + 2*28500 + 50000 + 3*2500 + 100*400
+	require.Equal(t, uint64(expected), ComputeMinBalance(account))
+	require.Equal(t, uint64(1000000-expected), account.SpendableBalance())
+
+	// the same account, fetched with its assets and applications excluded
+	excluded := account
+	excluded.Assets, excluded.CreatedApps, excluded.AppsLocalState = nil, nil, nil
+	excluded.TotalAssetsOptedIn, excluded.TotalCreatedApps, excluded.TotalAppsOptedIn = 2, 1, 1
+	require.Equal(t, uint64(expected), ComputeMinBalance(excluded))
+
+	account.Amount = 1000
+	require.Zero(t, account.SpendableBalance())
+
+	holding, ok := account.AssetHolding(1)
+	require.True(t, ok)
+	require.Equal(t, uint64(5), holding.Amount)
+	_, ok = account.AssetHolding(3)
+	require.False(t, ok)
+	state, ok := account.AppLocalState(11)
+	require.True(t, ok)
+	require.Equal(t, uint64(11), state.ID)
+	_, ok = account.AppLocalState(10)
+	require.False(t, ok)
+	_, ok = account.CreatedApp(10)
+	require.True(t, ok)
+	_, ok = account.CreatedAsset(1)
+	require.False(t, ok)
+}
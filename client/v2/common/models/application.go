@@ -0,0 +1,114 @@
+package models
+
+// Application is an application's index and its parameters
+type Application struct {
+	// CreatedAtRound is the round when this application was created (indexer only)
+	CreatedAtRound uint64 `json:"created-at-round,omitempty" codec:"created-at-round,omitempty"`
+
+	// Deleted indicates whether the application is currently deleted (indexer only)
+	Deleted bool `json:"deleted,omitempty" codec:"deleted,omitempty"`
+
+	// DeletedAtRound is the round when this application was deleted (indexer only)
+	DeletedAtRound uint64 `json:"deleted-at-round,omitempty" codec:"deleted-at-round,omitempty"`
+
+	// ID is the application index
+	ID uint64 `json:"id" codec:"id"`
+
+	// Params are the parameters of the application
+	Params ApplicationParams `json:"params" codec:"params"`
+}
+
+// ApplicationParams are the global information stored for an application
+type ApplicationParams struct {
+	// ApprovalProgram is the approval program
+	ApprovalProgram []byte `json:"approval-program" codec:"approval-program"`
+
+	// ClearStateProgram is the clear state program
+	ClearStateProgram []byte `json:"clear-state-program" codec:"clear-state-program"`
+
+	// Creator is the address that created this application
+	Creator string `json:"creator,omitempty" codec:"creator,omitempty"`
+
+	// ExtraProgramPages is the number of extra program pages available to this application
+	ExtraProgramPages uint64 `json:"extra-program-pages,omitempty" codec:"extra-program-pages,omitempty"`
+
+	// GlobalState is the global state of the application
+	GlobalState []TealKeyValue `json:"global-state,omitempty" codec:"global-state,omitempty"`
+
+	// GlobalStateSchema is the schema of the global state
+	GlobalStateSchema ApplicationStateSchema `json:"global-state-schema,omitempty" codec:"global-state-schema,omitempty"`
+
+	// LocalStateSchema is the schema of each account's local state
+	LocalStateSchema ApplicationStateSchema `json:"local-state-schema,omitempty" codec:"local-state-schema,omitempty"`
+}
+
+// ApplicationLocalState stores the local state of an application in an account
+type ApplicationLocalState struct {
+	// ID is the application index
+	ID uint64 `json:"id" codec:"id"`
+
+	// KeyValue is the local state
+	KeyValue []TealKeyValue `json:"key-value,omitempty" codec:"key-value,omitempty"`
+
+	// Schema is the schema of the local state
+	Schema ApplicationStateSchema `json:"schema" codec:"schema"`
+}
+
+// ApplicationStateSchema specifies maximums on the number of each type that may be stored
+type ApplicationStateSchema struct {
+	// NumByteSlice is the maximum number of TEAL byte slices that may be stored
+	NumByteSlice uint64 `json:"num-byte-slice" codec:"num-byte-slice"`
+
+	// NumUint is the maximum number of TEAL uints that may be stored
+	NumUint uint64 `json:"num-uint" codec:"num-uint"`
+}
+
+// TealKeyValue represents a key-value pair in an application store
+type TealKeyValue struct {
+	// Key is the base64 encoded key
+	Key string `json:"key" codec:"key"`
+
+	// Value is the stored value
+	Value TealValue `json:"value" codec:"value"`
+}
+
+// TealValue represents a TEAL value
+type TealValue struct {
+	// Bytes holds the base64 encoded bytes value
+	Bytes string `json:"bytes" codec:"bytes"`
+
+	// Type is the value type: 1 for bytes, 2 for uint
+	Type uint64 `json:"type" codec:"type"`
+
+	// Uint holds the uint value
+	Uint uint64 `json:"uint" codec:"uint"`
+}
+
+// Box is a box of an application, with its value
+type Box struct {
+	// Name is the box's name
+	Name []byte `json:"name" codec:"name"`
+
+	// Round is the round at which the box was read
+	Round uint64 `json:"round,omitempty" codec:"round,omitempty"`
+
+	// Value is the box's value
+	Value []byte `json:"value" codec:"value"`
+}
+
+// BoxDescriptor names a box of an application
+type BoxDescriptor struct {
+	// Name is the box's name
+	Name []byte `json:"name" codec:"name"`
+}
+
+// BoxesResponse lists the boxes of an application
+type BoxesResponse struct {
+	// ApplicationID is the application the boxes belong to (indexer only)
+	ApplicationID uint64 `json:"application-id,omitempty" codec:"application-id,omitempty"`
+
+	Boxes []BoxDescriptor `json:"boxes" codec:"boxes"`
+
+	// NextToken is used for pagination: pass it as Next to get the following page (indexer only)
+	NextToken string `json:"next-token,omitempty" codec:"next-token,omitempty"`
+}
@@ -0,0 +1,82 @@
+package models
+
+// Asset specifies both the unique identifier and the parameters for an asset
+type Asset struct {
+	// CreatedAtRound is the round during which this asset was created (indexer only)
+	CreatedAtRound uint64 `json:"created-at-round,omitempty" codec:"created-at-round,omitempty"`
+
+	// Deleted indicates whether the asset is currently deleted (indexer only)
+	Deleted bool `json:"deleted,omitempty" codec:"deleted,omitempty"`
+
+	// DestroyedAtRound is the round during which this asset was destroyed (indexer only)
+	DestroyedAtRound uint64 `json:"destroyed-at-round,omitempty" codec:"destroyed-at-round,omitempty"`
+
+	// Index is the unique asset identifier
+	Index uint64 `json:"index" codec:"index"`
+
+	// Params are the parameters of the asset
+	Params AssetParams `json:"params" codec:"params"`
+}
+
+// AssetHolding describes an account's holding of an asset
+type AssetHolding struct {
+	// Amount is the number of units held
+	Amount uint64 `json:"amount" codec:"amount"`
+
+	// AssetID is the asset ID of the holding
+	AssetID uint64 `json:"asset-id" codec:"asset-id"`
+
+	// IsFrozen indicates whether the holding is frozen
+	IsFrozen bool `json:"is-frozen" codec:"is-frozen"`
+}
+
+// AssetParams specifies the parameters for an asset
+type AssetParams struct {
+	// Clawback is the address used to clawback holdings of this asset.
+	// If empty, clawback is not permitted.
+	Clawback string `json:"clawback,omitempty" codec:"clawback,omitempty"`
+
+	// Creator is the address that created this asset
+	Creator string `json:"creator" codec:"creator"`
+
+	// Decimals is the number of digits to use after the decimal point when
+	// displaying this asset
+	Decimals uint64 `json:"decimals" codec:"decimals"`
+
+	// DefaultFrozen indicates whether holdings of this asset are frozen by default
+	DefaultFrozen bool `json:"default-frozen,omitempty" codec:"default-frozen,omitempty"`
+
+	// Freeze is the address used to freeze holdings of this asset.
+	// If empty, freezing is not permitted.
+	Freeze string `json:"freeze,omitempty" codec:"freeze,omitempty"`
+
+	// Manager is the address used to manage the keys of this asset and to destroy it
+	Manager string `json:"manager,omitempty" codec:"manager,omitempty"`
+
+	// MetadataHash is a commitment to some unspecified asset metadata
+	MetadataHash []byte `json:"metadata-hash,omitempty" codec:"metadata-hash,omitempty"`
+
+	// Name of this asset, as supplied by the creator
+	Name string `json:"name,omitempty" codec:"name,omitempty"`
+
+	// NameB64 is the base64 encoded name of this asset
+	NameB64 []byte `json:"name-b64,omitempty" codec:"name-b64,omitempty"`
+
+	// Reserve is the address holding reserve (non-minted) units of this asset
+	Reserve string `json:"reserve,omitempty" codec:"reserve,omitempty"`
+
+	// Total is the total number of units of this asset
+	Total uint64 `json:"total" codec:"total"`
+
+	// UnitName is the name of a unit of this asset, as supplied by the creator
+	UnitName string `json:"unit-name,omitempty" codec:"unit-name,omitempty"`
+
+	// UnitNameB64 is the base64 encoded name of a unit of this asset
+	UnitNameB64 []byte `json:"unit-name-b64,omitempty" codec:"unit-name-b64,omitempty"`
+
+	// URL where more information about the asset can be retrieved
+	URL string `json:"url,omitempty" codec:"url,omitempty"`
+
+	// URLB64 is the base64 encoded url
+	URLB64 []byte `json:"url-b64,omitempty" codec:"url-b64,omitempty"`
+}
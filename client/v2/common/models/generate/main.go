@@ -0,0 +1,388 @@
+// Command generate generates the models of the v2 algod and indexer REST
+// APIs from their OpenAPI 2 specs, algod.oas2.json and indexer.oas2.json:
+//
+//	go run ./client/v2/common/models/generate -out client/v2/common/models/models_gen.go algod.oas2.json indexer.oas2.json
+//
+// A struct is generated for each definition of the specs, and for each
+// response whose schema is an inline object, with both JSON and msgpack tags
+// named after the spec's properties, since algod and indexer encode both
+// formats with the same names. Types already declared by other files of the
+// output's package are skipped, so that models with methods or hand-tuned
+// field types can be written by hand; with -check, the properties of the specs
+// missing from those hand-written structs are listed instead, so that new
+// fields are not overlooked.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// schema is the subset of an OpenAPI 2 schema the generator understands
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Description          string             `json:"description"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties interface{}        `json:"additionalProperties"`
+	AlgorandFormat       string             `json:"x-algorand-format"`
+	GoType               string             `json:"x-go-type"`
+}
+
+// spec is the subset of an OpenAPI 2 spec the generator understands
+type spec struct {
+	Definitions map[string]*schema `json:"definitions"`
+	Responses   map[string]struct {
+		Description string  `json:"description"`
+		Schema      *schema `json:"schema"`
+	} `json:"responses"`
+}
+
+// algorandFormats are the Go types of the x-algorand-format values whose
+// encoding is defined by the types package
+var algorandFormats = map[string]string{
+	"SignedTransaction": "types.SignedTxn",
+	"Address":           "string",
+}
+
+// initialisms are the words of property names written in capitals in Go
+var initialisms = map[string]string{
+	"api":  "API",
+	"http": "HTTP",
+	"id":   "ID",
+	"ids":  "IDs",
+	"json": "JSON",
+	"txid": "TxID",
+	"url":  "URL",
+}
+
+// generator accumulates the structs of the specs' schemas
+type generator struct {
+	structs map[string]*schema
+	docs    map[string]string
+	imports map[string]bool
+}
+
+func main() {
+	out := flag.String("out", "models_gen.go", "the file to write the models to")
+	pkg := flag.String("package", "models", "the package of the generated file")
+	check := flag.Bool("check", false, "list the properties missing from hand-written models instead of generating")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: generate [-out file] [-package name] [-check] spec.json...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	g := &generator{structs: map[string]*schema{}, docs: map[string]string{}, imports: map[string]bool{}}
+	for _, path := range flag.Args() {
+		if err := g.load(path); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	existing, err := declaredStructs(filepath.Dir(*out), filepath.Base(*out))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if *check {
+		missing := g.missingFields(existing)
+		for _, m := range missing {
+			fmt.Println(m)
+		}
+		if len(missing) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+	src, err := g.generate(*pkg, existing)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// load adds the definitions and inline response schemas of the spec at path
+func (g *generator) load(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	for name, def := range s.Definitions {
+		g.add(goName(name), def, def.Description)
+	}
+	for name, resp := range s.Responses {
+		if resp.Schema == nil || resp.Schema.Ref != "" || len(resp.Schema.Properties) == 0 {
+			continue
+		}
+		g.add(goName(name), resp.Schema, resp.Description)
+	}
+	return nil
+}
+
+// add adds the struct of an object schema; algod and indexer share many
+// definitions, so the first spec to define a name wins
+func (g *generator) add(name string, s *schema, doc string) {
+	if _, ok := g.structs[name]; ok {
+		return
+	}
+	g.structs[name] = s
+	g.docs[name] = doc
+}
+
+// generate returns the source of the structs not in existing
+func (g *generator) generate(pkg string, existing map[string]map[string]bool) ([]byte, error) {
+	var body bytes.Buffer
+	// nested objects add structs while generating, so loop until none is new
+	done := map[string]bool{}
+	for {
+		var names []string
+		for name := range g.structs {
+			if !done[name] {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			break
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			done[name] = true
+			if _, ok := existing[name]; ok {
+				continue
+			}
+			if err := g.writeStruct(&body, name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by client/v2/common/models/generate from the OpenAPI specs. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	if g.imports["types"] {
+		src.WriteString("import \"github.com/algorand/go-algorand-sdk/types\"\n\n")
+	}
+	src.Write(body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+// writeStruct writes the struct of the named schema
+func (g *generator) writeStruct(w *bytes.Buffer, name string) error {
+	s := g.structs[name]
+	writeDoc(w, name, g.docs[name], "")
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	required := map[string]bool{}
+	for _, r := range s.Required {
+		required[r] = true
+	}
+	props := make([]string, 0, len(s.Properties))
+	for prop := range s.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+	for i, prop := range props {
+		field := goName(prop)
+		typ, err := g.goType(name+field, s.Properties[prop])
+		if err != nil {
+			return fmt.Errorf("%s.%s: %v", name, prop, err)
+		}
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		writeDoc(w, field, s.Properties[prop].Description, "\t")
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "\t%s %s `json:%s codec:%s`\n", field, typ, strconv.Quote(tag), strconv.Quote(tag))
+	}
+	w.WriteString("}\n\n")
+	return nil
+}
+
+// goType returns the Go type of a property; nested objects become structs
+// named after their parent and property
+func (g *generator) goType(nested string, s *schema) (string, error) {
+	if s.GoType != "" {
+		return s.GoType, nil
+	}
+	if s.Ref != "" {
+		const prefix = "#/definitions/"
+		if !strings.HasPrefix(s.Ref, prefix) {
+			return "", fmt.Errorf("unsupported reference %s", s.Ref)
+		}
+		return goName(strings.TrimPrefix(s.Ref, prefix)), nil
+	}
+	if typ, ok := algorandFormats[s.AlgorandFormat]; ok {
+		if strings.HasPrefix(typ, "types.") {
+			g.imports["types"] = true
+		}
+		return typ, nil
+	}
+	switch s.Type {
+	case "integer":
+		if s.Format == "int64" || s.Format == "int32" {
+			return "int64", nil
+		}
+		return "uint64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "string":
+		if s.Format == "byte" {
+			return "[]byte", nil
+		}
+		return "string", nil
+	case "array":
+		if s.Items == nil {
+			return "", fmt.Errorf("array without items")
+		}
+		elem, err := g.goType(nested, s.Items)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elem, nil
+	case "object", "":
+		if len(s.Properties) == 0 {
+			return "map[string]interface{}", nil
+		}
+		g.add(nested, s, s.Description)
+		return nested, nil
+	}
+	return "", fmt.Errorf("unsupported type %q", s.Type)
+}
+
+// missingFields lists the properties of the specs missing from the structs
+// in existing, as "Struct.property"
+func (g *generator) missingFields(existing map[string]map[string]bool) []string {
+	var missing []string
+	for name, s := range g.structs {
+		tags, ok := existing[name]
+		if !ok {
+			continue
+		}
+		for prop := range s.Properties {
+			if !tags[prop] {
+				missing = append(missing, name+"."+prop)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// declaredStructs returns the structs declared by the Go files of dir other
+// than skip, with the JSON names of their fields
+func declaredStructs(dir, skip string) (map[string]map[string]bool, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return info.Name() != skip && !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	structs := map[string]map[string]bool{}
+	for _, pkg := range pkgs {
+		ast.Inspect(pkg, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			tags := map[string]bool{}
+			if st, ok := spec.Type.(*ast.StructType); ok {
+				for _, field := range st.Fields.List {
+					if field.Tag == nil {
+						continue
+					}
+					tag, err := strconv.Unquote(field.Tag.Value)
+					if err != nil {
+						continue
+					}
+					name := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+					tags[name] = true
+				}
+			}
+			structs[spec.Name.Name] = tags
+			return true
+		})
+	}
+	return structs, nil
+}
+
+// goName converts a spec name, such as "amount-without-pending-rewards" or
+// "asset_id", to a Go name, such as AmountWithoutPendingRewards or AssetID
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+	var b strings.Builder
+	for _, word := range words {
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// writeDoc writes the doc comment of name, starting with its name as the
+// repo's doc comments do
+func writeDoc(w *bytes.Buffer, name, doc, indent string) {
+	doc = strings.Join(strings.Fields(doc), " ")
+	if doc == "" {
+		return
+	}
+	if !strings.HasPrefix(doc, name+" ") {
+		// lower the first word, unless it is an initialism such as "ID"
+		if len(doc) > 1 && doc[1] >= 'a' && doc[1] <= 'z' {
+			doc = strings.ToLower(doc[:1]) + doc[1:]
+		}
+		// "Describes an asset" reads as "Name describes an asset"
+		if first := strings.Fields(doc)[0]; strings.HasSuffix(first, "s") && doc[0] >= 'a' && doc[0] <= 'z' {
+			doc = name + " " + doc
+		} else {
+			doc = name + " is " + doc
+		}
+	}
+	doc = strings.TrimSuffix(doc, ".")
+	line := indent + "//"
+	for _, word := range strings.Fields(doc) {
+		if len(line)+1+len(word) > 80 && line != indent+"//" {
+			w.WriteString(line + "\n")
+			line = indent + "//"
+		}
+		line += " " + word
+	}
+	w.WriteString(line + "\n")
+}
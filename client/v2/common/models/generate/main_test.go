@@ -0,0 +1,61 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoName(t *testing.T) {
+	cases := map[string]string{
+		"amount-without-pending-rewards": "AmountWithoutPendingRewards",
+		"asset-id":                       "AssetID",
+		"genesis_hash_b64":               "GenesisHashB64",
+		"url":                            "URL",
+		"Account":                        "Account",
+	}
+	for name, expected := range cases {
+		require.Equal(t, expected, goName(name))
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	g := &generator{structs: map[string]*schema{}, docs: map[string]string{}, imports: map[string]bool{}}
+	require.NoError(t, g.load("testdata/spec.json"))
+
+	existing := map[string]map[string]bool{"Account": {"address": true, "amount": true}}
+	src, err := g.generate("models", existing)
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "models_gen.go", src, 0)
+	require.NoError(t, err)
+
+	code := string(src)
+	// hand-written structs are skipped, and references only respond with
+	// the referenced struct
+	require.NotContains(t, code, "type Account struct")
+	require.NotContains(t, code, "type AccountResponse")
+	require.Contains(t, code, "import \"github.com/algorand/go-algorand-sdk/types\"")
+	require.Contains(t, code, "// Holding describes an asset held by an account\ntype Holding struct {")
+	require.Contains(t, code, "\t// AssetID is asset ID of the holding\n\tAssetID uint64 `json:\"asset-id\" codec:\"asset-id\"`")
+	require.Contains(t, code, "IsFrozen bool `json:\"is-frozen,omitempty\" codec:\"is-frozen,omitempty\"`")
+	require.Contains(t, code, "Note []byte `json:\"note,omitempty\"")
+	require.Contains(t, code, "Delta int64 `json:\"delta,omitempty\"")
+	require.Contains(t, code, "Ratio float64 `json:\"ratio,omitempty\"")
+	require.Contains(t, code, "Account Account `json:\"account,omitempty\"")
+	require.Contains(t, code, "Txns []types.SignedTxn `json:\"txns,omitempty\"")
+	require.Contains(t, code, "Extra map[string]interface{} `json:\"extra,omitempty\"")
+	require.Contains(t, code, "Params HoldingParams `json:\"params,omitempty\"")
+	require.Contains(t, code, "type HoldingParams struct {\n\tURL string `json:\"url,omitempty\" codec:\"url,omitempty\"`")
+	require.Contains(t, code, "Holdings []Holding `json:\"holdings\" codec:\"holdings\"`")
+
+	require.Equal(t, []string{"Account.new-field"}, g.missingFields(existing))
+}
+
+func TestDeclaredStructs(t *testing.T) {
+	structs, err := declaredStructs("..", "models_gen.go")
+	require.NoError(t, err)
+	require.True(t, structs["Account"]["amount-without-pending-rewards"])
+	require.Contains(t, structs, "NodeStatusResponse")
+}
@@ -0,0 +1,164 @@
+package models
+
+// HealthCheckResponse is the indexer's health
+type HealthCheckResponse struct {
+	Data        *map[string]interface{} `json:"data,omitempty" codec:"data,omitempty"`
+	DbAvailable bool                    `json:"db-available" codec:"db-available"`
+	IsMigrating bool                    `json:"is-migrating" codec:"is-migrating"`
+	Message     string                  `json:"message" codec:"message"`
+	Round       uint64                  `json:"round" codec:"round"`
+	Version     string                  `json:"version,omitempty" codec:"version,omitempty"`
+}
+
+// AccountResponse is the response to an indexer account lookup
+type AccountResponse struct {
+	// Account information at a given round
+	Account Account `json:"account" codec:"account"`
+
+	// CurrentRound is the round at which the results were computed
+	CurrentRound uint64 `json:"current-round" codec:"current-round"`
+}
+
+// AccountsResponse is a page of an indexer account search
+type AccountsResponse struct {
+	Accounts     []Account `json:"accounts" codec:"accounts"`
+	CurrentRound uint64    `json:"current-round" codec:"current-round"`
+
+	// NextToken is used for pagination: pass it as Next to get the following page
+	NextToken string `json:"next-token,omitempty" codec:"next-token,omitempty"`
+}
+
+// AssetResponse is the response to an indexer asset lookup
+type AssetResponse struct {
+	Asset        Asset  `json:"asset" codec:"asset"`
+	CurrentRound uint64 `json:"current-round" codec:"current-round"`
+}
+
+// AssetsResponse is a page of an indexer asset search
+type AssetsResponse struct {
+	Assets       []Asset `json:"assets" codec:"assets"`
+	CurrentRound uint64  `json:"current-round" codec:"current-round"`
+
+	// NextToken is used for pagination: pass it as Next to get the following page
+	NextToken string `json:"next-token,omitempty" codec:"next-token,omitempty"`
+}
+
+// MiniAssetHolding is an account's holding of an asset, as listed by asset balance queries
+type MiniAssetHolding struct {
+	Address  string `json:"address" codec:"address"`
+	Amount   uint64 `json:"amount" codec:"amount"`
+	IsFrozen bool   `json:"is-frozen" codec:"is-frozen"`
+
+	// Deleted indicates whether the holding is currently deleted
+	Deleted bool `json:"deleted,omitempty" codec:"deleted,omitempty"`
+
+	// OptedInAtRound is the round during which the account opted into the asset
+	OptedInAtRound uint64 `json:"opted-in-at-round,omitempty" codec:"opted-in-at-round,omitempty"`
+
+	// OptedOutAtRound is the round during which the account opted out of the asset
+	OptedOutAtRound uint64 `json:"opted-out-at-round,omitempty" codec:"opted-out-at-round,omitempty"`
+}
+
+// AssetBalancesResponse is a page of the holders of an asset
+type AssetBalancesResponse struct {
+	Balances     []MiniAssetHolding `json:"balances" codec:"balances"`
+	CurrentRound uint64             `json:"current-round" codec:"current-round"`
+
+	// NextToken is used for pagination: pass it as Next to get the following page
+	NextToken string `json:"next-token,omitempty" codec:"next-token,omitempty"`
+}
+
+// ApplicationResponse is the response to an indexer application lookup
+type ApplicationResponse struct {
+	Application  Application `json:"application,omitempty" codec:"application,omitempty"`
+	CurrentRound uint64      `json:"current-round" codec:"current-round"`
+}
+
+// ApplicationsResponse is a page of an indexer application search
+type ApplicationsResponse struct {
+	Applications []Application `json:"applications" codec:"applications"`
+	CurrentRound uint64        `json:"current-round" codec:"current-round"`
+
+	// NextToken is used for pagination: pass it as Next to get the following page
+	NextToken string `json:"next-token,omitempty" codec:"next-token,omitempty"`
+}
+
+// TransactionResponse is the response to an indexer transaction lookup
+type TransactionResponse struct {
+	CurrentRound uint64      `json:"current-round" codec:"current-round"`
+	Transaction  Transaction `json:"transaction" codec:"transaction"`
+}
+
+// TransactionsResponse is a page of an indexer transaction search
+type TransactionsResponse struct {
+	CurrentRound uint64        `json:"current-round" codec:"current-round"`
+	Transactions []Transaction `json:"transactions" codec:"transactions"`
+
+	// NextToken is used for pagination: pass it as Next to get the following page
+	NextToken string `json:"next-token,omitempty" codec:"next-token,omitempty"`
+}
+
+// Block information, as returned by the indexer
+type Block struct {
+	// GenesisHash is the hash to which this block belongs
+	GenesisHash []byte `json:"genesis-hash" codec:"genesis-hash"`
+
+	// GenesisID is the ID to which this block belongs
+	GenesisID string `json:"genesis-id" codec:"genesis-id"`
+
+	// PreviousBlockHash is the hash of the previous block
+	PreviousBlockHash []byte `json:"previous-block-hash" codec:"previous-block-hash"`
+
+	// Rewards describes the rewards state at this block
+	Rewards BlockRewards `json:"rewards,omitempty" codec:"rewards,omitempty"`
+
+	// Round is the current round on which this block was appended to the chain
+	Round uint64 `json:"round" codec:"round"`
+
+	// Seed is the sortition seed
+	Seed []byte `json:"seed" codec:"seed"`
+
+	// Timestamp is the block creation time, in seconds since the epoch
+	Timestamp uint64 `json:"timestamp" codec:"timestamp"`
+
+	// Transactions are the transactions of the block
+	Transactions []Transaction `json:"transactions,omitempty" codec:"transactions,omitempty"`
+
+	// TransactionsRoot is the root of the merkle tree of the block's transactions
+	TransactionsRoot []byte `json:"transactions-root" codec:"transactions-root"`
+
+	// TxnCounter is the number of transactions in the ledger as of this block
+	TxnCounter uint64 `json:"txn-counter,omitempty" codec:"txn-counter,omitempty"`
+
+	// UpgradeState tracks the status of protocol upgrades
+	UpgradeState BlockUpgradeState `json:"upgrade-state,omitempty" codec:"upgrade-state,omitempty"`
+
+	// UpgradeVote is the upgrade vote of the block's proposer
+	UpgradeVote BlockUpgradeVote `json:"upgrade-vote,omitempty" codec:"upgrade-vote,omitempty"`
+}
+
+// BlockRewards describes the rewards state of a block
+type BlockRewards struct {
+	FeeSink                 string `json:"fee-sink" codec:"fee-sink"`
+	RewardsCalculationRound uint64 `json:"rewards-calculation-round" codec:"rewards-calculation-round"`
+	RewardsLevel            uint64 `json:"rewards-level" codec:"rewards-level"`
+	RewardsPool             string `json:"rewards-pool" codec:"rewards-pool"`
+	RewardsRate             uint64 `json:"rewards-rate" codec:"rewards-rate"`
+	RewardsResidue          uint64 `json:"rewards-residue" codec:"rewards-residue"`
+}
+
+// BlockUpgradeState tracks the status of protocol upgrades
+type BlockUpgradeState struct {
+	CurrentProtocol        string `json:"current-protocol" codec:"current-protocol"`
+	NextProtocol           string `json:"next-protocol,omitempty" codec:"next-protocol,omitempty"`
+	NextProtocolApprovals  uint64 `json:"next-protocol-approvals,omitempty" codec:"next-protocol-approvals,omitempty"`
+	NextProtocolSwitchOn   uint64 `json:"next-protocol-switch-on,omitempty" codec:"next-protocol-switch-on,omitempty"`
+	NextProtocolVoteBefore uint64 `json:"next-protocol-vote-before,omitempty" codec:"next-protocol-vote-before,omitempty"`
+}
+
+// BlockUpgradeVote is a block proposer's upgrade vote
+type BlockUpgradeVote struct {
+	UpgradeApprove bool   `json:"upgrade-approve,omitempty" codec:"upgrade-approve,omitempty"`
+	UpgradeDelay   uint64 `json:"upgrade-delay,omitempty" codec:"upgrade-delay,omitempty"`
+	UpgradePropose string `json:"upgrade-propose,omitempty" codec:"upgrade-propose,omitempty"`
+}
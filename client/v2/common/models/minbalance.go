@@ -0,0 +1,59 @@
+package models
+
+// The minimum balance requirements of the current consensus protocol, in
+// MicroAlgos
+const (
+	// minBalance is the minimum balance of any account
+	minBalance = 100000
+
+	// assetMinBalance is required per asset held, including assets created
+	assetMinBalance = 100000
+
+	// appFlatParamsMinBalance is required per application created, and per
+	// extra program page of those applications
+	appFlatParamsMinBalance = 100000
+
+	// appFlatOptInMinBalance is required per application opted in to
+	appFlatOptInMinBalance = 100000
+
+	// schemaMinBalancePerEntry is required per entry of the local and global
+	// state schemas of the account's applications, in addition to
+	// schemaUintMinBalance or schemaBytesMinBalance
+	schemaMinBalancePerEntry = 25000
+	schemaUintMinBalance     = 3500
+	schemaBytesMinBalance    = 25000
+
+	// boxFlatMinBalance is required per box of the application the account
+	// is the address of, and boxByteMinBalance per byte of their keys and
+	// values
+	boxFlatMinBalance = 2500
+	boxByteMinBalance = 400
+)
+
+// ComputeMinBalance computes the minimum balance the account must hold, from
+// its assets, applications, their state schemas and its boxes. Accounts
+// fetched with their assets and applications excluded are counted from their
+// totals. algod reports the same amount as MinBalance; this lets it be
+// computed for indexer accounts, or after a planned change to an account.
+func ComputeMinBalance(account Account) uint64 {
+	assets := maxUint64(uint64(len(account.Assets)), account.TotalAssetsOptedIn)
+	optedIn := maxUint64(uint64(len(account.AppsLocalState)), account.TotalAppsOptedIn)
+	created := maxUint64(uint64(len(account.CreatedApps)), account.TotalCreatedApps)
+	schema := account.AppsTotalSchema
+
+	return minBalance +
+		assets*assetMinBalance +
+		(created+account.AppsTotalExtraPages)*appFlatParamsMinBalance +
+		optedIn*appFlatOptInMinBalance +
+		schema.NumUint*(schemaMinBalancePerEntry+schemaUintMinBalance) +
+		schema.NumByteSlice*(schemaMinBalancePerEntry+schemaBytesMinBalance) +
+		account.TotalBoxes*boxFlatMinBalance +
+		account.TotalBoxBytes*boxByteMinBalance
+}
+
+func maxUint64(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
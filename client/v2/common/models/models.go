@@ -0,0 +1,245 @@
+// Package models defines the request and response models of the v2 algod and
+// indexer REST APIs. Field names follow the API's JSON encoding.
+package models
+
+import "github.com/algorand/go-algorand-sdk/types"
+
+// NodeStatusResponse is the current status of the node
+type NodeStatusResponse struct {
+	// Catchpoint is the catchpoint the node is catching up to, if it is
+	// catching up from a catchpoint
+	Catchpoint string `json:"catchpoint,omitempty" codec:"catchpoint,omitempty"`
+
+	// CatchpointAcquiredBlocks is the number of blocks of the catchpoint
+	// downloaded so far
+	CatchpointAcquiredBlocks uint64 `json:"catchpoint-acquired-blocks,omitempty" codec:"catchpoint-acquired-blocks,omitempty"`
+
+	// CatchpointProcessedAccounts is the number of accounts of the catchpoint
+	// processed so far
+	CatchpointProcessedAccounts uint64 `json:"catchpoint-processed-accounts,omitempty" codec:"catchpoint-processed-accounts,omitempty"`
+
+	// CatchpointTotalAccounts is the number of accounts of the catchpoint
+	CatchpointTotalAccounts uint64 `json:"catchpoint-total-accounts,omitempty" codec:"catchpoint-total-accounts,omitempty"`
+
+	// CatchpointTotalBlocks is the number of blocks the catchpoint needs
+	CatchpointTotalBlocks uint64 `json:"catchpoint-total-blocks,omitempty" codec:"catchpoint-total-blocks,omitempty"`
+
+	// CatchpointVerifiedAccounts is the number of accounts of the catchpoint
+	// verified so far
+	CatchpointVerifiedAccounts uint64 `json:"catchpoint-verified-accounts,omitempty" codec:"catchpoint-verified-accounts,omitempty"`
+
+	// CatchupTime in nanoseconds
+	CatchupTime uint64 `json:"catchup-time" codec:"catchup-time"`
+
+	// LastRound indicates the last round seen
+	LastRound uint64 `json:"last-round" codec:"last-round"`
+
+	// LastVersion indicates the last consensus version supported
+	LastVersion string `json:"last-version" codec:"last-version"`
+
+	// NextVersion of consensus protocol to use
+	NextVersion string `json:"next-version" codec:"next-version"`
+
+	// NextVersionRound is the round at which the next consensus version will apply
+	NextVersionRound uint64 `json:"next-version-round" codec:"next-version-round"`
+
+	// NextVersionSupported indicates whether the next consensus version is supported by this node
+	NextVersionSupported bool `json:"next-version-supported" codec:"next-version-supported"`
+
+	// StoppedAtUnsupportedRound indicates that the node does not support the consensus version of the next round
+	StoppedAtUnsupportedRound bool `json:"stopped-at-unsupported-round" codec:"stopped-at-unsupported-round"`
+
+	// TimeSinceLastRound in nanoseconds
+	TimeSinceLastRound uint64 `json:"time-since-last-round" codec:"time-since-last-round"`
+}
+
+// CatchpointStartResponse is the response to starting a catchup
+type CatchpointStartResponse struct {
+	// CatchupMessage says which catchpoint the node is catching up to
+	CatchupMessage string `json:"catchup-message" codec:"catchup-message"`
+}
+
+// CatchpointAbortResponse is the response to aborting a catchup
+type CatchpointAbortResponse struct {
+	// CatchupMessage says which catchpoint the node stopped catching up to
+	CatchupMessage string `json:"catchup-message" codec:"catchup-message"`
+}
+
+// Version is the response to the /versions endpoint
+type Version struct {
+	Build       BuildVersion `json:"build" codec:"build"`
+	GenesisHash []byte       `json:"genesis_hash_b64" codec:"genesis_hash_b64"`
+	GenesisID   string       `json:"genesis_id" codec:"genesis_id"`
+	Versions    []string     `json:"versions" codec:"versions"`
+}
+
+// Network returns the public network of the node, or types.UnknownNetwork
+func (v Version) Network() types.Network {
+	return types.NetworkFromGenesis(v.GenesisID, v.GenesisHash)
+}
+
+// BuildVersion describes the build of the node software
+type BuildVersion struct {
+	Branch      string `json:"branch" codec:"branch"`
+	BuildNumber uint64 `json:"build_number" codec:"build_number"`
+	Channel     string `json:"channel" codec:"channel"`
+	CommitHash  string `json:"commit_hash" codec:"commit_hash"`
+	Major       uint64 `json:"major" codec:"major"`
+	Minor       uint64 `json:"minor" codec:"minor"`
+}
+
+// SupplyResponse is the supply of Algos at a round
+type SupplyResponse struct {
+	// CurrentRound is the round for which the supply was computed
+	CurrentRound uint64 `json:"current_round" codec:"current_round"`
+
+	// OnlineMoney is the total amount of MicroAlgos in online accounts
+	OnlineMoney uint64 `json:"online-money" codec:"online-money"`
+
+	// TotalMoney is the total amount of MicroAlgos in circulation
+	TotalMoney uint64 `json:"total-money" codec:"total-money"`
+}
+
+// TransactionParametersResponse contains the parameters needed to build a new transaction
+type TransactionParametersResponse struct {
+	// ConsensusVersion indicates the consensus protocol version as of LastRound
+	ConsensusVersion string `json:"consensus-version" codec:"consensus-version"`
+
+	// Fee is the suggested transaction fee in units of MicroAlgos per byte
+	Fee uint64 `json:"fee" codec:"fee"`
+
+	// GenesisHash is the hash of the genesis block
+	GenesisHash []byte `json:"genesis-hash" codec:"genesis-hash"`
+
+	// GenesisID is an ID listed in the genesis block
+	GenesisID string `json:"genesis-id" codec:"genesis-id"`
+
+	// LastRound is the last round seen
+	LastRound uint64 `json:"last-round" codec:"last-round"`
+
+	// MinFee is the minimum transaction fee (not per byte) required for a
+	// transaction to be valid under the current consensus protocol
+	MinFee uint64 `json:"min-fee" codec:"min-fee"`
+}
+
+// PostTransactionsResponse is the response to submitting a transaction or group
+type PostTransactionsResponse struct {
+	// TxID is the encoding of the transaction hash
+	TxID string `json:"txId" codec:"txId"`
+}
+
+// PendingTransactionsResponse is a snapshot of pending transactions
+type PendingTransactionsResponse struct {
+	// TopTransactions is an array of signed transaction objects
+	TopTransactions []types.SignedTxn `json:"top-transactions" codec:"top-transactions"`
+
+	// TotalTransactions is the total number of transactions in the pool
+	TotalTransactions uint64 `json:"total-transactions" codec:"total-transactions"`
+}
+
+// PendingTransactionInfoResponse describes a recently submitted transaction.
+// There are several cases when this might succeed:
+//   - transaction committed (ConfirmedRound > 0)
+//   - transaction still in the pool (ConfirmedRound = 0, PoolError = "")
+//   - transaction removed from pool due to error (ConfirmedRound = 0, PoolError != "")
+type PendingTransactionInfoResponse struct {
+	// ApplicationIndex is the index of the newly created application, if any
+	ApplicationIndex uint64 `json:"application-index,omitempty" codec:"application-index,omitempty"`
+
+	// AssetClosingAmount is the number of the asset's units that were transferred to the close-to address
+	AssetClosingAmount uint64 `json:"asset-closing-amount,omitempty" codec:"asset-closing-amount,omitempty"`
+
+	// AssetIndex is the index of the newly created asset, if any
+	AssetIndex uint64 `json:"asset-index,omitempty" codec:"asset-index,omitempty"`
+
+	// CloseRewards are the rewards in MicroAlgos applied to the close remainder to account
+	CloseRewards uint64 `json:"close-rewards,omitempty" codec:"close-rewards,omitempty"`
+
+	// ClosingAmount is the amount of MicroAlgos transferred to the close remainder to account
+	ClosingAmount uint64 `json:"closing-amount,omitempty" codec:"closing-amount,omitempty"`
+
+	// ConfirmedRound is the round where this transaction was confirmed, if present
+	ConfirmedRound uint64 `json:"confirmed-round,omitempty" codec:"confirmed-round,omitempty"`
+
+	// InnerTxns are the inner transactions issued by application execution
+	InnerTxns []PendingTransactionInfoResponse `json:"inner-txns,omitempty" codec:"inner-txns,omitempty"`
+
+	// Logs are the logs emitted by application execution
+	Logs [][]byte `json:"logs,omitempty" codec:"logs,omitempty"`
+
+	// PoolError indicates that the transaction was kicked out of this node's
+	// transaction pool (and specifies why that happened)
+	PoolError string `json:"pool-error" codec:"pool-error"`
+
+	// ReceiverRewards are the rewards in MicroAlgos applied to the receiver account
+	ReceiverRewards uint64 `json:"receiver-rewards,omitempty" codec:"receiver-rewards,omitempty"`
+
+	// SenderRewards are the rewards in MicroAlgos applied to the sender account
+	SenderRewards uint64 `json:"sender-rewards,omitempty" codec:"sender-rewards,omitempty"`
+
+	// Transaction is the raw signed transaction
+	Transaction types.SignedTxn `json:"txn" codec:"txn"`
+}
+
+// Flatten returns the transaction followed by its inner transactions,
+// recursively, in the order they were executed
+func (r PendingTransactionInfoResponse) Flatten() []PendingTransactionInfoResponse {
+	flat := []PendingTransactionInfoResponse{r}
+	for _, inner := range r.InnerTxns {
+		flat = append(flat, inner.Flatten()...)
+	}
+	return flat
+}
+
+// BlockResponse is the response to a block request
+type BlockResponse struct {
+	// Block header data and transactions
+	Block map[string]interface{} `json:"block" codec:"block"`
+
+	// Cert is the block certificate, if requested
+	Cert *map[string]interface{} `json:"cert,omitempty" codec:"cert,omitempty"`
+}
+
+// TransactionProofResponse proves that a transaction is committed to by the
+// header of the block it was confirmed in
+type TransactionProofResponse struct {
+	// Hashtype is the hash of the commitment proven against: sha512_256 or sha256
+	Hashtype string `json:"hashtype" codec:"hashtype"`
+
+	// Idx is the position of the transaction in the block's payset
+	Idx uint64 `json:"idx" codec:"idx"`
+
+	// Proof is the sibling hashes on the path from the transaction to the
+	// root, concatenated
+	Proof []byte `json:"proof" codec:"proof"`
+
+	// Stibhash is the hash of the transaction as it is stored in the block,
+	// a types.SignedTxnInBlock
+	Stibhash []byte `json:"stibhash" codec:"stibhash"`
+
+	// Treedepth is the depth of the tree of the block's transactions
+	Treedepth uint64 `json:"treedepth" codec:"treedepth"`
+}
+
+// GetSyncRoundResponse is the sync round of a node in follower mode
+type GetSyncRoundResponse struct {
+	// Round is the round the node keeps its ledger at
+	Round uint64 `json:"round" codec:"round"`
+}
+
+// LedgerStateDeltaForTransactionGroup is the changes a transaction group made
+// to the ledger
+type LedgerStateDeltaForTransactionGroup struct {
+	// Delta is the changes the group made
+	Delta types.LedgerStateDelta `json:"Delta" codec:"Delta"`
+
+	// Ids are the IDs of the transactions of the group
+	Ids []string `json:"Ids" codec:"Ids"`
+}
+
+// TransactionGroupLedgerStateDeltasForRoundResponse is the changes each
+// transaction group of a round made to the ledger
+type TransactionGroupLedgerStateDeltasForRoundResponse struct {
+	// Deltas are the changes of each group
+	Deltas []LedgerStateDeltaForTransactionGroup `json:"Deltas" codec:"Deltas"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+)
+
+// TestCodecTags checks that each field has the same name in msgpack as in
+// JSON, as algod and indexer encode them
+func TestCodecTags(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+	fields := 0
+	for _, pkg := range pkgs {
+		ast.Inspect(pkg, func(n ast.Node) bool {
+			field, ok := n.(*ast.Field)
+			if !ok || field.Tag == nil {
+				return true
+			}
+			tag, err := strconv.Unquote(field.Tag.Value)
+			require.NoError(t, err)
+			structTag := reflect.StructTag(tag)
+			require.Equal(t, structTag.Get("json"), structTag.Get("codec"), "field %s at %s", field.Names, fset.Position(field.Pos()))
+			fields++
+			return true
+		})
+	}
+	require.NotZero(t, fields)
+}
+
+// TestBigIntegers checks that integers above 2^53, which a float64 would
+// round, decode exactly, in typed fields as in untyped ones
+func TestBigIntegers(t *testing.T) {
+	var account Account
+	require.NoError(t, json.LenientDecode([]byte(`{"amount":9007199254740993,"round":18446744073709551615,"assets":[{"asset-id":1,"amount":18446744073709551614}],"created-assets":[{"index":2,"params":{"total":18446744073709551615}}]}`), &account))
+	require.Equal(t, uint64(1<<53+1), account.Amount)
+	require.Equal(t, uint64(1<<64-1), account.Round)
+	require.Equal(t, uint64(1<<64-2), account.Assets[0].Amount)
+	require.Equal(t, uint64(1<<64-1), account.CreatedAssets[0].Params.Total)
+
+	var block BlockResponse
+	require.NoError(t, json.LenientDecode([]byte(`{"block":{"rnd":9007199254740993,"tc":18446744073709551615}}`), &block))
+	require.EqualValues(t, uint64(1<<53+1), block.Block["rnd"])
+	require.EqualValues(t, uint64(1<<64-1), block.Block["tc"])
+}
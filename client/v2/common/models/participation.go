@@ -0,0 +1,38 @@
+package models
+
+// ParticipationKey is a participation key installed on a node
+type ParticipationKey struct {
+	// Address is the account the key is for
+	Address string `json:"address" codec:"address"`
+
+	// EffectiveFirstValid is the first round the key is used in consensus,
+	// once the account is registered online with it
+	EffectiveFirstValid uint64 `json:"effective-first-valid,omitempty" codec:"effective-first-valid,omitempty"`
+
+	// EffectiveLastValid is the last round the key is used in consensus, once
+	// the account is registered online with it
+	EffectiveLastValid uint64 `json:"effective-last-valid,omitempty" codec:"effective-last-valid,omitempty"`
+
+	// ID is the ID of the key, by which the node's participation requests
+	// refer to it
+	ID string `json:"id" codec:"id"`
+
+	// Key are the keys' parameters, as registered by the key registration
+	// transaction bringing the account online with it
+	Key AccountParticipation `json:"key" codec:"key"`
+
+	// LastBlockProposal is the last round a block was proposed with the key
+	LastBlockProposal uint64 `json:"last-block-proposal,omitempty" codec:"last-block-proposal,omitempty"`
+
+	// LastStateProof is the last round a state proof was signed with the key
+	LastStateProof uint64 `json:"last-state-proof,omitempty" codec:"last-state-proof,omitempty"`
+
+	// LastVote is the last round a vote was cast with the key
+	LastVote uint64 `json:"last-vote,omitempty" codec:"last-vote,omitempty"`
+}
+
+// PostParticipationResponse is the response to adding a participation key
+type PostParticipationResponse struct {
+	// PartID is the ID of the added key
+	PartID string `json:"partId" codec:"partId"`
+}
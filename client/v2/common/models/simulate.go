@@ -0,0 +1,196 @@
+package models
+
+import "github.com/algorand/go-algorand-sdk/types"
+
+// SimulateRequest is a request to simulate transaction groups against the latest ledger
+type SimulateRequest struct {
+	// AllowEmptySignatures lets transactions without signatures be simulated as if they were properly signed
+	AllowEmptySignatures bool `json:"allow-empty-signatures,omitempty" codec:"allow-empty-signatures,omitempty"`
+
+	// AllowMoreLogging lifts the limits on log opcode usage during simulation
+	AllowMoreLogging bool `json:"allow-more-logging,omitempty" codec:"allow-more-logging,omitempty"`
+
+	// ExecTraceConfig sets what is recorded in each transaction's execution trace
+	ExecTraceConfig SimulateTraceConfig `json:"exec-trace-config,omitempty" codec:"exec-trace-config,omitempty"`
+
+	// ExtraOpcodeBudget is added to the opcode budget of each group's application calls
+	ExtraOpcodeBudget uint64 `json:"extra-opcode-budget,omitempty" codec:"extra-opcode-budget,omitempty"`
+
+	// Round is the round to simulate against, if not the latest
+	Round uint64 `json:"round,omitempty" codec:"round,omitempty"`
+
+	// TxnGroups are the transaction groups to simulate
+	TxnGroups []SimulateRequestTransactionGroup `json:"txn-groups" codec:"txn-groups"`
+}
+
+// SimulateRequestTransactionGroup is a transaction group to simulate
+type SimulateRequestTransactionGroup struct {
+	// Txns are the signed transactions of the group
+	Txns []types.SignedTxn `json:"txns" codec:"txns"`
+}
+
+// SimulateTraceConfig sets what is recorded in execution traces
+type SimulateTraceConfig struct {
+	// Enable records the program counter of each opcode executed
+	Enable bool `json:"enable,omitempty" codec:"enable,omitempty"`
+
+	// ScratchChange records changes to scratch space
+	ScratchChange bool `json:"scratch-change,omitempty" codec:"scratch-change,omitempty"`
+
+	// StackChange records changes to the stack
+	StackChange bool `json:"stack-change,omitempty" codec:"stack-change,omitempty"`
+
+	// StateChange records changes to application state
+	StateChange bool `json:"state-change,omitempty" codec:"state-change,omitempty"`
+}
+
+// SimulateResponse is the result of simulating transaction groups
+type SimulateResponse struct {
+	// EvalOverrides are the limits lifted or changed for the simulation
+	EvalOverrides SimulationEvalOverrides `json:"eval-overrides,omitempty" codec:"eval-overrides,omitempty"`
+
+	// ExecTraceConfig is the trace configuration the simulation used
+	ExecTraceConfig SimulateTraceConfig `json:"exec-trace-config,omitempty" codec:"exec-trace-config,omitempty"`
+
+	// LastRound is the round immediately preceding this simulation
+	LastRound uint64 `json:"last-round" codec:"last-round"`
+
+	// TxnGroups are the results of each transaction group, in order
+	TxnGroups []SimulateTransactionGroupResult `json:"txn-groups" codec:"txn-groups"`
+
+	// Version is the version of this response object
+	Version uint64 `json:"version" codec:"version"`
+}
+
+// SimulationEvalOverrides are the evaluation limits a simulation changed from those of the network
+type SimulationEvalOverrides struct {
+	// AllowEmptySignatures is set if transactions without signatures were simulated as if signed
+	AllowEmptySignatures bool `json:"allow-empty-signatures,omitempty" codec:"allow-empty-signatures,omitempty"`
+
+	// ExtraOpcodeBudget is the opcode budget added to each group's application calls
+	ExtraOpcodeBudget uint64 `json:"extra-opcode-budget,omitempty" codec:"extra-opcode-budget,omitempty"`
+
+	// MaxLogCalls is the maximum number of log calls allowed per transaction
+	MaxLogCalls uint64 `json:"max-log-calls,omitempty" codec:"max-log-calls,omitempty"`
+
+	// MaxLogSize is the maximum total size of logs allowed per transaction
+	MaxLogSize uint64 `json:"max-log-size,omitempty" codec:"max-log-size,omitempty"`
+}
+
+// SimulateTransactionGroupResult is the result of simulating a transaction group
+type SimulateTransactionGroupResult struct {
+	// AppBudgetAdded is the opcode budget available to the group's application calls
+	AppBudgetAdded uint64 `json:"app-budget-added,omitempty" codec:"app-budget-added,omitempty"`
+
+	// AppBudgetConsumed is the opcode budget used by the group's application calls
+	AppBudgetConsumed uint64 `json:"app-budget-consumed,omitempty" codec:"app-budget-consumed,omitempty"`
+
+	// FailedAt is the path to the transaction that failed, if any: its index in
+	// the group, followed by the indexes of any inner transactions leading to it
+	FailedAt []uint64 `json:"failed-at,omitempty" codec:"failed-at,omitempty"`
+
+	// FailureMessage says why the group would fail, if it would
+	FailureMessage string `json:"failure-message,omitempty" codec:"failure-message,omitempty"`
+
+	// TxnResults are the results of each transaction of the group, in order
+	TxnResults []SimulateTransactionResult `json:"txn-results" codec:"txn-results"`
+}
+
+// SimulateTransactionResult is the result of simulating a transaction
+type SimulateTransactionResult struct {
+	// AppBudgetConsumed is the opcode budget used by the transaction's application call, including inner transactions
+	AppBudgetConsumed uint64 `json:"app-budget-consumed,omitempty" codec:"app-budget-consumed,omitempty"`
+
+	// ExecTrace is the execution trace of the transaction's programs, if requested
+	ExecTrace SimulationTransactionExecTrace `json:"exec-trace,omitempty" codec:"exec-trace,omitempty"`
+
+	// LogicSigBudgetConsumed is the opcode budget used by the transaction's LogicSig
+	LogicSigBudgetConsumed uint64 `json:"logic-sig-budget-consumed,omitempty" codec:"logic-sig-budget-consumed,omitempty"`
+
+	// TxnResult is the transaction as it would be confirmed, with its logs and inner transactions
+	TxnResult PendingTransactionInfoResponse `json:"txn-result" codec:"txn-result"`
+}
+
+// SimulationTransactionExecTrace is the execution trace of the programs run by a transaction
+type SimulationTransactionExecTrace struct {
+	// ApprovalProgramHash is the SHA512_256 hash of the approval program run
+	ApprovalProgramHash []byte `json:"approval-program-hash,omitempty" codec:"approval-program-hash,omitempty"`
+
+	// ApprovalProgramTrace is the trace of the approval program
+	ApprovalProgramTrace []SimulationOpcodeTraceUnit `json:"approval-program-trace,omitempty" codec:"approval-program-trace,omitempty"`
+
+	// ClearStateProgramHash is the SHA512_256 hash of the clear state program run
+	ClearStateProgramHash []byte `json:"clear-state-program-hash,omitempty" codec:"clear-state-program-hash,omitempty"`
+
+	// ClearStateProgramTrace is the trace of the clear state program
+	ClearStateProgramTrace []SimulationOpcodeTraceUnit `json:"clear-state-program-trace,omitempty" codec:"clear-state-program-trace,omitempty"`
+
+	// InnerTrace are the traces of the inner transactions, in order
+	InnerTrace []SimulationTransactionExecTrace `json:"inner-trace,omitempty" codec:"inner-trace,omitempty"`
+
+	// LogicSigHash is the SHA512_256 hash of the LogicSig program run
+	LogicSigHash []byte `json:"logic-sig-hash,omitempty" codec:"logic-sig-hash,omitempty"`
+
+	// LogicSigTrace is the trace of the LogicSig program
+	LogicSigTrace []SimulationOpcodeTraceUnit `json:"logic-sig-trace,omitempty" codec:"logic-sig-trace,omitempty"`
+}
+
+// SimulationOpcodeTraceUnit is the trace of a single opcode
+type SimulationOpcodeTraceUnit struct {
+	// Pc is the program counter of the opcode
+	Pc uint64 `json:"pc" codec:"pc"`
+
+	// ScratchChanges are the changes the opcode made to scratch space
+	ScratchChanges []ScratchChange `json:"scratch-changes,omitempty" codec:"scratch-changes,omitempty"`
+
+	// SpawnedInners are the indexes of the inner transactions the opcode submitted
+	SpawnedInners []uint64 `json:"spawned-inners,omitempty" codec:"spawned-inners,omitempty"`
+
+	// StackAdditions are the values the opcode pushed on the stack
+	StackAdditions []AvmValue `json:"stack-additions,omitempty" codec:"stack-additions,omitempty"`
+
+	// StackPopCount is the number of values the opcode popped from the stack
+	StackPopCount uint64 `json:"stack-pop-count,omitempty" codec:"stack-pop-count,omitempty"`
+
+	// StateChanges are the changes the opcode made to application state
+	StateChanges []ApplicationStateOperation `json:"state-changes,omitempty" codec:"state-changes,omitempty"`
+}
+
+// ScratchChange is a change to a scratch slot
+type ScratchChange struct {
+	// NewValue is the slot's new value
+	NewValue AvmValue `json:"new-value" codec:"new-value"`
+
+	// Slot is the scratch slot changed
+	Slot uint64 `json:"slot" codec:"slot"`
+}
+
+// AvmValue is a value on the AVM stack or in scratch space
+type AvmValue struct {
+	// Bytes holds the bytes value
+	Bytes []byte `json:"bytes,omitempty" codec:"bytes,omitempty"`
+
+	// Type is the value type: 1 for bytes, 2 for uint
+	Type uint64 `json:"type" codec:"type"`
+
+	// Uint holds the uint value
+	Uint uint64 `json:"uint,omitempty" codec:"uint,omitempty"`
+}
+
+// ApplicationStateOperation is a change to an application's state
+type ApplicationStateOperation struct {
+	// Account is the account whose local state changed, for local state
+	Account string `json:"account,omitempty" codec:"account,omitempty"`
+
+	// AppStateType is the kind of state changed: g for global, l for local and b for boxes
+	AppStateType string `json:"app-state-type" codec:"app-state-type"`
+
+	// Key is the key changed
+	Key []byte `json:"key" codec:"key"`
+
+	// NewValue is the key's new value, for writes
+	NewValue AvmValue `json:"new-value,omitempty" codec:"new-value,omitempty"`
+
+	// Operation is w for a write and d for a delete
+	Operation string `json:"operation" codec:"operation"`
+}
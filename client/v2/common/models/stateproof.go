@@ -0,0 +1,46 @@
+package models
+
+// StateProof is a state proof and the message it attests to
+type StateProof struct {
+	// Message is the message the state proof attests to
+	Message StateProofMessage `json:"Message" codec:"Message"`
+
+	// StateProof is the msgpack encoded state proof, which decodes into a
+	// types.StateProof
+	StateProof []byte `json:"StateProof" codec:"StateProof"`
+}
+
+// StateProofMessage is the message a state proof attests to
+type StateProofMessage struct {
+	// BlockHeadersCommitment is the root of the vector commitment to the
+	// light block headers of the attested rounds
+	BlockHeadersCommitment []byte `json:"BlockHeadersCommitment" codec:"BlockHeadersCommitment"`
+
+	// FirstAttestedRound is the first round the message attests to
+	FirstAttestedRound uint64 `json:"FirstAttestedRound" codec:"FirstAttestedRound"`
+
+	// LastAttestedRound is the last round the message attests to
+	LastAttestedRound uint64 `json:"LastAttestedRound" codec:"LastAttestedRound"`
+
+	// LnProvenWeight is the natural log of the weight the next state proof
+	// must prove, in fixed point with 16 fractional bits
+	LnProvenWeight uint64 `json:"LnProvenWeight" codec:"LnProvenWeight"`
+
+	// VotersCommitment is the root of the vector commitment to the voters
+	// who sign the next state proof
+	VotersCommitment []byte `json:"VotersCommitment" codec:"VotersCommitment"`
+}
+
+// LightBlockHeaderProof proves that the light block header of a round is
+// committed to by the state proof message attesting to it
+type LightBlockHeaderProof struct {
+	// Index is the position of the light block header in the vector commitment
+	Index uint64 `json:"index" codec:"index"`
+
+	// Proof is the sibling hashes on the path from the light block header to
+	// the root, concatenated
+	Proof []byte `json:"proof" codec:"proof"`
+
+	// Treedepth is the depth of the vector commitment tree
+	Treedepth uint64 `json:"treedepth" codec:"treedepth"`
+}
@@ -0,0 +1,160 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// CompileResponse is the result of compiling TEAL source
+type CompileResponse struct {
+	// Hash is the address of the program, as used by a contract account
+	Hash string `json:"hash" codec:"hash"`
+
+	// Result is the base64 encoded program bytes
+	Result string `json:"result" codec:"result"`
+
+	// Sourcemap is the JSON source map of the program, if requested, see logic.DecodeSourceMap
+	Sourcemap json.RawMessage `json:"sourcemap,omitempty" codec:"sourcemap,omitempty"`
+}
+
+// DisassembleResponse is the result of disassembling program bytes
+type DisassembleResponse struct {
+	// Result is the disassembled TEAL source
+	Result string `json:"result" codec:"result"`
+}
+
+// DryrunRequest holds the transactions to run and the ledger state to run them against
+type DryrunRequest struct {
+	// Accounts are the accounts the transactions may read, with their balances and local state
+	Accounts []Account `json:"accounts" codec:"accounts"`
+
+	// Apps are the applications the transactions may call or read
+	Apps []Application `json:"apps" codec:"apps"`
+
+	// LatestTimestamp is available to some TEAL scripts. Defaults to the latest confirmed timestamp this algod is attached to.
+	LatestTimestamp uint64 `json:"latest-timestamp" codec:"latest-timestamp"`
+
+	// ProtocolVersion specifies a specific version string to operate under, otherwise whatever the current protocol of the network this algod is running in
+	ProtocolVersion string `json:"protocol-version" codec:"protocol-version"`
+
+	// Round is available to some TEAL scripts. Defaults to the current round on the network this algod is attached to.
+	Round uint64 `json:"round" codec:"round"`
+
+	// Sources are TEAL sources to compile and run in place of programs of the transactions or apps
+	Sources []DryrunSource `json:"sources" codec:"sources"`
+
+	// Txns are the signed transactions to run
+	Txns []types.SignedTxn `json:"txns" codec:"txns"`
+}
+
+// DryrunSource is TEAL source to compile and run in place of a program
+type DryrunSource struct {
+	// AppIndex is the application whose program the source replaces, for field names approv and clearp
+	AppIndex uint64 `json:"app-index" codec:"app-index"`
+
+	// FieldName is the program replaced: lsig, approv or clearp
+	FieldName string `json:"field-name" codec:"field-name"`
+
+	// Source is the TEAL source
+	Source string `json:"source" codec:"source"`
+
+	// TxnIndex is the transaction whose LogicSig the source replaces, for field name lsig
+	TxnIndex uint64 `json:"txn-index" codec:"txn-index"`
+}
+
+// DryrunResponse is the result of a dryrun
+type DryrunResponse struct {
+	// Error is set if the request could not be run
+	Error string `json:"error" codec:"error"`
+
+	// ProtocolVersion is the protocol version the transactions were run under
+	ProtocolVersion string `json:"protocol-version" codec:"protocol-version"`
+
+	// Txns are the results of each transaction, in order
+	Txns []DryrunTxnResult `json:"txns" codec:"txns"`
+}
+
+// DryrunTxnResult contains any LogicSig or ApplicationCall program debug information and state updates from a dryrun
+type DryrunTxnResult struct {
+	// AppCallMessages are messages from the approval program, such as PASS or REJECT
+	AppCallMessages []string `json:"app-call-messages,omitempty" codec:"app-call-messages,omitempty"`
+
+	// AppCallTrace is the program state after each step of the approval program
+	AppCallTrace []DryrunState `json:"app-call-trace,omitempty" codec:"app-call-trace,omitempty"`
+
+	// BudgetAdded is the opcode budget added by inner transactions
+	BudgetAdded uint64 `json:"budget-added,omitempty" codec:"budget-added,omitempty"`
+
+	// BudgetConsumed is the opcode budget consumed by the approval program
+	BudgetConsumed uint64 `json:"budget-consumed,omitempty" codec:"budget-consumed,omitempty"`
+
+	// Disassembly is the disassembled approval program, one line per element
+	Disassembly []string `json:"disassembly" codec:"disassembly"`
+
+	// GlobalDelta are the changes to the application's global state
+	GlobalDelta []EvalDeltaKeyValue `json:"global-delta,omitempty" codec:"global-delta,omitempty"`
+
+	// LocalDeltas are the changes to accounts' local state
+	LocalDeltas []AccountStateDelta `json:"local-deltas,omitempty" codec:"local-deltas,omitempty"`
+
+	// LogicSigDisassembly is the disassembled LogicSig program, one line per element
+	LogicSigDisassembly []string `json:"logic-sig-disassembly,omitempty" codec:"logic-sig-disassembly,omitempty"`
+
+	// LogicSigMessages are messages from the LogicSig program, such as PASS or REJECT
+	LogicSigMessages []string `json:"logic-sig-messages,omitempty" codec:"logic-sig-messages,omitempty"`
+
+	// LogicSigTrace is the program state after each step of the LogicSig program
+	LogicSigTrace []DryrunState `json:"logic-sig-trace,omitempty" codec:"logic-sig-trace,omitempty"`
+
+	// Logs are the logs emitted by the approval program
+	Logs [][]byte `json:"logs,omitempty" codec:"logs,omitempty"`
+}
+
+// DryrunState is the state of a program after one step
+type DryrunState struct {
+	// Error is the evaluation error, if any
+	Error string `json:"error,omitempty" codec:"error,omitempty"`
+
+	// Line is the line number of the step in the disassembly
+	Line uint64 `json:"line" codec:"line"`
+
+	// Pc is the program counter
+	Pc uint64 `json:"pc" codec:"pc"`
+
+	// Scratch is the scratch space
+	Scratch []TealValue `json:"scratch,omitempty" codec:"scratch,omitempty"`
+
+	// Stack is the stack, from bottom to top
+	Stack []TealValue `json:"stack" codec:"stack"`
+}
+
+// EvalDeltaKeyValue is a change to one key of an application's state
+type EvalDeltaKeyValue struct {
+	// Key is the base64 encoded key
+	Key string `json:"key" codec:"key"`
+
+	// Value is the change to the key's value
+	Value EvalDelta `json:"value" codec:"value"`
+}
+
+// EvalDelta is a change to a TEAL value
+type EvalDelta struct {
+	// Action is 1 to set bytes, 2 to set a uint and 3 to delete the value
+	Action uint64 `json:"action" codec:"action"`
+
+	// Bytes is the base64 encoded bytes value set
+	Bytes string `json:"bytes,omitempty" codec:"bytes,omitempty"`
+
+	// Uint is the uint value set
+	Uint uint64 `json:"uint,omitempty" codec:"uint,omitempty"`
+}
+
+// AccountStateDelta is the changes to the local state of an account
+type AccountStateDelta struct {
+	// Address is the account's address
+	Address string `json:"address" codec:"address"`
+
+	// Delta are the changes to the account's local state
+	Delta []EvalDeltaKeyValue `json:"delta" codec:"delta"`
+}
@@ -0,0 +1,198 @@
+package models
+
+// Transaction is a transaction as returned by the indexer. Exactly one of the
+// type specific fields is set, according to Type.
+type Transaction struct {
+	// ApplicationTransaction is set for appl transactions
+	ApplicationTransaction TransactionApplication `json:"application-transaction,omitempty" codec:"application-transaction,omitempty"`
+
+	// AssetConfigTransaction is set for acfg transactions
+	AssetConfigTransaction TransactionAssetConfig `json:"asset-config-transaction,omitempty" codec:"asset-config-transaction,omitempty"`
+
+	// AssetFreezeTransaction is set for afrz transactions
+	AssetFreezeTransaction TransactionAssetFreeze `json:"asset-freeze-transaction,omitempty" codec:"asset-freeze-transaction,omitempty"`
+
+	// AssetTransferTransaction is set for axfer transactions
+	AssetTransferTransaction TransactionAssetTransfer `json:"asset-transfer-transaction,omitempty" codec:"asset-transfer-transaction,omitempty"`
+
+	// AuthAddr is the address used to sign the transaction, if it differs from the sender
+	AuthAddr string `json:"auth-addr,omitempty" codec:"auth-addr,omitempty"`
+
+	// CloseRewards are the rewards applied to the close remainder to account
+	CloseRewards uint64 `json:"close-rewards,omitempty" codec:"close-rewards,omitempty"`
+
+	// ClosingAmount is the amount of MicroAlgos transferred to the close remainder to account
+	ClosingAmount uint64 `json:"closing-amount,omitempty" codec:"closing-amount,omitempty"`
+
+	// ConfirmedRound is the round when the transaction was confirmed
+	ConfirmedRound uint64 `json:"confirmed-round,omitempty" codec:"confirmed-round,omitempty"`
+
+	// CreatedApplicationIndex is the index of the application created by this transaction, if any
+	CreatedApplicationIndex uint64 `json:"created-application-index,omitempty" codec:"created-application-index,omitempty"`
+
+	// CreatedAssetIndex is the index of the asset created by this transaction, if any
+	CreatedAssetIndex uint64 `json:"created-asset-index,omitempty" codec:"created-asset-index,omitempty"`
+
+	// Fee is the transaction fee
+	Fee uint64 `json:"fee" codec:"fee"`
+
+	// FirstValid is the first valid round for this transaction
+	FirstValid uint64 `json:"first-valid" codec:"first-valid"`
+
+	// GenesisHash is the hash of the genesis block
+	GenesisHash []byte `json:"genesis-hash,omitempty" codec:"genesis-hash,omitempty"`
+
+	// GenesisID is the genesis block ID
+	GenesisID string `json:"genesis-id,omitempty" codec:"genesis-id,omitempty"`
+
+	// Group is the base64 encoded group ID, if any
+	Group []byte `json:"group,omitempty" codec:"group,omitempty"`
+
+	// ID is the transaction ID
+	ID string `json:"id,omitempty" codec:"id,omitempty"`
+
+	// InnerTxns are the inner transactions produced by application execution
+	InnerTxns []Transaction `json:"inner-txns,omitempty" codec:"inner-txns,omitempty"`
+
+	// IntraRoundOffset is the offset into the round where this transaction was confirmed
+	IntraRoundOffset uint64 `json:"intra-round-offset,omitempty" codec:"intra-round-offset,omitempty"`
+
+	// KeyregTransaction is set for keyreg transactions
+	KeyregTransaction TransactionKeyreg `json:"keyreg-transaction,omitempty" codec:"keyreg-transaction,omitempty"`
+
+	// LastValid is the last valid round for this transaction
+	LastValid uint64 `json:"last-valid" codec:"last-valid"`
+
+	// Lease enforces mutual exclusion of transactions
+	Lease []byte `json:"lease,omitempty" codec:"lease,omitempty"`
+
+	// Logs are the logs emitted by application execution
+	Logs [][]byte `json:"logs,omitempty" codec:"logs,omitempty"`
+
+	// Note is free form data
+	Note []byte `json:"note,omitempty" codec:"note,omitempty"`
+
+	// PaymentTransaction is set for pay transactions
+	PaymentTransaction TransactionPayment `json:"payment-transaction,omitempty" codec:"payment-transaction,omitempty"`
+
+	// ReceiverRewards are the rewards applied to the receiver account
+	ReceiverRewards uint64 `json:"receiver-rewards,omitempty" codec:"receiver-rewards,omitempty"`
+
+	// RekeyTo is the address the sender was rekeyed to, if any
+	RekeyTo string `json:"rekey-to,omitempty" codec:"rekey-to,omitempty"`
+
+	// RoundTime is the time the block containing this transaction was created, in seconds since the epoch
+	RoundTime uint64 `json:"round-time,omitempty" codec:"round-time,omitempty"`
+
+	// Sender is the address of the sender
+	Sender string `json:"sender" codec:"sender"`
+
+	// SenderRewards are the rewards applied to the sender account
+	SenderRewards uint64 `json:"sender-rewards,omitempty" codec:"sender-rewards,omitempty"`
+
+	// Signature is the signature of the transaction
+	Signature TransactionSignature `json:"signature,omitempty" codec:"signature,omitempty"`
+
+	// Type indicates what type of transaction this is: pay, keyreg, acfg, axfer, afrz or appl
+	Type string `json:"tx-type,omitempty" codec:"tx-type,omitempty"`
+}
+
+// Flatten returns the transaction followed by its inner transactions,
+// recursively, in the order they were executed
+func (tx Transaction) Flatten() []Transaction {
+	flat := []Transaction{tx}
+	for _, inner := range tx.InnerTxns {
+		flat = append(flat, inner.Flatten()...)
+	}
+	return flat
+}
+
+// TransactionPayment holds the fields of a payment transaction
+type TransactionPayment struct {
+	Amount           uint64 `json:"amount" codec:"amount"`
+	CloseAmount      uint64 `json:"close-amount,omitempty" codec:"close-amount,omitempty"`
+	CloseRemainderTo string `json:"close-remainder-to,omitempty" codec:"close-remainder-to,omitempty"`
+	Receiver         string `json:"receiver" codec:"receiver"`
+}
+
+// TransactionAssetTransfer holds the fields of an asset transfer transaction
+type TransactionAssetTransfer struct {
+	Amount      uint64 `json:"amount" codec:"amount"`
+	AssetID     uint64 `json:"asset-id" codec:"asset-id"`
+	CloseAmount uint64 `json:"close-amount,omitempty" codec:"close-amount,omitempty"`
+	CloseTo     string `json:"close-to,omitempty" codec:"close-to,omitempty"`
+	Receiver    string `json:"receiver" codec:"receiver"`
+
+	// Sender is the account being clawed back from, if this is a clawback
+	Sender string `json:"sender,omitempty" codec:"sender,omitempty"`
+}
+
+// TransactionAssetConfig holds the fields of an asset configuration transaction
+type TransactionAssetConfig struct {
+	// AssetID is the asset being configured or destroyed, zero on creation
+	AssetID uint64      `json:"asset-id,omitempty" codec:"asset-id,omitempty"`
+	Params  AssetParams `json:"params,omitempty" codec:"params,omitempty"`
+}
+
+// TransactionAssetFreeze holds the fields of an asset freeze transaction
+type TransactionAssetFreeze struct {
+	Address         string `json:"address" codec:"address"`
+	AssetID         uint64 `json:"asset-id" codec:"asset-id"`
+	NewFreezeStatus bool   `json:"new-freeze-status" codec:"new-freeze-status"`
+}
+
+// TransactionKeyreg holds the fields of a key registration transaction
+type TransactionKeyreg struct {
+	NonParticipation          bool   `json:"non-participation,omitempty" codec:"non-participation,omitempty"`
+	SelectionParticipationKey []byte `json:"selection-participation-key,omitempty" codec:"selection-participation-key,omitempty"`
+	StateProofKey             []byte `json:"state-proof-key,omitempty" codec:"state-proof-key,omitempty"`
+	VoteFirstValid            uint64 `json:"vote-first-valid,omitempty" codec:"vote-first-valid,omitempty"`
+	VoteKeyDilution           uint64 `json:"vote-key-dilution,omitempty" codec:"vote-key-dilution,omitempty"`
+	VoteLastValid             uint64 `json:"vote-last-valid,omitempty" codec:"vote-last-valid,omitempty"`
+	VoteParticipationKey      []byte `json:"vote-participation-key,omitempty" codec:"vote-participation-key,omitempty"`
+}
+
+// TransactionApplication holds the fields of an application call transaction
+type TransactionApplication struct {
+	Accounts          []string               `json:"accounts,omitempty" codec:"accounts,omitempty"`
+	ApplicationArgs   [][]byte               `json:"application-args,omitempty" codec:"application-args,omitempty"`
+	ApplicationID     uint64                 `json:"application-id" codec:"application-id"`
+	ApprovalProgram   []byte                 `json:"approval-program,omitempty" codec:"approval-program,omitempty"`
+	ClearStateProgram []byte                 `json:"clear-state-program,omitempty" codec:"clear-state-program,omitempty"`
+	ExtraProgramPages uint64                 `json:"extra-program-pages,omitempty" codec:"extra-program-pages,omitempty"`
+	ForeignApps       []uint64               `json:"foreign-apps,omitempty" codec:"foreign-apps,omitempty"`
+	ForeignAssets     []uint64               `json:"foreign-assets,omitempty" codec:"foreign-assets,omitempty"`
+	GlobalStateSchema ApplicationStateSchema `json:"global-state-schema,omitempty" codec:"global-state-schema,omitempty"`
+	LocalStateSchema  ApplicationStateSchema `json:"local-state-schema,omitempty" codec:"local-state-schema,omitempty"`
+
+	// OnCompletion is one of noop, optin, closeout, clear, update or delete
+	OnCompletion string `json:"on-completion,omitempty" codec:"on-completion,omitempty"`
+}
+
+// TransactionSignature holds exactly one of the three kinds of transaction signature
+type TransactionSignature struct {
+	Logicsig TransactionSignatureLogicsig `json:"logicsig,omitempty" codec:"logicsig,omitempty"`
+	Multisig TransactionSignatureMultisig `json:"multisig,omitempty" codec:"multisig,omitempty"`
+	Sig      []byte                       `json:"sig,omitempty" codec:"sig,omitempty"`
+}
+
+// TransactionSignatureLogicsig is a logic signature
+type TransactionSignatureLogicsig struct {
+	Args              [][]byte                     `json:"args,omitempty" codec:"args,omitempty"`
+	Logic             []byte                       `json:"logic" codec:"logic"`
+	MultisigSignature TransactionSignatureMultisig `json:"multisig-signature,omitempty" codec:"multisig-signature,omitempty"`
+	Signature         []byte                       `json:"signature,omitempty" codec:"signature,omitempty"`
+}
+
+// TransactionSignatureMultisig is a multisig signature
+type TransactionSignatureMultisig struct {
+	Subsignature []TransactionSignatureMultisigSubsignature `json:"subsignature,omitempty" codec:"subsignature,omitempty"`
+	Threshold    uint64                                     `json:"threshold,omitempty" codec:"threshold,omitempty"`
+	Version      uint64                                     `json:"version,omitempty" codec:"version,omitempty"`
+}
+
+// TransactionSignatureMultisigSubsignature is one key's part of a multisig signature
+type TransactionSignatureMultisigSubsignature struct {
+	PublicKey []byte `json:"public-key,omitempty" codec:"public-key,omitempty"`
+	Signature []byte `json:"signature,omitempty" codec:"signature,omitempty"`
+}
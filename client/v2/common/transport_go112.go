@@ -0,0 +1,10 @@
+//go:build !go1.13
+// +build !go1.13
+
+package common
+
+import "net/http"
+
+// attemptHTTP2 makes t use HTTP/2 with servers that support it, which
+// transports without a TLSNextProto map already do before Go 1.13
+func attemptHTTP2(t *http.Transport) {}
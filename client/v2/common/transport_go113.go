@@ -0,0 +1,12 @@
+//go:build go1.13
+// +build go1.13
+
+package common
+
+import "net/http"
+
+// attemptHTTP2 makes t use HTTP/2 with servers that support it. Since Go 1.13,
+// a transport with its own DialContext only does if ForceAttemptHTTP2 is set.
+func attemptHTTP2(t *http.Transport) {
+	t.ForceAttemptHTTP2 = true
+}
@@ -0,0 +1,36 @@
+//go:build go1.14
+// +build go1.14
+
+package common
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionPoolHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+	rootCAs := server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	for _, disable := range []bool{false, true} {
+		transport := ConnectionPool{DisableHTTP2: disable}.transport()
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+		resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+		if disable {
+			require.Equal(t, 1, resp.ProtoMajor)
+		} else {
+			require.Equal(t, 2, resp.ProtoMajor)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package indexer
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+type searchAccountsParams struct {
+	ApplicationID       uint64 `url:"application-id,omitempty"`
+	AssetID             uint64 `url:"asset-id,omitempty"`
+	AuthAddr            string `url:"auth-addr,omitempty"`
+	CurrencyGreaterThan uint64 `url:"currency-greater-than,omitempty"`
+	CurrencyLessThan    uint64 `url:"currency-less-than,omitempty"`
+	IncludeAll          bool   `url:"include-all,omitempty"`
+	Limit               uint64 `url:"limit,omitempty"`
+	Next                string `url:"next,omitempty"`
+	Round               uint64 `url:"round,omitempty"`
+}
+
+type lookupAccountByIDParams struct {
+	IncludeAll bool   `url:"include-all,omitempty"`
+	Round      uint64 `url:"round,omitempty"`
+}
+
+// SearchAccounts searches for accounts matching the given filters
+type SearchAccounts struct {
+	c *Client
+	p searchAccountsParams
+}
+
+// ApplicationID only includes results for this application
+func (s *SearchAccounts) ApplicationID(applicationID uint64) *SearchAccounts {
+	s.p.ApplicationID = applicationID
+	return s
+}
+
+// AssetID only includes results for this asset
+func (s *SearchAccounts) AssetID(assetID uint64) *SearchAccounts {
+	s.p.AssetID = assetID
+	return s
+}
+
+// AuthAddress only includes accounts rekeyed to this address
+func (s *SearchAccounts) AuthAddress(authAddr string) *SearchAccounts {
+	s.p.AuthAddr = authAddr
+	return s
+}
+
+// CurrencyGreaterThan only includes results with a currency value greater than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *SearchAccounts) CurrencyGreaterThan(greaterThan uint64) *SearchAccounts {
+	s.p.CurrencyGreaterThan = greaterThan
+	return s
+}
+
+// CurrencyLessThan only includes results with a currency value less than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *SearchAccounts) CurrencyLessThan(lessThan uint64) *SearchAccounts {
+	s.p.CurrencyLessThan = lessThan
+	return s
+}
+
+// IncludeAll includes closed and deleted objects in the results
+func (s *SearchAccounts) IncludeAll(includeAll bool) *SearchAccounts {
+	s.p.IncludeAll = includeAll
+	return s
+}
+
+// Limit is the maximum number of results to return in a page
+func (s *SearchAccounts) Limit(limit uint64) *SearchAccounts {
+	s.p.Limit = limit
+	return s
+}
+
+// Next is the next-token of the previous page, used to fetch the following page
+func (s *SearchAccounts) Next(nextToken string) *SearchAccounts {
+	s.p.Next = nextToken
+	return s
+}
+
+// Round only includes results for this round
+func (s *SearchAccounts) Round(round uint64) *SearchAccounts {
+	s.p.Round = round
+	return s
+}
+
+// Do performs the HTTP request
+func (s *SearchAccounts) Do(ctx context.Context, headers ...*common.Header) (response models.AccountsResponse, err error) {
+	err = s.c.get(ctx, &response, "/v2/accounts", s.p, headers)
+	return
+}
+
+// LookupAccountByID looks up an account at a round
+type LookupAccountByID struct {
+	c       *Client
+	address string
+	p       lookupAccountByIDParams
+}
+
+// IncludeAll includes closed and deleted objects in the results
+func (s *LookupAccountByID) IncludeAll(includeAll bool) *LookupAccountByID {
+	s.p.IncludeAll = includeAll
+	return s
+}
+
+// Round only includes results for this round
+func (s *LookupAccountByID) Round(round uint64) *LookupAccountByID {
+	s.p.Round = round
+	return s
+}
+
+// Do performs the HTTP request
+func (s *LookupAccountByID) Do(ctx context.Context, headers ...*common.Header) (response models.AccountResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/accounts/%s", common.EscapeParams(s.address)...), s.p, headers)
+	return
+}
+
+// LookupAccountTransactions searches for transactions involving an account
+type LookupAccountTransactions struct {
+	c       *Client
+	address string
+	p       transactionParams
+}
+
+// AfterTime only includes results after the given time
+func (s *LookupAccountTransactions) AfterTime(after time.Time) *LookupAccountTransactions {
+	s.p.AfterTime = after.Format(time.RFC3339)
+	return s
+}
+
+// ApplicationID only includes results for this application
+func (s *LookupAccountTransactions) ApplicationID(applicationID uint64) *LookupAccountTransactions {
+	s.p.ApplicationID = applicationID
+	return s
+}
+
+// AssetID only includes results for this asset
+func (s *LookupAccountTransactions) AssetID(assetID uint64) *LookupAccountTransactions {
+	s.p.AssetID = assetID
+	return s
+}
+
+// BeforeTime only includes results before the given time
+func (s *LookupAccountTransactions) BeforeTime(before time.Time) *LookupAccountTransactions {
+	s.p.BeforeTime = before.Format(time.RFC3339)
+	return s
+}
+
+// CurrencyGreaterThan only includes results with a currency value greater than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *LookupAccountTransactions) CurrencyGreaterThan(greaterThan uint64) *LookupAccountTransactions {
+	s.p.CurrencyGreaterThan = greaterThan
+	return s
+}
+
+// CurrencyLessThan only includes results with a currency value less than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *LookupAccountTransactions) CurrencyLessThan(lessThan uint64) *LookupAccountTransactions {
+	s.p.CurrencyLessThan = lessThan
+	return s
+}
+
+// Limit is the maximum number of results to return in a page
+func (s *LookupAccountTransactions) Limit(limit uint64) *LookupAccountTransactions {
+	s.p.Limit = limit
+	return s
+}
+
+// MaxRound only includes results at or before this round
+func (s *LookupAccountTransactions) MaxRound(round uint64) *LookupAccountTransactions {
+	s.p.MaxRound = round
+	return s
+}
+
+// MinRound only includes results at or after this round
+func (s *LookupAccountTransactions) MinRound(round uint64) *LookupAccountTransactions {
+	s.p.MinRound = round
+	return s
+}
+
+// Next is the next-token of the previous page, used to fetch the following page
+func (s *LookupAccountTransactions) Next(nextToken string) *LookupAccountTransactions {
+	s.p.Next = nextToken
+	return s
+}
+
+// NotePrefix only includes transactions whose note starts with prefix
+func (s *LookupAccountTransactions) NotePrefix(prefix []byte) *LookupAccountTransactions {
+	s.p.NotePrefix = base64.StdEncoding.EncodeToString(prefix)
+	return s
+}
+
+// RekeyTo only includes transactions that rekey an account
+func (s *LookupAccountTransactions) RekeyTo(rekeyTo bool) *LookupAccountTransactions {
+	s.p.RekeyTo = rekeyTo
+	return s
+}
+
+// Round only includes results for this round
+func (s *LookupAccountTransactions) Round(round uint64) *LookupAccountTransactions {
+	s.p.Round = round
+	return s
+}
+
+// SigType only includes transactions signed with this kind of signature
+func (s *LookupAccountTransactions) SigType(sigType SigType) *LookupAccountTransactions {
+	s.p.SigType = string(sigType)
+	return s
+}
+
+// TxType only includes transactions of this type
+func (s *LookupAccountTransactions) TxType(txType types.TxType) *LookupAccountTransactions {
+	s.p.TxType = string(txType)
+	return s
+}
+
+// TXID looks up a specific transaction by ID
+func (s *LookupAccountTransactions) TXID(txid string) *LookupAccountTransactions {
+	s.p.TXID = txid
+	return s
+}
+
+// Do performs the HTTP request
+func (s *LookupAccountTransactions) Do(ctx context.Context, headers ...*common.Header) (response models.TransactionsResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/accounts/%s/transactions", common.EscapeParams(s.address)...), s.p, headers)
+	return
+}
@@ -0,0 +1,125 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+type searchForApplicationsParams struct {
+	ApplicationID uint64 `url:"application-id,omitempty"`
+	Creator       string `url:"creator,omitempty"`
+	IncludeAll    bool   `url:"include-all,omitempty"`
+	Limit         uint64 `url:"limit,omitempty"`
+	Next          string `url:"next,omitempty"`
+}
+
+// SearchForApplications searches for applications matching the given filters
+type SearchForApplications struct {
+	c *Client
+	p searchForApplicationsParams
+}
+
+// ApplicationID only includes results for this application
+func (s *SearchForApplications) ApplicationID(applicationID uint64) *SearchForApplications {
+	s.p.ApplicationID = applicationID
+	return s
+}
+
+// Creator only includes results created by this address
+func (s *SearchForApplications) Creator(creator string) *SearchForApplications {
+	s.p.Creator = creator
+	return s
+}
+
+// IncludeAll includes closed and deleted objects in the results
+func (s *SearchForApplications) IncludeAll(includeAll bool) *SearchForApplications {
+	s.p.IncludeAll = includeAll
+	return s
+}
+
+// Limit is the maximum number of results to return in a page
+func (s *SearchForApplications) Limit(limit uint64) *SearchForApplications {
+	s.p.Limit = limit
+	return s
+}
+
+// Next is the next-token of the previous page, used to fetch the following page
+func (s *SearchForApplications) Next(nextToken string) *SearchForApplications {
+	s.p.Next = nextToken
+	return s
+}
+
+// Do performs the HTTP request
+func (s *SearchForApplications) Do(ctx context.Context, headers ...*common.Header) (response models.ApplicationsResponse, err error) {
+	err = s.c.get(ctx, &response, "/v2/applications", s.p, headers)
+	return
+}
+
+// LookupApplicationByID looks up an application
+type LookupApplicationByID struct {
+	c             *Client
+	applicationID uint64
+	p             includeAllParams
+}
+
+// IncludeAll includes closed and deleted objects in the results
+func (s *LookupApplicationByID) IncludeAll(includeAll bool) *LookupApplicationByID {
+	s.p.IncludeAll = includeAll
+	return s
+}
+
+// Do performs the HTTP request
+func (s *LookupApplicationByID) Do(ctx context.Context, headers ...*common.Header) (response models.ApplicationResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/applications/%d", s.applicationID), s.p, headers)
+	return
+}
+
+type searchForApplicationBoxesParams struct {
+	Limit uint64 `url:"limit,omitempty"`
+	Next  string `url:"next,omitempty"`
+}
+
+// SearchForApplicationBoxes lists the names of the boxes of an application
+type SearchForApplicationBoxes struct {
+	c             *Client
+	applicationID uint64
+	p             searchForApplicationBoxesParams
+}
+
+// Limit is the maximum number of results to return in a page
+func (s *SearchForApplicationBoxes) Limit(limit uint64) *SearchForApplicationBoxes {
+	s.p.Limit = limit
+	return s
+}
+
+// Next is the next-token of the previous page, used to fetch the following page
+func (s *SearchForApplicationBoxes) Next(nextToken string) *SearchForApplicationBoxes {
+	s.p.Next = nextToken
+	return s
+}
+
+// Do performs the HTTP request
+func (s *SearchForApplicationBoxes) Do(ctx context.Context, headers ...*common.Header) (response models.BoxesResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/applications/%d/boxes", s.applicationID), s.p, headers)
+	return
+}
+
+type lookupApplicationBoxParams struct {
+	Name string `url:"name"`
+}
+
+// LookupApplicationBoxByIDAndName looks up a box of an application, with its value
+type LookupApplicationBoxByIDAndName struct {
+	c             *Client
+	applicationID uint64
+	p             lookupApplicationBoxParams
+}
+
+// Do performs the HTTP request
+func (s *LookupApplicationBoxByIDAndName) Do(ctx context.Context, headers ...*common.Header) (response models.Box, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/applications/%d/box", s.applicationID), s.p, headers)
+	return
+}
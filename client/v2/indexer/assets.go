@@ -0,0 +1,265 @@
+package indexer
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+type searchForAssetsParams struct {
+	AssetID    uint64 `url:"asset-id,omitempty"`
+	Creator    string `url:"creator,omitempty"`
+	IncludeAll bool   `url:"include-all,omitempty"`
+	Limit      uint64 `url:"limit,omitempty"`
+	Name       string `url:"name,omitempty"`
+	Next       string `url:"next,omitempty"`
+	Unit       string `url:"unit,omitempty"`
+}
+
+type lookupAssetBalancesParams struct {
+	CurrencyGreaterThan uint64 `url:"currency-greater-than,omitempty"`
+	CurrencyLessThan    uint64 `url:"currency-less-than,omitempty"`
+	IncludeAll          bool   `url:"include-all,omitempty"`
+	Limit               uint64 `url:"limit,omitempty"`
+	Next                string `url:"next,omitempty"`
+}
+
+type includeAllParams struct {
+	IncludeAll bool `url:"include-all,omitempty"`
+}
+
+// SearchForAssets searches for assets matching the given filters
+type SearchForAssets struct {
+	c *Client
+	p searchForAssetsParams
+}
+
+// AssetID only includes results for this asset
+func (s *SearchForAssets) AssetID(assetID uint64) *SearchForAssets {
+	s.p.AssetID = assetID
+	return s
+}
+
+// Creator only includes results created by this address
+func (s *SearchForAssets) Creator(creator string) *SearchForAssets {
+	s.p.Creator = creator
+	return s
+}
+
+// IncludeAll includes closed and deleted objects in the results
+func (s *SearchForAssets) IncludeAll(includeAll bool) *SearchForAssets {
+	s.p.IncludeAll = includeAll
+	return s
+}
+
+// Limit is the maximum number of results to return in a page
+func (s *SearchForAssets) Limit(limit uint64) *SearchForAssets {
+	s.p.Limit = limit
+	return s
+}
+
+// AssetName only includes assets with this name
+func (s *SearchForAssets) AssetName(name string) *SearchForAssets {
+	s.p.Name = name
+	return s
+}
+
+// Next is the next-token of the previous page, used to fetch the following page
+func (s *SearchForAssets) Next(nextToken string) *SearchForAssets {
+	s.p.Next = nextToken
+	return s
+}
+
+// Unit only includes assets with this unit name
+func (s *SearchForAssets) Unit(unit string) *SearchForAssets {
+	s.p.Unit = unit
+	return s
+}
+
+// Do performs the HTTP request
+func (s *SearchForAssets) Do(ctx context.Context, headers ...*common.Header) (response models.AssetsResponse, err error) {
+	err = s.c.get(ctx, &response, "/v2/assets", s.p, headers)
+	return
+}
+
+// LookupAssetByID looks up an asset
+type LookupAssetByID struct {
+	c       *Client
+	assetID uint64
+	p       includeAllParams
+}
+
+// IncludeAll includes closed and deleted objects in the results
+func (s *LookupAssetByID) IncludeAll(includeAll bool) *LookupAssetByID {
+	s.p.IncludeAll = includeAll
+	return s
+}
+
+// Do performs the HTTP request
+func (s *LookupAssetByID) Do(ctx context.Context, headers ...*common.Header) (response models.AssetResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/assets/%d", s.assetID), s.p, headers)
+	return
+}
+
+// LookupAssetBalances lists the accounts holding an asset
+type LookupAssetBalances struct {
+	c       *Client
+	assetID uint64
+	p       lookupAssetBalancesParams
+}
+
+// CurrencyGreaterThan only includes results with a currency value greater than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *LookupAssetBalances) CurrencyGreaterThan(greaterThan uint64) *LookupAssetBalances {
+	s.p.CurrencyGreaterThan = greaterThan
+	return s
+}
+
+// CurrencyLessThan only includes results with a currency value less than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *LookupAssetBalances) CurrencyLessThan(lessThan uint64) *LookupAssetBalances {
+	s.p.CurrencyLessThan = lessThan
+	return s
+}
+
+// IncludeAll includes closed and deleted objects in the results
+func (s *LookupAssetBalances) IncludeAll(includeAll bool) *LookupAssetBalances {
+	s.p.IncludeAll = includeAll
+	return s
+}
+
+// Limit is the maximum number of results to return in a page
+func (s *LookupAssetBalances) Limit(limit uint64) *LookupAssetBalances {
+	s.p.Limit = limit
+	return s
+}
+
+// Next is the next-token of the previous page, used to fetch the following page
+func (s *LookupAssetBalances) Next(nextToken string) *LookupAssetBalances {
+	s.p.Next = nextToken
+	return s
+}
+
+// Do performs the HTTP request
+func (s *LookupAssetBalances) Do(ctx context.Context, headers ...*common.Header) (response models.AssetBalancesResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/assets/%d/balances", s.assetID), s.p, headers)
+	return
+}
+
+// LookupAssetTransactions searches for transactions of an asset
+type LookupAssetTransactions struct {
+	c       *Client
+	assetID uint64
+	p       transactionParams
+}
+
+// Address only includes transactions with this address in one of the transaction fields
+func (s *LookupAssetTransactions) Address(address string) *LookupAssetTransactions {
+	s.p.Address = address
+	return s
+}
+
+// AddressRole narrows Address to transactions where it is the sender, receiver or freeze target
+func (s *LookupAssetTransactions) AddressRole(role AddressRole) *LookupAssetTransactions {
+	s.p.AddressRole = string(role)
+	return s
+}
+
+// AfterTime only includes results after the given time
+func (s *LookupAssetTransactions) AfterTime(after time.Time) *LookupAssetTransactions {
+	s.p.AfterTime = after.Format(time.RFC3339)
+	return s
+}
+
+// BeforeTime only includes results before the given time
+func (s *LookupAssetTransactions) BeforeTime(before time.Time) *LookupAssetTransactions {
+	s.p.BeforeTime = before.Format(time.RFC3339)
+	return s
+}
+
+// CurrencyGreaterThan only includes results with a currency value greater than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *LookupAssetTransactions) CurrencyGreaterThan(greaterThan uint64) *LookupAssetTransactions {
+	s.p.CurrencyGreaterThan = greaterThan
+	return s
+}
+
+// CurrencyLessThan only includes results with a currency value less than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *LookupAssetTransactions) CurrencyLessThan(lessThan uint64) *LookupAssetTransactions {
+	s.p.CurrencyLessThan = lessThan
+	return s
+}
+
+// ExcludeCloseTo excludes transactions that only match because of their close-to address
+func (s *LookupAssetTransactions) ExcludeCloseTo(exclude bool) *LookupAssetTransactions {
+	s.p.ExcludeCloseTo = exclude
+	return s
+}
+
+// Limit is the maximum number of results to return in a page
+func (s *LookupAssetTransactions) Limit(limit uint64) *LookupAssetTransactions {
+	s.p.Limit = limit
+	return s
+}
+
+// MaxRound only includes results at or before this round
+func (s *LookupAssetTransactions) MaxRound(round uint64) *LookupAssetTransactions {
+	s.p.MaxRound = round
+	return s
+}
+
+// MinRound only includes results at or after this round
+func (s *LookupAssetTransactions) MinRound(round uint64) *LookupAssetTransactions {
+	s.p.MinRound = round
+	return s
+}
+
+// Next is the next-token of the previous page, used to fetch the following page
+func (s *LookupAssetTransactions) Next(nextToken string) *LookupAssetTransactions {
+	s.p.Next = nextToken
+	return s
+}
+
+// NotePrefix only includes transactions whose note starts with prefix
+func (s *LookupAssetTransactions) NotePrefix(prefix []byte) *LookupAssetTransactions {
+	s.p.NotePrefix = base64.StdEncoding.EncodeToString(prefix)
+	return s
+}
+
+// RekeyTo only includes transactions that rekey an account
+func (s *LookupAssetTransactions) RekeyTo(rekeyTo bool) *LookupAssetTransactions {
+	s.p.RekeyTo = rekeyTo
+	return s
+}
+
+// Round only includes results for this round
+func (s *LookupAssetTransactions) Round(round uint64) *LookupAssetTransactions {
+	s.p.Round = round
+	return s
+}
+
+// SigType only includes transactions signed with this kind of signature
+func (s *LookupAssetTransactions) SigType(sigType SigType) *LookupAssetTransactions {
+	s.p.SigType = string(sigType)
+	return s
+}
+
+// TxType only includes transactions of this type
+func (s *LookupAssetTransactions) TxType(txType types.TxType) *LookupAssetTransactions {
+	s.p.TxType = string(txType)
+	return s
+}
+
+// TXID looks up a specific transaction by ID
+func (s *LookupAssetTransactions) TXID(txid string) *LookupAssetTransactions {
+	s.p.TXID = txid
+	return s
+}
+
+// Do performs the HTTP request
+func (s *LookupAssetTransactions) Do(ctx context.Context, headers ...*common.Header) (response models.TransactionsResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/assets/%d/transactions", s.assetID), s.p, headers)
+	return
+}
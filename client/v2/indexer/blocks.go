@@ -0,0 +1,32 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// HealthCheck returns the indexer's health
+type HealthCheck struct {
+	c *Client
+}
+
+// Do performs the HTTP request
+func (s *HealthCheck) Do(ctx context.Context, headers ...*common.Header) (response models.HealthCheckResponse, err error) {
+	err = s.c.get(ctx, &response, "/health", nil, headers)
+	return
+}
+
+// LookupBlock looks up the block of a round
+type LookupBlock struct {
+	c     *Client
+	round uint64
+}
+
+// Do performs the HTTP request
+func (s *LookupBlock) Do(ctx context.Context, headers ...*common.Header) (response models.Block, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/blocks/%d", s.round), nil, headers)
+	return
+}
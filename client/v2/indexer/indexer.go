@@ -0,0 +1,129 @@
+// Package indexer is a client for the indexer v2 REST API.
+//
+// Each query is a request builder: filters are set with chained methods and the
+// request is sent by Do, which takes a context.Context:
+//
+//	result, err := client.SearchForTransactions().
+//		Address(addr).AddressRole(indexer.AddressRoleSender).
+//		MinRound(1000).Limit(100).Do(ctx)
+//
+// Search results are paginated: each page carries a NextToken which, passed
+// to Next, fetches the following page.
+//
+// A Client is safe for concurrent use by multiple goroutines, but the request
+// builders it returns are not: make one for each request.
+package indexer
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+)
+
+const indexerAuthHeader = "X-Indexer-API-Token"
+
+// Client manages the REST interface for a calling user.
+type Client common.Client
+
+// get performs a GET request to the specific path against the server
+func (c *Client) get(ctx context.Context, response interface{}, path string, params interface{}, headers []*common.Header) error {
+	return (*common.Client)(c).Get(ctx, response, path, params, headers)
+}
+
+// MakeClient is the factory for constructing an IndexerClient for a given endpoint.
+func MakeClient(address string, apiToken string) (c *Client, err error) {
+	commonClient, err := common.MakeClient(address, indexerAuthHeader, apiToken)
+	c = (*Client)(commonClient)
+	return
+}
+
+// MakeClientWithHeaders is the factory for constructing a Client for a given endpoint with additional user defined headers.
+func MakeClientWithHeaders(address string, apiToken string, headers []*common.Header) (c *Client, err error) {
+	commonClient, err := common.MakeClientWithHeaders(address, indexerAuthHeader, apiToken, headers)
+	c = (*Client)(commonClient)
+	return
+}
+
+// MakeClientWithOptions is the factory for constructing a Client for a given endpoint, configured by options,
+// e.g. common.WithRetryPolicy.
+func MakeClientWithOptions(address string, apiToken string, options ...common.ClientOption) (c *Client, err error) {
+	commonClient, err := common.MakeClientWithOptions(address, indexerAuthHeader, apiToken, options...)
+	c = (*Client)(commonClient)
+	return
+}
+
+// HealthCheck returns the indexer's health
+func (c *Client) HealthCheck() *HealthCheck {
+	return &HealthCheck{c: c}
+}
+
+// SearchAccounts searches for accounts
+func (c *Client) SearchAccounts() *SearchAccounts {
+	return &SearchAccounts{c: c}
+}
+
+// LookupAccountByID looks up an account by address
+func (c *Client) LookupAccountByID(address string) *LookupAccountByID {
+	return &LookupAccountByID{c: c, address: address}
+}
+
+// LookupAccountTransactions searches for transactions involving an account
+func (c *Client) LookupAccountTransactions(address string) *LookupAccountTransactions {
+	return &LookupAccountTransactions{c: c, address: address}
+}
+
+// SearchForAssets searches for assets
+func (c *Client) SearchForAssets() *SearchForAssets {
+	return &SearchForAssets{c: c}
+}
+
+// LookupAssetByID looks up an asset by index
+func (c *Client) LookupAssetByID(assetID uint64) *LookupAssetByID {
+	return &LookupAssetByID{c: c, assetID: assetID}
+}
+
+// LookupAssetBalances lists the accounts holding an asset
+func (c *Client) LookupAssetBalances(assetID uint64) *LookupAssetBalances {
+	return &LookupAssetBalances{c: c, assetID: assetID}
+}
+
+// LookupAssetTransactions searches for transactions of an asset
+func (c *Client) LookupAssetTransactions(assetID uint64) *LookupAssetTransactions {
+	return &LookupAssetTransactions{c: c, assetID: assetID}
+}
+
+// SearchForApplications searches for applications
+func (c *Client) SearchForApplications() *SearchForApplications {
+	return &SearchForApplications{c: c}
+}
+
+// LookupApplicationByID looks up an application by index
+func (c *Client) LookupApplicationByID(applicationID uint64) *LookupApplicationByID {
+	return &LookupApplicationByID{c: c, applicationID: applicationID}
+}
+
+// SearchForApplicationBoxes lists the names of the boxes of an application
+func (c *Client) SearchForApplicationBoxes(applicationID uint64) *SearchForApplicationBoxes {
+	return &SearchForApplicationBoxes{c: c, applicationID: applicationID}
+}
+
+// LookupApplicationBoxByIDAndName looks up the box of an application named name
+func (c *Client) LookupApplicationBoxByIDAndName(applicationID uint64, name []byte) *LookupApplicationBoxByIDAndName {
+	return &LookupApplicationBoxByIDAndName{c: c, applicationID: applicationID, p: lookupApplicationBoxParams{Name: "b64:" + base64.StdEncoding.EncodeToString(name)}}
+}
+
+// SearchForTransactions searches for transactions
+func (c *Client) SearchForTransactions() *SearchForTransactions {
+	return &SearchForTransactions{c: c}
+}
+
+// LookupTransaction looks up a transaction by ID
+func (c *Client) LookupTransaction(txid string) *LookupTransaction {
+	return &LookupTransaction{c: c, txid: txid}
+}
+
+// LookupBlock looks up the block of a round
+func (c *Client) LookupBlock(round uint64) *LookupBlock {
+	return &LookupBlock{c: c, round: round}
+}
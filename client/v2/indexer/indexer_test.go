@@ -0,0 +1,73 @@
+package indexer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestSearchQueries(t *testing.T) {
+	const addr = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	var request string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r.URL.EscapedPath() + "?" + r.URL.RawQuery
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+	ctx := context.Background()
+	after := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	before := after.Add(time.Hour)
+
+	_, err = client.SearchAccounts().ApplicationID(1).AssetID(2).AuthAddress(addr).
+		CurrencyGreaterThan(3).CurrencyLessThan(4).IncludeAll(true).Limit(5).Next("n").Round(6).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "/v2/accounts?application-id=1&asset-id=2&auth-addr="+addr+
+		"&currency-greater-than=3&currency-less-than=4&include-all=true&limit=5&next=n&round=6", request)
+
+	_, err = client.LookupAccountTransactions(addr).AfterTime(after).BeforeTime(before).ApplicationID(1).AssetID(2).
+		CurrencyGreaterThan(3).CurrencyLessThan(4).Limit(5).MaxRound(7).MinRound(6).Next("n").NotePrefix([]byte("hi?")).
+		RekeyTo(true).Round(8).SigType(SigTypeMsig).TxType(types.PaymentTx).TXID("T").Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "/v2/accounts/"+addr+"/transactions?after-time=2020-01-02T03%3A04%3A05Z&application-id=1&asset-id=2"+
+		"&before-time=2020-01-02T04%3A04%3A05Z&currency-greater-than=3&currency-less-than=4&limit=5&max-round=7&min-round=6"+
+		"&next=n&note-prefix=aGk%2F&rekey-to=true&round=8&sig-type=msig&tx-type=pay&txid=T", request)
+
+	_, err = client.SearchForAssets().AssetID(1).Creator(addr).IncludeAll(true).Limit(5).AssetName("a b").Next("n").Unit("u&v").Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "/v2/assets?asset-id=1&creator="+addr+"&include-all=true&limit=5&name=a+b&next=n&unit=u%26v", request)
+
+	_, err = client.LookupAssetBalances(9).CurrencyGreaterThan(3).CurrencyLessThan(4).IncludeAll(true).Limit(5).Next("n").Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "/v2/assets/9/balances?currency-greater-than=3&currency-less-than=4&include-all=true&limit=5&next=n", request)
+
+	_, err = client.LookupAssetTransactions(9).Address(addr).AddressRole(AddressRoleReceiver).ExcludeCloseTo(true).
+		Limit(5).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "/v2/assets/9/transactions?address="+addr+"&address-role=receiver&exclude-close-to=true&limit=5", request)
+
+	_, err = client.SearchForApplications().ApplicationID(1).Creator(addr).IncludeAll(true).Limit(5).Next("n").Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "/v2/applications?application-id=1&creator="+addr+"&include-all=true&limit=5&next=n", request)
+
+	_, err = client.SearchForApplicationBoxes(1).Limit(5).Next("n").Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "/v2/applications/1/boxes?limit=5&next=n", request)
+
+	_, err = client.SearchForTransactions().Address(addr).AddressRole(AddressRoleFreezeTarget).AssetID(2).
+		SigType(SigTypeLsig).TxType(types.AssetFreezeTx).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "/v2/transactions?address="+addr+"&address-role=freeze-target&asset-id=2&sig-type=lsig&tx-type=afrz", request)
+
+	// unset filters are left out of the query
+	_, err = client.SearchForTransactions().Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "/v2/transactions?", request)
+}
@@ -0,0 +1,242 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// pager holds the state shared by all pagers: the token of the next page,
+// whether the results are exhausted, and the first error encountered
+type pager struct {
+	nextToken    string
+	done         bool
+	err          error
+	currentRound uint64
+}
+
+// advance fetches the next page with fetch, which returns the number of
+// results in the page, its next-token and current round
+func (p *pager) advance(fetch func(token string) (count int, nextToken string, currentRound uint64, err error)) bool {
+	if p.done || p.err != nil {
+		return false
+	}
+	count, nextToken, currentRound, err := fetch(p.nextToken)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	// the indexer may hand out a next-token with the last page, in which
+	// case the page after it is empty
+	if count == 0 {
+		p.done = true
+		return false
+	}
+	if nextToken == "" {
+		p.done = true
+	}
+	p.nextToken = nextToken
+	p.currentRound = currentRound
+	return true
+}
+
+// Err returns the error that stopped the iteration, if any
+func (p *pager) Err() error {
+	return p.err
+}
+
+// CurrentRound returns the round at which the current page was computed
+func (p *pager) CurrentRound() uint64 {
+	return p.currentRound
+}
+
+// TransactionPager iterates over the pages of a transaction query:
+//
+//	pager := client.SearchForTransactions().Address(addr).Pager(100)
+//	for pager.Next(ctx) {
+//		for _, txn := range pager.Transactions() {
+//			...
+//		}
+//	}
+//	if err := pager.Err(); err != nil {
+//		...
+//	}
+type TransactionPager struct {
+	pager
+	fetch func(ctx context.Context, nextToken string, headers []*common.Header) (models.TransactionsResponse, error)
+	page  []models.Transaction
+}
+
+// Next fetches the next page, returning false when there are no more results or an error occurred
+func (p *TransactionPager) Next(ctx context.Context, headers ...*common.Header) bool {
+	return p.advance(func(nextToken string) (int, string, uint64, error) {
+		response, err := p.fetch(ctx, nextToken, headers)
+		p.page = response.Transactions
+		return len(response.Transactions), response.NextToken, response.CurrentRound, err
+	})
+}
+
+// Transactions returns the current page
+func (p *TransactionPager) Transactions() []models.Transaction {
+	return p.page
+}
+
+// Pager returns a TransactionPager over the results of this search, fetching pageSize results at a time
+func (s *SearchForTransactions) Pager(pageSize uint64) *TransactionPager {
+	s.p.Limit = pageSize
+	return &TransactionPager{fetch: func(ctx context.Context, nextToken string, headers []*common.Header) (models.TransactionsResponse, error) {
+		return s.Next(nextToken).Do(ctx, headers...)
+	}}
+}
+
+// Pager returns a TransactionPager over the results of this search, fetching pageSize results at a time
+func (s *LookupAccountTransactions) Pager(pageSize uint64) *TransactionPager {
+	s.p.Limit = pageSize
+	return &TransactionPager{fetch: func(ctx context.Context, nextToken string, headers []*common.Header) (models.TransactionsResponse, error) {
+		return s.Next(nextToken).Do(ctx, headers...)
+	}}
+}
+
+// Pager returns a TransactionPager over the results of this search, fetching pageSize results at a time
+func (s *LookupAssetTransactions) Pager(pageSize uint64) *TransactionPager {
+	s.p.Limit = pageSize
+	return &TransactionPager{fetch: func(ctx context.Context, nextToken string, headers []*common.Header) (models.TransactionsResponse, error) {
+		return s.Next(nextToken).Do(ctx, headers...)
+	}}
+}
+
+// AccountPager iterates over the pages of an account search
+type AccountPager struct {
+	pager
+	search *SearchAccounts
+	page   []models.Account
+}
+
+// Next fetches the next page, returning false when there are no more results or an error occurred
+func (p *AccountPager) Next(ctx context.Context, headers ...*common.Header) bool {
+	return p.advance(func(nextToken string) (int, string, uint64, error) {
+		response, err := p.search.Next(nextToken).Do(ctx, headers...)
+		p.page = response.Accounts
+		return len(response.Accounts), response.NextToken, response.CurrentRound, err
+	})
+}
+
+// Accounts returns the current page
+func (p *AccountPager) Accounts() []models.Account {
+	return p.page
+}
+
+// Pager returns an AccountPager over the results of this search, fetching pageSize results at a time
+func (s *SearchAccounts) Pager(pageSize uint64) *AccountPager {
+	s.p.Limit = pageSize
+	return &AccountPager{search: s}
+}
+
+// AssetPager iterates over the pages of an asset search
+type AssetPager struct {
+	pager
+	search *SearchForAssets
+	page   []models.Asset
+}
+
+// Next fetches the next page, returning false when there are no more results or an error occurred
+func (p *AssetPager) Next(ctx context.Context, headers ...*common.Header) bool {
+	return p.advance(func(nextToken string) (int, string, uint64, error) {
+		response, err := p.search.Next(nextToken).Do(ctx, headers...)
+		p.page = response.Assets
+		return len(response.Assets), response.NextToken, response.CurrentRound, err
+	})
+}
+
+// Assets returns the current page
+func (p *AssetPager) Assets() []models.Asset {
+	return p.page
+}
+
+// Pager returns an AssetPager over the results of this search, fetching pageSize results at a time
+func (s *SearchForAssets) Pager(pageSize uint64) *AssetPager {
+	s.p.Limit = pageSize
+	return &AssetPager{search: s}
+}
+
+// AssetBalancePager iterates over the pages of an asset's holders
+type AssetBalancePager struct {
+	pager
+	lookup *LookupAssetBalances
+	page   []models.MiniAssetHolding
+}
+
+// Next fetches the next page, returning false when there are no more results or an error occurred
+func (p *AssetBalancePager) Next(ctx context.Context, headers ...*common.Header) bool {
+	return p.advance(func(nextToken string) (int, string, uint64, error) {
+		response, err := p.lookup.Next(nextToken).Do(ctx, headers...)
+		p.page = response.Balances
+		return len(response.Balances), response.NextToken, response.CurrentRound, err
+	})
+}
+
+// Balances returns the current page
+func (p *AssetBalancePager) Balances() []models.MiniAssetHolding {
+	return p.page
+}
+
+// Pager returns an AssetBalancePager over the results of this lookup, fetching pageSize results at a time
+func (s *LookupAssetBalances) Pager(pageSize uint64) *AssetBalancePager {
+	s.p.Limit = pageSize
+	return &AssetBalancePager{lookup: s}
+}
+
+// ApplicationPager iterates over the pages of an application search
+type ApplicationPager struct {
+	pager
+	search *SearchForApplications
+	page   []models.Application
+}
+
+// Next fetches the next page, returning false when there are no more results or an error occurred
+func (p *ApplicationPager) Next(ctx context.Context, headers ...*common.Header) bool {
+	return p.advance(func(nextToken string) (int, string, uint64, error) {
+		response, err := p.search.Next(nextToken).Do(ctx, headers...)
+		p.page = response.Applications
+		return len(response.Applications), response.NextToken, response.CurrentRound, err
+	})
+}
+
+// Applications returns the current page
+func (p *ApplicationPager) Applications() []models.Application {
+	return p.page
+}
+
+// Pager returns an ApplicationPager over the results of this search, fetching pageSize results at a time
+func (s *SearchForApplications) Pager(pageSize uint64) *ApplicationPager {
+	s.p.Limit = pageSize
+	return &ApplicationPager{search: s}
+}
+
+// BoxPager iterates over the pages of an application's box names
+type BoxPager struct {
+	pager
+	search *SearchForApplicationBoxes
+	page   []models.BoxDescriptor
+}
+
+// Next fetches the next page, returning false when there are no more results or an error occurred
+func (p *BoxPager) Next(ctx context.Context, headers ...*common.Header) bool {
+	return p.advance(func(nextToken string) (int, string, uint64, error) {
+		response, err := p.search.Next(nextToken).Do(ctx, headers...)
+		p.page = response.Boxes
+		return len(response.Boxes), response.NextToken, 0, err
+	})
+}
+
+// Boxes returns the current page
+func (p *BoxPager) Boxes() []models.BoxDescriptor {
+	return p.page
+}
+
+// Pager returns a BoxPager over the results of this search, fetching pageSize results at a time
+func (s *SearchForApplicationBoxes) Pager(pageSize uint64) *BoxPager {
+	s.p.Limit = pageSize
+	return &BoxPager{search: s}
+}
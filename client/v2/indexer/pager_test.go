@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionPager(t *testing.T) {
+	// three pages of two, the last of which still carries a next-token
+	pages := map[string]string{
+		"":   `{"current-round":7,"next-token":"p2","transactions":[{"id":"A"},{"id":"B"}]}`,
+		"p2": `{"current-round":7,"next-token":"p3","transactions":[{"id":"C"},{"id":"D"}]}`,
+		"p3": `{"current-round":7,"next-token":"p4","transactions":[{"id":"E"}]}`,
+		"p4": `{"current-round":7,"transactions":[]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/accounts/ADDR/transactions", r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("limit"))
+		page, ok := pages[r.URL.Query().Get("next")]
+		require.True(t, ok)
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	pager := client.LookupAccountTransactions("ADDR").Pager(2)
+	var ids []string
+	for pager.Next(context.Background()) {
+		require.Equal(t, uint64(7), pager.CurrentRound())
+		for _, txn := range pager.Transactions() {
+			ids = append(ids, txn.ID)
+		}
+	}
+	require.NoError(t, pager.Err())
+	require.Equal(t, []string{"A", "B", "C", "D", "E"}, ids)
+	require.False(t, pager.Next(context.Background()))
+}
+
+func TestAccountPagerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("next") == "" {
+			fmt.Fprint(w, `{"current-round":7,"next-token":"p2","accounts":[{"address":"A"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"message":"boom"}`)
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	pager := client.SearchAccounts().Pager(1)
+	require.True(t, pager.Next(context.Background()))
+	require.Len(t, pager.Accounts(), 1)
+	require.False(t, pager.Next(context.Background()))
+	require.Error(t, pager.Err())
+}
+
+func TestBoxPager(t *testing.T) {
+	pages := map[string]string{
+		"":   `{"application-id":5,"next-token":"p2","boxes":[{"name":"YQ=="},{"name":"Yg=="}]}`,
+		"p2": `{"application-id":5,"boxes":[{"name":"Yw=="}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/applications/5/box" {
+			require.Equal(t, "b64:YQ==", r.URL.Query().Get("name"))
+			fmt.Fprint(w, `{"name":"YQ==","round":9,"value":"dmFsdWU="}`)
+			return
+		}
+		require.Equal(t, "/v2/applications/5/boxes", r.URL.Path)
+		require.Equal(t, "2", r.URL.Query().Get("limit"))
+		page, ok := pages[r.URL.Query().Get("next")]
+		require.True(t, ok)
+		fmt.Fprint(w, page)
+	}))
+	defer server.Close()
+	client, err := MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	pager := client.SearchForApplicationBoxes(5).Pager(2)
+	var names []string
+	for pager.Next(context.Background()) {
+		for _, box := range pager.Boxes() {
+			names = append(names, string(box.Name))
+		}
+	}
+	require.NoError(t, pager.Err())
+	require.Equal(t, []string{"a", "b", "c"}, names)
+
+	box, err := client.LookupApplicationBoxByIDAndName(5, []byte("a")).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []byte("value"), box.Value)
+	require.Equal(t, uint64(9), box.Round)
+}
@@ -0,0 +1,197 @@
+package indexer
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// AddressRole narrows an address filter to the part the address plays in a transaction
+type AddressRole string
+
+const (
+	// AddressRoleSender matches transactions sent by the address
+	AddressRoleSender AddressRole = "sender"
+	// AddressRoleReceiver matches transactions received by the address, including close-to
+	AddressRoleReceiver AddressRole = "receiver"
+	// AddressRoleFreezeTarget matches asset freeze transactions targeting the address
+	AddressRoleFreezeTarget AddressRole = "freeze-target"
+)
+
+// SigType is the kind of signature on a transaction
+type SigType string
+
+const (
+	// SigTypeSig is a single key signature
+	SigTypeSig SigType = "sig"
+	// SigTypeMsig is a multisig signature
+	SigTypeMsig SigType = "msig"
+	// SigTypeLsig is a logic signature
+	SigTypeLsig SigType = "lsig"
+)
+
+// transactionParams are the filters shared by the transaction search endpoints
+type transactionParams struct {
+	Address             string `url:"address,omitempty"`
+	AddressRole         string `url:"address-role,omitempty"`
+	AfterTime           string `url:"after-time,omitempty"`
+	ApplicationID       uint64 `url:"application-id,omitempty"`
+	AssetID             uint64 `url:"asset-id,omitempty"`
+	BeforeTime          string `url:"before-time,omitempty"`
+	CurrencyGreaterThan uint64 `url:"currency-greater-than,omitempty"`
+	CurrencyLessThan    uint64 `url:"currency-less-than,omitempty"`
+	ExcludeCloseTo      bool   `url:"exclude-close-to,omitempty"`
+	Limit               uint64 `url:"limit,omitempty"`
+	MaxRound            uint64 `url:"max-round,omitempty"`
+	MinRound            uint64 `url:"min-round,omitempty"`
+	Next                string `url:"next,omitempty"`
+	NotePrefix          string `url:"note-prefix,omitempty"`
+	RekeyTo             bool   `url:"rekey-to,omitempty"`
+	Round               uint64 `url:"round,omitempty"`
+	SigType             string `url:"sig-type,omitempty"`
+	TXID                string `url:"txid,omitempty"`
+	TxType              string `url:"tx-type,omitempty"`
+}
+
+// SearchForTransactions searches for transactions matching the given filters
+type SearchForTransactions struct {
+	c *Client
+	p transactionParams
+}
+
+// Address only includes transactions with this address in one of the transaction fields
+func (s *SearchForTransactions) Address(address string) *SearchForTransactions {
+	s.p.Address = address
+	return s
+}
+
+// AddressRole narrows Address to transactions where it is the sender, receiver or freeze target
+func (s *SearchForTransactions) AddressRole(role AddressRole) *SearchForTransactions {
+	s.p.AddressRole = string(role)
+	return s
+}
+
+// AfterTime only includes results after the given time
+func (s *SearchForTransactions) AfterTime(after time.Time) *SearchForTransactions {
+	s.p.AfterTime = after.Format(time.RFC3339)
+	return s
+}
+
+// ApplicationID only includes results for this application
+func (s *SearchForTransactions) ApplicationID(applicationID uint64) *SearchForTransactions {
+	s.p.ApplicationID = applicationID
+	return s
+}
+
+// AssetID only includes results for this asset
+func (s *SearchForTransactions) AssetID(assetID uint64) *SearchForTransactions {
+	s.p.AssetID = assetID
+	return s
+}
+
+// BeforeTime only includes results before the given time
+func (s *SearchForTransactions) BeforeTime(before time.Time) *SearchForTransactions {
+	s.p.BeforeTime = before.Format(time.RFC3339)
+	return s
+}
+
+// CurrencyGreaterThan only includes results with a currency value greater than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *SearchForTransactions) CurrencyGreaterThan(greaterThan uint64) *SearchForTransactions {
+	s.p.CurrencyGreaterThan = greaterThan
+	return s
+}
+
+// CurrencyLessThan only includes results with a currency value less than this; the value is in the asset's units when an asset is given, MicroAlgos otherwise
+func (s *SearchForTransactions) CurrencyLessThan(lessThan uint64) *SearchForTransactions {
+	s.p.CurrencyLessThan = lessThan
+	return s
+}
+
+// ExcludeCloseTo excludes transactions that only match because of their close-to address
+func (s *SearchForTransactions) ExcludeCloseTo(exclude bool) *SearchForTransactions {
+	s.p.ExcludeCloseTo = exclude
+	return s
+}
+
+// Limit is the maximum number of results to return in a page
+func (s *SearchForTransactions) Limit(limit uint64) *SearchForTransactions {
+	s.p.Limit = limit
+	return s
+}
+
+// MaxRound only includes results at or before this round
+func (s *SearchForTransactions) MaxRound(round uint64) *SearchForTransactions {
+	s.p.MaxRound = round
+	return s
+}
+
+// MinRound only includes results at or after this round
+func (s *SearchForTransactions) MinRound(round uint64) *SearchForTransactions {
+	s.p.MinRound = round
+	return s
+}
+
+// Next is the next-token of the previous page, used to fetch the following page
+func (s *SearchForTransactions) Next(nextToken string) *SearchForTransactions {
+	s.p.Next = nextToken
+	return s
+}
+
+// NotePrefix only includes transactions whose note starts with prefix
+func (s *SearchForTransactions) NotePrefix(prefix []byte) *SearchForTransactions {
+	s.p.NotePrefix = base64.StdEncoding.EncodeToString(prefix)
+	return s
+}
+
+// RekeyTo only includes transactions that rekey an account
+func (s *SearchForTransactions) RekeyTo(rekeyTo bool) *SearchForTransactions {
+	s.p.RekeyTo = rekeyTo
+	return s
+}
+
+// Round only includes results for this round
+func (s *SearchForTransactions) Round(round uint64) *SearchForTransactions {
+	s.p.Round = round
+	return s
+}
+
+// SigType only includes transactions signed with this kind of signature
+func (s *SearchForTransactions) SigType(sigType SigType) *SearchForTransactions {
+	s.p.SigType = string(sigType)
+	return s
+}
+
+// TxType only includes transactions of this type
+func (s *SearchForTransactions) TxType(txType types.TxType) *SearchForTransactions {
+	s.p.TxType = string(txType)
+	return s
+}
+
+// TXID looks up a specific transaction by ID
+func (s *SearchForTransactions) TXID(txid string) *SearchForTransactions {
+	s.p.TXID = txid
+	return s
+}
+
+// Do performs the HTTP request
+func (s *SearchForTransactions) Do(ctx context.Context, headers ...*common.Header) (response models.TransactionsResponse, err error) {
+	err = s.c.get(ctx, &response, "/v2/transactions", s.p, headers)
+	return
+}
+
+// LookupTransaction looks up a transaction by ID
+type LookupTransaction struct {
+	c    *Client
+	txid string
+}
+
+// Do performs the HTTP request
+func (s *LookupTransaction) Do(ctx context.Context, headers ...*common.Header) (response models.TransactionResponse, err error) {
+	err = s.c.get(ctx, &response, fmt.Sprintf("/v2/transactions/%s", common.EscapeParams(s.txid)...), nil, headers)
+	return
+}
@@ -0,0 +1,537 @@
+// Package mock provides an in-memory Algorand network served by fake algod and
+// indexer HTTP servers, so that code using the v2 clients can be unit tested
+// without a node. The network keeps the balances of accounts, checks and pools
+// the transactions sent to it, and confirms the pooled transactions in the
+// next round, which is made when a client waits for a block after the current
+// round, as WaitForConfirmation does, or when AdvanceRound is called.
+//
+// Payments move Algos, close accounts and rekey them. Other transactions are
+// checked, pay their fees and are confirmed, but have no other effect.
+package mock
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// The parameters of the network
+const (
+	GenesisID        = "mocknet-v1"
+	MinFee           = 1000
+	ConsensusVersion = "future"
+)
+
+// GenesisHash is the genesis hash of the network
+var GenesisHash = sha512.Sum512_256([]byte(GenesisID))
+
+// account is the state of an account
+type account struct {
+	amount   uint64
+	authAddr types.Address
+}
+
+// confirmedTxn is a transaction confirmed in a round
+type confirmedTxn struct {
+	stx   types.SignedTxn
+	round uint64
+	// closingAmount is the amount sent to the CloseRemainderTo account
+	closingAmount uint64
+}
+
+// Network is an in-memory network, served by a fake algod and indexer
+type Network struct {
+	// Algod and Indexer are the servers of the network's algod and indexer
+	Algod   *httptest.Server
+	Indexer *httptest.Server
+
+	mu        sync.Mutex
+	round     uint64
+	accounts  map[types.Address]account
+	pool      [][]types.SignedTxn
+	confirmed map[string]confirmedTxn
+	order     []string
+}
+
+// NewNetwork starts the servers of a network at round 1, in which no account
+// holds Algos. Close stops them.
+func NewNetwork() *Network {
+	n := &Network{
+		round:     1,
+		accounts:  make(map[types.Address]account),
+		confirmed: make(map[string]confirmedTxn),
+	}
+	n.Algod = httptest.NewServer(http.HandlerFunc(n.serveAlgod))
+	n.Indexer = httptest.NewServer(http.HandlerFunc(n.serveIndexer))
+	return n
+}
+
+// Close stops the servers of the network
+func (n *Network) Close() {
+	n.Algod.Close()
+	n.Indexer.Close()
+}
+
+// AlgodClient returns a client of the network's algod
+func (n *Network) AlgodClient() *algod.Client {
+	c, err := algod.MakeClient(n.Algod.URL, "")
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// IndexerClient returns a client of the network's indexer
+func (n *Network) IndexerClient() *indexer.Client {
+	c, err := indexer.MakeClient(n.Indexer.URL, "")
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Fund adds amount microAlgos to the balance of address
+func (n *Network) Fund(address types.Address, amount uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	a := n.accounts[address]
+	a.amount += amount
+	n.accounts[address] = a
+}
+
+// Balance returns the balance of address, in microAlgos, as of the current
+// round
+func (n *Network) Balance(address types.Address) uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.accounts[address].amount
+}
+
+// Round returns the current round, the last round made
+func (n *Network) Round() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.round
+}
+
+// AdvanceRound makes the next round, confirming the pooled transactions in it
+func (n *Network) AdvanceRound() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.advanceRound()
+}
+
+func (n *Network) advanceRound() {
+	n.round++
+	for _, group := range n.pool {
+		closingAmounts, err := apply(n.accounts, group)
+		if err != nil {
+			// groups are checked against the pool when sent
+			panic(err)
+		}
+		for i, stx := range group {
+			txid := crypto.GetTxID(stx.Txn)
+			n.confirmed[txid] = confirmedTxn{stx: stx, round: n.round, closingAmount: closingAmounts[i]}
+			n.order = append(n.order, txid)
+		}
+	}
+	n.pool = nil
+}
+
+// send checks a group of transactions against the ledger with the pooled
+// groups applied, and pools it
+func (n *Network) send(group []types.SignedTxn) error {
+	fees := uint64(0)
+	for _, stx := range group {
+		txid := crypto.GetTxID(stx.Txn)
+		if _, ok := n.confirmed[txid]; ok {
+			return fmt.Errorf("transaction already in ledger: %s", txid)
+		}
+		for _, pooled := range n.pool {
+			for _, pooledStx := range pooled {
+				if crypto.GetTxID(pooledStx.Txn) == txid {
+					return fmt.Errorf("transaction already in ledger: %s", txid)
+				}
+			}
+		}
+		if stx.Txn.GenesisHash != types.Digest(GenesisHash) {
+			return fmt.Errorf("transaction %s: genesis hash mismatch", txid)
+		}
+		if stx.Txn.GenesisID != "" && stx.Txn.GenesisID != GenesisID {
+			return fmt.Errorf("transaction %s: genesis ID mismatch", txid)
+		}
+		next := types.Round(n.round + 1)
+		if next < stx.Txn.FirstValid || next > stx.Txn.LastValid {
+			return fmt.Errorf("transaction %s: txn dead: round %d outside of %d--%d", txid, next, stx.Txn.FirstValid, stx.Txn.LastValid)
+		}
+		if !crypto.VerifySignedTransaction(stx) {
+			return fmt.Errorf("transaction %s: invalid signature", txid)
+		}
+		fees += uint64(stx.Txn.Fee)
+	}
+	if fees < MinFee*uint64(len(group)) {
+		return fmt.Errorf("transaction group pays %d in fees, less than the minimum of %d", fees, MinFee*uint64(len(group)))
+	}
+	if len(group) > 1 {
+		txns := make([]types.Transaction, len(group))
+		for i, stx := range group {
+			txns[i] = stx.Txn
+			txns[i].Group = types.Digest{}
+		}
+		gid, err := crypto.ComputeGroupID(txns)
+		if err != nil {
+			return err
+		}
+		for _, stx := range group {
+			if stx.Txn.Group != gid {
+				return fmt.Errorf("transaction %s: incomplete group", crypto.GetTxID(stx.Txn))
+			}
+		}
+	}
+
+	speculative := make(map[types.Address]account, len(n.accounts))
+	for address, a := range n.accounts {
+		speculative[address] = a
+	}
+	for _, pooled := range n.pool {
+		if _, err := apply(speculative, pooled); err != nil {
+			return err
+		}
+	}
+	if _, err := apply(speculative, group); err != nil {
+		return err
+	}
+	n.pool = append(n.pool, group)
+	return nil
+}
+
+// minBalance is the minimum balance of an account holding Algos
+var minBalance = models.ComputeMinBalance(models.Account{})
+
+// apply applies the effects of a group of transactions to accounts, returning
+// the amounts sent to the CloseRemainderTo accounts of each transaction
+func apply(accounts map[types.Address]account, group []types.SignedTxn) ([]uint64, error) {
+	closingAmounts := make([]uint64, len(group))
+	for i, stx := range group {
+		txn := stx.Txn
+		txid := crypto.GetTxID(txn)
+		sender := accounts[txn.Sender]
+		authorizer := txn.Sender
+		if !sender.authAddr.IsZero() {
+			authorizer = sender.authAddr
+		}
+		signer := txn.Sender
+		if !stx.AuthAddr.IsZero() {
+			signer = stx.AuthAddr
+		}
+		if signer != authorizer {
+			return nil, fmt.Errorf("transaction %s: should have been authorized by %s but was actually authorized by %s", txid, authorizer, signer)
+		}
+
+		spent := uint64(txn.Fee)
+		if txn.Type == types.PaymentTx {
+			spent += uint64(txn.Amount)
+		}
+		if spent > sender.amount {
+			return nil, fmt.Errorf("transaction %s: overspend (account %s, data {amount %d}, tried to spend {%d})", txid, txn.Sender, sender.amount, spent)
+		}
+		sender.amount -= spent
+		if !txn.RekeyTo.IsZero() {
+			sender.authAddr = txn.RekeyTo
+			if txn.RekeyTo == txn.Sender {
+				sender.authAddr = types.Address{}
+			}
+		}
+		accounts[txn.Sender] = sender
+
+		if txn.Type == types.PaymentTx {
+			receiver := accounts[txn.Receiver]
+			receiver.amount += uint64(txn.Amount)
+			accounts[txn.Receiver] = receiver
+			if !txn.CloseRemainderTo.IsZero() {
+				closingAmounts[i] = accounts[txn.Sender].amount
+				closeTo := accounts[txn.CloseRemainderTo]
+				closeTo.amount += closingAmounts[i]
+				accounts[txn.CloseRemainderTo] = closeTo
+				delete(accounts, txn.Sender)
+			}
+		}
+	}
+	for _, stx := range group {
+		for _, address := range []types.Address{stx.Txn.Sender, stx.Txn.Receiver} {
+			if a, ok := accounts[address]; ok && a.amount > 0 && a.amount < minBalance {
+				return nil, fmt.Errorf("account %s balance %d below min %d", address, a.amount, minBalance)
+			}
+		}
+	}
+	return closingAmounts, nil
+}
+
+// accountModel returns the model of the account at address
+func (n *Network) accountModel(address types.Address) models.Account {
+	a := n.accounts[address]
+	m := models.Account{
+		Address:                     address.String(),
+		Amount:                      a.amount,
+		AmountWithoutPendingRewards: a.amount,
+		Round:                       n.round,
+		Status:                      "Offline",
+	}
+	if !a.authAddr.IsZero() {
+		m.AuthAddr = a.authAddr.String()
+	}
+	if a.amount > 0 {
+		m.MinBalance = minBalance
+	}
+	return m
+}
+
+// writeResponse writes response in the format requested by r, JSON unless
+// msgpack is asked for
+func writeResponse(w http.ResponseWriter, r *http.Request, response interface{}) {
+	if r.URL.Query().Get("format") == "msgpack" {
+		w.Header().Set("Content-Type", "application/msgpack")
+		w.Write(msgpack.Encode(response))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(json.Encode(response))
+}
+
+// writeError writes an error response with message, as algod and indexer do
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(json.Encode(map[string]string{"message": message}))
+}
+
+func (n *Network) serveAlgod(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case path == "/health" || path == "/ready":
+		w.Write([]byte("null"))
+	case path == "/versions":
+		writeResponse(w, r, models.Version{GenesisID: GenesisID, GenesisHash: GenesisHash[:], Versions: []string{"v2"}})
+	case path == "/v2/status":
+		writeResponse(w, r, n.status())
+	case strings.HasPrefix(path, "/v2/status/wait-for-block-after/"):
+		round, err := strconv.ParseUint(strings.TrimPrefix(path, "/v2/status/wait-for-block-after/"), 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for n.round <= round {
+			n.advanceRound()
+		}
+		writeResponse(w, r, n.status())
+	case path == "/v2/transactions/params":
+		writeResponse(w, r, models.TransactionParametersResponse{
+			ConsensusVersion: ConsensusVersion,
+			GenesisHash:      GenesisHash[:],
+			GenesisID:        GenesisID,
+			LastRound:        n.round,
+			MinFee:           MinFee,
+		})
+	case path == "/v2/transactions" && r.Method == http.MethodPost:
+		n.serveSend(w, r)
+	case path == "/v2/transactions/pending":
+		n.servePending(w, r, nil)
+	case strings.HasPrefix(path, "/v2/transactions/pending/"):
+		n.servePendingTransaction(w, r, strings.TrimPrefix(path, "/v2/transactions/pending/"))
+	case strings.HasPrefix(path, "/v2/accounts/") && strings.HasSuffix(path, "/transactions/pending"):
+		address, err := types.DecodeAddress(strings.TrimSuffix(strings.TrimPrefix(path, "/v2/accounts/"), "/transactions/pending"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		n.servePending(w, r, &address)
+	case strings.HasPrefix(path, "/v2/accounts/"):
+		address, err := types.DecodeAddress(strings.TrimPrefix(path, "/v2/accounts/"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeResponse(w, r, n.accountModel(address))
+	default:
+		writeError(w, http.StatusNotFound, "unsupported request "+r.Method+" "+path)
+	}
+}
+
+func (n *Network) status() models.NodeStatusResponse {
+	return models.NodeStatusResponse{LastRound: n.round, LastVersion: ConsensusVersion, NextVersion: ConsensusVersion, NextVersionRound: n.round + 1, NextVersionSupported: true}
+}
+
+func (n *Network) serveSend(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var group []types.SignedTxn
+	reader := bytes.NewReader(body)
+	dec := msgpack.NewDecoder(reader)
+	for reader.Len() > 0 {
+		var stx types.SignedTxn
+		if err := dec.Decode(&stx); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("transaction %d: %v", len(group), err))
+			return
+		}
+		group = append(group, stx)
+	}
+	if len(group) == 0 {
+		writeError(w, http.StatusBadRequest, "no transactions")
+		return
+	}
+	if err := n.send(group); err != nil {
+		writeError(w, http.StatusBadRequest, "TransactionPool.Remember: "+err.Error())
+		return
+	}
+	writeResponse(w, r, models.PostTransactionsResponse{TxID: crypto.GetTxID(group[0].Txn)})
+}
+
+func (n *Network) servePending(w http.ResponseWriter, r *http.Request, address *types.Address) {
+	var pending []types.SignedTxn
+	for _, group := range n.pool {
+		for _, stx := range group {
+			if address == nil || stx.Txn.Sender == *address || stx.Txn.Receiver == *address {
+				pending = append(pending, stx)
+			}
+		}
+	}
+	total := uint64(len(pending))
+	if max, err := strconv.Atoi(r.URL.Query().Get("max")); err == nil && max > 0 && max < len(pending) {
+		pending = pending[:max]
+	}
+	writeResponse(w, r, models.PendingTransactionsResponse{TopTransactions: pending, TotalTransactions: total})
+}
+
+func (n *Network) servePendingTransaction(w http.ResponseWriter, r *http.Request, txid string) {
+	if confirmed, ok := n.confirmed[txid]; ok {
+		writeResponse(w, r, models.PendingTransactionInfoResponse{
+			ConfirmedRound: confirmed.round,
+			ClosingAmount:  confirmed.closingAmount,
+			Transaction:    confirmed.stx,
+		})
+		return
+	}
+	for _, group := range n.pool {
+		for _, stx := range group {
+			if crypto.GetTxID(stx.Txn) == txid {
+				writeResponse(w, r, models.PendingTransactionInfoResponse{Transaction: stx})
+				return
+			}
+		}
+	}
+	writeError(w, http.StatusNotFound, "txn does not exist")
+}
+
+func (n *Network) serveIndexer(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	path := r.URL.Path
+	switch {
+	case path == "/health":
+		writeResponse(w, r, models.HealthCheckResponse{DbAvailable: true, Message: "ok", Round: n.round})
+	case path == "/v2/transactions":
+		n.serveTransactions(w, r, r.URL.Query().Get("address"), r.URL.Query().Get("txid"))
+	case strings.HasPrefix(path, "/v2/transactions/"):
+		txid := strings.TrimPrefix(path, "/v2/transactions/")
+		confirmed, ok := n.confirmed[txid]
+		if !ok {
+			writeError(w, http.StatusNotFound, "no transaction found for transaction id: "+txid)
+			return
+		}
+		writeResponse(w, r, models.TransactionResponse{CurrentRound: n.round, Transaction: transactionModel(confirmed)})
+	case strings.HasPrefix(path, "/v2/accounts/") && strings.HasSuffix(path, "/transactions"):
+		n.serveTransactions(w, r, strings.TrimSuffix(strings.TrimPrefix(path, "/v2/accounts/"), "/transactions"), "")
+	case strings.HasPrefix(path, "/v2/accounts/"):
+		address, err := types.DecodeAddress(strings.TrimPrefix(path, "/v2/accounts/"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if _, ok := n.accounts[address]; !ok {
+			writeError(w, http.StatusNotFound, "no accounts found for address: "+address.String())
+			return
+		}
+		writeResponse(w, r, models.AccountResponse{Account: n.accountModel(address), CurrentRound: n.round})
+	default:
+		writeError(w, http.StatusNotFound, "unsupported request "+r.Method+" "+path)
+	}
+}
+
+// serveTransactions serves the confirmed transactions, in the order they
+// were confirmed, sent by or to address and of ID txid if they are not ""
+func (n *Network) serveTransactions(w http.ResponseWriter, r *http.Request, address, txid string) {
+	transactions := []models.Transaction{}
+	for _, id := range n.order {
+		if txid != "" && id != txid {
+			continue
+		}
+		confirmed := n.confirmed[id]
+		txn := confirmed.stx.Txn
+		if address != "" && txn.Sender.String() != address && txn.Receiver.String() != address && txn.CloseRemainderTo.String() != address {
+			continue
+		}
+		transactions = append(transactions, transactionModel(confirmed))
+	}
+	writeResponse(w, r, models.TransactionsResponse{CurrentRound: n.round, Transactions: transactions})
+}
+
+// transactionModel returns the indexer model of a confirmed transaction
+func transactionModel(confirmed confirmedTxn) models.Transaction {
+	txn := confirmed.stx.Txn
+	m := models.Transaction{
+		ConfirmedRound: confirmed.round,
+		Fee:            uint64(txn.Fee),
+		FirstValid:     uint64(txn.FirstValid),
+		GenesisHash:    txn.GenesisHash[:],
+		GenesisID:      txn.GenesisID,
+		ID:             crypto.GetTxID(txn),
+		LastValid:      uint64(txn.LastValid),
+		Note:           txn.Note,
+		Sender:         txn.Sender.String(),
+		Type:           string(txn.Type),
+	}
+	if txn.Group != (types.Digest{}) {
+		m.Group = txn.Group[:]
+	}
+	if txn.Lease != ([32]byte{}) {
+		m.Lease = txn.Lease[:]
+	}
+	if !txn.RekeyTo.IsZero() {
+		m.RekeyTo = txn.RekeyTo.String()
+	}
+	if !confirmed.stx.AuthAddr.IsZero() {
+		m.AuthAddr = confirmed.stx.AuthAddr.String()
+	}
+	if txn.Type == types.PaymentTx {
+		m.PaymentTransaction = models.TransactionPayment{
+			Amount:      uint64(txn.Amount),
+			CloseAmount: confirmed.closingAmount,
+			Receiver:    txn.Receiver.String(),
+		}
+		if !txn.CloseRemainderTo.IsZero() {
+			m.PaymentTransaction.CloseRemainderTo = txn.CloseRemainderTo.String()
+		}
+	}
+	return m
+}
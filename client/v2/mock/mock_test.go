@@ -0,0 +1,109 @@
+package mock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/future"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestNetwork(t *testing.T) {
+	ctx := context.Background()
+	n := NewNetwork()
+	defer n.Close()
+	algodClient, indexerClient := n.AlgodClient(), n.IndexerClient()
+
+	alice, bob, carol := crypto.GenerateAccount(), crypto.GenerateAccount(), crypto.GenerateAccount()
+	n.Fund(alice.Address, 10000000)
+
+	params, err := algodClient.SuggestedParams().Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, GenesisID, params.GenesisID)
+	require.Equal(t, GenesisHash[:], params.GenesisHash)
+
+	send := func(from crypto.Account, txn types.Transaction) (string, error) {
+		_, stx, err := crypto.SignTransaction(from.PrivateKey, txn)
+		require.NoError(t, err)
+		return algodClient.SendRawTransaction(stx).Do(ctx)
+	}
+
+	pay, err := future.MakePaymentTxn(alice.Address.String(), bob.Address.String(), 1000000, []byte("hi"), "", params)
+	require.NoError(t, err)
+	txid, err := send(alice, pay)
+	require.NoError(t, err)
+
+	// the payment is pooled until the next round
+	_, err = send(alice, pay)
+	require.Error(t, err)
+	total, _, err := algodClient.PendingTransactionsByAddress(bob.Address.String()).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), total)
+	require.Equal(t, uint64(10000000), n.Balance(alice.Address))
+
+	info, err := future.WaitForConfirmation(ctx, algodClient, txid, 4)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), info.ConfirmedRound)
+	require.Equal(t, uint64(3), n.Round())
+	require.Equal(t, uint64(10000000-1000000-MinFee), n.Balance(alice.Address))
+
+	account, err := algodClient.AccountInformation(bob.Address.String()).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000000), account.Amount)
+	require.Equal(t, uint64(100000), account.MinBalance)
+
+	// indexer
+	found, err := indexerClient.SearchForTransactions().TXID(txid).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, txid, found.Transactions[0].ID)
+	lookup, err := indexerClient.LookupTransaction(txid).Do(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), lookup.Transaction.ConfirmedRound)
+	require.Equal(t, bob.Address.String(), lookup.Transaction.PaymentTransaction.Receiver)
+	require.Equal(t, []byte("hi"), lookup.Transaction.Note)
+	_, err = indexerClient.LookupAccountByID(carol.Address.String()).Do(ctx)
+	require.Error(t, err)
+
+	// overspending and going below the minimum balance are rejected
+	params, err = algodClient.SuggestedParams().Do(ctx)
+	require.NoError(t, err)
+	overspend, err := future.MakePaymentTxn(bob.Address.String(), carol.Address.String(), 1000000, nil, "", params)
+	require.NoError(t, err)
+	_, err = send(bob, overspend)
+	httpErr, ok := err.(*common.HTTPError)
+	require.True(t, ok)
+	require.Equal(t, common.ErrOverspend, httpErr.Unwrap())
+	belowMin, err := future.MakePaymentTxn(bob.Address.String(), carol.Address.String(), 50000, nil, "", params)
+	require.NoError(t, err)
+	_, err = send(bob, belowMin)
+	require.Error(t, err)
+
+	// bob closes to carol after rekeying to alice
+	rekey, err := future.MakePaymentTxn(bob.Address.String(), bob.Address.String(), 0, nil, "", params)
+	require.NoError(t, err)
+	rekey.RekeyTo = alice.Address
+	_, err = send(bob, rekey)
+	require.NoError(t, err)
+	n.AdvanceRound()
+	closeOut, err := future.MakePaymentTxn(bob.Address.String(), carol.Address.String(), 0, nil, carol.Address.String(), params)
+	require.NoError(t, err)
+	_, err = send(bob, closeOut)
+	require.Error(t, err)
+	_, stx, err := crypto.SignTransaction(alice.PrivateKey, closeOut)
+	require.NoError(t, err)
+	closeID, err := algodClient.SendRawTransaction(stx).Do(ctx)
+	require.NoError(t, err)
+	info, err = future.WaitForConfirmation(ctx, algodClient, closeID, 4)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000000-2*MinFee), info.ClosingAmount)
+	require.Equal(t, uint64(0), n.Balance(bob.Address))
+	require.Equal(t, uint64(1000000-2*MinFee), n.Balance(carol.Address))
+
+	transactions, err := indexerClient.LookupAccountTransactions(bob.Address.String()).Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, transactions.Transactions, 3)
+}
@@ -0,0 +1,485 @@
+// Command algosdk wraps common operations of the SDK for scripting, and shows
+// how its API is used:
+//
+//	algosdk account
+//	algosdk decode [-json] txns.msgp
+//	algosdk encode -out txns.msgp txns.json
+//	algosdk sign -out signed.msgp txns.msgp
+//	algosdk group -out grouped.msgp txns.msgp...
+//	algosdk split -owner A -receiver-one B -receiver-two C -ratn 1 -ratd 3 -expiry 5000 -min-pay 1000 -max-fee 2000 [-asset ID -opt-in-round 4000]
+//	algosdk split-send -program split.teal.tok -amount 300000 [-remainder keep] -out split.msgp
+//	algosdk htlc -owner A -receiver B -hash-image IMAGE -expiry 5000 -max-fee 2000
+//	algosdk htlc-claim -program htlc.teal.tok -preimage PREIMAGE -out claim.msgp
+//	algosdk send signed.msgp
+//
+// Transaction files hold msgpack-encoded signed transactions, one after the
+// other, as goal writes them; unsigned transactions are signed transactions
+// with no signature. Signing reads the account's mnemonic from the
+// ALGOSDK_MNEMONIC environment variable, or else from the first line of
+// stdin. Commands talking to algod use the ALGOD_ADDRESS and ALGOD_TOKEN
+// environment variables, which default to those of a LocalNet.
+//
+// Subcommands are looked up in a map and parse their flags with the standard
+// flag package, as models/generate does, rather than with cobra, so that the
+// command adds no dependency to the SDK's vendor directory.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/dev"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/mnemonic"
+	"github.com/algorand/go-algorand-sdk/templates"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// env is what a command reads and writes besides its arguments
+type env struct {
+	stdin  io.Reader
+	stdout io.Writer
+	getenv func(string) string
+}
+
+// command is a subcommand of algosdk
+type command struct {
+	usage string
+	run   func(e env, args []string) error
+}
+
+var commands = map[string]command{
+	"account":    {"generate an account and print its address and mnemonic", runAccount},
+	"decode":     {"describe the transactions of a file, or print them as JSON", runDecode},
+	"encode":     {"encode transactions given as JSON into a transaction file", runEncode},
+	"sign":       {"sign the transactions of a file with a mnemonic's account", runSign},
+	"group":      {"assign a group ID to the transactions of files", runGroup},
+	"split":      {"make a split contract and print its address and program", runSplit},
+	"split-send": {"make the group paying out a split contract's funds", runSplitSend},
+	"htlc":       {"make a hash time locked contract and print its address and program", runHTLC},
+	"htlc-claim": {"make the transaction claiming a hash time locked contract's funds", runHTLCClaim},
+	"send":       {"send the signed transactions of a file to algod", runSend},
+}
+
+func main() {
+	e := env{stdin: os.Stdin, stdout: os.Stdout, getenv: os.Getenv}
+	if err := run(e, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "algosdk: %v\n", err)
+		if err == errUsage {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+var errUsage = fmt.Errorf("usage: algosdk command [flags] [args]")
+
+func run(e env, args []string) error {
+	if len(args) == 0 {
+		printUsage(e.stdout)
+		return errUsage
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		printUsage(e.stdout)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+	return cmd.run(e, args[1:])
+}
+
+func printUsage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Fprintln(w, "usage: algosdk command [flags] [args]")
+	fmt.Fprintln(w, "commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-11s %s\n", name, commands[name].usage)
+	}
+}
+
+// newFlagSet makes the flag set of a command, whose errors are returned
+// rather than exiting
+func newFlagSet(e env, name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("algosdk "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stdout)
+	return fs
+}
+
+func runAccount(e env, args []string) error {
+	fs := newFlagSet(e, "account")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	account := crypto.GenerateAccount()
+	defer account.PrivateKey.Zero()
+	m, err := mnemonic.FromPrivateKey(account.PrivateKey)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "address:  %s\nmnemonic: %s\n", account.Address, m)
+	return nil
+}
+
+func runDecode(e env, args []string) error {
+	fs := newFlagSet(e, "decode")
+	asJSON := fs.Bool("json", false, "print the transactions as algod's API encodes them in JSON")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("decode takes one transaction file")
+	}
+	stxns, err := readTxns(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		out, err := json.MarshalIndent(stxns, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(e.stdout, "%s\n", out)
+		return nil
+	}
+	for i, stx := range stxns {
+		if i > 0 {
+			fmt.Fprintln(e.stdout)
+		}
+		fmt.Fprint(e.stdout, transaction.DumpSigned(stx))
+	}
+	return nil
+}
+
+func runEncode(e env, args []string) error {
+	fs := newFlagSet(e, "encode")
+	out := fs.String("out", "", "the transaction file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *out == "" {
+		return fmt.Errorf("encode takes -out and one JSON file")
+	}
+	data, err := ioutil.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	// a single transaction, or an array of them, as decode -json prints
+	var stxns []types.SignedTxn
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "[") {
+		err = json.Unmarshal(data, &stxns)
+	} else {
+		var stx types.SignedTxn
+		err = json.Unmarshal(data, &stx)
+		stxns = append(stxns, stx)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %v", fs.Arg(0), err)
+	}
+	return writeTxns(*out, stxns)
+}
+
+func runSign(e env, args []string) error {
+	fs := newFlagSet(e, "sign")
+	out := fs.String("out", "", "the transaction file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 || *out == "" {
+		return fmt.Errorf("sign takes -out and one transaction file")
+	}
+	stxns, err := readTxns(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	sk, err := readMnemonic(e)
+	if err != nil {
+		return err
+	}
+	defer sk.Zero()
+
+	var signed []byte
+	for i, stx := range stxns {
+		_, stxBytes, err := crypto.SignTransaction(sk, stx.Txn)
+		if err != nil {
+			return fmt.Errorf("could not sign transaction %d: %v", i, err)
+		}
+		signed = append(signed, stxBytes...)
+	}
+	return ioutil.WriteFile(*out, signed, 0644)
+}
+
+func runGroup(e env, args []string) error {
+	fs := newFlagSet(e, "group")
+	out := fs.String("out", "", "the transaction file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 || *out == "" {
+		return fmt.Errorf("group takes -out and transaction files")
+	}
+	var txns []types.Transaction
+	for _, path := range fs.Args() {
+		stxns, err := readTxns(path)
+		if err != nil {
+			return err
+		}
+		for _, stx := range stxns {
+			txns = append(txns, stx.Txn)
+		}
+	}
+	grouped, err := transaction.AssignGroupID(txns, "")
+	if err != nil {
+		return err
+	}
+	// the signatures no longer match once the group ID is set
+	stxns := make([]types.SignedTxn, len(grouped))
+	for i, txn := range grouped {
+		stxns[i].Txn = txn
+	}
+	fmt.Fprintf(e.stdout, "group: %s\n", base64.StdEncoding.EncodeToString(grouped[0].Group[:]))
+	return writeTxns(*out, stxns)
+}
+
+func runSplit(e env, args []string) error {
+	fs := newFlagSet(e, "split")
+	owner := fs.String("owner", "", "the address receiving the funds left after the expiry round")
+	receiverOne := fs.String("receiver-one", "", "the first receiver of the funds")
+	receiverTwo := fs.String("receiver-two", "", "the second receiver of the funds")
+	ratn := fs.Uint64("ratn", 0, "the numerator of the first receiver's share")
+	ratd := fs.Uint64("ratd", 0, "the denominator of the first receiver's share")
+	expiry := fs.Uint64("expiry", 0, "the round after which the owner may close out the contract")
+	minPay := fs.Uint64("min-pay", 0, "the minimum amount of microAlgos paid to the first receiver")
+	maxFee := fs.Uint64("max-fee", 0, "the maximum fee of each transaction, in microAlgos")
+	assetID := fs.Uint64("asset", 0, "the asset to split rather than Algos; min-pay is then in its base units")
+	optInRound := fs.Uint64("opt-in-round", 0, "the last valid round of the contract's only opt-in to the asset")
+	out := fs.String("out", "", "the file to write the program to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	split, err := makeSplit(templates.SplitParameters{Owner: *owner, ReceiverOne: *receiverOne, ReceiverTwo: *receiverTwo,
+		Ratn: *ratn, Ratd: *ratd, ExpiryRound: *expiry, MinPay: *minPay, MaxFee: *maxFee, AssetID: *assetID, OptInRound: *optInRound})
+	if err != nil {
+		return err
+	}
+	return printContract(e, split.ContractTemplate, *out)
+}
+
+// splitRemainders are the values of the -remainder flag of split-send
+var splitRemainders = map[string]templates.SplitRemainder{
+	"error": templates.RemainderError,
+	"round": templates.RemainderRound,
+	"keep":  templates.RemainderKeep,
+}
+
+func runSplitSend(e env, args []string) error {
+	fs := newFlagSet(e, "split-send")
+	programFile := fs.String("program", "", "the split contract's program")
+	amount := fs.Uint64("amount", 0, "the microAlgos, or base units of the asset, to pay out")
+	remainder := fs.String("remainder", "round", "what to do when the amount does not split exactly: round, error, or keep it in the contract")
+	out := fs.String("out", "", "the transaction file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *programFile == "" || *out == "" {
+		return fmt.Errorf("split-send takes -program and -out")
+	}
+	mode, ok := splitRemainders[*remainder]
+	if !ok {
+		return fmt.Errorf("unknown -remainder %q", *remainder)
+	}
+	program, err := ioutil.ReadFile(*programFile)
+	if err != nil {
+		return err
+	}
+	p, err := templates.ReadSplitFromProgram(program)
+	if err != nil {
+		return err
+	}
+	split, err := makeSplit(p)
+	if err != nil {
+		return err
+	}
+	sp, err := suggestedParams(e)
+	if err != nil {
+		return err
+	}
+	stxBytes, kept, err := split.GetSendFundsTransactionWithRemainder(context.Background(), split.Signer(), *amount, mode,
+		uint64(sp.FirstRoundValid), uint64(sp.LastRoundValid), uint64(sp.Fee), sp.GenesisHash)
+	if err != nil {
+		return err
+	}
+	if kept != 0 {
+		fmt.Fprintf(e.stdout, "remainder: %d microAlgos kept in the contract\n", kept)
+	}
+	return ioutil.WriteFile(*out, stxBytes, 0644)
+}
+
+// makeSplit makes the split of Algos or of an asset with parameters p
+func makeSplit(p templates.SplitParameters) (templates.Split, error) {
+	if p.AssetID != 0 {
+		return templates.MakeAssetSplit(p.Owner, p.ReceiverOne, p.ReceiverTwo, p.AssetID, p.Ratn, p.Ratd, p.ExpiryRound, p.OptInRound, p.MinPay, p.MaxFee)
+	}
+	return templates.MakeSplit(p.Owner, p.ReceiverOne, p.ReceiverTwo, p.Ratn, p.Ratd, p.ExpiryRound, p.MinPay, p.MaxFee)
+}
+
+func runHTLC(e env, args []string) error {
+	fs := newFlagSet(e, "htlc")
+	owner := fs.String("owner", "", "the address receiving the funds after the expiry round")
+	receiver := fs.String("receiver", "", "the address receiving the funds given the preimage")
+	hashFunction := fs.String("hash-function", "sha256", "the hash function of the image: sha256 or keccak256")
+	hashImage := fs.String("hash-image", "", "the hash of the preimage, in base64")
+	expiry := fs.Uint64("expiry", 0, "the round after which the owner may close out the contract")
+	maxFee := fs.Uint64("max-fee", 0, "the maximum fee of the claim, in microAlgos")
+	out := fs.String("out", "", "the file to write the program to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	htlc, err := templates.MakeHTLC(*owner, *receiver, *hashFunction, *hashImage, *expiry, *maxFee)
+	if err != nil {
+		return err
+	}
+	return printContract(e, htlc.ContractTemplate, *out)
+}
+
+func runHTLCClaim(e env, args []string) error {
+	fs := newFlagSet(e, "htlc-claim")
+	programFile := fs.String("program", "", "the hash time locked contract's program")
+	preImage := fs.String("preimage", "", "the preimage of the contract's hash image, in base64")
+	out := fs.String("out", "", "the transaction file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *programFile == "" || *out == "" {
+		return fmt.Errorf("htlc-claim takes -program and -out")
+	}
+	program, err := ioutil.ReadFile(*programFile)
+	if err != nil {
+		return err
+	}
+	p, err := templates.ReadHTLCFromProgram(program)
+	if err != nil {
+		return err
+	}
+	htlc, err := templates.MakeHTLC(p.Owner, p.Receiver, p.HashFunction, p.HashImage, p.ExpiryRound, p.MaxFee)
+	if err != nil {
+		return err
+	}
+	sp, err := suggestedParams(e)
+	if err != nil {
+		return err
+	}
+	stxBytes, err := htlc.GetClaimTransaction(*preImage, uint64(sp.FirstRoundValid), uint64(sp.LastRoundValid), uint64(sp.Fee), sp.GenesisHash)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(*out, stxBytes, 0644)
+}
+
+func runSend(e env, args []string) error {
+	fs := newFlagSet(e, "send")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("send takes one transaction file")
+	}
+	stxBytes, err := ioutil.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	// decoding first reports a malformed file without a round trip to algod
+	if _, err := transaction.Decode(stxBytes); err != nil {
+		return fmt.Errorf("%s: %v", fs.Arg(0), err)
+	}
+	client, err := algodClient(e)
+	if err != nil {
+		return err
+	}
+	txid, err := client.SendRawTransaction(stxBytes).Do(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(e.stdout, "txid: %s\n", txid)
+	return nil
+}
+
+// printContract prints the address and program of a contract, and writes its
+// program to out if it is set
+func printContract(e env, contract templates.ContractTemplate, out string) error {
+	fmt.Fprintf(e.stdout, "address: %s\nprogram: %s\n", contract.GetAddress(), base64.StdEncoding.EncodeToString(contract.GetProgram()))
+	if out == "" {
+		return nil
+	}
+	return ioutil.WriteFile(out, contract.GetProgram(), 0644)
+}
+
+func readTxns(path string) ([]types.SignedTxn, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	stxns, err := transaction.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return stxns, nil
+}
+
+func writeTxns(path string, stxns []types.SignedTxn) error {
+	var data []byte
+	for _, stx := range stxns {
+		data = append(data, msgpack.Encode(stx)...)
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// readMnemonic reads the key to sign with from ALGOSDK_MNEMONIC, or else from
+// the first line of stdin
+func readMnemonic(e env) (crypto.SecretKey, error) {
+	m := e.getenv("ALGOSDK_MNEMONIC")
+	if m == "" {
+		line, err := bufio.NewReader(e.stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		m = strings.TrimSpace(line)
+	}
+	if m == "" {
+		return nil, fmt.Errorf("no mnemonic: set ALGOSDK_MNEMONIC or write it to stdin")
+	}
+	return mnemonic.ToPrivateKey(m)
+}
+
+func algodClient(e env) (*algod.Client, error) {
+	address, token := e.getenv("ALGOD_ADDRESS"), e.getenv("ALGOD_TOKEN")
+	if address == "" {
+		address = dev.AlgodAddress
+	}
+	if token == "" {
+		token = dev.Token
+	}
+	return algod.MakeClient(address, token)
+}
+
+func suggestedParams(e env) (types.SuggestedParams, error) {
+	client, err := algodClient(e)
+	if err != nil {
+		return types.SuggestedParams{}, err
+	}
+	return client.SuggestedParams().Do(context.Background())
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/mock"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/future"
+	"github.com/algorand/go-algorand-sdk/mnemonic"
+	"github.com/algorand/go-algorand-sdk/templates"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func runWith(t *testing.T, vars map[string]string, stdin string, args ...string) (string, error) {
+	var stdout bytes.Buffer
+	e := env{stdin: strings.NewReader(stdin), stdout: &stdout, getenv: func(name string) string { return vars[name] }}
+	err := run(e, args)
+	return stdout.String(), err
+}
+
+func TestCommands(t *testing.T) {
+	dir, err := ioutil.TempDir("", "algosdk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	n := mock.NewNetwork()
+	defer n.Close()
+	vars := map[string]string{"ALGOD_ADDRESS": n.Algod.URL}
+
+	out, err := runWith(t, vars, "", "account")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	m := strings.TrimSpace(strings.TrimPrefix(lines[1], "mnemonic:"))
+	sk, err := mnemonic.ToPrivateKey(m)
+	require.NoError(t, err)
+	account, err := crypto.AccountFromPrivateKey(sk)
+	require.NoError(t, err)
+	require.Contains(t, lines[0], account.Address.String())
+	n.Fund(account.Address, 10000000)
+
+	// encode the JSON of two payments, group and sign them, then send them
+	receiver := crypto.GenerateAccount().Address
+	params, err := suggestedParams(env{getenv: func(name string) string { return vars[name] }})
+	require.NoError(t, err)
+	var stxns []types.SignedTxn
+	for _, amount := range []uint64{100000, 200000} {
+		txn, err := future.MakePaymentTxn(account.Address.String(), receiver.String(), amount, nil, "", params)
+		require.NoError(t, err)
+		stxns = append(stxns, types.SignedTxn{Txn: txn})
+	}
+	require.NoError(t, writeTxns(path("txns.msgp"), stxns))
+	out, err = runWith(t, vars, "", "decode", "-json", path("txns.msgp"))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path("txns.json"), []byte(out), 0644))
+	_, err = runWith(t, vars, "", "encode", "-out", path("encoded.msgp"), path("txns.json"))
+	require.NoError(t, err)
+	encoded, err := readTxns(path("encoded.msgp"))
+	require.NoError(t, err)
+	require.Equal(t, stxns, encoded)
+
+	out, err = runWith(t, vars, "", "group", "-out", path("grouped.msgp"), path("encoded.msgp"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out, "group: "))
+	_, err = runWith(t, vars, m+"\n", "sign", "-out", path("signed.msgp"), path("grouped.msgp"))
+	require.NoError(t, err)
+
+	out, err = runWith(t, vars, "", "decode", path("signed.msgp"))
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(out, "signature:         single\n"))
+	require.Equal(t, 2, strings.Count(out, "group:"))
+
+	out, err = runWith(t, vars, "", "send", path("signed.msgp"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out, "txid: "))
+	n.AdvanceRound()
+	require.Equal(t, uint64(300000), n.Balance(receiver))
+
+	// signing without a mnemonic fails
+	_, err = runWith(t, vars, "", "sign", "-out", path("signed.msgp"), path("grouped.msgp"))
+	require.Error(t, err)
+}
+
+func TestContractCommands(t *testing.T) {
+	dir, err := ioutil.TempDir("", "algosdk")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	program := filepath.Join(dir, "split.teal.tok")
+
+	owner, one, two := crypto.GenerateAccount().Address, crypto.GenerateAccount().Address, crypto.GenerateAccount().Address
+	out, err := runWith(t, nil, "", "split", "-owner", owner.String(), "-receiver-one", one.String(), "-receiver-two", two.String(),
+		"-ratn", "1", "-ratd", "3", "-expiry", "5000", "-min-pay", "1000", "-max-fee", "2000", "-out", program)
+	require.NoError(t, err)
+	require.Contains(t, out, "address: ")
+	written, err := ioutil.ReadFile(program)
+	require.NoError(t, err)
+	require.Contains(t, out, "program: "+base64.StdEncoding.EncodeToString(written)+"\n")
+
+	out, err = runWith(t, nil, "", "split", "-owner", owner.String(), "-receiver-one", one.String(), "-receiver-two", two.String(),
+		"-ratn", "1", "-ratd", "3", "-expiry", "5000", "-min-pay", "1000", "-max-fee", "2000", "-asset", "7", "-opt-in-round", "4000", "-out", program)
+	require.NoError(t, err)
+	written, err = ioutil.ReadFile(program)
+	require.NoError(t, err)
+	params, err := templates.ReadSplitFromProgram(written)
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), params.AssetID)
+	require.Equal(t, uint64(4000), params.OptInRound)
+
+	image := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	_, err = runWith(t, nil, "", "htlc", "-owner", owner.String(), "-receiver", one.String(), "-hash-image", image, "-expiry", "5000", "-max-fee", "2000")
+	require.NoError(t, err)
+	_, err = runWith(t, nil, "", "htlc", "-owner", owner.String(), "-receiver", one.String(), "-hash-function", "md5", "-hash-image", image)
+	require.Error(t, err)
+
+	_, err = runWith(t, nil, "", "unknown")
+	require.Error(t, err)
+	_, err = runWith(t, nil, "")
+	require.Equal(t, errUsage, err)
+}
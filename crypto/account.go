@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"bytes"
 	"crypto/sha512"
 	"fmt"
 
@@ -16,7 +17,7 @@ const msigAddrPrefix = "MultisigAddr"
 // Algorand address
 type Account struct {
 	PublicKey  ed25519.PublicKey
-	PrivateKey ed25519.PrivateKey
+	PrivateKey SecretKey
 	Address    types.Address
 }
 
@@ -45,11 +46,32 @@ func GenerateAccount() (kp Account) {
 
 	// Build the account
 	kp.PublicKey = pk
-	kp.PrivateKey = sk
+	kp.PrivateKey = SecretKey(sk)
 	kp.Address = a
 	return
 }
 
+// AccountFromPrivateKey derives the Account of an ed25519 private key, such as
+// one restored from a mnemonic with mnemonic.ToPrivateKey
+func AccountFromPrivateKey(sk SecretKey) (account Account, err error) {
+	if len(sk) != ed25519.PrivateKeySize {
+		err = ErrInvalidPrivateKey
+		return
+	}
+	// the second half of the private key is the public key, which must be
+	// the one derived from the seed in the first half
+	pk := ed25519.NewKeyFromSeed(sk[:ed25519.SeedSize]).Public().(ed25519.PublicKey)
+	if !bytes.Equal(pk, sk[ed25519.SeedSize:]) {
+		err = ErrInvalidPrivateKey
+		return
+	}
+
+	account.PublicKey = pk
+	account.PrivateKey = sk
+	copy(account.Address[:], pk)
+	return
+}
+
 /* Multisig Support */
 
 // MultisigAccount is a convenience type for holding multisig preimage data
@@ -62,7 +84,10 @@ type MultisigAccount struct {
 	Pks []ed25519.PublicKey
 }
 
-// MultisigAccountWithParams creates a MultisigAccount with the given parameters
+// MultisigAccountWithParams creates a MultisigAccount with the given
+// parameters. As the protocol does, it accepts an address listed several
+// times, with the weight of several signatures; ValidateDistinct rejects such
+// accounts, e.g. when setting one up.
 func MultisigAccountWithParams(version uint8, threshold uint8, addrs []types.Address) (ma MultisigAccount, err error) {
 	ma.Version = version
 	ma.Threshold = threshold
@@ -74,6 +99,15 @@ func MultisigAccountWithParams(version uint8, threshold uint8, addrs []types.Add
 	return
 }
 
+// MultisigAddress returns the address of the multisig account of the given
+// version and threshold with the public keys pks, in order, without needing a
+// transaction, e.g. to set up a cold storage account. Keys listed several
+// times are accepted, as for MultisigAccountWithParams.
+func MultisigAddress(version uint8, threshold uint8, pks []ed25519.PublicKey) (types.Address, error) {
+	ma := MultisigAccount{Version: version, Threshold: threshold, Pks: pks}
+	return ma.Address()
+}
+
 // MultisigAccountFromSig is a convenience method that creates an account
 // from a sig in a signed tx. Useful for getting addresses from signed msig txs, etc.
 func MultisigAccountFromSig(sig types.MultisigSig) (ma MultisigAccount, err error) {
@@ -105,19 +139,44 @@ func (ma MultisigAccount) Address() (addr types.Address, err error) {
 	return sha512.Sum512_256(buffer), nil
 }
 
-// Validate ensures that this multisig setup is a valid multisig account
+// Validate ensures that this multisig setup is a valid multisig account: its
+// threshold is between 1 and its number of keys, which are ed25519 public keys
 func (ma MultisigAccount) Validate() (err error) {
 	if ma.Version != 1 {
-		err = errMsigUnknownVersion
+		err = ErrMsigUnknownVersion
 		return
 	}
 	if ma.Threshold == 0 || len(ma.Pks) == 0 || int(ma.Threshold) > len(ma.Pks) {
-		err = errMsigInvalidThreshold
+		err = ErrMsigInvalidThreshold
 		return
 	}
+	for i, pk := range ma.Pks {
+		if len(pk) != ed25519.PublicKeySize {
+			err = fmt.Errorf("multisig public key %d has length %d, expected %d", i, len(pk), ed25519.PublicKeySize)
+			return
+		}
+	}
 	return
 }
 
+// ValidateDistinct validates the account and checks that its keys are
+// distinct. The protocol accepts a key listed several times, with the weight
+// of several signatures, but that is almost always a mistake when setting up
+// an account.
+func (ma MultisigAccount) ValidateDistinct() error {
+	if err := ma.Validate(); err != nil {
+		return err
+	}
+	seen := make(map[string]int, len(ma.Pks))
+	for i, pk := range ma.Pks {
+		if j, ok := seen[string(pk)]; ok {
+			return fmt.Errorf("multisig public keys %d and %d are the same", j, i)
+		}
+		seen[string(pk)] = i
+	}
+	return nil
+}
+
 // Blank return true if MultisigAccount is empty
 // struct containing []ed25519.PublicKey cannot be compared
 func (ma MultisigAccount) Blank() bool {
@@ -145,3 +204,96 @@ func LogicSigAddress(lsig types.LogicSig) types.Address {
 	}
 	return addr
 }
+
+/* LogicSig account support */
+
+// LogicSigAccount is a LogicSig together with the public key that signed it,
+// if it was delegated by a single account. A LogicSig alone does not record
+// that key, so it is needed to know which account the LogicSig signs for.
+type LogicSigAccount struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// Lsig is the LogicSig
+	Lsig types.LogicSig `codec:"lsig"`
+
+	// SigningKey is the public key of the account that signed Lsig.Sig, if any
+	SigningKey ed25519.PublicKey `codec:"sigkey"`
+}
+
+// MakeLogicSigAccountEscrow makes a LogicSigAccount for the contract account
+// of program, whose address is the hash of the program
+func MakeLogicSigAccountEscrow(program []byte, args [][]byte) (lsa LogicSigAccount, err error) {
+	lsa.Lsig, err = MakeLogicSig(program, args, nil, MultisigAccount{})
+	return
+}
+
+// MakeLogicSigAccountDelegated makes a LogicSigAccount delegating the
+// account of sk to program
+func MakeLogicSigAccountDelegated(program []byte, args [][]byte, sk SecretKey) (lsa LogicSigAccount, err error) {
+	lsa.Lsig, err = MakeLogicSig(program, args, sk, MultisigAccount{})
+	if err != nil {
+		return
+	}
+	lsa.SigningKey = sk.PublicKey()
+	return
+}
+
+// MakeLogicSigAccountDelegatedMsig makes a LogicSigAccount delegating the
+// multisig account ma to program, signed by sk. The other members of ma add
+// their signatures with AppendMultisigSignature.
+func MakeLogicSigAccountDelegatedMsig(program []byte, args [][]byte, ma MultisigAccount, sk SecretKey) (lsa LogicSigAccount, err error) {
+	if ma.Blank() {
+		err = ErrLsigEmptyMsig
+		return
+	}
+	lsa.Lsig, err = MakeLogicSig(program, args, sk, ma)
+	return
+}
+
+// AppendMultisigSignature adds the signature of sk to a LogicSigAccount
+// delegated by a multisig account
+func (lsa *LogicSigAccount) AppendMultisigSignature(sk SecretKey) error {
+	return AppendMultisigToLogicSig(&lsa.Lsig, sk)
+}
+
+// IsDelegated returns true if the LogicSig is signed by an account or
+// multisig account, rather than being a contract account
+func (lsa LogicSigAccount) IsDelegated() bool {
+	return lsa.Lsig.Sig != (types.Signature{}) || !lsa.Lsig.Msig.Blank()
+}
+
+// Address returns the address of the account the LogicSig signs for: the
+// delegating account, or the contract account
+func (lsa LogicSigAccount) Address() (addr types.Address, err error) {
+	hasSig := lsa.Lsig.Sig != (types.Signature{})
+	hasMsig := !lsa.Lsig.Msig.Blank()
+	switch {
+	case hasSig && hasMsig:
+		err = ErrLsigInvalidSignature
+	case hasSig:
+		if len(lsa.SigningKey) != ed25519.PublicKeySize {
+			err = fmt.Errorf("delegated LogicSig has no signing key")
+			return
+		}
+		copy(addr[:], lsa.SigningKey)
+	case hasMsig:
+		var ma MultisigAccount
+		ma, err = MultisigAccountFromSig(lsa.Lsig.Msig)
+		if err != nil {
+			return
+		}
+		addr, err = ma.Address()
+	default:
+		addr = LogicSigAddress(lsa.Lsig)
+	}
+	return
+}
+
+// Verify returns true if the LogicSig is valid for the account it signs for
+func (lsa LogicSigAccount) Verify() bool {
+	addr, err := lsa.Address()
+	if err != nil {
+		return false
+	}
+	return VerifyLogicSig(lsa.Lsig, addr)
+}
@@ -1,7 +1,13 @@
 package crypto
 
 import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ed25519"
@@ -23,6 +29,22 @@ func TestKeyGeneration(t *testing.T) {
 	require.Equal(t, pk, kp.PublicKey)
 }
 
+func TestAccountFromPrivateKey(t *testing.T) {
+	kp := GenerateAccount()
+	account, err := AccountFromPrivateKey(kp.PrivateKey)
+	require.NoError(t, err)
+	require.Equal(t, kp, account)
+
+	_, err = AccountFromPrivateKey(kp.PrivateKey[:ed25519.SeedSize])
+	require.Error(t, err)
+
+	// a private key whose public half was not derived from its seed
+	other := GenerateAccount()
+	mismatched := append(append(SecretKey{}, kp.PrivateKey.Seed()...), other.PublicKey...)
+	_, err = AccountFromPrivateKey(mismatched)
+	require.Error(t, err)
+}
+
 func TestMultisigAccount_Address(t *testing.T) {
 	addr1, err := types.DecodeAddress("XMHLMNAVJIMAW2RHJXLXKKK4G3J3U6VONNO3BTAQYVDC3MHTGDP3J5OCRU")
 	require.NoError(t, err)
@@ -66,3 +88,121 @@ func TestMultisigAccount_Version1Only(t *testing.T) {
 	})
 	require.Error(t, ma.Validate())
 }
+
+func TestMultisigAddress(t *testing.T) {
+	var pks []ed25519.PublicKey
+	for _, a := range []string{
+		"XMHLMNAVJIMAW2RHJXLXKKK4G3J3U6VONNO3BTAQYVDC3MHTGDP3J5OCRU",
+		"HTNOX33OCQI2JCOLZ2IRM3BC2WZ6JUILSLEORBPFI6W7GU5Q4ZW6LINHLA",
+		"E6JSNTY4PVCY3IRZ6XEDHEO6VIHCQ5KGXCIQKFQCMB2N6HXRY4IB43VSHI",
+	} {
+		addr, err := types.DecodeAddress(a)
+		require.NoError(t, err)
+		pks = append(pks, addr[:])
+	}
+	addr, err := MultisigAddress(1, 2, pks)
+	require.NoError(t, err)
+	require.Equal(t, "UCE2U2JC4O4ZR6W763GUQCG57HQCDZEUJY4J5I6VYY4HQZUJDF7AKZO5GM", addr.String())
+
+	_, err = MultisigAddress(1, 0, pks)
+	require.Error(t, err)
+	_, err = MultisigAddress(1, 4, pks)
+	require.Error(t, err)
+	_, err = MultisigAddress(2, 2, pks)
+	require.Error(t, err)
+	_, err = MultisigAddress(1, 2, []ed25519.PublicKey{pks[0], pks[1][:31]})
+	require.Error(t, err)
+
+	// the protocol accepts duplicate keys, so accounts with them are made
+	// and have an address, unless distinct keys are asked for
+	duplicated := []ed25519.PublicKey{pks[0], pks[1], pks[0]}
+	_, err = MultisigAddress(1, 2, duplicated)
+	require.NoError(t, err)
+	ma, err := MultisigAccountWithParams(1, 2, []types.Address{addr, addr})
+	require.NoError(t, err)
+	require.Error(t, ma.ValidateDistinct())
+	ma = MultisigAccount{Version: 1, Threshold: 2, Pks: duplicated}
+	_, err = ma.Address()
+	require.NoError(t, err)
+	require.Error(t, ma.ValidateDistinct())
+	ma.Pks = pks
+	require.NoError(t, ma.ValidateDistinct())
+}
+
+func TestGenerateVanityAddress(t *testing.T) {
+	var calls int
+	account, err := GenerateVanityAddress(context.Background(), "AB", 2, func(attempts, expected uint64) {
+		calls++
+		require.Equal(t, uint64(1024), expected)
+		require.NotZero(t, attempts)
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(account.Address.String(), "AB"))
+	require.NotZero(t, calls)
+	recovered, err := AccountFromPrivateKey(account.PrivateKey)
+	require.NoError(t, err)
+	require.Equal(t, account, recovered)
+
+	_, err = GenerateVanityAddress(context.Background(), "AB1", 1, nil)
+	require.Error(t, err)
+	_, err = GenerateVanityAddress(context.Background(), "ab", 1, nil)
+	require.Error(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = GenerateVanityAddress(ctx, "AAAAAAAAAA", 0, nil)
+	require.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestGenerateVanityAddressStops(t *testing.T) {
+	defer func(generate func() Account) { vanityGenerateAccount = generate }(vanityGenerateAccount)
+	match := GenerateAccount()
+	prefix := match.Address.String()[:10]
+
+	// the first worker to try finds the match and the others never do, so
+	// the search returns only if it stops them
+	var calls int32
+	vanityGenerateAccount = func() Account {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return match
+		}
+		time.Sleep(time.Millisecond)
+		return GenerateAccount()
+	}
+	done := make(chan Account, 1)
+	go func() {
+		account, err := GenerateVanityAddress(context.Background(), prefix, 4, nil)
+		require.NoError(t, err)
+		done <- account
+	}()
+	select {
+	case account := <-done:
+		require.Equal(t, match.Address, account.Address)
+	case <-time.After(5 * time.Second):
+		t.Fatal("GenerateVanityAddress did not return after finding a match")
+	}
+
+	// every worker finds a match, and the keys of those not returned are wiped
+	var mu sync.Mutex
+	var found []Account
+	vanityGenerateAccount = func() Account {
+		account := match
+		account.PrivateKey = append(SecretKey{}, match.PrivateKey...)
+		mu.Lock()
+		found = append(found, account)
+		mu.Unlock()
+		return account
+	}
+	account, err := GenerateVanityAddress(context.Background(), prefix, 4, nil)
+	require.NoError(t, err)
+	require.Equal(t, match.PrivateKey, account.PrivateKey)
+	kept := 0
+	for _, f := range found {
+		if bytes.Equal(f.PrivateKey, match.PrivateKey) {
+			kept++
+		} else {
+			require.True(t, f.PrivateKey.IsZero())
+		}
+	}
+	require.Equal(t, 1, kept)
+}
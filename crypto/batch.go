@@ -0,0 +1,117 @@
+package crypto
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// signingChunkPerWorker is how many transactions per worker
+// SignTransactionsTo signs before writing them, which bounds the signed
+// transactions it holds in memory however many it is given
+const signingChunkPerWorker = 64
+
+// SignTransactions signs a batch of transactions with sk, as SignTransaction
+// does, on parallelism goroutines, or one per CPU if parallelism is not
+// positive. The txids and signed transaction bytes are returned in the order
+// of txns. If signing fails, the error of the first transaction that failed is
+// returned.
+func SignTransactions(sk SecretKey, txns []types.Transaction, parallelism int) (txids []string, stxBytes [][]byte, err error) {
+	txids = make([]string, len(txns))
+	stxBytes = make([][]byte, len(txns))
+	failed, err := parallelFor(len(txns), parallelism, func(i int) (err error) {
+		txids[i], stxBytes[i], err = SignTransaction(sk, txns[i])
+		return
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("transaction %d: %v", failed, err)
+	}
+	return
+}
+
+// SignTransactionsTo signs a batch of transactions with sk, as
+// SignTransactions does, and writes the signed transactions to w in the order
+// of txns, concatenated as SendRawTransaction takes them. Transactions are
+// signed and written in chunks, so that batches of any size, such as those of
+// an airdrop, are signed in bounded memory. Each signed transaction is passed
+// to w in a separate Write, so a w writing to a file or connection is best
+// buffered. Transactions written before an error are not rolled back.
+func SignTransactionsTo(w io.Writer, sk SecretKey, txns []types.Transaction, parallelism int) error {
+	if parallelism <= 0 {
+		parallelism = runtime.NumCPU()
+	}
+	chunk := parallelism * signingChunkPerWorker
+	if chunk > len(txns) {
+		chunk = len(txns)
+	}
+	// the buffers are reused by each chunk, so signing does not allocate them
+	// once they are long enough
+	bufs := make([][]byte, chunk)
+	for start := 0; start < len(txns); start += chunk {
+		batch := txns[start:]
+		if len(batch) > chunk {
+			batch = batch[:chunk]
+		}
+		failed, err := parallelFor(len(batch), parallelism, func(i int) error {
+			stx, _, err := signTransaction(sk, batch[i])
+			if err != nil {
+				return err
+			}
+			bufs[i] = msgpack.AppendEncode(bufs[i][:0], &stx)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("transaction %d: %v", start+failed, err)
+		}
+		for i := range batch {
+			if _, err := w.Write(bufs[i]); err != nil {
+				return fmt.Errorf("transaction %d: %v", start+i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parallelFor calls f with each index below n on workers goroutines, or one
+// per CPU if workers is not positive. Once f fails, no more indexes are
+// started, and the lowest failed index and its error are returned.
+func parallelFor(n, workers int, f func(i int) error) (failed int, err error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > n {
+		workers = n
+	}
+	var next int64 = -1
+	var stop int32
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for atomic.LoadInt32(&stop) == 0 {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= n {
+					return
+				}
+				if ferr := f(i); ferr != nil {
+					atomic.StoreInt32(&stop, 1)
+					mu.Lock()
+					if err == nil || i < failed {
+						failed, err = i, ferr
+					}
+					mu.Unlock()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return
+}
@@ -5,7 +5,9 @@ import (
 	"crypto/rand"
 	"crypto/sha512"
 	"encoding/base32"
+	"encoding/binary"
 	"fmt"
+
 	"golang.org/x/crypto/ed25519"
 
 	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
@@ -28,6 +30,9 @@ var bytesPrefix = []byte("MX")
 // programPrefix is prepended to a logic program when computing a hash
 var programPrefix = []byte("Program")
 
+// programDataPrefix is prepended to the program hash and data that ed25519verify checks
+var programDataPrefix = []byte("ProgData")
+
 // RandomBytes fills the passed slice with randomness, and panics if it is
 // unable to do so
 func RandomBytes(s []byte) {
@@ -38,32 +43,90 @@ func RandomBytes(s []byte) {
 }
 
 // SignTransaction accepts a private key and a transaction, and returns the
-// bytes of a signed transaction ready to be broadcasted to the network
-func SignTransaction(sk ed25519.PrivateKey, tx types.Transaction) (txid string, stxBytes []byte, err error) {
+// bytes of a signed transaction ready to be broadcasted to the network.
+// The key may be that of the account the sender was rekeyed to rather than
+// the sender's own, in which case its address is the signed transaction's AuthAddr.
+func SignTransaction(sk SecretKey, tx types.Transaction) (txid string, stxBytes []byte, err error) {
+	stx, txid, err := signTransaction(sk, tx)
+	if err != nil {
+		return
+	}
+
+	// Encode the SignedTxn
+	stxBytes = msgpack.Encode(stx)
+	return
+}
+
+// signTransaction returns the SignedTxn of tx signed by sk, and its txid
+func signTransaction(sk SecretKey, tx types.Transaction) (stx types.SignedTxn, txid string, err error) {
 	s, txid, err := rawSignTransaction(sk, tx)
 	if err != nil {
 		return
 	}
 	// Construct the SignedTxn
-	stx := types.SignedTxn{
+	stx = types.SignedTxn{
 		Sig: s,
 		Txn: tx,
 	}
+	var signer types.Address
+	copy(signer[:], sk.PublicKey())
+	if signer != tx.Sender {
+		stx.AuthAddr = signer
+	}
+	return
+}
 
-	// Encode the SignedTxn
-	stxBytes = msgpack.Encode(stx)
+// DecodeSignedTransaction decodes the bytes of a single signed transaction, as
+// returned by SignTransaction, and returns it with its txid
+func DecodeSignedTransaction(stxBytes []byte) (stx types.SignedTxn, txid string, err error) {
+	r := bytes.NewReader(stxBytes)
+	err = msgpack.NewDecoder(r).Decode(&stx)
+	if err != nil {
+		return
+	}
+	if r.Len() != 0 {
+		err = ErrDecodeTrailingBytes
+		return
+	}
+	txid = txIDFromTransaction(stx.Txn)
 	return
 }
 
-// rawTransactionBytesToSign returns the byte form of the tx that we actually sign
-// and compute txID from.
-func rawTransactionBytesToSign(tx types.Transaction) []byte {
-	// Encode the transaction as msgpack
-	encodedTx := msgpack.Encode(tx)
+// RawTransactionBytesToSign returns the bytes a signature of tx signs, from
+// which its ID is computed: its msgpack encoding prefixed with "TX". Signers
+// holding keys outside the SDK, such as hardware wallets, KMSes or air-gapped
+// machines, sign these bytes, and AttachSignature makes the signed
+// transaction.
+func RawTransactionBytesToSign(tx types.Transaction) []byte {
+	// Append the transaction's encoding to the hashable prefix; capping the
+	// prefix's capacity makes append copy it rather than write into it
+	return msgpack.AppendEncode(txidPrefix[:len(txidPrefix):len(txidPrefix)], tx)
+}
 
-	// Prepend the hashable prefix
-	msgParts := [][]byte{txidPrefix, encodedTx}
-	return bytes.Join(msgParts, nil)
+// AttachSignature returns the signed transaction of tx and a signature of its
+// RawTransactionBytesToSign by its sender's key, made offline. An error is
+// returned if the signature does not verify.
+func AttachSignature(tx types.Transaction, signature []byte) (txid string, stxBytes []byte, err error) {
+	return AttachSignatureWithSigner(tx, tx.Sender, signature)
+}
+
+// AttachSignatureWithSigner is AttachSignature for a signature by the key of
+// signer, the account the sender was rekeyed to if it is not the sender,
+// which is then the signed transaction's AuthAddr
+func AttachSignatureWithSigner(tx types.Transaction, signer types.Address, signature []byte) (txid string, stxBytes []byte, err error) {
+	toBeSigned := RawTransactionBytesToSign(tx)
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(signer[:], toBeSigned, signature) {
+		err = ErrInvalidSignature
+		return
+	}
+	stx := types.SignedTxn{Txn: tx}
+	copy(stx.Sig[:], signature)
+	if signer != tx.Sender {
+		stx.AuthAddr = signer
+	}
+	txid = txIDFromRawTxnBytesToSign(toBeSigned)
+	stxBytes = msgpack.Encode(stx)
+	return
 }
 
 // txID computes a transaction id from raw transaction bytes
@@ -73,24 +136,69 @@ func txIDFromRawTxnBytesToSign(toBeSigned []byte) (txid string) {
 	return
 }
 
+// GetTxID returns the ID of the transaction tx
+func GetTxID(tx types.Transaction) string {
+	return txIDFromTransaction(tx)
+}
+
+// GetInnerTxID returns the ID of the inner transaction tx, issued by the
+// transaction with ID parent as its index'th inner transaction. Inner
+// transactions are not signed, so their IDs fold in their parent's ID and
+// their position to keep them unique.
+func GetInnerTxID(parent string, index int, tx types.Transaction) (string, error) {
+	parentID, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(parent)
+	if err != nil || len(parentID) != sha512.Size256 {
+		return "", ErrInvalidTxID
+	}
+	var indexBytes [8]byte
+	binary.BigEndian.PutUint64(indexBytes[:], uint64(index))
+	msgParts := [][]byte{txidPrefix, parentID, indexBytes[:], msgpack.Encode(tx)}
+	return txIDFromRawTxnBytesToSign(bytes.Join(msgParts, nil)), nil
+}
+
+// GetFlatTxIDs returns the IDs of transactions flattened by
+// types.SignedTxnWithAD.Flatten. The root must have its genesis ID and hash
+// set, as by types.Block.SignedTxns, for the IDs to be those on chain.
+func GetFlatTxIDs(flat []types.FlatTxn) ([]string, error) {
+	ids := make([]string, len(flat))
+	for i, ftx := range flat {
+		if ftx.Parent < 0 {
+			ids[i] = GetTxID(ftx.Txn)
+			continue
+		}
+		if ftx.Parent >= i {
+			return nil, ErrInvalidFlatTxns
+		}
+		id, err := GetInnerTxID(ids[ftx.Parent], ftx.Index, ftx.Txn)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
 // txIDFromTransaction is a convenience function for generating txID from txn
 func txIDFromTransaction(tx types.Transaction) (txid string) {
-	txid = txIDFromRawTxnBytesToSign(rawTransactionBytesToSign(tx))
+	txid = txIDFromRawTxnBytesToSign(RawTransactionBytesToSign(tx))
 	return
 }
 
 // rawSignTransaction signs the msgpack-encoded tx (with prepended "TX" prefix), and returns the sig and txid
-func rawSignTransaction(sk ed25519.PrivateKey, tx types.Transaction) (s types.Signature, txid string, err error) {
-	toBeSigned := rawTransactionBytesToSign(tx)
+func rawSignTransaction(sk SecretKey, tx types.Transaction) (s types.Signature, txid string, err error) {
+	toBeSigned := RawTransactionBytesToSign(tx)
 
 	// Sign the encoded transaction
-	signature := ed25519.Sign(sk, toBeSigned)
+	signature, err := sk.Sign(toBeSigned)
+	if err != nil {
+		return
+	}
 
 	// Copy the resulting signature into a Signature, and check that it's
 	// the expected length
 	n := copy(s[:], signature)
 	if n != len(s) {
-		err = errInvalidSignatureReturned
+		err = ErrInvalidSignatureReturned
 		return
 	}
 	// Populate txID
@@ -99,12 +207,12 @@ func rawSignTransaction(sk ed25519.PrivateKey, tx types.Transaction) (s types.Si
 }
 
 // SignBytes signs the bytes and returns the signature
-func SignBytes(sk ed25519.PrivateKey, bytesToSign []byte) (signature []byte, err error) {
+func SignBytes(sk SecretKey, bytesToSign []byte) (signature []byte, err error) {
 	// prepend the prefix for signing bytes
 	toBeSigned := bytes.Join([][]byte{bytesPrefix, bytesToSign}, nil)
 
 	// sign the bytes
-	signature = ed25519.Sign(sk, toBeSigned)
+	signature, err = sk.Sign(toBeSigned)
 	return
 }
 
@@ -117,7 +225,7 @@ func VerifyBytes(pk ed25519.PublicKey, message, signature []byte) bool {
 
 // SignBid accepts a private key and a bid, and returns the signature of the
 // bid under that key
-func SignBid(sk ed25519.PrivateKey, bid types.Bid) (signedBid []byte, err error) {
+func SignBid(sk SecretKey, bid types.Bid) (signedBid []byte, err error) {
 	// Encode the bid as msgpack
 	encodedBid := msgpack.Encode(bid)
 
@@ -126,12 +234,15 @@ func SignBid(sk ed25519.PrivateKey, bid types.Bid) (signedBid []byte, err error)
 	toBeSigned := bytes.Join(msgParts, nil)
 
 	// Sign the encoded bid
-	sig := ed25519.Sign(sk, toBeSigned)
+	sig, err := sk.Sign(toBeSigned)
+	if err != nil {
+		return
+	}
 
 	var s types.Signature
 	n := copy(s[:], sig)
 	if n != len(s) {
-		err = errInvalidSignatureReturned
+		err = ErrInvalidSignatureReturned
 		return
 	}
 
@@ -154,17 +265,17 @@ func SignBid(sk ed25519.PrivateKey, bid types.Bid) (signedBid []byte, err error)
 type signer func() (signature types.Signature, err error)
 
 // Service function to make a single signature in Multisig
-func multisigSingle(sk ed25519.PrivateKey, ma MultisigAccount, customSigner signer) (msig types.MultisigSig, myIndex int, err error) {
+func multisigSingle(sk SecretKey, ma MultisigAccount, customSigner signer) (msig types.MultisigSig, myIndex int, err error) {
 	// check that sk.pk exists in the list of public keys in MultisigAccount ma
 	myIndex = len(ma.Pks)
-	myPublicKey := sk.Public().(ed25519.PublicKey)
+	myPublicKey := sk.PublicKey()
 	for i := 0; i < len(ma.Pks); i++ {
 		if bytes.Equal(myPublicKey, ma.Pks[i]) {
 			myIndex = i
 		}
 	}
 	if myIndex == len(ma.Pks) {
-		err = errMsigInvalidSecretKey
+		err = ErrMsigInvalidSecretKey
 		return
 	}
 
@@ -188,20 +299,17 @@ func multisigSingle(sk ed25519.PrivateKey, ma MultisigAccount, customSigner sign
 // SignMultisigTransaction signs the given transaction, and multisig preimage, with the
 // private key, returning the bytes of a signed transaction with the multisig field
 // partially populated, ready to be passed to other multisig signers to sign or broadcast.
-func SignMultisigTransaction(sk ed25519.PrivateKey, ma MultisigAccount, tx types.Transaction) (txid string, stxBytes []byte, err error) {
+// If the transaction's sender is not the multisig account, the sender must have been
+// rekeyed to it, and the multisig address is the signed transaction's AuthAddr.
+func SignMultisigTransaction(sk SecretKey, ma MultisigAccount, tx types.Transaction) (txid string, stxBytes []byte, err error) {
 	err = ma.Validate()
 	if err != nil {
 		return
 	}
-	// check that the address of txn matches the preimage
 	maAddress, err := ma.Address()
 	if err != nil {
 		return
 	}
-	if tx.Sender != maAddress { // array value comparison is fine
-		err = errMsigBadTxnSender
-		return
-	}
 
 	// this signer signs a transaction and sets txid from the closure
 	customSigner := func() (rawSig types.Signature, err error) {
@@ -219,6 +327,9 @@ func SignMultisigTransaction(sk ed25519.PrivateKey, ma MultisigAccount, tx types
 		Msig: sig,
 		Txn:  tx,
 	}
+	if tx.Sender != maAddress { // array value comparison is fine
+		stx.AuthAddr = maAddress
+	}
 	stxBytes = msgpack.Encode(stx)
 	return
 }
@@ -227,7 +338,7 @@ func SignMultisigTransaction(sk ed25519.PrivateKey, ma MultisigAccount, tx types
 // returns an encoded signed multisig transaction with the component signatures.
 func MergeMultisigTransactions(stxsBytes ...[]byte) (txid string, stxBytes []byte, err error) {
 	if len(stxsBytes) < 2 {
-		err = errMsigMergeLessThanTwo
+		err = ErrMsigMergeLessThanTwo
 		return
 	}
 	var sig types.MultisigSig
@@ -264,7 +375,11 @@ func MergeMultisigTransactions(stxsBytes ...[]byte) (txid string, stxBytes []byt
 			refTx = partStx.Txn
 		} else {
 			if partAddr != *refAddr {
-				err = errMsigMergeKeysMismatch
+				err = ErrMsigMergeKeysMismatch
+				return
+			}
+			if txIDFromTransaction(partStx.Txn) != txIDFromTransaction(refTx) {
+				err = ErrMsigMergeTxnMismatch
 				return
 			}
 		}
@@ -276,7 +391,7 @@ func MergeMultisigTransactions(stxsBytes ...[]byte) (txid string, stxBytes []byt
 				if sig.Subsigs[i].Sig == zeroSig {
 					sig.Subsigs[i].Sig = mSubsig.Sig
 				} else if sig.Subsigs[i].Sig != mSubsig.Sig {
-					err = errMsigMergeInvalidDups
+					err = ErrMsigMergeInvalidDups
 					return
 				}
 			}
@@ -287,6 +402,9 @@ func MergeMultisigTransactions(stxsBytes ...[]byte) (txid string, stxBytes []byt
 		Msig: sig,
 		Txn:  refTx,
 	}
+	if refTx.Sender != *refAddr {
+		stx.AuthAddr = *refAddr
+	}
 	stxBytes = msgpack.Encode(stx)
 	// let's also compute the txid.
 	txid = txIDFromTransaction(refTx)
@@ -297,7 +415,7 @@ func MergeMultisigTransactions(stxsBytes ...[]byte) (txid string, stxBytes []byt
 // returning an encoded signed multisig transaction including the signature.
 // While we could compute the multisig preimage from the multisig blob, we ask the caller
 // to pass it back in, to explicitly check that they know who they are signing as.
-func AppendMultisigTransaction(sk ed25519.PrivateKey, ma MultisigAccount, preStxBytes []byte) (txid string, stxBytes []byte, err error) {
+func AppendMultisigTransaction(sk SecretKey, ma MultisigAccount, preStxBytes []byte) (txid string, stxBytes []byte, err error) {
 	preStx := types.SignedTxn{}
 	err = msgpack.Decode(preStxBytes, &preStx)
 	if err != nil {
@@ -361,6 +479,40 @@ func VerifyMultisig(addr types.Address, message []byte, msig types.MultisigSig)
 	return true
 }
 
+// VerifySignedTransaction verifies the signature of a signed transaction: its
+// ed25519 signature, multisig or LogicSig must be by the account authorized to
+// sign for the sender, which is AuthAddr if set and the sender otherwise. The
+// program of a LogicSig is checked but not run, so its approval is not verified.
+func VerifySignedTransaction(stx types.SignedTxn) bool {
+	authorizer := stx.Txn.Sender
+	if !stx.AuthAddr.IsZero() {
+		authorizer = stx.AuthAddr
+	}
+
+	hasSig := stx.Sig != (types.Signature{})
+	hasMsig := !stx.Msig.Blank()
+	hasLsig := len(stx.Lsig.Logic) != 0
+
+	// require exactly one kind of signature
+	sigs := 0
+	for _, has := range []bool{hasSig, hasMsig, hasLsig} {
+		if has {
+			sigs++
+		}
+	}
+	if sigs != 1 {
+		return false
+	}
+
+	if hasSig {
+		return ed25519.Verify(authorizer[:], RawTransactionBytesToSign(stx.Txn), stx.Sig[:])
+	}
+	if hasMsig {
+		return VerifyMultisig(authorizer, RawTransactionBytesToSign(stx.Txn), stx.Msig)
+	}
+	return VerifyLogicSig(stx.Lsig, authorizer)
+}
+
 // ComputeGroupID returns group ID for a group of transactions
 func ComputeGroupID(txgroup []types.Transaction) (gid types.Digest, err error) {
 	if len(txgroup) > types.MaxTxGroupSize {
@@ -375,7 +527,7 @@ func ComputeGroupID(txgroup []types.Transaction) (gid types.Digest, err error) {
 			return
 		}
 
-		txID := sha512.Sum512_256(rawTransactionBytesToSign(tx))
+		txID := sha512.Sum512_256(RawTransactionBytesToSign(tx))
 		group.TxGroupHashes = append(group.TxGroupHashes, txID)
 	}
 
@@ -422,11 +574,18 @@ func VerifyLogicSig(lsig types.LogicSig, sender types.Address) (result bool) {
 // SignLogicsigTransaction takes LogicSig object and a transaction and returns the
 // bytes of a signed transaction ready to be broadcasted to the network
 // Note, LogicSig actually can be attached to any transaction (with matching sender field for Sig and Multisig cases)
-// and it is a program's responsibility to approve/decline the transaction
+// and it is a program's responsibility to approve/decline the transaction.
+// A contract account LogicSig may also sign for a sender rekeyed to its address,
+// which is then the signed transaction's AuthAddr.
 func SignLogicsigTransaction(lsig types.LogicSig, tx types.Transaction) (txid string, stxBytes []byte, err error) {
+	signer := tx.Header.Sender
+	hasSig := lsig.Sig != (types.Signature{})
+	if !hasSig && lsig.Msig.Blank() {
+		signer = LogicSigAddress(lsig)
+	}
 
-	if !VerifyLogicSig(lsig, tx.Header.Sender) {
-		err = errLsigInvalidSignature
+	if !VerifyLogicSig(lsig, signer) {
+		err = ErrLsigInvalidSignature
 		return
 	}
 
@@ -436,24 +595,55 @@ func SignLogicsigTransaction(lsig types.LogicSig, tx types.Transaction) (txid st
 		Lsig: lsig,
 		Txn:  tx,
 	}
+	if signer != tx.Header.Sender {
+		stx.AuthAddr = signer
+	}
 
 	// Encode the SignedTxn
 	stxBytes = msgpack.Encode(stx)
 	return
 }
 
+// SignLogicSigAccountTransaction signs tx with the LogicSigAccount lsa. The
+// sender of tx must be the account lsa signs for, or have been rekeyed to it,
+// in which case that account is the signed transaction's AuthAddr.
+func SignLogicSigAccountTransaction(lsa LogicSigAccount, tx types.Transaction) (txid string, stxBytes []byte, err error) {
+	signer, err := lsa.Address()
+	if err != nil {
+		return
+	}
+	if !VerifyLogicSig(lsa.Lsig, signer) {
+		err = ErrLsigInvalidSignature
+		return
+	}
+
+	txid = txIDFromTransaction(tx)
+	stx := types.SignedTxn{
+		Lsig: lsa.Lsig,
+		Txn:  tx,
+	}
+	if signer != tx.Sender {
+		stx.AuthAddr = signer
+	}
+	stxBytes = msgpack.Encode(stx)
+	return
+}
+
 func programToSign(program []byte) []byte {
 	parts := [][]byte{programPrefix, program}
 	toBeSigned := bytes.Join(parts, nil)
 	return toBeSigned
 }
 
-func signProgram(sk ed25519.PrivateKey, program []byte) (sig types.Signature, err error) {
+func signProgram(sk SecretKey, program []byte) (sig types.Signature, err error) {
 	toBeSigned := programToSign(program)
-	rawSig := ed25519.Sign(sk, toBeSigned)
+	rawSig, err := sk.Sign(toBeSigned)
+	if err != nil {
+		return
+	}
 	n := copy(sig[:], rawSig)
 	if n != len(sig) {
-		err = errInvalidSignatureReturned
+		err = ErrInvalidSignatureReturned
 		return
 	}
 	return
@@ -471,9 +661,9 @@ func AddressFromProgram(program []byte) types.Address {
 // 1. If no sk and ma provided then it returns contract-only LogicSig
 // 2. If no ma provides, it returns Sig delegated LogicSig
 // 3. If both sk and ma specified the function returns Multisig delegated LogicSig
-func MakeLogicSig(program []byte, args [][]byte, sk ed25519.PrivateKey, ma MultisigAccount) (lsig types.LogicSig, err error) {
+func MakeLogicSig(program []byte, args [][]byte, sk SecretKey, ma MultisigAccount) (lsig types.LogicSig, err error) {
 	if len(program) == 0 {
-		err = errLsigInvalidProgram
+		err = ErrLsigInvalidProgram
 		return
 	}
 	if err = logic.CheckProgram(program, args); err != nil {
@@ -523,9 +713,9 @@ func MakeLogicSig(program []byte, args [][]byte, sk ed25519.PrivateKey, ma Multi
 }
 
 // AppendMultisigToLogicSig adds a new signature to multisigned LogicSig
-func AppendMultisigToLogicSig(lsig *types.LogicSig, sk ed25519.PrivateKey) error {
+func AppendMultisigToLogicSig(lsig *types.LogicSig, sk SecretKey) error {
 	if lsig.Msig.Blank() {
-		return errLsigEmptyMsig
+		return ErrLsigEmptyMsig
 	}
 
 	ma, err := MultisigAccountFromSig(lsig.Msig)
@@ -546,3 +736,37 @@ func AppendMultisigToLogicSig(lsig *types.LogicSig, sk ed25519.PrivateKey) error
 
 	return nil
 }
+
+// programDataToSign returns the bytes the ed25519verify opcode verifies a
+// signature of, when run by the program whose address is contractAddress
+func programDataToSign(data []byte, contractAddress types.Address) []byte {
+	parts := [][]byte{programDataPrefix, contractAddress[:], data}
+	return bytes.Join(parts, nil)
+}
+
+// TealSign signs data so that the ed25519verify opcode of the program whose
+// address is contractAddress accepts the signature, as an oracle would sign
+// values for a contract account to check
+func TealSign(sk SecretKey, data []byte, contractAddress types.Address) (sig types.Signature, err error) {
+	rawSig, err := sk.Sign(programDataToSign(data, contractAddress))
+	if err != nil {
+		return
+	}
+	n := copy(sig[:], rawSig)
+	if n != len(sig) {
+		err = ErrInvalidSignatureReturned
+		return
+	}
+	return
+}
+
+// TealSignFromProgram signs data like TealSign, for the program with the given bytes
+func TealSignFromProgram(sk SecretKey, data []byte, program []byte) (types.Signature, error) {
+	return TealSign(sk, data, AddressFromProgram(program))
+}
+
+// TealVerify verifies a signature of data made with TealSign, as the
+// ed25519verify opcode of the program whose address is contractAddress does
+func TealVerify(pk ed25519.PublicKey, data []byte, contractAddress types.Address, sig types.Signature) bool {
+	return ed25519.Verify(pk, programDataToSign(data, contractAddress), sig[:])
+}
@@ -2,18 +2,23 @@ package crypto
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/base64"
+	"io"
 	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ed25519"
 
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
 	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
-	"github.com/algorand/go-algorand-sdk/mnemonic"
 	"github.com/algorand/go-algorand-sdk/types"
 )
 
-func makeTestMultisigAccount(t *testing.T) (MultisigAccount, ed25519.PrivateKey, ed25519.PrivateKey, ed25519.PrivateKey) {
+func makeTestMultisigAccount(t *testing.T) (MultisigAccount, SecretKey, SecretKey, SecretKey) {
 	addr1, err := types.DecodeAddress("DN7MBMCL5JQ3PFUQS7TMX5AH4EEKOBJVDUF4TCV6WERATKFLQF4MQUPZTA")
 	require.NoError(t, err)
 	addr2, err := types.DecodeAddress("BFRTECKTOOE7A5LHCF3TTEOH2A7BW46IYT2SX5VP6ANKEXHZYJY77SJTVM")
@@ -26,15 +31,23 @@ func makeTestMultisigAccount(t *testing.T) (MultisigAccount, ed25519.PrivateKey,
 		addr3,
 	})
 	require.NoError(t, err)
-	mn1 := "auction inquiry lava second expand liberty glass involve ginger illness length room item discover ahead table doctor term tackle cement bonus profit right above catch"
-	sk1, err := mnemonic.ToPrivateKey(mn1)
-	require.NoError(t, err)
-	mn2 := "since during average anxiety protect cherry club long lawsuit loan expand embark forum theory winter park twenty ball kangaroo cram burst board host ability left"
-	sk2, err := mnemonic.ToPrivateKey(mn2)
-	require.NoError(t, err)
-	mn3 := "advice pudding treat near rule blouse same whisper inner electric quit surface sunny dismiss leader blood seat clown cost exist hospital century reform able sponsor"
-	sk3, err := mnemonic.ToPrivateKey(mn3)
-	return ma, sk1, sk2, sk3
+	// the seeds of the mnemonics
+	// "auction inquiry lava second expand liberty glass involve ginger illness length room item discover ahead table doctor term tackle cement bonus profit right above catch",
+	// "since during average anxiety protect cherry club long lawsuit loan expand embark forum theory winter park twenty ball kangaroo cram burst board host ability left" and
+	// "advice pudding treat near rule blouse same whisper inner electric quit surface sunny dismiss leader blood seat clown cost exist hospital century reform able sponsor"
+	var sks []SecretKey
+	for _, seed := range []string{
+		"dzCd+yYcqANeLHYQSxwAv2u7+6Tg3ALaN7pTsgyvOpc=",
+		"SQ6RH6BQ1p2ExYPxw2CghNQtgYNfoFl/BPMiY49iwC0=",
+		"IEirzzmZ3mDcl/qj25Ffo71s/dDvFxIGS2H89LaViFY=",
+	} {
+		seedBytes, err := base64.StdEncoding.DecodeString(seed)
+		require.NoError(t, err)
+		sk, err := SecretKeyFromSeed(seedBytes)
+		require.NoError(t, err)
+		sks = append(sks, sk)
+	}
+	return ma, sks[0], sks[1], sks[2]
 }
 
 func TestSignMultisigTransaction(t *testing.T) {
@@ -69,7 +82,7 @@ func TestSignMultisigTransaction(t *testing.T) {
 	var stx types.SignedTxn
 	err = msgpack.Decode(txBytes, &stx)
 	require.NoError(t, err)
-	bytesToSign := rawTransactionBytesToSign(stx.Txn)
+	bytesToSign := RawTransactionBytesToSign(stx.Txn)
 
 	verified := VerifyMultisig(fromAddr, bytesToSign, stx.Msig)
 	require.False(t, verified) // not enough signatures
@@ -89,7 +102,7 @@ func TestAppendMultisigTransaction(t *testing.T) {
 	var stx types.SignedTxn
 	err = msgpack.Decode(txBytes, &stx)
 	require.NoError(t, err)
-	bytesToSign := rawTransactionBytesToSign(stx.Txn)
+	bytesToSign := RawTransactionBytesToSign(stx.Txn)
 
 	fromAddr, err := ma.Address()
 	require.NoError(t, err)
@@ -166,7 +179,7 @@ func TestMakeLogicSigBasic(t *testing.T) {
 	// basic checks and contracts without delegation
 	var program []byte
 	var args [][]byte
-	var sk ed25519.PrivateKey
+	var sk SecretKey
 	var pk MultisigAccount
 
 	// check empty LogicSig
@@ -228,7 +241,7 @@ func TestMakeLogicSigBasic(t *testing.T) {
 func TestMakeLogicSigSingle(t *testing.T) {
 	var program []byte
 	var args [][]byte
-	var sk ed25519.PrivateKey
+	var sk SecretKey
 	var pk MultisigAccount
 
 	acc := GenerateAccount()
@@ -256,7 +269,7 @@ func TestMakeLogicSigSingle(t *testing.T) {
 func TestMakeLogicSigMulti(t *testing.T) {
 	var program []byte
 	var args [][]byte
-	var sk ed25519.PrivateKey
+	var sk SecretKey
 	var pk MultisigAccount
 
 	ma, sk1, sk2, _ := makeTestMultisigAccount(t)
@@ -305,3 +318,562 @@ func TestMakeLogicSigMulti(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, lsig, lsig1)
 }
+
+func TestSignRekeyedTransaction(t *testing.T) {
+	sender := GenerateAccount()
+	authorized := GenerateAccount()
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     sender.Address,
+			Fee:        1000,
+			FirstValid: 1,
+			LastValid:  1001,
+		},
+		PaymentTxnFields: types.PaymentTxnFields{
+			Receiver: sender.Address,
+		},
+	}
+
+	// signed by the sender
+	txid, stxBytes, err := SignTransaction(sender.PrivateKey, tx)
+	require.NoError(t, err)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, types.Address{}, stx.AuthAddr)
+
+	// signed by the account the sender was rekeyed to
+	rekeyedTxid, stxBytes, err := SignTransaction(authorized.PrivateKey, tx)
+	require.NoError(t, err)
+	require.Equal(t, txid, rekeyedTxid)
+	stx = types.SignedTxn{}
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, authorized.Address, stx.AuthAddr)
+	require.True(t, ed25519.Verify(authorized.PublicKey, RawTransactionBytesToSign(tx), stx.Sig[:]))
+
+	// signed by a multisig account
+	ma, sk1, sk2, _ := makeTestMultisigAccount(t)
+	maAddr, err := ma.Address()
+	require.NoError(t, err)
+	_, part1, err := SignMultisigTransaction(sk1, ma, tx)
+	require.NoError(t, err)
+	_, part2, err := SignMultisigTransaction(sk2, ma, tx)
+	require.NoError(t, err)
+	_, stxBytes, err = MergeMultisigTransactions(part1, part2)
+	require.NoError(t, err)
+	stx = types.SignedTxn{}
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, maAddr, stx.AuthAddr)
+	require.True(t, VerifyMultisig(maAddr, RawTransactionBytesToSign(tx), stx.Msig))
+
+	// signed by a contract account
+	lsig, err := MakeLogicSig([]byte{1, 32, 1, 1, 34}, nil, nil, MultisigAccount{})
+	require.NoError(t, err)
+	_, stxBytes, err = SignLogicsigTransaction(lsig, tx)
+	require.NoError(t, err)
+	stx = types.SignedTxn{}
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, LogicSigAddress(lsig), stx.AuthAddr)
+
+	// a delegated logic sig must still be signed by the sender
+	lsig, err = MakeLogicSig([]byte{1, 32, 1, 1, 34}, nil, authorized.PrivateKey, MultisigAccount{})
+	require.NoError(t, err)
+	_, _, err = SignLogicsigTransaction(lsig, tx)
+	require.Error(t, err)
+}
+
+func TestAttachSignature(t *testing.T) {
+	sender := GenerateAccount()
+	authorized := GenerateAccount()
+	tx := types.Transaction{
+		Type:             types.PaymentTx,
+		Header:           types.Header{Sender: sender.Address, Fee: 1000, FirstValid: 1, LastValid: 100},
+		PaymentTxnFields: types.PaymentTxnFields{Receiver: authorized.Address, Amount: 5},
+	}
+
+	// the bytes to sign are taken offline and the signature brought back
+	signature := ed25519.Sign(ed25519.PrivateKey(sender.PrivateKey), RawTransactionBytesToSign(tx))
+	txid, stxBytes, err := AttachSignature(tx, signature)
+	require.NoError(t, err)
+	expectedTxid, expectedStxBytes, err := SignTransaction(sender.PrivateKey, tx)
+	require.NoError(t, err)
+	require.Equal(t, expectedTxid, txid)
+	require.Equal(t, expectedStxBytes, stxBytes)
+
+	signature = ed25519.Sign(ed25519.PrivateKey(authorized.PrivateKey), RawTransactionBytesToSign(tx))
+	_, _, err = AttachSignature(tx, signature)
+	require.Error(t, err)
+	_, stxBytes, err = AttachSignatureWithSigner(tx, authorized.Address, signature)
+	require.NoError(t, err)
+	_, expectedStxBytes, err = SignTransaction(authorized.PrivateKey, tx)
+	require.NoError(t, err)
+	require.Equal(t, expectedStxBytes, stxBytes)
+
+	_, _, err = AttachSignature(tx, signature[:32])
+	require.Error(t, err)
+}
+
+func TestMultisigLifecycle(t *testing.T) {
+	ma, sk1, sk2, sk3 := makeTestMultisigAccount(t)
+	fromAddr, err := ma.Address()
+	require.NoError(t, err)
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     fromAddr,
+			Fee:        1000,
+			FirstValid: 1,
+			LastValid:  1001,
+		},
+		PaymentTxnFields: types.PaymentTxnFields{
+			Receiver: fromAddr,
+			Amount:   5,
+		},
+	}
+
+	// the first party signs and passes the blob on to the second, who appends
+	_, blob1, err := SignMultisigTransaction(sk1, ma, tx)
+	require.NoError(t, err)
+	txid, blob, err := AppendMultisigTransaction(sk2, ma, blob1)
+	require.NoError(t, err)
+	require.Equal(t, GetTxID(tx), txid)
+
+	// the multisig account can be recovered from the blob
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(blob, &stx))
+	recovered, err := MultisigAccountFromSig(stx.Msig)
+	require.NoError(t, err)
+	recoveredAddr, err := recovered.Address()
+	require.NoError(t, err)
+	require.Equal(t, fromAddr, recoveredAddr)
+	require.True(t, VerifyMultisig(fromAddr, RawTransactionBytesToSign(tx), stx.Msig))
+
+	// a third signature signed separately merges with the others
+	_, blob3, err := SignMultisigTransaction(sk3, ma, tx)
+	require.NoError(t, err)
+	_, merged, err := MergeMultisigTransactions(blob, blob3)
+	require.NoError(t, err)
+	stx = types.SignedTxn{}
+	require.NoError(t, msgpack.Decode(merged, &stx))
+	for _, subsig := range stx.Msig.Subsigs {
+		require.NotEqual(t, types.Signature{}, subsig.Sig)
+	}
+
+	// signatures of another transaction do not merge
+	other := tx
+	other.Amount = 6
+	_, otherBlob, err := SignMultisigTransaction(sk3, ma, other)
+	require.NoError(t, err)
+	_, _, err = MergeMultisigTransactions(blob1, otherBlob)
+	require.Error(t, err)
+
+	// nor does a key outside the multisig account sign for it
+	_, _, err = SignMultisigTransaction(GenerateAccount().PrivateKey, ma, tx)
+	require.Error(t, err)
+}
+
+func TestLogicSigAccount(t *testing.T) {
+	program := []byte{1, 32, 1, 1, 34}
+	acct := GenerateAccount()
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     acct.Address,
+			Fee:        1000,
+			FirstValid: 1,
+			LastValid:  1001,
+		},
+	}
+
+	escrow, err := MakeLogicSigAccountEscrow(program, nil)
+	require.NoError(t, err)
+	require.False(t, escrow.IsDelegated())
+	addr, err := escrow.Address()
+	require.NoError(t, err)
+	require.Equal(t, AddressFromProgram(program), addr)
+	require.True(t, escrow.Verify())
+
+	delegated, err := MakeLogicSigAccountDelegated(program, nil, acct.PrivateKey)
+	require.NoError(t, err)
+	require.True(t, delegated.IsDelegated())
+	addr, err = delegated.Address()
+	require.NoError(t, err)
+	require.Equal(t, acct.Address, addr)
+	require.True(t, delegated.Verify())
+
+	// the signing key survives encoding
+	var decoded LogicSigAccount
+	require.NoError(t, msgpack.Decode(msgpack.Encode(delegated), &decoded))
+	require.Equal(t, delegated, decoded)
+
+	_, stxBytes, err := SignLogicSigAccountTransaction(delegated, tx)
+	require.NoError(t, err)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, types.Address{}, stx.AuthAddr)
+
+	// a delegation by the account the sender was rekeyed to
+	rekeyed := tx
+	rekeyed.Sender = GenerateAccount().Address
+	_, stxBytes, err = SignLogicSigAccountTransaction(delegated, rekeyed)
+	require.NoError(t, err)
+	stx = types.SignedTxn{}
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, acct.Address, stx.AuthAddr)
+
+	ma, sk1, sk2, _ := makeTestMultisigAccount(t)
+	maAddr, err := ma.Address()
+	require.NoError(t, err)
+	msig, err := MakeLogicSigAccountDelegatedMsig(program, nil, ma, sk1)
+	require.NoError(t, err)
+	require.True(t, msig.IsDelegated())
+	addr, err = msig.Address()
+	require.NoError(t, err)
+	require.Equal(t, maAddr, addr)
+	require.False(t, msig.Verify()) // not enough signatures
+	_, _, err = SignLogicSigAccountTransaction(msig, tx)
+	require.Error(t, err)
+	require.NoError(t, msig.AppendMultisigSignature(sk2))
+	require.True(t, msig.Verify())
+
+	_, err = MakeLogicSigAccountDelegatedMsig(program, nil, MultisigAccount{}, sk1)
+	require.Error(t, err)
+	delegated.SigningKey = nil
+	_, err = delegated.Address()
+	require.Error(t, err)
+}
+
+func TestDecodeSignedTransaction(t *testing.T) {
+	account := GenerateAccount()
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     account.Address,
+			Fee:        1000,
+			FirstValid: 1,
+			LastValid:  1001,
+		},
+		PaymentTxnFields: types.PaymentTxnFields{
+			Receiver: account.Address,
+			Amount:   5,
+		},
+	}
+	txid, stxBytes, err := SignTransaction(account.PrivateKey, tx)
+	require.NoError(t, err)
+
+	stx, decodedTxid, err := DecodeSignedTransaction(stxBytes)
+	require.NoError(t, err)
+	require.Equal(t, txid, decodedTxid)
+	require.Equal(t, tx, stx.Txn)
+	require.True(t, ed25519.Verify(account.PublicKey, RawTransactionBytesToSign(tx), stx.Sig[:]))
+
+	// a group is rejected
+	_, _, err = DecodeSignedTransaction(append(append([]byte{}, stxBytes...), stxBytes...))
+	require.Error(t, err)
+	_, _, err = DecodeSignedTransaction(append(append([]byte{}, stxBytes...), 0x80))
+	require.Error(t, err)
+	_, _, err = DecodeSignedTransaction(stxBytes[:len(stxBytes)-1])
+	require.Error(t, err)
+	_, _, err = DecodeSignedTransaction(nil)
+	require.Error(t, err)
+}
+
+func TestVerifySignedTransaction(t *testing.T) {
+	sender := GenerateAccount()
+	authorized := GenerateAccount()
+	tx := types.Transaction{
+		Type: types.PaymentTx,
+		Header: types.Header{
+			Sender:     sender.Address,
+			Fee:        1000,
+			FirstValid: 1,
+			LastValid:  1001,
+		},
+		PaymentTxnFields: types.PaymentTxnFields{
+			Receiver: sender.Address,
+		},
+	}
+	verify := func(stxBytes []byte) bool {
+		stx, _, err := DecodeSignedTransaction(stxBytes)
+		require.NoError(t, err)
+		return VerifySignedTransaction(stx)
+	}
+
+	// single signatures, by the sender and by the account it was rekeyed to
+	_, stxBytes, err := SignTransaction(sender.PrivateKey, tx)
+	require.NoError(t, err)
+	require.True(t, verify(stxBytes))
+	_, stxBytes, err = SignTransaction(authorized.PrivateKey, tx)
+	require.NoError(t, err)
+	require.True(t, verify(stxBytes))
+
+	// a signature of another transaction, or by another account
+	stx, _, err := DecodeSignedTransaction(stxBytes)
+	require.NoError(t, err)
+	stx.Txn.Amount = 1
+	require.False(t, VerifySignedTransaction(stx))
+	stx.Txn.Amount = 0
+	stx.AuthAddr = types.Address{}
+	require.False(t, VerifySignedTransaction(stx))
+
+	// multisig, below and at the threshold
+	ma, sk1, sk2, _ := makeTestMultisigAccount(t)
+	maAddr, err := ma.Address()
+	require.NoError(t, err)
+	msigTx := tx
+	msigTx.Sender = maAddr
+	_, part1, err := SignMultisigTransaction(sk1, ma, msigTx)
+	require.NoError(t, err)
+	require.False(t, verify(part1))
+	_, part2, err := SignMultisigTransaction(sk2, ma, msigTx)
+	require.NoError(t, err)
+	_, stxBytes, err = MergeMultisigTransactions(part1, part2)
+	require.NoError(t, err)
+	require.True(t, verify(stxBytes))
+
+	// contract account and delegated logic sigs
+	program := []byte{1, 32, 1, 1, 34}
+	lsig, err := MakeLogicSig(program, nil, nil, MultisigAccount{})
+	require.NoError(t, err)
+	_, stxBytes, err = SignLogicsigTransaction(lsig, tx)
+	require.NoError(t, err)
+	require.True(t, verify(stxBytes))
+	lsig, err = MakeLogicSig(program, nil, sender.PrivateKey, MultisigAccount{})
+	require.NoError(t, err)
+	_, stxBytes, err = SignLogicsigTransaction(lsig, tx)
+	require.NoError(t, err)
+	require.True(t, verify(stxBytes))
+
+	// no signature, or more than one
+	require.False(t, VerifySignedTransaction(types.SignedTxn{Txn: tx}))
+	stx, _, err = DecodeSignedTransaction(stxBytes)
+	require.NoError(t, err)
+	stx.Sig = types.Signature{1}
+	require.False(t, VerifySignedTransaction(stx))
+}
+
+func TestSignBid(t *testing.T) {
+	bidder := GenerateAccount()
+	auction := GenerateAccount()
+	bid := types.Bid{
+		BidderKey:   bidder.Address,
+		BidCurrency: 1000,
+		MaxPrice:    10,
+		BidID:       2,
+		AuctionKey:  auction.Address,
+		AuctionID:   3,
+	}
+	note, err := SignBid(bidder.PrivateKey, bid)
+	require.NoError(t, err)
+
+	// the note is a canonically encoded NoteField holding the signed bid
+	var nf types.NoteField
+	require.NoError(t, msgpack.DecodeStrict(note, &nf))
+	require.Equal(t, types.NoteBid, nf.Type)
+	require.Equal(t, bid, nf.SignedBid.Bid)
+
+	// the bid is signed with its own domain separation prefix
+	toBeSigned := append([]byte("aB"), msgpack.Encode(bid)...)
+	require.True(t, ed25519.Verify(bidder.PublicKey, toBeSigned, nf.SignedBid.Sig[:]))
+	require.False(t, ed25519.Verify(bidder.PublicKey, msgpack.Encode(bid), nf.SignedBid.Sig[:]))
+
+	// the note fits in a transaction
+	require.True(t, len(note) <= 1024)
+}
+
+func TestTealSign(t *testing.T) {
+	account := GenerateAccount()
+	program := []byte{1, 32, 1, 1, 34}
+	data := []byte("price:42")
+	addr := AddressFromProgram(program)
+
+	sig, err := TealSign(account.PrivateKey, data, addr)
+	require.NoError(t, err)
+	sig2, err := TealSignFromProgram(account.PrivateKey, data, program)
+	require.NoError(t, err)
+	require.Equal(t, sig, sig2)
+
+	// ed25519verify checks "ProgData" || program hash || data
+	toBeSigned := append(append([]byte("ProgData"), addr[:]...), data...)
+	require.True(t, ed25519.Verify(account.PublicKey, toBeSigned, sig[:]))
+	require.True(t, TealVerify(account.PublicKey, data, addr, sig))
+
+	// the signature is bound to the program and the data
+	require.False(t, TealVerify(account.PublicKey, []byte("price:43"), addr, sig))
+	require.False(t, TealVerify(account.PublicKey, data, AddressFromProgram([]byte{1, 32, 1, 0, 34}), sig))
+	require.False(t, TealVerify(GenerateAccount().PublicKey, data, addr, sig))
+}
+
+func TestGetInnerTxID(t *testing.T) {
+	var stad types.SignedTxnWithAD
+	stad.Txn.Type = types.ApplicationCallTx
+	stad.Txn.GenesisID = "testnet-v1.0"
+	inner := types.SignedTxnWithAD{}
+	inner.Txn.Type = types.AssetTransferTx
+	inner.Txn.AssetAmount = 5
+	nested := inner
+	nested.EvalDelta.InnerTxns = []types.SignedTxnWithAD{inner}
+	// the same transaction twice, so only the position tells them apart
+	stad.EvalDelta.InnerTxns = []types.SignedTxnWithAD{inner, nested}
+
+	flat := stad.Flatten()
+	ids, err := GetFlatTxIDs(flat)
+	require.NoError(t, err)
+	require.Len(t, ids, 4)
+	require.Equal(t, GetTxID(stad.Txn), ids[0])
+
+	// "TX" || parent ID || index as a big-endian uint64 || msgpack transaction
+	parentID, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(ids[0])
+	require.NoError(t, err)
+	toHash := append(append(append([]byte("TX"), parentID...), 0, 0, 0, 0, 0, 0, 0, 1), msgpack.Encode(nested.Txn)...)
+	hash := sha512.Sum512_256(toHash)
+	require.Equal(t, base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(hash[:]), ids[2])
+
+	id, err := GetInnerTxID(ids[0], 0, inner.Txn)
+	require.NoError(t, err)
+	require.Equal(t, ids[1], id)
+	id, err = GetInnerTxID(ids[2], 0, inner.Txn)
+	require.NoError(t, err)
+	require.Equal(t, ids[3], id)
+	require.Len(t, map[string]bool{ids[0]: true, ids[1]: true, ids[2]: true, ids[3]: true}, 4)
+
+	_, err = GetInnerTxID("not a txid", 0, inner.Txn)
+	require.Error(t, err)
+	flat[1].Parent = 1
+	_, err = GetFlatTxIDs(flat)
+	require.Error(t, err)
+}
+
+func TestVerifyTransactionProof(t *testing.T) {
+	var txns []types.Transaction
+	for i := uint64(0); i < 3; i++ {
+		txns = append(txns, types.Transaction{
+			Type: types.PaymentTx,
+			Header: types.Header{
+				Fee:        1000,
+				FirstValid: 1,
+				LastValid:  1001,
+				Note:       []byte{byte(i)},
+			},
+		})
+	}
+	stibHashes := [][]byte{
+		bytes.Repeat([]byte{1}, 32),
+		bytes.Repeat([]byte{2}, 32),
+		bytes.Repeat([]byte{3}, 32),
+	}
+	node := func(sum func([]byte) []byte, left, right []byte) []byte {
+		buf := append([]byte("MA"), left...)
+		return sum(append(buf, right...))
+	}
+
+	for _, test := range []struct {
+		hashType string
+		sum      func([]byte) []byte
+	}{
+		{"sha512_256", func(b []byte) []byte { h := sha512.Sum512_256(b); return h[:] }},
+		{"sha256", func(b []byte) []byte { h := sha256.Sum256(b); return h[:] }},
+	} {
+		// a vector commitment of depth 2, which stores the leaf of payset
+		// index i at the bit-reversed position of i, and pads the fourth
+		// leaf with the hash of no bytes
+		var leaves [][]byte
+		for i, tx := range txns {
+			txHash := test.sum(RawTransactionBytesToSign(tx))
+			leaves = append(leaves, test.sum(append(append([]byte("TL"), txHash...), stibHashes[i]...)))
+		}
+		padding := test.sum(nil)
+		left := node(test.sum, leaves[0], leaves[2])
+		right := node(test.sum, leaves[1], padding)
+		root := node(test.sum, left, right)
+
+		proof := models.TransactionProofResponse{
+			Hashtype:  test.hashType,
+			Idx:       2,
+			Proof:     append(append([]byte{}, leaves[0]...), right...),
+			Stibhash:  stibHashes[2],
+			Treedepth: 2,
+		}
+		require.NoError(t, VerifyTransactionProof(txns[2], proof, root), test.hashType)
+		require.Error(t, VerifyTransactionProof(txns[1], proof, root), test.hashType)
+
+		proof = models.TransactionProofResponse{
+			Hashtype:  test.hashType,
+			Idx:       1,
+			Proof:     append(append([]byte{}, padding...), left...),
+			Stibhash:  stibHashes[1],
+			Treedepth: 2,
+		}
+		require.NoError(t, VerifyTransactionProof(txns[1], proof, root), test.hashType)
+
+		proof = models.TransactionProofResponse{
+			Hashtype:  test.hashType,
+			Idx:       0,
+			Proof:     append(append([]byte{}, leaves[2]...), right...),
+			Stibhash:  stibHashes[0],
+			Treedepth: 2,
+		}
+		require.NoError(t, VerifyTransactionProof(txns[0], proof, root), test.hashType)
+		proof.Stibhash = stibHashes[1]
+		require.Error(t, VerifyTransactionProof(txns[0], proof, root), test.hashType)
+		proof.Stibhash = stibHashes[0]
+		proof.Proof = proof.Proof[1:]
+		require.Error(t, VerifyTransactionProof(txns[0], proof, root), test.hashType)
+	}
+
+	err := VerifyTransactionProof(txns[0], models.TransactionProofResponse{Hashtype: "sumhash"}, nil)
+	require.Error(t, err)
+}
+
+type failingWriter struct{ writes int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.writes == 0 {
+		return 0, io.ErrShortWrite
+	}
+	w.writes--
+	return len(p), nil
+}
+
+func TestSignTransactions(t *testing.T) {
+	sender := GenerateAccount()
+	receiver := GenerateAccount()
+	txns := make([]types.Transaction, 300)
+	var expected []byte
+	for i := range txns {
+		txns[i] = types.Transaction{
+			Type:             types.PaymentTx,
+			Header:           types.Header{Sender: sender.Address, Fee: 1000, FirstValid: 1, LastValid: 1000, GenesisHash: types.Digest{1}, Note: []byte{byte(i), byte(i >> 8)}},
+			PaymentTxnFields: types.PaymentTxnFields{Receiver: receiver.Address, Amount: types.MicroAlgos(i)},
+		}
+		_, stx, err := SignTransaction(sender.PrivateKey, txns[i])
+		require.NoError(t, err)
+		expected = append(expected, stx...)
+	}
+
+	for _, parallelism := range []int{0, 1, 3} {
+		txids, stxs, err := SignTransactions(sender.PrivateKey, txns, parallelism)
+		require.NoError(t, err)
+		require.Len(t, stxs, len(txns))
+		require.Equal(t, expected, bytes.Join(stxs, nil))
+		for i := range txns {
+			require.Equal(t, GetTxID(txns[i]), txids[i])
+		}
+
+		// with 3 workers, the batch is signed and written in two chunks
+		var w bytes.Buffer
+		require.NoError(t, SignTransactionsTo(&w, sender.PrivateKey, txns, parallelism))
+		require.Equal(t, expected, w.Bytes())
+	}
+
+	_, _, err := SignTransactions(SecretKey(make([]byte, ed25519.PrivateKeySize)), txns, 3)
+	require.EqualError(t, err, "transaction 0: "+ErrInvalidPrivateKey.Error())
+	err = SignTransactionsTo(&failingWriter{writes: 200}, sender.PrivateKey, txns, 3)
+	require.EqualError(t, err, "transaction 200: "+io.ErrShortWrite.Error())
+
+	txids, stxs, err := SignTransactions(sender.PrivateKey, nil, 0)
+	require.NoError(t, err)
+	require.Empty(t, txids)
+	require.Empty(t, stxs)
+	require.NoError(t, SignTransactionsTo(&failingWriter{}, sender.PrivateKey, nil, 0))
+}
@@ -4,14 +4,30 @@ import (
 	"errors"
 )
 
-var errInvalidSignatureReturned = errors.New("ed25519 library returned an invalid signature")
-var errMsigUnknownVersion = errors.New("unknown version != 1")
-var errMsigInvalidThreshold = errors.New("invalid threshold")
-var errMsigBadTxnSender = errors.New("transaction sender does not match multisig parameters")
-var errMsigInvalidSecretKey = errors.New("secret key has no corresponding public identity in multisig preimage")
-var errMsigMergeLessThanTwo = errors.New("cannot merge fewer than two multisig transactions")
-var errMsigMergeKeysMismatch = errors.New("multisig parameters do not match")
-var errMsigMergeInvalidDups = errors.New("mismatched duplicate signatures")
-var errLsigInvalidSignature = errors.New("invalid logicsig signature")
-var errLsigInvalidProgram = errors.New("invalid logicsig program")
-var errLsigEmptyMsig = errors.New("empty multisig in logicsig")
+// Errors of signing and verifying transactions and their signatures
+var (
+	ErrInvalidSignatureReturned = errors.New("ed25519 library returned an invalid signature")
+	ErrInvalidSignature         = errors.New("signature does not verify with the signer's public key")
+	ErrInvalidPrivateKey        = errors.New("invalid private key")
+	ErrDecodeTrailingBytes      = errors.New("signed transaction is followed by more bytes, use transaction.Decode for groups")
+	ErrInvalidTxID              = errors.New("invalid transaction ID")
+	ErrInvalidFlatTxns          = errors.New("flattened transactions must follow their parents")
+)
+
+// Errors of multisig accounts and signatures
+var (
+	ErrMsigUnknownVersion    = errors.New("unknown version != 1")
+	ErrMsigInvalidThreshold  = errors.New("invalid threshold")
+	ErrMsigInvalidSecretKey  = errors.New("secret key has no corresponding public identity in multisig preimage")
+	ErrMsigMergeLessThanTwo  = errors.New("cannot merge fewer than two multisig transactions")
+	ErrMsigMergeKeysMismatch = errors.New("multisig parameters do not match")
+	ErrMsigMergeInvalidDups  = errors.New("mismatched duplicate signatures")
+	ErrMsigMergeTxnMismatch  = errors.New("cannot merge signatures of different transactions")
+)
+
+// Errors of logic signatures
+var (
+	ErrLsigInvalidSignature = errors.New("invalid logicsig signature")
+	ErrLsigInvalidProgram   = errors.New("invalid logicsig program")
+	ErrLsigEmptyMsig        = errors.New("empty multisig in logicsig")
+)
@@ -0,0 +1,49 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/merklearray"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// txnMerkleLeafPrefix is prepended to the hashes of a transaction when
+// hashing it into a leaf of the transactions commitment of a block
+var txnMerkleLeafPrefix = []byte("TL")
+
+// VerifyTransactionProof verifies a proof, as returned by algod's
+// GetTransactionProof, that a transaction was confirmed in a block. The root
+// is the transactions commitment of the block header of the type of the
+// proof: NativeSha512_256Commitment for sha512_256 proofs, Sha256Commitment
+// for sha256 proofs. The transaction must be as it was signed, with its
+// genesis ID and hash, as returned by BlockHeader.DecodeSignedTxn.
+func VerifyTransactionProof(tx types.Transaction, proof models.TransactionProofResponse, root []byte) error {
+	var hashType uint16
+	var txHash []byte
+	switch proof.Hashtype {
+	case "", "sha512_256":
+		hashType = merklearray.Sha512_256
+		sum := sha512.Sum512_256(RawTransactionBytesToSign(tx))
+		txHash = sum[:]
+	case "sha256":
+		hashType = merklearray.Sha256
+		sum := sha256.Sum256(RawTransactionBytesToSign(tx))
+		txHash = sum[:]
+	default:
+		return fmt.Errorf("unsupported proof hash type %s", proof.Hashtype)
+	}
+	if proof.Treedepth > merklearray.MaxEncodedTreeDepth {
+		return fmt.Errorf("tree depth %d exceeds the maximum of %d", proof.Treedepth, merklearray.MaxEncodedTreeDepth)
+	}
+	p, err := merklearray.SingleLeafProof(proof.Proof, uint8(proof.Treedepth), hashType)
+	if err != nil {
+		return err
+	}
+	leaf := append(append(append([]byte{}, txnMerkleLeafPrefix...), txHash...), proof.Stibhash...)
+	// algod commits the transactions of a block as a vector commitment, so
+	// the leaf of the transaction is at the bit-reversed position of its index
+	return merklearray.VerifyVectorCommitment(root, map[uint64][]byte{proof.Idx: leaf}, p)
+}
@@ -0,0 +1,97 @@
+package crypto
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// redactedSecretKey is what the fmt package prints for a SecretKey
+const redactedSecretKey = "SecretKey(redacted)"
+
+// SecretKey is an ed25519 private key, as the signing functions of this
+// package take. Unlike an ed25519.PrivateKey, it is printed by the fmt package
+// as a redacted placeholder, whatever the verb, so that keys do not end up in
+// logs, and it can be zeroed once it is no longer needed. Its bytes are a
+// single allocation, which callers may lock in memory, e.g. with
+// syscall.Mlock, to keep them out of swap. An ed25519.PrivateKey converts to a
+// SecretKey sharing its bytes, and back.
+type SecretKey ed25519.PrivateKey
+
+// GenerateSecretKey returns a random secret key
+func GenerateSecretKey() (SecretKey, error) {
+	_, sk, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return nil, err
+	}
+	return SecretKey(sk), nil
+}
+
+// SecretKeyFromSeed returns the secret key of a 32 byte ed25519 seed
+func SecretKeyFromSeed(seed []byte) (SecretKey, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("seed has length %d, expected %d", len(seed), ed25519.SeedSize)
+	}
+	return SecretKey(ed25519.NewKeyFromSeed(seed)), nil
+}
+
+// PublicKey returns a copy of the public key of the secret key
+func (sk SecretKey) PublicKey() ed25519.PublicKey {
+	if len(sk) != ed25519.PrivateKeySize {
+		return nil
+	}
+	return append(ed25519.PublicKey{}, sk[ed25519.SeedSize:]...)
+}
+
+// Seed returns a copy of the seed of the secret key, its first 32 bytes
+func (sk SecretKey) Seed() []byte {
+	if len(sk) != ed25519.PrivateKeySize {
+		return nil
+	}
+	return append([]byte{}, sk[:ed25519.SeedSize]...)
+}
+
+// Sign returns the ed25519 signature of message by the key. An error is
+// returned if the key is not a valid private key, e.g. because it was zeroed.
+func (sk SecretKey) Sign(message []byte) ([]byte, error) {
+	if len(sk) != ed25519.PrivateKeySize || sk.IsZero() {
+		return nil, ErrInvalidPrivateKey
+	}
+	return ed25519.Sign(ed25519.PrivateKey(sk), message), nil
+}
+
+// Zero overwrites the key with zeros, so that it does not linger in memory.
+// The key no longer signs afterwards.
+func (sk SecretKey) Zero() {
+	for i := range sk {
+		sk[i] = 0
+	}
+}
+
+// IsZero reports whether the key is all zeros, e.g. because it was zeroed
+func (sk SecretKey) IsZero() bool {
+	return subtle.ConstantTimeCompare(sk, make([]byte, len(sk))) == 1
+}
+
+// Close zeroes the key, so that it can be released with a deferred Close
+func (sk SecretKey) Close() error {
+	sk.Zero()
+	return nil
+}
+
+// String returns a redacted placeholder, not the key
+func (sk SecretKey) String() string {
+	return redactedSecretKey
+}
+
+// GoString returns a redacted placeholder, not the key
+func (sk SecretKey) GoString() string {
+	return redactedSecretKey
+}
+
+// Format prints a redacted placeholder for every verb, not the key
+func (sk SecretKey) Format(f fmt.State, verb rune) {
+	io.WriteString(f, redactedSecretKey)
+}
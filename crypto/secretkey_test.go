@@ -0,0 +1,52 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestSecretKeyRedaction(t *testing.T) {
+	account := GenerateAccount()
+	seedHex := hex.EncodeToString(account.PrivateKey.Seed())
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%x", "%X", "%d", "%q"} {
+		for _, value := range []interface{}{account.PrivateKey, account, &account} {
+			printed := fmt.Sprintf(format, value)
+			require.Contains(t, printed, redactedSecretKey, format)
+			require.False(t, strings.Contains(strings.ToLower(printed), seedHex), format)
+		}
+	}
+	require.Equal(t, redactedSecretKey, account.PrivateKey.String())
+}
+
+func TestSecretKeyZero(t *testing.T) {
+	sk, err := GenerateSecretKey()
+	require.NoError(t, err)
+	message := []byte("message")
+	signature, err := sk.Sign(message)
+	require.NoError(t, err)
+	require.True(t, ed25519.Verify(sk.PublicKey(), message, signature))
+
+	account, err := AccountFromPrivateKey(sk)
+	require.NoError(t, err)
+	require.NoError(t, sk.Close())
+	require.True(t, sk.IsZero())
+	// the account shares the key's bytes
+	require.True(t, account.PrivateKey.IsZero())
+
+	_, err = sk.Sign(message)
+	require.Error(t, err)
+	_, _, err = SignTransaction(account.PrivateKey, types.Transaction{Header: types.Header{Sender: account.Address}})
+	require.Error(t, err)
+
+	_, err = SecretKey(nil).Sign(message)
+	require.Error(t, err)
+	_, err = SecretKeyFromSeed(make([]byte, 31))
+	require.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// base32Alphabet is the alphabet of addresses
+const base32Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+
+// vanityProgressInterval is how often GenerateVanityAddress reports progress
+var vanityProgressInterval = time.Second
+
+// vanityGenerateAccount generates the accounts GenerateVanityAddress tries
+var vanityGenerateAccount = GenerateAccount
+
+// VanityProgress is called by GenerateVanityAddress with the number of
+// accounts generated so far, and the expected number of accounts to generate
+// before one matches
+type VanityProgress func(attempts, expected uint64)
+
+// GenerateVanityAddress generates random accounts until one has an address
+// starting with prefix, in upper case base32 (A-Z and 2-7). Each character of
+// the prefix multiplies the expected number of accounts to generate by 32, so
+// prefixes of more than 5 or 6 characters take very long to find. The search
+// runs on workers goroutines, or one per CPU if workers is not positive, until
+// an account is found or ctx is done, when ctx.Err() is returned. If progress
+// is not nil, it is called each second with the number of accounts generated.
+func GenerateVanityAddress(ctx context.Context, prefix string, workers int, progress VanityProgress) (Account, error) {
+	if len(prefix) > len(Account{}.Address.String()) {
+		return Account{}, fmt.Errorf("vanity prefix %q is longer than an address", prefix)
+	}
+	for _, c := range prefix {
+		if !strings.ContainsRune(base32Alphabet, c) {
+			return Account{}, fmt.Errorf("vanity prefix %q has %q, which is not an address character", prefix, c)
+		}
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	expected := uint64(1)
+	for i := 0; i < len(prefix) && expected < 1<<59; i++ {
+		expected *= 32
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var attempts uint64
+	found := make(chan Account, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				account := vanityGenerateAccount()
+				atomic.AddUint64(&attempts, 1)
+				if strings.HasPrefix(account.Address.String(), prefix) {
+					found <- account
+					return
+				}
+				account.PrivateKey.Zero()
+			}
+		}()
+	}
+	defer func() {
+		// stop the workers before returning, so that they do not keep using
+		// CPU, and wipe the keys of the accounts they found that are not
+		// returned
+		cancel()
+		wg.Wait()
+		close(found)
+		for unused := range found {
+			unused.PrivateKey.Zero()
+		}
+	}()
+
+	ticker := time.NewTicker(vanityProgressInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case account := <-found:
+			if progress != nil {
+				progress(atomic.LoadUint64(&attempts), expected)
+			}
+			return account, nil
+		case <-ticker.C:
+			if progress != nil {
+				progress(atomic.LoadUint64(&attempts), expected)
+			}
+		case <-ctx.Done():
+			return Account{}, ctx.Err()
+		}
+	}
+}
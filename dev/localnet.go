@@ -0,0 +1,156 @@
+// Package dev helps develop and test against a LocalNet, a private network
+// run on the developer's machine by AlgoKit ("algokit localnet start") or the
+// sandbox, whose genesis accounts are kept in a kmd wallet without password.
+package dev
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/client/kmd"
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/client/v2/indexer"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/future"
+)
+
+// The addresses and API token of the LocalNet's services, as AlgoKit and the
+// sandbox run them
+const (
+	AlgodAddress   = "http://localhost:4001"
+	KmdAddress     = "http://localhost:4002"
+	IndexerAddress = "http://localhost:8980"
+	Token          = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+)
+
+// DispenserWallet is the name of the kmd wallet holding the genesis accounts
+// of the LocalNet, which has no password
+const DispenserWallet = "unencrypted-default-wallet"
+
+// fundingWaitRounds is the number of rounds EnsureFunded waits for its
+// payment to be confirmed
+const fundingWaitRounds = 10
+
+// LocalNet is a client of a LocalNet's algod, kmd and indexer
+type LocalNet struct {
+	Algod   client.AlgodClient
+	Kmd     client.KmdClient
+	Indexer client.IndexerClient
+
+	// dispenser is the account found by GetDispenserAccount
+	dispenser *crypto.Account
+}
+
+// MakeLocalNet returns a client of the LocalNet at the default addresses
+func MakeLocalNet() (*LocalNet, error) {
+	return MakeLocalNetAt(AlgodAddress, KmdAddress, IndexerAddress, Token)
+}
+
+// MakeLocalNetAt returns a client of the LocalNet whose services are at the
+// given addresses, all accepting the given API token
+func MakeLocalNetAt(algodAddress, kmdAddress, indexerAddress, token string) (*LocalNet, error) {
+	algodClient, err := algod.MakeClient(algodAddress, token)
+	if err != nil {
+		return nil, err
+	}
+	kmdClient, err := kmd.MakeClient(kmdAddress, token)
+	if err != nil {
+		return nil, err
+	}
+	indexerClient, err := indexer.MakeClient(indexerAddress, token)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalNet{Algod: algodClient, Kmd: kmdClient, Indexer: indexerClient}, nil
+}
+
+// GetDispenserAccount returns the genesis account of the LocalNet holding the
+// most Algos, exported from the kmd wallet DispenserWallet. The account is
+// looked up once and remembered.
+func (n *LocalNet) GetDispenserAccount(ctx context.Context) (crypto.Account, error) {
+	if n.dispenser != nil {
+		return *n.dispenser, nil
+	}
+	wallets, err := n.Kmd.ListWallets(ctx)
+	if err != nil {
+		return crypto.Account{}, err
+	}
+	walletID := ""
+	for _, wallet := range wallets.Wallets {
+		if wallet.Name == DispenserWallet {
+			walletID = wallet.ID
+		}
+	}
+	if walletID == "" {
+		return crypto.Account{}, fmt.Errorf("kmd has no wallet %s", DispenserWallet)
+	}
+	handle, err := n.Kmd.InitWalletHandle(ctx, walletID, "")
+	if err != nil {
+		return crypto.Account{}, err
+	}
+	defer n.Kmd.ReleaseWalletHandle(ctx, handle.WalletHandleToken)
+	keys, err := n.Kmd.ListKeys(ctx, handle.WalletHandleToken)
+	if err != nil {
+		return crypto.Account{}, err
+	}
+
+	dispenser, balance := "", uint64(0)
+	for _, address := range keys.Addresses {
+		info, err := n.Algod.AccountInformation(address).Do(ctx)
+		if err != nil {
+			return crypto.Account{}, err
+		}
+		if dispenser == "" || info.Amount > balance {
+			dispenser, balance = address, info.Amount
+		}
+	}
+	if dispenser == "" {
+		return crypto.Account{}, fmt.Errorf("kmd wallet %s has no accounts", DispenserWallet)
+	}
+	key, err := n.Kmd.ExportKey(ctx, handle.WalletHandleToken, "", dispenser)
+	if err != nil {
+		return crypto.Account{}, err
+	}
+	account, err := crypto.AccountFromPrivateKey(crypto.SecretKey(key.PrivateKey))
+	if err != nil {
+		return crypto.Account{}, err
+	}
+	n.dispenser = &account
+	return account, nil
+}
+
+// EnsureFunded pays address from the dispenser account, if needed, so that
+// it holds at least amount microAlgos, and waits for the payment to be
+// confirmed. It returns the ID of the payment, or "" if none was needed.
+func (n *LocalNet) EnsureFunded(ctx context.Context, address string, amount uint64) (txid string, err error) {
+	info, err := n.Algod.AccountInformation(address).Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	if info.Amount >= amount {
+		return "", nil
+	}
+	dispenser, err := n.GetDispenserAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+	params, err := n.Algod.SuggestedParams().Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	txn, err := future.MakePaymentTxn(dispenser.Address.String(), address, amount-info.Amount, nil, "", params)
+	if err != nil {
+		return "", err
+	}
+	_, stx, err := crypto.SignTransaction(dispenser.PrivateKey, txn)
+	if err != nil {
+		return "", err
+	}
+	txid, err = n.Algod.SendRawTransaction(stx).Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	_, err = future.WaitForConfirmation(ctx, n.Algod, txid, fundingWaitRounds)
+	return txid, err
+}
@@ -0,0 +1,90 @@
+package dev
+
+import (
+	"context"
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+)
+
+func TestLocalNet(t *testing.T) {
+	poor, rich := crypto.GenerateAccount(), crypto.GenerateAccount()
+	user := crypto.GenerateAccount().Address.String()
+	balances := map[string]uint64{
+		poor.Address.String(): 1000000,
+		rich.Address.String(): 4000000000000000,
+	}
+	var sent []crypto.Account
+	exported := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		switch {
+		case r.URL.Path == "/v1/wallets":
+			w.Write([]byte(`{"wallets":[{"id":"W1","name":"other"},{"id":"W2","name":"` + DispenserWallet + `"}]}`))
+		case r.URL.Path == "/v1/wallet/init":
+			require.Contains(t, string(body), `"W2"`)
+			w.Write([]byte(`{"wallet_handle_token":"H"}`))
+		case r.URL.Path == "/v1/wallet/release":
+			w.Write([]byte(`{}`))
+		case r.URL.Path == "/v1/key/list":
+			w.Write([]byte(`{"addresses":["` + poor.Address.String() + `","` + rich.Address.String() + `"]}`))
+		case r.URL.Path == "/v1/key/export":
+			exported++
+			require.Contains(t, string(body), rich.Address.String())
+			w.Write([]byte(`{"private_key":"` + base64.StdEncoding.EncodeToString(rich.PrivateKey) + `"}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/accounts/"):
+			address := strings.TrimPrefix(r.URL.Path, "/v2/accounts/")
+			w.Write([]byte(`{"address":"` + address + `","amount":` + strconv.FormatUint(balances[address], 10) + `}`))
+		case r.URL.Path == "/v2/transactions/params":
+			w.Write([]byte(`{"last-round":10,"genesis-id":"dockernet-v1","genesis-hash":"` + base64.StdEncoding.EncodeToString(make([]byte, 32)) + `","min-fee":1000}`))
+		case r.URL.Path == "/v2/transactions":
+			stx, txid, err := crypto.DecodeSignedTransaction(body)
+			require.NoError(t, err)
+			require.Equal(t, rich.Address, stx.Txn.Sender)
+			balances[stx.Txn.Receiver.String()] += uint64(stx.Txn.Amount)
+			sent = append(sent, rich)
+			w.Write([]byte(`{"txId":"` + txid + `"}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/transactions/pending/"):
+			w.Write(msgpack.Encode(map[string]interface{}{"confirmed-round": uint64(11), "pool-error": ""}))
+		case r.URL.Path == "/v2/status":
+			w.Write([]byte(`{"last-round":11}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	localnet, err := MakeLocalNetAt(server.URL, server.URL, server.URL, Token)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	dispenser, err := localnet.GetDispenserAccount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, rich.Address, dispenser.Address)
+
+	txid, err := localnet.EnsureFunded(ctx, user, 5000000)
+	require.NoError(t, err)
+	require.NotEmpty(t, txid)
+	require.Equal(t, uint64(5000000), balances[user])
+
+	// funded accounts are not paid again
+	txid, err = localnet.EnsureFunded(ctx, user, 2000000)
+	require.NoError(t, err)
+	require.Empty(t, txid)
+	txid, err = localnet.EnsureFunded(ctx, user, 7000000)
+	require.NoError(t, err)
+	require.NotEmpty(t, txid)
+	require.Equal(t, uint64(7000000), balances[user])
+	require.Len(t, sent, 2)
+	require.Equal(t, 1, exported)
+}
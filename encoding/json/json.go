@@ -1,6 +1,9 @@
 package json
 
 import (
+	"bytes"
+	stdjson "encoding/json"
+	"fmt"
 	"io"
 
 	"github.com/algorand/go-codec/codec"
@@ -10,6 +13,10 @@ import (
 // with our settings (canonical, paranoid about decoding errors)
 var CodecHandle *codec.JsonHandle
 
+// LenientCodecHandle is used to instantiate json decoders for data
+// that may carry fields we do not know about, such as REST responses
+var LenientCodecHandle *codec.JsonHandle
+
 // init configures our json encoder and decoder
 func init() {
 	CodecHandle = new(codec.JsonHandle)
@@ -19,6 +26,14 @@ func init() {
 	CodecHandle.RecursiveEmptyCheck = true
 	CodecHandle.Indent = 2
 	CodecHandle.HTMLCharsAsIs = true
+
+	LenientCodecHandle = new(codec.JsonHandle)
+	LenientCodecHandle.ErrorIfNoField = false
+	LenientCodecHandle.ErrorIfNoArrayExpand = true
+	LenientCodecHandle.Canonical = true
+	LenientCodecHandle.RecursiveEmptyCheck = true
+	LenientCodecHandle.Indent = 2
+	LenientCodecHandle.HTMLCharsAsIs = true
 }
 
 // Encode returns a json-encoded byte buffer for a given object
@@ -40,7 +55,35 @@ func Decode(b []byte, objptr interface{}) error {
 	return nil
 }
 
+// LenientDecode attempts to decode a json-encoded byte buffer into an
+// object instance pointed to by objptr, ignoring fields objptr does not have
+func LenientDecode(b []byte, objptr interface{}) error {
+	dec := codec.NewDecoderBytes(b, LenientCodecHandle)
+	err := dec.Decode(objptr)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 // NewDecoder returns a json decoder
 func NewDecoder(r io.Reader) *codec.Decoder {
 	return codec.NewDecoder(r, CodecHandle)
 }
+
+// DecodeStd decodes JSON into objptr as encoding/json's Unmarshal does,
+// honoring the standard library's tags and Unmarshaler implementations, but
+// decodes numbers held by interface{} values as json.Number rather than
+// float64, so that integers above 2^53, such as amounts and asset totals,
+// keep their precision
+func DecodeStd(b []byte, objptr interface{}) error {
+	dec := stdjson.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(objptr); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("invalid data after top-level JSON value")
+	}
+	return nil
+}
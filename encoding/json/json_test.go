@@ -0,0 +1,30 @@
+package json
+
+import (
+	stdjson "encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeStd(t *testing.T) {
+	var typed struct {
+		Amount uint64      `json:"amount"`
+		Extra  interface{} `json:"extra"`
+	}
+	require.NoError(t, DecodeStd([]byte(`{"amount":18446744073709551615,"extra":{"total":9007199254740993}}`), &typed))
+	require.Equal(t, uint64(1<<64-1), typed.Amount)
+	require.Equal(t, map[string]interface{}{"total": stdjson.Number("9007199254740993")}, typed.Extra)
+
+	// the numbers are encoded again as they were
+	encoded, err := stdjson.Marshal(typed.Extra)
+	require.NoError(t, err)
+	require.Equal(t, `{"total":9007199254740993}`, string(encoded))
+
+	require.Error(t, DecodeStd([]byte(`{"amount":1} {}`), &typed))
+	require.Error(t, DecodeStd([]byte(`{"amount":-1}`), &typed))
+
+	var untyped interface{}
+	require.NoError(t, LenientDecode([]byte(`[9007199254740993]`), &untyped))
+	require.Equal(t, []interface{}{uint64(1<<53 + 1)}, untyped)
+}
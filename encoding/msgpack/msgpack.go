@@ -1,7 +1,14 @@
+// Package msgpack encodes and decodes values the way go-algorand does on
+// chain: maps with sorted keys, with zero values omitted, so that encoding
+// the same value always gives the same bytes. Transactions and other
+// structures are hashed and signed in this encoding.
 package msgpack
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"sync"
 
 	"github.com/algorand/go-codec/codec"
 )
@@ -10,6 +17,10 @@ import (
 // with our settings (canonical, paranoid about decoding errors)
 var CodecHandle *codec.MsgpackHandle
 
+// LenientCodecHandle is used to instantiate msgpack decoders for data
+// that may carry fields we do not know about, such as REST responses
+var LenientCodecHandle *codec.MsgpackHandle
+
 // init configures our msgpack encoder and decoder
 func init() {
 	CodecHandle = new(codec.MsgpackHandle)
@@ -19,14 +30,79 @@ func init() {
 	CodecHandle.RecursiveEmptyCheck = true
 	CodecHandle.WriteExt = true
 	CodecHandle.PositiveIntUnsigned = true
+
+	LenientCodecHandle = new(codec.MsgpackHandle)
+	LenientCodecHandle.ErrorIfNoField = false
+	LenientCodecHandle.ErrorIfNoArrayExpand = true
+	LenientCodecHandle.Canonical = true
+	LenientCodecHandle.RecursiveEmptyCheck = true
+	LenientCodecHandle.WriteExt = true
+	LenientCodecHandle.PositiveIntUnsigned = true
+}
+
+// maxPooledBufferSize is the largest buffer kept for reuse after encoding, so
+// that encoding a large object, such as a block, does not pin its memory
+const maxPooledBufferSize = 64 * 1024
+
+// pooledEncoder is an encoder, which caches how to encode the types it has
+// seen, and the buffer it encodes into, kept in encoderPool for reuse
+type pooledEncoder struct {
+	enc *codec.Encoder
+	buf []byte
+}
+
+var encoderPool = sync.Pool{
+	New: func() interface{} {
+		e := new(pooledEncoder)
+		e.enc = codec.NewEncoderBytes(&e.buf, CodecHandle)
+		return e
+	},
+}
+
+// encodePooled encodes obj into the buffer of a pooled encoder, and passes the
+// encoding to use, which must not retain it, before returning the encoder to
+// the pool
+func encodePooled(obj interface{}, use func(encoded []byte) error) error {
+	e := encoderPool.Get().(*pooledEncoder)
+	e.buf = e.buf[:0]
+	e.enc.ResetBytes(&e.buf)
+	if err := e.enc.Encode(obj); err != nil {
+		return err
+	}
+	err := use(e.buf)
+	if cap(e.buf) <= maxPooledBufferSize {
+		encoderPool.Put(e)
+	}
+	return err
 }
 
 // Encode returns a msgpack-encoded byte buffer for a given object
 func Encode(obj interface{}) []byte {
-	var b []byte
-	enc := codec.NewEncoderBytes(&b, CodecHandle)
-	enc.MustEncode(obj)
-	return b
+	return AppendEncode(nil, obj)
+}
+
+// AppendEncode appends the msgpack encoding of obj to dst and returns the
+// extended buffer, as append does. Encoders and their buffers are pooled, so
+// encoding a pointer into a dst with enough capacity, such as a buffer reused
+// across a batch of transactions, does not allocate.
+func AppendEncode(dst []byte, obj interface{}) []byte {
+	err := encodePooled(obj, func(encoded []byte) error {
+		dst = append(dst, encoded...)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return dst
+}
+
+// EncodeTo writes the msgpack encoding of obj to w, encoding it with a pooled
+// encoder and buffer, and returns any error encoding or writing it
+func EncodeTo(w io.Writer, obj interface{}) error {
+	return encodePooled(obj, func(encoded []byte) error {
+		_, err := w.Write(encoded)
+		return err
+	})
 }
 
 // Decode attempts to decode a msgpack-encoded byte buffer into an
@@ -40,6 +116,33 @@ func Decode(b []byte, objptr interface{}) error {
 	return nil
 }
 
+// DecodeStrict decodes a msgpack-encoded byte buffer like Decode, but also
+// returns an error unless the buffer is the canonical encoding of the decoded
+// object, with no trailing bytes, unsorted keys or zero values. Use it for
+// data that will be hashed or signed, so that it cannot carry bytes that the
+// decoded object does not account for.
+func DecodeStrict(b []byte, objptr interface{}) error {
+	err := Decode(b, objptr)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(Encode(objptr), b) {
+		return fmt.Errorf("msgpack data is not canonically encoded")
+	}
+	return nil
+}
+
+// LenientDecode attempts to decode a msgpack-encoded byte buffer into an
+// object instance pointed to by objptr, ignoring fields objptr does not have
+func LenientDecode(b []byte, objptr interface{}) error {
+	dec := codec.NewDecoderBytes(b, LenientCodecHandle)
+	err := dec.Decode(objptr)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 // NewDecoder returns a msgpack decoder
 func NewDecoder(r io.Reader) *codec.Decoder {
 	return codec.NewDecoder(r, CodecHandle)
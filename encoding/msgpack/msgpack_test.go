@@ -0,0 +1,158 @@
+package msgpack
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// randomize fills v with random values, leaving each field zero half the time
+// so that omitted fields are exercised too
+func randomize(r *rand.Rand, v reflect.Value, depth int) {
+	if r.Intn(2) == 0 && depth > 0 {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(r.Intn(2) == 1)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(r.Uint64() >> uint(r.Intn(64)))
+	case reflect.Int, reflect.Int64:
+		v.SetInt(r.Int63() >> uint(r.Intn(63)))
+	case reflect.String:
+		b := make([]byte, r.Intn(40))
+		r.Read(b)
+		v.SetString(string(b))
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			randomize(r, v.Index(i), depth+1)
+		}
+	case reflect.Slice:
+		n := r.Intn(5)
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			n = r.Intn(100)
+		}
+		if n == 0 {
+			return
+		}
+		v.Set(reflect.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			randomize(r, v.Index(i), depth+1)
+		}
+	case reflect.Map:
+		n := r.Intn(4)
+		if n == 0 {
+			return
+		}
+		v.Set(reflect.MakeMap(v.Type()))
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			randomize(r, key, 0)
+			elem := reflect.New(v.Type().Elem()).Elem()
+			randomize(r, elem, depth+1)
+			v.SetMapIndex(key, elem)
+		}
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		randomize(r, v.Elem(), depth+1)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			randomize(r, v.Field(i), depth+1)
+		}
+	}
+}
+
+func TestRoundTripRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		var stx types.SignedTxn
+		randomize(r, reflect.ValueOf(&stx).Elem(), 0)
+		encoded := Encode(stx)
+
+		var decoded types.SignedTxn
+		require.NoError(t, DecodeStrict(encoded, &decoded))
+		require.Equal(t, encoded, Encode(decoded))
+
+		var decodedTxn types.Transaction
+		require.NoError(t, DecodeStrict(Encode(stx.Txn), &decodedTxn))
+		require.Equal(t, Encode(stx.Txn), Encode(decodedTxn))
+
+		// a damaged encoding must either fail to decode or decode to
+		// something that encodes back to the same bytes
+		damaged := append([]byte{}, encoded...)
+		damaged[r.Intn(len(damaged))] ^= byte(1 + r.Intn(255))
+		var out types.SignedTxn
+		if err := DecodeStrict(damaged, &out); err == nil {
+			require.Equal(t, damaged, Encode(out))
+		}
+	}
+}
+
+func TestEncodeCanonical(t *testing.T) {
+	// keys are sorted and zero values omitted, however the value was built
+	a := map[string]interface{}{"b": uint64(1), "a": "x", "c": map[string]uint64{"z": 2, "y": 1}}
+	require.Equal(t, []byte{0x83, 0xa1, 0x61, 0xa1, 0x78, 0xa1, 0x62, 0x01, 0xa1, 0x63, 0x82, 0xa1, 0x79, 0x01, 0xa1, 0x7a, 0x02}, Encode(a))
+
+	tx := types.Transaction{Type: types.PaymentTx, Header: types.Header{Fee: 1000}}
+	require.Equal(t, Encode(map[string]interface{}{"fee": uint64(1000), "type": "pay"}), Encode(tx))
+}
+
+func TestDecodeStrict(t *testing.T) {
+	canonical := Encode(map[string]interface{}{"fee": uint64(1000), "type": "pay"})
+	var tx types.Transaction
+	require.NoError(t, DecodeStrict(canonical, &tx))
+	require.Equal(t, types.MicroAlgos(1000), tx.Fee)
+
+	// trailing bytes
+	require.Error(t, DecodeStrict(append(canonical, 0x00), &types.Transaction{}))
+	require.NoError(t, Decode(append(canonical, 0x00), &types.Transaction{}))
+
+	// an explicit zero value
+	withZero := Encode(map[string]interface{}{"fee": uint64(1000), "fv": uint64(0), "type": "pay"})
+	require.Error(t, DecodeStrict(withZero, &types.Transaction{}))
+	require.NoError(t, Decode(withZero, &types.Transaction{}))
+
+	// unsorted keys
+	unsorted := []byte{0x82, 0xa4, 't', 'y', 'p', 'e', 0xa3, 'p', 'a', 'y', 0xa3, 'f', 'e', 'e', 0xcd, 0x03, 0xe8}
+	require.Error(t, DecodeStrict(unsorted, &types.Transaction{}))
+	require.NoError(t, Decode(unsorted, &types.Transaction{}))
+
+	// an integer encoded wider than it needs
+	wide := []byte{0x82, 0xa3, 'f', 'e', 'e', 0xcf, 0, 0, 0, 0, 0, 0, 0x03, 0xe8, 0xa4, 't', 'y', 'p', 'e', 0xa3, 'p', 'a', 'y'}
+	require.Error(t, DecodeStrict(wide, &types.Transaction{}))
+
+	// unknown fields are rejected by Decode already
+	require.Error(t, DecodeStrict(Encode(map[string]interface{}{"foo": uint64(1)}), &types.Transaction{}))
+}
+
+func TestAppendEncode(t *testing.T) {
+	tx := types.Transaction{Type: types.PaymentTx, Header: types.Header{Fee: 1000, FirstValid: 1, LastValid: 1000, Note: []byte("note")}}
+	encoded := Encode(tx)
+
+	buf := AppendEncode([]byte("TX"), tx)
+	require.Equal(t, append([]byte("TX"), encoded...), buf)
+
+	var w bytes.Buffer
+	require.NoError(t, EncodeTo(&w, tx))
+	require.NoError(t, EncodeTo(&w, tx))
+	require.Equal(t, append(append([]byte{}, encoded...), encoded...), w.Bytes())
+
+	// reusing a buffer with enough capacity does not allocate
+	if raceEnabled {
+		t.Skip("allocation counts are not meaningful under the race detector")
+	}
+	buf = make([]byte, 0, 1024)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf = AppendEncode(buf[:0], &tx)
+	})
+	require.Equal(t, encoded, buf)
+	require.Zero(t, allocs)
+}
@@ -0,0 +1,8 @@
+//go:build race
+// +build race
+
+package msgpack
+
+// raceEnabled reports whether the tests were built with the race detector,
+// which adds allocations of its own.
+const raceEnabled = true
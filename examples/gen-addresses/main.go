@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	"github.com/algorand/go-algorand-sdk/client/algod"
@@ -22,6 +23,8 @@ const exampleWalletPassword = "example-password"
 const exampleWalletDriver = kmd.DefaultWalletDriver
 
 func main() {
+	ctx := context.Background()
+
 	// Create a kmd client
 	kmdClient, err := kmd.MakeClient(kmdAddress, kmdToken)
 	if err != nil {
@@ -38,7 +41,7 @@ func main() {
 	}
 
 	// Print algod status
-	nodeStatus, err := algodClient.Status()
+	nodeStatus, err := algodClient.Status(ctx)
 	if err != nil {
 		fmt.Printf("error getting algod status: %s\n", err)
 		return
@@ -46,7 +49,7 @@ func main() {
 	fmt.Printf("algod last round: %d\n", nodeStatus.LastRound)
 
 	// List existing wallets, and check if our example wallet already exists
-	resp0, err := kmdClient.ListWallets()
+	resp0, err := kmdClient.ListWallets(ctx)
 	if err != nil {
 		fmt.Printf("error listing wallets: %s\n", err)
 		return
@@ -64,7 +67,7 @@ func main() {
 
 	// Create the example wallet, if it doesn't already exist
 	if !exampleExists {
-		resp1, err := kmdClient.CreateWallet(exampleWalletName, exampleWalletPassword, exampleWalletDriver, types.MasterDerivationKey{})
+		resp1, err := kmdClient.CreateWallet(ctx, exampleWalletName, exampleWalletPassword, exampleWalletDriver, types.MasterDerivationKey{})
 		if err != nil {
 			fmt.Printf("error creating wallet: %s\n", err)
 			return
@@ -74,7 +77,7 @@ func main() {
 	}
 
 	// Get a wallet handle
-	resp2, err := kmdClient.InitWalletHandle(exampleWalletID, exampleWalletPassword)
+	resp2, err := kmdClient.InitWalletHandle(ctx, exampleWalletID, exampleWalletPassword)
 	if err != nil {
 		fmt.Printf("Error initializing wallet: %s\n", err)
 		return
@@ -87,7 +90,7 @@ func main() {
 	fmt.Println("Generating 10 addresses")
 	var addresses []string
 	for i := 0; i < 10; i++ {
-		resp3, err := kmdClient.GenerateKey(exampleWalletHandleToken)
+		resp3, err := kmdClient.GenerateKey(ctx, exampleWalletHandleToken)
 		if err != nil {
 			fmt.Printf("Error generating key: %s\n", err)
 			return
@@ -98,15 +101,16 @@ func main() {
 
 	// Extract the private key of the first address
 	fmt.Printf("Extracting private key for %s\n", addresses[0])
-	resp4, err := kmdClient.ExportKey(exampleWalletHandleToken, exampleWalletPassword, addresses[0])
+	resp4, err := kmdClient.ExportKey(ctx, exampleWalletHandleToken, exampleWalletPassword, addresses[0])
 	if err != nil {
 		fmt.Printf("Error extracting secret key: %s\n", err)
 		return
 	}
-	privateKey := resp4.PrivateKey
+	privateKey := crypto.SecretKey(resp4.PrivateKey)
+	defer privateKey.Zero()
 
 	// Get the suggested transaction parameters
-	txParams, err := algodClient.SuggestedParams()
+	txParams, err := algodClient.SuggestedParams(ctx)
 	if err != nil {
 		fmt.Printf("error getting suggested tx params: %s\n", err)
 		return
@@ -133,7 +137,7 @@ func main() {
 
 	// Sign the same transaction with kmd
 	fmt.Println("Signing same transaction with kmd")
-	resp5, err := kmdClient.SignTransaction(exampleWalletHandleToken, exampleWalletPassword, tx)
+	resp5, err := kmdClient.SignTransaction(ctx, exampleWalletHandleToken, exampleWalletPassword, tx)
 	if err != nil {
 		fmt.Printf("Failed to sign transaction with kmd: %s\n", err)
 		return
@@ -0,0 +1,156 @@
+package future
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/appspec"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// programPageSize is the size of the program pages an application is allocated
+const programPageSize = 2048
+
+// AppClient adds calls of an application described by an app spec to an
+// AtomicTransactionComposer, encoding method arguments from the spec and
+// checking each call is one of the actions the spec allows.
+type AppClient struct {
+	Contract appspec.Contract
+
+	// AppID is the ID of the application, 0 until it has been created
+	AppID uint64
+
+	// Sender sends the application calls and Signer signs them
+	Sender types.Address
+	Signer TransactionSigner
+}
+
+// AppCallParams are the parameters of a call made through an AppClient
+type AppCallParams struct {
+	// Method is the name or signature of the method to call, or empty for a
+	// bare call without a method
+	Method string
+
+	// Args are the method's arguments, see AddMethodCallParams.MethodArgs
+	Args []interface{}
+
+	// OnComplete is the action taken after the call
+	OnComplete types.OnCompletion
+
+	// SuggestedParams are the parameters of the application call, typically
+	// fetched from algod
+	SuggestedParams types.SuggestedParams
+
+	Note  []byte
+	Lease [32]byte
+
+	// RekeyTo, if nonzero, rekeys the sender to this address
+	RekeyTo types.Address
+
+	ForeignAccounts []types.Address
+	ForeignApps     []uint64
+	ForeignAssets   []uint64
+	BoxReferences   []types.AppBoxReference
+}
+
+// AddCreate adds a call creating the application with the given programs and
+// the state schema of the app spec. See appspec.Contract.Programs to get the programs.
+func (c AppClient) AddCreate(atc *AtomicTransactionComposer, params AppCallParams, approval, clear []byte) error {
+	if len(approval) == 0 || len(clear) == 0 {
+		return fmt.Errorf("approval and clear programs are required to create an application")
+	}
+	extraPages := (len(approval) + len(clear) - 1) / programPageSize
+	return c.add(atc, params, 0, approval, clear, uint32(extraPages))
+}
+
+// AddUpdate adds a call replacing the application's programs
+func (c AppClient) AddUpdate(atc *AtomicTransactionComposer, params AppCallParams, approval, clear []byte) error {
+	if len(approval) == 0 || len(clear) == 0 {
+		return fmt.Errorf("approval and clear programs are required to update an application")
+	}
+	params.OnComplete = types.UpdateApplicationOC
+	return c.add(atc, params, c.AppID, approval, clear, 0)
+}
+
+// AddCall adds a call of the application, e.g. a NoOp method call or an opt in
+func (c AppClient) AddCall(atc *AtomicTransactionComposer, params AppCallParams) error {
+	if params.OnComplete == types.UpdateApplicationOC {
+		return fmt.Errorf("use AddUpdate to update the application")
+	}
+	return c.add(atc, params, c.AppID, nil, nil, 0)
+}
+
+func (c AppClient) add(atc *AtomicTransactionComposer, params AppCallParams, appID uint64, approval, clear []byte, extraPages uint32) error {
+	create := appID == 0
+	if !create && c.AppID == 0 {
+		return fmt.Errorf("the application has not been created")
+	}
+
+	callParams := AddMethodCallParams{
+		AppID:           appID,
+		MethodArgs:      params.Args,
+		Sender:          c.Sender,
+		SuggestedParams: params.SuggestedParams,
+		OnComplete:      params.OnComplete,
+		ApprovalProgram: approval,
+		ClearProgram:    clear,
+		ExtraPages:      extraPages,
+		Note:            params.Note,
+		Lease:           params.Lease,
+		RekeyTo:         params.RekeyTo,
+		ForeignAccounts: params.ForeignAccounts,
+		ForeignApps:     params.ForeignApps,
+		ForeignAssets:   params.ForeignAssets,
+		BoxReferences:   params.BoxReferences,
+		Signer:          c.Signer,
+	}
+	if create {
+		callParams.GlobalSchema = c.Contract.GlobalSchema
+		callParams.LocalSchema = c.Contract.LocalSchema
+	}
+
+	if params.Method == "" {
+		if !allowed(c.Contract.BareActions, create, params.OnComplete) {
+			return fmt.Errorf("the app spec does not allow bare calls with on-completion %d when %s", params.OnComplete, callKind(create))
+		}
+		if len(params.Args) > 0 {
+			return fmt.Errorf("bare calls take no arguments")
+		}
+		apps := make([]types.AppIndex, len(params.ForeignApps))
+		for i, app := range params.ForeignApps {
+			apps[i] = types.AppIndex(app)
+		}
+		assets := make([]types.AssetIndex, len(params.ForeignAssets))
+		for i, asset := range params.ForeignAssets {
+			assets[i] = types.AssetIndex(asset)
+		}
+		tx, err := makeAppCallTxn(callParams, nil, params.ForeignAccounts, apps, assets)
+		if err != nil {
+			return err
+		}
+		return atc.AddTransaction(TransactionWithSigner{Txn: tx, Signer: c.Signer})
+	}
+
+	method, err := c.Contract.GetMethod(params.Method)
+	if err != nil {
+		return err
+	}
+	if !allowed(method.Actions, create, params.OnComplete) {
+		return fmt.Errorf("the app spec does not allow calling %s with on-completion %d when %s", method.GetSignature(), params.OnComplete, callKind(create))
+	}
+	callParams.Method = method.Method
+	return atc.AddMethodCall(callParams)
+}
+
+func allowed(actions appspec.Actions, create bool, onComplete types.OnCompletion) bool {
+	if create {
+		return actions.AllowsCreate(onComplete)
+	}
+	return actions.AllowsCall(onComplete)
+}
+
+func callKind(create bool) string {
+	if create {
+		return "creating the application"
+	}
+	return "calling the application"
+}
@@ -0,0 +1,82 @@
+package future
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/appspec"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+var testAppSpec = []byte(`{
+  "arcs": [4, 56],
+  "name": "Counter",
+  "methods": [
+    {"name": "create", "args": [{"type": "uint64"}], "returns": {"type": "void"}, "actions": {"create": ["NoOp"], "call": []}},
+    {"name": "increment", "args": [{"type": "uint64"}], "returns": {"type": "uint64"}, "actions": {"create": [], "call": ["NoOp"]}}
+  ],
+  "state": {"schema": {"global": {"ints": 1, "bytes": 0}, "local": {"ints": 0, "bytes": 1}}},
+  "bareActions": {"create": [], "call": ["OptIn", "UpdateApplication"]},
+  "byteCode": {"approval": "CIEBQw==", "clear": "CIEB"}
+}`)
+
+func TestAppClient(t *testing.T) {
+	contract, err := appspec.Parse(testAppSpec)
+	require.NoError(t, err)
+	approval, clear, err := contract.Programs(nil)
+	require.NoError(t, err)
+
+	acct := crypto.GenerateAccount()
+	client := AppClient{Contract: contract, Sender: acct.Address, Signer: BasicAccountTransactionSigner{Account: acct}}
+	params := AppCallParams{SuggestedParams: makeTestSuggestedParams()}
+
+	var atc AtomicTransactionComposer
+	// the application does not exist yet
+	params.Method = "increment"
+	params.Args = []interface{}{uint64(1)}
+	require.Error(t, client.AddCall(&atc, params))
+
+	// create may only be used to create the application
+	params.Method = "create"
+	params.Args = []interface{}{uint64(5)}
+	require.NoError(t, client.AddCreate(&atc, params, approval, clear))
+	txList, err := atc.BuildGroup()
+	require.NoError(t, err)
+	createTxn := txList[0].Txn
+	require.Equal(t, types.AppIndex(0), createTxn.ApplicationID)
+	require.Equal(t, approval, createTxn.ApprovalProgram)
+	require.Equal(t, clear, createTxn.ClearStateProgram)
+	require.Equal(t, types.StateSchema{NumUint: 1}, createTxn.GlobalStateSchema)
+	require.Equal(t, types.StateSchema{NumByteSlice: 1}, createTxn.LocalStateSchema)
+	require.Equal(t, uint32(0), createTxn.ExtraProgramPages)
+	require.Len(t, createTxn.ApplicationArgs, 2)
+
+	client.AppID = 42
+	atc = AtomicTransactionComposer{}
+	require.Error(t, client.AddCall(&atc, params))
+
+	params.Method = "increment"
+	params.Args = []interface{}{uint64(1)}
+	require.NoError(t, client.AddCall(&atc, params))
+	params.OnComplete = types.OptInOC
+	require.Error(t, client.AddCall(&atc, params))
+
+	// bare calls
+	params.Method = ""
+	params.Args = nil
+	require.NoError(t, client.AddCall(&atc, params))
+	require.NoError(t, client.AddUpdate(&atc, params, approval, clear))
+	params.OnComplete = types.CloseOutOC
+	require.Error(t, client.AddCall(&atc, params))
+
+	txList, err = atc.BuildGroup()
+	require.NoError(t, err)
+	require.Len(t, txList, 3)
+	require.Equal(t, types.AppIndex(42), txList[1].Txn.ApplicationID)
+	require.Equal(t, types.OptInOC, txList[1].Txn.OnCompletion)
+	require.Empty(t, txList[1].Txn.ApplicationArgs)
+	require.Equal(t, types.UpdateApplicationOC, txList[2].Txn.OnCompletion)
+	require.Equal(t, approval, txList[2].Txn.ApprovalProgram)
+}
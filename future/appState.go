@@ -0,0 +1,157 @@
+package future
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+const (
+	// tealBytesType and tealUintType are the types of TEAL values
+	tealBytesType = 1
+	tealUintType  = 2
+)
+
+// StateValue is a value of an application's global or local state: a byte
+// slice if IsBytes, a uint otherwise
+type StateValue struct {
+	IsBytes bool
+	Bytes   []byte
+	Uint    uint64
+}
+
+// AppState is the global state of an application, or its local state in an
+// account, by key. Keys are the raw key bytes, not base64 encoded.
+type AppState map[string]StateValue
+
+// DecodeAppState decodes the key-value pairs of global or local state, as
+// returned by algod and the indexer, with their keys and byte values base64
+// encoded
+func DecodeAppState(kvs []models.TealKeyValue) (AppState, error) {
+	state := make(AppState, len(kvs))
+	for _, kv := range kvs {
+		key, err := base64.StdEncoding.DecodeString(kv.Key)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode state key %q: %v", kv.Key, err)
+		}
+		var value StateValue
+		switch kv.Value.Type {
+		case tealBytesType:
+			value.IsBytes = true
+			value.Bytes, err = base64.StdEncoding.DecodeString(kv.Value.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode the value of state key %q: %v", key, err)
+			}
+		case tealUintType:
+			value.Uint = kv.Value.Uint
+		default:
+			return nil, fmt.Errorf("state key %q has unknown value type %d", key, kv.Value.Type)
+		}
+		state[string(key)] = value
+	}
+	return state, nil
+}
+
+// Unmarshal sets the fields of the struct v points to from the state. Each
+// field tagged `state:"key"` is set from the value of key: uint fields from
+// uint values, and string, []byte, types.Address and other byte array fields
+// from byte values. Fields whose key is not in the state are left unchanged.
+func (s AppState) Unmarshal(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("can only unmarshal state into a pointer to a struct, not %T", v)
+	}
+	rv = rv.Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		key, ok := field.Tag.Lookup("state")
+		if !ok {
+			continue
+		}
+		value, ok := s[key]
+		if !ok {
+			continue
+		}
+		if err := setStateField(rv.Field(i), value); err != nil {
+			return fmt.Errorf("state key %q: field %s: %v", key, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// setStateField sets a struct field from a state value of a matching type
+func setStateField(field reflect.Value, value StateValue) error {
+	switch field.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if value.IsBytes {
+			return fmt.Errorf("expected a uint value, got bytes")
+		}
+		if field.OverflowUint(value.Uint) {
+			return fmt.Errorf("value %d overflows %s", value.Uint, field.Type())
+		}
+		field.SetUint(value.Uint)
+	case reflect.String:
+		if !value.IsBytes {
+			return fmt.Errorf("expected a bytes value, got a uint")
+		}
+		field.SetString(string(value.Bytes))
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported field type %s", field.Type())
+		}
+		if !value.IsBytes {
+			return fmt.Errorf("expected a bytes value, got a uint")
+		}
+		field.SetBytes(append([]byte{}, value.Bytes...))
+	case reflect.Array:
+		if field.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported field type %s", field.Type())
+		}
+		if !value.IsBytes {
+			return fmt.Errorf("expected a bytes value, got a uint")
+		}
+		if len(value.Bytes) != field.Len() {
+			return fmt.Errorf("expected %d bytes, got %d", field.Len(), len(value.Bytes))
+		}
+		reflect.Copy(field, reflect.ValueOf(value.Bytes))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// GlobalState fetches and decodes the global state of the application
+func (c AppClient) GlobalState(ctx context.Context, client client.AlgodClient, headers ...*common.Header) (AppState, error) {
+	if c.AppID == 0 {
+		return nil, fmt.Errorf("the application has not been created")
+	}
+	app, err := client.GetApplicationByID(c.AppID).Do(ctx, headers...)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeAppState(app.Params.GlobalState)
+}
+
+// LocalState fetches and decodes the local state of the application in an
+// account, returning an error if the account has not opted in
+func (c AppClient) LocalState(ctx context.Context, client client.AlgodClient, account types.Address, headers ...*common.Header) (AppState, error) {
+	if c.AppID == 0 {
+		return nil, fmt.Errorf("the application has not been created")
+	}
+	info, err := client.AccountInformation(account.String()).Do(ctx, headers...)
+	if err != nil {
+		return nil, err
+	}
+	for _, local := range info.AppsLocalState {
+		if local.ID == c.AppID {
+			return DecodeAppState(local.KeyValue)
+		}
+	}
+	return nil, fmt.Errorf("account %s has not opted in to application %d", account, c.AppID)
+}
@@ -0,0 +1,110 @@
+package future
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestDecodeAppState(t *testing.T) {
+	owner := crypto.GenerateAccount().Address
+	kvs := []models.TealKeyValue{
+		// "counter" = 7
+		{Key: "Y291bnRlcg==", Value: models.TealValue{Type: 2, Uint: 7}},
+		// "name" = "test"
+		{Key: "bmFtZQ==", Value: models.TealValue{Type: 1, Bytes: "dGVzdA=="}},
+		// "owner" = owner
+		{Key: "b3duZXI=", Value: models.TealValue{Type: 1, Bytes: base64.StdEncoding.EncodeToString(owner[:])}},
+	}
+	state, err := DecodeAppState(kvs)
+	require.NoError(t, err)
+	require.Equal(t, AppState{
+		"counter": {Uint: 7},
+		"name":    {IsBytes: true, Bytes: []byte("test")},
+		"owner":   {IsBytes: true, Bytes: owner[:]},
+	}, state)
+
+	var s struct {
+		Counter uint64        `state:"counter"`
+		Name    string        `state:"name"`
+		Raw     []byte        `state:"name"`
+		Owner   types.Address `state:"owner"`
+		Missing uint64        `state:"missing"`
+		Other   string
+	}
+	s.Missing = 3
+	require.NoError(t, state.Unmarshal(&s))
+	require.Equal(t, uint64(7), s.Counter)
+	require.Equal(t, "test", s.Name)
+	require.Equal(t, []byte("test"), s.Raw)
+	require.Equal(t, owner, s.Owner)
+	require.Equal(t, uint64(3), s.Missing)
+
+	// type mismatches
+	var wrongUint struct {
+		Name uint64 `state:"name"`
+	}
+	require.Error(t, state.Unmarshal(&wrongUint))
+	var wrongBytes struct {
+		Counter string `state:"counter"`
+	}
+	require.Error(t, state.Unmarshal(&wrongBytes))
+	var overflow struct {
+		Counter uint8 `state:"counter"`
+	}
+	require.NoError(t, state.Unmarshal(&overflow))
+	require.Error(t, AppState{"counter": {Uint: 256}}.Unmarshal(&overflow))
+	var shortAddress struct {
+		Owner types.Address `state:"name"`
+	}
+	require.Error(t, state.Unmarshal(&shortAddress))
+	require.Error(t, state.Unmarshal(s))
+
+	_, err = DecodeAppState([]models.TealKeyValue{{Key: "!", Value: models.TealValue{Type: 2}}})
+	require.Error(t, err)
+	_, err = DecodeAppState([]models.TealKeyValue{{Key: "a2V5", Value: models.TealValue{Type: 3}}})
+	require.Error(t, err)
+}
+
+func TestAppClientState(t *testing.T) {
+	acct := crypto.GenerateAccount()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/applications/5":
+			w.Write([]byte(`{"id":5,"params":{"global-state":[{"key":"Y291bnRlcg==","value":{"type":2,"uint":7}}]}}`))
+		case "/v2/accounts/" + acct.Address.String():
+			w.Write([]byte(`{"apps-local-state":[{"id":5,"key-value":[{"key":"bmFtZQ==","value":{"type":1,"bytes":"dGVzdA=="}}]}]}`))
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	app := AppClient{}
+	_, err = app.GlobalState(context.Background(), client)
+	require.Error(t, err)
+
+	app.AppID = 5
+	global, err := app.GlobalState(context.Background(), client)
+	require.NoError(t, err)
+	require.Equal(t, AppState{"counter": {Uint: 7}}, global)
+
+	local, err := app.LocalState(context.Background(), client, acct.Address)
+	require.NoError(t, err)
+	require.Equal(t, AppState{"name": {IsBytes: true, Bytes: []byte("test")}}, local)
+
+	app.AppID = 6
+	_, err = app.LocalState(context.Background(), client, acct.Address)
+	require.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package future
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// AssetCloseOut is an asset to close out of, and the account receiving the
+// remaining holding, which must be opted in to the asset, e.g. its creator
+type AssetCloseOut struct {
+	AssetID uint64
+	CloseTo string
+}
+
+// MakeAssetOptInGroups returns groups of transactions opting account in to
+// each of the assets, in order, with at most types.MaxTxGroupSize
+// transactions per group. The fees of each group are pooled onto its first
+// transaction, and each group of more than one transaction has its group ID
+// set, so that each group is opted in to atomically. opts are applied to each
+// transaction.
+func MakeAssetOptInGroups(account string, assetIDs []uint64, params types.SuggestedParams, opts ...transaction.TxnOption) ([][]types.Transaction, error) {
+	closeOuts := make([]AssetCloseOut, len(assetIDs))
+	for i, id := range assetIDs {
+		closeOuts[i] = AssetCloseOut{AssetID: id}
+	}
+	return makeAssetGroups(account, closeOuts, params, opts)
+}
+
+// MakeAssetOptOutGroups returns groups of transactions closing account out of
+// each of the assets, sending its holding to their CloseTo account, grouped as
+// MakeAssetOptInGroups does
+func MakeAssetOptOutGroups(account string, closeOuts []AssetCloseOut, params types.SuggestedParams, opts ...transaction.TxnOption) ([][]types.Transaction, error) {
+	for _, closeOut := range closeOuts {
+		if closeOut.CloseTo == "" {
+			return nil, fmt.Errorf("asset %d has no account to close out to", closeOut.AssetID)
+		}
+	}
+	return makeAssetGroups(account, closeOuts, params, opts)
+}
+
+// makeAssetGroups makes the groups of MakeAssetOptInGroups and
+// MakeAssetOptOutGroups: closeOuts without a CloseTo account are opted in to
+func makeAssetGroups(account string, closeOuts []AssetCloseOut, params types.SuggestedParams, opts []transaction.TxnOption) ([][]types.Transaction, error) {
+	if len(closeOuts) == 0 {
+		return nil, fmt.Errorf("no assets given")
+	}
+	seen := make(map[uint64]bool, len(closeOuts))
+	for _, closeOut := range closeOuts {
+		if seen[closeOut.AssetID] {
+			return nil, fmt.Errorf("asset %d is given more than once", closeOut.AssetID)
+		}
+		seen[closeOut.AssetID] = true
+	}
+
+	var groups [][]types.Transaction
+	for start := 0; start < len(closeOuts); start += types.MaxTxGroupSize {
+		end := start + types.MaxTxGroupSize
+		if end > len(closeOuts) {
+			end = len(closeOuts)
+		}
+		var builder transaction.GroupBuilder
+		for _, closeOut := range closeOuts[start:end] {
+			txn, err := MakeAssetTransferTxn(account, account, 0, nil, params, closeOut.CloseTo, closeOut.AssetID, opts...)
+			if err != nil {
+				return nil, err
+			}
+			if err := builder.Add(txn); err != nil {
+				return nil, err
+			}
+		}
+		if err := builder.PoolFees(0, params.MinFee); err != nil {
+			return nil, err
+		}
+		group, err := builder.Build()
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
@@ -0,0 +1,84 @@
+package future
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestMakeAssetOptInGroups(t *testing.T) {
+	params := makeTestParams(1000, true)
+	ids := make([]uint64, 20)
+	for i := range ids {
+		ids[i] = uint64(100 + i)
+	}
+	groups, err := MakeAssetOptInGroups(testAddr, ids, params)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.Len(t, groups[0], types.MaxTxGroupSize)
+	require.Len(t, groups[1], 4)
+
+	sender, err := types.DecodeAddress(testAddr)
+	require.NoError(t, err)
+	next := 0
+	for _, group := range groups {
+		gid, err := crypto.ComputeGroupID(clearGroup(group))
+		require.NoError(t, err)
+		for i, txn := range group {
+			require.Equal(t, types.AssetTransferTx, txn.Type)
+			require.Equal(t, types.AssetIndex(ids[next]), txn.XferAsset)
+			require.Equal(t, sender, txn.AssetReceiver)
+			require.Equal(t, types.Address{}, txn.AssetCloseTo)
+			require.Equal(t, gid, txn.Group)
+			if i == 0 {
+				require.Equal(t, types.MicroAlgos(1000*len(group)), txn.Fee)
+			} else {
+				require.Zero(t, txn.Fee)
+			}
+			next++
+		}
+	}
+
+	// a single opt-in needs no group
+	groups, err = MakeAssetOptInGroups(testAddr, []uint64{1}, params)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, types.Digest{}, groups[0][0].Group)
+
+	_, err = MakeAssetOptInGroups(testAddr, nil, params)
+	require.Error(t, err)
+	_, err = MakeAssetOptInGroups(testAddr, []uint64{1, 2, 1}, params)
+	require.Error(t, err)
+	// the pooled fee must cover the group
+	_, err = MakeAssetOptInGroups(testAddr, []uint64{1, 2}, makeTestParams(0, true))
+	require.Error(t, err)
+}
+
+func TestMakeAssetOptOutGroups(t *testing.T) {
+	params := makeTestParams(1000, true)
+	creator := "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	groups, err := MakeAssetOptOutGroups(testAddr, []AssetCloseOut{{1, creator}, {2, testAddr}}, params)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Len(t, groups[0], 2)
+	require.Equal(t, creator, groups[0][0].AssetCloseTo.String())
+	require.Equal(t, types.AssetIndex(2), groups[0][1].XferAsset)
+	require.Equal(t, types.MicroAlgos(2000), groups[0][0].Fee)
+
+	_, err = MakeAssetOptOutGroups(testAddr, []AssetCloseOut{{AssetID: 1}}, params)
+	require.Error(t, err)
+}
+
+// clearGroup returns a copy of the transactions of a group without their
+// group ID
+func clearGroup(group []types.Transaction) []types.Transaction {
+	cleared := make([]types.Transaction, len(group))
+	for i, txn := range group {
+		txn.Group = types.Digest{}
+		cleared[i] = txn
+	}
+	return cleared
+}
@@ -0,0 +1,693 @@
+package future
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/abi"
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// maxAppArgs is the maximum number of application call arguments, one of which
+// is taken by the method selector
+const maxAppArgs = 16
+
+// abiReturnHash is the prefix of the log holding a method's return value
+var abiReturnHash = []byte{0x15, 0x1f, 0x7c, 0x75}
+
+// AtomicTransactionComposerStatus represents the state of an AtomicTransactionComposer
+type AtomicTransactionComposerStatus int
+
+const (
+	// Building means transactions can still be added to the group
+	Building AtomicTransactionComposerStatus = iota
+
+	// Built means the group ID has been assigned and no more transactions can be added
+	Built
+
+	// Signed means every transaction in the group has been signed
+	Signed
+
+	// Submitted means the group has been sent to the network
+	Submitted
+
+	// Committed means the group has been confirmed by the network
+	Committed
+)
+
+// TransactionWithSigner is a transaction and the signer that can authorize it
+type TransactionWithSigner struct {
+	Txn    types.Transaction
+	Signer TransactionSigner
+}
+
+// AddMethodCallParams contains the parameters for AtomicTransactionComposer.AddMethodCall
+type AddMethodCallParams struct {
+	// AppID is the ID of the application to call, or 0 to create an application
+	AppID uint64
+
+	// Method is the ABI method to call
+	Method abi.Method
+
+	// MethodArgs are the method's arguments, in order: an ABI value (see
+	// abi.Type.Encode) for each ABI type argument, a TransactionWithSigner for
+	// each transaction argument, a types.Address for each account reference
+	// and a uint64 ID for each asset or application reference
+	MethodArgs []interface{}
+
+	// Sender is the sender of the application call
+	Sender types.Address
+
+	// SuggestedParams are the parameters of the application call, typically
+	// fetched from algod
+	SuggestedParams types.SuggestedParams
+
+	// OnComplete is the action taken after the call
+	OnComplete types.OnCompletion
+
+	// ApprovalProgram and ClearProgram are the programs of an application
+	// being created or updated
+	ApprovalProgram []byte
+	ClearProgram    []byte
+
+	// GlobalSchema, LocalSchema and ExtraPages size an application being created
+	GlobalSchema types.StateSchema
+	LocalSchema  types.StateSchema
+	ExtraPages   uint32
+
+	// Note and Lease are set on the application call
+	Note  []byte
+	Lease [32]byte
+
+	// RekeyTo, if nonzero, rekeys the sender to this address
+	RekeyTo types.Address
+
+	// ForeignAccounts, ForeignApps and ForeignAssets are made available to
+	// the application in addition to those passed as reference arguments
+	ForeignAccounts []types.Address
+	ForeignApps     []uint64
+	ForeignAssets   []uint64
+
+	// BoxReferences are the boxes the application may access; the
+	// applications of the boxes are added to the foreign apps if missing
+	BoxReferences []types.AppBoxReference
+
+	// Signer signs the application call
+	Signer TransactionSigner
+}
+
+// ABIMethodResult is the result of a method call in an executed group
+type ABIMethodResult struct {
+	// TxID is the ID of the application call
+	TxID string
+
+	// TxInfo is the confirmed application call, as reported by algod
+	TxInfo models.PendingTransactionInfoResponse
+
+	// Method is the method that was called
+	Method abi.Method
+
+	// RawReturnValue is the encoded return value, without the return prefix
+	RawReturnValue []byte
+
+	// ReturnValue is the decoded return value, or nil for void methods
+	ReturnValue interface{}
+
+	// DecodeError is set if the return value could not be found or decoded
+	DecodeError error
+}
+
+// ExecuteResult is returned by AtomicTransactionComposer.Execute
+type ExecuteResult struct {
+	// ConfirmedRound is the round the group was committed in
+	ConfirmedRound uint64
+
+	// TxIDs are the IDs of the transactions of the group, in order
+	TxIDs []string
+
+	// MethodResults are the results of the group's method calls, in order
+	MethodResults []ABIMethodResult
+}
+
+// SimulateResult is returned by AtomicTransactionComposer.Simulate
+type SimulateResult struct {
+	// SimulateResponse is the response of algod's simulate endpoint
+	SimulateResponse models.SimulateResponse
+
+	// GroupResult is the result of the group, including the failure message
+	// and budget used, and the result and trace of each transaction
+	GroupResult models.SimulateTransactionGroupResult
+
+	// TxIDs are the IDs of the transactions of the group, in order
+	TxIDs []string
+
+	// MethodResults are the results the group's method calls would have, in order
+	MethodResults []ABIMethodResult
+}
+
+// AtomicTransactionComposer collects transactions and ABI method calls, each
+// with its own signer, into an atomic group, then signs and submits the group
+// and decodes the values returned by the method calls.
+//
+// A composer moves through the Building, Built, Signed, Submitted and Committed
+// states; once it has left Building no more transactions can be added. Use
+// Clone to reuse the transactions of a composer in a new group.
+type AtomicTransactionComposer struct {
+	status AtomicTransactionComposerStatus
+	txList []TransactionWithSigner
+	signed [][]byte
+	txIDs  []string
+
+	// methodMap maps the index of each method call in txList to its method
+	methodMap map[int]abi.Method
+}
+
+// GetStatus returns the status of the composer
+func (atc *AtomicTransactionComposer) GetStatus() AtomicTransactionComposerStatus {
+	return atc.status
+}
+
+// Count returns the number of transactions currently in the composer
+func (atc *AtomicTransactionComposer) Count() int {
+	return len(atc.txList)
+}
+
+// Clone creates a new composer in the Building state holding the same
+// transactions and signers, with their group IDs cleared
+func (atc *AtomicTransactionComposer) Clone() AtomicTransactionComposer {
+	newTxList := make([]TransactionWithSigner, len(atc.txList))
+	copy(newTxList, atc.txList)
+	for i := range newTxList {
+		newTxList[i].Txn.Group = types.Digest{}
+	}
+	newMethodMap := make(map[int]abi.Method, len(atc.methodMap))
+	for i, method := range atc.methodMap {
+		newMethodMap[i] = method
+	}
+	return AtomicTransactionComposer{
+		status:    Building,
+		txList:    newTxList,
+		methodMap: newMethodMap,
+	}
+}
+
+// AddTransaction adds a transaction and its signer to the group. The
+// transaction must not already belong to a group.
+func (atc *AtomicTransactionComposer) AddTransaction(txnAndSigner TransactionWithSigner) error {
+	if atc.status != Building {
+		return errorf(ErrComposerStatus, "status must be Building in order to add transactions")
+	}
+	if atc.Count() == types.MaxTxGroupSize {
+		return errorf(transaction.ErrGroupTooLarge, "reached max group size: %d", types.MaxTxGroupSize)
+	}
+	if txnAndSigner.Txn.Group != (types.Digest{}) {
+		return errorf(transaction.ErrAlreadyGrouped, "cannot add a transaction with nonzero group ID")
+	}
+	if txnAndSigner.Signer == nil {
+		return errorf(ErrMissingSigner, "transaction must have a signer")
+	}
+
+	atc.txList = append(atc.txList, txnAndSigner)
+	return nil
+}
+
+// AddMethodCall adds an application call of an ABI method to the group,
+// preceded by the transactions passed as its transaction arguments
+func (atc *AtomicTransactionComposer) AddMethodCall(params AddMethodCallParams) error {
+	if atc.status != Building {
+		return errorf(ErrComposerStatus, "status must be Building in order to add transactions")
+	}
+	if len(params.MethodArgs) != len(params.Method.Args) {
+		return errorf(ErrInvalidMethodArgs, "method %s takes %d arguments, got %d", params.Method.Name, len(params.Method.Args), len(params.MethodArgs))
+	}
+	if atc.Count()+params.Method.GetTxCount() > types.MaxTxGroupSize {
+		return errorf(transaction.ErrGroupTooLarge, "reached max group size: %d", types.MaxTxGroupSize)
+	}
+	if params.AppID == 0 {
+		if len(params.ApprovalProgram) == 0 || len(params.ClearProgram) == 0 {
+			return errorf(transaction.ErrInvalidApplicationCall, "approval and clear programs are required to create an application")
+		}
+	} else if params.OnComplete == types.UpdateApplicationOC {
+		if len(params.ApprovalProgram) == 0 || len(params.ClearProgram) == 0 {
+			return errorf(transaction.ErrInvalidApplicationCall, "approval and clear programs are required to update an application")
+		}
+	} else if len(params.ApprovalProgram) != 0 || len(params.ClearProgram) != 0 {
+		return errorf(transaction.ErrInvalidApplicationCall, "approval and clear programs are only set when creating or updating an application")
+	}
+	if params.Signer == nil {
+		return errorf(ErrMissingSigner, "transaction must have a signer")
+	}
+
+	var txArgs []TransactionWithSigner
+	var abiTypes []abi.Type
+	var abiValues []interface{}
+	accounts := append([]types.Address{}, params.ForeignAccounts...)
+	apps := make([]types.AppIndex, len(params.ForeignApps))
+	for i, app := range params.ForeignApps {
+		apps[i] = types.AppIndex(app)
+	}
+	assets := make([]types.AssetIndex, len(params.ForeignAssets))
+	for i, asset := range params.ForeignAssets {
+		assets[i] = types.AssetIndex(asset)
+	}
+
+	for i, arg := range params.Method.Args {
+		value := params.MethodArgs[i]
+		switch {
+		case abi.IsTransactionType(arg.Type):
+			txArg, ok := value.(TransactionWithSigner)
+			if !ok {
+				return errorf(ErrInvalidMethodArgs, "argument %d must be a TransactionWithSigner, got %T", i, value)
+			}
+			if arg.Type != abi.AnyTransactionType && string(txArg.Txn.Type) != arg.Type {
+				return errorf(ErrInvalidMethodArgs, "argument %d must be a %s transaction, got %s", i, arg.Type, txArg.Txn.Type)
+			}
+			if txArg.Txn.Group != (types.Digest{}) {
+				return errorf(transaction.ErrAlreadyGrouped, "cannot add a transaction with nonzero group ID")
+			}
+			if txArg.Signer == nil {
+				return errorf(ErrMissingSigner, "transaction argument %d must have a signer", i)
+			}
+			txArgs = append(txArgs, txArg)
+		case arg.Type == abi.AccountReferenceType:
+			addr, ok := value.(types.Address)
+			if !ok {
+				return errorf(ErrInvalidMethodArgs, "argument %d must be a types.Address, got %T", i, value)
+			}
+			index := 0
+			if addr != params.Sender {
+				index = findOrAppendAccount(&accounts, addr) + 1
+			}
+			abiTypes = append(abiTypes, mustUint8Type())
+			abiValues = append(abiValues, uint8(index))
+		case arg.Type == abi.AssetReferenceType:
+			id, ok := value.(uint64)
+			if !ok {
+				return errorf(ErrInvalidMethodArgs, "argument %d must be a uint64 asset ID, got %T", i, value)
+			}
+			index := findOrAppendAsset(&assets, types.AssetIndex(id))
+			abiTypes = append(abiTypes, mustUint8Type())
+			abiValues = append(abiValues, uint8(index))
+		case arg.Type == abi.ApplicationReferenceType:
+			id, ok := value.(uint64)
+			if !ok {
+				return errorf(ErrInvalidMethodArgs, "argument %d must be a uint64 application ID, got %T", i, value)
+			}
+			index := 0
+			if id != params.AppID {
+				index = findOrAppendApp(&apps, types.AppIndex(id)) + 1
+			}
+			abiTypes = append(abiTypes, mustUint8Type())
+			abiValues = append(abiValues, uint8(index))
+		default:
+			abiType, err := abi.TypeOf(arg.Type)
+			if err != nil {
+				return err
+			}
+			abiTypes = append(abiTypes, abiType)
+			abiValues = append(abiValues, value)
+		}
+	}
+	if len(accounts) > 255 || len(apps) > 255 || len(assets) > 256 {
+		return errorf(ErrInvalidMethodArgs, "too many foreign references to encode as arguments")
+	}
+
+	// arguments beyond the 15th are packed into a tuple in the last argument
+	if len(abiTypes) > maxAppArgs-1 {
+		tupleType, err := abi.MakeTupleType(abiTypes[maxAppArgs-2:])
+		if err != nil {
+			return err
+		}
+		tupleValues := append([]interface{}{}, abiValues[maxAppArgs-2:]...)
+		abiTypes = append(abiTypes[:maxAppArgs-2], tupleType)
+		abiValues = append(abiValues[:maxAppArgs-2], tupleValues)
+	}
+
+	appArgs := [][]byte{params.Method.GetSelector()}
+	for i, abiType := range abiTypes {
+		encoded, err := abiType.Encode(abiValues[i])
+		if err != nil {
+			return errorf(ErrInvalidMethodArgs, "could not encode argument %d of %s: %v", i, params.Method.Name, err)
+		}
+		appArgs = append(appArgs, encoded)
+	}
+
+	tx, err := makeAppCallTxn(params, appArgs, accounts, apps, assets)
+	if err != nil {
+		return err
+	}
+
+	atc.txList = append(atc.txList, txArgs...)
+	atc.txList = append(atc.txList, TransactionWithSigner{Txn: tx, Signer: params.Signer})
+	if atc.methodMap == nil {
+		atc.methodMap = make(map[int]abi.Method)
+	}
+	atc.methodMap[len(atc.txList)-1] = params.Method
+	return nil
+}
+
+// BuildGroup finalizes the group, assigning its group ID if there is more than
+// one transaction, and returns the transactions with their signers
+func (atc *AtomicTransactionComposer) BuildGroup() ([]TransactionWithSigner, error) {
+	if atc.status > Building {
+		return atc.txList, nil
+	}
+	if atc.Count() == 0 {
+		return nil, errorf(ErrEmptyGroup, "attempting to build group with zero transactions")
+	}
+
+	if atc.Count() > 1 {
+		txns := make([]types.Transaction, len(atc.txList))
+		for i, txnAndSigner := range atc.txList {
+			txns[i] = txnAndSigner.Txn
+		}
+		gid, err := crypto.ComputeGroupID(txns)
+		if err != nil {
+			return nil, err
+		}
+		for i := range atc.txList {
+			atc.txList[i].Txn.Group = gid
+		}
+	}
+
+	atc.status = Built
+	return atc.txList, nil
+}
+
+// GatherSignatures builds the group if needed and obtains a signature for each
+// transaction, returning the encoded signed transactions in group order.
+// Transactions that share a signer are signed by it in a single call.
+func (atc *AtomicTransactionComposer) GatherSignatures(ctx context.Context) ([][]byte, error) {
+	if atc.status >= Signed {
+		return atc.signed, nil
+	}
+
+	txList, err := atc.BuildGroup()
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make([]types.Transaction, len(txList))
+	for i, txnAndSigner := range txList {
+		txns[i] = txnAndSigner.Txn
+	}
+
+	// group the indexes of the transactions by signer, keeping group order
+	var signers []TransactionSigner
+	var indexes [][]int
+	for i, txnAndSigner := range txList {
+		found := false
+		for j, signer := range signers {
+			if signer.Equals(txnAndSigner.Signer) {
+				indexes[j] = append(indexes[j], i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			signers = append(signers, txnAndSigner.Signer)
+			indexes = append(indexes, []int{i})
+		}
+	}
+
+	signed := make([][]byte, len(txList))
+	for j, signer := range signers {
+		stxs, err := signer.SignTransactions(ctx, txns, indexes[j])
+		if err != nil {
+			return nil, err
+		}
+		if len(stxs) != len(indexes[j]) {
+			return nil, errorf(ErrMissingSignature, "signer returned %d signed transactions for %d transactions", len(stxs), len(indexes[j]))
+		}
+		for k, pos := range indexes[j] {
+			signed[pos] = stxs[k]
+		}
+	}
+
+	for i, stx := range signed {
+		if len(stx) == 0 {
+			return nil, errorf(ErrMissingSignature, "missing signature for transaction %d", i)
+		}
+	}
+
+	txIDs := make([]string, len(txns))
+	for i, txn := range txns {
+		txIDs[i] = crypto.GetTxID(txn)
+	}
+
+	atc.signed = signed
+	atc.txIDs = txIDs
+	atc.status = Signed
+	return signed, nil
+}
+
+// Submit signs the group if needed and sends it to the network without waiting
+// for it to be confirmed. It returns the IDs of the transactions in the group.
+func (atc *AtomicTransactionComposer) Submit(ctx context.Context, client client.AlgodClient, headers ...*common.Header) ([]string, error) {
+	if atc.status > Submitted {
+		return nil, errorf(ErrComposerStatus, "status must be Submitted or lower in order to call Submit")
+	}
+	if atc.status == Submitted {
+		return atc.txIDs, nil
+	}
+
+	signed, err := atc.GatherSignatures(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = client.SendRawTransaction(bytes.Join(signed, nil)).Do(ctx, headers...)
+	if err != nil {
+		return nil, err
+	}
+
+	atc.status = Submitted
+	return atc.txIDs, nil
+}
+
+// Execute submits the group and waits up to waitRounds rounds for it to be
+// confirmed; a waitRounds of 0 waits until ctx is done. The transactions of a
+// group are committed together, so the result holds a single confirmed round.
+func (atc *AtomicTransactionComposer) Execute(ctx context.Context, client client.AlgodClient, waitRounds uint64, headers ...*common.Header) (ExecuteResult, error) {
+	if atc.status == Committed {
+		return ExecuteResult{}, errorf(ErrComposerStatus, "group has already been executed")
+	}
+
+	txIDs, err := atc.Submit(ctx, client, headers...)
+	if err != nil {
+		return ExecuteResult{}, err
+	}
+
+	txInfo, err := WaitForConfirmation(ctx, client, txIDs[0], waitRounds, headers...)
+	if err != nil {
+		return ExecuteResult{}, err
+	}
+
+	atc.status = Committed
+	result := ExecuteResult{
+		ConfirmedRound: txInfo.ConfirmedRound,
+		TxIDs:          txIDs,
+	}
+
+	for i := range atc.txList {
+		method, ok := atc.methodMap[i]
+		if !ok {
+			continue
+		}
+		txInfo, _, err := client.PendingTransactionInformation(txIDs[i]).Do(ctx, headers...)
+		if err != nil {
+			return result, err
+		}
+		result.MethodResults = append(result.MethodResults, makeMethodResult(method, txIDs[i], txInfo))
+	}
+	return result, nil
+}
+
+// Simulate signs the group if needed and simulates it with algod, without
+// submitting it, decoding the values the method calls would return. The
+// group is built and, when signed, keeps its signatures, so it can still be
+// submitted afterwards.
+//
+// request sets the simulation options, such as ExecTraceConfig; its
+// TxnGroups are replaced by the group. If request.AllowEmptySignatures is set
+// and the group is not yet signed, it is simulated without signatures.
+//
+// A group that would be rejected is not an error: the group result's
+// FailureMessage and FailedAt say why and where it failed.
+func (atc *AtomicTransactionComposer) Simulate(ctx context.Context, client client.AlgodClient, request models.SimulateRequest, headers ...*common.Header) (SimulateResult, error) {
+	if atc.status > Submitted {
+		return SimulateResult{}, errorf(ErrComposerStatus, "status must be Submitted or lower in order to call Simulate")
+	}
+
+	var stxs []types.SignedTxn
+	if request.AllowEmptySignatures && atc.status < Signed {
+		txList, err := atc.BuildGroup()
+		if err != nil {
+			return SimulateResult{}, err
+		}
+		for _, txnAndSigner := range txList {
+			stxs = append(stxs, types.SignedTxn{Txn: txnAndSigner.Txn})
+		}
+	} else {
+		signed, err := atc.GatherSignatures(ctx)
+		if err != nil {
+			return SimulateResult{}, err
+		}
+		for _, encoded := range signed {
+			var stx types.SignedTxn
+			if err := msgpack.Decode(encoded, &stx); err != nil {
+				return SimulateResult{}, err
+			}
+			stxs = append(stxs, stx)
+		}
+	}
+
+	request.TxnGroups = []models.SimulateRequestTransactionGroup{{Txns: stxs}}
+	response, err := client.SimulateTransaction(request).Do(ctx, headers...)
+	if err != nil {
+		return SimulateResult{}, err
+	}
+	if len(response.TxnGroups) != 1 || len(response.TxnGroups[0].TxnResults) != len(stxs) {
+		return SimulateResult{}, errorf(ErrUnexpectedResponse, "simulate response does not match the group")
+	}
+
+	result := SimulateResult{
+		SimulateResponse: response,
+		GroupResult:      response.TxnGroups[0],
+	}
+	for i, stx := range stxs {
+		txID := crypto.GetTxID(stx.Txn)
+		result.TxIDs = append(result.TxIDs, txID)
+		method, ok := atc.methodMap[i]
+		if !ok {
+			continue
+		}
+		result.MethodResults = append(result.MethodResults, makeMethodResult(method, txID, result.GroupResult.TxnResults[i].TxnResult))
+	}
+	return result, nil
+}
+
+// makeMethodResult decodes the return value of a method call from its logs
+func makeMethodResult(method abi.Method, txID string, txInfo models.PendingTransactionInfoResponse) ABIMethodResult {
+	methodResult := ABIMethodResult{TxID: txID, TxInfo: txInfo, Method: method}
+	if method.Returns.Type != abi.VoidReturnType {
+		methodResult.RawReturnValue, methodResult.ReturnValue, methodResult.DecodeError = decodeReturnValue(method, txInfo.Logs)
+	}
+	return methodResult
+}
+
+// makeAppCallTxn builds an application call from params with the given
+// arguments and foreign arrays, setting its fee from params.SuggestedParams
+func makeAppCallTxn(params AddMethodCallParams, appArgs [][]byte, accounts []types.Address, apps []types.AppIndex, assets []types.AssetIndex) (types.Transaction, error) {
+	for _, box := range params.BoxReferences {
+		if box.AppID != 0 && box.AppID != params.AppID {
+			findOrAppendApp(&apps, types.AppIndex(box.AppID))
+		}
+	}
+	boxes, err := transaction.ResolveBoxReferences(params.BoxReferences, apps, types.AppIndex(params.AppID))
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	var gh types.Digest
+	copy(gh[:], params.SuggestedParams.GenesisHash)
+	tx := types.Transaction{
+		Type: types.ApplicationCallTx,
+		Header: types.Header{
+			Sender:      params.Sender,
+			FirstValid:  params.SuggestedParams.FirstRoundValid,
+			LastValid:   params.SuggestedParams.LastRoundValid,
+			Note:        params.Note,
+			GenesisID:   params.SuggestedParams.GenesisID,
+			GenesisHash: gh,
+			Lease:       params.Lease,
+			RekeyTo:     params.RekeyTo,
+		},
+		ApplicationFields: types.ApplicationFields{
+			ApplicationID:     types.AppIndex(params.AppID),
+			OnCompletion:      params.OnComplete,
+			ApplicationArgs:   appArgs,
+			Accounts:          accounts,
+			ForeignApps:       apps,
+			ForeignAssets:     assets,
+			BoxReferences:     boxes,
+			ApprovalProgram:   params.ApprovalProgram,
+			ClearStateProgram: params.ClearProgram,
+			ExtraProgramPages: params.ExtraPages,
+		},
+	}
+	if params.AppID == 0 {
+		tx.GlobalStateSchema = params.GlobalSchema
+		tx.LocalStateSchema = params.LocalSchema
+	}
+	if err := transaction.SetFee(&tx, params.SuggestedParams); err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, nil
+}
+
+// decodeReturnValue finds a method's return value in the last log of its
+// application call and decodes it
+func decodeReturnValue(method abi.Method, logs [][]byte) (raw []byte, value interface{}, err error) {
+	if len(logs) == 0 {
+		return nil, nil, errorf(ErrNoReturnValue, "method %s has no return value logged", method.Name)
+	}
+	lastLog := logs[len(logs)-1]
+	if !bytes.HasPrefix(lastLog, abiReturnHash) {
+		return nil, nil, errorf(ErrNoReturnValue, "last log of method %s is not a return value", method.Name)
+	}
+	raw = lastLog[len(abiReturnHash):]
+	returnType, err := abi.TypeOf(method.Returns.Type)
+	if err != nil {
+		return raw, nil, err
+	}
+	value, err = returnType.Decode(raw)
+	return raw, value, err
+}
+
+func mustUint8Type() abi.Type {
+	uint8Type, err := abi.MakeUintType(8)
+	if err != nil {
+		panic(err)
+	}
+	return uint8Type
+}
+
+// findOrAppendAccount returns the index of addr in accounts, appending it if missing
+func findOrAppendAccount(accounts *[]types.Address, addr types.Address) int {
+	for i, account := range *accounts {
+		if account == addr {
+			return i
+		}
+	}
+	*accounts = append(*accounts, addr)
+	return len(*accounts) - 1
+}
+
+// findOrAppendApp returns the index of id in apps, appending it if missing
+func findOrAppendApp(apps *[]types.AppIndex, id types.AppIndex) int {
+	for i, app := range *apps {
+		if app == id {
+			return i
+		}
+	}
+	*apps = append(*apps, id)
+	return len(*apps) - 1
+}
+
+// findOrAppendAsset returns the index of id in assets, appending it if missing
+func findOrAppendAsset(assets *[]types.AssetIndex, id types.AssetIndex) int {
+	for i, asset := range *assets {
+		if asset == id {
+			return i
+		}
+	}
+	*assets = append(*assets, id)
+	return len(*assets) - 1
+}
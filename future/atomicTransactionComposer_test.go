@@ -0,0 +1,441 @@
+package future
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/abi"
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func makeTestPayment(t *testing.T, from, to types.Address, amount uint64) types.Transaction {
+	genesisHash := make([]byte, 32)
+	txn, err := transaction.MakePaymentTxnWithFlatFee(from.String(), to.String(), 1000, amount, 100, 1100, nil, "", "testnet-v1.0", genesisHash)
+	require.NoError(t, err)
+	return txn
+}
+
+func TestAtomicTransactionComposerGatherSignatures(t *testing.T) {
+	acct1 := crypto.GenerateAccount()
+	acct2 := crypto.GenerateAccount()
+	signer1 := BasicAccountTransactionSigner{Account: acct1}
+	signer2 := BasicAccountTransactionSigner{Account: acct2}
+
+	var atc AtomicTransactionComposer
+	require.Equal(t, Building, atc.GetStatus())
+	txns := []types.Transaction{
+		makeTestPayment(t, acct1.Address, acct2.Address, 1),
+		makeTestPayment(t, acct2.Address, acct1.Address, 2),
+		makeTestPayment(t, acct1.Address, acct2.Address, 3),
+	}
+	for i, txn := range txns {
+		signer := TransactionSigner(signer1)
+		if i == 1 {
+			signer = signer2
+		}
+		require.NoError(t, atc.AddTransaction(TransactionWithSigner{Txn: txn, Signer: signer}))
+	}
+	require.Equal(t, 3, atc.Count())
+
+	gid, err := crypto.ComputeGroupID(txns)
+	require.NoError(t, err)
+
+	signed, err := atc.GatherSignatures(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, Signed, atc.GetStatus())
+	require.Len(t, signed, 3)
+	for i, stxBytes := range signed {
+		var stx types.SignedTxn
+		require.NoError(t, msgpack.Decode(stxBytes, &stx))
+		require.Equal(t, gid, stx.Txn.Group)
+		require.Equal(t, txns[i].Amount, stx.Txn.Amount)
+		pk := ed25519.PublicKey(stx.Txn.Sender[:])
+		require.True(t, ed25519.Verify(pk, append([]byte("TX"), msgpack.Encode(stx.Txn)...), stx.Sig[:]))
+	}
+
+	err = atc.AddTransaction(TransactionWithSigner{Txn: txns[0], Signer: signer1})
+	require.Equal(t, ErrComposerStatus, errorKind(err))
+
+	clone := atc.Clone()
+	require.Equal(t, Building, clone.GetStatus())
+	require.Equal(t, 3, clone.Count())
+	require.NoError(t, clone.AddTransaction(TransactionWithSigner{Txn: txns[0], Signer: signer1}))
+}
+
+// errorKind returns the kind an error unwraps to, or nil
+func errorKind(err error) error {
+	if kinded, ok := err.(interface{ Unwrap() error }); ok {
+		return kinded.Unwrap()
+	}
+	return nil
+}
+
+func TestAtomicTransactionComposerChecks(t *testing.T) {
+	acct := crypto.GenerateAccount()
+	signer := BasicAccountTransactionSigner{Account: acct}
+	txn := makeTestPayment(t, acct.Address, acct.Address, 0)
+
+	var atc AtomicTransactionComposer
+	_, err := atc.BuildGroup()
+	require.Equal(t, ErrEmptyGroup, errorKind(err))
+
+	err = atc.AddTransaction(TransactionWithSigner{Txn: txn})
+	require.Equal(t, ErrMissingSigner, errorKind(err))
+
+	grouped := txn
+	grouped.Group = types.Digest{1}
+	err = atc.AddTransaction(TransactionWithSigner{Txn: grouped, Signer: signer})
+	require.Equal(t, transaction.ErrAlreadyGrouped, errorKind(err))
+
+	for i := 0; i < types.MaxTxGroupSize; i++ {
+		require.NoError(t, atc.AddTransaction(TransactionWithSigner{Txn: txn, Signer: signer}))
+	}
+	err = atc.AddTransaction(TransactionWithSigner{Txn: txn, Signer: signer})
+	require.Equal(t, transaction.ErrGroupTooLarge, errorKind(err))
+
+	// a single transaction is not grouped
+	var single AtomicTransactionComposer
+	require.NoError(t, single.AddTransaction(TransactionWithSigner{Txn: txn, Signer: signer}))
+	txList, err := single.BuildGroup()
+	require.NoError(t, err)
+	require.Equal(t, types.Digest{}, txList[0].Txn.Group)
+}
+
+func TestMultiSigAccountTransactionSigner(t *testing.T) {
+	accts := []crypto.Account{crypto.GenerateAccount(), crypto.GenerateAccount(), crypto.GenerateAccount()}
+	ma, err := crypto.MultisigAccountWithParams(1, 2, []types.Address{accts[0].Address, accts[1].Address, accts[2].Address})
+	require.NoError(t, err)
+	from, err := ma.Address()
+	require.NoError(t, err)
+
+	signer := MultiSigAccountTransactionSigner{Msig: ma, Sks: []crypto.SecretKey{accts[0].PrivateKey, accts[2].PrivateKey}}
+	require.True(t, signer.Equals(MultiSigAccountTransactionSigner{Msig: ma, Sks: []crypto.SecretKey{accts[0].PrivateKey, accts[2].PrivateKey}}))
+	require.False(t, signer.Equals(MultiSigAccountTransactionSigner{Msig: ma, Sks: []crypto.SecretKey{accts[0].PrivateKey}}))
+
+	txn := makeTestPayment(t, from, accts[0].Address, 10)
+	stxs, err := signer.SignTransactions(context.Background(), []types.Transaction{txn}, []int{0})
+	require.NoError(t, err)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxs[0], &stx))
+	require.True(t, crypto.VerifyMultisig(from, append([]byte("TX"), msgpack.Encode(txn)...), stx.Msig))
+}
+
+func TestAtomicTransactionComposerExecute(t *testing.T) {
+	acct := crypto.GenerateAccount()
+	signer := BasicAccountTransactionSigner{Account: acct}
+
+	var atc AtomicTransactionComposer
+	txns := []types.Transaction{
+		makeTestPayment(t, acct.Address, acct.Address, 1),
+		makeTestPayment(t, acct.Address, acct.Address, 2),
+	}
+	for _, txn := range txns {
+		require.NoError(t, atc.AddTransaction(TransactionWithSigner{Txn: txn, Signer: signer}))
+	}
+	txList, err := atc.BuildGroup()
+	require.NoError(t, err)
+	firstID := crypto.GetTxID(txList[0].Txn)
+
+	var posted []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/transactions":
+			posted, _ = ioutil.ReadAll(r.Body)
+			w.Write([]byte(`{"txId":"` + firstID + `"}`))
+		case r.URL.Path == "/v2/status":
+			w.Write([]byte(`{"last-round":10}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/status/wait-for-block-after/"):
+			w.Write([]byte(`{"last-round":11}`))
+		case r.URL.Path == "/v2/transactions/pending/"+firstID:
+			w.Write(msgpack.Encode(map[string]interface{}{"confirmed-round": uint64(11), "pool-error": ""}))
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	result, err := atc.Execute(context.Background(), client, 5)
+	require.NoError(t, err)
+	require.Equal(t, Committed, atc.GetStatus())
+	require.Equal(t, uint64(11), result.ConfirmedRound)
+	require.Equal(t, []string{firstID, crypto.GetTxID(txList[1].Txn)}, result.TxIDs)
+
+	signed, err := atc.GatherSignatures(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, append(append([]byte{}, signed[0]...), signed[1]...), posted)
+
+	_, err = atc.Execute(context.Background(), client, 5)
+	require.Error(t, err)
+}
+
+func makeTestSuggestedParams() types.SuggestedParams {
+	return types.SuggestedParams{
+		Fee:             0,
+		GenesisID:       "testnet-v1.0",
+		GenesisHash:     make([]byte, 32),
+		FirstRoundValid: 100,
+		LastRoundValid:  1100,
+		MinFee:          1000,
+	}
+}
+
+func TestAddMethodCall(t *testing.T) {
+	acct := crypto.GenerateAccount()
+	other := crypto.GenerateAccount()
+	signer := BasicAccountTransactionSigner{Account: acct}
+
+	method, err := abi.MethodFromSignature("transfer(pay,account,account,asset,application,application,(uint64,bool),string)uint64")
+	require.NoError(t, err)
+	payment := TransactionWithSigner{Txn: makeTestPayment(t, acct.Address, other.Address, 5), Signer: signer}
+
+	var atc AtomicTransactionComposer
+	err = atc.AddMethodCall(AddMethodCallParams{
+		AppID:           10,
+		Method:          method,
+		MethodArgs:      []interface{}{payment, acct.Address, other.Address, uint64(7), uint64(10), uint64(11), []interface{}{uint64(3), true}, "hi"},
+		Sender:          acct.Address,
+		SuggestedParams: makeTestSuggestedParams(),
+		ForeignAssets:   []uint64{6},
+		Signer:          signer,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, atc.Count())
+
+	txList, err := atc.BuildGroup()
+	require.NoError(t, err)
+	require.Equal(t, types.PaymentTx, txList[0].Txn.Type)
+	appCall := txList[1].Txn
+	require.Equal(t, types.ApplicationCallTx, appCall.Type)
+	require.Equal(t, types.AppIndex(10), appCall.ApplicationID)
+	require.Equal(t, types.MicroAlgos(1000), appCall.Fee)
+	require.Equal(t, []types.Address{other.Address}, appCall.Accounts)
+	require.Equal(t, []types.AssetIndex{6, 7}, appCall.ForeignAssets)
+	require.Equal(t, []types.AppIndex{11}, appCall.ForeignApps)
+	require.Equal(t, [][]byte{
+		method.GetSelector(),
+		{0}, {1}, {1}, {0}, {1},
+		{0, 0, 0, 0, 0, 0, 0, 3, 0x80},
+		{0, 2, 'h', 'i'},
+	}, appCall.ApplicationArgs)
+
+	// transaction arguments must match their type
+	var bad AtomicTransactionComposer
+	method, err = abi.MethodFromSignature("deposit(axfer)void")
+	require.NoError(t, err)
+	err = bad.AddMethodCall(AddMethodCallParams{AppID: 10, Method: method, MethodArgs: []interface{}{payment}, Sender: acct.Address, SuggestedParams: makeTestSuggestedParams(), Signer: signer})
+	require.Error(t, err)
+	err = bad.AddMethodCall(AddMethodCallParams{AppID: 10, Method: method, MethodArgs: []interface{}{}, Sender: acct.Address, SuggestedParams: makeTestSuggestedParams(), Signer: signer})
+	require.Error(t, err)
+	// creating an application needs programs
+	method, err = abi.MethodFromSignature("create()void")
+	require.NoError(t, err)
+	err = bad.AddMethodCall(AddMethodCallParams{Method: method, MethodArgs: []interface{}{}, Sender: acct.Address, SuggestedParams: makeTestSuggestedParams(), Signer: signer})
+	require.Error(t, err)
+}
+
+func TestAddMethodCallBoxes(t *testing.T) {
+	acct := crypto.GenerateAccount()
+	signer := BasicAccountTransactionSigner{Account: acct}
+	method, err := abi.MethodFromSignature("put(application)void")
+	require.NoError(t, err)
+
+	var atc AtomicTransactionComposer
+	err = atc.AddMethodCall(AddMethodCallParams{
+		AppID:           10,
+		Method:          method,
+		MethodArgs:      []interface{}{uint64(11)},
+		Sender:          acct.Address,
+		SuggestedParams: makeTestSuggestedParams(),
+		BoxReferences: []types.AppBoxReference{
+			{AppID: 10, Name: []byte("a")},
+			{AppID: 0, Name: []byte("b")},
+			{AppID: 11, Name: []byte("c")},
+			{AppID: 12, Name: []byte("d")},
+		},
+		Signer: signer,
+	})
+	require.NoError(t, err)
+	appCall := atc.txList[0].Txn
+	// the application of a box is added to the foreign apps if missing
+	require.Equal(t, []types.AppIndex{11, 12}, appCall.ForeignApps)
+	require.Equal(t, []types.BoxReference{
+		{ForeignAppIdx: 0, Name: []byte("a")},
+		{ForeignAppIdx: 0, Name: []byte("b")},
+		{ForeignAppIdx: 1, Name: []byte("c")},
+		{ForeignAppIdx: 2, Name: []byte("d")},
+	}, appCall.BoxReferences)
+}
+
+func TestAddMethodCallPacksArgs(t *testing.T) {
+	acct := crypto.GenerateAccount()
+	argTypes := make([]string, 17)
+	args := make([]interface{}, 17)
+	for i := range argTypes {
+		argTypes[i] = "uint8"
+		args[i] = uint8(i)
+	}
+	method, err := abi.MethodFromSignature("many(" + strings.Join(argTypes, ",") + ")void")
+	require.NoError(t, err)
+
+	var atc AtomicTransactionComposer
+	err = atc.AddMethodCall(AddMethodCallParams{
+		AppID:           1,
+		Method:          method,
+		MethodArgs:      args,
+		Sender:          acct.Address,
+		SuggestedParams: makeTestSuggestedParams(),
+		Signer:          BasicAccountTransactionSigner{Account: acct},
+	})
+	require.NoError(t, err)
+	txList, err := atc.BuildGroup()
+	require.NoError(t, err)
+	appArgs := txList[0].Txn.ApplicationArgs
+	require.Len(t, appArgs, 16)
+	require.Equal(t, []byte{13}, appArgs[14])
+	require.Equal(t, []byte{14, 15, 16}, appArgs[15])
+}
+
+func TestExecuteMethodCall(t *testing.T) {
+	acct := crypto.GenerateAccount()
+	method, err := abi.MethodFromSignature("add(uint64,uint64)uint128")
+	require.NoError(t, err)
+
+	var atc AtomicTransactionComposer
+	err = atc.AddMethodCall(AddMethodCallParams{
+		AppID:           1,
+		Method:          method,
+		MethodArgs:      []interface{}{uint64(1), uint64(2)},
+		Sender:          acct.Address,
+		SuggestedParams: makeTestSuggestedParams(),
+		Signer:          BasicAccountTransactionSigner{Account: acct},
+	})
+	require.NoError(t, err)
+	txList, err := atc.BuildGroup()
+	require.NoError(t, err)
+	txID := crypto.GetTxID(txList[0].Txn)
+
+	returnLog := append([]byte{0x15, 0x1f, 0x7c, 0x75}, make([]byte, 16)...)
+	returnLog[len(returnLog)-1] = 3
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/transactions":
+			w.Write([]byte(`{"txId":"` + txID + `"}`))
+		case "/v2/status":
+			w.Write([]byte(`{"last-round":10}`))
+		case "/v2/transactions/pending/" + txID:
+			w.Write(msgpack.Encode(map[string]interface{}{
+				"confirmed-round": uint64(11),
+				"pool-error":      "",
+				"logs":            [][]byte{[]byte("debug"), returnLog},
+			}))
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	result, err := atc.Execute(context.Background(), client, 5)
+	require.NoError(t, err)
+	require.Len(t, result.MethodResults, 1)
+	methodResult := result.MethodResults[0]
+	require.NoError(t, methodResult.DecodeError)
+	require.Equal(t, txID, methodResult.TxID)
+	require.Equal(t, returnLog[4:], methodResult.RawReturnValue)
+	require.Equal(t, big.NewInt(3), methodResult.ReturnValue)
+
+	_, _, err = decodeReturnValue(method, [][]byte{returnLog, []byte("debug")})
+	require.Error(t, err)
+}
+
+func TestSimulateMethodCall(t *testing.T) {
+	acct := crypto.GenerateAccount()
+	method, err := abi.MethodFromSignature("add(uint64,uint64)uint128")
+	require.NoError(t, err)
+
+	var atc AtomicTransactionComposer
+	err = atc.AddTransaction(TransactionWithSigner{
+		Txn:    makeTestPayment(t, acct.Address, acct.Address, 0),
+		Signer: BasicAccountTransactionSigner{Account: acct},
+	})
+	require.NoError(t, err)
+	err = atc.AddMethodCall(AddMethodCallParams{
+		AppID:           1,
+		Method:          method,
+		MethodArgs:      []interface{}{uint64(1), uint64(2)},
+		Sender:          acct.Address,
+		SuggestedParams: makeTestSuggestedParams(),
+		Signer:          BasicAccountTransactionSigner{Account: acct},
+	})
+	require.NoError(t, err)
+
+	returnLog := append([]byte{0x15, 0x1f, 0x7c, 0x75}, make([]byte, 16)...)
+	returnLog[len(returnLog)-1] = 3
+	var simulateRequest models.SimulateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/transactions/simulate", r.URL.Path)
+		require.Equal(t, "msgpack", r.URL.Query().Get("format"))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, msgpack.Decode(body, &simulateRequest))
+		w.Write(msgpack.Encode(models.SimulateResponse{
+			LastRound: 10,
+			Version:   2,
+			TxnGroups: []models.SimulateTransactionGroupResult{{
+				AppBudgetConsumed: 20,
+				TxnResults: []models.SimulateTransactionResult{
+					{},
+					{AppBudgetConsumed: 20, TxnResult: models.PendingTransactionInfoResponse{Logs: [][]byte{returnLog}}},
+				},
+			}},
+		}))
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	request := models.SimulateRequest{
+		AllowEmptySignatures: true,
+		ExtraOpcodeBudget:    700,
+		ExecTraceConfig:      models.SimulateTraceConfig{Enable: true, StackChange: true},
+	}
+	result, err := atc.Simulate(context.Background(), client, request)
+	require.NoError(t, err)
+	require.Equal(t, Built, atc.GetStatus())
+	require.Equal(t, uint64(700), simulateRequest.ExtraOpcodeBudget)
+	require.True(t, simulateRequest.ExecTraceConfig.StackChange)
+	require.Len(t, simulateRequest.TxnGroups, 1)
+	require.Len(t, simulateRequest.TxnGroups[0].Txns, 2)
+	require.Equal(t, types.Signature{}, simulateRequest.TxnGroups[0].Txns[1].Sig)
+	require.NotEqual(t, types.Digest{}, simulateRequest.TxnGroups[0].Txns[1].Txn.Group)
+
+	require.Equal(t, uint64(20), result.GroupResult.AppBudgetConsumed)
+	require.Len(t, result.TxIDs, 2)
+	require.Len(t, result.MethodResults, 1)
+	require.Equal(t, result.TxIDs[1], result.MethodResults[0].TxID)
+	require.NoError(t, result.MethodResults[0].DecodeError)
+	require.Equal(t, big.NewInt(3), result.MethodResults[0].ReturnValue)
+
+	// signed groups are simulated with their signatures
+	request.AllowEmptySignatures = false
+	_, err = atc.Simulate(context.Background(), client, request)
+	require.NoError(t, err)
+	require.Equal(t, Signed, atc.GetStatus())
+	require.NotEqual(t, types.Signature{}, simulateRequest.TxnGroups[0].Txns[1].Sig)
+}
@@ -0,0 +1,86 @@
+package future
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// RoundDelta is the state delta of a round, as sent by a BlockFollower
+type RoundDelta struct {
+	// Round is the round of the delta
+	Round uint64
+
+	// Delta is the changes the block of the round made to the ledger
+	Delta types.LedgerStateDelta
+}
+
+// BlockFollower follows the chain through a node in follower mode, fetching
+// the state delta of each round in turn and then moving the node's sync round
+// past it, so that the node keeps each delta until it has been fetched. It
+// can be used to build custom indexers.
+type BlockFollower struct {
+	client  client.AlgodClient
+	headers []*common.Header
+	next    uint64
+}
+
+// MakeBlockFollower returns a follower of the chain through client, a node in
+// follower mode, from round start on
+func MakeBlockFollower(client client.AlgodClient, start uint64, headers ...*common.Header) *BlockFollower {
+	return &BlockFollower{client: client, headers: headers, next: start}
+}
+
+// NextRound returns the round whose delta the follower fetches next
+func (f *BlockFollower) NextRound() uint64 {
+	return f.next
+}
+
+// Run sets the node's sync round to the follower's next round, then sends the
+// state delta of each round on deltas as the node reaches it, until ctx is
+// done or a request fails. The sync round is moved past a round once its
+// delta has been received from deltas, so an interrupted follower can resume
+// from NextRound. Run returns ctx.Err() when ctx is done, or the error of the
+// failed request.
+func (f *BlockFollower) Run(ctx context.Context, deltas chan<- RoundDelta) error {
+	err := f.run(ctx, deltas)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+func (f *BlockFollower) run(ctx context.Context, deltas chan<- RoundDelta) error {
+	if err := f.client.SetSyncRound(f.next).Do(ctx, f.headers...); err != nil {
+		return err
+	}
+	status, err := f.client.Status().Do(ctx, f.headers...)
+	if err != nil {
+		return err
+	}
+	for {
+		for status.LastRound < f.next {
+			// the node returns when a block is added, or after a timeout
+			status, err = f.client.StatusAfterBlock(status.LastRound).Do(ctx, f.headers...)
+			if err != nil {
+				return err
+			}
+		}
+
+		delta, err := f.client.GetLedgerStateDelta(f.next).Do(ctx, f.headers...)
+		if err != nil {
+			return err
+		}
+		select {
+		case deltas <- RoundDelta{Round: f.next, Delta: delta}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		f.next++
+		if err := f.client.SetSyncRound(f.next).Do(ctx, f.headers...); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package future
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestBlockFollower(t *testing.T) {
+	var mu sync.Mutex
+	var syncRound uint64
+	var syncRounds []uint64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "POST" && strings.HasPrefix(r.URL.Path, "/v2/ledger/sync/"):
+			round, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/v2/ledger/sync/"), 10, 64)
+			require.NoError(t, err)
+			syncRound = round
+			syncRounds = append(syncRounds, round)
+		case r.URL.Path == "/v2/status" || strings.HasPrefix(r.URL.Path, "/v2/status/wait-for-block-after/"):
+			// the node catches up to its sync round and waits there
+			fmt.Fprintf(w, `{"last-round":%d}`, syncRound)
+		case strings.HasPrefix(r.URL.Path, "/v2/deltas/"):
+			require.Equal(t, "msgpack", r.URL.Query().Get("format"))
+			round, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/v2/deltas/"), 10, 64)
+			require.NoError(t, err)
+			require.True(t, round <= syncRound)
+			w.Write(msgpack.Encode(types.LedgerStateDelta{
+				Hdr:           &types.BlockHeader{Round: types.Round(round)},
+				PrevTimestamp: int64(round),
+			}))
+		default:
+			t.Fatalf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	follower := MakeBlockFollower(client, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+	deltas := make(chan RoundDelta)
+	done := make(chan error)
+	go func() { done <- follower.Run(ctx, deltas) }()
+	for round := uint64(5); round < 8; round++ {
+		delta := <-deltas
+		require.Equal(t, round, delta.Round)
+		require.Equal(t, types.Round(round), delta.Delta.Hdr.Round)
+		require.Equal(t, int64(round), delta.Delta.PrevTimestamp)
+	}
+	cancel()
+	require.Equal(t, context.Canceled, <-done)
+	require.Equal(t, uint64(8), follower.NextRound())
+	mu.Lock()
+	// the sync round may be moved past the last delta before Run returns
+	require.Equal(t, []uint64{5, 6, 7}, syncRounds[:3])
+	mu.Unlock()
+}
@@ -0,0 +1,98 @@
+package future
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// defaultAppID is the ID a dryrun gives an application created by one of its
+// transactions, which has no ID yet
+const defaultAppID uint64 = 1380011588
+
+// CreateDryrun builds a DryrunRequest for txns, fetching from algod the
+// applications they call or read and the accounts they reference, including
+// the creators of those applications. Applications created by txns are added
+// with ID 1380011588. Other settings, such as Round or Sources, are taken
+// from dr, which may be nil.
+func CreateDryrun(ctx context.Context, client client.AlgodClient, txns []types.SignedTxn, dr *models.DryrunRequest, headers ...*common.Header) (request models.DryrunRequest, err error) {
+	if dr != nil {
+		request = *dr
+	}
+	request.Txns = txns
+
+	var accounts []string
+	var apps []uint64
+	seenAccounts := make(map[string]bool)
+	seenApps := make(map[uint64]bool)
+	addAccount := func(address string) {
+		if !seenAccounts[address] {
+			seenAccounts[address] = true
+			accounts = append(accounts, address)
+		}
+	}
+	addApp := func(appID uint64) {
+		if !seenApps[appID] {
+			seenApps[appID] = true
+			apps = append(apps, appID)
+		}
+	}
+
+	for _, stx := range txns {
+		tx := stx.Txn
+		if tx.Type != types.ApplicationCallTx {
+			continue
+		}
+		addAccount(tx.Sender.String())
+		for _, account := range tx.Accounts {
+			addAccount(account.String())
+		}
+		for _, app := range tx.ForeignApps {
+			addApp(uint64(app))
+		}
+		if tx.ApplicationID == 0 {
+			request.Apps = append(request.Apps, models.Application{
+				ID: defaultAppID,
+				Params: models.ApplicationParams{
+					ApprovalProgram:   tx.ApprovalProgram,
+					ClearStateProgram: tx.ClearStateProgram,
+					Creator:           tx.Sender.String(),
+					ExtraProgramPages: uint64(tx.ExtraProgramPages),
+					GlobalStateSchema: models.ApplicationStateSchema{
+						NumByteSlice: tx.GlobalStateSchema.NumByteSlice,
+						NumUint:      tx.GlobalStateSchema.NumUint,
+					},
+					LocalStateSchema: models.ApplicationStateSchema{
+						NumByteSlice: tx.LocalStateSchema.NumByteSlice,
+						NumUint:      tx.LocalStateSchema.NumUint,
+					},
+				},
+			})
+		} else {
+			addApp(uint64(tx.ApplicationID))
+		}
+	}
+
+	for _, appID := range apps {
+		var app models.Application
+		app, err = client.GetApplicationByID(appID).Do(ctx, headers...)
+		if err != nil {
+			return
+		}
+		request.Apps = append(request.Apps, app)
+		addAccount(app.Params.Creator)
+	}
+
+	for _, address := range accounts {
+		var account models.Account
+		account, err = client.AccountInformation(address).Do(ctx, headers...)
+		if err != nil {
+			return
+		}
+		request.Accounts = append(request.Accounts, account)
+	}
+	return
+}
@@ -0,0 +1,209 @@
+package future
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// rejectMessage is the message a dryrun gives for a program that rejected
+const rejectMessage = "REJECT"
+
+// DryrunResponse wraps the result of a dryrun with methods to inspect it,
+// for example to assert on in tests
+type DryrunResponse struct {
+	// Error is set if the request could not be run
+	Error string
+
+	// ProtocolVersion is the protocol version the transactions were run under
+	ProtocolVersion string
+
+	// Txns are the results of each transaction, in order
+	Txns []DryrunTxnResult
+}
+
+// DryrunTxnResult is the result of one transaction of a dryrun
+type DryrunTxnResult struct {
+	models.DryrunTxnResult
+}
+
+// NewDryrunResponse wraps the result of a dryrun, as returned by algod's
+// TealDryrun
+func NewDryrunResponse(resp models.DryrunResponse) DryrunResponse {
+	d := DryrunResponse{Error: resp.Error, ProtocolVersion: resp.ProtocolVersion}
+	for _, txn := range resp.Txns {
+		d.Txns = append(d.Txns, DryrunTxnResult{txn})
+	}
+	return d
+}
+
+// AppCallRejected returns true if the approval program of any transaction
+// rejected
+func (d DryrunResponse) AppCallRejected() bool {
+	for _, txn := range d.Txns {
+		if txn.AppCallRejected() {
+			return true
+		}
+	}
+	return false
+}
+
+// LogicSigRejected returns true if the LogicSig program of any transaction
+// rejected
+func (d DryrunResponse) LogicSigRejected() bool {
+	for _, txn := range d.Txns {
+		if txn.LogicSigRejected() {
+			return true
+		}
+	}
+	return false
+}
+
+// String describes the outcome of each transaction, with the trace of each
+// program that rejected, for readable test failures
+func (d DryrunResponse) String() string {
+	var b strings.Builder
+	if d.Error != "" {
+		fmt.Fprintf(&b, "error: %s\n", d.Error)
+	}
+	config := DefaultStackPrinterConfig()
+	for i, txn := range d.Txns {
+		if len(txn.LogicSigMessages) > 0 {
+			fmt.Fprintf(&b, "txn %d logicsig: %s\n", i, strings.Join(txn.LogicSigMessages, ", "))
+			if txn.LogicSigRejected() {
+				b.WriteString(txn.GetLogicSigTrace(config))
+			}
+		}
+		if len(txn.AppCallMessages) > 0 {
+			fmt.Fprintf(&b, "txn %d app call: %s\n", i, strings.Join(txn.AppCallMessages, ", "))
+			if txn.AppCallRejected() {
+				b.WriteString(txn.GetAppCallTrace(config))
+			}
+		}
+	}
+	return b.String()
+}
+
+// AppCallRejected returns true if the transaction's approval program rejected
+func (r DryrunTxnResult) AppCallRejected() bool {
+	return rejected(r.AppCallMessages)
+}
+
+// LogicSigRejected returns true if the transaction's LogicSig program rejected
+func (r DryrunTxnResult) LogicSigRejected() bool {
+	return rejected(r.LogicSigMessages)
+}
+
+func rejected(messages []string) bool {
+	for _, m := range messages {
+		if m == rejectMessage {
+			return true
+		}
+	}
+	return false
+}
+
+// StackPrinterConfig configures how traces are formatted
+type StackPrinterConfig struct {
+	// MaxValueWidth is the width values are truncated to, or 0 not to truncate
+	MaxValueWidth int
+
+	// TopOfStackFirst prints stacks from the top instead of the bottom
+	TopOfStackFirst bool
+}
+
+// DefaultStackPrinterConfig returns the StackPrinterConfig String uses
+func DefaultStackPrinterConfig() StackPrinterConfig {
+	return StackPrinterConfig{MaxValueWidth: 30}
+}
+
+// GetAppCallTrace formats the trace of the transaction's approval program as
+// a table of the program counter, the line and its source, the changes to
+// the scratch space and the stack after each step
+func (r DryrunTxnResult) GetAppCallTrace(config StackPrinterConfig) string {
+	return formatTrace(r.AppCallTrace, r.Disassembly, config)
+}
+
+// GetLogicSigTrace formats the trace of the transaction's LogicSig program as
+// GetAppCallTrace does
+func (r DryrunTxnResult) GetLogicSigTrace(config StackPrinterConfig) string {
+	return formatTrace(r.LogicSigTrace, r.LogicSigDisassembly, config)
+}
+
+func formatTrace(trace []models.DryrunState, disassembly []string, config StackPrinterConfig) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 1, ' ', tabwriter.Debug)
+	fmt.Fprintln(w, "pc#\tln#\tsource\tscratch\tstack")
+
+	var prevScratch []models.TealValue
+	for _, state := range trace {
+		var source string
+		if state.Line < uint64(len(disassembly)) {
+			source = disassembly[state.Line]
+		}
+		stack := make([]string, len(state.Stack))
+		for i, v := range state.Stack {
+			stack[i] = formatTealValue(v, config.MaxValueWidth)
+		}
+		if config.TopOfStackFirst {
+			for i, j := 0, len(stack)-1; i < j; i, j = i+1, j-1 {
+				stack[i], stack[j] = stack[j], stack[i]
+			}
+		}
+		stackCol := "[" + strings.Join(stack, ", ") + "]"
+		if state.Error != "" {
+			stackCol += " !! " + state.Error + " !!"
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", state.Pc, state.Line, truncate(source, config.MaxValueWidth),
+			scratchChanges(prevScratch, state.Scratch, config.MaxValueWidth), stackCol)
+		prevScratch = state.Scratch
+	}
+	w.Flush()
+	return buf.String()
+}
+
+// scratchChanges lists the scratch slots a step wrote
+func scratchChanges(prev, cur []models.TealValue, width int) string {
+	var changes []string
+	for i, v := range cur {
+		if i < len(prev) && prev[i] == v {
+			continue
+		}
+		if i >= len(prev) && v.Type == 0 {
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%d = %s", i, formatTealValue(v, width)))
+	}
+	return strings.Join(changes, ", ")
+}
+
+// formatTealValue formats uints in decimal and bytes in hex
+func formatTealValue(v models.TealValue, width int) string {
+	switch v.Type {
+	case tealUintType:
+		return fmt.Sprintf("%d", v.Uint)
+	case tealBytesType:
+		b, err := base64.StdEncoding.DecodeString(v.Bytes)
+		if err != nil {
+			return truncate(v.Bytes, width)
+		}
+		return truncate("0x"+hex.EncodeToString(b), width)
+	default:
+		return ""
+	}
+}
+
+func truncate(s string, width int) string {
+	if width <= 0 || len(s) <= width {
+		return s
+	}
+	if width <= 3 {
+		return s[:width]
+	}
+	return s[:width-3] + "..."
+}
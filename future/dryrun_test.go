@@ -0,0 +1,110 @@
+package future
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+const testCreator = "DN7MBMCL5JQ3PFUQS7TMX5AH4EEKOBJVDUF4TCV6WERATKFLQF4MQUPZTA"
+
+func TestCreateDryrun(t *testing.T) {
+	var dryrunRequest models.DryrunRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/applications/5":
+			w.Write([]byte(`{"id":5,"params":{"creator":"` + testCreator + `","approval-program":"ASABASI="}}`))
+		case "/v2/accounts/" + testAddr, "/v2/accounts/" + testCreator:
+			w.Write([]byte(`{"address":"` + r.URL.Path[len("/v2/accounts/"):] + `","amount":1000000}`))
+		case "/v2/teal/dryrun":
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, msgpack.Decode(body, &dryrunRequest))
+			w.Write([]byte(`{"protocol-version":"future","txns":[{"disassembly":["#pragma version 1","intc_0"],"app-call-messages":["PASS"]}]}`))
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	params := makeTestParams(0, true)
+	call, err := transaction.MakeApplicationCallTxn(5, nil, []string{testCreator}, nil, nil, types.NoOpOC, params, testAddr, nil)
+	require.NoError(t, err)
+	create, err := transaction.MakeApplicationCreateTxn(false, []byte{1, 32, 1, 1, 34}, []byte{1, 32, 1, 1, 34},
+		types.StateSchema{NumUint: 1}, types.StateSchema{}, 0, nil, nil, nil, nil, params, testAddr, nil)
+	require.NoError(t, err)
+	txns := []types.SignedTxn{{Txn: call}, {Txn: create}}
+
+	request, err := CreateDryrun(context.Background(), client, txns, &models.DryrunRequest{Round: 100})
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), request.Round)
+	require.Equal(t, txns, request.Txns)
+	require.Len(t, request.Apps, 2)
+	require.Equal(t, defaultAppID, request.Apps[0].ID)
+	require.Equal(t, testAddr, request.Apps[0].Params.Creator)
+	require.Equal(t, uint64(1), request.Apps[0].Params.GlobalStateSchema.NumUint)
+	require.Equal(t, uint64(5), request.Apps[1].ID)
+	require.Len(t, request.Accounts, 2)
+	require.Equal(t, testAddr, request.Accounts[0].Address)
+	require.Equal(t, testCreator, request.Accounts[1].Address)
+
+	response, err := client.TealDryrun(request).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"PASS"}, response.Txns[0].AppCallMessages)
+	require.Equal(t, request.Round, dryrunRequest.Round)
+	require.Equal(t, request.Txns, dryrunRequest.Txns)
+	require.Equal(t, request.Apps[1].Params.ApprovalProgram, dryrunRequest.Apps[1].Params.ApprovalProgram)
+}
+
+func TestDryrunResponse(t *testing.T) {
+	var resp models.DryrunResponse
+	err := json.Unmarshal([]byte(`{"protocol-version":"future","txns":[
+		{"logic-sig-messages":["PASS"],"logic-sig-disassembly":["#pragma version 5","int 1"],
+		 "logic-sig-trace":[{"line":1,"pc":1,"stack":[]},{"line":2,"pc":3,"stack":[{"type":2,"uint":1}]}]},
+		{"app-call-messages":["ApprovalProgram","REJECT"],
+		 "disassembly":["#pragma version 5","byte 0x6869","store 0","load 0","int 0","return"],
+		 "app-call-trace":[
+			{"line":1,"pc":1,"stack":[]},
+			{"line":2,"pc":4,"stack":[{"type":1,"bytes":"aGk="}]},
+			{"line":3,"pc":6,"stack":[],"scratch":[{"type":1,"bytes":"aGk="}]},
+			{"line":4,"pc":8,"stack":[{"type":1,"bytes":"aGk="}],"scratch":[{"type":1,"bytes":"aGk="}]},
+			{"line":5,"pc":9,"stack":[{"type":1,"bytes":"aGk="},{"type":2}],"scratch":[{"type":1,"bytes":"aGk="}]}]}]}`), &resp)
+	require.NoError(t, err)
+	d := NewDryrunResponse(resp)
+	require.Len(t, d.Txns, 2)
+	require.True(t, d.AppCallRejected())
+	require.False(t, d.LogicSigRejected())
+	require.False(t, d.Txns[0].AppCallRejected())
+	require.True(t, d.Txns[1].AppCallRejected())
+
+	expected := "" +
+		"pc# |ln# |source      |scratch    |stack\n" +
+		"1   |1   |byte 0x6869 |           |[]\n" +
+		"4   |2   |store 0     |           |[0x6869]\n" +
+		"6   |3   |load 0      |0 = 0x6869 |[]\n" +
+		"8   |4   |int 0       |           |[0x6869]\n" +
+		"9   |5   |return      |           |[0x6869, 0]\n"
+	require.Equal(t, expected, d.Txns[1].GetAppCallTrace(DefaultStackPrinterConfig()))
+
+	config := StackPrinterConfig{MaxValueWidth: 5, TopOfStackFirst: true}
+	require.Contains(t, d.Txns[1].GetAppCallTrace(config), "|[0, 0x...]\n")
+
+	summary := d.String()
+	require.Contains(t, summary, "txn 0 logicsig: PASS\n")
+	require.Contains(t, summary, "txn 1 app call: ApprovalProgram, REJECT\n")
+	require.Contains(t, summary, expected)
+	require.NotContains(t, summary, "int 1")
+}
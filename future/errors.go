@@ -0,0 +1,62 @@
+package future
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of errors of composing and executing transaction groups. The errors
+// returned say what is wrong and unwrap to one of these, or to
+// transaction.ErrGroupTooLarge, transaction.ErrAlreadyGrouped or
+// transaction.ErrInvalidApplicationCall, so that callers can branch on the
+// kind, e.g. with errors.Is.
+var (
+	// ErrComposerStatus is the kind of errors of AtomicTransactionComposer
+	// methods called in a status that does not allow them, such as adding a
+	// transaction to a group that was already built
+	ErrComposerStatus = errors.New("wrong composer status")
+
+	// ErrEmptyGroup is the kind of errors of building a group without
+	// transactions
+	ErrEmptyGroup = errors.New("empty group")
+
+	// ErrMissingSigner is the kind of errors of transactions added without a
+	// signer
+	ErrMissingSigner = errors.New("missing signer")
+
+	// ErrMissingSignature is the kind of errors of signers that did not
+	// return a signed transaction for each transaction they were given
+	ErrMissingSignature = errors.New("missing signature")
+
+	// ErrInvalidMethodArgs is the kind of errors of method calls whose
+	// arguments do not match the method's
+	ErrInvalidMethodArgs = errors.New("invalid method arguments")
+
+	// ErrNoReturnValue is the kind of errors of method calls whose
+	// transaction did not log the method's return value
+	ErrNoReturnValue = errors.New("no return value")
+
+	// ErrUnexpectedResponse is the kind of errors of node responses that do
+	// not match the request, such as a simulation of another group
+	ErrUnexpectedResponse = errors.New("unexpected response")
+)
+
+// kindError is an error of one of the kinds above: it reads as its message
+// and unwraps to its kind
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// errorf returns an error of the given kind with a formatted message
+func errorf(kind error, format string, args ...interface{}) error {
+	return &kindError{kind: kind, msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,24 @@
+package future
+
+import (
+	"github.com/algorand/go-algorand-sdk/abi"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// DecodeEvents decodes the ARC-28 events emitted by a transaction and its
+// inner transactions, in the order they were emitted, from its logs. txInfo
+// may be a confirmed transaction or the result of a simulated one. Logs that
+// are not one of the given events are skipped.
+func DecodeEvents(events []abi.Event, txInfo models.PendingTransactionInfoResponse) ([]abi.DecodedEvent, error) {
+	var logs [][]byte
+	for _, txn := range txInfo.Flatten() {
+		logs = append(logs, txn.Logs...)
+	}
+	return abi.DecodeEvents(events, logs)
+}
+
+// Events decodes the events the called method declares from the logs of the
+// method call, as DecodeEvents does
+func (r ABIMethodResult) Events() ([]abi.DecodedEvent, error) {
+	return DecodeEvents(r.Method.Events, r.TxInfo)
+}
@@ -0,0 +1,37 @@
+package future
+
+import (
+	"testing"
+
+	"github.com/algorand/go-algorand-sdk/abi"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	swapped, err := abi.EventFromSignature("Swapped(uint64,uint64)")
+	require.NoError(t, err)
+	outer, err := swapped.Encode(uint64(1), uint64(2))
+	require.NoError(t, err)
+	inner, err := swapped.Encode(uint64(3), uint64(4))
+	require.NoError(t, err)
+
+	method, err := abi.MethodFromSignature("swap(uint64)void")
+	require.NoError(t, err)
+	method.Events = []abi.Event{swapped}
+	txInfo := models.PendingTransactionInfoResponse{
+		Logs:      [][]byte{outer, []byte("debug")},
+		InnerTxns: []models.PendingTransactionInfoResponse{{Logs: [][]byte{inner}}},
+	}
+
+	result := makeMethodResult(method, "txid", txInfo)
+	events, err := result.Events()
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, []interface{}{uint64(1), uint64(2)}, events[0].Values)
+	require.Equal(t, []interface{}{uint64(3), uint64(4)}, events[1].Values)
+
+	events, err = DecodeEvents(nil, txInfo)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
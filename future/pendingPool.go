@@ -0,0 +1,32 @@
+package future
+
+import (
+	"context"
+
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/crypto"
+)
+
+// FindPendingTransactions returns which of the transactions txids are still
+// in the node's pool, by looking through the pending transactions sent by or
+// to address, e.g. the sender of a previously submitted group such as a split
+// contract's. Transactions that are not pending have either been confirmed or
+// dropped from the pool; see PendingTransactionInformation to tell which.
+func FindPendingTransactions(ctx context.Context, c client.AlgodClient, address string, txids []string, headers ...*common.Header) (map[string]bool, error) {
+	_, stxns, err := c.PendingTransactionsByAddress(address).Do(ctx, headers...)
+	if err != nil {
+		return nil, err
+	}
+	wanted := make(map[string]bool, len(txids))
+	for _, txid := range txids {
+		wanted[txid] = true
+	}
+	pending := make(map[string]bool)
+	for _, stxn := range stxns {
+		if txid := crypto.GetTxID(stxn.Txn); wanted[txid] {
+			pending[txid] = true
+		}
+	}
+	return pending, nil
+}
@@ -0,0 +1,56 @@
+package future
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestPendingTransactions(t *testing.T) {
+	params := makeTestParams(1000, true)
+	first, err := MakePaymentTxn(testAddr, testAddr, 1, nil, "", params)
+	require.NoError(t, err)
+	second, err := MakePaymentTxn(testAddr, testAddr, 2, nil, "", params)
+	require.NoError(t, err)
+	confirmed, err := MakePaymentTxn(testAddr, testAddr, 3, nil, "", params)
+	require.NoError(t, err)
+	pool := models.PendingTransactionsResponse{
+		TopTransactions:   []types.SignedTxn{{Txn: first}, {Txn: second}},
+		TotalTransactions: 2,
+	}
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		switch r.URL.Path {
+		case "/v2/transactions/pending", "/v2/accounts/" + testAddr + "/transactions/pending":
+			w.Write(msgpack.Encode(pool))
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	total, stxns, err := client.PendingTransactions().Max(1).Do(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), total)
+	require.Equal(t, pool.TopTransactions, stxns)
+	require.Equal(t, "format=msgpack&max=1", queries[0])
+
+	pending, err := FindPendingTransactions(context.Background(), client, testAddr,
+		[]string{crypto.GetTxID(second), crypto.GetTxID(confirmed)})
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{crypto.GetTxID(second): true}, pending)
+	require.Equal(t, "format=msgpack", queries[1])
+}
@@ -0,0 +1,187 @@
+package future
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/protocol"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// refreshRetryDelay is how long Run waits before retrying a failed refresh
+const refreshRetryDelay = time.Second
+
+// SuggestedParamsCache caches the suggested params of algod, so that senders
+// of many transactions need not fetch them for each one. Params are valid for
+// a thousand rounds from the round they were fetched at, so they may be
+// cached for up to a few hundred rounds. It is safe for concurrent use.
+type SuggestedParamsCache struct {
+	client    client.AlgodClient
+	headers   []*common.Header
+	maxAge    time.Duration
+	maxRounds uint64
+	now       func() time.Time
+
+	mu      sync.Mutex
+	params  types.SuggestedParams
+	fetched time.Time
+	cached  bool
+	// lastRound is the last round of the node the cache has seen
+	lastRound uint64
+	// running is the number of calls of Run that have not returned
+	running int
+}
+
+// MakeSuggestedParamsCache returns a cache of the suggested params of client.
+// Params older than maxAge are fetched again by Get; a maxAge of 0 keeps them
+// until Invalidate is called or Run refreshes them. If maxRounds is not 0,
+// params are fetched again once maxRounds rounds have passed since the round
+// they were fetched at, and Run refreshes them each maxRounds rounds. Params
+// are only valid for the max transaction life of the protocol, so an error is
+// returned if maxRounds is not below it.
+func MakeSuggestedParamsCache(client client.AlgodClient, maxAge time.Duration, maxRounds uint64, headers ...*common.Header) (*SuggestedParamsCache, error) {
+	if maxRounds >= protocol.Current.MaxTxnLife {
+		return nil, fmt.Errorf("maxRounds %d is not below the max transaction life of %d rounds", maxRounds, protocol.Current.MaxTxnLife)
+	}
+	return &SuggestedParamsCache{
+		client:    client,
+		headers:   headers,
+		maxAge:    maxAge,
+		maxRounds: maxRounds,
+		now:       time.Now,
+	}, nil
+}
+
+// Get returns the cached params, fetching them if none are cached, they are
+// older than the cache's maxAge, or the cache's maxRounds rounds have passed
+// since they were fetched. While Run runs, the rounds are those Run has seen;
+// otherwise Get asks algod for its status to count them.
+func (c *SuggestedParamsCache) Get(ctx context.Context) (types.SuggestedParams, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expired := !c.cached || (c.maxAge > 0 && c.now().Sub(c.fetched) >= c.maxAge)
+	if !expired && c.maxRounds > 0 {
+		if c.running == 0 {
+			status, err := c.client.Status().Do(ctx, c.headers...)
+			if err != nil {
+				return types.SuggestedParams{}, err
+			}
+			c.seeRound(status.LastRound)
+		}
+		expired = c.lastRound >= uint64(c.params.FirstRoundValid)+c.maxRounds
+	}
+	if expired {
+		if err := c.fetch(ctx); err != nil {
+			return types.SuggestedParams{}, err
+		}
+	}
+	params := c.params
+	params.GenesisHash = append([]byte{}, c.params.GenesisHash...)
+	return params, nil
+}
+
+// Refresh fetches the params, replacing those cached
+func (c *SuggestedParamsCache) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fetch(ctx)
+}
+
+// Invalidate discards the cached params, so that Get fetches them again
+func (c *SuggestedParamsCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cached = false
+}
+
+// fetch fetches the params, with c.mu held so that concurrent callers of Get
+// wait for one request
+func (c *SuggestedParamsCache) fetch(ctx context.Context) error {
+	params, err := c.client.SuggestedParams().Do(ctx, c.headers...)
+	if err != nil {
+		return err
+	}
+	c.params = params
+	c.fetched = c.now()
+	c.cached = true
+	c.seeRound(uint64(params.FirstRoundValid))
+	return nil
+}
+
+// seeRound records that the node has reached round, with c.mu held
+func (c *SuggestedParamsCache) seeRound(round uint64) {
+	if round > c.lastRound {
+		c.lastRound = round
+	}
+}
+
+// Run refreshes the params in the background until ctx is done, when it
+// returns ctx.Err(): each maxRounds rounds after the round the params were
+// fetched at if the cache's maxRounds is not 0, and otherwise before they are
+// maxAge old. Failed refreshes are retried after a second. Run is meant to be
+// called in its own goroutine, so that Get rarely has to wait for algod.
+func (c *SuggestedParamsCache) Run(ctx context.Context) error {
+	c.mu.Lock()
+	c.running++
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.running--
+		c.mu.Unlock()
+	}()
+	for {
+		var err error
+		switch {
+		case c.maxRounds > 0:
+			err = c.waitRounds(ctx)
+		case c.maxAge > 0:
+			err = sleep(ctx, c.maxAge*9/10)
+		default:
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		if err == nil {
+			err = c.Refresh(ctx)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			if err := sleep(ctx, refreshRetryDelay); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// waitRounds waits until maxRounds rounds have passed since the cached params
+// were fetched, fetching them first if none are cached
+func (c *SuggestedParamsCache) waitRounds(ctx context.Context) error {
+	params, err := c.Get(ctx)
+	if err != nil {
+		return err
+	}
+	status, err := c.client.StatusAfterBlock(uint64(params.FirstRoundValid)+c.maxRounds-1).Do(ctx, c.headers...)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.seeRound(status.LastRound)
+	c.mu.Unlock()
+	return nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
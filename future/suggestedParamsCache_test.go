@@ -0,0 +1,110 @@
+package future
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestSuggestedParamsCache(t *testing.T) {
+	var round, fetches int64 = 100, 0
+	refreshed := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/transactions/params":
+			atomic.AddInt64(&fetches, 1)
+			fmt.Fprintf(w, `{"last-round":%d,"min-fee":1000,"genesis-id":"test"}`, atomic.LoadInt64(&round))
+			select {
+			case refreshed <- struct{}{}:
+			default:
+			}
+		case r.URL.Path == "/v2/status":
+			fmt.Fprintf(w, `{"last-round":%d}`, atomic.LoadInt64(&round))
+		case strings.HasPrefix(r.URL.Path, "/v2/status/wait-for-block-after/"):
+			var after int64
+			fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/v2/status/wait-for-block-after/"), "%d", &after)
+			atomic.StoreInt64(&round, after+1)
+			fmt.Fprintf(w, `{"last-round":%d}`, after+1)
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+	cache, err := MakeSuggestedParamsCache(client, time.Minute, 0)
+	require.NoError(t, err)
+	cache.now = func() time.Time { return now }
+	params, err := cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, types.Round(100), params.FirstRoundValid)
+	require.Equal(t, "test", params.GenesisID)
+	_, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), atomic.LoadInt64(&fetches))
+
+	// params expire after maxAge, or when invalidated
+	now = now.Add(time.Minute)
+	_, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(2), atomic.LoadInt64(&fetches))
+	cache.Invalidate()
+	_, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(3), atomic.LoadInt64(&fetches))
+	for len(refreshed) > 0 {
+		<-refreshed
+	}
+
+	// params expire after maxRounds rounds, even without Run
+	cache, err = MakeSuggestedParamsCache(client, 0, 5)
+	require.NoError(t, err)
+	_, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(4), atomic.LoadInt64(&fetches))
+	atomic.StoreInt64(&round, 104)
+	_, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(4), atomic.LoadInt64(&fetches))
+	atomic.StoreInt64(&round, 105)
+	params, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(5), atomic.LoadInt64(&fetches))
+	require.Equal(t, types.Round(105), params.FirstRoundValid)
+	for len(refreshed) > 0 {
+		<-refreshed
+	}
+
+	// params are valid for the max transaction life, so maxRounds must be
+	// below it
+	_, err = MakeSuggestedParamsCache(client, 0, 1000)
+	require.Error(t, err)
+
+	// Run refreshes the params each maxRounds rounds
+	cache, err = MakeSuggestedParamsCache(client, 0, 5)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- cache.Run(ctx) }()
+	for i := 0; i < 4; i++ {
+		<-refreshed
+	}
+	cancel()
+	require.Equal(t, context.Canceled, <-done)
+	params, err = cache.Get(context.Background())
+	require.NoError(t, err)
+	require.True(t, params.FirstRoundValid >= 115)
+	require.Equal(t, types.Round(0), params.FirstRoundValid%5)
+}
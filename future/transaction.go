@@ -0,0 +1,274 @@
+package future
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/assetmetadata"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// The constructors below are as those of the transaction package, but take
+// their fee, validity window and genesis from a types.SuggestedParams, as
+// returned by the SuggestedParams endpoint of the algod v2 client. See
+// transaction.SetFee for how the fee is set. Optional fields, such as the
+// lease, are set with transaction.TxnOptions, e.g. transaction.WithLease.
+
+// MakePaymentTxn constructs a payment transaction using the passed parameters.
+// `from` and `to` addresses should be checksummed, human-readable addresses
+// - closeRemainderTo is a checksummed, human-readable address to send the sender's remaining algos to, or empty
+func MakePaymentTxn(from, to string, amount uint64, note []byte, closeRemainderTo string, params types.SuggestedParams, opts ...transaction.TxnOption) (types.Transaction, error) {
+	tx, err := transaction.MakePaymentTxnWithFlatFee(from, to, 0, amount, uint64(params.FirstRoundValid), uint64(params.LastRoundValid),
+		note, closeRemainderTo, params.GenesisID, params.GenesisHash)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, setOptionsAndFee(&tx, params, opts)
+}
+
+// MakeKeyRegTxn constructs a keyreg transaction using the passed parameters.
+// - account is a checksummed, human-readable address for which we register the given participation key.
+// - note is a byte array
+// KeyReg parameters:
+// - votePK is a base64-encoded string corresponding to the root participation public key
+// - selectionKey is a base64-encoded string corresponding to the vrf public key
+// - voteFirst is the first round this participation key is valid
+// - voteLast is the last round this participation key is valid
+// - voteKeyDilution is the dilution for the 2-level participation key
+func MakeKeyRegTxn(account string, note []byte, params types.SuggestedParams,
+	voteKey, selectionKey string, voteFirst, voteLast, voteKeyDilution uint64, opts ...transaction.TxnOption) (types.Transaction, error) {
+	tx, err := transaction.MakeKeyRegTxnWithFlatFee(account, 0, uint64(params.FirstRoundValid), uint64(params.LastRoundValid),
+		note, params.GenesisID, genesisHashString(params), voteKey, selectionKey, voteFirst, voteLast, voteKeyDilution)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, setOptionsAndFee(&tx, params, opts)
+}
+
+// MakeKeyRegTxnWithStateProofKey constructs a keyreg transaction using the passed parameters.
+// Given participation keys it registers the account online; given none, it
+// registers the account offline, or, if nonpart is set, marks it as never
+// participating again, which cannot be undone.
+// - account is a checksummed, human-readable address for which we register the given participation key.
+// - note is a byte array
+// KeyReg parameters:
+// - voteKey is a base64-encoded string corresponding to the root participation public key
+// - selectionKey is a base64-encoded string corresponding to the vrf public key
+// - stateProofPK is a base64-encoded string corresponding to the 64 byte root of the state proof keys, or empty
+// - voteFirst is the first round this participation key is valid
+// - voteLast is the last round this participation key is valid
+// - voteKeyDilution is the dilution for the 2-level participation key
+// - nonpart marks the account as nonparticipating; no keys may be given with it
+func MakeKeyRegTxnWithStateProofKey(account string, note []byte, params types.SuggestedParams,
+	voteKey, selectionKey, stateProofPK string, voteFirst, voteLast, voteKeyDilution uint64, nonpart bool, opts ...transaction.TxnOption) (types.Transaction, error) {
+	accountAddr, err := types.DecodeAddress(account)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if len(params.GenesisHash) != len(types.Digest{}) {
+		return types.Transaction{}, fmt.Errorf("key registration transaction must contain a 32 byte genesisHash")
+	}
+
+	online := voteKey != "" || selectionKey != "" || stateProofPK != ""
+	if online && nonpart {
+		return types.Transaction{}, fmt.Errorf("a nonparticipating account cannot register participation keys")
+	}
+	if online && (voteKey == "" || selectionKey == "") {
+		return types.Transaction{}, fmt.Errorf("both a vote key and a selection key are required to register participation keys")
+	}
+	if online && voteFirst > voteLast {
+		return types.Transaction{}, fmt.Errorf("voteFirst %d is after voteLast %d", voteFirst, voteLast)
+	}
+
+	var fields types.KeyregTxnFields
+	if online {
+		if err := decodeKey("vote key", voteKey, fields.VotePK[:]); err != nil {
+			return types.Transaction{}, err
+		}
+		if err := decodeKey("selection key", selectionKey, fields.SelectionPK[:]); err != nil {
+			return types.Transaction{}, err
+		}
+		if stateProofPK != "" {
+			if err := decodeKey("state proof key", stateProofPK, fields.StateProofPK[:]); err != nil {
+				return types.Transaction{}, err
+			}
+		}
+		fields.VoteFirst = types.Round(voteFirst)
+		fields.VoteLast = types.Round(voteLast)
+		fields.VoteKeyDilution = voteKeyDilution
+	}
+	fields.Nonparticipation = nonpart
+
+	var gh types.Digest
+	copy(gh[:], params.GenesisHash)
+	tx := types.Transaction{
+		Type: types.KeyRegistrationTx,
+		Header: types.Header{
+			Sender:      accountAddr,
+			FirstValid:  params.FirstRoundValid,
+			LastValid:   params.LastRoundValid,
+			Note:        note,
+			GenesisID:   params.GenesisID,
+			GenesisHash: gh,
+		},
+		KeyregTxnFields: fields,
+	}
+	return tx, setOptionsAndFee(&tx, params, opts)
+}
+
+// MakeAssetCreateTxn constructs an asset creation transaction using the passed parameters.
+// - account is a checksummed, human-readable address which will send the transaction.
+// - note is a byte array
+// Asset creation parameters:
+// - see transaction.MakeAssetCreateTxn
+func MakeAssetCreateTxn(account string, note []byte, params types.SuggestedParams,
+	total uint64, decimals uint32, defaultFrozen bool, manager, reserve, freeze, clawback string,
+	unitName, assetName, url, metadataHash string, opts ...transaction.TxnOption) (types.Transaction, error) {
+	tx, err := transaction.MakeAssetCreateTxnWithFlatFee(account, 0, uint64(params.FirstRoundValid), uint64(params.LastRoundValid),
+		note, params.GenesisID, genesisHashString(params),
+		total, decimals, defaultFrozen, manager, reserve, freeze, clawback, unitName, assetName, url, metadataHash)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, setOptionsAndFee(&tx, params, opts)
+}
+
+// MakeARC3AssetCreateTxn is as MakeAssetCreateTxn, for an ARC-3 asset whose
+// URL points at the JSON metadata file metadataJSON: the asset's metadata hash
+// is computed from the file, and an error is returned if the asset's name or
+// URL does not mark it as ARC-3 or its decimals differ from the file's.
+func MakeARC3AssetCreateTxn(account string, note []byte, params types.SuggestedParams,
+	total uint64, decimals uint32, defaultFrozen bool, manager, reserve, freeze, clawback string,
+	unitName, assetName, url string, metadataJSON []byte, opts ...transaction.TxnOption) (types.Transaction, error) {
+	metadataHash, err := assetmetadata.ARC3MetadataHash(metadataJSON)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	tx, err := MakeAssetCreateTxn(account, note, params, total, decimals, defaultFrozen, manager, reserve, freeze, clawback,
+		unitName, assetName, url, string(metadataHash[:]), opts...)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	if err := assetmetadata.ValidateARC3Asset(tx.AssetParams, metadataJSON); err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, nil
+}
+
+// MakeAssetConfigTxn creates a tx template for changing the
+// keys for an asset. An empty string means a zero key (which
+// cannot be changed after becoming zero); to keep a key
+// unchanged, you must specify that key.
+// - account is a checksummed, human-readable address that will send the transaction
+// - note is an arbitrary byte array
+// - index is the asset index
+// - strictEmptyAddressChecking: if true, disallow empty admin accounts from being set (preventing accidental disable of asset features)
+func MakeAssetConfigTxn(account string, note []byte, params types.SuggestedParams,
+	index uint64, newManager, newReserve, newFreeze, newClawback string, strictEmptyAddressChecking bool, opts ...transaction.TxnOption) (types.Transaction, error) {
+	tx, err := transaction.MakeAssetConfigTxnWithFlatFee(account, 0, uint64(params.FirstRoundValid), uint64(params.LastRoundValid),
+		note, params.GenesisID, genesisHashString(params),
+		index, newManager, newReserve, newFreeze, newClawback, strictEmptyAddressChecking)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, setOptionsAndFee(&tx, params, opts)
+}
+
+// MakeAssetTransferTxn creates a tx for sending some asset from an asset holder to another user
+// the recipient address must have previously issued an asset acceptance transaction for this asset
+// - account is a checksummed, human-readable address that will send the transaction and assets
+// - recipient is a checksummed, human-readable address what will receive the assets
+// - amount is the number of assets to send
+// - note is an arbitrary byte array
+// - closeAssetsTo is a checksummed, human-readable address that behaves as a close-to address for the asset transaction; the remaining assets not sent to recipient will be sent to closeAssetsTo. Leave blank for no close-to behavior.
+// - index is the asset index
+func MakeAssetTransferTxn(account, recipient string, amount uint64, note []byte, params types.SuggestedParams,
+	closeAssetsTo string, index uint64, opts ...transaction.TxnOption) (types.Transaction, error) {
+	tx, err := transaction.MakeAssetTransferTxnWithFlatFee(account, recipient, closeAssetsTo, amount, 0,
+		uint64(params.FirstRoundValid), uint64(params.LastRoundValid), note, params.GenesisID, genesisHashString(params), index)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, setOptionsAndFee(&tx, params, opts)
+}
+
+// MakeAssetAcceptanceTxn creates a tx for marking an account as willing to accept the given asset
+// - account is a checksummed, human-readable address that will send the transaction and begin accepting the asset
+// - note is an arbitrary byte array
+// - index is the asset index
+func MakeAssetAcceptanceTxn(account string, note []byte, params types.SuggestedParams, index uint64, opts ...transaction.TxnOption) (types.Transaction, error) {
+	return MakeAssetTransferTxn(account, account, 0, note, params, "", index, opts...)
+}
+
+// MakeAssetRevocationTxn creates a tx for revoking an asset from an account and sending it to another
+// - account is a checksummed, human-readable address; it must be the revocation manager / clawback address from the asset's parameters
+// - target is a checksummed, human-readable address; it is the account whose assets will be revoked
+// - amount is the number of assets to revoke
+// - recipient is a checksummed, human-readable address; it will receive the revoked assets
+// - note is an arbitrary byte array
+// - index is the asset index
+func MakeAssetRevocationTxn(account, target string, amount uint64, recipient string, note []byte, params types.SuggestedParams,
+	index uint64, opts ...transaction.TxnOption) (types.Transaction, error) {
+	tx, err := transaction.MakeAssetRevocationTxnWithFlatFee(account, target, recipient, amount, 0,
+		uint64(params.FirstRoundValid), uint64(params.LastRoundValid), note, params.GenesisID, genesisHashString(params), "", index)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, setOptionsAndFee(&tx, params, opts)
+}
+
+// MakeAssetDestroyTxn creates a tx template for destroying an asset, removing it from the record.
+// All outstanding asset amount must be held by the creator, and this transaction must be issued by the asset manager.
+// - account is a checksummed, human-readable address that will send the transaction; it also must be the asset manager
+// - note is an arbitrary byte array
+// - index is the asset index
+func MakeAssetDestroyTxn(account string, note []byte, params types.SuggestedParams, index uint64, opts ...transaction.TxnOption) (types.Transaction, error) {
+	// an asset destroy transaction is just a configuration transaction with AssetParams zeroed
+	return MakeAssetConfigTxn(account, note, params, index, "", "", "", "", false, opts...)
+}
+
+// MakeAssetFreezeTxn constructs a transaction that freezes or unfreezes an account's asset holdings
+// It must be issued by the freeze address for the asset
+// - account is a checksummed, human-readable address which will send the transaction.
+// - note is an optional arbitrary byte array
+// - assetIndex is the index for tracking the asset
+// - target is the account to be frozen or unfrozen
+// - newFreezeSetting is the new state of the target account
+func MakeAssetFreezeTxn(account string, note []byte, params types.SuggestedParams,
+	assetIndex uint64, target string, newFreezeSetting bool, opts ...transaction.TxnOption) (types.Transaction, error) {
+	tx, err := transaction.MakeAssetFreezeTxnWithFlatFee(account, 0, uint64(params.FirstRoundValid), uint64(params.LastRoundValid),
+		note, params.GenesisID, genesisHashString(params), "", assetIndex, target, newFreezeSetting)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, setOptionsAndFee(&tx, params, opts)
+}
+
+// setOptionsAndFee applies opts to tx, then sets its fee from params
+func setOptionsAndFee(tx *types.Transaction, params types.SuggestedParams, opts []transaction.TxnOption) error {
+	if err := transaction.ApplyOptions(tx, opts...); err != nil {
+		return err
+	}
+	return transaction.SetFee(tx, params)
+}
+
+// genesisHashString returns the genesis hash of params as the transaction
+// package's constructors take it, base64 encoded
+func genesisHashString(params types.SuggestedParams) string {
+	return base64.StdEncoding.EncodeToString(params.GenesisHash)
+}
+
+// decodeKey decodes the base64-encoded key named name into out, which must be
+// exactly as long as the key
+func decodeKey(name, key string, out []byte) error {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %v", name, err)
+	}
+	if len(decoded) != len(out) {
+		return fmt.Errorf("%s must be %d bytes, got %d", name, len(out), len(decoded))
+	}
+	copy(out, decoded)
+	return nil
+}
@@ -0,0 +1,23 @@
+package future
+
+import (
+	"github.com/algorand/go-algorand-sdk/wallet"
+)
+
+// The transaction signers are defined by the wallet package, so that the
+// templates can use them too; the aliases below keep their names here.
+
+// TransactionSigner signs some of the transactions in a group.
+type TransactionSigner = wallet.TransactionSigner
+
+// BasicAccountTransactionSigner signs transactions with a single account's private key.
+type BasicAccountTransactionSigner = wallet.BasicAccountTransactionSigner
+
+// LogicSigAccountTransactionSigner signs transactions with a logic signature.
+type LogicSigAccountTransactionSigner = wallet.LogicSigAccountTransactionSigner
+
+// MultiSigAccountTransactionSigner signs transactions for a multisig account.
+type MultiSigAccountTransactionSigner = wallet.MultiSigAccountTransactionSigner
+
+// KMDTransactionSigner signs transactions with the keys held in a kmd wallet.
+type KMDTransactionSigner = wallet.KMDTransactionSigner
@@ -0,0 +1,202 @@
+package future
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/assetmetadata"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/protocol"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+const testAddr = "BH55E5RMBD4GYWXGX5W5PJ5JAHPGM5OXKDQH5DC4O2MGI7NW4H6VOE4CP4"
+const testGenesisHash = "SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI="
+
+func makeTestParams(fee uint64, flatFee bool) types.SuggestedParams {
+	gh, _ := base64.StdEncoding.DecodeString(testGenesisHash)
+	return types.SuggestedParams{
+		Fee:             types.MicroAlgos(fee),
+		GenesisID:       "testnet-v1.0",
+		GenesisHash:     gh,
+		FirstRoundValid: 322575,
+		LastRoundValid:  323575,
+		FlatFee:         flatFee,
+	}
+}
+
+func TestMakePaymentTxn(t *testing.T) {
+	params := makeTestParams(10, false)
+	tx, err := MakePaymentTxn(testAddr, testAddr, 5, []byte("note"), "", params)
+	require.NoError(t, err)
+
+	expected, err := transaction.MakePaymentTxn(testAddr, testAddr, 10, 5, 322575, 323575, []byte("note"), "", "testnet-v1.0", params.GenesisHash)
+	require.NoError(t, err)
+	expected.Fee = 0
+	size, err := transaction.EstimateSize(expected)
+	require.NoError(t, err)
+	expected.Fee = types.MicroAlgos(size * 10)
+	require.Equal(t, expected, tx)
+
+	// the minimum fee applies to fees per byte
+	params.Fee = 1
+	tx, err = MakePaymentTxn(testAddr, testAddr, 5, nil, "", params)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(protocol.Current.MinTxnFee), tx.Fee)
+	params.MinFee = 2000
+	tx, err = MakePaymentTxn(testAddr, testAddr, 5, nil, "", params)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(2000), tx.Fee)
+
+	// but not to flat fees
+	params = makeTestParams(0, true)
+	tx, err = MakePaymentTxn(testAddr, testAddr, 5, nil, "", params)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(0), tx.Fee)
+
+	params.GenesisHash = nil
+	_, err = MakePaymentTxn(testAddr, testAddr, 5, nil, "", params)
+	require.Error(t, err)
+}
+
+func TestMakeAssetTxns(t *testing.T) {
+	params := makeTestParams(2500, true)
+
+	tx, err := MakeAssetCreateTxn(testAddr, nil, params, 100, 2, false, testAddr, testAddr, testAddr, testAddr, "tst", "testcoin", "website", "")
+	require.NoError(t, err)
+	require.Equal(t, types.AssetConfigTx, tx.Type)
+	require.Equal(t, types.MicroAlgos(2500), tx.Fee)
+	require.Equal(t, types.Round(322575), tx.FirstValid)
+	require.Equal(t, types.Round(323575), tx.LastValid)
+	require.Equal(t, "testnet-v1.0", tx.GenesisID)
+	require.Equal(t, params.GenesisHash, tx.GenesisHash[:])
+	require.Equal(t, uint64(100), tx.AssetParams.Total)
+	require.Equal(t, uint32(2), tx.AssetParams.Decimals)
+
+	tx, err = MakeAssetConfigTxn(testAddr, nil, params, 1, testAddr, testAddr, testAddr, testAddr, true)
+	require.NoError(t, err)
+	require.Equal(t, types.AssetIndex(1), tx.ConfigAsset)
+
+	tx, err = MakeAssetDestroyTxn(testAddr, nil, params, 1)
+	require.NoError(t, err)
+	require.Equal(t, types.AssetIndex(1), tx.ConfigAsset)
+	require.Equal(t, types.AssetParams{}, tx.AssetParams)
+
+	tx, err = MakeAssetTransferTxn(testAddr, testAddr, 3, nil, params, testAddr, 1)
+	require.NoError(t, err)
+	require.Equal(t, types.AssetIndex(1), tx.XferAsset)
+	require.Equal(t, uint64(3), tx.AssetAmount)
+	require.NotEqual(t, types.Address{}, tx.AssetCloseTo)
+
+	tx, err = MakeAssetAcceptanceTxn(testAddr, nil, params, 1)
+	require.NoError(t, err)
+	require.Equal(t, tx.Sender, tx.AssetReceiver)
+	require.Equal(t, uint64(0), tx.AssetAmount)
+
+	tx, err = MakeAssetRevocationTxn(testAddr, testAddr, 3, testAddr, nil, params, 1)
+	require.NoError(t, err)
+	require.Equal(t, tx.Sender, tx.AssetSender)
+
+	tx, err = MakeAssetFreezeTxn(testAddr, nil, params, 1, testAddr, true)
+	require.NoError(t, err)
+	require.Equal(t, types.AssetIndex(1), tx.FreezeAsset)
+	require.True(t, tx.AssetFrozen)
+	require.Equal(t, types.MicroAlgos(2500), tx.Fee)
+
+	lease, err := types.MakeLease(nil)
+	require.NoError(t, err)
+	tx, err = MakeAssetAcceptanceTxn(testAddr, nil, params, 1, transaction.WithLease(lease[:]))
+	require.NoError(t, err)
+	require.Equal(t, lease, tx.Lease)
+	_, err = MakeAssetDestroyTxn(testAddr, nil, params, 1, transaction.WithLease(nil))
+	require.Error(t, err)
+
+	params.GenesisHash = []byte{1, 2, 3}
+	_, err = MakeAssetFreezeTxn(testAddr, nil, params, 1, testAddr, true)
+	require.Error(t, err)
+}
+
+func TestMakeARC3AssetCreateTxn(t *testing.T) {
+	params := makeTestParams(2500, true)
+	metadataJSON := []byte(`{"name":"My Song","decimals":0}`)
+
+	tx, err := MakeARC3AssetCreateTxn(testAddr, nil, params, 1, 0, false, testAddr, "", "", "", "song", "My Song@arc3", "ipfs://cid", metadataJSON)
+	require.NoError(t, err)
+	hash, err := assetmetadata.ARC3MetadataHash(metadataJSON)
+	require.NoError(t, err)
+	require.Equal(t, hash, tx.AssetParams.MetadataHash)
+
+	_, err = MakeARC3AssetCreateTxn(testAddr, nil, params, 1, 0, false, testAddr, "", "", "", "song", "My Song", "ipfs://cid", metadataJSON)
+	require.Error(t, err)
+	_, err = MakeARC3AssetCreateTxn(testAddr, nil, params, 100, 2, false, testAddr, "", "", "", "song", "My Song@arc3", "ipfs://cid", metadataJSON)
+	require.Error(t, err)
+	_, err = MakeAssetCreateTxn(testAddr, nil, params, 1, 0, false, testAddr, "", "", "", "song", "My Song", "ipfs://cid#arc3", "short")
+	require.Error(t, err)
+}
+
+func TestMakeKeyRegTxn(t *testing.T) {
+	params := makeTestParams(10, false)
+	const voteKey = "Kv7QI7chi1y6axoy+t7wzAVpePqRq/rkjzWh/RMYyLo="
+	const selectionKey = "bPgrv4YogPcdaUAxrt1QysYZTVyRAuUMD4zQmCu9llc="
+	tx, err := MakeKeyRegTxn(testAddr, nil, params, voteKey, selectionKey, 10000, 10111, 11)
+	require.NoError(t, err)
+	require.Equal(t, types.KeyRegistrationTx, tx.Type)
+	require.Equal(t, types.Round(10000), tx.VoteFirst)
+	require.Equal(t, uint64(11), tx.VoteKeyDilution)
+
+	size, err := transaction.EstimateSize(types.Transaction{Type: tx.Type, Header: types.Header{
+		Sender: tx.Sender, FirstValid: tx.FirstValid, LastValid: tx.LastValid, GenesisID: tx.GenesisID, GenesisHash: tx.GenesisHash,
+	}, KeyregTxnFields: tx.KeyregTxnFields})
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(size*10), tx.Fee)
+}
+
+func TestMakeKeyRegTxnWithStateProofKey(t *testing.T) {
+	params := makeTestParams(1000, true)
+	const voteKey = "Kv7QI7chi1y6axoy+t7wzAVpePqRq/rkjzWh/RMYyLo="
+	const selectionKey = "bPgrv4YogPcdaUAxrt1QysYZTVyRAuUMD4zQmCu9llc="
+	const stateProofKey = "mYR0GVEObMTSNdsKM6RwYywHYPqVDqg3E4JFzxZOreH9NU8B+tKzUanyY8AQ144hETgSMX7fXWwjBdHz6AWk9w=="
+
+	// online
+	tx, err := MakeKeyRegTxnWithStateProofKey(testAddr, nil, params, voteKey, selectionKey, stateProofKey, 10000, 10111, 11, false)
+	require.NoError(t, err)
+	require.Equal(t, types.KeyRegistrationTx, tx.Type)
+	require.Equal(t, types.MicroAlgos(1000), tx.Fee)
+	spk, _ := base64.StdEncoding.DecodeString(stateProofKey)
+	require.Equal(t, spk, tx.StateProofPK[:])
+	require.Equal(t, types.Round(10111), tx.VoteLast)
+	require.Equal(t, uint64(11), tx.VoteKeyDilution)
+	require.False(t, tx.Nonparticipation)
+	encoded := string(msgpack.Encode(tx))
+	require.Contains(t, encoded, "sprfkey")
+	require.NotContains(t, encoded, "nonpart")
+
+	// the state proof key is optional
+	tx, err = MakeKeyRegTxnWithStateProofKey(testAddr, nil, params, voteKey, selectionKey, "", 10000, 10111, 11, false)
+	require.NoError(t, err)
+	require.Equal(t, types.MerkleVerifier{}, tx.StateProofPK)
+	require.NotContains(t, string(msgpack.Encode(tx)), "sprfkey")
+
+	// offline
+	tx, err = MakeKeyRegTxnWithStateProofKey(testAddr, nil, params, "", "", "", 0, 0, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, types.KeyregTxnFields{}, tx.KeyregTxnFields)
+
+	// nonparticipating
+	tx, err = MakeKeyRegTxnWithStateProofKey(testAddr, nil, params, "", "", "", 0, 0, 0, true)
+	require.NoError(t, err)
+	require.True(t, tx.Nonparticipation)
+	require.Contains(t, string(msgpack.Encode(tx)), "nonpart")
+
+	_, err = MakeKeyRegTxnWithStateProofKey(testAddr, nil, params, voteKey, selectionKey, "", 10000, 10111, 11, true)
+	require.Error(t, err)
+	_, err = MakeKeyRegTxnWithStateProofKey(testAddr, nil, params, voteKey, "", "", 10000, 10111, 11, false)
+	require.Error(t, err)
+	_, err = MakeKeyRegTxnWithStateProofKey(testAddr, nil, params, voteKey, selectionKey, voteKey, 10000, 10111, 11, false)
+	require.EqualError(t, err, "state proof key must be 64 bytes, got 32")
+	_, err = MakeKeyRegTxnWithStateProofKey(testAddr, nil, params, voteKey, selectionKey, "", 10111, 10000, 11, false)
+	require.Error(t, err)
+}
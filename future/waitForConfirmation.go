@@ -0,0 +1,68 @@
+// Package future contains helpers built on the v2 clients, such as transaction
+// constructors taking the suggested params fetched from algod, waiting for
+// transactions to be confirmed and composing atomic transaction groups.
+package future
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+)
+
+// WaitForConfirmation waits until the transaction txid is confirmed or rejected,
+// or until waitRounds rounds have passed without either, whichever comes first.
+// A waitRounds of 0 waits indefinitely (or until ctx is done).
+//
+// The returned response holds the round the transaction was confirmed in. If
+// the node dropped the transaction from its pool, the response's PoolError says
+// why and an error is returned.
+func WaitForConfirmation(ctx context.Context, c client.AlgodClient, txid string, waitRounds uint64, headers ...*common.Header) (txInfo models.PendingTransactionInfoResponse, err error) {
+	return WaitForConfirmationWith(ctx, client.AlgodResults{Client: c, Headers: headers}, txid, waitRounds)
+}
+
+// WaitForConfirmationWith waits for the transaction txid as
+// WaitForConfirmation does, with the status and pending transactions of c,
+// which can be an in-memory implementation rather than an algod client.
+func WaitForConfirmationWith(ctx context.Context, c client.ConfirmationClient, txid string, waitRounds uint64) (txInfo models.PendingTransactionInfoResponse, err error) {
+	status, err := c.Status(ctx)
+	if err != nil {
+		return
+	}
+
+	startRound := status.LastRound + 1
+	currentRound := startRound
+
+	for {
+		if waitRounds > 0 && currentRound >= startRound+waitRounds {
+			err = fmt.Errorf("transaction %s not confirmed after %d rounds", txid, waitRounds)
+			return
+		}
+
+		txInfo, err = c.PendingTransactionInformation(ctx, txid)
+		if err == nil {
+			if txInfo.ConfirmedRound > 0 {
+				// Transaction confirmed
+				return
+			}
+			if txInfo.PoolError != "" {
+				// Transaction was rejected by the node
+				err = fmt.Errorf("transaction %s rejected: %s", txid, txInfo.PoolError)
+				return
+			}
+		} else if httpErr, ok := err.(*common.HTTPError); !ok || httpErr.Status != http.StatusNotFound {
+			// the node may not have seen the transaction yet, any other error is fatal
+			return
+		}
+
+		// Wait until the next round before checking again
+		_, err = c.StatusAfterBlock(ctx, currentRound)
+		if err != nil {
+			return
+		}
+		currentRound++
+	}
+}
@@ -0,0 +1,170 @@
+package future
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/client/v2/algod"
+	"github.com/algorand/go-algorand-sdk/client/v2/common"
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+)
+
+// mockAlgod serves status and pending transaction requests; the transaction is
+// unknown until round seenRound, and then reported with pendingInfo from confirmRound
+func mockAlgod(t *testing.T, seenRound, confirmRound uint64, pendingInfo map[string]interface{}) *httptest.Server {
+	round := uint64(10)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/status":
+			w.Write([]byte(`{"last-round":10}`))
+		case strings.HasPrefix(r.URL.Path, "/v2/status/wait-for-block-after/"):
+			round++
+			w.Write([]byte(`{"last-round":` + strings.TrimPrefix(r.URL.Path, "/v2/status/wait-for-block-after/") + `}`))
+		case r.URL.Path == "/v2/transactions/pending/TXID":
+			if round < seenRound {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"message":"txn not found"}`))
+				return
+			}
+			info := map[string]interface{}{"pool-error": ""}
+			if round >= confirmRound {
+				info = pendingInfo
+			}
+			w.Write(msgpack.Encode(info))
+		default:
+			t.Fatalf("unexpected request %s", r.URL.Path)
+		}
+	}))
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	server := mockAlgod(t, 12, 13, map[string]interface{}{"confirmed-round": uint64(13), "pool-error": ""})
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	txInfo, err := WaitForConfirmation(context.Background(), client, "TXID", 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(13), txInfo.ConfirmedRound)
+
+	// not enough rounds
+	server2 := mockAlgod(t, 12, 13, map[string]interface{}{"confirmed-round": uint64(13), "pool-error": ""})
+	defer server2.Close()
+	client, err = algod.MakeClient(server2.URL, "")
+	require.NoError(t, err)
+	_, err = WaitForConfirmation(context.Background(), client, "TXID", 2)
+	require.Error(t, err)
+}
+
+// countingAlgod is a middleware counting the pending transaction requests of
+// the client it wraps
+type countingAlgod struct {
+	client.AlgodClient
+	pendingRequests int
+}
+
+func (c *countingAlgod) PendingTransactionInformation(txid string) *algod.PendingTransactionInformation {
+	c.pendingRequests++
+	return c.AlgodClient.PendingTransactionInformation(txid)
+}
+
+func TestWaitForConfirmationMiddleware(t *testing.T) {
+	server := mockAlgod(t, 12, 13, map[string]interface{}{"confirmed-round": uint64(13), "pool-error": ""})
+	defer server.Close()
+	algodClient, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	counting := &countingAlgod{AlgodClient: algodClient}
+	txInfo, err := WaitForConfirmation(context.Background(), counting, "TXID", 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(13), txInfo.ConfirmedRound)
+	require.Equal(t, 4, counting.pendingRequests)
+}
+
+// memoryAlgod is an in-memory client.ConfirmationClient, at round 10, which
+// sees the transaction TXID from round seenRound and confirms it at confirmRound
+type memoryAlgod struct {
+	round, seenRound, confirmRound uint64
+	poolError                      string
+}
+
+func (m *memoryAlgod) Status(ctx context.Context) (models.NodeStatusResponse, error) {
+	return models.NodeStatusResponse{LastRound: m.round}, nil
+}
+
+func (m *memoryAlgod) StatusAfterBlock(ctx context.Context, round uint64) (models.NodeStatusResponse, error) {
+	if m.round <= round {
+		m.round = round + 1
+	}
+	return models.NodeStatusResponse{LastRound: m.round}, nil
+}
+
+func (m *memoryAlgod) PendingTransactionInformation(ctx context.Context, txid string) (models.PendingTransactionInfoResponse, error) {
+	if txid != "TXID" || m.round < m.seenRound {
+		return models.PendingTransactionInfoResponse{}, &common.HTTPError{Status: http.StatusNotFound, Body: []byte(`{"message":"txn not found"}`)}
+	}
+	if m.round < m.confirmRound {
+		return models.PendingTransactionInfoResponse{}, nil
+	}
+	if m.poolError != "" {
+		return models.PendingTransactionInfoResponse{PoolError: m.poolError}, nil
+	}
+	return models.PendingTransactionInfoResponse{ConfirmedRound: m.confirmRound}, nil
+}
+
+func TestWaitForConfirmationWith(t *testing.T) {
+	ctx := context.Background()
+	txInfo, err := WaitForConfirmationWith(ctx, &memoryAlgod{round: 10, seenRound: 12, confirmRound: 13}, "TXID", 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(13), txInfo.ConfirmedRound)
+
+	// not enough rounds
+	_, err = WaitForConfirmationWith(ctx, &memoryAlgod{round: 10, seenRound: 12, confirmRound: 13}, "TXID", 2)
+	require.EqualError(t, err, "transaction TXID not confirmed after 2 rounds")
+
+	txInfo, err = WaitForConfirmationWith(ctx, &memoryAlgod{round: 10, seenRound: 11, confirmRound: 11, poolError: "overspend"}, "TXID", 0)
+	require.EqualError(t, err, "transaction TXID rejected: overspend")
+	require.Equal(t, "overspend", txInfo.PoolError)
+}
+
+func TestWaitForConfirmationRejected(t *testing.T) {
+	server := mockAlgod(t, 11, 11, map[string]interface{}{"pool-error": "overspend"})
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	txInfo, err := WaitForConfirmation(context.Background(), client, "TXID", 0)
+	require.Error(t, err)
+	require.Equal(t, "overspend", txInfo.PoolError)
+}
+
+func TestWaitForConfirmationCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/status" {
+			w.Write([]byte(`{"last-round":10}`))
+			return
+		}
+		if r.URL.Path == "/v2/transactions/pending/TXID" {
+			w.Write(msgpack.Encode(map[string]interface{}{"pool-error": ""}))
+			return
+		}
+		// never produce a block
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	client, err := algod.MakeClient(server.URL, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = WaitForConfirmation(ctx, client, "TXID", 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,42 @@
+package hdwallet
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"strings"
+)
+
+// bip39Iterations is the number of PBKDF2 iterations of BIP-39 seeds
+const bip39Iterations = 2048
+
+// SeedFromMnemonic returns the 64 byte BIP-39 seed of a mnemonic and optional
+// passphrase, the seed ARC-52 wallets derive their root key from. The words of
+// the mnemonic are not checked, and are expected in NFKD form, as the words of
+// the English wordlist are. This is not the seed of the 25 word mnemonics of
+// the mnemonic package, which encode a single key.
+func SeedFromMnemonic(mnemonic, passphrase string) []byte {
+	password := []byte(strings.Join(strings.Fields(mnemonic), " "))
+	salt := []byte("mnemonic" + passphrase)
+	return pbkdf2SHA512(password, salt, bip39Iterations)
+}
+
+// pbkdf2SHA512 returns the first block of PBKDF2 with HMAC-SHA512, 64 bytes
+func pbkdf2SHA512(password, salt []byte, iterations int) []byte {
+	mac := hmac.New(sha512.New, password)
+	var blockIndex [4]byte
+	binary.BigEndian.PutUint32(blockIndex[:], 1)
+	mac.Write(salt)
+	mac.Write(blockIndex[:])
+	u := mac.Sum(nil)
+	key := append([]byte{}, u...)
+	for i := 1; i < iterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		for j := range key {
+			key[j] ^= u[j]
+		}
+	}
+	return key
+}
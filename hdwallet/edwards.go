@@ -0,0 +1,169 @@
+package hdwallet
+
+import (
+	"crypto/sha512"
+	"errors"
+	"math/big"
+)
+
+// The arithmetic of edwards25519 needed to use extended keys, whose scalars are
+// not derived from a seed as the ed25519 package expects. It uses math/big and
+// is not constant time.
+
+var (
+	// fieldP is the order of the field, 2^255 - 19
+	fieldP, _ = new(big.Int).SetString("57896044618658097711785492504343953926634992332820282019728792003956564819949", 10)
+	// groupL is the order of the base point, 2^252 + 27742317777372353535851937790883648493
+	groupL, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+	// curveD is the constant -121665/121666 of the curve
+	curveD, _ = new(big.Int).SetString("37095705934669439343138083508754565189542113879843219016388785533085940283555", 10)
+	// sqrtM1 is a square root of -1
+	sqrtM1, _ = new(big.Int).SetString("19681161376707505956807079304988542015446066515923890162744021073123829784752", 10)
+
+	basePoint = point{
+		x: bigFromString("15112221349535400772501151409588531511454012693041857206046113283949847762202"),
+		y: bigFromString("46316835694926478169428394003475163141307993866256225615783033603165251855960"),
+	}
+)
+
+func bigFromString(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 10)
+	return n
+}
+
+// point is a point of the curve in affine coordinates
+type point struct {
+	x, y *big.Int
+}
+
+func identity() point {
+	return point{x: big.NewInt(0), y: big.NewInt(1)}
+}
+
+// add returns p + q
+func (p point) add(q point) point {
+	x1y2 := new(big.Int).Mul(p.x, q.y)
+	y1x2 := new(big.Int).Mul(p.y, q.x)
+	x1x2 := new(big.Int).Mul(p.x, q.x)
+	y1y2 := new(big.Int).Mul(p.y, q.y)
+	dxy := new(big.Int).Mul(curveD, x1x2)
+	dxy.Mul(dxy, y1y2).Mod(dxy, fieldP)
+
+	xDen := new(big.Int).Add(big.NewInt(1), dxy)
+	yDen := new(big.Int).Sub(big.NewInt(1), dxy)
+	yDen.Mod(yDen, fieldP)
+	x := x1y2.Add(x1y2, y1x2)
+	x.Mul(x, xDen.ModInverse(xDen, fieldP)).Mod(x, fieldP)
+	y := y1y2.Add(y1y2, x1x2)
+	y.Mul(y, yDen.ModInverse(yDen, fieldP)).Mod(y, fieldP)
+	return point{x: x, y: y}
+}
+
+// mul returns n * p
+func (p point) mul(n *big.Int) point {
+	result := identity()
+	for i := n.BitLen() - 1; i >= 0; i-- {
+		result = result.add(result)
+		if n.Bit(i) == 1 {
+			result = result.add(p)
+		}
+	}
+	return result
+}
+
+// encode returns the 32 byte encoding of the point: its y coordinate, little
+// endian, with the sign of x in the top bit
+func (p point) encode() [32]byte {
+	var encoded [32]byte
+	putLittleEndian(encoded[:], p.y)
+	if p.x.Bit(0) == 1 {
+		encoded[31] |= 0x80
+	}
+	return encoded
+}
+
+// decodePoint decodes the 32 byte encoding of a point
+func decodePoint(encoded [32]byte) (point, error) {
+	sign := encoded[31] >> 7
+	encoded[31] &= 0x7f
+	y := littleEndian(encoded[:])
+	if y.Cmp(fieldP) >= 0 {
+		return point{}, errors.New("invalid point encoding")
+	}
+
+	// x^2 = (y^2 - 1) / (d y^2 + 1)
+	y2 := new(big.Int).Mul(y, y)
+	num := new(big.Int).Sub(y2, big.NewInt(1))
+	den := new(big.Int).Mul(curveD, y2)
+	den.Add(den, big.NewInt(1))
+	x2 := num.Mul(num, den.ModInverse(den, fieldP))
+	x2.Mod(x2, fieldP)
+
+	// fieldP is 5 mod 8, so a square root of x2 is x2^((p+3)/8), possibly
+	// times the square root of -1
+	exp := new(big.Int).Add(fieldP, big.NewInt(3))
+	exp.Rsh(exp, 3)
+	x := new(big.Int).Exp(x2, exp, fieldP)
+	if new(big.Int).Exp(x, big.NewInt(2), fieldP).Cmp(x2) != 0 {
+		x.Mul(x, sqrtM1).Mod(x, fieldP)
+	}
+	if new(big.Int).Exp(x, big.NewInt(2), fieldP).Cmp(x2) != 0 {
+		return point{}, errors.New("invalid point encoding: not on the curve")
+	}
+	if x.Sign() == 0 && sign == 1 {
+		return point{}, errors.New("invalid point encoding")
+	}
+	if uint8(x.Bit(0)) != sign {
+		x.Sub(fieldP, x)
+	}
+	return point{x: x, y: y}, nil
+}
+
+// scalarBaseMul returns the encoding of n * B, n a little endian scalar used
+// as is, without clamping
+func scalarBaseMul(n []byte) [32]byte {
+	return basePoint.mul(littleEndian(n)).encode()
+}
+
+// sign returns the ed25519 signature of message by the key with scalar kL and
+// nonce key kR, whose public key is publicKey
+func sign(kL, kR, publicKey, message []byte) []byte {
+	h := sha512.New()
+	h.Write(kR)
+	h.Write(message)
+	r := new(big.Int).Mod(littleEndian(h.Sum(nil)), groupL)
+	R := basePoint.mul(r).encode()
+
+	h.Reset()
+	h.Write(R[:])
+	h.Write(publicKey)
+	h.Write(message)
+	k := new(big.Int).Mod(littleEndian(h.Sum(nil)), groupL)
+
+	s := k.Mul(k, littleEndian(kL))
+	s.Add(s, r).Mod(s, groupL)
+	signature := make([]byte, 64)
+	copy(signature, R[:])
+	putLittleEndian(signature[32:], s)
+	return signature
+}
+
+// littleEndian returns the integer with little endian bytes b
+func littleEndian(b []byte) *big.Int {
+	reversed := make([]byte, len(b))
+	for i := range b {
+		reversed[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(reversed)
+}
+
+// putLittleEndian writes n to b in little endian, n fitting in len(b) bytes
+func putLittleEndian(b []byte, n *big.Int) {
+	bigEndian := n.Bytes()
+	for i := range b {
+		b[i] = 0
+	}
+	for i := range bigEndian {
+		b[i] = bigEndian[len(bigEndian)-1-i]
+	}
+}
@@ -0,0 +1,238 @@
+// Package hdwallet derives Algorand accounts from a single seed, following
+// ARC-52: keys are derived with BIP32-Ed25519, with the top 9 bits of the
+// derivation scalars cleared as Peikert suggests, along BIP-44 paths
+// m/44'/283'/account'/change/index.
+//
+// Derived keys are extended ed25519 keys: their scalar is not the hash of a
+// seed, so they are not ed25519.PrivateKeys and sign with ExtendedKey.Sign. The
+// arithmetic used is not constant time, so keys should be derived and used on
+// trusted machines only.
+package hdwallet
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/types"
+	"github.com/algorand/go-algorand-sdk/wallet"
+)
+
+// HardenedOffset is added to the index of hardened children, whose keys can
+// only be derived from their parent's private key
+const HardenedOffset uint32 = 0x80000000
+
+// The BIP-44 purpose and the coin types of ARC-52
+const (
+	Purpose uint32 = 44
+
+	// CoinTypeAlgorand is the coin type of keys of Algorand accounts
+	CoinTypeAlgorand uint32 = 283
+
+	// CoinTypeIdentity is the coin type of identity keys, e.g. for
+	// decentralized identifiers
+	CoinTypeIdentity uint32 = 0
+)
+
+// DerivationBits is the number of top bits cleared from the derivation
+// scalars, as ARC-52 specifies
+const DerivationBits = 9
+
+// Harden returns the index of the hardened child with the given index
+func Harden(index uint32) uint32 {
+	return index | HardenedOffset
+}
+
+// Path returns the BIP-44 path m/44'/coinType'/account'/change/index
+func Path(coinType, account, change, index uint32) []uint32 {
+	return []uint32{Harden(Purpose), Harden(coinType), Harden(account), change, index}
+}
+
+// AddressPath returns the path of the key of an Algorand account,
+// m/44'/283'/account'/change/index
+func AddressPath(account, change, index uint32) []uint32 {
+	return Path(CoinTypeAlgorand, account, change, index)
+}
+
+// ExtendedKey is a BIP32-Ed25519 private key: the scalar KL, the nonce key KR
+// used in signatures, and the chain code children are derived with
+type ExtendedKey struct {
+	KL        [32]byte
+	KR        [32]byte
+	ChainCode [32]byte
+}
+
+// MakeRootKey returns the root key of the seed, e.g. the BIP-39 seed of a
+// mnemonic
+func MakeRootKey(seed []byte) ExtendedKey {
+	k := sha512.Sum512(seed)
+	// the third highest bit of the scalar must be clear, so hash until it is
+	for k[31]&0x20 != 0 {
+		mac := hmac.New(sha512.New, k[:32])
+		mac.Write(k[32:])
+		copy(k[:], mac.Sum(nil))
+	}
+	var key ExtendedKey
+	copy(key.KL[:], k[:32])
+	copy(key.KR[:], k[32:])
+	key.KL[0] &= 0xf8
+	key.KL[31] &= 0x7f
+	key.KL[31] |= 0x40
+	key.ChainCode = sha256.Sum256(append([]byte{0x01}, seed...))
+	return key
+}
+
+// PublicKey returns the public key of the key
+func (k ExtendedKey) PublicKey() ed25519.PublicKey {
+	pk := scalarBaseMul(k.KL[:])
+	return ed25519.PublicKey(pk[:])
+}
+
+// Address returns the address of the account of the key
+func (k ExtendedKey) Address() types.Address {
+	return types.Address(scalarBaseMul(k.KL[:]))
+}
+
+// ExtendedPublicKey returns the extended public key of the key, which derives
+// the public keys of its non-hardened children
+func (k ExtendedKey) ExtendedPublicKey() ExtendedPublicKey {
+	return ExtendedPublicKey{PublicKey: scalarBaseMul(k.KL[:]), ChainCode: k.ChainCode}
+}
+
+// DeriveChild returns the key of the child with the given index, hardened if
+// index is at least HardenedOffset
+func (k ExtendedKey) DeriveChild(index uint32) (ExtendedKey, error) {
+	var data []byte
+	if index >= HardenedOffset {
+		data = append(append([]byte{0x00}, k.KL[:]...), k.KR[:]...)
+	} else {
+		pk := scalarBaseMul(k.KL[:])
+		data = append([]byte{0x02}, pk[:]...)
+	}
+	data = appendIndex(data, index)
+	z := hmacSHA512(k.ChainCode[:], data)
+	data[0]++
+	chainCode := hmacSHA512(k.ChainCode[:], data)
+
+	// KL + 8 * truncated zL, which must not overflow 256 bits
+	kL := scaledScalar(z[:32])
+	kL.Add(kL, littleEndian(k.KL[:]))
+	if kL.BitLen() > 256 {
+		return ExtendedKey{}, fmt.Errorf("child %d has an invalid key", index)
+	}
+	// KR + zR, modulo 2^256
+	kR := littleEndian(z[32:])
+	kR.Add(kR, littleEndian(k.KR[:]))
+
+	var child ExtendedKey
+	putLittleEndian(child.KL[:], kL)
+	putLittleEndian(child.KR[:], kR.And(kR, mask256))
+	copy(child.ChainCode[:], chainCode[32:])
+	return child, nil
+}
+
+// DerivePath returns the key at the given path from the key, such as one made
+// by AddressPath
+func (k ExtendedKey) DerivePath(path []uint32) (ExtendedKey, error) {
+	var err error
+	for _, index := range path {
+		k, err = k.DeriveChild(index)
+		if err != nil {
+			return ExtendedKey{}, err
+		}
+	}
+	return k, nil
+}
+
+// Sign returns the ed25519 signature of message by the key, which verifies
+// with ed25519.Verify and the key's public key
+func (k ExtendedKey) Sign(message []byte) []byte {
+	pk := scalarBaseMul(k.KL[:])
+	return sign(k.KL[:], k.KR[:], pk[:], message)
+}
+
+// Signer returns a signer of transactions with the key
+func (k ExtendedKey) Signer() wallet.RawTransactionSigner {
+	return wallet.RawTransactionSigner{
+		PublicKey: k.PublicKey(),
+		Sign: func(ctx context.Context, message []byte) ([]byte, error) {
+			return k.Sign(message), nil
+		},
+	}
+}
+
+// ExtendedPublicKey is a BIP32-Ed25519 public key and its chain code, from
+// which the public keys of non-hardened children are derived, e.g. to watch the
+// accounts of a wallet without its private keys
+type ExtendedPublicKey struct {
+	PublicKey [32]byte
+	ChainCode [32]byte
+}
+
+// Address returns the address of the account of the key
+func (k ExtendedPublicKey) Address() types.Address {
+	return types.Address(k.PublicKey)
+}
+
+// DeriveChild returns the public key of the non-hardened child with the given
+// index
+func (k ExtendedPublicKey) DeriveChild(index uint32) (ExtendedPublicKey, error) {
+	if index >= HardenedOffset {
+		return ExtendedPublicKey{}, fmt.Errorf("cannot derive hardened child %d from a public key", index)
+	}
+	parent, err := decodePoint(k.PublicKey)
+	if err != nil {
+		return ExtendedPublicKey{}, err
+	}
+	data := appendIndex(append([]byte{0x02}, k.PublicKey[:]...), index)
+	z := hmacSHA512(k.ChainCode[:], data)
+	data[0]++
+	chainCode := hmacSHA512(k.ChainCode[:], data)
+
+	child := ExtendedPublicKey{PublicKey: parent.add(basePoint.mul(scaledScalar(z[:32]))).encode()}
+	copy(child.ChainCode[:], chainCode[32:])
+	return child, nil
+}
+
+// DerivePath returns the public key at the given path of non-hardened indexes
+// from the key
+func (k ExtendedPublicKey) DerivePath(path []uint32) (ExtendedPublicKey, error) {
+	var err error
+	for _, index := range path {
+		k, err = k.DeriveChild(index)
+		if err != nil {
+			return ExtendedPublicKey{}, err
+		}
+	}
+	return k, nil
+}
+
+// mask256 is 2^256 - 1
+var mask256 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+// scaledScalar returns 8 times zL with its top DerivationBits bits cleared
+func scaledScalar(zL []byte) *big.Int {
+	truncated := make([]byte, 32)
+	copy(truncated, zL)
+	truncated[31] = 0
+	truncated[30] &= 0xff >> (DerivationBits - 8)
+	return new(big.Int).Lsh(littleEndian(truncated), 3)
+}
+
+func appendIndex(data []byte, index uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], index)
+	return append(data, b[:]...)
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,82 @@
+package hdwallet
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// the mnemonic of the ARC-52 reference implementation's tests
+const testMnemonic = "salon zoo engage submit smile frost later decide wing sight chaos renew lizard rely canal coral scene hobby scare step bus leaf tobacco slice"
+
+func testRootKey(t *testing.T) ExtendedKey {
+	seed := SeedFromMnemonic(testMnemonic, "")
+	require.Equal(t, "3aff2db416b895ec3cf9a4f8d1e970bc9819920e7bf44a5e350477af0ef557b1511b0986debf78dd38c7c520cd44ff7c7231618f958e21ef0250733a8c1915ea", hex.EncodeToString(seed))
+	root := MakeRootKey(seed)
+	require.Equal(t, "a8ba80028922d9fcfa055c78aede55b5c575bcd8d5a53168edf45f36d9ec8f46", hex.EncodeToString(root.KL[:]))
+	require.Equal(t, "94592b4bc892907583e22669ecdf1b0409a9f3bd5549f2dd751b51360909cd05", hex.EncodeToString(root.KR[:]))
+	require.Equal(t, "796b9206ec30e142e94b790a98805bf999042b55046963174ee6cee2d0375946", hex.EncodeToString(root.ChainCode[:]))
+	return root
+}
+
+func TestDerivePath(t *testing.T) {
+	root := testRootKey(t)
+	vectors := []struct {
+		path      []uint32
+		publicKey string
+	}{
+		{AddressPath(0, 0, 0), "7bda7ac12627b2c259f1df6875d30c10b35f55b33ad2cc8ea2736eaa3ebcfab9"},
+		{AddressPath(0, 0, 1), "5bae8828f111064637ac5061bd63bc4fcfe4a833252305f25eeab9c64ecdf519"},
+		{AddressPath(1, 0, 0), "358d8c4382992849a764438e02b1c45c2ca4e86bbcfe10fd5b963f3610012bc9"},
+		{AddressPath(1, 0, 2), "a1632a5ed95d8b5705552529ce25bdd5e23f9ee447ed34f7ad6c4460be36ee39"},
+		{Path(CoinTypeIdentity, 0, 0, 0), "ff8b1863ef5e40d0a48c245f26a6dbdf5da94dc75a1851f51d8a04e547bd5f5a"},
+	}
+	for _, vector := range vectors {
+		key, err := root.DerivePath(vector.path)
+		require.NoError(t, err)
+		require.Equal(t, vector.publicKey, hex.EncodeToString(key.PublicKey()))
+		addr := key.Address()
+		require.Equal(t, vector.publicKey, hex.EncodeToString(addr[:]))
+	}
+}
+
+func TestExtendedPublicKey(t *testing.T) {
+	root := testRootKey(t)
+	account, err := root.DerivePath([]uint32{Harden(Purpose), Harden(CoinTypeAlgorand), Harden(0)})
+	require.NoError(t, err)
+	xpub := account.ExtendedPublicKey()
+
+	for index := uint32(0); index < 3; index++ {
+		key, err := account.DerivePath([]uint32{0, index})
+		require.NoError(t, err)
+		pub, err := xpub.DerivePath([]uint32{0, index})
+		require.NoError(t, err)
+		require.Equal(t, key.ExtendedPublicKey(), pub)
+	}
+
+	_, err = xpub.DeriveChild(Harden(0))
+	require.Error(t, err)
+}
+
+func TestSign(t *testing.T) {
+	key, err := testRootKey(t).DerivePath(AddressPath(0, 0, 0))
+	require.NoError(t, err)
+	message := []byte("Hello, World!")
+	signature := key.Sign(message)
+	require.Equal(t, "cc45a20729d6858671cb9e99c29d7e5f39306c3fb318156c6187b25652e8c8418968acf250c6c61a668782c9291a68fce740e98776831a49206b94bfe550800e", hex.EncodeToString(signature))
+	require.True(t, ed25519.Verify(key.PublicKey(), message, signature))
+
+	txn := types.Transaction{Type: types.PaymentTx, Header: types.Header{Sender: key.Address()}}
+	stxs, err := key.Signer().SignTransactions(context.Background(), []types.Transaction{txn}, []int{0})
+	require.NoError(t, err)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxs[0], &stx))
+	require.True(t, crypto.VerifySignedTransaction(stx))
+}
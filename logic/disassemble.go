@@ -0,0 +1,175 @@
+package logic
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Instruction is a single instruction of a disassembled program
+type Instruction struct {
+	// PC is the offset of the instruction's opcode in the program
+	PC int
+	// Size is the number of bytes taken by the opcode and its immediate arguments
+	Size int
+	// Text is the instruction in TEAL source, with branches to labels
+	Text string
+}
+
+// Disassemble converts program bytes back into TEAL source. Branch targets
+// are given labels, label1, label2, ... in program order.
+func Disassemble(program []byte) (string, error) {
+	version, instructions, labels, err := disassemble(program)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	fmt.Fprintf(&out, "// version %d\n", version)
+	for _, instruction := range instructions {
+		if label, ok := labels[instruction.PC]; ok {
+			fmt.Fprintf(&out, "%s:\n", label)
+		}
+		out.WriteString(instruction.Text)
+		out.WriteString("\n")
+	}
+	if label, ok := labels[len(program)]; ok {
+		fmt.Fprintf(&out, "%s:\n", label)
+	}
+	return out.String(), nil
+}
+
+// DisassembleInstructions is as Disassemble, but returns each instruction
+// along with its offset in the program, e.g. to find where a template's
+// constants are
+func DisassembleInstructions(program []byte) ([]Instruction, error) {
+	_, instructions, _, err := disassemble(program)
+	return instructions, err
+}
+
+func disassemble(program []byte) (version uint64, instructions []Instruction, labels map[int]string, err error) {
+	const intcblockOpcode = 32
+	const bytecblockOpcode = 38
+	const bnzOpcode = 64
+	if len(program) == 0 {
+		err = errorf(ErrInvalidProgram, "empty program")
+		return
+	}
+	if err = loadSpec(); err != nil {
+		return
+	}
+	version, vlen := binary.Uvarint(program)
+	if vlen <= 0 {
+		err = errorf(ErrInvalidProgram, "version parsing error")
+		return
+	}
+	if int(version) > spec.EvalMaxVersion {
+		err = errorf(ErrUnsupportedVersion, "unsupported version")
+		return
+	}
+
+	// branch targets, resolved to labels once all of them are known
+	var targets []int
+	var branches []int
+	for pc := vlen; pc < len(program); {
+		op := opcodes[program[pc]]
+		if op.Name == "" {
+			err = errorf(ErrInvalidProgram, "invalid instruction %#x at pc=%d", program[pc], pc)
+			return
+		}
+		var text string
+		size := op.Size
+		switch op.Opcode {
+		case intcblockOpcode:
+			var ints []uint64
+			if size, ints, err = readIntConstBlock(program, pc); err != nil {
+				return
+			}
+			parts := []string{op.Name}
+			for _, i := range ints {
+				parts = append(parts, strconv.FormatUint(i, 10))
+			}
+			text = strings.Join(parts, " ")
+		case bytecblockOpcode:
+			var byteArrays [][]byte
+			if size, byteArrays, err = readByteConstBlock(program, pc); err != nil {
+				return
+			}
+			parts := []string{op.Name}
+			for _, b := range byteArrays {
+				parts = append(parts, "0x"+hex.EncodeToString(b))
+			}
+			text = strings.Join(parts, " ")
+		}
+		if size == 0 {
+			err = errorf(ErrInvalidProgram, "invalid instruction %#x at pc=%d", program[pc], pc)
+			return
+		}
+		if pc+size > len(program) {
+			err = errorf(ErrInvalidProgram, "%s at pc=%d ran past end of program", op.Name, pc)
+			return
+		}
+
+		if op.Opcode == bnzOpcode {
+			offset := int(binary.BigEndian.Uint16(program[pc+1:]))
+			target := pc + size + offset
+			if target > len(program) {
+				err = errorf(ErrInvalidProgram, "%s at pc=%d branches past end of program", op.Name, pc)
+				return
+			}
+			targets = append(targets, target)
+			branches = append(branches, len(instructions))
+			text = op.Name
+		} else if text == "" {
+			text, err = immediateText(op, program[pc+1:pc+size])
+			if err != nil {
+				err = errorf(ErrInvalidProgram, "%s at pc=%d: %v", op.Name, pc, err)
+				return
+			}
+		}
+		instructions = append(instructions, Instruction{PC: pc, Size: size, Text: text})
+		pc = pc + size
+	}
+
+	labels = make(map[int]string)
+	for _, target := range sortedUnique(targets) {
+		labels[target] = fmt.Sprintf("label%d", len(labels)+1)
+	}
+	for i, index := range branches {
+		instructions[index].Text += " " + labels[targets[i]]
+	}
+	return
+}
+
+// immediateText formats an instruction whose immediate arguments are uint8s,
+// naming transaction and global fields
+func immediateText(op operation, immediates []byte) (string, error) {
+	parts := []string{op.Name}
+	for i, immediate := range immediates {
+		// the field is the last immediate, after e.g. gtxn's group index
+		if len(op.ArgEnum) > 0 && i == len(immediates)-1 {
+			if int(immediate) >= len(op.ArgEnum) {
+				return "", errorf(ErrInvalidProgram, "invalid field %d", immediate)
+			}
+			parts = append(parts, op.ArgEnum[immediate])
+			continue
+		}
+		parts = append(parts, strconv.Itoa(int(immediate)))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+func sortedUnique(values []int) []int {
+	seen := make(map[int]bool)
+	var unique []int
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	sort.Ints(unique)
+	return unique
+}
@@ -0,0 +1,50 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of errors of reading programs and source maps. The errors returned
+// say what is wrong and unwrap to one of these, so that callers can branch on
+// the kind, e.g. with errors.Is.
+var (
+	// ErrInvalidProgram is the kind of errors of programs that cannot be
+	// decoded, such as empty programs or invalid instructions
+	ErrInvalidProgram = errors.New("invalid program")
+
+	// ErrUnsupportedVersion is the kind of errors of programs of a version
+	// above the supported one
+	ErrUnsupportedVersion = errors.New("unsupported version")
+
+	// ErrProgramTooLong is the kind of errors of programs longer than allowed
+	ErrProgramTooLong = errors.New("program too long")
+
+	// ErrProgramTooCostly is the kind of errors of programs costing more to
+	// run than allowed
+	ErrProgramTooCostly = errors.New("program too costly to run")
+
+	// ErrInvalidSourceMap is the kind of errors of source maps that cannot
+	// be decoded
+	ErrInvalidSourceMap = errors.New("invalid source map")
+)
+
+// kindError is an error of one of the kinds above: it reads as its message
+// and unwraps to its kind
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// errorf returns an error of the given kind with a formatted message
+func errorf(kind error, format string, args ...interface{}) error {
+	return &kindError{kind: kind, msg: fmt.Sprintf(format, args...)}
+}
@@ -5,7 +5,6 @@ package logic
 import (
 	"encoding/binary"
 	"encoding/json"
-	"fmt"
 
 	"github.com/algorand/go-algorand-sdk/types"
 )
@@ -32,127 +31,217 @@ type operation struct {
 var spec *langSpec
 var opcodes []operation
 
-// CheckProgram performs basic program validation: instruction count and program cost
+// loadSpec parses the bundled langspec.json and indexes its opcodes
+func loadSpec() error {
+	if spec != nil {
+		return nil
+	}
+	parsed := new(langSpec)
+	if err := json.Unmarshal(langSpecJson, parsed); err != nil {
+		return err
+	}
+	opcodes = make([]operation, 256)
+	for _, op := range parsed.Ops {
+		opcodes[op.Opcode] = op
+	}
+	spec = parsed
+	return nil
+}
+
+// CheckProgram performs basic program validation: program length, opcodes
+// and their immediate arguments, and program cost
 func CheckProgram(program []byte, args [][]byte) error {
+	_, _, err := ReadProgram(program, args)
+	return err
+}
+
+// Limits are the limits a consensus version places on stateless programs, the
+// logic signatures that approve transactions
+type Limits struct {
+	// LogicSigVersion is the highest program version the consensus version accepts
+	LogicSigVersion uint64
+	// LogicSigMaxSize is the most bytes the program and its arguments may take
+	LogicSigMaxSize int
+	// LogicSigMaxCost is the highest total opcode cost of the program
+	LogicSigMaxCost int
+}
+
+// DefaultLimits are the limits of the consensus version described by the
+// bundled language spec, which CheckProgram and ReadProgram enforce
+var DefaultLimits = Limits{
+	LogicSigVersion: 1,
+	LogicSigMaxSize: types.LogicSigMaxSize,
+	LogicSigMaxCost: types.LogicSigMaxCost,
+}
+
+// ProgramStats describes a program's size and cost, to compare against the
+// limits of a consensus version before the program is deployed
+type ProgramStats struct {
+	// Version is the program's version
+	Version uint64
+	// Length is the length of the program plus its arguments
+	Length int
+	// Cost is the summed cost of the program's opcodes. Programs without
+	// branches run each opcode once, so this is also the cost of running them.
+	Cost int
+}
+
+// Check returns an error describing the first limit the program exceeds, or
+// nil if it fits within all of them
+func (stats ProgramStats) Check(limits Limits) error {
+	if stats.Version > limits.LogicSigVersion {
+		return errorf(ErrUnsupportedVersion, "unsupported version")
+	}
+	if stats.Length > limits.LogicSigMaxSize {
+		return errorf(ErrProgramTooLong, "program too long")
+	}
+	if stats.Cost > limits.LogicSigMaxCost {
+		return errorf(ErrProgramTooCostly, "program too costly to run")
+	}
+	return nil
+}
+
+// Fits reports whether the program fits within limits
+func (stats ProgramStats) Fits(limits Limits) bool {
+	return stats.Check(limits) == nil
+}
+
+// EstimateProgram validates the program's opcodes and returns its length and
+// estimated cost when run with args, without checking them against any limits
+func EstimateProgram(program []byte, args [][]byte) (ProgramStats, error) {
+	stats, _, _, err := readProgram(program, args)
+	return stats, err
+}
+
+// ReadProgram performs the same validation as CheckProgram, and also returns
+// the constants found in the program's int and []byte constant blocks, in order
+func ReadProgram(program []byte, args [][]byte) (ints []uint64, byteArrays [][]byte, err error) {
+	stats, ints, byteArrays, err := readProgram(program, args)
+	if err != nil {
+		return
+	}
+	err = stats.Check(DefaultLimits)
+	return
+}
+
+// readProgram walks the program's opcodes, summing their costs and collecting
+// the constants of its constant blocks
+func readProgram(program []byte, args [][]byte) (stats ProgramStats, ints []uint64, byteArrays [][]byte, err error) {
 	const intcblockOpcode = 32
 	const bytecblockOpcode = 38
 	if program == nil || len(program) == 0 {
-		return fmt.Errorf("empty program")
+		err = errorf(ErrInvalidProgram, "empty program")
+		return
 	}
 
-	if spec == nil {
-		spec = new(langSpec)
-		if err := json.Unmarshal(langSpecJson, spec); err != nil {
-			return err
-		}
+	if err = loadSpec(); err != nil {
+		return
 	}
 	version, vlen := binary.Uvarint(program)
 	if vlen <= 0 {
-		return fmt.Errorf("version parsing error")
+		err = errorf(ErrInvalidProgram, "version parsing error")
+		return
 	}
 	if int(version) > spec.EvalMaxVersion {
-		return fmt.Errorf("unsupported version")
+		err = errorf(ErrUnsupportedVersion, "unsupported version")
+		return
 	}
+	stats.Version = version
 
-	cost := 0
-	length := len(program)
+	stats.Length = len(program)
 	for _, arg := range args {
-		length += len(arg)
-	}
-	if length > types.LogicSigMaxSize {
-		return fmt.Errorf("program too long")
-	}
-
-	if opcodes == nil {
-		opcodes = make([]operation, 256)
-		for _, op := range spec.Ops {
-			opcodes[op.Opcode] = op
-		}
+		stats.Length += len(arg)
 	}
 
 	for pc := vlen; pc < len(program); {
 		op := opcodes[program[pc]]
 		if op.Name == "" {
-			return fmt.Errorf("invalid instruction")
+			err = errorf(ErrInvalidProgram, "invalid instruction")
+			return
 		}
 
-		cost = cost + op.Cost
+		stats.Cost = stats.Cost + op.Cost
 		size := op.Size
-		var err error
 		if size == 0 {
 			switch op.Opcode {
 			case intcblockOpcode:
-				size, err = checkIntConstBlock(program, pc)
+				var foundInts []uint64
+				size, foundInts, err = readIntConstBlock(program, pc)
 				if err != nil {
-					return err
+					return
 				}
+				ints = append(ints, foundInts...)
 			case bytecblockOpcode:
-				size, err = checkByteConstBlock(program, pc)
+				var foundByteArrays [][]byte
+				size, foundByteArrays, err = readByteConstBlock(program, pc)
 				if err != nil {
-					return err
+					return
 				}
+				byteArrays = append(byteArrays, foundByteArrays...)
 			default:
-				return fmt.Errorf("invalid instruction")
+				err = errorf(ErrInvalidProgram, "invalid instruction")
+				return
 			}
 		}
+		if pc+size > len(program) {
+			err = errorf(ErrInvalidProgram, "%s at pc=%d ran past end of program", op.Name, pc)
+			return
+		}
 		pc = pc + size
 	}
-
-	if cost > types.LogicSigMaxCost {
-		return fmt.Errorf("program too costly to run")
-	}
-
-	return nil
+	return
 }
 
-func checkIntConstBlock(program []byte, pc int) (size int, err error) {
+func readIntConstBlock(program []byte, pc int) (size int, ints []uint64, err error) {
 	size = 1
 	numInts, bytesUsed := binary.Uvarint(program[pc+size:])
 	if bytesUsed <= 0 {
-		err = fmt.Errorf("could not decode int const block size at pc=%d", pc+size)
+		err = errorf(ErrInvalidProgram, "could not decode int const block size at pc=%d", pc+size)
 		return
 	}
 
 	size += bytesUsed
 	for i := uint64(0); i < numInts; i++ {
 		if pc+size >= len(program) {
-			err = fmt.Errorf("intcblock ran past end of program")
+			err = errorf(ErrInvalidProgram, "intcblock ran past end of program")
 			return
 		}
-		_, bytesUsed = binary.Uvarint(program[pc+size:])
+		num, bytesUsed := binary.Uvarint(program[pc+size:])
 		if bytesUsed <= 0 {
-			err = fmt.Errorf("could not decode int const[%d] at pc=%d", i, pc+size)
+			err = errorf(ErrInvalidProgram, "could not decode int const[%d] at pc=%d", i, pc+size)
 			return
 		}
+		ints = append(ints, num)
 		size += bytesUsed
 	}
 	return
 }
 
-func checkByteConstBlock(program []byte, pc int) (size int, err error) {
+func readByteConstBlock(program []byte, pc int) (size int, byteArrays [][]byte, err error) {
 	size = 1
 	numInts, bytesUsed := binary.Uvarint(program[pc+size:])
 	if bytesUsed <= 0 {
-		err = fmt.Errorf("could not decode []byte const block size at pc=%d", pc+size)
+		err = errorf(ErrInvalidProgram, "could not decode []byte const block size at pc=%d", pc+size)
 		return
 	}
 
 	size += bytesUsed
 	for i := uint64(0); i < numInts; i++ {
 		if pc+size >= len(program) {
-			err = fmt.Errorf("bytecblock ran past end of program")
+			err = errorf(ErrInvalidProgram, "bytecblock ran past end of program")
 			return
 		}
 		itemLen, bytesUsed := binary.Uvarint(program[pc+size:])
 		if bytesUsed <= 0 {
-			err = fmt.Errorf("could not decode []byte const[%d] at pc=%d", i, pc+size)
+			err = errorf(ErrInvalidProgram, "could not decode []byte const[%d] at pc=%d", i, pc+size)
 			return
 		}
 		size += bytesUsed
-		if pc+size >= len(program) {
-			err = fmt.Errorf("bytecblock ran past end of program")
+		if itemLen > uint64(len(program)-(pc+size)) {
+			err = errorf(ErrInvalidProgram, "bytecblock ran past end of program")
 			return
 		}
+		byteArrays = append(byteArrays, program[pc+size:pc+size+int(itemLen)])
 		size += int(itemLen)
 	}
 	return
@@ -33,6 +33,7 @@ func TestCheckProgram(t *testing.T) {
 	args[0] = []byte(strings.Repeat("a", 10))
 	err = CheckProgram(program, args)
 	require.EqualError(t, err, "program too long")
+	require.Equal(t, ErrProgramTooLong, errorKind(err))
 
 	// invalid opcode
 	program = []byte{1, 32, 1, 1, 34} // int 1
@@ -40,6 +41,7 @@ func TestCheckProgram(t *testing.T) {
 	args[0] = []byte(strings.Repeat("a", 10))
 	err = CheckProgram(program, args)
 	require.EqualError(t, err, "invalid instruction")
+	require.Equal(t, ErrInvalidProgram, errorKind(err))
 
 	// check single keccak256 and 10x keccak256 work
 	program = []byte{0x01, 0x26, 0x01, 0x01, 0x01, 0x28, 0x02} // byte 0x01 + keccak256
@@ -54,4 +56,152 @@ func TestCheckProgram(t *testing.T) {
 	program = append(program, []byte(strings.Repeat("\x02", 800))...) // append 800x keccak256
 	err = CheckProgram(program, args)
 	require.EqualError(t, err, "program too costly to run")
+	require.Equal(t, ErrProgramTooCostly, errorKind(err))
+}
+
+// errorKind returns the kind an error unwraps to, or nil
+func errorKind(err error) error {
+	if kinded, ok := err.(interface{ Unwrap() error }); ok {
+		return kinded.Unwrap()
+	}
+	return nil
+}
+
+func TestReadProgram(t *testing.T) {
+	// int 1; int 2; byte 0x0102; byte 0x03
+	program := []byte{0x01, 0x20, 0x02, 0x01, 0x02, 0x26, 0x02, 0x02, 0x01, 0x02, 0x01, 0x03, 0x22}
+	ints, byteArrays, err := ReadProgram(program, nil)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1, 2}, ints)
+	require.Equal(t, [][]byte{{0x01, 0x02}, {0x03}}, byteArrays)
+
+	// truncated bytecblock
+	_, _, err = ReadProgram(program[:10], nil)
+	require.Error(t, err)
+}
+
+func TestEstimateProgram(t *testing.T) {
+	// byte 0x01; keccak256
+	program := []byte{0x01, 0x26, 0x01, 0x01, 0x01, 0x28, 0x02}
+	stats, err := EstimateProgram(program, [][]byte{[]byte("arg")})
+	require.NoError(t, err)
+	require.Equal(t, ProgramStats{Version: 1, Length: 10, Cost: 1 + 1 + 26}, stats)
+	require.True(t, stats.Fits(DefaultLimits))
+
+	// 800 more keccak256 are estimated rather than rejected
+	program = append(program, []byte(strings.Repeat("\x02", 800))...)
+	stats, err = EstimateProgram(program, nil)
+	require.NoError(t, err)
+	require.Equal(t, 807, stats.Length)
+	require.Equal(t, 2+801*26, stats.Cost)
+	require.EqualError(t, stats.Check(DefaultLimits), "program too costly to run")
+	require.False(t, stats.Fits(DefaultLimits))
+
+	limits := DefaultLimits
+	limits.LogicSigMaxCost = 30000
+	require.NoError(t, stats.Check(limits))
+	limits.LogicSigMaxSize = 800
+	require.EqualError(t, stats.Check(limits), "program too long")
+	limits.LogicSigVersion = 0
+	require.EqualError(t, stats.Check(limits), "unsupported version")
+	require.Equal(t, ErrUnsupportedVersion, errorKind(stats.Check(limits)))
+
+	_, err = EstimateProgram([]byte{0x01, 0x80}, nil)
+	require.EqualError(t, err, "invalid instruction")
+}
+
+func TestCheckProgramTruncated(t *testing.T) {
+	// intc 0 without its immediate
+	err := CheckProgram([]byte{0x01, 0x20, 0x01, 0x01, 0x21}, nil)
+	require.EqualError(t, err, "intc at pc=4 ran past end of program")
+
+	// bnz with only one byte of its offset
+	err = CheckProgram([]byte{0x01, 0x20, 0x01, 0x01, 0x22, 0x40, 0x00}, nil)
+	require.Error(t, err)
+
+	// bytecblock whose item length overflows
+	err = CheckProgram([]byte{0x01, 0x26, 0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0x00}, nil)
+	require.Error(t, err)
+}
+
+func TestDisassemble(t *testing.T) {
+	program := []byte{
+		0x01,                         // version 1
+		0x20, 0x02, 0x01, 0xe8, 0x07, // intcblock 1 1000
+		0x26, 0x01, 0x02, 0xab, 0xcd, // bytecblock 0xabcd
+		0x31, 0x01, // txn Fee
+		0x23,             // intc_1
+		0x0e,             // <=
+		0x40, 0x00, 0x02, // bnz label1
+		0x22,             // intc_0
+		0x48,             // pop
+		0x33, 0x01, 0x10, // label1: gtxn 1 TypeEnum
+		0x40, 0x00, 0x00, // bnz label2
+	}
+	source, err := Disassemble(program)
+	require.NoError(t, err)
+	require.Equal(t, `// version 1
+intcblock 1 1000
+bytecblock 0xabcd
+txn Fee
+intc_1
+<=
+bnz label1
+intc_0
+pop
+label1:
+gtxn 1 TypeEnum
+bnz label2
+label2:
+`, source)
+
+	instructions, err := DisassembleInstructions(program)
+	require.NoError(t, err)
+	require.Len(t, instructions, 10)
+	require.Equal(t, Instruction{PC: 1, Size: 5, Text: "intcblock 1 1000"}, instructions[0])
+	require.Equal(t, Instruction{PC: 20, Size: 3, Text: "gtxn 1 TypeEnum"}, instructions[8])
+
+	_, err = Disassemble([]byte{0x01, 0x31, 0x7f})
+	require.EqualError(t, err, "txn at pc=1: invalid field 127")
+	_, err = Disassemble([]byte{0x01, 0x40, 0x00, 0x01})
+	require.EqualError(t, err, "bnz at pc=1 branches past end of program")
+	_, err = Disassemble([]byte{0x01, 0x05})
+	require.EqualError(t, err, "invalid instruction 0x5 at pc=1")
+	_, err = Disassemble([]byte{0x02, 0x22})
+	require.Error(t, err)
+}
+
+func TestSourceMap(t *testing.T) {
+	// pc 0 on line 0, the intcblock at pc 1 on line 1, intc_0 at pc 4 on line 17
+	// and intc_0 at pc 5 back on line 16
+	sm, err := DecodeSourceMap([]byte(`{"version":3,"sources":[],"names":[],"mappings":"AAAA;AACA;;;AAgBA;AADA"}`))
+	require.NoError(t, err)
+
+	line, ok := sm.PcToLine(0)
+	require.True(t, ok)
+	require.Equal(t, 0, line)
+	line, ok = sm.PcToLine(1)
+	require.True(t, ok)
+	require.Equal(t, 1, line)
+	_, ok = sm.PcToLine(2)
+	require.False(t, ok)
+	line, ok = sm.PcToLine(4)
+	require.True(t, ok)
+	require.Equal(t, 17, line)
+	line, ok = sm.PcToLine(5)
+	require.True(t, ok)
+	require.Equal(t, 16, line)
+	_, ok = sm.PcToLine(6)
+	require.False(t, ok)
+
+	require.Equal(t, []int{1}, sm.LineToPcs(1))
+	require.Equal(t, []int{4}, sm.LineToPcs(17))
+	require.Empty(t, sm.LineToPcs(2))
+
+	_, err = DecodeSourceMap([]byte(`{"version":2,"mappings":"AAAA"}`))
+	require.Error(t, err)
+	_, err = DecodeSourceMap([]byte(`{"version":3,"mappings":"AA!A"}`))
+	require.Error(t, err)
+	_, err = DecodeSourceMap([]byte(`{"version":3,"mappings":"AAg"}`))
+	require.Error(t, err)
 }
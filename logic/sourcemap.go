@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// sourceMapVersion is the only version of the source map format supported
+const sourceMapVersion = 3
+
+const base64Alphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+
+// SourceMap maps the program counters of a compiled program to the lines of
+// its TEAL source, as returned by algod's compile endpoint when asked for a
+// source map. Lines are numbered from 0.
+type SourceMap struct {
+	Version  int      `json:"version"`
+	File     string   `json:"file,omitempty"`
+	Sources  []string `json:"sources"`
+	Names    []string `json:"names"`
+	Mappings string   `json:"mappings"`
+
+	pcToLine  map[int]int
+	lineToPcs map[int][]int
+}
+
+// DecodeSourceMap parses a source map in its JSON encoding
+func DecodeSourceMap(data []byte) (SourceMap, error) {
+	var sm SourceMap
+	if err := json.Unmarshal(data, &sm); err != nil {
+		return SourceMap{}, err
+	}
+	if sm.Version != sourceMapVersion {
+		return SourceMap{}, errorf(ErrInvalidSourceMap, "only version %d source maps are supported, got version %d", sourceMapVersion, sm.Version)
+	}
+	if sm.Mappings == "" {
+		return SourceMap{}, errorf(ErrInvalidSourceMap, "source map has no mappings")
+	}
+
+	sm.pcToLine = make(map[int]int)
+	sm.lineToPcs = make(map[int][]int)
+	// each ;-separated group of the mappings is a pc, empty for pcs within an
+	// instruction's immediates; its first segment holds the change in source
+	// line since the previous mapped pc
+	line := 0
+	for pc, group := range strings.Split(sm.Mappings, ";") {
+		if group == "" {
+			continue
+		}
+		segment := strings.SplitN(group, ",", 2)[0]
+		values, err := decodeVLQ(segment)
+		if err != nil {
+			return SourceMap{}, errorf(ErrInvalidSourceMap, "pc %d: %v", pc, err)
+		}
+		if len(values) >= 3 {
+			line += values[2]
+		}
+		sm.pcToLine[pc] = line
+		sm.lineToPcs[line] = append(sm.lineToPcs[line], pc)
+	}
+	return sm, nil
+}
+
+// PcToLine returns the source line of the instruction at pc, and whether an
+// instruction starts at pc
+func (sm SourceMap) PcToLine(pc int) (line int, ok bool) {
+	line, ok = sm.pcToLine[pc]
+	return
+}
+
+// LineToPcs returns the program counters of the instructions compiled from a
+// source line, in increasing order
+func (sm SourceMap) LineToPcs(line int) []int {
+	return sm.lineToPcs[line]
+}
+
+// decodeVLQ decodes a segment of base64 VLQ encoded values
+func decodeVLQ(segment string) ([]int, error) {
+	var values []int
+	value, shift := 0, uint(0)
+	for i := 0; i < len(segment); i++ {
+		digit := strings.IndexByte(base64Alphabet, segment[i])
+		if digit < 0 {
+			return nil, errorf(ErrInvalidSourceMap, "invalid character %q in mapping", segment[i])
+		}
+		value += (digit & 0x1f) << shift
+		if digit&0x20 != 0 {
+			shift += 5
+			continue
+		}
+		// the lowest bit is the sign
+		if value&1 != 0 {
+			values = append(values, -(value >> 1))
+		} else {
+			values = append(values, value>>1)
+		}
+		value, shift = 0, 0
+	}
+	if shift != 0 {
+		return nil, errorf(ErrInvalidSourceMap, "truncated value in mapping")
+	}
+	return values, nil
+}
@@ -0,0 +1,187 @@
+// Package merklearray verifies proofs that elements belong to the Merkle
+// trees that blocks and state proofs commit to, such as the transactions of a
+// block or the participants of a state proof.
+package merklearray
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"hash"
+	"math/bits"
+	"sort"
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// The hash types of Merkle trees, as in types.HashFactory
+const (
+	Sha512_256 uint16 = 0
+	Sumhash    uint16 = 1
+	Sha256     uint16 = 2
+)
+
+// MaxEncodedTreeDepth is the depth of the deepest tree a proof may be for
+const MaxEncodedTreeDepth = 16
+
+// nodePrefix is prepended to the hashes of two siblings when hashing them
+// into their parent
+var nodePrefix = []byte("MA")
+
+var (
+	hashesMu sync.RWMutex
+	hashes   = map[uint16]func() hash.Hash{
+		Sha512_256: sha512.New512_256,
+		Sha256:     sha256.New,
+	}
+)
+
+// RegisterHash registers the implementation of a hash type. SHA-512/256 and
+// SHA-256 are built in; state proofs hash their trees with SumHash512, which
+// must be registered, e.g. with the New512 function of
+// github.com/algorand/go-sumhash wrapped to take no salt.
+func RegisterHash(hashType uint16, newHash func() hash.Hash) {
+	hashesMu.Lock()
+	defer hashesMu.Unlock()
+	hashes[hashType] = newHash
+}
+
+// NewHash returns a new hash of the type of a tree
+func NewHash(hf types.HashFactory) (hash.Hash, error) {
+	hashesMu.RLock()
+	newHash, ok := hashes[hf.HashType]
+	hashesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported hash type %d", hf.HashType)
+	}
+	return newHash(), nil
+}
+
+// layerItem is a node of one layer of a tree, at position pos in the layer
+type layerItem struct {
+	pos  uint64
+	hash []byte
+}
+
+// Verify checks that the elements at the given positions are the leaves of
+// the tree with the given root. The elements are given by their hash
+// representation: their domain separation prefix followed by their data,
+// which is what is hashed into the leaves.
+func Verify(root []byte, elems map[uint64][]byte, proof types.MerkleArrayProof) error {
+	if len(elems) == 0 {
+		if len(proof.Path) != 0 {
+			return fmt.Errorf("non-empty proof for no elements")
+		}
+		return nil
+	}
+	if proof.TreeDepth > MaxEncodedTreeDepth {
+		return fmt.Errorf("tree depth %d exceeds the maximum of %d", proof.TreeDepth, MaxEncodedTreeDepth)
+	}
+	hsh, err := NewHash(proof.HashFactory)
+	if err != nil {
+		return err
+	}
+
+	layer := make([]layerItem, 0, len(elems))
+	for pos, elem := range elems {
+		if pos >= uint64(1)<<proof.TreeDepth {
+			return fmt.Errorf("position %d is outside a tree of depth %d", pos, proof.TreeDepth)
+		}
+		layer = append(layer, layerItem{pos: pos, hash: hashBytes(hsh, elem)})
+	}
+	sort.Slice(layer, func(i, j int) bool { return layer[i].pos < layer[j].pos })
+
+	path := proof.Path
+	for level := uint8(0); level < proof.TreeDepth; level++ {
+		var next []layerItem
+		for i := 0; i < len(layer); i++ {
+			item := layer[i]
+			var sibling []byte
+			if i+1 < len(layer) && layer[i+1].pos == item.pos^1 {
+				// the sibling is proven too, so is not on the path
+				sibling = layer[i+1].hash
+				i++
+			} else {
+				if len(path) == 0 {
+					return fmt.Errorf("proof path is too short")
+				}
+				sibling, path = path[0], path[1:]
+			}
+			left, right := item.hash, sibling
+			if item.pos&1 != 0 {
+				left, right = sibling, item.hash
+			}
+			next = append(next, layerItem{pos: item.pos / 2, hash: hashNode(hsh, left, right)})
+		}
+		layer = next
+	}
+	if len(path) != 0 {
+		return fmt.Errorf("proof path is too long")
+	}
+	if !bytes.Equal(layer[0].hash, root) {
+		return fmt.Errorf("root mismatch")
+	}
+	return nil
+}
+
+// VerifyVectorCommitment is Verify for vector commitments, the trees whose
+// leaves are stored at bit-reversed positions, which state proofs and light
+// block header commitments use, as do the transactions commitments of blocks
+func VerifyVectorCommitment(root []byte, elems map[uint64][]byte, proof types.MerkleArrayProof) error {
+	if proof.TreeDepth > MaxEncodedTreeDepth {
+		return fmt.Errorf("tree depth %d exceeds the maximum of %d", proof.TreeDepth, MaxEncodedTreeDepth)
+	}
+	reversed := make(map[uint64][]byte, len(elems))
+	for pos, elem := range elems {
+		if pos >= uint64(1)<<proof.TreeDepth {
+			return fmt.Errorf("position %d is outside a tree of depth %d", pos, proof.TreeDepth)
+		}
+		reversed[msbToLsbIndex(pos, proof.TreeDepth)] = elem
+	}
+	return Verify(root, reversed, proof)
+}
+
+// msbToLsbIndex reverses the depth low bits of a position
+func msbToLsbIndex(pos uint64, depth uint8) uint64 {
+	if depth == 0 {
+		return 0
+	}
+	return bits.Reverse64(pos) >> (64 - uint(depth))
+}
+
+func hashBytes(hsh hash.Hash, b []byte) []byte {
+	hsh.Reset()
+	hsh.Write(b)
+	return hsh.Sum(nil)
+}
+
+// hashNode hashes two siblings into their parent. Missing siblings are given
+// as empty digests, and hashed as zeros.
+func hashNode(hsh hash.Hash, left, right []byte) []byte {
+	size := hsh.Size()
+	buf := make([]byte, len(nodePrefix)+2*size)
+	copy(buf, nodePrefix)
+	copy(buf[len(nodePrefix):], left)
+	copy(buf[len(nodePrefix)+size:], right)
+	return hashBytes(hsh, buf)
+}
+
+// SingleLeafProof returns the proof for one leaf of a tree from its path
+// concatenated into bytes, as algod returns proofs
+func SingleLeafProof(concatenated []byte, treeDepth uint8, hashType uint16) (types.MerkleArrayProof, error) {
+	proof := types.MerkleArrayProof{HashFactory: types.HashFactory{HashType: hashType}, TreeDepth: treeDepth}
+	hsh, err := NewHash(proof.HashFactory)
+	if err != nil {
+		return types.MerkleArrayProof{}, err
+	}
+	size := hsh.Size()
+	if len(concatenated) != int(treeDepth)*size {
+		return types.MerkleArrayProof{}, fmt.Errorf("a proof for a tree of depth %d must have %d bytes, not %d", treeDepth, int(treeDepth)*size, len(concatenated))
+	}
+	for i := 0; i < len(concatenated); i += size {
+		proof.Path = append(proof.Path, types.GenericDigest(concatenated[i:i+size]))
+	}
+	return proof, nil
+}
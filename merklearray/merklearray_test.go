@@ -0,0 +1,77 @@
+package merklearray
+
+import (
+	"crypto/sha512"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func sha512_256(parts ...[]byte) []byte {
+	var b []byte
+	for _, part := range parts {
+		b = append(b, part...)
+	}
+	h := sha512.Sum512_256(b)
+	return h[:]
+}
+
+func TestVerify(t *testing.T) {
+	// a tree of three leaves, the fourth missing and hashed as zeros
+	leaves := [][]byte{[]byte("TLa"), []byte("TLb"), []byte("TLc")}
+	h0, h1, h2 := sha512_256(leaves[0]), sha512_256(leaves[1]), sha512_256(leaves[2])
+	zero := make([]byte, 32)
+	left := sha512_256([]byte("MA"), h0, h1)
+	right := sha512_256([]byte("MA"), h2, zero)
+	root := sha512_256([]byte("MA"), left, right)
+
+	proof := func(path ...[]byte) types.MerkleArrayProof {
+		p := types.MerkleArrayProof{TreeDepth: 2}
+		for _, d := range path {
+			p.Path = append(p.Path, d)
+		}
+		return p
+	}
+	require.NoError(t, Verify(root, map[uint64][]byte{0: leaves[0]}, proof(h1, right)))
+	require.NoError(t, Verify(root, map[uint64][]byte{1: leaves[1]}, proof(h0, right)))
+	// a missing sibling may be given empty or as zeros
+	require.NoError(t, Verify(root, map[uint64][]byte{2: leaves[2]}, proof(nil, left)))
+	require.NoError(t, Verify(root, map[uint64][]byte{2: leaves[2]}, proof(zero, left)))
+	// siblings proven together are not on the path
+	require.NoError(t, Verify(root, map[uint64][]byte{0: leaves[0], 1: leaves[1]}, proof(right)))
+	require.NoError(t, Verify(root, map[uint64][]byte{0: leaves[0], 1: leaves[1], 2: leaves[2]}, proof(nil)))
+	require.NoError(t, Verify(root, nil, types.MerkleArrayProof{}))
+
+	require.Error(t, Verify(root, map[uint64][]byte{1: leaves[0]}, proof(h1, right)))
+	require.Error(t, Verify(root, map[uint64][]byte{0: leaves[0]}, proof(h1)))
+	require.Error(t, Verify(root, map[uint64][]byte{0: leaves[0]}, proof(h1, right, h2)))
+	require.Error(t, Verify(root, map[uint64][]byte{4: leaves[0]}, proof(h1, right)))
+	require.Error(t, Verify(root, nil, proof(h1)))
+	unsupported := proof(h1, right)
+	unsupported.HashFactory.HashType = Sumhash + 10
+	require.Error(t, Verify(root, map[uint64][]byte{0: leaves[0]}, unsupported))
+
+	// in a vector commitment, position 1 is stored at 2 and 2 at 1
+	require.NoError(t, VerifyVectorCommitment(root, map[uint64][]byte{2: leaves[1]}, proof(h0, right)))
+	require.NoError(t, VerifyVectorCommitment(root, map[uint64][]byte{1: leaves[2]}, proof(nil, left)))
+	require.Error(t, VerifyVectorCommitment(root, map[uint64][]byte{1: leaves[1]}, proof(h0, right)))
+}
+
+func TestSingleLeafProof(t *testing.T) {
+	concatenated := make([]byte, 64)
+	concatenated[32] = 1
+	proof, err := SingleLeafProof(concatenated, 2, Sha512_256)
+	require.NoError(t, err)
+	require.Equal(t, uint8(2), proof.TreeDepth)
+	require.Equal(t, []types.GenericDigest{concatenated[:32], concatenated[32:]}, proof.Path)
+
+	_, err = SingleLeafProof(concatenated, 3, Sha512_256)
+	require.Error(t, err)
+	_, err = SingleLeafProof(concatenated, 2, Sumhash+10)
+	require.Error(t, err)
+	proof, err = SingleLeafProof(nil, 0, Sha256)
+	require.NoError(t, err)
+	require.Empty(t, proof.Path)
+}
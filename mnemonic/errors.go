@@ -2,8 +2,14 @@ package mnemonic
 
 import (
 	"fmt"
+
+	"golang.org/x/crypto/ed25519"
 )
 
-var errWrongKeyLen = fmt.Errorf("key length must be %d bytes", keyLenBytes)
-var errWrongMnemonicLen = fmt.Errorf("mnemonic must be %d words", mnemonicLenWords)
-var errWrongChecksum = fmt.Errorf("checksum failed to validate")
+// Errors of converting keys to and from mnemonics
+var (
+	ErrWrongKeyLen        = fmt.Errorf("key length must be %d bytes", keyLenBytes)
+	ErrWrongPrivateKeyLen = fmt.Errorf("private key length must be %d bytes", ed25519.PrivateKeySize)
+	ErrWrongMnemonicLen   = fmt.Errorf("mnemonic must be %d words", mnemonicLenWords)
+	ErrWrongChecksum      = fmt.Errorf("checksum failed to validate")
+)
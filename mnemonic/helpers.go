@@ -3,24 +3,35 @@ package mnemonic
 import (
 	"golang.org/x/crypto/ed25519"
 
+	"github.com/algorand/go-algorand-sdk/crypto"
 	"github.com/algorand/go-algorand-sdk/types"
 )
 
 // FromPrivateKey is a helper that converts an ed25519 private key to a
 // human-readable mnemonic
-func FromPrivateKey(sk ed25519.PrivateKey) (string, error) {
-	seed := sk.Seed()
-	return FromKey(seed)
+func FromPrivateKey(sk crypto.SecretKey) (string, error) {
+	if len(sk) != ed25519.PrivateKeySize {
+		return "", ErrWrongPrivateKeyLen
+	}
+	return FromKey(sk[:ed25519.SeedSize])
 }
 
 // ToPrivateKey is a helper that converts a mnemonic directly to an ed25519
 // private key
-func ToPrivateKey(mnemonic string) (sk ed25519.PrivateKey, err error) {
+func ToPrivateKey(mnemonic string) (sk crypto.SecretKey, err error) {
 	seedBytes, err := ToKey(mnemonic)
 	if err != nil {
 		return
 	}
-	return ed25519.NewKeyFromSeed(seedBytes), nil
+	// the seed is the key's first half, so the copy is zeroed
+	defer zero(seedBytes)
+	return crypto.SecretKeyFromSeed(seedBytes)
+}
+
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
 }
 
 // FromMasterDerivationKey is a helper that converts an MDK to a human-readable
@@ -30,7 +30,7 @@ func init() {
 func FromKey(key []byte) (string, error) {
 	// Ensure the key we are passed is the expected length
 	if len(key) != keyLenBytes {
-		return "", errWrongKeyLen
+		return "", ErrWrongKeyLen
 	}
 
 	// Compute the checksum of these bytes
@@ -41,24 +41,18 @@ func FromKey(key []byte) (string, error) {
 }
 
 // ToKey converts a mnemonic generated using this library into the source
-// key used to create it. It returns an error if the passed mnemonic has an
+// key used to create it. Words may be separated by any whitespace, such as
+// the newlines of a mnemonic written one word per line, and are not case
+// sensitive. It returns an error if the passed mnemonic has an
 // incorrect checksum, if the number of words is unexpected, or if one
 // of the passed words is not found in the words list.
 func ToKey(mnemonic string) ([]byte, error) {
 	// Split input on whitespace
-	wordsRaw := strings.Split(mnemonic, sepStr)
-
-	// Strip out extra whitespace
-	var words []string
-	for _, word := range wordsRaw {
-		if word != "" {
-			words = append(words, word)
-		}
-	}
+	words := strings.Fields(strings.ToLower(mnemonic))
 
 	// Ensure the mnemonic is the correct length
 	if len(words) != mnemonicLenWords {
-		return nil, errWrongMnemonicLen
+		return nil, ErrWrongMnemonicLen
 	}
 
 	// Check that all words are in list
@@ -85,11 +79,11 @@ func ToKey(mnemonic string) ([]byte, error) {
 
 	// Check that we have 33 bytes long array as expected
 	if len(byteArr) != keyLenBytes+1 {
-		return nil, errWrongKeyLen
+		return nil, ErrWrongKeyLen
 	}
 	// Check that the last one is actually 0
 	if byteArr[keyLenBytes] != emptyByte {
-		return nil, errWrongChecksum
+		return nil, ErrWrongChecksum
 	}
 
 	// chop it !
@@ -100,7 +94,7 @@ func ToKey(mnemonic string) ([]byte, error) {
 
 	// Verify the checksum
 	if mnemonicChecksum != words[len(words)-1] {
-		return nil, errWrongChecksum
+		return nil, ErrWrongChecksum
 	}
 
 	// Verify that we recovered the correct amount of data
@@ -61,6 +61,38 @@ func TestZeroVector(t *testing.T) {
 	return
 }
 
+func TestMasterDerivationKeyVector(t *testing.T) {
+	mn := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon invest"
+	m, err := FromMasterDerivationKey(types.MasterDerivationKey{})
+	require.NoError(t, err)
+	require.Equal(t, mn, m)
+
+	mdk, err := ToMasterDerivationKey(mn)
+	require.NoError(t, err)
+	require.Equal(t, types.MasterDerivationKey{}, mdk)
+
+	// a mnemonic of a private key's seed is also a valid master derivation key
+	acct := crypto.GenerateAccount()
+	m, err = FromPrivateKey(acct.PrivateKey)
+	require.NoError(t, err)
+	mdk, err = ToMasterDerivationKey(m)
+	require.NoError(t, err)
+	require.Equal(t, []byte(acct.PrivateKey.Seed()), mdk[:])
+
+	_, err = ToMasterDerivationKey(strings.Replace(mn, "invest", "abandon", 1))
+	require.Error(t, err)
+}
+
+func TestWhitespaceAndCase(t *testing.T) {
+	zeroVector := make([]byte, 32)
+	mn := "ABANDON abandon abandon abandon abandon\n\tabandon abandon abandon abandon abandon\r\n" +
+		"abandon abandon abandon abandon abandon  abandon abandon abandon abandon abandon\n" +
+		"Abandon abandon abandon abandon Invest\n"
+	key, err := ToKey(mn)
+	require.NoError(t, err)
+	require.Equal(t, zeroVector, key)
+}
+
 func TestWordNotInList(t *testing.T) {
 	mn := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon zzz invest"
 	_, err := ToKey(mn)
@@ -0,0 +1,197 @@
+// Package notefield encodes and parses structured transaction notes, following
+// the ARC-2 convention "<dapp-name>:<format><data>", so that applications can
+// tag their transactions, e.g. those of templates such as Split, and find them
+// again, e.g. through the indexer's note prefix search.
+package notefield
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// Format is the format of the data of a note
+type Format byte
+
+// The formats of note data
+const (
+	// FormatMsgpack is msgpack encoded data
+	FormatMsgpack Format = 'm'
+	// FormatJSON is JSON encoded data
+	FormatJSON Format = 'j'
+	// FormatBytes is arbitrary bytes
+	FormatBytes Format = 'b'
+	// FormatText is UTF-8 text
+	FormatText Format = 'u'
+)
+
+// dappNamePattern matches the dapp names of ARC-2: 5 to 32 characters, the
+// first alphanumeric
+var dappNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_/@.-]{4,31}$`)
+
+// Note is a structured note
+type Note struct {
+	// DappName identifies the application that sent the transaction
+	DappName string
+
+	// Format is the format of Data
+	Format Format
+
+	// Data is the body of the note
+	Data []byte
+}
+
+// ValidateDappName returns an error if name is not a valid dapp name: 5 to 32
+// letters, digits and the characters "_/@.-", starting with a letter or digit
+func ValidateDappName(name string) error {
+	if !dappNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid dapp name %q", name)
+	}
+	return nil
+}
+
+func validateFormat(format Format) error {
+	switch format {
+	case FormatMsgpack, FormatJSON, FormatBytes, FormatText:
+		return nil
+	default:
+		return fmt.Errorf("invalid note format %q", format)
+	}
+}
+
+// MakeMsgpackNote returns a note whose body is v, msgpack encoded
+func MakeMsgpackNote(dappName string, v interface{}) Note {
+	return Note{DappName: dappName, Format: FormatMsgpack, Data: msgpack.Encode(v)}
+}
+
+// MakeJSONNote returns a note whose body is v, JSON encoded
+func MakeJSONNote(dappName string, v interface{}) (Note, error) {
+	data, err := stdjson.Marshal(v)
+	if err != nil {
+		return Note{}, err
+	}
+	return Note{DappName: dappName, Format: FormatJSON, Data: data}, nil
+}
+
+// Prefix returns the prefix of the encoded note, "<dapp-name>:<format>", which
+// can be searched for with the indexer
+func (n Note) Prefix() []byte {
+	return append([]byte(n.DappName+":"), byte(n.Format))
+}
+
+// Encode returns the note field holding the note, or an error if the note is
+// invalid or does not fit in a transaction
+func (n Note) Encode() ([]byte, error) {
+	if err := ValidateDappName(n.DappName); err != nil {
+		return nil, err
+	}
+	if err := validateFormat(n.Format); err != nil {
+		return nil, err
+	}
+	encoded := append(n.Prefix(), n.Data...)
+	if len(encoded) > types.MaxTxnNoteBytes {
+		return nil, fmt.Errorf("note too long: %d > %d", len(encoded), types.MaxTxnNoteBytes)
+	}
+	return encoded, nil
+}
+
+// Decode decodes the body of a msgpack or JSON note into the value pointed to
+// by objptr
+func (n Note) Decode(objptr interface{}) error {
+	switch n.Format {
+	case FormatMsgpack:
+		return msgpack.Decode(n.Data, objptr)
+	case FormatJSON:
+		return json.DecodeStd(n.Data, objptr)
+	default:
+		return fmt.Errorf("cannot decode note of format %q", n.Format)
+	}
+}
+
+// Parse parses a structured note field
+func Parse(note []byte) (Note, error) {
+	colon := bytes.IndexByte(note, ':')
+	if colon < 0 || colon+1 >= len(note) {
+		return Note{}, fmt.Errorf("note is not structured")
+	}
+	n := Note{DappName: string(note[:colon]), Format: Format(note[colon+1]), Data: note[colon+2:]}
+	if err := ValidateDappName(n.DappName); err != nil {
+		return Note{}, err
+	}
+	if err := validateFormat(n.Format); err != nil {
+		return Note{}, err
+	}
+	return n, nil
+}
+
+// Registry records the dapp names an application uses and the format of each,
+// so that it can pick its own notes out of those of other applications. It is
+// safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	formats map[string]Format
+}
+
+// MakeRegistry returns an empty registry
+func MakeRegistry() *Registry {
+	return &Registry{formats: make(map[string]Format)}
+}
+
+// Register registers a dapp name whose notes have the given format. It returns
+// an error if the name is invalid or already registered with another format.
+func (r *Registry) Register(dappName string, format Format) error {
+	if err := ValidateDappName(dappName); err != nil {
+		return err
+	}
+	if err := validateFormat(format); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if registered, ok := r.formats[dappName]; ok && registered != format {
+		return fmt.Errorf("dapp name %s is registered with format %q", dappName, registered)
+	}
+	r.formats[dappName] = format
+	return nil
+}
+
+// Encode is as Note.Encode, but returns an error if the note's dapp name is
+// not registered with its format
+func (r *Registry) Encode(n Note) ([]byte, error) {
+	if err := r.check(n); err != nil {
+		return nil, err
+	}
+	return n.Encode()
+}
+
+// Parse is as Parse, but returns an error if the note's dapp name is not
+// registered with its format
+func (r *Registry) Parse(note []byte) (Note, error) {
+	n, err := Parse(note)
+	if err != nil {
+		return Note{}, err
+	}
+	if err := r.check(n); err != nil {
+		return Note{}, err
+	}
+	return n, nil
+}
+
+func (r *Registry) check(n Note) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	registered, ok := r.formats[n.DappName]
+	if !ok {
+		return fmt.Errorf("dapp name %s is not registered", n.DappName)
+	}
+	if registered != n.Format {
+		return fmt.Errorf("dapp name %s is registered with format %q, not %q", n.DappName, registered, n.Format)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package notefield
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type splitTag struct {
+	Contract string `codec:"c" json:"contract"`
+	Round    uint64 `codec:"r" json:"round"`
+}
+
+func TestNote(t *testing.T) {
+	tag := splitTag{Contract: "split", Round: 7}
+
+	note := MakeMsgpackNote("my-dapp", tag)
+	encoded, err := note.Encode()
+	require.NoError(t, err)
+	require.Equal(t, []byte("my-dapp:m"), encoded[:9])
+	parsed, err := Parse(encoded)
+	require.NoError(t, err)
+	require.Equal(t, note, parsed)
+	var decoded splitTag
+	require.NoError(t, parsed.Decode(&decoded))
+	require.Equal(t, tag, decoded)
+
+	note, err = MakeJSONNote("my-dapp", tag)
+	require.NoError(t, err)
+	encoded, err = note.Encode()
+	require.NoError(t, err)
+	require.Equal(t, `my-dapp:j{"contract":"split","round":7}`, string(encoded))
+	parsed, err = Parse(encoded)
+	require.NoError(t, err)
+	decoded = splitTag{}
+	require.NoError(t, parsed.Decode(&decoded))
+	require.Equal(t, tag, decoded)
+
+	// integers above 2^53 keep their precision in untyped values
+	parsed, err = Parse([]byte(`my-dapp:j{"total":18446744073709551615}`))
+	require.NoError(t, err)
+	var untyped map[string]interface{}
+	require.NoError(t, parsed.Decode(&untyped))
+	require.Equal(t, "18446744073709551615", untyped["total"].(fmt.Stringer).String())
+
+	parsed, err = Parse([]byte("my-dapp:uhello"))
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(parsed.Data))
+	require.Error(t, parsed.Decode(&decoded))
+
+	_, err = Note{DappName: "dapp", Format: FormatText}.Encode()
+	require.Error(t, err)
+	_, err = Note{DappName: "my-dapp", Format: 'x'}.Encode()
+	require.Error(t, err)
+	_, err = Note{DappName: "my-dapp", Format: FormatBytes, Data: make([]byte, 1024)}.Encode()
+	require.Error(t, err)
+
+	invalid := []string{"", "hello", "my-dapp", "my-dapp:", "my-dapp:x", "-dapp:u", strings.Repeat("a", 33) + ":u"}
+	for _, note := range invalid {
+		_, err := Parse([]byte(note))
+		require.Error(t, err, note)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	registry := MakeRegistry()
+	require.NoError(t, registry.Register("my-dapp", FormatJSON))
+	require.NoError(t, registry.Register("my-dapp", FormatJSON))
+	require.Error(t, registry.Register("my-dapp", FormatMsgpack))
+	require.Error(t, registry.Register("dapp", FormatMsgpack))
+
+	note, err := MakeJSONNote("my-dapp", splitTag{})
+	require.NoError(t, err)
+	encoded, err := registry.Encode(note)
+	require.NoError(t, err)
+	_, err = registry.Parse(encoded)
+	require.NoError(t, err)
+
+	_, err = registry.Encode(MakeMsgpackNote("my-dapp", splitTag{}))
+	require.Error(t, err)
+	_, err = registry.Parse([]byte("my-dapp:m"))
+	require.Error(t, err)
+	_, err = registry.Parse([]byte("other-dapp:j{}"))
+	require.Error(t, err)
+}
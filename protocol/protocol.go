@@ -0,0 +1,199 @@
+// Package protocol holds the consensus parameters of the versions of the
+// Algorand consensus protocol, which bound the transactions, programs and
+// applications the network accepts, so that they can be checked before they
+// are sent to a node.
+//
+// The versions of MainNet and TestNet are the URLs of the specs commits that
+// define them, as their nodes report them in the LastVersion and NextVersion
+// of their status. V38, V39 and V40 are registered; ForVersion and ForStatus
+// return Current for other versions until they are registered, by the SDK or
+// by applications with Register.
+package protocol
+
+import (
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/logic"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// Future is the consensus version of networks, such as private and test
+// networks, running the node's newest protocol
+const Future = "future"
+
+// The consensus versions of MainNet and TestNet, as defined by go-algorand's
+// protocol/consensus.go
+const (
+	V38 = "https://github.com/algorandfoundation/specs/tree/abd3d4823c6f77349fc04c3af7b1e99fe4df699f"
+	V39 = "https://github.com/algorandfoundation/specs/tree/925a46433742afb0b51bb939354bd907fa88bf95"
+	V40 = "https://github.com/algorandfoundation/specs/tree/236dcc18c9c507d794813ab768e467ea42d1b4d9"
+)
+
+// Params are the parameters of a consensus version
+type Params struct {
+	// MinTxnFee is the minimum fee of a transaction, in microAlgos
+	MinTxnFee uint64
+	// MaxTxnLife is the largest number of rounds a transaction's last valid
+	// round may be after its first valid round
+	MaxTxnLife uint64
+	// MaxTxGroupSize is the most transactions a group may hold
+	MaxTxGroupSize int
+	// MaxTxnNoteBytes is the max length of the note field of a transaction
+	MaxTxnNoteBytes int
+	// MaxTxnBytesPerBlock is the max total length of the encoded transactions
+	// of a block
+	MaxTxnBytesPerBlock int
+
+	// LogicSigVersion is the highest version of logic signature programs
+	LogicSigVersion uint64
+	// LogicSigMaxSize is the max length of a logic signature program plus its
+	// arguments
+	LogicSigMaxSize int
+	// LogicSigMaxCost is the max total opcode cost of a logic signature program
+	LogicSigMaxCost int
+
+	// MaxAppArgs is the most arguments an application call may pass
+	MaxAppArgs int
+	// MaxAppTotalArgLen is the max total length of the arguments of an
+	// application call
+	MaxAppTotalArgLen int
+	// MaxAppProgramLen is the max length of an approval or clear state program
+	// without extra pages, and the length of each extra page
+	MaxAppProgramLen int
+	// MaxExtraAppProgramPages is the most extra program pages an application
+	// may request
+	MaxExtraAppProgramPages int
+	// MaxAppTxnAccounts is the most accounts an application call may reference
+	MaxAppTxnAccounts int
+	// MaxAppTxnForeignApps is the most applications an application call may
+	// reference
+	MaxAppTxnForeignApps int
+	// MaxAppTxnForeignAssets is the most assets an application call may
+	// reference
+	MaxAppTxnForeignAssets int
+	// MaxAppTotalTxnReferences is the most accounts, applications, assets and
+	// boxes an application call may reference in total
+	MaxAppTotalTxnReferences int
+	// MaxAppBoxReferences is the most boxes an application call may reference
+	MaxAppBoxReferences int
+	// MaxGlobalSchemaEntries is the most global state entries of an application
+	MaxGlobalSchemaEntries uint64
+	// MaxLocalSchemaEntries is the most local state entries of an application
+	MaxLocalSchemaEntries uint64
+}
+
+// Current are the parameters of the protocol the SDK is written against, which
+// its constants, such as types.MaxTxGroupSize, describe. They are used for
+// consensus versions that have not been registered.
+var Current = Params{
+	MinTxnFee:           1000,
+	MaxTxnLife:          1000,
+	MaxTxGroupSize:      types.MaxTxGroupSize,
+	MaxTxnNoteBytes:     types.MaxTxnNoteBytes,
+	MaxTxnBytesPerBlock: types.MaxTxnBytesPerBlock,
+
+	LogicSigVersion: logic.DefaultLimits.LogicSigVersion,
+	LogicSigMaxSize: logic.DefaultLimits.LogicSigMaxSize,
+	LogicSigMaxCost: logic.DefaultLimits.LogicSigMaxCost,
+
+	MaxAppArgs:               16,
+	MaxAppTotalArgLen:        2048,
+	MaxAppProgramLen:         2048,
+	MaxExtraAppProgramPages:  3,
+	MaxAppTxnAccounts:        4,
+	MaxAppTxnForeignApps:     8,
+	MaxAppTxnForeignAssets:   8,
+	MaxAppTotalTxnReferences: 8,
+	MaxAppBoxReferences:      8,
+	MaxGlobalSchemaEntries:   64,
+	MaxLocalSchemaEntries:    16,
+}
+
+// v38 are the parameters of V38, as set by go-algorand's config/consensus.go
+var v38 = Params{
+	MinTxnFee:           1000,
+	MaxTxnLife:          1000,
+	MaxTxGroupSize:      16,
+	MaxTxnNoteBytes:     1024,
+	MaxTxnBytesPerBlock: 5 * 1024 * 1024,
+
+	LogicSigVersion: 9,
+	LogicSigMaxSize: 1000,
+	LogicSigMaxCost: 20000,
+
+	MaxAppArgs:               16,
+	MaxAppTotalArgLen:        2048,
+	MaxAppProgramLen:         2048,
+	MaxExtraAppProgramPages:  3,
+	MaxAppTxnAccounts:        4,
+	MaxAppTxnForeignApps:     8,
+	MaxAppTxnForeignAssets:   8,
+	MaxAppTotalTxnReferences: 8,
+	MaxAppBoxReferences:      8,
+	MaxGlobalSchemaEntries:   64,
+	MaxLocalSchemaEntries:    16,
+}
+
+// withLogicSigVersion returns params with another logic signature version,
+// which is what V39 and V40 change of the parameters
+func withLogicSigVersion(params Params, version uint64) Params {
+	params.LogicSigVersion = version
+	return params
+}
+
+var (
+	versionsMu sync.RWMutex
+	versions   = map[string]Params{
+		Future: Current,
+		V38:    v38,
+		V39:    withLogicSigVersion(v38, 10),
+		V40:    withLogicSigVersion(v38, 11),
+	}
+)
+
+// Register sets the parameters of a consensus version, replacing any that were
+// registered before, so that networks running it are checked against them
+func Register(version string, params Params) {
+	versionsMu.Lock()
+	defer versionsMu.Unlock()
+	versions[version] = params
+}
+
+// Lookup returns the parameters registered for a consensus version, and
+// whether there were any
+func Lookup(version string) (Params, bool) {
+	versionsMu.RLock()
+	defer versionsMu.RUnlock()
+	params, ok := versions[version]
+	return params, ok
+}
+
+// ForVersion returns the parameters of a consensus version, such as the
+// ConsensusVersion of types.SuggestedParams, or Current if none are registered
+func ForVersion(version string) Params {
+	if params, ok := Lookup(version); ok {
+		return params
+	}
+	return Current
+}
+
+// ForStatus returns the parameters of the consensus version that transactions
+// sent to a node with this status will be evaluated under: the next version if
+// it applies from the round after the node's last round, else the last version
+func ForStatus(status models.NodeStatusResponse) Params {
+	if status.NextVersion != "" && status.NextVersionRound <= status.LastRound+1 {
+		return ForVersion(status.NextVersion)
+	}
+	return ForVersion(status.LastVersion)
+}
+
+// LogicSigLimits returns the limits the parameters place on logic signature
+// programs, to check a program's logic.ProgramStats against
+func (params Params) LogicSigLimits() logic.Limits {
+	return logic.Limits{
+		LogicSigVersion: params.LogicSigVersion,
+		LogicSigMaxSize: params.LogicSigMaxSize,
+		LogicSigMaxCost: params.LogicSigMaxCost,
+	}
+}
@@ -0,0 +1,69 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/logic"
+)
+
+func TestVersions(t *testing.T) {
+	params, ok := Lookup(Future)
+	require.True(t, ok)
+	require.Equal(t, Current, params)
+
+	_, ok = Lookup("unknown")
+	require.False(t, ok)
+	require.Equal(t, Current, ForVersion("unknown"))
+
+	custom := Current
+	custom.MinTxnFee = 2000
+	custom.LogicSigMaxCost = 20
+	Register("custom", custom)
+	defer Register("custom", Current)
+	require.Equal(t, custom, ForVersion("custom"))
+
+	// the next version applies once the round after the last one reaches it
+	status := models.NodeStatusResponse{LastRound: 10, LastVersion: Future, NextVersion: "custom", NextVersionRound: 12}
+	require.Equal(t, Current, ForStatus(status))
+	status.LastRound = 11
+	require.Equal(t, custom, ForStatus(status))
+
+	// byte 0x01; keccak256
+	stats, err := logic.EstimateProgram([]byte{0x01, 0x26, 0x01, 0x01, 0x01, 0x28, 0x02}, nil)
+	require.NoError(t, err)
+	require.True(t, stats.Fits(Current.LogicSigLimits()))
+	require.EqualError(t, stats.Check(custom.LogicSigLimits()), "program too costly to run")
+}
+
+func TestNetworkVersions(t *testing.T) {
+	for _, test := range []struct {
+		last, next      string
+		logicSigVersion uint64
+	}{
+		{V38, V39, 9},
+		{V39, V40, 10},
+		{V40, "", 11},
+	} {
+		// a network running test.last, which upgrades to test.next at round 101
+		status := models.NodeStatusResponse{LastRound: 99, LastVersion: test.last, NextVersion: test.last}
+		if test.next != "" {
+			status.NextVersion = test.next
+			status.NextVersionRound = 101
+		}
+		params := ForStatus(status)
+		require.Equal(t, test.logicSigVersion, params.LogicSigVersion, test.last)
+		require.Equal(t, uint64(1000), params.MinTxnFee, test.last)
+		require.Equal(t, uint64(1000), params.MaxTxnLife, test.last)
+		require.Equal(t, 5*1024*1024, params.MaxTxnBytesPerBlock, test.last)
+		require.Equal(t, 8, params.MaxAppBoxReferences, test.last)
+
+		if test.next != "" {
+			status.LastRound = 100
+			require.Equal(t, ForVersion(test.next), ForStatus(status), test.last)
+			require.Equal(t, test.logicSigVersion+1, ForStatus(status).LogicSigVersion, test.last)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package stateproofs
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// coinGeneratorVersion is the version of the coin choice seed
+const coinGeneratorVersion = 0
+
+// coinChoiceSeed is the data the choice of revealed signatures depends on
+type coinChoiceSeed struct {
+	partCommitment types.GenericDigest
+	lnProvenWeight uint64
+	sigCommitment  types.GenericDigest
+	signedWeight   uint64
+	data           MessageHash
+}
+
+func (cc coinChoiceSeed) hashRep() []byte {
+	b := append([]byte{}, coinPrefix...)
+	b = append(b, coinGeneratorVersion)
+	b = append(b, cc.partCommitment...)
+	b = appendUint64(b, cc.lnProvenWeight)
+	b = append(b, cc.sigCommitment...)
+	b = appendUint64(b, cc.signedWeight)
+	return append(b, cc.data[:]...)
+}
+
+// coinGenerator draws the coins that choose the revealed signatures, uniform
+// in [0, signedWeight)
+type coinGenerator struct {
+	shake        *shake256
+	signedWeight uint64
+	// threshold is the largest multiple of signedWeight that fits in 64 bits,
+	// above which draws are rejected
+	threshold *big.Int
+}
+
+func makeCoinGenerator(seed coinChoiceSeed) coinGenerator {
+	shake := &shake256{}
+	shake.Write(seed.hashRep())
+	weight := new(big.Int).SetUint64(seed.signedWeight)
+	threshold := new(big.Int).Lsh(big.NewInt(1), 64)
+	threshold.Div(threshold, weight).Mul(threshold, weight)
+	return coinGenerator{shake: shake, signedWeight: seed.signedWeight, threshold: threshold}
+}
+
+func (cg coinGenerator) next() uint64 {
+	var buf [8]byte
+	for {
+		cg.shake.Read(buf[:])
+		r := binary.LittleEndian.Uint64(buf[:])
+		if new(big.Int).SetUint64(r).Cmp(cg.threshold) < 0 {
+			return r % cg.signedWeight
+		}
+	}
+}
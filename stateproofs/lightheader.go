@@ -0,0 +1,42 @@
+package stateproofs
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/merklearray"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// lightBlockHeaderPrefix is prepended to a light block header when hashing it
+var lightBlockHeaderPrefix = []byte("B256")
+
+// HashLightBlockHeader returns the hash of a light block header, as committed
+// to by the BlockHeadersCommitment of state proof messages
+func HashLightBlockHeader(header types.LightBlockHeader) [sha256.Size]byte {
+	return sha256.Sum256(hashRepLightBlockHeader(header))
+}
+
+func hashRepLightBlockHeader(header types.LightBlockHeader) []byte {
+	return append(append([]byte{}, lightBlockHeaderPrefix...), msgpack.Encode(header)...)
+}
+
+// VerifyLightBlockHeader verifies that a light block header is committed to by
+// a state proof message, given the proof algod returns for its round
+func VerifyLightBlockHeader(header types.LightBlockHeader, proof models.LightBlockHeaderProof, msg types.StateProofMessage) error {
+	round := uint64(header.RoundNumber)
+	if round < msg.FirstAttestedRound || round > msg.LastAttestedRound {
+		return fmt.Errorf("round %d is not attested to by the message for rounds %d to %d", round, msg.FirstAttestedRound, msg.LastAttestedRound)
+	}
+	if proof.Treedepth > merklearray.MaxEncodedTreeDepth {
+		return fmt.Errorf("tree depth %d exceeds the maximum of %d", proof.Treedepth, merklearray.MaxEncodedTreeDepth)
+	}
+	path, err := merklearray.SingleLeafProof(proof.Proof, uint8(proof.Treedepth), merklearray.Sha256)
+	if err != nil {
+		return err
+	}
+	elems := map[uint64][]byte{round - msg.FirstAttestedRound: hashRepLightBlockHeader(header)}
+	return merklearray.VerifyVectorCommitment(msg.BlockHeadersCommitment, elems, path)
+}
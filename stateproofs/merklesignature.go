@@ -0,0 +1,79 @@
+package stateproofs
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/merklearray"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// cryptoPrimitivesID identifies the signature scheme of Merkle signatures,
+// Falcon-1024 keys committed to in a SumHash512 tree
+const cryptoPrimitivesID uint16 = 0
+
+// VerifyMerkleSignature verifies a signature of msg by one of the state proof
+// keys of an account, the one valid for round
+func VerifyMerkleSignature(v types.StateProofVerifier, round uint64, msg []byte, sig types.MerkleSignature, falcon Falcon) error {
+	if v.KeyLifetime == 0 {
+		return fmt.Errorf("the key lifetime is zero")
+	}
+	key := hashRepCommittablePublicKey(sig.VerifyingKey, round-round%v.KeyLifetime)
+	if err := merklearray.VerifyVectorCommitment(v.Commitment[:], map[uint64][]byte{sig.VectorCommitmentIndex: key}, sig.Proof); err != nil {
+		return fmt.Errorf("the key is not the account's for round %d: %v", round, err)
+	}
+	return falcon.Verify(sig.VerifyingKey, msg, sig.Signature)
+}
+
+// hashRepCommittablePublicKey is the hash representation of the key of an
+// account valid from round, as committed to by its StateProofVerifier
+func hashRepCommittablePublicKey(key types.FalconVerifier, round uint64) []byte {
+	b := append([]byte{}, keyPrefix...)
+	b = appendUint16(b, cryptoPrimitivesID)
+	b = appendUint64(b, round)
+	return append(b, key.PublicKey[:]...)
+}
+
+// hashableMerkleSignature is the fixed length representation of a signature,
+// as committed to in a signature slot
+func hashableMerkleSignature(sig types.MerkleSignature, falcon Falcon) ([]byte, error) {
+	ct, err := falcon.ConvertToCT(sig.Signature)
+	if err != nil {
+		return nil, err
+	}
+	proof, err := hashableProof(sig.Proof)
+	if err != nil {
+		return nil, err
+	}
+	b := appendUint16(nil, cryptoPrimitivesID)
+	b = append(b, ct...)
+	b = append(b, sig.VerifyingKey.PublicKey[:]...)
+	b = appendUint64(b, sig.VectorCommitmentIndex)
+	return append(b, proof...), nil
+}
+
+// hashableProof is the fixed length representation of a single leaf proof:
+// its depth, then zeros for the levels the tree does not have, then the path
+func hashableProof(proof types.MerkleArrayProof) ([]byte, error) {
+	if proof.TreeDepth > merklearray.MaxEncodedTreeDepth {
+		return nil, fmt.Errorf("tree depth %d exceeds the maximum of %d", proof.TreeDepth, merklearray.MaxEncodedTreeDepth)
+	}
+	hsh, err := merklearray.NewHash(proof.HashFactory)
+	if err != nil {
+		return nil, err
+	}
+	size := hsh.Size()
+	b := make([]byte, 1+merklearray.MaxEncodedTreeDepth*size)
+	b[0] = proof.TreeDepth
+	offset := 1 + int(merklearray.MaxEncodedTreeDepth-proof.TreeDepth)*size
+	for i := 0; i < int(proof.TreeDepth) && i < len(proof.Path); i++ {
+		copy(b[offset+i*size:offset+(i+1)*size], proof.Path[i])
+	}
+	return b, nil
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	var buf [2]byte
+	binary.LittleEndian.PutUint16(buf[:], v)
+	return append(b, buf[:]...)
+}
@@ -0,0 +1,115 @@
+package stateproofs
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// shake256 is the SHAKE256 extendable-output function of FIPS 202, which
+// state proofs use to choose the signatures they reveal
+type shake256 struct {
+	state     [25]uint64
+	buf       [shake256Rate]byte
+	n         int
+	squeezing bool
+}
+
+// shake256Rate is the number of bytes absorbed or squeezed per permutation
+const shake256Rate = 136
+
+var keccakRoundConstants = [24]uint64{
+	0x0000000000000001, 0x0000000000008082, 0x800000000000808A, 0x8000000080008000,
+	0x000000000000808B, 0x0000000080000001, 0x8000000080008081, 0x8000000000008009,
+	0x000000000000008A, 0x0000000000000088, 0x0000000080008009, 0x000000008000000A,
+	0x000000008000808B, 0x800000000000008B, 0x8000000000008089, 0x8000000000008003,
+	0x8000000000008002, 0x8000000000000080, 0x000000000000800A, 0x800000008000000A,
+	0x8000000080008081, 0x8000000000008080, 0x0000000080000001, 0x8000000080008008,
+}
+
+// keccakRotations and keccakPi are the rotations and the order of the lanes
+// of the rho and pi steps, starting from lane 1
+var keccakRotations = [24]int{1, 3, 6, 10, 15, 21, 28, 36, 45, 55, 2, 14, 27, 41, 56, 8, 25, 43, 62, 18, 39, 61, 20, 44}
+var keccakPi = [24]int{10, 7, 11, 17, 18, 3, 5, 16, 8, 21, 24, 4, 15, 23, 19, 13, 12, 2, 20, 14, 22, 9, 6, 1}
+
+func keccakF1600(a *[25]uint64) {
+	var c [5]uint64
+	for round := 0; round < 24; round++ {
+		// theta
+		for x := 0; x < 5; x++ {
+			c[x] = a[x] ^ a[x+5] ^ a[x+10] ^ a[x+15] ^ a[x+20]
+		}
+		for x := 0; x < 5; x++ {
+			d := c[(x+4)%5] ^ bits.RotateLeft64(c[(x+1)%5], 1)
+			for y := 0; y < 25; y += 5 {
+				a[y+x] ^= d
+			}
+		}
+		// rho and pi
+		t := a[1]
+		for i := 0; i < 24; i++ {
+			j := keccakPi[i]
+			t, a[j] = a[j], bits.RotateLeft64(t, keccakRotations[i])
+		}
+		// chi
+		for y := 0; y < 25; y += 5 {
+			copy(c[:], a[y:y+5])
+			for x := 0; x < 5; x++ {
+				a[y+x] ^= ^c[(x+1)%5] & c[(x+2)%5]
+			}
+		}
+		// iota
+		a[0] ^= keccakRoundConstants[round]
+	}
+}
+
+// xorIn absorbs a full block into the state
+func (s *shake256) xorIn(block []byte) {
+	for i := 0; i < shake256Rate/8; i++ {
+		s.state[i] ^= binary.LittleEndian.Uint64(block[8*i:])
+	}
+}
+
+// Write absorbs more input. It must not be called after Read.
+func (s *shake256) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		k := copy(s.buf[s.n:], p)
+		s.n += k
+		p = p[k:]
+		if s.n == shake256Rate {
+			s.xorIn(s.buf[:])
+			keccakF1600(&s.state)
+			s.n = 0
+		}
+	}
+	return written, nil
+}
+
+// Read squeezes output
+func (s *shake256) Read(p []byte) (int, error) {
+	if !s.squeezing {
+		// pad the last block with the SHAKE domain and the final bit
+		for i := s.n; i < shake256Rate; i++ {
+			s.buf[i] = 0
+		}
+		s.buf[s.n] ^= 0x1f
+		s.buf[shake256Rate-1] ^= 0x80
+		s.xorIn(s.buf[:])
+		s.squeezing = true
+		s.n = shake256Rate
+	}
+	read := len(p)
+	for len(p) > 0 {
+		if s.n == shake256Rate {
+			keccakF1600(&s.state)
+			for i := 0; i < shake256Rate/8; i++ {
+				binary.LittleEndian.PutUint64(s.buf[8*i:], s.state[i])
+			}
+			s.n = 0
+		}
+		k := copy(p, s.buf[s.n:])
+		s.n += k
+		p = p[k:]
+	}
+	return read, nil
+}
@@ -0,0 +1,180 @@
+// Package stateproofs verifies state proofs, with which the network attests
+// to the headers of the blocks of each interval of rounds, so that light
+// clients and bridges can trust chain data without running a node.
+//
+// A light client starts from a trusted StateProofMessage, whose
+// VotersCommitment and LnProvenWeight say who must sign the next state proof,
+// verifies the next state proof and its message with a Verifier, and then
+// trusts that message in turn. Block headers of the attested rounds are
+// verified against the message with VerifyLightBlockHeader.
+//
+// State proofs are signed with deterministic Falcon-1024 keys and their trees
+// are hashed with SumHash512, neither of which this package implements. The
+// Falcon implementation is passed to MakeVerifier, and SumHash512 must be
+// registered with merklearray.RegisterHash before verifying.
+package stateproofs
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/merklearray"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// StrengthTarget is the security level of state proofs, in bits
+const StrengthTarget = 256
+
+// MaxReveals is the maximum number of signatures a state proof reveals
+const MaxReveals = 640
+
+// The domain separation prefixes of the values state proofs hash
+var (
+	messagePrefix     = []byte("spm")
+	participantPrefix = []byte("spp")
+	sigSlotPrefix     = []byte("sps")
+	coinPrefix        = []byte("spc")
+	keyPrefix         = []byte("KP")
+)
+
+// Falcon verifies the deterministic Falcon-1024 signatures of participation
+// keys. github.com/algorand/falcon implements both methods.
+type Falcon interface {
+	// Verify returns an error unless sig is a signature of msg by pk
+	Verify(pk types.FalconVerifier, msg []byte, sig types.FalconSignature) error
+	// ConvertToCT returns the signature in its fixed length CT format, in
+	// which signatures are committed to
+	ConvertToCT(sig types.FalconSignature) ([]byte, error)
+}
+
+// MessageHash is the hash of a state proof message, which participants sign
+type MessageHash [sha256.Size]byte
+
+// HashMessage returns the hash of a state proof message
+func HashMessage(msg types.StateProofMessage) MessageHash {
+	return sha256.Sum256(append(append([]byte{}, messagePrefix...), msgpack.Encode(msg)...))
+}
+
+// Verifier verifies state proofs signed by one set of voters
+type Verifier struct {
+	votersCommitment types.GenericDigest
+	lnProvenWeight   uint64
+	falcon           Falcon
+}
+
+// MakeVerifier returns a Verifier of the state proofs signed by the voters
+// with the given commitment, which must prove the given weight: those of the
+// message of the previous state proof, or of the StateProofTracking of the
+// block header before the first interval
+func MakeVerifier(votersCommitment types.GenericDigest, lnProvenWeight uint64, falcon Falcon) Verifier {
+	return Verifier{votersCommitment: votersCommitment, lnProvenWeight: lnProvenWeight, falcon: falcon}
+}
+
+// VerifyMessage verifies that a state proof attests to a message
+func (v Verifier) VerifyMessage(proof types.StateProof, msg types.StateProofMessage) error {
+	return v.Verify(msg.LastAttestedRound, HashMessage(msg), proof)
+}
+
+// Verify verifies that a state proof attests to the message with the given
+// hash, signed with keys valid for round
+func (v Verifier) Verify(round uint64, data MessageHash, proof types.StateProof) error {
+	if proof.SigProofs.TreeDepth > merklearray.MaxEncodedTreeDepth || proof.PartProofs.TreeDepth > merklearray.MaxEncodedTreeDepth {
+		return fmt.Errorf("state proof trees are too deep")
+	}
+	numReveals := uint64(len(proof.PositionsToReveal))
+	if err := verifyWeights(proof.SignedWeight, v.lnProvenWeight, numReveals, StrengthTarget); err != nil {
+		return err
+	}
+
+	sigs := make(map[uint64][]byte, len(proof.Reveals))
+	parts := make(map[uint64][]byte, len(proof.Reveals))
+	for pos, reveal := range proof.Reveals {
+		sig := reveal.SigSlot.Sig
+		if len(sig.Signature) < 2 || sig.Signature[1] != proof.MerkleSignatureSaltVersion {
+			return fmt.Errorf("the signature at position %d has the wrong salt version", pos)
+		}
+		slot, err := v.hashRepSigSlot(reveal.SigSlot)
+		if err != nil {
+			return fmt.Errorf("the signature at position %d: %v", pos, err)
+		}
+		sigs[pos] = slot
+		parts[pos] = hashRepParticipant(reveal.Part)
+		if err := VerifyMerkleSignature(reveal.Part.PK, round, data[:], sig, v.falcon); err != nil {
+			return fmt.Errorf("the signature at position %d: %v", pos, err)
+		}
+	}
+	if err := merklearray.VerifyVectorCommitment(proof.SigCommit, sigs, proof.SigProofs); err != nil {
+		return fmt.Errorf("signatures not in the signature commitment: %v", err)
+	}
+	if err := merklearray.VerifyVectorCommitment(v.votersCommitment, parts, proof.PartProofs); err != nil {
+		return fmt.Errorf("participants not in the voters commitment: %v", err)
+	}
+
+	coins := makeCoinGenerator(coinChoiceSeed{
+		partCommitment: v.votersCommitment,
+		lnProvenWeight: v.lnProvenWeight,
+		sigCommitment:  proof.SigCommit,
+		signedWeight:   proof.SignedWeight,
+		data:           data,
+	})
+	for _, pos := range proof.PositionsToReveal {
+		reveal, ok := proof.Reveals[pos]
+		if !ok {
+			return fmt.Errorf("position %d is not revealed", pos)
+		}
+		coin := coins.next()
+		if coin < reveal.SigSlot.L || coin >= reveal.SigSlot.L+reveal.Part.Weight {
+			return fmt.Errorf("the signature at position %d was not chosen by coin %d", pos, coin)
+		}
+	}
+	return nil
+}
+
+// hashRepParticipant is the hash representation of a participant, as
+// committed to by the voters commitment
+func hashRepParticipant(p types.Participant) []byte {
+	b := append([]byte{}, participantPrefix...)
+	b = appendUint64(b, p.Weight)
+	b = appendUint64(b, p.PK.KeyLifetime)
+	return append(b, p.PK.Commitment[:]...)
+}
+
+// hashRepSigSlot is the hash representation of a signature slot, as committed
+// to by the signature commitment
+func (v Verifier) hashRepSigSlot(slot types.SigslotCommit) ([]byte, error) {
+	b := append([]byte{}, sigSlotPrefix...)
+	if len(slot.Sig.Signature) == 0 {
+		return b, nil
+	}
+	sig, err := hashableMerkleSignature(slot.Sig, v.falcon)
+	if err != nil {
+		return nil, err
+	}
+	b = appendUint64(b, slot.L)
+	return append(b, sig...), nil
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// DecodeStateProof decodes a state proof and its message as algod returns them
+func DecodeStateProof(sp models.StateProof) (proof types.StateProof, msg types.StateProofMessage, err error) {
+	err = msgpack.Decode(sp.StateProof, &proof)
+	if err != nil {
+		return
+	}
+	msg = types.StateProofMessage{
+		BlockHeadersCommitment: sp.Message.BlockHeadersCommitment,
+		VotersCommitment:       sp.Message.VotersCommitment,
+		LnProvenWeight:         sp.Message.LnProvenWeight,
+		FirstAttestedRound:     sp.Message.FirstAttestedRound,
+		LastAttestedRound:      sp.Message.LastAttestedRound,
+	}
+	return
+}
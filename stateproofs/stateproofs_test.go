@@ -0,0 +1,373 @@
+package stateproofs
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/encoding/json"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/merklearray"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+func TestShake256(t *testing.T) {
+	// FIPS 202 test vectors
+	out := make([]byte, 32)
+	var s shake256
+	s.Read(out)
+	require.Equal(t, "46b9dd2b0ba88d13233b3feb743eeb243fcd52ea62b81b82b50c27646ed5762f", fmt.Sprintf("%x", out))
+	s = shake256{}
+	s.Write([]byte("ab"))
+	s.Write([]byte("c"))
+	s.Read(out[:5])
+	s.Read(out[5:])
+	require.Equal(t, "483366601360a8771c6863080cc4114d8db44530f8f1e1ee4f94ea37e78b5739", fmt.Sprintf("%x", out))
+
+	// reading across blocks is the same as reading at once
+	long := make([]byte, 3*shake256Rate+1)
+	s = shake256{}
+	s.Write(bytes.Repeat([]byte{7}, 2*shake256Rate))
+	s.Read(long)
+	pieces := make([]byte, len(long))
+	s = shake256{}
+	s.Write(bytes.Repeat([]byte{7}, shake256Rate))
+	s.Write(bytes.Repeat([]byte{7}, shake256Rate))
+	for i := 0; i < len(pieces); i += 50 {
+		end := i + 50
+		if end > len(pieces) {
+			end = len(pieces)
+		}
+		s.Read(pieces[i:end])
+	}
+	require.Equal(t, long, pieces)
+}
+
+func TestVerifyWeights(t *testing.T) {
+	signedWeight := uint64(1) << 40
+	// the proven weight is half the signed weight
+	lnProvenWeight := uint64(math.Ceil(math.Log(float64(signedWeight/2)) * (1 << precisionBits)))
+	require.Error(t, verifyWeights(0, lnProvenWeight, 10, StrengthTarget))
+	require.Error(t, verifyWeights(signedWeight, lnProvenWeight, MaxReveals+1, StrengthTarget))
+	require.Error(t, verifyWeights(signedWeight, lnProvenWeight, 100, StrengthTarget))
+	require.NoError(t, verifyWeights(signedWeight, lnProvenWeight, MaxReveals, StrengthTarget))
+
+	// more reveals are needed as the signed weight approaches the proven weight
+	minReveals := func(signedWeight uint64) uint64 {
+		for n := uint64(1); n <= MaxReveals; n++ {
+			if verifyWeights(signedWeight, lnProvenWeight, n, StrengthTarget) == nil {
+				return n
+			}
+		}
+		return math.MaxUint64
+	}
+	require.True(t, minReveals(signedWeight) > minReveals(signedWeight*2))
+	// each reveal of a proof signed by twice the proven weight halves the odds
+	// of a forgery, so at least StrengthTarget are needed
+	require.True(t, minReveals(signedWeight) >= StrengthTarget)
+}
+
+// fakeFalcon stands in for Falcon: a signature is a header, the salt version
+// and the SHA-256 of the key and message
+type fakeFalcon struct{}
+
+func (fakeFalcon) sign(pk types.FalconVerifier, msg []byte) types.FalconSignature {
+	h := sha256.Sum256(append(append([]byte{}, pk.PublicKey[:]...), msg...))
+	return append(types.FalconSignature{0xba, 0}, h[:]...)
+}
+
+func (f fakeFalcon) Verify(pk types.FalconVerifier, msg []byte, sig types.FalconSignature) error {
+	if !bytes.Equal(sig, f.sign(pk, msg)) {
+		return fmt.Errorf("bad signature")
+	}
+	return nil
+}
+
+func (fakeFalcon) ConvertToCT(sig types.FalconSignature) ([]byte, error) {
+	ct := make([]byte, 100)
+	copy(ct, sig)
+	return ct, nil
+}
+
+// vectorCommitment builds a vector commitment tree, returning its root and a
+// function proving some of its leaves
+func vectorCommitment(t *testing.T, hashType uint16, leaves [][]byte) ([]byte, func(positions ...uint64) types.MerkleArrayProof) {
+	hf := types.HashFactory{HashType: hashType}
+	hsh, err := merklearray.NewHash(hf)
+	require.NoError(t, err)
+	depth := uint8(0)
+	for 1<<depth < len(leaves) {
+		depth++
+	}
+	reverse := func(pos uint64) uint64 {
+		var r uint64
+		for i := uint8(0); i < depth; i++ {
+			r |= (pos >> i & 1) << (depth - 1 - i)
+		}
+		return r
+	}
+	layers := [][][]byte{make([][]byte, 1<<depth)}
+	for i, leaf := range leaves {
+		hsh.Reset()
+		hsh.Write(leaf)
+		layers[0][reverse(uint64(i))] = hsh.Sum(nil)
+	}
+	for len(layers[len(layers)-1]) > 1 {
+		below := layers[len(layers)-1]
+		var layer [][]byte
+		for i := 0; i < len(below); i += 2 {
+			buf := append([]byte("MA"), make([]byte, 2*hsh.Size())...)
+			copy(buf[2:], below[i])
+			copy(buf[2+hsh.Size():], below[i+1])
+			hsh.Reset()
+			hsh.Write(buf)
+			layer = append(layer, hsh.Sum(nil))
+		}
+		layers = append(layers, layer)
+	}
+	prove := func(positions ...uint64) types.MerkleArrayProof {
+		proof := types.MerkleArrayProof{HashFactory: hf, TreeDepth: depth}
+		var level []uint64
+		for _, pos := range positions {
+			level = append(level, reverse(pos))
+		}
+		for l := 0; l < int(depth); l++ {
+			sort.Slice(level, func(i, j int) bool { return level[i] < level[j] })
+			var next []uint64
+			for i := 0; i < len(level); i++ {
+				if i+1 < len(level) && level[i+1] == level[i]^1 {
+					i++
+				} else {
+					proof.Path = append(proof.Path, layers[l][level[i]^1])
+				}
+				next = append(next, level[i]/2)
+			}
+			level = next
+		}
+		return proof
+	}
+	return layers[len(layers)-1][0], prove
+}
+
+func TestVerify(t *testing.T) {
+	// SHA-512 stands in for SumHash512, which also has 64 byte digests
+	merklearray.RegisterHash(merklearray.Sumhash, sha512.New)
+	var falcon fakeFalcon
+	const keyLifetime = 256
+	msg := types.StateProofMessage{BlockHeadersCommitment: []byte{1}, FirstAttestedRound: 257, LastAttestedRound: 512, LnProvenWeight: 7}
+	data := HashMessage(msg)
+
+	// each participant commits to its key for round 512 alone
+	weights := []uint64{1 << 20, 3 << 20, 1 << 19, 5 << 20, 1 << 21}
+	var parts []types.Participant
+	var sigs []types.MerkleSignature
+	for i, weight := range weights {
+		var key types.FalconVerifier
+		key.PublicKey[0] = byte(i)
+		keyRoot, proveKey := vectorCommitment(t, merklearray.Sumhash, [][]byte{hashRepCommittablePublicKey(key, 512)})
+		var commitment types.MerkleVerifier
+		copy(commitment[:], keyRoot)
+		parts = append(parts, types.Participant{PK: types.StateProofVerifier{Commitment: commitment, KeyLifetime: keyLifetime}, Weight: weight})
+		sigs = append(sigs, types.MerkleSignature{Signature: falcon.sign(key, data[:]), Proof: proveKey(0), VerifyingKey: key})
+	}
+	var partLeaves [][]byte
+	for _, part := range parts {
+		partLeaves = append(partLeaves, hashRepParticipant(part))
+	}
+	votersCommitment, proveParts := vectorCommitment(t, merklearray.Sumhash, partLeaves)
+	// a participant of weight about a tenth of the total proves the weight
+	lnProvenWeight := uint64(math.Ceil(math.Log(float64(1<<20)) * (1 << precisionBits)))
+	verifier := MakeVerifier(votersCommitment, lnProvenWeight, falcon)
+
+	// every participant signed
+	var slots []types.SigslotCommit
+	var sigLeaves [][]byte
+	var signedWeight uint64
+	for i, sig := range sigs {
+		slot := types.SigslotCommit{Sig: sig, L: signedWeight}
+		signedWeight += weights[i]
+		slots = append(slots, slot)
+		leaf, err := verifier.hashRepSigSlot(slot)
+		require.NoError(t, err)
+		sigLeaves = append(sigLeaves, leaf)
+	}
+	sigCommit, proveSigs := vectorCommitment(t, merklearray.Sumhash, sigLeaves)
+
+	// reveal the signatures the coins choose
+	numReveals := uint64(1)
+	for verifyWeights(signedWeight, lnProvenWeight, numReveals, StrengthTarget) != nil {
+		numReveals++
+	}
+	proof := types.StateProof{SigCommit: sigCommit, SignedWeight: signedWeight, Reveals: map[uint64]types.Reveal{}}
+	coins := makeCoinGenerator(coinChoiceSeed{partCommitment: votersCommitment, lnProvenWeight: lnProvenWeight, sigCommitment: sigCommit, signedWeight: signedWeight, data: data})
+	for j := uint64(0); j < numReveals; j++ {
+		coin := coins.next()
+		pos := uint64(sort.Search(len(slots), func(i int) bool { return slots[i].L > coin }) - 1)
+		proof.PositionsToReveal = append(proof.PositionsToReveal, pos)
+		proof.Reveals[pos] = types.Reveal{SigSlot: slots[pos], Part: parts[pos]}
+	}
+	var revealed []uint64
+	for pos := range proof.Reveals {
+		revealed = append(revealed, pos)
+	}
+	proof.SigProofs = proveSigs(revealed...)
+	proof.PartProofs = proveParts(revealed...)
+
+	require.NoError(t, verifier.VerifyMessage(proof, msg))
+
+	// a state proof survives encoding as algod returns it
+	decoded, decodedMsg, err := DecodeStateProof(models.StateProof{
+		Message:    models.StateProofMessage{BlockHeadersCommitment: msg.BlockHeadersCommitment, FirstAttestedRound: 257, LastAttestedRound: 512, LnProvenWeight: 7},
+		StateProof: msgpack.Encode(proof),
+	})
+	require.NoError(t, err)
+	require.Equal(t, msg, decodedMsg)
+	require.NoError(t, verifier.VerifyMessage(decoded, decodedMsg))
+
+	// a different message
+	other := msg
+	other.LnProvenWeight++
+	require.Error(t, verifier.VerifyMessage(proof, other))
+	// keys for a different round
+	require.Error(t, verifier.Verify(768, data, proof))
+	// different voters
+	require.Error(t, MakeVerifier(sigCommit, lnProvenWeight, falcon).VerifyMessage(proof, msg))
+	// a higher proven weight
+	require.Error(t, MakeVerifier(votersCommitment, lnProvenWeight*2, falcon).VerifyMessage(proof, msg))
+	// too few reveals
+	fewer := proof
+	fewer.PositionsToReveal = proof.PositionsToReveal[:len(proof.PositionsToReveal)-1]
+	require.Error(t, verifier.VerifyMessage(fewer, msg))
+	// an overstated signed weight
+	heavier := proof
+	heavier.SignedWeight *= 2
+	require.Error(t, verifier.VerifyMessage(heavier, msg))
+	// the wrong salt version
+	salted := proof
+	salted.MerkleSignatureSaltVersion = 1
+	require.Error(t, verifier.VerifyMessage(salted, msg))
+}
+
+func TestVerifyMerkleSignature(t *testing.T) {
+	merklearray.RegisterHash(merklearray.Sumhash, sha512.New)
+	var falcon fakeFalcon
+	const keyLifetime = 256
+
+	// an account with keys for rounds 0, 256, 512 and 768
+	var keys []types.FalconVerifier
+	var leaves [][]byte
+	for i := 0; i < 4; i++ {
+		var key types.FalconVerifier
+		key.PublicKey[0] = byte(i + 1)
+		keys = append(keys, key)
+		leaves = append(leaves, hashRepCommittablePublicKey(key, uint64(i*keyLifetime)))
+	}
+	root, prove := vectorCommitment(t, merklearray.Sumhash, leaves)
+	v := types.StateProofVerifier{KeyLifetime: keyLifetime}
+	copy(v.Commitment[:], root)
+
+	msg := []byte("message")
+	sig := types.MerkleSignature{Signature: falcon.sign(keys[2], msg), Proof: prove(2), VectorCommitmentIndex: 2, VerifyingKey: keys[2]}
+	require.NoError(t, VerifyMerkleSignature(v, 600, msg, sig, falcon))
+	require.Error(t, VerifyMerkleSignature(v, 800, msg, sig, falcon))
+	require.Error(t, VerifyMerkleSignature(v, 600, []byte("other"), sig, falcon))
+
+	wrongKey := sig
+	wrongKey.VerifyingKey = keys[1]
+	wrongKey.Signature = falcon.sign(keys[1], msg)
+	require.Error(t, VerifyMerkleSignature(v, 600, msg, wrongKey, falcon))
+
+	v.KeyLifetime = 0
+	require.Error(t, VerifyMerkleSignature(v, 600, msg, sig, falcon))
+}
+
+func TestVerifyLightBlockHeader(t *testing.T) {
+	var headers []types.LightBlockHeader
+	var leaves [][]byte
+	for round := types.Round(257); round <= 512; round++ {
+		header := types.LightBlockHeader{Seed: types.Seed{byte(round)}, RoundNumber: round, GenesisHash: types.Digest{1}, Sha256TxnCommitment: []byte{31: byte(round)}}
+		headers = append(headers, header)
+		leaves = append(leaves, hashRepLightBlockHeader(header))
+	}
+	require.Equal(t, sha256.Sum256(leaves[0]), HashLightBlockHeader(headers[0]))
+	root, prove := vectorCommitment(t, merklearray.Sha256, leaves)
+	msg := types.StateProofMessage{BlockHeadersCommitment: root, FirstAttestedRound: 257, LastAttestedRound: 512}
+
+	proofFor := func(i uint64) models.LightBlockHeaderProof {
+		proof := prove(i)
+		var concatenated []byte
+		for _, d := range proof.Path {
+			concatenated = append(concatenated, d...)
+		}
+		return models.LightBlockHeaderProof{Index: i, Proof: concatenated, Treedepth: uint64(proof.TreeDepth)}
+	}
+	for _, i := range []uint64{0, 1, 100, 255} {
+		require.NoError(t, VerifyLightBlockHeader(headers[i], proofFor(i), msg))
+	}
+
+	changed := headers[100]
+	changed.Seed[1] = 1
+	require.Error(t, VerifyLightBlockHeader(changed, proofFor(100), msg))
+	require.Error(t, VerifyLightBlockHeader(headers[100], proofFor(101), msg))
+	late := headers[100]
+	late.RoundNumber = 600
+	require.Error(t, VerifyLightBlockHeader(late, proofFor(100), msg))
+	truncated := proofFor(100)
+	truncated.Proof = truncated.Proof[1:]
+	require.Error(t, VerifyLightBlockHeader(headers[100], truncated, msg))
+}
+
+// networkCapture is a state proof and a light block header of one of its
+// rounds, as captured from a MainNet or TestNet node into testdata/network.
+// A capture of round R is a JSON object of:
+//
+//   - "state-proof": GET /v2/stateproofs/R, the state proof covering R
+//   - "light-block-header-proof": GET /v2/blocks/R/lightheader/proof
+//   - "light-block-header": the seed, round, genesis hash and sha256
+//     transactions commitment ("seed", "rnd", "gh" and "txn256") of
+//     GET /v2/blocks/R, under the keys "0", "r", "gh" and "tc", and for
+//     versions committing to it, the block hash under "1"
+type networkCapture struct {
+	// StateProof is the response of GET /v2/stateproofs/{round}
+	StateProof models.StateProof `codec:"state-proof"`
+	// Header is the light block header of the round, in the JSON encoding
+	// of types.LightBlockHeader
+	Header types.LightBlockHeader `codec:"light-block-header"`
+	// HeaderProof is the response of GET /v2/blocks/{round}/lightheader/proof
+	HeaderProof models.LightBlockHeaderProof `codec:"light-block-header-proof"`
+}
+
+func TestNetworkCaptures(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "network", "*.json"))
+	require.NoError(t, err)
+	if len(files) == 0 {
+		t.Skip("no captures in testdata/network; see networkCapture to add one")
+	}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		var capture networkCapture
+		require.NoError(t, json.Decode(data, &capture), file)
+
+		proof, msg, err := DecodeStateProof(capture.StateProof)
+		require.NoError(t, err, file)
+		require.NotEmpty(t, proof.PositionsToReveal, file)
+		for _, pos := range proof.PositionsToReveal {
+			require.Contains(t, proof.Reveals, pos, file)
+		}
+
+		require.NoError(t, VerifyLightBlockHeader(capture.Header, capture.HeaderProof, msg), file)
+		tampered := capture.Header
+		tampered.Seed[0] ^= 1
+		require.Error(t, VerifyLightBlockHeader(tampered, capture.HeaderProof, msg), file)
+	}
+}
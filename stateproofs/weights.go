@@ -0,0 +1,58 @@
+package stateproofs
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// precisionBits is the number of fractional bits of the fixed point
+// approximations of logarithms
+const precisionBits = 16
+
+// ln2IntApproximation is ln(2) in fixed point, rounded up
+const ln2IntApproximation = 45427
+
+// verifyWeights checks that a state proof reveals enough signatures for the
+// weight it claims to have been signed with, checking that
+//
+// numReveals * (3 * 2^b * (signedWeight^2 - 2^2d) + d * (T-1) * Y) >= ((strengthTarget) * T + numReveals * P) * Y
+//
+// where 2^d <= signedWeight < 2^(d+1), Y = signedWeight^2 + 2^(d+2) * signedWeight + 2^2d,
+// b is precisionBits, T is ln2IntApproximation and P is lnProvenWeight.
+func verifyWeights(signedWeight, lnProvenWeight, numReveals, strengthTarget uint64) error {
+	if numReveals > MaxReveals {
+		return fmt.Errorf("%d reveals exceed the maximum of %d", numReveals, MaxReveals)
+	}
+	if signedWeight == 0 {
+		return fmt.Errorf("the signed weight is zero")
+	}
+	// d is computed as the network computes it, in floating point
+	d := uint(math.Log2(float64(signedWeight)))
+	signedWeightSquared := new(big.Int).SetUint64(signedWeight)
+	signedWeightSquared.Mul(signedWeightSquared, signedWeightSquared)
+	twoPow2d := new(big.Int).Lsh(big.NewInt(1), 2*d)
+
+	// y = signedWeight^2 + 2^(d+2) * signedWeight + 2^2d
+	y := new(big.Int).Lsh(big.NewInt(1), d+2)
+	y.Mul(y, new(big.Int).SetUint64(signedWeight))
+	y.Add(y, signedWeightSquared).Add(y, twoPow2d)
+	// x = 3 * 2^b * (signedWeight^2 - 2^2d)
+	x := new(big.Int).Sub(signedWeightSquared, twoPow2d)
+	x.Mul(x, big.NewInt(3)).Lsh(x, precisionBits)
+	// w = d * (T - 1)
+	w := new(big.Int).SetUint64(uint64(d) * (ln2IntApproximation - 1))
+
+	// numReveals * (x + w * y)
+	lhs := new(big.Int).Mul(w, y)
+	lhs.Add(lhs, x).Mul(lhs, new(big.Int).SetUint64(numReveals))
+	// (strengthTarget * T + numReveals * P) * y
+	rhs := new(big.Int).SetUint64(numReveals)
+	rhs.Mul(rhs, new(big.Int).SetUint64(lnProvenWeight))
+	rhs.Add(rhs, new(big.Int).Mul(new(big.Int).SetUint64(strengthTarget), big.NewInt(ln2IntApproximation)))
+	rhs.Mul(rhs, y)
+	if lhs.Cmp(rhs) < 0 {
+		return fmt.Errorf("%d reveals are not enough for signed weight %d", numReveals, signedWeight)
+	}
+	return nil
+}
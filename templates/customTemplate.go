@@ -0,0 +1,292 @@
+package templates
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// placeholderPattern matches named placeholders in TEAL template source, e.g. TMPL_RCV
+var placeholderPattern = regexp.MustCompile(`\bTMPL_[A-Z0-9_]+\b`)
+
+// placeholderNamePattern matches a whole placeholder name, with nothing before or after it
+var placeholderNamePattern = regexp.MustCompile(`^TMPL_[A-Z0-9_]+$`)
+
+// Compiler turns TEAL source into program bytes, typically by calling an algod node's compile endpoint
+type Compiler func(source string) ([]byte, error)
+
+// CustomTemplate is a user-defined contract template. It holds TEAL source with
+// named placeholders (e.g. TMPL_RCV, TMPL_FEE), a precompiled reference program
+// with the byte offset of each placeholder, or both.
+//
+// Placeholder values may be:
+//   - uint64: an integer constant (int TMPL_FEE)
+//   - types.Address: a 32 byte address constant (addr TMPL_RCV)
+//   - []byte: a byte string constant (byte base64 TMPL_LEASE)
+type CustomTemplate struct {
+	source  string
+	program []byte
+	offsets map[string]uint64
+	kinds   map[string]constantKind
+}
+
+// constantKind is the kind of constant a placeholder offset points at, which
+// decides the type of value that may be injected there
+type constantKind int
+
+const (
+	intConstant constantKind = iota
+	bytesConstant
+	addressConstant
+)
+
+func (kind constantKind) String() string {
+	switch kind {
+	case intConstant:
+		return "an integer constant"
+	case addressConstant:
+		return "an address constant"
+	default:
+		return "a byte string constant"
+	}
+}
+
+// kindOf returns the kind of constant a placeholder value must be injected into
+func kindOf(value interface{}) (constantKind, bool) {
+	switch value.(type) {
+	case uint64:
+		return intConstant, true
+	case types.Address:
+		return addressConstant, true
+	case []byte:
+		return bytesConstant, true
+	}
+	return 0, false
+}
+
+// MakeCustomTemplateFromSource creates a template from TEAL source containing TMPL_ placeholders
+func MakeCustomTemplateFromSource(source string) CustomTemplate {
+	return CustomTemplate{source: source}
+}
+
+// MakeCustomTemplateFromProgram creates a template from a compiled reference program.
+// offsets maps each placeholder name to its position in the program: for integers the
+// start of the uvarint in the intcblock, for addresses the start of the 32 address bytes,
+// and for byte strings the start of the length prefix in the bytecblock.
+// Every offset is checked against the program's constant blocks, and the kind
+// of constant it points at is recorded, so that Inject only accepts values of
+// the matching type. Two placeholders may not share an offset.
+func MakeCustomTemplateFromProgram(program []byte, offsets map[string]uint64) (CustomTemplate, error) {
+	ints, byteArrays, err := constantOffsets(program)
+	if err != nil {
+		return CustomTemplate{}, err
+	}
+	names := make([]string, 0, len(offsets))
+	for name := range offsets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	kinds := make(map[string]constantKind, len(offsets))
+	used := make(map[uint64]string, len(offsets))
+	for _, name := range names {
+		offset := offsets[name]
+		if !placeholderNamePattern.MatchString(name) {
+			return CustomTemplate{}, errorf(ErrInvalidTemplateValue, "invalid placeholder name %s", name)
+		}
+		if other, ok := used[offset]; ok {
+			return CustomTemplate{}, errorf(ErrInvalidTemplateValue, "offset %d is used by both %s and %s", offset, other, name)
+		}
+		used[offset] = name
+		_, isInt := ints[offset]
+		_, isBytes := byteArrays[offset]
+		_, isAddress := byteArrays[offset-1]
+		if isAddress {
+			isAddress = byteArrays[offset-1] == uint64(len(types.Address{}))
+		}
+		switch {
+		case isInt:
+			kinds[name] = intConstant
+		case isAddress:
+			kinds[name] = addressConstant
+		case isBytes:
+			kinds[name] = bytesConstant
+		default:
+			return CustomTemplate{}, errorf(ErrInvalidProgram, "offset %d for %s is not a constant in the program", offset, name)
+		}
+	}
+	copied := make(map[string]uint64, len(offsets))
+	for name, offset := range offsets {
+		copied[name] = offset
+	}
+	return CustomTemplate{program: program, offsets: copied, kinds: kinds}, nil
+}
+
+// Placeholders returns the sorted names of the template's placeholders
+func (t CustomTemplate) Placeholders() []string {
+	seen := make(map[string]bool)
+	for _, name := range placeholderPattern.FindAllString(t.source, -1) {
+		seen[name] = true
+	}
+	for name := range t.offsets {
+		seen[name] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Substitute replaces each placeholder in the template source with its value,
+// returning TEAL source ready to be compiled
+func (t CustomTemplate) Substitute(values map[string]interface{}) (string, error) {
+	if t.source == "" {
+		return "", errorf(ErrInvalidProgram, "template has no TEAL source")
+	}
+	if err := checkPlaceholderValues(placeholderPattern.FindAllString(t.source, -1), values); err != nil {
+		return "", err
+	}
+	var err error
+	result := placeholderPattern.ReplaceAllStringFunc(t.source, func(name string) string {
+		switch value := values[name].(type) {
+		case uint64:
+			return strconv.FormatUint(value, 10)
+		case types.Address:
+			return value.String()
+		case []byte:
+			return base64.StdEncoding.EncodeToString(value)
+		default:
+			if err == nil {
+				err = errorf(ErrInvalidTemplateValue, "unsupported type %T for %s", value, name)
+			}
+			return name
+		}
+	})
+	return result, err
+}
+
+// Compile substitutes values into the template source and compiles the result
+func (t CustomTemplate) Compile(values map[string]interface{}, compile Compiler) (ContractTemplate, error) {
+	source, err := t.Substitute(values)
+	if err != nil {
+		return ContractTemplate{}, err
+	}
+	program, err := compile(source)
+	if err != nil {
+		return ContractTemplate{}, err
+	}
+	return makeContractTemplate(program), nil
+}
+
+// Inject patches values into the reference program at the placeholder offsets.
+// Each value must have the type of the constant its offset points at: uint64
+// for integers, types.Address for addresses and []byte for byte strings.
+func (t CustomTemplate) Inject(values map[string]interface{}) (ContractTemplate, error) {
+	if t.program == nil {
+		return ContractTemplate{}, errorf(ErrInvalidProgram, "template has no reference program")
+	}
+	names := make([]string, 0, len(t.offsets))
+	for name := range t.offsets {
+		names = append(names, name)
+	}
+	if err := checkPlaceholderValues(names, values); err != nil {
+		return ContractTemplate{}, err
+	}
+	// inject expects offsets in program order
+	sort.Slice(names, func(i, j int) bool { return t.offsets[names[i]] < t.offsets[names[j]] })
+	offsets := make([]uint64, len(names))
+	injectionVector := make([]interface{}, len(names))
+	for i, name := range names {
+		offsets[i] = t.offsets[name]
+		if kind, ok := kindOf(values[name]); ok && kind != t.kinds[name] {
+			return ContractTemplate{}, errorf(ErrInvalidTemplateValue, "value for %s is a %T, but its offset %d is %s", name, values[name], offsets[i], t.kinds[name])
+		}
+		switch value := values[name].(type) {
+		case uint64, types.Address:
+			injectionVector[i] = value
+		case []byte:
+			injectionVector[i] = base64.StdEncoding.EncodeToString(value)
+		default:
+			return ContractTemplate{}, errorf(ErrInvalidTemplateValue, "unsupported type %T for %s", value, name)
+		}
+	}
+	program, err := inject(t.program, offsets, injectionVector)
+	if err != nil {
+		return ContractTemplate{}, err
+	}
+	return makeContractTemplate(program), nil
+}
+
+func makeContractTemplate(program []byte) ContractTemplate {
+	address := crypto.AddressFromProgram(program)
+	return ContractTemplate{address: address.String(), program: program}
+}
+
+// checkPlaceholderValues makes sure there is exactly one value for each placeholder
+func checkPlaceholderValues(names []string, values map[string]interface{}) error {
+	expected := make(map[string]bool)
+	for _, name := range names {
+		expected[name] = true
+		if _, ok := values[name]; !ok {
+			return errorf(ErrInvalidTemplateValue, "missing value for %s", name)
+		}
+	}
+	for name := range values {
+		if !expected[name] {
+			return errorf(ErrInvalidTemplateValue, "unknown placeholder %s", name)
+		}
+	}
+	return nil
+}
+
+// constantOffsets walks the constant blocks at the start of a program and returns the
+// offsets of each integer constant, and of each byte constant's length prefix mapped to its length
+func constantOffsets(program []byte) (ints map[uint64]bool, byteArrays map[uint64]uint64, err error) {
+	const intcblock, bytecblock = 0x20, 0x26
+	ints = make(map[uint64]bool)
+	byteArrays = make(map[uint64]uint64)
+	_, pc := binary.Uvarint(program)
+	if pc <= 0 {
+		err = errorf(ErrInvalidProgram, "invalid program version")
+		return
+	}
+	for pc < len(program) && (program[pc] == intcblock || program[pc] == bytecblock) {
+		op := program[pc]
+		pc++
+		count, bytesUsed := binary.Uvarint(program[pc:])
+		if bytesUsed <= 0 {
+			err = errorf(ErrInvalidProgram, "could not decode constant block size at pc=%d", pc)
+			return
+		}
+		pc += bytesUsed
+		for i := uint64(0); i < count; i++ {
+			if pc >= len(program) {
+				err = errorf(ErrInvalidProgram, "constant block exceeds program length")
+				return
+			}
+			value, bytesUsed := binary.Uvarint(program[pc:])
+			if bytesUsed <= 0 {
+				err = errorf(ErrInvalidProgram, "could not decode constant at pc=%d", pc)
+				return
+			}
+			if op == intcblock {
+				ints[uint64(pc)] = true
+				pc += bytesUsed
+				continue
+			}
+			byteArrays[uint64(pc)] = value
+			pc += bytesUsed + int(value)
+			if pc > len(program) {
+				err = errorf(ErrInvalidProgram, "constant block exceeds program length")
+				return
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,194 @@
+package templates
+
+import (
+	"encoding/base64"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/protocol"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// DynamicFee template representation
+type DynamicFee struct {
+	ContractTemplate
+}
+
+// MakeDynamicFee contract allows you to create a transaction without
+// specifying the fee. The fee will be determined at the moment of
+// transfer.
+//
+// Parameters:
+//   - receiver: address to receive the assets
+//   - closeRemainder: address to receive remaining funds (optional)
+//   - amount: amount of assets to transfer
+//   - firstValid: first valid round for the transaction
+//   - lastValid: last valid round for the transaction
+func MakeDynamicFee(receiver, closeRemainder string, amount, firstValid, lastValid uint64) (DynamicFee, error) {
+	var lease [32]byte
+	crypto.RandomBytes(lease[:])
+	return makeDynamicFeeWithLease(receiver, closeRemainder, lease, amount, firstValid, lastValid)
+}
+
+// makeDynamicFeeWithLease is as MakeDynamicFee, but the caller can specify the lease
+func makeDynamicFeeWithLease(receiver, closeRemainder string, lease [32]byte, amount, firstValid, lastValid uint64) (DynamicFee, error) {
+	if lastValid < firstValid || lastValid-firstValid > protocol.Current.MaxTxnLife {
+		return DynamicFee{}, errorf(transaction.ErrInvalidValidity, "invalid validity window [%d, %d]: it must span at most %d rounds", firstValid, lastValid, protocol.Current.MaxTxnLife)
+	}
+	const referenceProgram = "ASAFAgEFBgcmAyD+vKC7FEpaTqe0OKRoGsgObKEFvLYH/FZTJclWlfaiEyDmmpYeby1feshmB5JlUr6YI17TM2PKiJGLuck4qRW2+QEGMgQiEjMAECMSEDMABzEAEhAzAAgxARIQMRYjEhAxECMSEDEHKBIQMQkpEhAxCCQSEDECJRIQMQQhBBIQMQYqEhA="
+	referenceAsBytes, err := base64.StdEncoding.DecodeString(referenceProgram)
+	if err != nil {
+		return DynamicFee{}, err
+	}
+	receiverAddr, err := types.DecodeAddress(receiver)
+	if err != nil {
+		return DynamicFee{}, err
+	}
+	var closeRemainderAddr types.Address
+	if closeRemainder != "" {
+		closeRemainderAddr, err = types.DecodeAddress(closeRemainder)
+		if err != nil {
+			return DynamicFee{}, err
+		}
+	}
+
+	var referenceOffsets = []uint64{ /*amount*/ 5 /*firstValid*/, 6 /*lastValid*/, 7 /*receiver*/, 11 /*closeRemainder*/, 44 /*lease*/, 76}
+	injectionVector := []interface{}{amount, firstValid, lastValid, receiverAddr, closeRemainderAddr, base64.StdEncoding.EncodeToString(lease[:])}
+	injectedBytes, err := inject(referenceAsBytes, referenceOffsets, injectionVector)
+	if err != nil {
+		return DynamicFee{}, err
+	}
+
+	address := crypto.AddressFromProgram(injectedBytes)
+	dynamicFee := DynamicFee{
+		ContractTemplate: ContractTemplate{
+			address: address.String(),
+			program: injectedBytes,
+		},
+	}
+	return dynamicFee, err
+}
+
+// SignDynamicFee takes in the contract bytes and returns the main transaction and signed logic needed to complete the
+// transfer. These should be sent to the fee payer, who can use
+// GetDynamicFeeTransactions() to update fields and create the auxiliary
+// transaction.
+// Parameters:
+// contract - the bytearray representing the contract in question
+// privateKey - the secret key of the account sending the funds, which delegates the contract
+// genesisHash - the bytearray representing the network for the txns
+func SignDynamicFee(contract []byte, privateKey crypto.SecretKey, genesisHash []byte) (txn types.Transaction, lsig types.LogicSig, err error) {
+	params, err := ReadDynamicFeeFromProgram(contract)
+	if err != nil {
+		return
+	}
+	var sender types.Address
+	copy(sender[:], privateKey[ed25519.PublicKeySize:])
+	txn, err = transaction.MakePaymentTxn(sender.String(), params.Receiver, 0, params.Amount, params.FirstValid, params.LastValid, nil, params.CloseRemainder, "", genesisHash)
+	if err != nil {
+		return
+	}
+	txn.AddLease(params.Lease, 0)
+	lsig, err = crypto.MakeLogicSig(contract, nil, privateKey, crypto.MultisigAccount{})
+	return
+}
+
+// GetDynamicFeeTransactions creates and signs the secondary dynamic fee transaction, updates
+// transaction fields, and signs as the fee payer; it returns both
+// transactions as bytes suitable for SendRawTransaction.
+// Parameters:
+// txn - main transaction from payer
+// lsig - the signed logic received from the payer
+// privateKey - the private key for the account that pays the fee
+// fee - fee per byte for both transactions
+func GetDynamicFeeTransactions(txn types.Transaction, lsig types.LogicSig, privateKey crypto.SecretKey, fee uint64) ([]byte, error) {
+	eSize, err := transaction.EstimateSize(txn)
+	if err != nil {
+		return nil, err
+	}
+	txn.Fee = types.MicroAlgos(eSize * fee)
+	if uint64(txn.Fee) < protocol.Current.MinTxnFee {
+		txn.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
+	}
+
+	var feePayer types.Address
+	copy(feePayer[:], privateKey[ed25519.PublicKeySize:])
+	// the fee payer reimburses the sender for exactly the main transaction's fee
+	feeTxn, err := transaction.MakePaymentTxn(feePayer.String(), txn.Sender.String(), fee, uint64(txn.Fee), uint64(txn.FirstValid), uint64(txn.LastValid), nil, "", "", txn.GenesisHash[:])
+	if err != nil {
+		return nil, err
+	}
+	feeTxn.AddLease(txn.Lease, fee)
+
+	gid, err := crypto.ComputeGroupID([]types.Transaction{feeTxn, txn})
+	if err != nil {
+		return nil, err
+	}
+	feeTxn.Group = gid
+	txn.Group = gid
+
+	_, feeTxnSigned, err := crypto.SignTransaction(privateKey, feeTxn)
+	if err != nil {
+		return nil, err
+	}
+	_, txnSigned, err := crypto.SignLogicsigTransaction(lsig, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	var signedGroup []byte
+	signedGroup = append(signedGroup, feeTxnSigned...)
+	signedGroup = append(signedGroup, txnSigned...)
+	return signedGroup, nil
+}
+
+// DynamicFeeParameters are the values a DynamicFee contract was created with
+type DynamicFeeParameters struct {
+	Receiver       string
+	CloseRemainder string
+	Amount         uint64
+	FirstValid     uint64
+	LastValid      uint64
+	Lease          [32]byte
+}
+
+// ReadDynamicFeeFromProgram checks that program was generated by MakeDynamicFee and
+// returns the parameters it enforces
+func ReadDynamicFeeFromProgram(program []byte) (DynamicFeeParameters, error) {
+	const name = "dynamic fee"
+	ints, byteArrays, err := readTemplateConstants(program, name, 5, 3)
+	if err != nil {
+		return DynamicFeeParameters{}, err
+	}
+	receiver, err := addressFromConstant(byteArrays[0], name)
+	if err != nil {
+		return DynamicFeeParameters{}, err
+	}
+	closeRemainder, err := addressFromConstant(byteArrays[1], name)
+	if err != nil {
+		return DynamicFeeParameters{}, err
+	}
+	params := DynamicFeeParameters{
+		Receiver:   receiver.String(),
+		Amount:     ints[2],
+		FirstValid: ints[3],
+		LastValid:  ints[4],
+	}
+	if closeRemainder != (types.Address{}) {
+		params.CloseRemainder = closeRemainder.String()
+	}
+	if len(byteArrays[2]) != len(params.Lease) {
+		return DynamicFeeParameters{}, errorf(ErrProgramMismatch, "program does not match the %s template", name)
+	}
+	copy(params.Lease[:], byteArrays[2])
+	df, err := makeDynamicFeeWithLease(params.Receiver, params.CloseRemainder, params.Lease, params.Amount, params.FirstValid, params.LastValid)
+	if err != nil {
+		return DynamicFeeParameters{}, err
+	}
+	if err = checkTemplateProgram(program, df.ContractTemplate, name); err != nil {
+		return DynamicFeeParameters{}, err
+	}
+	return params, nil
+}
@@ -0,0 +1,68 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of errors of making contract templates and their transactions. The
+// errors returned say what is wrong and unwrap to one of these, or to
+// transaction.ErrInvalidValidity for validity windows longer than a
+// transaction's life, so that callers can branch on the kind, e.g. with
+// errors.Is.
+var (
+	// ErrInvalidParameters is the kind of errors of contract parameters the
+	// contract cannot work with, such as a zero ratio or address, and of
+	// operations its parameters rule out, such as opting a split of Algos in
+	// to an asset
+	ErrInvalidParameters = errors.New("invalid contract parameters")
+
+	// ErrFeeTooHigh is the kind of errors of transaction fees the contract
+	// rejects because they are not below its maxFee
+	ErrFeeTooHigh = errors.New("fee not below the contract's maxFee")
+
+	// ErrInvalidRound is the kind of errors of transactions valid in rounds
+	// the contract rejects them in, such as a split refund valid before the
+	// contract's expiry round
+	ErrInvalidRound = errors.New("round not allowed by the contract")
+
+	// ErrImpreciseSplit is the kind of errors of split amounts that cannot be
+	// divided exactly at the contract's ratio
+	ErrImpreciseSplit = errors.New("amount does not split exactly")
+
+	// ErrTradeRejected is the kind of errors of limit order trades below the
+	// contract's exchange rate or minimum trade
+	ErrTradeRejected = errors.New("trade rejected by the contract")
+
+	// ErrProgramMismatch is the kind of errors of programs that were not
+	// built from the template they are read as
+	ErrProgramMismatch = errors.New("program does not match the template")
+
+	// ErrInvalidProgram is the kind of errors of programs whose constants or
+	// placeholders cannot be decoded
+	ErrInvalidProgram = errors.New("invalid template program")
+
+	// ErrInvalidTemplateValue is the kind of errors of custom template values
+	// that are missing, unknown or of the wrong type for their placeholder
+	ErrInvalidTemplateValue = errors.New("invalid template value")
+)
+
+// kindError is an error of one of the kinds above: it reads as its message
+// and unwraps to its kind
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// errorf returns an error of the given kind with a formatted message
+func errorf(kind error, format string, args ...interface{}) error {
+	return &kindError{kind: kind, msg: fmt.Sprintf(format, args...)}
+}
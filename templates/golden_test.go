@@ -0,0 +1,274 @@
+package templates
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	stdjson "encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+)
+
+// The golden files in testdata/golden record the encodings the templates
+// produce for matrices of parameters: programs, contract addresses and signed
+// transaction groups. Tests fail when an encoding changes, e.g. because of a
+// change to inject(), which would change the addresses of contracts users
+// have funded. Intended changes are recorded with
+//
+//	go test ./templates -run TestGolden -update
+//
+// and reviewed in the diff of the golden files.
+var update = flag.Bool("update", false, "rewrite the golden files of the templates")
+
+// goldenCase is the encodings of a template for a set of parameters
+type goldenCase struct {
+	Name    string            `json:"name"`
+	Params  map[string]string `json:"params"`
+	Program string            `json:"program"`
+	Address string            `json:"address"`
+	Group   string            `json:"group,omitempty"`
+}
+
+// sdkVector is a program and address the other SDKs' tests expect for the
+// parameters of a "cross-sdk" golden case
+type sdkVector struct {
+	program string
+	address string
+}
+
+var sdkVectors = map[string]sdkVector{
+	"split": {
+		program: "ASAIAcCWsQICAMDEBx5kkE4mAyCztwQn0+DycN+vsk+vJWcsoz/b7NDS6i33HOkvTpf+YiC3qUpIgHGWE8/1LPh9SGCalSN7IaITeeWSXbfsS5wsXyC4kBQ38Z8zcwWVAym4S8vpFB/c0XC6R4mnPi9EBADsPDEQIhIxASMMEDIEJBJAABkxCSgSMQcyAxIQMQglEhAxAiEEDRAiQAAuMwAAMwEAEjEJMgMSEDMABykSEDMBByoSEDMACCEFCzMBCCEGCxIQMwAIIQcPEBA=",
+		address: "KPYGWKTV7CKMPMTLQRNGMEQRSYTYDHUOFNV4UDSBDLC44CLIJPQWRTCPBU",
+	},
+	"htlc": {
+		program: "ASAE6AcBAMDPJCYDIOaalh5vLV96yGYHkmVSvpgjXtMzY8qIkYu5yTipFbb5IH+DsWV/8fxTuS3BgUih1l38LUsfo9Z3KErd0gASbZBpIP68oLsUSlpOp7Q4pGgayA5soQW8tgf8VlMlyVaV9qITMQEiDjEQIxIQMQcyAxIQMQgkEhAxCSgSLQEpEhAxCSoSMQIlDRAREA==",
+		address: "KNBD7ATNUVQ4NTLOI72EEUWBVMBNKMPHWVBCETERV2W7T2YO6CVMLJRBM4",
+	},
+	"limitOrder": {
+		program: "ASAKAAHAlrECApBOBLlgZB7AxAcmASD+vKC7FEpaTqe0OKRoGsgObKEFvLYH/FZTJclWlfaiEzEWIhIxECMSEDEBJA4QMgQjEkAAVTIEJRIxCCEEDRAxCTIDEhAzARAhBRIQMwERIQYSEDMBFCgSEDMBEzIDEhAzARIhBx01AjUBMQghCB01BDUDNAE0Aw1AACQ0ATQDEjQCNAQPEEAAFgAxCSgSMQIhCQ0QMQcyAxIQMQgiEhAQ",
+		address: "LXQWT2XLIVNFS54VTLR63UY5K6AMIEWI7YTVE6LB4RWZDBZKH22ZO3S36I",
+	},
+	"periodicPayment": {
+		program: "ASAHAegHZABfoMIevKOVASYCIAECAwQFBgcIAQIDBAUGBwgBAgMEBQYHCAECAwQFBgcIIJKvkYTkEzwJf2arzJOxERsSogG9nQzKPkpIoc4TzPTFMRAiEjEBIw4QMQIkGCUSEDEEIQQxAggSEDEGKBIQMQkyAxIxBykSEDEIIQUSEDEJKRIxBzIDEhAxAiEGDRAxCCUSEBEQ",
+	},
+	"dynamicFee": {
+		program: "ASAFAgGIJ7lgumAmAyD+vKC7FEpaTqe0OKRoGsgObKEFvLYH/FZTJclWlfaiEyDmmpYeby1feshmB5JlUr6YI17TM2PKiJGLuck4qRW2+SABAgMEBQYHCAECAwQFBgcIAQIDBAUGBwgBAgMEBQYHCDIEIhIzABAjEhAzAAcxABIQMwAIMQESEDEWIxIQMRAjEhAxBygSEDEJKRIQMQgkEhAxAiUSEDEEIQQSEDEGKhIQ",
+	},
+}
+
+var (
+	goldenOwner     = "726KBOYUJJNE5J5UHCSGQGWIBZWKCBN4WYD7YVSTEXEVNFPWUIJ7TAEOPM"
+	goldenReceivers = [2]string{"W6UUUSEAOGLBHT7VFT4H2SDATKKSG6ZBUIJXTZMSLW36YS44FRP5NVAU7U", "XCIBIN7RT4ZXGBMVAMU3QS6L5EKB7XGROC5EPCNHHYXUIBAA5Q6C5Y7NEU"}
+	goldenLease     = [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+)
+
+// goldenGenesisHash is the genesis hash of the transactions of the golden
+// groups
+func goldenGenesisHash(t *testing.T) []byte {
+	genesisHash, err := base64.StdEncoding.DecodeString("f4OxZX/x/FO5LcGBSKHWXfwtSx+j1ncoSt3SABJtkGk=")
+	require.NoError(t, err)
+	return genesisHash
+}
+
+// goldenAccount returns an account whose key is derived from name, so that
+// the groups it signs are the same on each run
+func goldenAccount(t *testing.T, name string) crypto.Account {
+	seed := sha256.Sum256([]byte("golden " + name))
+	sk, err := crypto.SecretKeyFromSeed(seed[:])
+	require.NoError(t, err)
+	account, err := crypto.AccountFromPrivateKey(sk)
+	require.NoError(t, err)
+	return account
+}
+
+// params returns the parameters of a golden case, formatted as strings
+func params(keysAndValues ...interface{}) map[string]string {
+	m := make(map[string]string)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		m[keysAndValues[i].(string)] = fmt.Sprint(keysAndValues[i+1])
+	}
+	return m
+}
+
+func goldenContract(name string, contract ContractTemplate, group []byte, p map[string]string) goldenCase {
+	c := goldenCase{
+		Name:    name,
+		Params:  p,
+		Program: base64.StdEncoding.EncodeToString(contract.GetProgram()),
+		Address: contract.GetAddress(),
+	}
+	if group != nil {
+		c.Group = base64.StdEncoding.EncodeToString(group)
+	}
+	return c
+}
+
+func splitCases(t *testing.T) []goldenCase {
+	matrix := []struct {
+		name                               string
+		ratn, ratd, expiry, minPay, maxFee uint64
+		amount, firstRound, lastRound, fee uint64
+	}{
+		{"cross-sdk", 30, 100, 123456, 10000, 5000000, 1000000, 1, 100, 0},
+		{"even", 1, 2, 1, 1, 2000, 2000, 1, 1000, 0},
+		{"large", math.MaxUint32, math.MaxUint64, math.MaxUint64 - 1, 1 << 40, 1 << 20, 1 << 50, 1 << 40, 1<<40 + 1000, 1},
+	}
+	var cases []goldenCase
+	for _, m := range matrix {
+		c, err := MakeSplit("WO3QIJ6T4DZHBX5PWJH26JLHFSRT7W7M2DJOULPXDTUS6TUX7ZRIO4KDFY", goldenReceivers[0], goldenReceivers[1], m.ratn, m.ratd, m.expiry, m.minPay, m.maxFee)
+		require.NoError(t, err, m.name)
+		group, err := c.GetSendFundsTransaction(m.amount, false, m.firstRound, m.lastRound, m.fee, goldenGenesisHash(t))
+		require.NoError(t, err, m.name)
+		cases = append(cases, goldenContract(m.name, c.ContractTemplate, group, params(
+			"ratn", m.ratn, "ratd", m.ratd, "expiryRound", m.expiry, "minPay", m.minPay, "maxFee", m.maxFee,
+			"amount", m.amount, "firstRound", m.firstRound, "lastRound", m.lastRound, "fee", m.fee)))
+	}
+	return cases
+}
+
+func htlcCases(t *testing.T) []goldenCase {
+	preImage := base64.StdEncoding.EncodeToString([]byte("secret"))
+	sha256Image := sha256.Sum256([]byte("secret"))
+	matrix := []struct {
+		name, hashFn, hashImg string
+		expiry, maxFee        uint64
+		claim                 bool
+	}{
+		{"cross-sdk", "sha256", "f4OxZX/x/FO5LcGBSKHWXfwtSx+j1ncoSt3SABJtkGk=", 600000, 1000, false},
+		{"sha256-claim", "sha256", base64.StdEncoding.EncodeToString(sha256Image[:]), 600000, 1000, true},
+		{"keccak256", "keccak256", "f4OxZX/x/FO5LcGBSKHWXfwtSx+j1ncoSt3SABJtkGk=", 1, 0, false},
+		{"large", "sha256", base64.StdEncoding.EncodeToString(sha256Image[:]), math.MaxUint64, math.MaxUint64, true},
+	}
+	var cases []goldenCase
+	for _, m := range matrix {
+		c, err := MakeHTLC(goldenOwner, "42NJMHTPFVPXVSDGA6JGKUV6TARV5UZTMPFIREMLXHETRKIVW34QFSDFRE", m.hashFn, m.hashImg, m.expiry, m.maxFee)
+		require.NoError(t, err, m.name)
+		var group []byte
+		if m.claim {
+			group, err = c.GetClaimTransaction(preImage, 1, 100, 0, goldenGenesisHash(t))
+			require.NoError(t, err, m.name)
+		}
+		cases = append(cases, goldenContract(m.name, c.ContractTemplate, group, params(
+			"hashFunction", m.hashFn, "hashImage", m.hashImg, "expiryRound", m.expiry, "maxFee", m.maxFee)))
+	}
+	return cases
+}
+
+func limitOrderCases(t *testing.T) []goldenCase {
+	buyer := goldenAccount(t, "buyer")
+	matrix := []struct {
+		name                                          string
+		assetID, ratn, ratd, expiry, minTrade, maxFee uint64
+		assetAmount, algoAmount                       uint64
+	}{
+		{"cross-sdk", 12345, 30, 100, 123456, 10000, 5000000, 3001, 10001},
+		{"one-to-one", 1, 1, 1, 1, 0, 1000, 10, 10},
+		{"large", math.MaxUint64, 1, math.MaxUint64, math.MaxUint64, 1 << 40, math.MaxUint64, 1 << 41, 1<<40 + 1},
+	}
+	var cases []goldenCase
+	for _, m := range matrix {
+		c, err := MakeLimitOrder(goldenOwner, m.assetID, m.ratn, m.ratd, m.expiry, m.minTrade, m.maxFee)
+		require.NoError(t, err, m.name)
+		group, err := c.GetSwapAssetsTransaction(m.assetAmount, c.GetProgram(), buyer.PrivateKey, 0, m.algoAmount, 1, 100, goldenGenesisHash(t))
+		require.NoError(t, err, m.name)
+		cases = append(cases, goldenContract(m.name, c.ContractTemplate, group, params(
+			"assetID", m.assetID, "ratn", m.ratn, "ratd", m.ratd, "expiryRound", m.expiry, "minTrade", m.minTrade, "maxFee", m.maxFee,
+			"assetAmount", m.assetAmount, "algoAmount", m.algoAmount)))
+	}
+	return cases
+}
+
+func periodicPaymentCases(t *testing.T) []goldenCase {
+	matrix := []struct {
+		name                                           string
+		receiver                                       string
+		amount, withdrawWindow, period, expiry, maxFee uint64
+		firstValid                                     uint64
+	}{
+		{"cross-sdk", "SKXZDBHECM6AS73GVPGJHMIRDMJKEAN5TUGMUPSKJCQ44E6M6TC2H2UJ3I", 500000, 95, 100, 2445756, 1000, 1200},
+		{"small", goldenReceivers[0], 1, 1, 1, 1, 0, 7},
+		{"large", goldenReceivers[1], math.MaxUint64, 1000, 1 << 40, math.MaxUint64, math.MaxUint64, 1 << 41},
+	}
+	var cases []goldenCase
+	for _, m := range matrix {
+		c, err := MakePeriodicPaymentWithLease(m.receiver, goldenLease[:], m.amount, m.withdrawWindow, m.period, m.expiry, m.maxFee)
+		require.NoError(t, err, m.name)
+		group, err := c.GetWithdrawalTransaction(m.firstValid, 0, goldenGenesisHash(t))
+		require.NoError(t, err, m.name)
+		cases = append(cases, goldenContract(m.name, c.ContractTemplate, group, params(
+			"receiver", m.receiver, "amount", m.amount, "withdrawWindow", m.withdrawWindow, "period", m.period,
+			"expiryRound", m.expiry, "maxFee", m.maxFee, "firstValid", m.firstValid)))
+	}
+	return cases
+}
+
+func dynamicFeeCases(t *testing.T) []goldenCase {
+	sender := goldenAccount(t, "sender")
+	feePayer := goldenAccount(t, "fee payer")
+	matrix := []struct {
+		name                          string
+		receiver, closeRemainder      string
+		amount, firstValid, lastValid uint64
+	}{
+		{"cross-sdk", goldenOwner, "42NJMHTPFVPXVSDGA6JGKUV6TARV5UZTMPFIREMLXHETRKIVW34QFSDFRE", 5000, 12345, 12346},
+		{"no-close", goldenReceivers[0], "", 1, 1, 1000},
+		{"large", goldenReceivers[1], goldenReceivers[0], math.MaxUint64, 1 << 40, 1<<40 + 1000},
+	}
+	var cases []goldenCase
+	for _, m := range matrix {
+		c, err := makeDynamicFeeWithLease(m.receiver, m.closeRemainder, goldenLease, m.amount, m.firstValid, m.lastValid)
+		require.NoError(t, err, m.name)
+		txn, lsig, err := SignDynamicFee(c.GetProgram(), sender.PrivateKey, goldenGenesisHash(t))
+		require.NoError(t, err, m.name)
+		group, err := GetDynamicFeeTransactions(txn, lsig, feePayer.PrivateKey, 1)
+		require.NoError(t, err, m.name)
+		cases = append(cases, goldenContract(m.name, c.ContractTemplate, group, params(
+			"receiver", m.receiver, "closeRemainder", m.closeRemainder, "amount", m.amount,
+			"firstValid", m.firstValid, "lastValid", m.lastValid)))
+	}
+	return cases
+}
+
+// checkGolden compares cases with those of the golden file of the template,
+// or rewrites the file with -update
+func checkGolden(t *testing.T, template string, cases []goldenCase) {
+	if vector, ok := sdkVectors[template]; ok {
+		require.Equal(t, "cross-sdk", cases[0].Name)
+		require.Equal(t, vector.program, cases[0].Program, "%s program differs from the other SDKs'", template)
+		if vector.address != "" {
+			require.Equal(t, vector.address, cases[0].Address, "%s address differs from the other SDKs'", template)
+		}
+	}
+
+	path := filepath.Join("testdata", "golden", template+".json")
+	if *update {
+		encoded, err := stdjson.MarshalIndent(cases, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(path, append(encoded, '\n'), 0644))
+		return
+	}
+	encoded, err := ioutil.ReadFile(path)
+	require.NoError(t, err, "run with -update to record the golden file")
+	var golden []goldenCase
+	require.NoError(t, stdjson.Unmarshal(encoded, &golden))
+	require.Len(t, cases, len(golden), "%s: the golden file has a different matrix, run with -update", template)
+	for i := range golden {
+		require.Equal(t, golden[i], cases[i], "%s: %s differs from the golden file", template, golden[i].Name)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	checkGolden(t, "split", splitCases(t))
+	checkGolden(t, "htlc", htlcCases(t))
+	checkGolden(t, "limitOrder", limitOrderCases(t))
+	checkGolden(t, "periodicPayment", periodicPaymentCases(t))
+	checkGolden(t, "dynamicFee", dynamicFeeCases(t))
+}
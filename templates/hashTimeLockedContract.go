@@ -2,14 +2,71 @@ package templates
 
 import (
 	"encoding/base64"
-	"fmt"
+
 	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/transaction"
 	"github.com/algorand/go-algorand-sdk/types"
 )
 
 // HTLC template representation
 type HTLC struct {
 	ContractTemplate
+	owner       types.Address
+	receiver    types.Address
+	expiryRound uint64
+	maxFee      uint64
+}
+
+// GetClaimTransaction returns a signed transaction, suitable for passing to SendRawTransaction,
+// which closes the contract account out to the receiver by revealing the hash preimage.
+// preImage: the value whose hash is the contract's hashImage, base64-encoded
+// firstRound: first round on which the txn will be valid
+// lastRound: last round on which the txn will be valid
+// fee: fee per byte used for the transaction; the resulting fee must not exceed the contract's maxFee
+// genesisHash: genesisHash indicating the network for the txn
+func (contract HTLC) GetClaimTransaction(preImage string, firstRound, lastRound, fee uint64, genesisHash []byte) ([]byte, error) {
+	var zeroAddress types.Address
+	// the contract requires a zero receiver and amount, closing everything out to the receiver
+	txn, err := transaction.MakePaymentTxn(contract.address, zeroAddress.String(), fee, 0, firstRound, lastRound, nil, contract.receiver.String(), "", genesisHash)
+	if err != nil {
+		return nil, err
+	}
+	_, stx, err := SignTransactionWithHTLCUnlock(contract.program, txn, preImage)
+	return stx, err
+}
+
+// GetRefundTransaction returns a signed transaction, suitable for passing to
+// SendRawTransaction, which closes the contract account out to the owner. The
+// contract approves it once its expiry round has passed, so firstRound must be
+// after it.
+// fee: fee per byte used for the transaction; the resulting fee must not exceed the contract's maxFee
+func (contract HTLC) GetRefundTransaction(firstRound, lastRound, fee uint64, genesisHash []byte) ([]byte, error) {
+	txn, err := contract.makeRefundTxn(contract.owner, contract.expiryRound, firstRound, lastRound, fee, genesisHash)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(txn.Fee) > contract.maxFee {
+		return nil, errorf(ErrFeeTooHigh, "HTLC transaction fee %d exceeds the contract's maxFee %d", txn.Fee, contract.maxFee)
+	}
+	// the program hashes its first argument on either path, so one must be
+	// passed even though the refund does not reveal the preimage
+	return contract.signWithLogicSig(txn, [][]byte{{}})
+}
+
+// SignTransactionWithHTLCUnlock signs txn with the HTLC program as a contract account logicsig,
+// passing the base64-encoded preImage as the program argument that unlocks the contract.
+// txn must satisfy the contract: for a claim it has to close out to the receiver with no
+// receiver or amount of its own.
+func SignTransactionWithHTLCUnlock(program []byte, txn types.Transaction, preImage string) (txid string, stx []byte, err error) {
+	preImageBytes, err := base64.StdEncoding.DecodeString(preImage)
+	if err != nil {
+		return
+	}
+	lsig, err := crypto.MakeLogicSig(program, [][]byte{preImageBytes}, nil, crypto.MultisigAccount{})
+	if err != nil {
+		return
+	}
+	return crypto.SignLogicsigTransaction(lsig, txn)
 }
 
 // MakeHTLC allows a user to receive the Algo prior to a deadline (in terms of a round) by proving a knowledge
@@ -36,7 +93,7 @@ func MakeHTLC(owner, receiver, hashFunction, hashImage string, expiryRound, maxF
 	} else if hashFunction == "keccak256" {
 		referenceProgram = "ASAECAEACSYDIOaalh5vLV96yGYHkmVSvpgjXtMzY8qIkYu5yTipFbb5IH+DsWV/8fxTuS3BgUih1l38LUsfo9Z3KErd0gASbZBpIP68oLsUSlpOp7Q4pGgayA5soQW8tgf8VlMlyVaV9qITMQEiDjEQIxIQMQcyAxIQMQgkEhAxCSgSLQIpEhAxCSoSMQIlDRAREA=="
 	} else {
-		return HTLC{}, fmt.Errorf("invalid hash function supplied")
+		return HTLC{}, errorf(ErrInvalidParameters, "invalid hash function supplied")
 	}
 	referenceAsBytes, err := base64.StdEncoding.DecodeString(referenceProgram)
 	if err != nil {
@@ -68,6 +125,57 @@ func MakeHTLC(owner, receiver, hashFunction, hashImage string, expiryRound, maxF
 			address: address.String(),
 			program: injectedBytes,
 		},
+		owner:       ownerAddr,
+		receiver:    receiverAddr,
+		expiryRound: expiryRound,
+		maxFee:      maxFee,
 	}
 	return htlc, err
 }
+
+// HTLCParameters are the values an HTLC contract was created with
+type HTLCParameters struct {
+	Owner        string
+	Receiver     string
+	HashFunction string
+	HashImage    string
+	ExpiryRound  uint64
+	MaxFee       uint64
+}
+
+// ReadHTLCFromProgram checks that program was generated by MakeHTLC and
+// returns the parameters it enforces
+func ReadHTLCFromProgram(program []byte) (HTLCParameters, error) {
+	const name = "HTLC"
+	ints, byteArrays, err := readTemplateConstants(program, name, 4, 3)
+	if err != nil {
+		return HTLCParameters{}, err
+	}
+	receiver, err := addressFromConstant(byteArrays[0], name)
+	if err != nil {
+		return HTLCParameters{}, err
+	}
+	owner, err := addressFromConstant(byteArrays[2], name)
+	if err != nil {
+		return HTLCParameters{}, err
+	}
+	params := HTLCParameters{
+		Owner:       owner.String(),
+		Receiver:    receiver.String(),
+		HashImage:   base64.StdEncoding.EncodeToString(byteArrays[1]),
+		ExpiryRound: ints[3],
+		MaxFee:      ints[0],
+	}
+	// the hash function is the only part of the program that is not a constant
+	for _, hashFunction := range []string{"sha256", "keccak256"} {
+		htlc, err := MakeHTLC(params.Owner, params.Receiver, hashFunction, params.HashImage, params.ExpiryRound, params.MaxFee)
+		if err != nil {
+			return HTLCParameters{}, err
+		}
+		if checkTemplateProgram(program, htlc.ContractTemplate, name) == nil {
+			params.HashFunction = hashFunction
+			return params, nil
+		}
+	}
+	return HTLCParameters{}, errorf(ErrProgramMismatch, "program does not match the %s template", name)
+}
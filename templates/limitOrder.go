@@ -2,16 +2,43 @@ package templates
 
 import (
 	"encoding/base64"
+	"math/big"
+
 	"github.com/algorand/go-algorand-sdk/crypto"
 	"github.com/algorand/go-algorand-sdk/transaction"
 	"github.com/algorand/go-algorand-sdk/types"
 )
 
-// Split template representation
+// LimitOrder template representation
 type LimitOrder struct {
 	ContractTemplate
-	assetID uint64
-	owner   string
+	assetID     uint64
+	owner       string
+	ratn        uint64
+	ratd        uint64
+	minTrade    uint64
+	expiryRound uint64
+	maxFee      uint64
+}
+
+// GetRefundTransaction returns a signed transaction, suitable for passing to
+// SendRawTransaction, which closes the contract account out to the owner. The
+// contract approves it once its expiry round has passed, so firstRound must be
+// after it.
+// fee: fee per byte used for the transaction; the resulting fee must not exceed the contract's maxFee
+func (lo LimitOrder) GetRefundTransaction(firstRound, lastRound, fee uint64, genesisHash []byte) ([]byte, error) {
+	owner, err := types.DecodeAddress(lo.owner)
+	if err != nil {
+		return nil, err
+	}
+	txn, err := lo.makeRefundTxn(owner, lo.expiryRound, firstRound, lastRound, fee, genesisHash)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(txn.Fee) > lo.maxFee {
+		return nil, errorf(ErrFeeTooHigh, "limit order transaction fee %d exceeds the contract's maxFee %d", txn.Fee, lo.maxFee)
+	}
+	return lo.signWithLogicSig(txn, nil)
 }
 
 // GetSwapAssetsTransaction returns a group transaction array which transfer funds according to the contract's ratio
@@ -23,19 +50,29 @@ type LimitOrder struct {
 // firstRound: first round on which these txns will be valid
 // lastRound: last round on which these txns will be valid
 // genesisHash: genesisHash indicating the network for the txns
-// the first payment sends money (Algos) from contract to the recipient (we'll call him Buyer), closing the rest of the account to Owner
+// the first payment sends money (Algos) from contract to the recipient (we'll call him Buyer)
 // the second payment sends money (the asset) from Buyer to the Owner
 // these transactions will be rejected if they do not meet the restrictions set by the contract
 func (lo LimitOrder) GetSwapAssetsTransaction(assetAmount uint64, contract, secretKey []byte, fee, algoAmount, firstRound, lastRound uint64, genesisHash []byte) ([]byte, error) {
+	// the contract requires assetAmount * ratd >= algoAmount * ratn, checked here without overflow
+	assetSide := new(big.Int).Mul(new(big.Int).SetUint64(assetAmount), new(big.Int).SetUint64(lo.ratd))
+	algoSide := new(big.Int).Mul(new(big.Int).SetUint64(algoAmount), new(big.Int).SetUint64(lo.ratn))
+	if assetSide.Cmp(algoSide) < 0 {
+		return nil, errorf(ErrTradeRejected, "%d assets for %d microAlgos is worse than the contract's exchange rate %d/%d", assetAmount, algoAmount, lo.ratn, lo.ratd)
+	}
+	if algoAmount <= lo.minTrade {
+		return nil, errorf(ErrTradeRejected, "trade of %d microAlgos does not exceed the contract's minimum trade %d", algoAmount, lo.minTrade)
+	}
+
 	var buyerAddress types.Address
 	copy(buyerAddress[:], secretKey[32:])
 	contractAddress := crypto.AddressFromProgram(contract)
-	algosForAssets, err := transaction.MakePaymentTxn(contractAddress.String(), buyerAddress.String(), fee, algoAmount, firstRound, lastRound, nil, lo.owner, "", genesisHash)
+	algosForAssets, err := transaction.MakePaymentTxn(contractAddress.String(), buyerAddress.String(), fee, algoAmount, firstRound, lastRound, nil, "", "", genesisHash)
 	if err != nil {
 		return nil, err
 	}
 
-	assetsForAlgos, err := transaction.MakeAssetTransferTxn(buyerAddress.String(), lo.owner, "", assetAmount, fee, firstRound, lastRound, nil, lo.owner, "", lo.assetID)
+	assetsForAlgos, err := transaction.MakeAssetTransferTxn(buyerAddress.String(), lo.owner, "", assetAmount, fee, firstRound, lastRound, nil, "", base64.StdEncoding.EncodeToString(genesisHash), lo.assetID)
 	if err != nil {
 		return nil, err
 	}
@@ -59,9 +96,10 @@ func (lo LimitOrder) GetSwapAssetsTransaction(assetAmount uint64, contract, secr
 		return nil, err
 	}
 
+	// the contract must be the first transaction in the group
 	var signedGroup []byte
-	signedGroup = append(signedGroup, assetsForAlgosSigned...)
 	signedGroup = append(signedGroup, algosForAssetsSigned...)
+	signedGroup = append(signedGroup, assetsForAlgosSigned...)
 
 	return signedGroup, nil
 }
@@ -118,8 +156,55 @@ func MakeLimitOrder(owner string, assetID, ratn, ratd, expiryRound, minTrade, ma
 			address: address.String(),
 			program: injectedBytes,
 		},
-		owner:   owner,
-		assetID: assetID,
+		owner:       owner,
+		assetID:     assetID,
+		ratn:        ratn,
+		ratd:        ratd,
+		minTrade:    minTrade,
+		expiryRound: expiryRound,
+		maxFee:      maxFee,
 	}
 	return lo, err
 }
+
+// LimitOrderParameters are the values a LimitOrder contract was created with
+type LimitOrderParameters struct {
+	Owner       string
+	AssetID     uint64
+	Ratn        uint64
+	Ratd        uint64
+	ExpiryRound uint64
+	MinTrade    uint64
+	MaxFee      uint64
+}
+
+// ReadLimitOrderFromProgram checks that program was generated by MakeLimitOrder and
+// returns the parameters it enforces
+func ReadLimitOrderFromProgram(program []byte) (LimitOrderParameters, error) {
+	const name = "limit order"
+	ints, byteArrays, err := readTemplateConstants(program, name, 10, 1)
+	if err != nil {
+		return LimitOrderParameters{}, err
+	}
+	owner, err := addressFromConstant(byteArrays[0], name)
+	if err != nil {
+		return LimitOrderParameters{}, err
+	}
+	params := LimitOrderParameters{
+		Owner:       owner.String(),
+		MaxFee:      ints[2],
+		MinTrade:    ints[4],
+		AssetID:     ints[6],
+		Ratd:        ints[7],
+		Ratn:        ints[8],
+		ExpiryRound: ints[9],
+	}
+	lo, err := MakeLimitOrder(params.Owner, params.AssetID, params.Ratn, params.Ratd, params.ExpiryRound, params.MinTrade, params.MaxFee)
+	if err != nil {
+		return LimitOrderParameters{}, err
+	}
+	if err = checkTemplateProgram(program, lo.ContractTemplate, name); err != nil {
+		return LimitOrderParameters{}, err
+	}
+	return params, nil
+}
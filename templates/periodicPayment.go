@@ -0,0 +1,191 @@
+package templates
+
+import (
+	"encoding/base64"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/protocol"
+	"github.com/algorand/go-algorand-sdk/transaction"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// PeriodicPayment template representation
+type PeriodicPayment struct {
+	ContractTemplate
+	receiver       types.Address
+	amount         uint64
+	withdrawWindow uint64
+	period         uint64
+	expiryRound    uint64
+	lease          [32]byte
+}
+
+// NextWithdrawalRound returns the first round at or after round on which a withdrawal may begin,
+// that is, the next multiple of the contract's period.
+func (contract PeriodicPayment) NextWithdrawalRound(round uint64) uint64 {
+	if round%contract.period == 0 {
+		return round
+	}
+	return round + contract.period - round%contract.period
+}
+
+// GetWithdrawalTransaction returns a signed transaction, suitable for passing to SendRawTransaction,
+// which withdraws the contract's amount to the receiver.
+// The contract only allows withdrawals whose first valid round is a multiple of the period and whose
+// last valid round is exactly withdrawWindow rounds later; the returned transaction uses that window.
+// firstValid: the first round on which the txn will be valid; see NextWithdrawalRound
+// fee: fee per byte used for the transaction
+// genesisHash: genesisHash indicating the network for the txn
+func (contract PeriodicPayment) GetWithdrawalTransaction(firstValid, fee uint64, genesisHash []byte) ([]byte, error) {
+	if firstValid%contract.period != 0 {
+		return nil, errorf(ErrInvalidRound, "firstValid round %d is not a multiple of the contract period %d", firstValid, contract.period)
+	}
+	lastValid := firstValid + contract.withdrawWindow
+	txn, err := transaction.MakePaymentTxn(contract.address, contract.receiver.String(), fee, contract.amount, firstValid, lastValid, nil, "", "", genesisHash)
+	if err != nil {
+		return nil, err
+	}
+	txn.AddLease(contract.lease, fee)
+
+	logicSig, err := crypto.MakeLogicSig(contract.program, nil, nil, crypto.MultisigAccount{})
+	if err != nil {
+		return nil, err
+	}
+	_, stx, err := crypto.SignLogicsigTransaction(logicSig, txn)
+	return stx, err
+}
+
+// GetCloseTransaction returns a signed transaction, suitable for passing to
+// SendRawTransaction, which closes the contract account out to the receiver,
+// as the contract allows after its expiry round. Like a withdrawal, its first
+// valid round must be a multiple of the period, after the expiry round.
+// firstValid: the first round on which the txn will be valid; see NextWithdrawalRound
+// fee: fee per byte used for the transaction
+// genesisHash: genesisHash indicating the network for the txn
+func (contract PeriodicPayment) GetCloseTransaction(firstValid, fee uint64, genesisHash []byte) ([]byte, error) {
+	if firstValid%contract.period != 0 {
+		return nil, errorf(ErrInvalidRound, "firstValid round %d is not a multiple of the contract period %d", firstValid, contract.period)
+	}
+	txn, err := contract.makeRefundTxn(contract.receiver, contract.expiryRound, firstValid, firstValid+contract.withdrawWindow, fee, genesisHash)
+	if err != nil {
+		return nil, err
+	}
+	txn.AddLease(contract.lease, fee)
+	return contract.signWithLogicSig(txn, nil)
+}
+
+// MakePeriodicPayment allows some account to execute periodic withdrawal of funds.
+// This is a contract account.
+//
+// This allows receiver to withdraw amount every period rounds for
+// withdrawWindow rounds after every multiple of period. Each withdrawal
+// holds the contract's lease, so only one can be confirmed per window.
+//
+// After expiryRound, all remaining funds in the escrow are available to receiver.
+//
+// Parameters:
+//   - receiver: address which is authorized to receive withdrawals
+//   - amount: the amount to send each period
+//   - withdrawWindow: the duration of a withdrawal period
+//   - period: the time between a pair of withdrawal periods
+//   - expiryRound: the round at which the account expires
+//   - maxFee: maximum fee used by the withdrawal transaction
+func MakePeriodicPayment(receiver string, amount, withdrawWindow, period, expiryRound, maxFee uint64) (PeriodicPayment, error) {
+	return MakePeriodicPaymentWithLease(receiver, nil, amount, withdrawWindow, period, expiryRound, maxFee)
+}
+
+// MakePeriodicPaymentWithLease is as MakePeriodicPayment, but the caller can
+// specify the lease the withdrawals hold, e.g. to recreate a contract; see
+// types.MakeLease. A random lease is used if lease is empty.
+func MakePeriodicPaymentWithLease(receiver string, lease []byte, amount, withdrawWindow, period, expiryRound, maxFee uint64) (PeriodicPayment, error) {
+	contractLease, err := types.MakeLease(lease)
+	if err != nil {
+		return PeriodicPayment{}, err
+	}
+	return makePeriodicPaymentWithLease(receiver, contractLease, amount, withdrawWindow, period, expiryRound, maxFee)
+}
+
+func makePeriodicPaymentWithLease(receiver string, lease [32]byte, amount, withdrawWindow, period, expiryRound, maxFee uint64) (PeriodicPayment, error) {
+	if period == 0 {
+		return PeriodicPayment{}, errorf(ErrInvalidParameters, "period must be positive")
+	}
+	if withdrawWindow > protocol.Current.MaxTxnLife {
+		return PeriodicPayment{}, errorf(transaction.ErrInvalidValidity, "withdrawWindow %d exceeds the maximum transaction life of %d rounds", withdrawWindow, protocol.Current.MaxTxnLife)
+	}
+	const referenceProgram = "ASAHAQoLAAwNDiYCAQYg/ryguxRKWk6ntDikaBrIDmyhBby2B/xWUyXJVpX2ohMxECISMQEjDhAxAiQYJRIQMQQhBDECCBIQMQYoEhAxCTIDEjEHKRIQMQghBRIQMQkpEjEHMgMSEDECIQYNEDEIJRIQERA="
+	referenceAsBytes, err := base64.StdEncoding.DecodeString(referenceProgram)
+	if err != nil {
+		return PeriodicPayment{}, err
+	}
+
+	receiverAddr, err := types.DecodeAddress(receiver)
+	if err != nil {
+		return PeriodicPayment{}, err
+	}
+	var referenceOffsets = []uint64{ /*fee*/ 4 /*period*/, 5 /*withdrawWindow*/, 7 /*amount*/, 8 /*expiryRound*/, 9 /*lease*/, 12 /*receiver*/, 15}
+	injectionVector := []interface{}{maxFee, period, withdrawWindow, amount, expiryRound, base64.StdEncoding.EncodeToString(lease[:]), receiverAddr}
+	injectedBytes, err := inject(referenceAsBytes, referenceOffsets, injectionVector)
+	if err != nil {
+		return PeriodicPayment{}, err
+	}
+
+	address := crypto.AddressFromProgram(injectedBytes)
+	periodicPayment := PeriodicPayment{
+		ContractTemplate: ContractTemplate{
+			address: address.String(),
+			program: injectedBytes,
+		},
+		receiver:       receiverAddr,
+		amount:         amount,
+		withdrawWindow: withdrawWindow,
+		period:         period,
+		expiryRound:    expiryRound,
+		lease:          lease,
+	}
+	return periodicPayment, err
+}
+
+// PeriodicPaymentParameters are the values a PeriodicPayment contract was created with
+type PeriodicPaymentParameters struct {
+	Receiver       string
+	Amount         uint64
+	WithdrawWindow uint64
+	Period         uint64
+	ExpiryRound    uint64
+	MaxFee         uint64
+	Lease          [32]byte
+}
+
+// ReadPeriodicPaymentFromProgram checks that program was generated by MakePeriodicPayment and
+// returns the parameters it enforces
+func ReadPeriodicPaymentFromProgram(program []byte) (PeriodicPaymentParameters, error) {
+	const name = "periodic payment"
+	ints, byteArrays, err := readTemplateConstants(program, name, 7, 2)
+	if err != nil {
+		return PeriodicPaymentParameters{}, err
+	}
+	receiver, err := addressFromConstant(byteArrays[1], name)
+	if err != nil {
+		return PeriodicPaymentParameters{}, err
+	}
+	params := PeriodicPaymentParameters{
+		Receiver:       receiver.String(),
+		MaxFee:         ints[1],
+		Period:         ints[2],
+		WithdrawWindow: ints[4],
+		Amount:         ints[5],
+		ExpiryRound:    ints[6],
+	}
+	if len(byteArrays[0]) != len(params.Lease) {
+		return PeriodicPaymentParameters{}, errorf(ErrProgramMismatch, "program does not match the %s template", name)
+	}
+	copy(params.Lease[:], byteArrays[0])
+	pp, err := makePeriodicPaymentWithLease(params.Receiver, params.Lease, params.Amount, params.WithdrawWindow, params.Period, params.ExpiryRound, params.MaxFee)
+	if err != nil {
+		return PeriodicPaymentParameters{}, err
+	}
+	if err = checkTemplateProgram(program, pp.ContractTemplate, name); err != nil {
+		return PeriodicPaymentParameters{}, err
+	}
+	return params, nil
+}
@@ -1,11 +1,16 @@
 package templates
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
-	"fmt"
+	"math/big"
+
 	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/protocol"
 	"github.com/algorand/go-algorand-sdk/transaction"
 	"github.com/algorand/go-algorand-sdk/types"
+	"github.com/algorand/go-algorand-sdk/wallet"
 )
 
 // Split template representation
@@ -13,58 +18,277 @@ type Split struct {
 	ContractTemplate
 	ratn        uint64
 	ratd        uint64
+	expiryRound uint64
+	minPay      uint64
+	maxFee      uint64
+	owner       types.Address
 	receiverOne types.Address
 	receiverTwo types.Address
+
+	// assetID is the asset the contract splits, or 0 if it splits Algos
+	assetID uint64
+	// optInRound is the last valid round of an asset split's only opt-in
+	optInRound uint64
+}
+
+// AssetID returns the ID of the asset the contract splits, or 0 if it splits
+// Algos
+func (contract Split) AssetID() uint64 {
+	return contract.assetID
+}
+
+// Validate checks that the split's parameters describe a contract that can be used:
+// distinct non-zero addresses, a ratio with non-zero terms, and a maxFee that
+// allows transactions paying at least the minimum fee.
+func (contract Split) Validate() error {
+	var zero types.Address
+	if contract.owner == zero {
+		return errorf(ErrInvalidParameters, "split owner must not be the zero address")
+	}
+	if contract.receiverOne == zero || contract.receiverTwo == zero {
+		return errorf(ErrInvalidParameters, "split receivers must not be the zero address")
+	}
+	if contract.receiverOne == contract.receiverTwo {
+		return errorf(ErrInvalidParameters, "split receivers must be different accounts, both are %s", contract.receiverOne.String())
+	}
+	if contract.ratn == 0 || contract.ratd == 0 {
+		return errorf(ErrInvalidParameters, "split ratio %d/%d must have non-zero terms", contract.ratn, contract.ratd)
+	}
+	if contract.expiryRound == 0 {
+		return errorf(ErrInvalidParameters, "split expiryRound must be non-zero")
+	}
+	if contract.assetID != 0 && (contract.optInRound == 0 || contract.optInRound > contract.expiryRound) {
+		return errorf(ErrInvalidParameters, "split optInRound %d must be non-zero and no later than its expiryRound %d", contract.optInRound, contract.expiryRound)
+	}
+	// the contract requires txn.Fee < maxFee
+	if contract.maxFee <= protocol.Current.MinTxnFee {
+		return errorf(ErrInvalidParameters, "split maxFee %d must be greater than the minimum transaction fee %d", contract.maxFee, protocol.Current.MinTxnFee)
+	}
+	return nil
 }
 
-//GetSendFundsTransaction returns a group transaction array which transfer funds according to the contract's ratio
+// GetSplitAmounts previews how amount would be divided between the two receivers.
+// The contract only approves withdrawals where amountOne*ratn == amountTwo*ratd,
+// so amountOne and amountTwo are the largest such pair whose sum does not exceed
+// amount, and remainder is what is left over (zero when amount splits exactly).
+func (contract Split) GetSplitAmounts(amount uint64) (amountOne, amountTwo, remainder uint64, err error) {
+	share, err := contract.receiverOneShare()
+	if err != nil {
+		return
+	}
+	// a whole number of "units" of (num + denom - num) microAlgos can be split exactly
+	units := new(big.Int).Quo(new(big.Int).SetUint64(amount), share.Denom())
+	one := new(big.Int).Mul(units, share.Num())
+	two := new(big.Int).Mul(units, new(big.Int).Sub(share.Denom(), share.Num()))
+	amountOne = one.Uint64()
+	amountTwo = two.Uint64()
+	remainder = amount - amountOne - amountTwo
+	return
+}
+
+// receiverOneShare returns the exact fraction of a withdrawal owed to receiverOne,
+// ratd / (ratn + ratd), computed without risk of uint64 overflow.
+func (contract Split) receiverOneShare() (*big.Rat, error) {
+	if contract.ratn == 0 || contract.ratd == 0 {
+		return nil, errorf(ErrInvalidParameters, "split ratio %d/%d must have non-zero terms", contract.ratn, contract.ratd)
+	}
+	total := new(big.Int).Add(new(big.Int).SetUint64(contract.ratn), new(big.Int).SetUint64(contract.ratd))
+	return new(big.Rat).SetFrac(new(big.Int).SetUint64(contract.ratd), total), nil
+}
+
+// roundedSplitAmounts divides amount as closely as possible to the contract ratio,
+// giving receiverOne its exact share rounded to the nearest microAlgo.
+func (contract Split) roundedSplitAmounts(amount uint64) (amountOne, amountTwo uint64, err error) {
+	share, err := contract.receiverOneShare()
+	if err != nil {
+		return
+	}
+	exact := new(big.Rat).Mul(share, new(big.Rat).SetInt(new(big.Int).SetUint64(amount)))
+	// round half up: floor(exact + 1/2)
+	exact.Add(exact, big.NewRat(1, 2))
+	one := new(big.Int).Quo(exact.Num(), exact.Denom())
+	amountOne = one.Uint64()
+	amountTwo = amount - amountOne
+	return
+}
+
+// SplitRemainder says what to do with the part of an amount that the split
+// contract's ratio cannot divide exactly
+type SplitRemainder int
+
+const (
+	// RemainderError returns an error if the amount does not split exactly
+	RemainderError SplitRemainder = iota
+
+	// RemainderRound divides the amount as closely as possible to the ratio,
+	// so that one receiver gets slightly more
+	RemainderRound
+
+	// RemainderKeep pays the receivers the largest amounts that split exactly
+	// and leaves the remainder in the contract account, to be paid out with a
+	// later withdrawal or refunded to the owner after the expiry round. The
+	// contract only approves groups of two payments in its exact ratio, so
+	// the remainder cannot be paid to a receiver or the owner in the same
+	// group.
+	RemainderKeep
+)
+
+// GetSendFundsTransaction returns a group transaction array which transfer funds according to the contract's ratio
 // the returned byte array is suitable for passing to SendRawTransaction
 // amount: uint64 number of assets to be transferred total
 // precise: handles rounding error. When False, the amount will be divided as closely as possible but one account will get
 // 			slightly more. When true, returns an error.
 func (contract Split) GetSendFundsTransaction(amount uint64, precise bool, firstRound, lastRound, fee uint64, genesisHash []byte) ([]byte, error) {
-	ratio := contract.ratn / contract.ratd
-	amountForReceiverOne := amount * ratio
-	amountForReceiverTwo := amount * (1 - ratio)
-	remainder := amount - amountForReceiverOne - amountForReceiverTwo
-	if precise && remainder != 0 {
-		return nil, fmt.Errorf("could not precisely divide funds between the two accounts")
+	return contract.GetSendFundsTransactionWithSigner(context.Background(), contract.Signer(), amount, precise, firstRound, lastRound, fee, genesisHash)
+}
+
+// GetSendFundsTransactionWithSigner is as GetSendFundsTransaction, but signs
+// the group with signer rather than with the contract's logic signature, e.g.
+// with a wallet.Wallet holding contract.Signer() for the contract's address,
+// or a signer of an account the contract account was rekeyed to.
+func (contract Split) GetSendFundsTransactionWithSigner(ctx context.Context, signer wallet.TransactionSigner, amount uint64, precise bool, firstRound, lastRound, fee uint64, genesisHash []byte) ([]byte, error) {
+	mode := RemainderRound
+	if precise {
+		mode = RemainderError
 	}
+	stxs, _, err := contract.GetSendFundsTransactionWithRemainder(ctx, signer, amount, mode, firstRound, lastRound, fee, genesisHash)
+	return stxs, err
+}
 
-	from := contract.address
-	tx1, err := transaction.MakePaymentTxn(from, contract.receiverOne.String(), fee, amountForReceiverOne, firstRound, lastRound, nil, "", "", genesisHash)
+// GetSendFundsTransactionWithRemainder is as GetSendFundsTransactionWithSigner,
+// but handles an amount that does not split exactly as mode says, and also
+// returns the microAlgos of amount left in the contract account, which is
+// non-zero only with RemainderKeep. Pass contract.Signer() as signer to sign
+// with the contract's logic signature.
+func (contract Split) GetSendFundsTransactionWithRemainder(ctx context.Context, signer wallet.TransactionSigner, amount uint64, mode SplitRemainder, firstRound, lastRound, fee uint64, genesisHash []byte) ([]byte, uint64, error) {
+	amountForReceiverOne, amountForReceiverTwo, remainder, err := contract.GetSplitAmounts(amount)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	tx2, err := transaction.MakePaymentTxn(from, contract.receiverTwo.String(), fee, amountForReceiverTwo, firstRound, lastRound, nil, "", "", genesisHash)
+	if remainder != 0 {
+		switch mode {
+		case RemainderError:
+			return nil, 0, errorf(ErrImpreciseSplit, "could not precisely divide funds between the two accounts")
+		case RemainderRound:
+			amountForReceiverOne, amountForReceiverTwo, err = contract.roundedSplitAmounts(amount)
+			if err != nil {
+				return nil, 0, err
+			}
+			remainder = 0
+		case RemainderKeep:
+			// the remainder stays in the contract account
+		default:
+			return nil, 0, errorf(ErrInvalidParameters, "unknown split remainder mode %d", mode)
+		}
+	}
+
+	tx1, err := contract.makeWithdrawal(contract.receiverOne, amountForReceiverOne, firstRound, lastRound, fee, genesisHash)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	gid, err := crypto.ComputeGroupID([]types.Transaction{tx1, tx2})
+	tx2, err := contract.makeWithdrawal(contract.receiverTwo, amountForReceiverTwo, firstRound, lastRound, fee, genesisHash)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+	// the contract rejects the group unless each fee is below maxFee
+	for _, tx := range []types.Transaction{tx1, tx2} {
+		if uint64(tx.Fee) >= contract.maxFee {
+			return nil, 0, errorf(ErrFeeTooHigh, "split transaction fee %d must be less than the contract's maxFee %d", tx.Fee, contract.maxFee)
+		}
+	}
+	var group transaction.GroupBuilder
+	if err := group.Add(tx1, tx2); err != nil {
+		return nil, 0, err
+	}
+	txns, err := group.Build()
+	if err != nil {
+		return nil, 0, err
 	}
-	tx1.Group = gid
-	tx2.Group = gid
 
-	logicSig, err := crypto.MakeLogicSig(contract.program, nil, nil, crypto.MultisigAccount{})
+	stxs, err := signer.SignTransactions(ctx, txns, []int{0, 1})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	_, stx1, err := crypto.SignLogicsigTransaction(logicSig, tx1)
+	return bytes.Join(stxs, nil), remainder, nil
+}
+
+// makeWithdrawal makes the transaction paying amount of the split Algos or
+// asset from the contract account to receiver
+func (contract Split) makeWithdrawal(receiver types.Address, amount, firstRound, lastRound, fee uint64, genesisHash []byte) (types.Transaction, error) {
+	if contract.assetID == 0 {
+		return transaction.MakePaymentTxn(contract.address, receiver.String(), fee, amount, firstRound, lastRound, nil, "", "", genesisHash)
+	}
+	return transaction.MakeAssetTransferTxn(contract.address, receiver.String(), "", amount, fee, firstRound, lastRound, nil,
+		"", base64.StdEncoding.EncodeToString(genesisHash), contract.assetID)
+}
+
+// GetOptInTransaction returns the signed transaction opting the contract
+// account of an asset split in to its asset, which it must do before it can
+// receive the asset. The contract pays the opt-in's fee, which must be below
+// its maxFee, and approves only opt-ins whose last valid round is its
+// optInRound and whose lease is its owner's address, so that at most one can
+// ever be confirmed: anyone may submit it, but not repeat it to spend the
+// contract account's Algos on fees. firstRound must be within the maximum
+// transaction life of the optInRound.
+func (contract Split) GetOptInTransaction(firstRound, fee uint64, genesisHash []byte) ([]byte, error) {
+	if contract.assetID == 0 {
+		return nil, errorf(ErrInvalidParameters, "split of Algos does not opt in to an asset")
+	}
+	if firstRound > contract.optInRound {
+		return nil, errorf(ErrInvalidRound, "opt-in first round %d is after the split's opt-in round %d", firstRound, contract.optInRound)
+	}
+	txn, err := transaction.MakeAssetAcceptanceTxn(contract.address, fee, firstRound, contract.optInRound, nil,
+		"", base64.StdEncoding.EncodeToString(genesisHash), contract.assetID)
 	if err != nil {
 		return nil, err
 	}
-	_, stx2, err := crypto.SignLogicsigTransaction(logicSig, tx2)
+	txn.AddLease([32]byte(contract.owner), fee)
+	if uint64(txn.Fee) >= contract.maxFee {
+		return nil, errorf(ErrFeeTooHigh, "split transaction fee %d must be less than the contract's maxFee %d", txn.Fee, contract.maxFee)
+	}
+	stxs, err := contract.Signer().SignTransactions(context.Background(), []types.Transaction{txn}, []int{0})
 	if err != nil {
 		return nil, err
 	}
+	return stxs[0], nil
+}
 
-	var signedGroup []byte
-	signedGroup = append(signedGroup, stx1...)
-	signedGroup = append(signedGroup, stx2...)
+// GetRefundTransaction returns the signed transaction closing the contract
+// account out to the owner, which the contract approves once its expiry round
+// has passed, so firstRound must be after it. The contract account of an asset
+// split must first close out its holding of the asset with
+// GetAssetRefundTransaction.
+func (contract Split) GetRefundTransaction(firstRound, lastRound, fee uint64, genesisHash []byte) ([]byte, error) {
+	txn, err := contract.makeRefundTxn(contract.owner, contract.expiryRound, firstRound, lastRound, fee, genesisHash)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(txn.Fee) >= contract.maxFee {
+		return nil, errorf(ErrFeeTooHigh, "split transaction fee %d must be less than the contract's maxFee %d", txn.Fee, contract.maxFee)
+	}
+	return contract.signWithLogicSig(txn, nil)
+}
 
-	return signedGroup, err
+// GetAssetRefundTransaction returns the signed transaction of an asset split
+// closing the contract account's holding of the asset out to the owner, which
+// the contract approves once its expiry round has passed, so firstRound must be
+// after it
+func (contract Split) GetAssetRefundTransaction(firstRound, lastRound, fee uint64, genesisHash []byte) ([]byte, error) {
+	if contract.assetID == 0 {
+		return nil, errorf(ErrInvalidParameters, "split of Algos has no asset to refund")
+	}
+	if firstRound <= contract.expiryRound {
+		return nil, errorf(ErrInvalidRound, "refund first round %d must be after the contract's expiry round %d", firstRound, contract.expiryRound)
+	}
+	txn, err := transaction.MakeAssetTransferTxn(contract.address, contract.owner.String(), contract.owner.String(), 0, fee, firstRound, lastRound, nil,
+		"", base64.StdEncoding.EncodeToString(genesisHash), contract.assetID)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(txn.Fee) >= contract.maxFee {
+		return nil, errorf(ErrFeeTooHigh, "split transaction fee %d must be less than the contract's maxFee %d", txn.Fee, contract.maxFee)
+	}
+	return contract.signWithLogicSig(txn, nil)
 }
 
 // MakeSplit splits money sent to some account to two recipients at some ratio.
@@ -74,57 +298,167 @@ func (contract Split) GetSendFundsTransaction(amount uint64, precise bool, first
 // split, or single transaction, for closing the account.
 //
 // Withdrawals from this account are allowed as a group transaction which
-// sends receiverOne and receiverTwo amounts with exactly the ratio of
-// ratn/ratd.  At least minPay must be sent to receiverOne.
+// sends receiverOne and receiverTwo amounts such that
+// amountOne * ratn == amountTwo * ratd, i.e. receiverOne is paid
+// ratd/(ratn+ratd) of the withdrawal.  At least minPay must be sent to receiverOne.
 // (CloseRemainderTo must be zero.)
 //
-// After expiryRound passes, all funds can be refunded to owner.
+// After expiryRound passes, all funds can be refunded to owner, with
+// GetRefundTransaction.
 //
 // Parameters:
 //  - owner: the address to refund funds to on timeout
 //  - receiverOne: the first recipient in the split account
 //  - receiverTwo: the second recipient in the split account
-//  - ratn: fraction of money to be paid to the first recipient (numerator)
-//  - ratd: fraction of money to be paid to the first recipient (denominator)
+//  - ratn: the second recipient's part of the split ratio
+//  - ratd: the first recipient's part of the split ratio
 //  - expiryRound: the round at which the account expires
 //  - minPay: minimum amount to be paid out of the account
 //  - maxFee: half of the maximum fee used by each split forwarding group transaction
 func MakeSplit(owner, receiverOne, receiverTwo string, ratn, ratd, expiryRound, minPay, maxFee uint64) (Split, error) {
-	const referenceProgram = "ASAIAQUCAAYHCAkmAyCztwQn0+DycN+vsk+vJWcsoz/b7NDS6i33HOkvTpf+YiC3qUpIgHGWE8/1LPh9SGCalSN7IaITeeWSXbfsS5wsXyC4kBQ38Z8zcwWVAym4S8vpFB/c0XC6R4mnPi9EBADsPDEQIhIxASMMEDIEJBJAABkxCSgSMQcyAxIQMQglEhAxAiEEDRAiQAAuMwAAMwEAEjEJMgMSEDMABykSEDMBByoSEDMACCEFCzMBCCEGCxIQMwAIIQcPEBA="
+	return makeSplit(owner, receiverOne, receiverTwo, 0, ratn, ratd, expiryRound, 0, minPay, maxFee)
+}
+
+// MakeAssetSplit is as MakeSplit, but splits the asset assetID rather than
+// Algos: withdrawals are groups of two asset transfers in the ratio, and minPay
+// is in base units of the asset. The contract account must opt in to the
+// asset, with GetOptInTransaction, before it can receive it. The contract
+// approves a single opt-in, valid until optInRound, which must be no later
+// than expiryRound; if it is not confirmed by then, the contract can never
+// receive the asset and its Algos can only be refunded after expiryRound.
+//
+// After expiryRound passes, the asset can be refunded to owner by an asset
+// transfer closing the contract account's holding out to owner, made by
+// GetAssetRefundTransaction, and then the Algos by GetRefundTransaction.
+func MakeAssetSplit(owner, receiverOne, receiverTwo string, assetID, ratn, ratd, expiryRound, optInRound, minPay, maxFee uint64) (Split, error) {
+	if assetID == 0 {
+		return Split{}, errorf(ErrInvalidParameters, "split asset ID must be non-zero")
+	}
+	return makeSplit(owner, receiverOne, receiverTwo, assetID, ratn, ratd, expiryRound, optInRound, minPay, maxFee)
+}
+
+// The reference programs of splits of Algos and of assets, and the offsets of
+// the constants injected into them
+const (
+	splitReferenceProgram      = "ASAIAQUCAAYHCAkmAyCztwQn0+DycN+vsk+vJWcsoz/b7NDS6i33HOkvTpf+YiC3qUpIgHGWE8/1LPh9SGCalSN7IaITeeWSXbfsS5wsXyC4kBQ38Z8zcwWVAym4S8vpFB/c0XC6R4mnPi9EBADsPDEQIhIxASMMEDIEJBJAABkxCSgSMQcyAxIQMQglEhAxAiEEDRAiQAAuMwAAMwEAEjEJMgMSEDMABykSEDMBByoSEDMACCEFCzMBCCEGCxIQMwAIIQcPEBA="
+	assetSplitReferenceProgram = "ASALAQUCAAYHCAkECgsmAyCztwQn0+DycN+vsk+vJWcsoz/b7NDS6i33HOkvTpf+YiC3qUpIgHGWE8/1LPh9SGCalSN7IaITeeWSXbfsS5wsXyC4kBQ38Z8zcwWVAym4S8vpFB/c0XC6R4mnPi9EBADsPDEBIwwyBCQSQABpMRAiEkAASDEQIQgSEDERIQkSEDETMgMSEDEVMgMSQAAUMRUoEhAxFCgSEDECIQQNECJAAIUxFDEAEhAxEiUSEDEEIQoSEDEGKBIQIkAAazEJKBIxBzIDEhAxCCUSEDECIQQNEBAiQABRMwAQIQgSMwEQIQgSEDMAADMBABIQMwARIQkSEDMBESEJEhAxEzIDEhAxFTIDEhAzABQpEhAzARQqEhAzABIhBQszARIhBgsSEDMAEiEHDxAQ"
+)
+
+func makeSplit(owner, receiverOne, receiverTwo string, assetID, ratn, ratd, expiryRound, optInRound, minPay, maxFee uint64) (Split, error) {
+	referenceProgram := splitReferenceProgram
+	var referenceOffsets = []uint64{ /*fee*/ 4 /*timeout*/, 7 /*ratn*/, 8 /*ratd*/, 9 /*minPay*/, 10 /*owner*/, 14 /*receiver1*/, 47 /*receiver2*/, 80}
+	if assetID != 0 {
+		// the asset split also checks the type of its transfers, their asset
+		// and the round and lease of its opt-in
+		referenceProgram = assetSplitReferenceProgram
+		referenceOffsets = []uint64{ /*fee*/ 4 /*timeout*/, 7 /*ratn*/, 8 /*ratd*/, 9 /*minPay*/, 10 /*assetID*/, 12 /*optInRound*/, 13 /*owner*/, 17 /*receiver1*/, 50 /*receiver2*/, 83}
+	}
 	referenceAsBytes, err := base64.StdEncoding.DecodeString(referenceProgram)
 	if err != nil {
 		return Split{}, err
 	}
 
-	var referenceOffsets = []uint64{ /*fee*/ 4 /*timeout*/, 7 /*ratn*/, 8 /*ratd*/, 9 /*minPay*/, 10 /*owner*/, 14 /*receiver1*/, 47 /*receiver2*/, 80}
 	ownerAddr, err := types.DecodeAddress(owner)
 	if err != nil {
-		return Split{}, err
+		return Split{}, errorf(ErrInvalidParameters, "invalid owner address %q: %v", owner, err)
 	}
 	receiverOneAddr, err := types.DecodeAddress(receiverOne)
 	if err != nil {
-		return Split{}, err
+		return Split{}, errorf(ErrInvalidParameters, "invalid receiverOne address %q: %v", receiverOne, err)
 	}
 	receiverTwoAddr, err := types.DecodeAddress(receiverTwo)
 	if err != nil {
+		return Split{}, errorf(ErrInvalidParameters, "invalid receiverTwo address %q: %v", receiverTwo, err)
+	}
+	split := Split{
+		ratn:        ratn,
+		ratd:        ratd,
+		expiryRound: expiryRound,
+		minPay:      minPay,
+		maxFee:      maxFee,
+		owner:       ownerAddr,
+		receiverOne: receiverOneAddr,
+		receiverTwo: receiverTwoAddr,
+		assetID:     assetID,
+		optInRound:  optInRound,
+	}
+	if err = split.Validate(); err != nil {
 		return Split{}, err
 	}
 	injectionVector := []interface{}{maxFee, expiryRound, ratn, ratd, minPay, ownerAddr, receiverOneAddr, receiverTwoAddr}
+	if assetID != 0 {
+		injectionVector = []interface{}{maxFee, expiryRound, ratn, ratd, minPay, assetID, optInRound, ownerAddr, receiverOneAddr, receiverTwoAddr}
+	}
 	injectedBytes, err := inject(referenceAsBytes, referenceOffsets, injectionVector)
 	if err != nil {
 		return Split{}, err
 	}
 
 	address := crypto.AddressFromProgram(injectedBytes)
-	split := Split{
-		ContractTemplate: ContractTemplate{
-			address: address.String(),
-			program: injectedBytes,
-		},
-		ratn:        ratn,
-		ratd:        ratd,
-		receiverOne: receiverOneAddr,
-		receiverTwo: receiverTwoAddr,
+	split.ContractTemplate = ContractTemplate{
+		address: address.String(),
+		program: injectedBytes,
 	}
 	return split, err
 }
+
+// SplitParameters are the values a Split contract was created with
+type SplitParameters struct {
+	Owner       string
+	ReceiverOne string
+	ReceiverTwo string
+	Ratn        uint64
+	Ratd        uint64
+	ExpiryRound uint64
+	MinPay      uint64
+	MaxFee      uint64
+
+	// AssetID is the asset split by a contract made by MakeAssetSplit, or 0
+	AssetID uint64
+	// OptInRound is the last valid round of the opt-in of a contract made by
+	// MakeAssetSplit, or 0
+	OptInRound uint64
+}
+
+// ReadSplitFromProgram checks that program was generated by MakeSplit or
+// MakeAssetSplit and returns the parameters it enforces
+func ReadSplitFromProgram(program []byte) (SplitParameters, error) {
+	const name = "split"
+	ints, byteArrays, err := readTemplateConstants(program, name, 8, 3)
+	if err != nil {
+		// the asset split has three more integer constants
+		ints, byteArrays, err = readTemplateConstants(program, name, 11, 3)
+	}
+	if err != nil {
+		return SplitParameters{}, err
+	}
+	var addresses [3]types.Address
+	for i := range addresses {
+		addresses[i], err = addressFromConstant(byteArrays[i], name)
+		if err != nil {
+			return SplitParameters{}, err
+		}
+	}
+	params := SplitParameters{
+		Owner:       addresses[0].String(),
+		ReceiverOne: addresses[1].String(),
+		ReceiverTwo: addresses[2].String(),
+		MaxFee:      ints[1],
+		ExpiryRound: ints[4],
+		Ratn:        ints[5],
+		Ratd:        ints[6],
+		MinPay:      ints[7],
+	}
+	if len(ints) == 11 {
+		params.AssetID = ints[9]
+		params.OptInRound = ints[10]
+	}
+	split, err := makeSplit(params.Owner, params.ReceiverOne, params.ReceiverTwo, params.AssetID, params.Ratn, params.Ratd, params.ExpiryRound, params.OptInRound, params.MinPay, params.MaxFee)
+	if err != nil {
+		return SplitParameters{}, err
+	}
+	if err = checkTemplateProgram(program, split.ContractTemplate, name); err != nil {
+		return SplitParameters{}, err
+	}
+	return params, nil
+}
@@ -1,10 +1,15 @@
 package templates
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/binary"
-	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/logic"
+	"github.com/algorand/go-algorand-sdk/transaction"
 	"github.com/algorand/go-algorand-sdk/types"
+	"github.com/algorand/go-algorand-sdk/wallet"
 )
 
 // ContractTemplate template representation
@@ -23,6 +28,73 @@ func (contract ContractTemplate) GetProgram() []byte {
 	return contract.program
 }
 
+// Signer returns a signer of the transactions of the contract account, which
+// signs them with the contract's logic signature
+func (contract ContractTemplate) Signer() wallet.TransactionSigner {
+	return wallet.LogicSigAccountTransactionSigner{
+		LogicSigAccount: crypto.LogicSigAccount{Lsig: types.LogicSig{Logic: contract.program}},
+	}
+}
+
+// EstimateProgram returns the contract program's length and estimated cost when
+// run with args. Check the result against the limits of the network's consensus
+// version to know whether the injected program can approve transactions there.
+func (contract ContractTemplate) EstimateProgram(args [][]byte) (logic.ProgramStats, error) {
+	return logic.EstimateProgram(contract.program, args)
+}
+
+// makeRefundTxn makes the payment closing the contract account out to closeTo,
+// which the templates with an expiry approve once expiryRound has passed
+func (contract ContractTemplate) makeRefundTxn(closeTo types.Address, expiryRound, firstRound, lastRound, fee uint64, genesisHash []byte) (types.Transaction, error) {
+	if firstRound <= expiryRound {
+		return types.Transaction{}, errorf(ErrInvalidRound, "refund first round %d must be after the contract's expiry round %d", firstRound, expiryRound)
+	}
+	return transaction.MakePaymentTxn(contract.address, types.ZeroAddress.String(), fee, 0, firstRound, lastRound, nil, closeTo.String(), "", genesisHash)
+}
+
+// signWithLogicSig signs txn with the contract's logic signature, passing args
+// to the program
+func (contract ContractTemplate) signWithLogicSig(txn types.Transaction, args [][]byte) ([]byte, error) {
+	lsig, err := crypto.MakeLogicSig(contract.program, args, nil, crypto.MultisigAccount{})
+	if err != nil {
+		return nil, err
+	}
+	_, stx, err := crypto.SignLogicsigTransaction(lsig, txn)
+	return stx, err
+}
+
+// readTemplateConstants returns the constants injected into a program built from the named
+// template, checking that the program has the template's number of constants
+func readTemplateConstants(program []byte, name string, numInts, numByteArrays int) (ints []uint64, byteArrays [][]byte, err error) {
+	ints, byteArrays, err = logic.ReadProgram(program, nil)
+	if err != nil {
+		return
+	}
+	if len(ints) != numInts || len(byteArrays) != numByteArrays {
+		err = errorf(ErrProgramMismatch, "program does not match the %s template", name)
+	}
+	return
+}
+
+// addressFromConstant converts a 32 byte constant back into an address
+func addressFromConstant(constant []byte, name string) (address types.Address, err error) {
+	if len(constant) != len(address) {
+		err = errorf(ErrProgramMismatch, "program does not match the %s template", name)
+		return
+	}
+	copy(address[:], constant)
+	return
+}
+
+// checkTemplateProgram makes sure program is exactly what the named template
+// generates from the parameters read out of it
+func checkTemplateProgram(program []byte, contract ContractTemplate, name string) error {
+	if !bytes.Equal(program, contract.program) {
+		return errorf(ErrProgramMismatch, "program does not match the %s template", name)
+	}
+	return nil
+}
+
 func replace(buf, newBytes []byte, offset, placeholderLength uint64) []byte {
 	firstChunk := make([]byte, len(buf[:offset]))
 	copy(firstChunk, buf[:offset])
@@ -35,20 +107,28 @@ func replace(buf, newBytes []byte, offset, placeholderLength uint64) []byte {
 func inject(original []byte, offsets []uint64, values []interface{}) (result []byte, err error) {
 	result = original
 	if len(offsets) != len(values) {
-		err = fmt.Errorf("length of offsets %v does not match length of replacement values %v", len(offsets), len(values))
+		err = errorf(ErrInvalidProgram, "length of offsets %v does not match length of replacement values %v", len(offsets), len(values))
 		return
 	}
 
 	for i, value := range values {
-		decodedLength := 0
 		if valueAsUint, ok := value.(uint64); ok {
 			// make the exact minimum buffer needed and no larger
 			// because otherwise there will be extra bytes inserted
 			sizingBuffer := make([]byte, binary.MaxVarintLen64)
-			decodedLength = binary.PutUvarint(sizingBuffer, valueAsUint)
+			decodedLength := binary.PutUvarint(sizingBuffer, valueAsUint)
 			fillingBuffer := make([]byte, decodedLength)
 			decodedLength = binary.PutUvarint(fillingBuffer, valueAsUint)
-			result = replace(result, fillingBuffer, offsets[i], uint64(1))
+			// the placeholder is itself a uvarint; replace all of it
+			_, placeholderLength := binary.Uvarint(result[offsets[i]:])
+			if placeholderLength <= 0 {
+				err = errorf(ErrInvalidProgram, "could not decode integer placeholder at offset %d", offsets[i])
+				return
+			}
+			result = replace(result, fillingBuffer, offsets[i], uint64(placeholderLength))
+			for j := range offsets {
+				offsets[j] = offsets[j] + uint64(decodedLength) - uint64(placeholderLength)
+			}
 		} else if address, ok := value.(types.Address); ok {
 			addressLen := uint64(32)
 			addressBytes := make([]byte, addressLen)
@@ -60,6 +140,13 @@ func inject(original []byte, offsets []uint64, values []interface{}) (result []b
 				err = decodeErr
 				return
 			}
+			// the placeholder is itself a length-prefixed byte string; replace all of it
+			placeholderLen, prefixLen := binary.Uvarint(result[offsets[i]:])
+			if prefixLen <= 0 {
+				err = errorf(ErrInvalidProgram, "could not decode byte string placeholder length at offset %d", offsets[i])
+				return
+			}
+			placeholderLength := uint64(prefixLen) + placeholderLen
 			// do the same thing as in the uint64 case to trim empty bytes:
 			// first fill one buffer to figure out the number of bytes to be written,
 			// then fill a second buffer of exactly the right size
@@ -69,14 +156,14 @@ func inject(original []byte, offsets []uint64, values []interface{}) (result []b
 			binary.PutUvarint(fillingBuffer, uint64(len(decodeBytes))) // indicate length of b64 bytes
 			// want to write [length of b64 bytes, b64 bytes]
 			decodeBytes = append(fillingBuffer, decodeBytes...)
-			result = replace(result, decodeBytes, offsets[i], uint64(33))
-		}
-
-		if decodedLength != 0 {
+			result = replace(result, decodeBytes, offsets[i], placeholderLength)
+			// shift the remaining offsets by however much the program grew
 			for j := range offsets {
-				offsets[j] = offsets[j] + uint64(decodedLength) - 1
+				offsets[j] = offsets[j] + uint64(len(decodeBytes)) - placeholderLength
 			}
 		}
 	}
+	// reject a malformed program before anyone derives an address from it
+	err = logic.CheckProgram(result, nil)
 	return
 }
@@ -1,9 +1,20 @@
 package templates
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
-	"github.com/stretchr/testify/require"
+	"math"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/logic"
+	"github.com/algorand/go-algorand-sdk/types"
+	"github.com/algorand/go-algorand-sdk/wallet"
 )
 
 func TestSplit(t *testing.T) {
@@ -56,3 +67,607 @@ func TestLimitOrder(t *testing.T) {
 	goldenAddress := "LXQWT2XLIVNFS54VTLR63UY5K6AMIEWI7YTVE6LB4RWZDBZKH22ZO3S36I"
 	require.Equal(t, goldenAddress, c.GetAddress())
 }
+
+func TestSplitAmounts(t *testing.T) {
+	owner := "WO3QIJ6T4DZHBX5PWJH26JLHFSRT7W7M2DJOULPXDTUS6TUX7ZRIO4KDFY"
+	receivers := [2]string{"W6UUUSEAOGLBHT7VFT4H2SDATKKSG6ZBUIJXTZMSLW36YS44FRP5NVAU7U", "XCIBIN7RT4ZXGBMVAMU3QS6L5EKB7XGROC5EPCNHHYXUIBAA5Q6C5Y7NEU"}
+	ratn, ratd := uint64(30), uint64(100) // the contract enforces amountOne*30 == amountTwo*100
+	c, err := MakeSplit(owner, receivers[0], receivers[1], ratn, ratd, 123456, 10000, 5000000)
+	require.NoError(t, err)
+
+	one, two, remainder, err := c.GetSplitAmounts(1300)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), one)
+	require.Equal(t, uint64(300), two)
+	require.Equal(t, uint64(0), remainder)
+	require.Equal(t, one*ratn, two*ratd)
+
+	one, two, remainder, err = c.GetSplitAmounts(1305)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), one)
+	require.Equal(t, uint64(300), two)
+	require.Equal(t, uint64(5), remainder)
+
+	one, two, err = c.roundedSplitAmounts(1305)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1004), one)
+	require.Equal(t, uint64(301), two)
+
+	// ratio terms large enough to overflow uint64 when summed
+	c, err = MakeSplit(owner, receivers[0], receivers[1], math.MaxUint64, math.MaxUint64, 123456, 10000, 5000000)
+	require.NoError(t, err)
+	one, two, remainder, err = c.GetSplitAmounts(1001)
+	require.NoError(t, err)
+	require.Equal(t, uint64(500), one)
+	require.Equal(t, uint64(500), two)
+	require.Equal(t, uint64(1), remainder)
+
+	_, err = c.GetSendFundsTransaction(1001, true, 1, 100, 0, []byte("abcdefghijklmnopqrstuvwxyz012345"))
+	require.Equal(t, ErrImpreciseSplit, errorKind(err))
+
+	_, err = c.GetSendFundsTransaction(1000, true, 1, 100, 0, []byte("abcdefghijklmnopqrstuvwxyz012345"))
+	require.NoError(t, err)
+
+	// fees the contract would reject
+	c, err = MakeSplit(owner, receivers[0], receivers[1], ratn, ratd, 123456, 10000, 2000)
+	require.NoError(t, err)
+	_, err = c.GetSendFundsTransaction(1300, true, 1, 100, 0, []byte("abcdefghijklmnopqrstuvwxyz012345"))
+	require.NoError(t, err)
+	_, err = c.GetSendFundsTransaction(1300, true, 1, 100, 10, []byte("abcdefghijklmnopqrstuvwxyz012345"))
+	require.Equal(t, ErrFeeTooHigh, errorKind(err))
+}
+
+// errorKind returns the kind an error unwraps to, or nil
+func errorKind(err error) error {
+	if kinded, ok := err.(interface{ Unwrap() error }); ok {
+		return kinded.Unwrap()
+	}
+	return nil
+}
+
+func TestSplitRemainder(t *testing.T) {
+	owner := "WO3QIJ6T4DZHBX5PWJH26JLHFSRT7W7M2DJOULPXDTUS6TUX7ZRIO4KDFY"
+	receivers := [2]string{"W6UUUSEAOGLBHT7VFT4H2SDATKKSG6ZBUIJXTZMSLW36YS44FRP5NVAU7U", "XCIBIN7RT4ZXGBMVAMU3QS6L5EKB7XGROC5EPCNHHYXUIBAA5Q6C5Y7NEU"}
+	c, err := MakeSplit(owner, receivers[0], receivers[1], 30, 100, 123456, 10000, 5000000)
+	require.NoError(t, err)
+	genesisHash := []byte("abcdefghijklmnopqrstuvwxyz012345")
+	send := func(amount uint64, mode SplitRemainder) ([]types.SignedTxn, uint64, error) {
+		stxBytes, remainder, err := c.GetSendFundsTransactionWithRemainder(context.Background(), c.Signer(), amount, mode, 1, 100, 0, genesisHash)
+		if err != nil {
+			return nil, 0, err
+		}
+		var stxns []types.SignedTxn
+		dec := msgpack.NewDecoder(bytes.NewReader(stxBytes))
+		for i := 0; i < 2; i++ {
+			var stx types.SignedTxn
+			require.NoError(t, dec.Decode(&stx))
+			stxns = append(stxns, stx)
+		}
+		return stxns, remainder, nil
+	}
+
+	stxns, remainder, err := send(1305, RemainderKeep)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), remainder)
+	require.Equal(t, types.MicroAlgos(1000), stxns[0].Txn.Amount)
+	require.Equal(t, types.MicroAlgos(300), stxns[1].Txn.Amount)
+
+	stxns, remainder, err = send(1305, RemainderRound)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), remainder)
+	require.Equal(t, types.MicroAlgos(1004), stxns[0].Txn.Amount)
+	require.Equal(t, types.MicroAlgos(301), stxns[1].Txn.Amount)
+
+	_, _, err = send(1305, RemainderError)
+	require.Error(t, err)
+	_, _, err = send(1305, SplitRemainder(7))
+	require.Error(t, err)
+
+	// an exact split has no remainder in any mode
+	for _, mode := range []SplitRemainder{RemainderError, RemainderRound, RemainderKeep} {
+		_, remainder, err = send(1300, mode)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), remainder)
+	}
+}
+
+func TestAssetSplit(t *testing.T) {
+	owner := "WO3QIJ6T4DZHBX5PWJH26JLHFSRT7W7M2DJOULPXDTUS6TUX7ZRIO4KDFY"
+	receivers := [2]string{"W6UUUSEAOGLBHT7VFT4H2SDATKKSG6ZBUIJXTZMSLW36YS44FRP5NVAU7U", "XCIBIN7RT4ZXGBMVAMU3QS6L5EKB7XGROC5EPCNHHYXUIBAA5Q6C5Y7NEU"}
+	genesisHash := []byte("abcdefghijklmnopqrstuvwxyz012345")
+	c, err := MakeAssetSplit(owner, receivers[0], receivers[1], 31566704, 30, 100, 123456, 500, 10000, 5000000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(31566704), c.AssetID())
+	algos, err := MakeSplit(owner, receivers[0], receivers[1], 30, 100, 123456, 10000, 5000000)
+	require.NoError(t, err)
+	require.NotEqual(t, algos.GetAddress(), c.GetAddress())
+	_, err = MakeAssetSplit(owner, receivers[0], receivers[1], 0, 30, 100, 123456, 500, 10000, 5000000)
+	require.Error(t, err)
+	for _, optInRound := range []uint64{0, 123457} {
+		_, err = MakeAssetSplit(owner, receivers[0], receivers[1], 31566704, 30, 100, 123456, optInRound, 10000, 5000000)
+		require.Equal(t, ErrInvalidParameters, errorKind(err), optInRound)
+	}
+
+	params, err := ReadSplitFromProgram(c.GetProgram())
+	require.NoError(t, err)
+	require.Equal(t, SplitParameters{Owner: owner, ReceiverOne: receivers[0], ReceiverTwo: receivers[1],
+		Ratn: 30, Ratd: 100, ExpiryRound: 123456, MinPay: 10000, MaxFee: 5000000, AssetID: 31566704, OptInRound: 500}, params)
+	params, err = ReadSplitFromProgram(algos.GetProgram())
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), params.AssetID)
+
+	stxBytes, remainder, err := c.GetSendFundsTransactionWithRemainder(context.Background(), c.Signer(), 1305, RemainderKeep, 1, 100, 0, genesisHash)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), remainder)
+	dec := msgpack.NewDecoder(bytes.NewReader(stxBytes))
+	for i, amount := range []uint64{1000, 300} {
+		var stx types.SignedTxn
+		require.NoError(t, dec.Decode(&stx))
+		require.Equal(t, types.AssetTransferTx, stx.Txn.Type)
+		require.Equal(t, types.AssetIndex(31566704), stx.Txn.XferAsset)
+		require.Equal(t, amount, stx.Txn.AssetAmount)
+		require.Equal(t, receivers[i], stx.Txn.AssetReceiver.String())
+		require.Equal(t, c.GetAddress(), stx.Txn.Sender.String())
+		require.Equal(t, c.GetProgram(), stx.Lsig.Logic)
+	}
+
+	// the only opt-in the contract approves is valid until its opt-in round
+	// and holds the owner's address as its lease, so it cannot be repeated
+	stxBytes, err = c.GetOptInTransaction(1, 0, genesisHash)
+	require.NoError(t, err)
+	var optIn types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxBytes, &optIn))
+	require.Equal(t, optIn.Txn.Sender, optIn.Txn.AssetReceiver)
+	require.Equal(t, uint64(0), optIn.Txn.AssetAmount)
+	require.Equal(t, types.AssetIndex(31566704), optIn.Txn.XferAsset)
+	require.Equal(t, types.Round(1), optIn.Txn.FirstValid)
+	require.Equal(t, types.Round(500), optIn.Txn.LastValid)
+	require.Equal(t, owner, types.Address(optIn.Txn.Lease).String())
+	_, err = c.GetOptInTransaction(501, 0, genesisHash)
+	require.Equal(t, ErrInvalidRound, errorKind(err))
+	_, err = algos.GetOptInTransaction(1, 0, genesisHash)
+	require.Error(t, err)
+}
+
+func TestSplitWithSigner(t *testing.T) {
+	owner := "WO3QIJ6T4DZHBX5PWJH26JLHFSRT7W7M2DJOULPXDTUS6TUX7ZRIO4KDFY"
+	receivers := [2]string{"W6UUUSEAOGLBHT7VFT4H2SDATKKSG6ZBUIJXTZMSLW36YS44FRP5NVAU7U", "XCIBIN7RT4ZXGBMVAMU3QS6L5EKB7XGROC5EPCNHHYXUIBAA5Q6C5Y7NEU"}
+	c, err := MakeSplit(owner, receivers[0], receivers[1], 30, 100, 123456, 10000, 5000000)
+	require.NoError(t, err)
+	genesisHash := []byte("abcdefghijklmnopqrstuvwxyz012345")
+	contractAddr, err := types.DecodeAddress(c.GetAddress())
+	require.NoError(t, err)
+
+	w := wallet.MakeWallet()
+	w.Add(contractAddr, c.Signer())
+	withWallet, err := c.GetSendFundsTransactionWithSigner(context.Background(), w, 1300, true, 1, 100, 0, genesisHash)
+	require.NoError(t, err)
+	signed, err := c.GetSendFundsTransaction(1300, true, 1, 100, 0, genesisHash)
+	require.NoError(t, err)
+	require.Equal(t, signed, withWallet)
+
+	// the contract account rekeyed to an account
+	account := crypto.GenerateAccount()
+	signer := wallet.BasicAccountTransactionSigner{Account: account}
+	rekeyed, err := c.GetSendFundsTransactionWithSigner(context.Background(), signer, 1300, true, 1, 100, 0, genesisHash)
+	require.NoError(t, err)
+	dec := msgpack.NewDecoder(bytes.NewReader(rekeyed))
+	for i := 0; i < 2; i++ {
+		var stx types.SignedTxn
+		require.NoError(t, dec.Decode(&stx))
+		require.Equal(t, contractAddr, stx.Txn.Sender)
+		require.Equal(t, account.Address, stx.AuthAddr)
+		require.True(t, crypto.VerifySignedTransaction(stx))
+	}
+
+	_, err = c.GetSendFundsTransactionWithSigner(context.Background(), wallet.MakeWallet(), 1300, true, 1, 100, 0, genesisHash)
+	require.Error(t, err)
+}
+
+func TestSplitValidation(t *testing.T) {
+	owner := "WO3QIJ6T4DZHBX5PWJH26JLHFSRT7W7M2DJOULPXDTUS6TUX7ZRIO4KDFY"
+	receivers := [2]string{"W6UUUSEAOGLBHT7VFT4H2SDATKKSG6ZBUIJXTZMSLW36YS44FRP5NVAU7U", "XCIBIN7RT4ZXGBMVAMU3QS6L5EKB7XGROC5EPCNHHYXUIBAA5Q6C5Y7NEU"}
+	c, err := MakeSplit(owner, receivers[0], receivers[1], 30, 100, 123456, 10000, 5000000)
+	require.NoError(t, err)
+	require.NoError(t, c.Validate())
+
+	// bad checksum
+	_, err = MakeSplit(owner, receivers[0], "XCIBIN7RT4ZXGBMVAMU3QS6L5EKB7XGROC5EPCNHHYXUIBAA5Q6C5Y7NAU", 30, 100, 123456, 10000, 5000000)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "receiverTwo")
+	_, err = MakeSplit(owner, receivers[0], receivers[0], 30, 100, 123456, 10000, 5000000)
+	require.Error(t, err)
+	_, err = MakeSplit(owner, receivers[0], receivers[1], 0, 100, 123456, 10000, 5000000)
+	require.Error(t, err)
+	_, err = MakeSplit(owner, receivers[0], receivers[1], 30, 0, 123456, 10000, 5000000)
+	require.Error(t, err)
+	_, err = MakeSplit(owner, receivers[0], receivers[1], 30, 100, 0, 10000, 5000000)
+	require.Error(t, err)
+	_, err = MakeSplit(owner, receivers[0], receivers[1], 30, 100, 123456, 10000, 1000)
+	require.Error(t, err)
+	require.Error(t, Split{}.Validate())
+}
+
+func TestInjectChecksProgram(t *testing.T) {
+	// intcblock 0; intc_0
+	program := []byte{0x01, 0x20, 0x01, 0x00, 0x22}
+	injected, err := inject(program, []uint64{3}, []interface{}{uint64(300)})
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x01, 0x20, 0x01, 0xac, 0x02, 0x22}, injected)
+
+	// a trailing bnz without its branch offset is rejected
+	_, err = inject(append(program[:4:4], 0x40), []uint64{3}, []interface{}{uint64(300)})
+	require.Error(t, err)
+}
+
+func TestEstimateProgram(t *testing.T) {
+	owner := "726KBOYUJJNE5J5UHCSGQGWIBZWKCBN4WYD7YVSTEXEVNFPWUIJ7TAEOPM"
+	receiver := "W6UUUSEAOGLBHT7VFT4H2SDATKKSG6ZBUIJXTZMSLW36YS44FRP5NVAU7U"
+	htlc, err := MakeHTLC(owner, receiver, "sha256", "EHZhE08h/HwCIj1Qq56zYAvD/8NxJCOh5Hux+anb9V8=", 600000, 1000)
+	require.NoError(t, err)
+	preimage := []byte("hello world")
+	stats, err := htlc.EstimateProgram([][]byte{preimage})
+	require.NoError(t, err)
+	require.Equal(t, len(htlc.GetProgram())+len(preimage), stats.Length)
+	require.True(t, stats.Cost > 0)
+	require.True(t, stats.Fits(logic.DefaultLimits))
+}
+
+func TestHTLCClaim(t *testing.T) {
+	owner := "726KBOYUJJNE5J5UHCSGQGWIBZWKCBN4WYD7YVSTEXEVNFPWUIJ7TAEOPM"
+	receiver := "42NJMHTPFVPXVSDGA6JGKUV6TARV5UZTMPFIREMLXHETRKIVW34QFSDFRE"
+	preImage := base64.StdEncoding.EncodeToString([]byte("secret"))
+	hashImage := sha256.Sum256([]byte("secret"))
+	c, err := MakeHTLC(owner, receiver, "sha256", base64.StdEncoding.EncodeToString(hashImage[:]), 600000, 1000)
+	require.NoError(t, err)
+
+	genesisHash, err := base64.StdEncoding.DecodeString("f4OxZX/x/FO5LcGBSKHWXfwtSx+j1ncoSt3SABJtkGk=")
+	require.NoError(t, err)
+	stxBytes, err := c.GetClaimTransaction(preImage, 1, 100, 0, genesisHash)
+	require.NoError(t, err)
+
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, c.GetAddress(), stx.Txn.Sender.String())
+	require.Equal(t, receiver, stx.Txn.CloseRemainderTo.String())
+	require.Equal(t, types.Address{}, stx.Txn.Receiver)
+	require.Equal(t, types.MicroAlgos(0), stx.Txn.Amount)
+	require.Equal(t, c.GetProgram(), stx.Lsig.Logic)
+	require.Equal(t, [][]byte{[]byte("secret")}, stx.Lsig.Args)
+
+	_, err = c.GetClaimTransaction("not base64!", 1, 100, 0, genesisHash)
+	require.Error(t, err)
+}
+
+func TestRefundTransactions(t *testing.T) {
+	owner := "726KBOYUJJNE5J5UHCSGQGWIBZWKCBN4WYD7YVSTEXEVNFPWUIJ7TAEOPM"
+	receivers := [2]string{"W6UUUSEAOGLBHT7VFT4H2SDATKKSG6ZBUIJXTZMSLW36YS44FRP5NVAU7U", "XCIBIN7RT4ZXGBMVAMU3QS6L5EKB7XGROC5EPCNHHYXUIBAA5Q6C5Y7NEU"}
+	genesisHash, err := base64.StdEncoding.DecodeString("f4OxZX/x/FO5LcGBSKHWXfwtSx+j1ncoSt3SABJtkGk=")
+	require.NoError(t, err)
+	checkRefund := func(stxBytes []byte, contract ContractTemplate, closeTo string) types.SignedTxn {
+		var stx types.SignedTxn
+		require.NoError(t, msgpack.Decode(stxBytes, &stx))
+		require.Equal(t, contract.GetAddress(), stx.Txn.Sender.String())
+		require.Equal(t, closeTo, stx.Txn.CloseRemainderTo.String())
+		require.True(t, stx.Txn.Receiver.IsZero())
+		require.Equal(t, types.MicroAlgos(0), stx.Txn.Amount)
+		require.Equal(t, types.Round(1001), stx.Txn.FirstValid)
+		require.Equal(t, contract.GetProgram(), stx.Lsig.Logic)
+		return stx
+	}
+
+	split, err := MakeSplit(owner, receivers[0], receivers[1], 30, 100, 1000, 10000, 2000)
+	require.NoError(t, err)
+	stxBytes, err := split.GetRefundTransaction(1001, 1100, 0, genesisHash)
+	require.NoError(t, err)
+	checkRefund(stxBytes, split.ContractTemplate, owner)
+	_, err = split.GetRefundTransaction(1000, 1100, 0, genesisHash)
+	require.Equal(t, ErrInvalidRound, errorKind(err))
+	_, err = split.GetRefundTransaction(1001, 1100, 10, genesisHash)
+	require.Equal(t, ErrFeeTooHigh, errorKind(err))
+	_, err = split.GetAssetRefundTransaction(1001, 1100, 0, genesisHash)
+	require.Equal(t, ErrInvalidParameters, errorKind(err))
+
+	assetSplit, err := MakeAssetSplit(owner, receivers[0], receivers[1], 31566704, 30, 100, 1000, 500, 10000, 2000)
+	require.NoError(t, err)
+	stxBytes, err = assetSplit.GetAssetRefundTransaction(1001, 1100, 0, genesisHash)
+	require.NoError(t, err)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, types.AssetTransferTx, stx.Txn.Type)
+	require.Equal(t, types.AssetIndex(31566704), stx.Txn.XferAsset)
+	require.Equal(t, owner, stx.Txn.AssetCloseTo.String())
+	require.Equal(t, owner, stx.Txn.AssetReceiver.String())
+	_, err = assetSplit.GetAssetRefundTransaction(1000, 1100, 0, genesisHash)
+	require.Error(t, err)
+
+	htlc, err := MakeHTLC(owner, receivers[0], "sha256", "f4OxZX/x/FO5LcGBSKHWXfwtSx+j1ncoSt3SABJtkGk=", 1000, 1000)
+	require.NoError(t, err)
+	stxBytes, err = htlc.GetRefundTransaction(1001, 1100, 0, genesisHash)
+	require.NoError(t, err)
+	stx = checkRefund(stxBytes, htlc.ContractTemplate, owner)
+	require.Len(t, stx.Lsig.Args, 1)
+	_, err = htlc.GetRefundTransaction(1001, 1100, 10, genesisHash)
+	require.Error(t, err)
+
+	lo, err := MakeLimitOrder(owner, 12345, 30, 100, 1000, 10000, 1000)
+	require.NoError(t, err)
+	stxBytes, err = lo.GetRefundTransaction(1001, 1100, 0, genesisHash)
+	require.NoError(t, err)
+	checkRefund(stxBytes, lo.ContractTemplate, owner)
+	_, err = lo.GetRefundTransaction(999, 1100, 0, genesisHash)
+	require.Equal(t, ErrInvalidRound, errorKind(err))
+
+	lease := [32]byte{1, 2, 3}
+	pp, err := MakePeriodicPaymentWithLease(receivers[0], lease[:], 500000, 95, 100, 1000, 1000)
+	require.NoError(t, err)
+	_, err = pp.GetCloseTransaction(1001, 0, genesisHash)
+	require.Error(t, err)
+	_, err = pp.GetCloseTransaction(1000, 0, genesisHash)
+	require.Error(t, err)
+	stxBytes, err = pp.GetCloseTransaction(1100, 0, genesisHash)
+	require.NoError(t, err)
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, receivers[0], stx.Txn.CloseRemainderTo.String())
+	require.True(t, stx.Txn.Receiver.IsZero())
+	require.Equal(t, types.Round(1195), stx.Txn.LastValid)
+	require.Equal(t, lease, stx.Txn.Lease)
+}
+
+func TestPeriodicPayment(t *testing.T) {
+	// Inputs
+	receiver := "SKXZDBHECM6AS73GVPGJHMIRDMJKEAN5TUGMUPSKJCQ44E6M6TC2H2UJ3I"
+	lease := [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+	amount := uint64(500000)
+	withdrawWindow := uint64(95)
+	period := uint64(100)
+	maxFee := uint64(1000)
+	expiryRound := uint64(2445756)
+	c, err := MakePeriodicPaymentWithLease(receiver, lease[:], amount, withdrawWindow, period, expiryRound, maxFee)
+	// Outputs
+	require.NoError(t, err)
+	goldenProgram := "ASAHAegHZABfoMIevKOVASYCIAECAwQFBgcIAQIDBAUGBwgBAgMEBQYHCAECAwQFBgcIIJKvkYTkEzwJf2arzJOxERsSogG9nQzKPkpIoc4TzPTFMRAiEjEBIw4QMQIkGCUSEDEEIQQxAggSEDEGKBIQMQkyAxIxBykSEDEIIQUSEDEJKRIxBzIDEhAxAiEGDRAxCCUSEBEQ"
+	require.Equal(t, goldenProgram, base64.StdEncoding.EncodeToString(c.GetProgram()))
+	require.Equal(t, crypto.AddressFromProgram(c.GetProgram()).String(), c.GetAddress())
+	_, err = MakePeriodicPaymentWithLease(receiver, lease[:8], amount, withdrawWindow, period, expiryRound, maxFee)
+	require.Error(t, err)
+	_, err = MakePeriodicPaymentWithLease(receiver, lease[:], amount, 1001, period, expiryRound, maxFee)
+	require.Error(t, err)
+
+	require.Equal(t, uint64(1200), c.NextWithdrawalRound(1200))
+	require.Equal(t, uint64(1300), c.NextWithdrawalRound(1201))
+
+	genesisHash, err := base64.StdEncoding.DecodeString("f4OxZX/x/FO5LcGBSKHWXfwtSx+j1ncoSt3SABJtkGk=")
+	require.NoError(t, err)
+	_, err = c.GetWithdrawalTransaction(1201, 0, genesisHash)
+	require.Error(t, err)
+	stxBytes, err := c.GetWithdrawalTransaction(1200, 0, genesisHash)
+	require.NoError(t, err)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxBytes, &stx))
+	require.Equal(t, types.Round(1200), stx.Txn.FirstValid)
+	require.Equal(t, types.Round(1295), stx.Txn.LastValid)
+	require.Equal(t, lease, stx.Txn.Lease)
+	require.Equal(t, receiver, stx.Txn.Receiver.String())
+	require.Equal(t, types.MicroAlgos(amount), stx.Txn.Amount)
+}
+
+func TestLimitOrderSwap(t *testing.T) {
+	owner := "726KBOYUJJNE5J5UHCSGQGWIBZWKCBN4WYD7YVSTEXEVNFPWUIJ7TAEOPM"
+	assetid := uint64(12345)
+	ratn, ratd := uint64(30), uint64(100)
+	c, err := MakeLimitOrder(owner, assetid, ratn, ratd, 123456, 10000, 5000000)
+	require.NoError(t, err)
+	buyer := crypto.GenerateAccount()
+	genesisHash, err := base64.StdEncoding.DecodeString("f4OxZX/x/FO5LcGBSKHWXfwtSx+j1ncoSt3SABJtkGk=")
+	require.NoError(t, err)
+
+	// 3000 assets * 100 >= 10001 microAlgos * 30
+	blob, err := c.GetSwapAssetsTransaction(3001, c.GetProgram(), buyer.PrivateKey, 0, 10001, 1, 100, genesisHash)
+	require.NoError(t, err)
+	dec := msgpack.NewDecoder(bytes.NewReader(blob))
+	var algosForAssets, assetsForAlgos types.SignedTxn
+	require.NoError(t, dec.Decode(&algosForAssets))
+	require.NoError(t, dec.Decode(&assetsForAlgos))
+	require.Equal(t, c.GetAddress(), algosForAssets.Txn.Sender.String())
+	require.Equal(t, buyer.Address, algosForAssets.Txn.Receiver)
+	require.Equal(t, types.Address{}, algosForAssets.Txn.CloseRemainderTo)
+	require.Equal(t, buyer.Address, assetsForAlgos.Txn.Sender)
+	require.Equal(t, owner, assetsForAlgos.Txn.AssetReceiver.String())
+	require.Equal(t, types.AssetIndex(assetid), assetsForAlgos.Txn.XferAsset)
+	require.Equal(t, algosForAssets.Txn.Group, assetsForAlgos.Txn.Group)
+
+	// worse than the contract's rate
+	_, err = c.GetSwapAssetsTransaction(3000, c.GetProgram(), buyer.PrivateKey, 0, 10001, 1, 100, genesisHash)
+	require.Error(t, err)
+	// below the minimum trade
+	_, err = c.GetSwapAssetsTransaction(3001, c.GetProgram(), buyer.PrivateKey, 0, 10000, 1, 100, genesisHash)
+	require.Error(t, err)
+}
+
+func TestDynamicFee(t *testing.T) {
+	// Inputs
+	receiver := "726KBOYUJJNE5J5UHCSGQGWIBZWKCBN4WYD7YVSTEXEVNFPWUIJ7TAEOPM"
+	closeRemainder := "42NJMHTPFVPXVSDGA6JGKUV6TARV5UZTMPFIREMLXHETRKIVW34QFSDFRE"
+	lease := [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+	amount := uint64(5000)
+	firstValid := uint64(12345)
+	lastValid := uint64(12346)
+	c, err := makeDynamicFeeWithLease(receiver, closeRemainder, lease, amount, firstValid, lastValid)
+	// Outputs
+	require.NoError(t, err)
+	_, err = makeDynamicFeeWithLease(receiver, closeRemainder, lease, amount, lastValid, firstValid)
+	require.Error(t, err)
+	_, err = makeDynamicFeeWithLease(receiver, closeRemainder, lease, amount, firstValid, firstValid+1001)
+	require.Error(t, err)
+	goldenProgram := "ASAFAgGIJ7lgumAmAyD+vKC7FEpaTqe0OKRoGsgObKEFvLYH/FZTJclWlfaiEyDmmpYeby1feshmB5JlUr6YI17TM2PKiJGLuck4qRW2+SABAgMEBQYHCAECAwQFBgcIAQIDBAUGBwgBAgMEBQYHCDIEIhIzABAjEhAzAAcxABIQMwAIMQESEDEWIxIQMRAjEhAxBygSEDEJKRIQMQgkEhAxAiUSEDEEIQQSEDEGKhIQ"
+	require.Equal(t, goldenProgram, base64.StdEncoding.EncodeToString(c.GetProgram()))
+
+	genesisHash, err := base64.StdEncoding.DecodeString("f4OxZX/x/FO5LcGBSKHWXfwtSx+j1ncoSt3SABJtkGk=")
+	require.NoError(t, err)
+	sender := crypto.GenerateAccount()
+	txn, lsig, err := SignDynamicFee(c.GetProgram(), sender.PrivateKey, genesisHash)
+	require.NoError(t, err)
+	require.Equal(t, sender.Address, txn.Sender)
+	require.Equal(t, receiver, txn.Receiver.String())
+	require.Equal(t, closeRemainder, txn.CloseRemainderTo.String())
+	require.Equal(t, types.MicroAlgos(amount), txn.Amount)
+	require.Equal(t, lease, txn.Lease)
+	require.True(t, crypto.VerifyLogicSig(lsig, sender.Address))
+
+	feePayer := crypto.GenerateAccount()
+	blob, err := GetDynamicFeeTransactions(txn, lsig, feePayer.PrivateKey, 0)
+	require.NoError(t, err)
+	dec := msgpack.NewDecoder(bytes.NewReader(blob))
+	var feeStx, mainStx types.SignedTxn
+	require.NoError(t, dec.Decode(&feeStx))
+	require.NoError(t, dec.Decode(&mainStx))
+	require.Equal(t, feePayer.Address, feeStx.Txn.Sender)
+	require.Equal(t, sender.Address, feeStx.Txn.Receiver)
+	require.Equal(t, mainStx.Txn.Fee, feeStx.Txn.Amount)
+	require.Equal(t, feeStx.Txn.Group, mainStx.Txn.Group)
+	require.Equal(t, lease, feeStx.Txn.Lease)
+}
+
+func TestCustomTemplate(t *testing.T) {
+	receiver, err := types.DecodeAddress("726KBOYUJJNE5J5UHCSGQGWIBZWKCBN4WYD7YVSTEXEVNFPWUIJ7TAEOPM")
+	require.NoError(t, err)
+	closeRemainder, err := types.DecodeAddress("42NJMHTPFVPXVSDGA6JGKUV6TARV5UZTMPFIREMLXHETRKIVW34QFSDFRE")
+	require.NoError(t, err)
+	lease := [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+	values := map[string]interface{}{
+		"TMPL_AMT":   uint64(5000),
+		"TMPL_FV":    uint64(12345),
+		"TMPL_LV":    uint64(12346),
+		"TMPL_RCV":   receiver,
+		"TMPL_CLS":   closeRemainder,
+		"TMPL_LEASE": lease[:],
+	}
+
+	// patching precompiled bytecode reproduces the dynamic fee template
+	reference, err := base64.StdEncoding.DecodeString("ASAFAgEFBgcmAyD+vKC7FEpaTqe0OKRoGsgObKEFvLYH/FZTJclWlfaiEyDmmpYeby1feshmB5JlUr6YI17TM2PKiJGLuck4qRW2+QEGMgQiEjMAECMSEDMABzEAEhAzAAgxARIQMRYjEhAxECMSEDEHKBIQMQkpEhAxCCQSEDECJRIQMQQhBBIQMQYqEhA=")
+	require.NoError(t, err)
+	offsets := map[string]uint64{"TMPL_AMT": 5, "TMPL_FV": 6, "TMPL_LV": 7, "TMPL_RCV": 11, "TMPL_CLS": 44, "TMPL_LEASE": 76}
+	ct, err := MakeCustomTemplateFromProgram(reference, offsets)
+	require.NoError(t, err)
+	require.Equal(t, []string{"TMPL_AMT", "TMPL_CLS", "TMPL_FV", "TMPL_LEASE", "TMPL_LV", "TMPL_RCV"}, ct.Placeholders())
+	injected, err := ct.Inject(values)
+	require.NoError(t, err)
+	expected, err := makeDynamicFeeWithLease(receiver.String(), closeRemainder.String(), lease, 5000, 12345, 12346)
+	require.NoError(t, err)
+	require.Equal(t, expected.GetProgram(), injected.GetProgram())
+	require.Equal(t, expected.GetAddress(), injected.GetAddress())
+
+	// offsets must point at constants
+	_, err = MakeCustomTemplateFromProgram(reference, map[string]uint64{"TMPL_AMT": 4, "TMPL_RCV": 44})
+	require.NoError(t, err)
+	_, err = MakeCustomTemplateFromProgram(reference, map[string]uint64{"TMPL_RCV": 12})
+	require.Error(t, err)
+	_, err = MakeCustomTemplateFromProgram(reference, map[string]uint64{"TMPL_AMT": 100})
+	require.Error(t, err)
+
+	// placeholder names must be whole TMPL_ names, and offsets may not repeat
+	for _, name := range []string{"x TMPL_AMT", "TMPL_AMT x", "TMPL_amt", "AMT"} {
+		_, err = MakeCustomTemplateFromProgram(reference, map[string]uint64{name: 5})
+		require.Error(t, err, name)
+		require.Equal(t, ErrInvalidTemplateValue, errorKind(err), name)
+	}
+	_, err = MakeCustomTemplateFromProgram(reference, map[string]uint64{"TMPL_AMT": 5, "TMPL_FEE": 5})
+	require.EqualError(t, err, "offset 5 is used by both TMPL_AMT and TMPL_FEE")
+	require.Equal(t, ErrInvalidTemplateValue, errorKind(err))
+
+	// missing and unknown values are rejected
+	delete(values, "TMPL_LEASE")
+	_, err = ct.Inject(values)
+	require.Error(t, err)
+	values["TMPL_LEASE"] = lease[:]
+	values["TMPL_OTHER"] = uint64(1)
+	_, err = ct.Inject(values)
+	require.Error(t, err)
+	delete(values, "TMPL_OTHER")
+
+	// values must have the type of the constant at their offset
+	mismatched := map[string]interface{}{
+		"TMPL_AMT":   receiver,       // an address at an integer offset
+		"TMPL_FV":    lease[:],       // a byte string at an integer offset
+		"TMPL_RCV":   uint64(1),      // an integer at an address offset
+		"TMPL_CLS":   lease[:],       // a byte string at an address offset
+		"TMPL_LEASE": closeRemainder, // an address at a byte string length prefix
+	}
+	for name, value := range mismatched {
+		wrong := make(map[string]interface{}, len(values))
+		for n, v := range values {
+			wrong[n] = v
+		}
+		wrong[name] = value
+		_, err = ct.Inject(wrong)
+		require.Error(t, err, name)
+	}
+	values["TMPL_LEASE"] = uint64(1) // an integer at a byte string length prefix
+	_, err = ct.Inject(values)
+	require.EqualError(t, err, "value for TMPL_LEASE is a uint64, but its offset 76 is a byte string constant")
+	values["TMPL_LEASE"] = lease[:]
+
+	// source substitution
+	source := "txn Amount\nint TMPL_AMT\n==\ntxn Receiver\naddr TMPL_RCV\n==\n&&\ntxn Lease\nbyte base64 TMPL_LEASE\n==\n&&"
+	st := MakeCustomTemplateFromSource(source)
+	require.Equal(t, []string{"TMPL_AMT", "TMPL_LEASE", "TMPL_RCV"}, st.Placeholders())
+	substituted, err := st.Substitute(map[string]interface{}{"TMPL_AMT": uint64(5000), "TMPL_RCV": receiver, "TMPL_LEASE": lease[:]})
+	require.NoError(t, err)
+	require.Equal(t, "txn Amount\nint 5000\n==\ntxn Receiver\naddr "+receiver.String()+"\n==\n&&\ntxn Lease\nbyte base64 "+base64.StdEncoding.EncodeToString(lease[:])+"\n==\n&&", substituted)
+
+	compiled, err := st.Compile(map[string]interface{}{"TMPL_AMT": uint64(5000), "TMPL_RCV": receiver, "TMPL_LEASE": lease[:]}, func(source string) ([]byte, error) {
+		require.Equal(t, substituted, source)
+		return reference, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, crypto.AddressFromProgram(reference).String(), compiled.GetAddress())
+}
+
+func TestReadTemplatesFromProgram(t *testing.T) {
+	owner := "726KBOYUJJNE5J5UHCSGQGWIBZWKCBN4WYD7YVSTEXEVNFPWUIJ7TAEOPM"
+	receiver := "42NJMHTPFVPXVSDGA6JGKUV6TARV5UZTMPFIREMLXHETRKIVW34QFSDFRE"
+	other := "W6UUUSEAOGLBHT7VFT4H2SDATKKSG6ZBUIJXTZMSLW36YS44FRP5NVAU7U"
+	lease := [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+
+	split, err := MakeSplit(owner, receiver, other, 30, 100, 123456, 10000, 5000000)
+	require.NoError(t, err)
+	splitParams, err := ReadSplitFromProgram(split.GetProgram())
+	require.NoError(t, err)
+	require.Equal(t, SplitParameters{Owner: owner, ReceiverOne: receiver, ReceiverTwo: other, Ratn: 30, Ratd: 100, ExpiryRound: 123456, MinPay: 10000, MaxFee: 5000000}, splitParams)
+
+	hashImage := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	htlc, err := MakeHTLC(owner, receiver, "keccak256", hashImage, 600000, 1000)
+	require.NoError(t, err)
+	htlcParams, err := ReadHTLCFromProgram(htlc.GetProgram())
+	require.NoError(t, err)
+	require.Equal(t, HTLCParameters{Owner: owner, Receiver: receiver, HashFunction: "keccak256", HashImage: hashImage, ExpiryRound: 600000, MaxFee: 1000}, htlcParams)
+
+	lo, err := MakeLimitOrder(owner, 12345, 30, 100, 123456, 10000, 5000000)
+	require.NoError(t, err)
+	loParams, err := ReadLimitOrderFromProgram(lo.GetProgram())
+	require.NoError(t, err)
+	require.Equal(t, LimitOrderParameters{Owner: owner, AssetID: 12345, Ratn: 30, Ratd: 100, ExpiryRound: 123456, MinTrade: 10000, MaxFee: 5000000}, loParams)
+
+	pp, err := makePeriodicPaymentWithLease(receiver, lease, 500000, 95, 100, 2445756, 1000)
+	require.NoError(t, err)
+	ppParams, err := ReadPeriodicPaymentFromProgram(pp.GetProgram())
+	require.NoError(t, err)
+	require.Equal(t, PeriodicPaymentParameters{Receiver: receiver, Amount: 500000, WithdrawWindow: 95, Period: 100, ExpiryRound: 2445756, MaxFee: 1000, Lease: lease}, ppParams)
+
+	df, err := makeDynamicFeeWithLease(receiver, "", lease, 5000, 12345, 12346)
+	require.NoError(t, err)
+	dfParams, err := ReadDynamicFeeFromProgram(df.GetProgram())
+	require.NoError(t, err)
+	require.Equal(t, DynamicFeeParameters{Receiver: receiver, Amount: 5000, FirstValid: 12345, LastValid: 12346, Lease: lease}, dfParams)
+
+	// a program from another template, or a tampered one, is rejected
+	_, err = ReadSplitFromProgram(lo.GetProgram())
+	require.Error(t, err)
+	_, err = ReadLimitOrderFromProgram(split.GetProgram())
+	require.Error(t, err)
+	tampered := append([]byte{}, split.GetProgram()...)
+	tampered[len(tampered)-1] = 0x11 // && -> ||
+	_, err = ReadSplitFromProgram(tampered)
+	require.Error(t, err)
+}
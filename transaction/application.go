@@ -0,0 +1,200 @@
+package transaction
+
+import (
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// Application call transactions take their fee, validity window and genesis
+// from a types.SuggestedParams, as returned by the algod v2 SuggestedParams
+// endpoint. See SetFee for how the fee is set.
+//
+// For all of them:
+// - appArgs are arguments passed to the application's programs
+// - accounts are checksummed, human-readable addresses of accounts whose state the programs may access
+// - foreignApps are IDs of applications whose global state the programs may read
+// - foreignAssets are IDs of assets whose parameters the programs may read
+// - sp are the suggested parameters of the transaction
+// - sender is a checksummed, human-readable address that will send the transaction
+// - note is an arbitrary byte array
+// - opts set optional fields, such as the lease; see TxnOption
+
+// MakeApplicationCreateTxn makes a transaction creating an application
+// - optIn also opts the sender in to the application
+// - approvalProg and clearProg are the compiled approval and clear state programs
+// - globalSchema and localSchema bound the global state and each account's local state
+// - extraPages are the program pages allocated beyond the first
+func MakeApplicationCreateTxn(optIn bool, approvalProg, clearProg []byte, globalSchema, localSchema types.StateSchema, extraPages uint32,
+	appArgs [][]byte, accounts []string, foreignApps, foreignAssets []uint64, sp types.SuggestedParams, sender string, note []byte, opts ...TxnOption) (types.Transaction, error) {
+	if len(approvalProg) == 0 || len(clearProg) == 0 {
+		return types.Transaction{}, errorf(ErrInvalidApplicationCall, "approval and clear programs are required to create an application")
+	}
+	onComplete := types.NoOpOC
+	if optIn {
+		onComplete = types.OptInOC
+	}
+	return applicationCallBuilder(0, onComplete, appArgs, accounts, foreignApps, foreignAssets, nil,
+		approvalProg, clearProg, globalSchema, localSchema, extraPages, sp, sender, note, opts...)
+}
+
+// MakeApplicationUpdateTxn makes a transaction replacing the programs of the application appID
+// - approvalProg and clearProg are the new compiled approval and clear state programs
+func MakeApplicationUpdateTxn(appID uint64, appArgs [][]byte, accounts []string, foreignApps, foreignAssets []uint64,
+	approvalProg, clearProg []byte, sp types.SuggestedParams, sender string, note []byte, opts ...TxnOption) (types.Transaction, error) {
+	if appID == 0 {
+		return types.Transaction{}, errorf(ErrInvalidApplicationCall, "application ID is required to update an application")
+	}
+	if len(approvalProg) == 0 || len(clearProg) == 0 {
+		return types.Transaction{}, errorf(ErrInvalidApplicationCall, "approval and clear programs are required to update an application")
+	}
+	return applicationCallBuilder(appID, types.UpdateApplicationOC, appArgs, accounts, foreignApps, foreignAssets, nil,
+		approvalProg, clearProg, types.StateSchema{}, types.StateSchema{}, 0, sp, sender, note, opts...)
+}
+
+// MakeApplicationCallTxn makes a transaction calling the application appID
+// - onComplete is the action taken after the approval program runs, e.g. types.NoOpOC
+func MakeApplicationCallTxn(appID uint64, appArgs [][]byte, accounts []string, foreignApps, foreignAssets []uint64,
+	onComplete types.OnCompletion, sp types.SuggestedParams, sender string, note []byte, opts ...TxnOption) (types.Transaction, error) {
+	return MakeApplicationCallTxnWithBoxes(appID, appArgs, accounts, foreignApps, foreignAssets, nil, onComplete, sp, sender, note, opts...)
+}
+
+// MakeApplicationCallTxnWithBoxes makes a transaction calling the application
+// appID, like MakeApplicationCallTxn, that may also access boxes
+// - boxes are the boxes the programs may access; the application of each box
+// must be appID or in foreignApps
+func MakeApplicationCallTxnWithBoxes(appID uint64, appArgs [][]byte, accounts []string, foreignApps, foreignAssets []uint64,
+	boxes []types.AppBoxReference, onComplete types.OnCompletion, sp types.SuggestedParams, sender string, note []byte, opts ...TxnOption) (types.Transaction, error) {
+	if appID == 0 {
+		return types.Transaction{}, errorf(ErrInvalidApplicationCall, "application ID is required to call an existing application")
+	}
+	if onComplete == types.UpdateApplicationOC {
+		return types.Transaction{}, errorf(ErrInvalidApplicationCall, "use MakeApplicationUpdateTxn to update an application")
+	}
+	return applicationCallBuilder(appID, onComplete, appArgs, accounts, foreignApps, foreignAssets, boxes,
+		nil, nil, types.StateSchema{}, types.StateSchema{}, 0, sp, sender, note, opts...)
+}
+
+// MakeApplicationOptInTxn makes a transaction opting the sender in to the application appID
+func MakeApplicationOptInTxn(appID uint64, appArgs [][]byte, accounts []string, foreignApps, foreignAssets []uint64,
+	sp types.SuggestedParams, sender string, note []byte, opts ...TxnOption) (types.Transaction, error) {
+	return MakeApplicationCallTxn(appID, appArgs, accounts, foreignApps, foreignAssets, types.OptInOC, sp, sender, note, opts...)
+}
+
+// MakeApplicationCloseOutTxn makes a transaction closing the sender out of the
+// application appID, if the approval program allows it
+func MakeApplicationCloseOutTxn(appID uint64, appArgs [][]byte, accounts []string, foreignApps, foreignAssets []uint64,
+	sp types.SuggestedParams, sender string, note []byte, opts ...TxnOption) (types.Transaction, error) {
+	return MakeApplicationCallTxn(appID, appArgs, accounts, foreignApps, foreignAssets, types.CloseOutOC, sp, sender, note, opts...)
+}
+
+// MakeApplicationClearStateTxn makes a transaction clearing the sender's local
+// state of the application appID, which succeeds whatever the clear state program does
+func MakeApplicationClearStateTxn(appID uint64, appArgs [][]byte, accounts []string, foreignApps, foreignAssets []uint64,
+	sp types.SuggestedParams, sender string, note []byte, opts ...TxnOption) (types.Transaction, error) {
+	return MakeApplicationCallTxn(appID, appArgs, accounts, foreignApps, foreignAssets, types.ClearStateOC, sp, sender, note, opts...)
+}
+
+// MakeApplicationDeleteTxn makes a transaction deleting the application appID
+func MakeApplicationDeleteTxn(appID uint64, appArgs [][]byte, accounts []string, foreignApps, foreignAssets []uint64,
+	sp types.SuggestedParams, sender string, note []byte, opts ...TxnOption) (types.Transaction, error) {
+	return MakeApplicationCallTxn(appID, appArgs, accounts, foreignApps, foreignAssets, types.DeleteApplicationOC, sp, sender, note, opts...)
+}
+
+// applicationCallBuilder is a helper that builds application call transactions
+func applicationCallBuilder(appID uint64, onComplete types.OnCompletion, appArgs [][]byte, accounts []string, foreignApps, foreignAssets []uint64,
+	boxes []types.AppBoxReference, approvalProg, clearProg []byte, globalSchema, localSchema types.StateSchema, extraPages uint32,
+	sp types.SuggestedParams, sender string, note []byte, opts ...TxnOption) (types.Transaction, error) {
+	senderAddr, err := types.DecodeAddress(sender)
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	var accountAddrs []types.Address
+	for _, account := range accounts {
+		addr, err := types.DecodeAddress(account)
+		if err != nil {
+			return types.Transaction{}, err
+		}
+		accountAddrs = append(accountAddrs, addr)
+	}
+
+	var apps []types.AppIndex
+	for _, app := range foreignApps {
+		apps = append(apps, types.AppIndex(app))
+	}
+
+	var assets []types.AssetIndex
+	for _, asset := range foreignAssets {
+		assets = append(assets, types.AssetIndex(asset))
+	}
+
+	boxRefs, err := ResolveBoxReferences(boxes, apps, types.AppIndex(appID))
+	if err != nil {
+		return types.Transaction{}, err
+	}
+
+	if len(sp.GenesisHash) != len(types.Digest{}) {
+		return types.Transaction{}, errorf(ErrMissingGenesisHash, "application call transaction must contain a 32 byte genesisHash")
+	}
+	var gh types.Digest
+	copy(gh[:], sp.GenesisHash)
+
+	tx := types.Transaction{
+		Type: types.ApplicationCallTx,
+		Header: types.Header{
+			Sender:      senderAddr,
+			FirstValid:  sp.FirstRoundValid,
+			LastValid:   sp.LastRoundValid,
+			Note:        note,
+			GenesisID:   sp.GenesisID,
+			GenesisHash: gh,
+		},
+		ApplicationFields: types.ApplicationFields{
+			ApplicationID:     types.AppIndex(appID),
+			OnCompletion:      onComplete,
+			ApplicationArgs:   appArgs,
+			Accounts:          accountAddrs,
+			ForeignApps:       apps,
+			ForeignAssets:     assets,
+			BoxReferences:     boxRefs,
+			ApprovalProgram:   approvalProg,
+			ClearStateProgram: clearProg,
+			LocalStateSchema:  localSchema,
+			GlobalStateSchema: globalSchema,
+			ExtraProgramPages: extraPages,
+		},
+	}
+
+	if err := ApplyOptions(&tx, opts...); err != nil {
+		return types.Transaction{}, err
+	}
+	if err := SetFee(&tx, sp); err != nil {
+		return types.Transaction{}, err
+	}
+	return tx, nil
+}
+
+// ResolveBoxReferences turns boxes named by application ID into the box
+// references of an application call to appID with the given foreign apps. A
+// box of appID, or of application 0, refers to the called application; any
+// other application must be in foreignApps.
+func ResolveBoxReferences(boxes []types.AppBoxReference, foreignApps []types.AppIndex, appID types.AppIndex) ([]types.BoxReference, error) {
+	var refs []types.BoxReference
+	for _, box := range boxes {
+		index := -1
+		if box.AppID == 0 || types.AppIndex(box.AppID) == appID {
+			index = 0
+		} else {
+			for i, app := range foreignApps {
+				if app == types.AppIndex(box.AppID) {
+					index = i + 1
+					break
+				}
+			}
+		}
+		if index < 0 {
+			return nil, errorf(ErrInvalidApplicationCall, "box %q belongs to application %d, which is not a foreign app", box.Name, box.AppID)
+		}
+		refs = append(refs, types.BoxReference{ForeignAppIdx: uint64(index), Name: box.Name})
+	}
+	return refs, nil
+}
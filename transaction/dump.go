@@ -0,0 +1,231 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// onCompletionNames are the names goal gives each OnCompletion
+var onCompletionNames = map[types.OnCompletion]string{
+	types.NoOpOC:              "noop",
+	types.OptInOC:             "optin",
+	types.CloseOutOC:          "closeout",
+	types.ClearStateOC:        "clearstate",
+	types.UpdateApplicationOC: "update",
+	types.DeleteApplicationOC: "delete",
+}
+
+// dumper writes the labeled lines of a dump, with the values aligned
+type dumper struct {
+	buf bytes.Buffer
+}
+
+func (d *dumper) line(label string, format string, args ...interface{}) {
+	fmt.Fprintf(&d.buf, "%-18s %s\n", label+":", fmt.Sprintf(format, args...))
+}
+
+func (d *dumper) address(label string, addr types.Address) {
+	if !addr.IsZero() {
+		d.line(label, "%s", addr)
+	}
+}
+
+// Dump describes tx in human-readable lines, one per field that is set, for
+// logging and display. Amounts of Algos are given in Algos, and application
+// arguments are shown as text, integers or base64, as their bytes suggest.
+func Dump(tx types.Transaction) string {
+	var d dumper
+	dumpTxn(&d, tx)
+	return d.buf.String()
+}
+
+// DumpSigned describes stx as Dump does, followed by how it is signed
+func DumpSigned(stx types.SignedTxn) string {
+	var d dumper
+	dumpTxn(&d, stx.Txn)
+	switch {
+	case stx.Sig != (types.Signature{}):
+		d.line("signature", "single")
+	case !stx.Msig.Blank():
+		d.line("signature", "multisig, %d of %d, %d signed", stx.Msig.Threshold, len(stx.Msig.Subsigs), countSubsigs(stx.Msig))
+	case len(stx.Lsig.Logic) > 0:
+		kind := "escrow"
+		if stx.Lsig.Sig != (types.Signature{}) || !stx.Lsig.Msig.Blank() {
+			kind = "delegated"
+		}
+		d.line("signature", "logicsig, %s, %d program bytes, %d args", kind, len(stx.Lsig.Logic), len(stx.Lsig.Args))
+	default:
+		d.line("signature", "none")
+	}
+	d.address("auth addr", stx.AuthAddr)
+	return d.buf.String()
+}
+
+func countSubsigs(msig types.MultisigSig) (n int) {
+	for _, sub := range msig.Subsigs {
+		if sub.Sig != (types.Signature{}) {
+			n++
+		}
+	}
+	return
+}
+
+func dumpTxn(d *dumper, tx types.Transaction) {
+	d.line("type", "%s", tx.Type)
+	d.line("txid", "%s", crypto.GetTxID(tx))
+	d.address("sender", tx.Sender)
+	d.line("fee", "%s Algos", tx.Fee.FormatAlgos())
+	d.line("valid", "rounds %d to %d", tx.FirstValid, tx.LastValid)
+	if tx.GenesisID != "" {
+		d.line("genesis id", "%s", tx.GenesisID)
+	}
+	if tx.GenesisHash != (types.Digest{}) {
+		d.line("genesis hash", "%s", base64.StdEncoding.EncodeToString(tx.GenesisHash[:]))
+	}
+	if tx.Group != (types.Digest{}) {
+		d.line("group", "%s", base64.StdEncoding.EncodeToString(tx.Group[:]))
+	}
+	if tx.Lease != ([32]byte{}) {
+		d.line("lease", "%s", base64.StdEncoding.EncodeToString(tx.Lease[:]))
+	}
+	d.address("rekey to", tx.RekeyTo)
+	if len(tx.Note) > 0 {
+		d.line("note", "%s", describeBytes(tx.Note))
+	}
+
+	switch tx.Type {
+	case types.PaymentTx:
+		d.address("receiver", tx.Receiver)
+		d.line("amount", "%s Algos", tx.Amount.FormatAlgos())
+		d.address("close to", tx.CloseRemainderTo)
+	case types.KeyRegistrationTx:
+		switch {
+		case tx.Nonparticipation:
+			d.line("keyreg", "nonparticipating")
+		case tx.VotePK == (types.VotePK{}):
+			d.line("keyreg", "offline")
+		default:
+			d.line("keyreg", "online")
+			d.line("vote valid", "rounds %d to %d", tx.VoteFirst, tx.VoteLast)
+			d.line("key dilution", "%d", tx.VoteKeyDilution)
+		}
+	case types.AssetConfigTx:
+		dumpAssetConfig(d, tx.AssetConfigTxnFields)
+	case types.AssetTransferTx:
+		d.line("asset", "%d", tx.XferAsset)
+		d.address("asset sender", tx.AssetSender)
+		d.address("receiver", tx.AssetReceiver)
+		d.line("amount", "%d", tx.AssetAmount)
+		d.address("close to", tx.AssetCloseTo)
+	case types.AssetFreezeTx:
+		d.line("asset", "%d", tx.FreezeAsset)
+		d.address("freeze account", tx.FreezeAccount)
+		d.line("frozen", "%t", tx.AssetFrozen)
+	case types.ApplicationCallTx:
+		dumpAppCall(d, tx.ApplicationFields)
+	}
+}
+
+func dumpAssetConfig(d *dumper, fields types.AssetConfigTxnFields) {
+	params := fields.AssetParams
+	switch {
+	case fields.ConfigAsset == 0:
+		d.line("asset", "create")
+	case params == (types.AssetParams{}):
+		d.line("asset", "%d, destroy", fields.ConfigAsset)
+		return
+	default:
+		d.line("asset", "%d, reconfigure", fields.ConfigAsset)
+	}
+	if fields.ConfigAsset == 0 {
+		d.line("total", "%d", params.Total)
+		d.line("decimals", "%d", params.Decimals)
+		d.line("default frozen", "%t", params.DefaultFrozen)
+		if params.UnitName != "" {
+			d.line("unit name", "%q", params.UnitName)
+		}
+		if params.AssetName != "" {
+			d.line("asset name", "%q", params.AssetName)
+		}
+		if params.URL != "" {
+			d.line("url", "%s", params.URL)
+		}
+		if params.MetadataHash != ([types.AssetMetadataHashLen]byte{}) {
+			d.line("metadata hash", "%s", base64.StdEncoding.EncodeToString(params.MetadataHash[:]))
+		}
+	}
+	d.address("manager", params.Manager)
+	d.address("reserve", params.Reserve)
+	d.address("freeze", params.Freeze)
+	d.address("clawback", params.Clawback)
+}
+
+func dumpAppCall(d *dumper, fields types.ApplicationFields) {
+	if fields.ApplicationID == 0 {
+		d.line("application", "create")
+	} else {
+		d.line("application", "%d", fields.ApplicationID)
+	}
+	oc, ok := onCompletionNames[fields.OnCompletion]
+	if !ok {
+		oc = fmt.Sprintf("unknown (%d)", fields.OnCompletion)
+	}
+	d.line("on completion", "%s", oc)
+	for i, arg := range fields.ApplicationArgs {
+		d.line(fmt.Sprintf("arg %d", i), "%s", describeBytes(arg))
+	}
+	for i, addr := range fields.Accounts {
+		d.line(fmt.Sprintf("account %d", i), "%s", addr)
+	}
+	for i, app := range fields.ForeignApps {
+		d.line(fmt.Sprintf("foreign app %d", i), "%d", app)
+	}
+	for i, asset := range fields.ForeignAssets {
+		d.line(fmt.Sprintf("foreign asset %d", i), "%d", asset)
+	}
+	for i, box := range fields.BoxReferences {
+		d.line(fmt.Sprintf("box %d", i), "app index %d, name %s", box.ForeignAppIdx, describeBytes(box.Name))
+	}
+	if fields.ApplicationID == 0 || fields.OnCompletion == types.UpdateApplicationOC {
+		d.line("approval program", "%d bytes", len(fields.ApprovalProgram))
+		d.line("clear program", "%d bytes", len(fields.ClearStateProgram))
+	}
+	if fields.ApplicationID == 0 {
+		d.line("global schema", "%d uints, %d byte slices", fields.GlobalStateSchema.NumUint, fields.GlobalStateSchema.NumByteSlice)
+		d.line("local schema", "%d uints, %d byte slices", fields.LocalStateSchema.NumUint, fields.LocalStateSchema.NumByteSlice)
+		if fields.ExtraProgramPages > 0 {
+			d.line("extra pages", "%d", fields.ExtraProgramPages)
+		}
+	}
+}
+
+// describeBytes shows b as quoted text if it is printable UTF-8, otherwise as
+// an integer and base64 if it is 8 bytes, as TEAL's itob encodes integers, and
+// otherwise as base64
+func describeBytes(b []byte) string {
+	if isPrintable(b) {
+		return fmt.Sprintf("%q", b)
+	}
+	encoded := base64.StdEncoding.EncodeToString(b)
+	if len(b) == 8 {
+		return fmt.Sprintf("%d (b64 %s)", binary.BigEndian.Uint64(b), encoded)
+	}
+	return "b64 " + encoded
+}
+
+func isPrintable(b []byte) bool {
+	if len(b) == 0 || !utf8.Valid(b) {
+		return false
+	}
+	return strings.IndexFunc(string(b), func(r rune) bool {
+		return !unicode.IsPrint(r)
+	}) < 0
+}
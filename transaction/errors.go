@@ -0,0 +1,71 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of errors of building and validating transactions. The errors
+// returned say what is wrong and unwrap to one of these, so that callers can
+// branch on the kind, e.g. with errors.Is.
+var (
+	// ErrMissingGenesisHash is the kind of errors of transactions without a
+	// genesis hash, which nodes reject
+	ErrMissingGenesisHash = errors.New("missing genesis hash")
+
+	// ErrInvalidValidity is the kind of errors of validity windows that are
+	// empty, overflow or are longer than the maximum transaction life
+	ErrInvalidValidity = errors.New("invalid validity window")
+
+	// ErrInvalidFee is the kind of errors of fees below the minimum and of
+	// group fees that overflow
+	ErrInvalidFee = errors.New("invalid fee")
+
+	// ErrNoteTooLong is the kind of errors of notes longer than allowed
+	ErrNoteTooLong = errors.New("note too long")
+
+	// ErrGroupTooLarge is the kind of errors of groups with more
+	// transactions, or more bytes, than allowed
+	ErrGroupTooLarge = errors.New("group too large")
+
+	// ErrAlreadyGrouped is the kind of errors of transactions added to a group
+	// while they already have a group ID
+	ErrAlreadyGrouped = errors.New("transaction already grouped")
+
+	// ErrInvalidAssetParams is the kind of errors of asset parameters the
+	// node would reject, such as names longer than allowed
+	ErrInvalidAssetParams = errors.New("invalid asset parameters")
+
+	// ErrInvalidApplicationCall is the kind of errors of application calls
+	// missing the programs or the application ID their action needs
+	ErrInvalidApplicationCall = errors.New("invalid application call")
+)
+
+// kindError is an error of one of the kinds above: it reads as its message
+// and unwraps to its kind
+type kindError struct {
+	kind error
+	msg  string
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// errorf returns an error of the given kind with a formatted message
+func errorf(kind error, format string, args ...interface{}) error {
+	return &kindError{kind: kind, msg: fmt.Sprintf(format, args...)}
+}
+
+// prefixf prefixes the message of err, keeping its kind
+func prefixf(err error, format string, args ...interface{}) error {
+	var kind error
+	if k, ok := err.(*kindError); ok {
+		kind = k.kind
+	}
+	return &kindError{kind: kind, msg: fmt.Sprintf(format, args...) + err.Error()}
+}
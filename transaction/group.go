@@ -0,0 +1,147 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/protocol"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// GroupBuilder accumulates transactions into an atomic group, checking the
+// group's size and length, and assigns them their group ID. The zero value is
+// an empty builder.
+type GroupBuilder struct {
+	txns    []types.Transaction
+	signers []types.Address
+}
+
+// SigningStep is a transaction of a built group and the address that must sign it
+type SigningStep struct {
+	// Txn is the transaction, with its group ID set
+	Txn types.Transaction
+
+	// Signer is the address whose key must sign Txn: its sender, unless
+	// another signer was given when adding it, e.g. for a rekeyed sender
+	Signer types.Address
+}
+
+// Add adds transactions to the group, to be signed by their senders
+func (b *GroupBuilder) Add(txns ...types.Transaction) error {
+	for _, txn := range txns {
+		if err := b.AddWithSigner(txn, txn.Sender); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddWithSigner adds a transaction to the group, to be signed by signer
+func (b *GroupBuilder) AddWithSigner(txn types.Transaction, signer types.Address) error {
+	if len(b.txns) == types.MaxTxGroupSize {
+		return errorf(ErrGroupTooLarge, "group is full: it may hold %d transactions", types.MaxTxGroupSize)
+	}
+	if txn.Group != (types.Digest{}) {
+		return errorf(ErrAlreadyGrouped, "transaction %d already belongs to a group", len(b.txns))
+	}
+	b.txns = append(b.txns, txn)
+	b.signers = append(b.signers, signer)
+	return nil
+}
+
+// Count returns the number of transactions in the group
+func (b *GroupBuilder) Count() int {
+	return len(b.txns)
+}
+
+// Build returns the transactions of the group, in the order they were added,
+// with their group ID set. A single transaction is returned as it is, as it
+// needs no group. The group's length, estimated as if each transaction were
+// signed by a single key, must fit in a block.
+func (b *GroupBuilder) Build() ([]types.Transaction, error) {
+	if len(b.txns) == 0 {
+		return nil, fmt.Errorf("group has no transactions")
+	}
+
+	var size uint64
+	for _, txn := range b.txns {
+		txnSize, err := EstimateSize(txn)
+		if err != nil {
+			return nil, err
+		}
+		size += txnSize
+	}
+	if size > types.MaxTxnBytesPerBlock {
+		return nil, errorf(ErrGroupTooLarge, "group of %d bytes is longer than the %d bytes of a block", size, types.MaxTxnBytesPerBlock)
+	}
+
+	txns := make([]types.Transaction, len(b.txns))
+	copy(txns, b.txns)
+	if len(txns) == 1 {
+		return txns, nil
+	}
+	gid, err := crypto.ComputeGroupID(txns)
+	if err != nil {
+		return nil, err
+	}
+	for i := range txns {
+		txns[i].Group = gid
+	}
+	return txns, nil
+}
+
+// BuildSigningPlan is as Build, but also returns the address that must sign
+// each transaction
+func (b *GroupBuilder) BuildSigningPlan() ([]SigningStep, error) {
+	txns, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	plan := make([]SigningStep, len(txns))
+	for i, txn := range txns {
+		plan[i] = SigningStep{Txn: txn, Signer: b.signers[i]}
+	}
+	return plan, nil
+}
+
+// PoolFees moves the fees of a group of transactions onto the transaction at
+// index payer, which then pays for the whole group while the others pay
+// nothing. The group's total fee must be at least minFee, or the MinTxnFee of
+// protocol.Current if minFee is 0, for each of its transactions. Fees are part of the group ID, so
+// PoolFees must be called before the group ID is assigned.
+func PoolFees(txns []types.Transaction, payer int, minFee uint64) error {
+	if payer < 0 || payer >= len(txns) {
+		return fmt.Errorf("fee payer %d is not one of the %d transactions of the group", payer, len(txns))
+	}
+	if minFee == 0 {
+		minFee = protocol.Current.MinTxnFee
+	}
+	var total uint64
+	for i, txn := range txns {
+		if txn.Group != (types.Digest{}) {
+			return errorf(ErrAlreadyGrouped, "transaction %d already has a group ID", i)
+		}
+		if total+uint64(txn.Fee) < total {
+			return errorf(ErrInvalidFee, "total fee of the group overflows")
+		}
+		total += uint64(txn.Fee)
+	}
+	required := minFee * uint64(len(txns))
+	if required/uint64(len(txns)) != minFee {
+		return errorf(ErrInvalidFee, "minimum fee of the group overflows")
+	}
+	if total < required {
+		return errorf(ErrInvalidFee, "total fee %d of the group is less than the minimum %d for %d transactions", total, required, len(txns))
+	}
+	for i := range txns {
+		txns[i].Fee = 0
+	}
+	txns[payer].Fee = types.MicroAlgos(total)
+	return nil
+}
+
+// PoolFees pools the fees of the group's transactions onto the transaction
+// added at index payer, as PoolFees does
+func (b *GroupBuilder) PoolFees(payer int, minFee uint64) error {
+	return PoolFees(b.txns, payer, minFee)
+}
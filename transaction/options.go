@@ -0,0 +1,37 @@
+package transaction
+
+import (
+	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// TxnOption sets an optional field of a transaction made by a constructor
+// taking a types.SuggestedParams, such as the application call constructors
+// of this package and those of the future package. Options are applied before
+// the transaction's fee is set, so that a fee per byte accounts for them.
+type TxnOption func(tx *types.Transaction) error
+
+// WithLease sets the lease of the transaction, which must be 32 bytes; see
+// types.MakeLease. While the transaction is valid, no other transaction of
+// its sender with the same lease can be confirmed.
+func WithLease(lease []byte) TxnOption {
+	return func(tx *types.Transaction) error {
+		if len(lease) != len(tx.Lease) {
+			return fmt.Errorf("lease must be %d bytes, not %d", len(tx.Lease), len(lease))
+		}
+		copy(tx.Lease[:], lease)
+		return nil
+	}
+}
+
+// ApplyOptions applies opts to tx, in order. Its fee is not updated: if it
+// was computed per byte, compute it again with SetFee.
+func ApplyOptions(tx *types.Transaction, opts ...TxnOption) error {
+	for _, opt := range opts {
+		if err := opt(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
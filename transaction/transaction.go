@@ -4,11 +4,19 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
+
+	"github.com/algorand/go-algorand-sdk/assetmetadata"
 	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/protocol"
 	"github.com/algorand/go-algorand-sdk/types"
 )
 
-// MinTxnFee is v5 consensus params, in microAlgos
+// MinTxnFee is the minimum fee of protocol.Current, in microAlgos, which
+// transactions built by this package pay at least.
+//
+// Deprecated: use the MinTxnFee of the protocol.Params of the network, such as
+// those returned by protocol.ForStatus.
 const MinTxnFee = 1000
 
 // MakePaymentTxn constructs a payment transaction using the passed parameters.
@@ -38,7 +46,7 @@ func MakePaymentTxn(from, to string, fee, amount, firstRound, lastRound uint64,
 
 	// Decode GenesisHash
 	if len(genesisHash) == 0 {
-		return types.Transaction{}, fmt.Errorf("payment transaction must contain a genesisHash")
+		return types.Transaction{}, errorf(ErrMissingGenesisHash, "payment transaction must contain a genesisHash")
 	}
 
 	var gh types.Digest
@@ -64,14 +72,14 @@ func MakePaymentTxn(from, to string, fee, amount, firstRound, lastRound uint64,
 	}
 
 	// Update fee
-	eSize, err := estimateSize(tx)
+	eSize, err := EstimateSize(tx)
 	if err != nil {
 		return types.Transaction{}, err
 	}
 	tx.Fee = types.MicroAlgos(eSize * fee)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 
 	return tx, nil
@@ -87,8 +95,8 @@ func MakePaymentTxnWithFlatFee(from, to string, fee, amount, firstRound, lastRou
 	}
 	tx.Fee = types.MicroAlgos(fee)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 
 	return tx, nil
@@ -152,14 +160,14 @@ func MakeKeyRegTxn(account string, feePerByte, firstRound, lastRound uint64, not
 	}
 
 	// Update fee
-	eSize, err := estimateSize(tx)
+	eSize, err := EstimateSize(tx)
 	if err != nil {
 		return types.Transaction{}, err
 	}
 	tx.Fee = types.MicroAlgos(eSize * feePerByte)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 
 	return tx, nil
@@ -188,8 +196,8 @@ func MakeKeyRegTxnWithFlatFee(account string, fee, firstRound, lastRound uint64,
 
 	tx.Fee = types.MicroAlgos(fee)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 
 	return tx, nil
@@ -212,7 +220,7 @@ func MakeAssetCreateTxn(account string, feePerByte, firstRound, lastRound uint64
 	var err error
 
 	if decimals > types.AssetMaxNumberOfDecimals {
-		return tx, fmt.Errorf("cannot create an asset with number of decimals %d (more than maximum %d)", decimals, types.AssetMaxNumberOfDecimals)
+		return tx, errorf(ErrInvalidAssetParams, "cannot create an asset with number of decimals %d (more than maximum %d)", decimals, types.AssetMaxNumberOfDecimals)
 	}
 
 	tx.Type = types.AssetConfigTx
@@ -251,22 +259,25 @@ func MakeAssetCreateTxn(account string, feePerByte, firstRound, lastRound uint64
 	}
 
 	if len(assetName) > types.AssetNameMaxLen {
-		return tx, fmt.Errorf("asset name too long: %d > %d", len(assetName), types.AssetNameMaxLen)
+		return tx, errorf(ErrInvalidAssetParams, "asset name too long: %d > %d", len(assetName), types.AssetNameMaxLen)
 	}
 	tx.AssetParams.AssetName = assetName
 
 	if len(url) > types.AssetURLMaxLen {
-		return tx, fmt.Errorf("asset url too long: %d > %d", len(url), types.AssetURLMaxLen)
+		return tx, errorf(ErrInvalidAssetParams, "asset url too long: %d > %d", len(url), types.AssetURLMaxLen)
 	}
 	tx.AssetParams.URL = url
 
 	if len(unitName) > types.AssetUnitNameMaxLen {
-		return tx, fmt.Errorf("asset unit name too long: %d > %d", len(unitName), types.AssetUnitNameMaxLen)
+		return tx, errorf(ErrInvalidAssetParams, "asset unit name too long: %d > %d", len(unitName), types.AssetUnitNameMaxLen)
 	}
 	tx.AssetParams.UnitName = unitName
 
 	if len(metadataHash) > types.AssetMetadataHashLen {
-		return tx, fmt.Errorf("asset metadata hash '%s' too long: %d > %d)", metadataHash, len(metadataHash), types.AssetMetadataHashLen)
+		return tx, errorf(ErrInvalidAssetParams, "asset metadata hash '%s' too long: %d > %d)", metadataHash, len(metadataHash), types.AssetMetadataHashLen)
+	}
+	if assetmetadata.IsARC3(assetName, url) && len(metadataHash) != 0 && len(metadataHash) != types.AssetMetadataHashLen {
+		return tx, errorf(ErrInvalidAssetParams, "ARC-3 asset metadata hash must be %d bytes, not %d", types.AssetMetadataHashLen, len(metadataHash))
 	}
 	copy(tx.AssetParams.MetadataHash[:], []byte(metadataHash))
 
@@ -290,14 +301,14 @@ func MakeAssetCreateTxn(account string, feePerByte, firstRound, lastRound uint64
 	}
 
 	// Update fee
-	eSize, err := estimateSize(tx)
+	eSize, err := EstimateSize(tx)
 	if err != nil {
 		return types.Transaction{}, err
 	}
 	tx.Fee = types.MicroAlgos(eSize * feePerByte)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 
 	return tx, nil
@@ -325,7 +336,7 @@ func MakeAssetConfigTxn(account string, feePerByte, firstRound, lastRound uint64
 	var tx types.Transaction
 
 	if strictEmptyAddressChecking && (newManager == "" || newReserve == "" || newFreeze == "" || newClawback == "") {
-		return tx, fmt.Errorf("strict empty address checking requested but empty address supplied to one or more manager addresses")
+		return tx, errorf(ErrInvalidAssetParams, "strict empty address checking requested but empty address supplied to one or more manager addresses")
 	}
 
 	tx.Type = types.AssetConfigTx
@@ -382,14 +393,14 @@ func MakeAssetConfigTxn(account string, feePerByte, firstRound, lastRound uint64
 	}
 
 	// Update fee
-	eSize, err := estimateSize(tx)
+	eSize, err := EstimateSize(tx)
 	if err != nil {
 		return types.Transaction{}, err
 	}
 	tx.Fee = types.MicroAlgos(eSize * feePerByte)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 
 	return tx, nil
@@ -449,14 +460,14 @@ func transferAssetBuilder(account, recipient, closeAssetsTo, revocationTarget st
 	tx.AssetAmount = amount
 
 	// Update fee
-	eSize, err := estimateSize(tx)
+	eSize, err := EstimateSize(tx)
 	if err != nil {
 		return types.Transaction{}, err
 	}
 	tx.Fee = types.MicroAlgos(eSize * feePerByte)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 
 	return tx, nil
@@ -518,9 +529,10 @@ func MakeAssetRevocationTxn(account, target, recipient string, amount, feePerByt
 // MakeAssetDestroyTxn creates a tx template for destroying an asset, removing it from the record.
 // All outstanding asset amount must be held by the creator, and this transaction must be issued by the asset manager.
 // - account is a checksummed, human-readable address that will send the transaction; it also must be the asset manager
-// - fee is a fee per byte
+// - feePerByte is a fee per byte
 // - firstRound is the first round this txn is valid (txn semantics unrelated to asset management)
 // - lastRound is the last round this txn is valid
+// - note is an arbitrary byte array
 // - genesis id corresponds to the id of the network
 // - genesis hash corresponds to the base64-encoded hash of the genesis of the network
 // - index is the asset index
@@ -536,7 +548,7 @@ func MakeAssetDestroyTxn(account string, feePerByte, firstRound, lastRound uint6
 // MakeAssetFreezeTxn constructs a transaction that freezes or unfreezes an account's asset holdings
 // It must be issued by the freeze address for the asset
 // - account is a checksummed, human-readable address which will send the transaction.
-// - fee is fee per byte as received from algod SuggestedFee API call.
+// - feePerByte is fee per byte as received from algod SuggestedFee API call.
 // - firstRound is the first round this txn is valid (txn semantics unrelated to the asset)
 // - lastRound is the last round this txn is valid
 // - note is an optional arbitrary byte array
@@ -545,7 +557,7 @@ func MakeAssetDestroyTxn(account string, feePerByte, firstRound, lastRound uint6
 // - assetIndex is the index for tracking the asset
 // - target is the account to be frozen or unfrozen
 // - newFreezeSetting is the new state of the target account
-func MakeAssetFreezeTxn(account string, fee, firstRound, lastRound uint64, note []byte, genesisID, genesisHash string,
+func MakeAssetFreezeTxn(account string, feePerByte, firstRound, lastRound uint64, note []byte, genesisID, genesisHash string,
 	assetIndex uint64, target string, newFreezeSetting bool) (types.Transaction, error) {
 	var tx types.Transaction
 
@@ -563,7 +575,7 @@ func MakeAssetFreezeTxn(account string, fee, firstRound, lastRound uint64, note
 
 	tx.Header = types.Header{
 		Sender:      accountAddr,
-		Fee:         types.MicroAlgos(fee),
+		Fee:         types.MicroAlgos(feePerByte),
 		FirstValid:  types.Round(firstRound),
 		LastValid:   types.Round(lastRound),
 		GenesisHash: types.Digest(ghBytes),
@@ -580,14 +592,14 @@ func MakeAssetFreezeTxn(account string, fee, firstRound, lastRound uint64, note
 
 	tx.AssetFrozen = newFreezeSetting
 	// Update fee
-	eSize, err := estimateSize(tx)
+	eSize, err := EstimateSize(tx)
 	if err != nil {
 		return types.Transaction{}, err
 	}
-	tx.Fee = types.MicroAlgos(eSize * fee)
+	tx.Fee = types.MicroAlgos(eSize * feePerByte)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 
 	return tx, nil
@@ -611,8 +623,8 @@ func MakeAssetCreateTxnWithFlatFee(account string, fee, firstRound, lastRound ui
 
 	tx.Fee = types.MicroAlgos(fee)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 
 	return tx, nil
@@ -632,8 +644,8 @@ func MakeAssetConfigTxnWithFlatFee(account string, fee, firstRound, lastRound ui
 
 	tx.Fee = types.MicroAlgos(fee)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 	return tx, nil
 }
@@ -660,8 +672,8 @@ func MakeAssetTransferTxnWithFlatFee(account, recipient, closeAssetsTo string, a
 
 	tx.Fee = types.MicroAlgos(fee)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 	return tx, nil
 }
@@ -703,8 +715,8 @@ func MakeAssetRevocationTxnWithFlatFee(account, target, recipient string, amount
 
 	tx.Fee = types.MicroAlgos(fee)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 	return tx, nil
 }
@@ -736,29 +748,31 @@ func MakeAssetFreezeTxnWithFlatFee(account string, fee, firstRound, lastRound ui
 
 	tx.Fee = types.MicroAlgos(fee)
 
-	if tx.Fee < MinTxnFee {
-		tx.Fee = MinTxnFee
+	if uint64(tx.Fee) < protocol.Current.MinTxnFee {
+		tx.Fee = types.MicroAlgos(protocol.Current.MinTxnFee)
 	}
 	return tx, nil
 }
 
-// AssignGroupID computes and return list of transactions with Group field set.
-// - txns is a list of transactions to process
-// - account specifies a sender field of transaction to return. Set to empty string to return all of them
-func AssignGroupID(txns []types.Transaction, account string) (result []types.Transaction, err error) {
+// AssignGroupID computes the group ID of txns, and returns the transactions
+// sent by filterAddress, in order, with their Group field set. This lets each
+// party to a group sign only its own transactions.
+// - txns is the whole group; none of its transactions may already have a group ID
+// - filterAddress is a checksummed, human-readable address. Set to empty string to return all of them
+func AssignGroupID(txns []types.Transaction, filterAddress string) (result []types.Transaction, err error) {
 	gid, err := crypto.ComputeGroupID(txns)
 	if err != nil {
 		return
 	}
 	var decoded types.Address
-	if account != "" {
-		decoded, err = types.DecodeAddress(account)
+	if filterAddress != "" {
+		decoded, err = types.DecodeAddress(filterAddress)
 		if err != nil {
 			return
 		}
 	}
 	for _, tx := range txns {
-		if account == "" || bytes.Compare(tx.Sender[:], decoded[:]) == 0 {
+		if filterAddress == "" || tx.Sender == decoded {
 			tx.Group = gid
 			result = append(result, tx)
 		}
@@ -766,14 +780,53 @@ func AssignGroupID(txns []types.Transaction, account string) (result []types.Tra
 	return result, nil
 }
 
-// EstimateSize returns the estimated length of the encoded transaction
-func estimateSize(txn types.Transaction) (uint64, error) {
-	key := crypto.GenerateAccount()
-	_, stx, err := crypto.SignTransaction(key.PrivateKey, txn)
+// EstimateSize returns the estimated length of the encoded transaction, once
+// signed by its sender
+func EstimateSize(txn types.Transaction) (uint64, error) {
+	// any signature has the same encoded length as the sender's
+	var sig types.Signature
+	crypto.RandomBytes(sig[:])
+	stx := types.SignedTxn{
+		Sig: sig,
+		Txn: txn,
+	}
+	return uint64(len(msgpack.Encode(stx))), nil
+}
+
+// ComputeFee returns the fee of txn at feePerByte microAlgos per byte of its
+// encoding without a fee, but no less than minFee, or the MinTxnFee of
+// protocol.Current if minFee is 0.
+func ComputeFee(txn types.Transaction, feePerByte, minFee uint64) (types.MicroAlgos, error) {
+	txn.Fee = 0
+	eSize, err := EstimateSize(txn)
 	if err != nil {
 		return 0, err
 	}
-	return uint64(len(stx)), nil
+	if minFee == 0 {
+		minFee = protocol.Current.MinTxnFee
+	}
+	fee := eSize * feePerByte
+	if fee < minFee {
+		fee = minFee
+	}
+	return types.MicroAlgos(fee), nil
+}
+
+// SetFee sets the fee of txn from params. If params.FlatFee is set, params.Fee
+// is the fee, even if it is below the minimum fee, e.g. for a transaction whose
+// fee is paid by another of its group. Otherwise params.Fee is a fee per byte,
+// and the fee is computed by ComputeFee with params.MinFee as the minimum.
+func SetFee(txn *types.Transaction, params types.SuggestedParams) error {
+	if params.FlatFee {
+		txn.Fee = params.Fee
+		return nil
+	}
+	fee, err := ComputeFee(*txn, uint64(params.Fee), params.MinFee)
+	if err != nil {
+		return err
+	}
+	txn.Fee = fee
+	return nil
 }
 
 // byte32FromBase64 decodes the input base64 string and outputs a
@@ -789,3 +842,24 @@ func byte32FromBase64(in string) (out [32]byte, err error) {
 	copy(out[:], slice)
 	return
 }
+
+// Decode decodes signed transaction bytes into the signed transactions they
+// hold: one, or a group of them concatenated, as returned by
+// templates.Split.GetSendFundsTransaction and accepted by SendRawTransaction
+func Decode(b []byte) ([]types.SignedTxn, error) {
+	var stxns []types.SignedTxn
+	r := bytes.NewReader(b)
+	dec := msgpack.NewDecoder(r)
+	for r.Len() > 0 {
+		var stx types.SignedTxn
+		err := dec.Decode(&stx)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode signed transaction %d: %v", len(stxns), err)
+		}
+		stxns = append(stxns, stx)
+	}
+	if len(stxns) == 0 {
+		return nil, fmt.Errorf("no signed transactions to decode")
+	}
+	return stxns, nil
+}
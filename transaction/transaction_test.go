@@ -2,13 +2,16 @@ package transaction
 
 import (
 	"encoding/base64"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
 	"github.com/algorand/go-algorand-sdk/crypto"
 	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
 	"github.com/algorand/go-algorand-sdk/mnemonic"
+	"github.com/algorand/go-algorand-sdk/protocol"
 	"github.com/algorand/go-algorand-sdk/types"
 )
 
@@ -48,6 +51,45 @@ func TestMakePaymentTxn(t *testing.T) {
 	require.Equal(t, referenceTxID, id)
 }
 
+func TestDecode(t *testing.T) {
+	const golden = "gqNzaWfEQPhUAZ3xkDDcc8FvOVo6UinzmKBCqs0woYSfodlmBMfQvGbeUx3Srxy3dyJDzv7rLm26BRv9FnL2/AuT7NYfiAWjdHhui6NhbXTNA+ilY2xvc2XEIEDpNJKIJWTLzpxZpptnVCaJ6aHDoqnqW2Wm6KRCH/xXo2ZlZc0EmKJmds0wsqNnZW6sZGV2bmV0LXYzMy4womdoxCAmCyAJoJOohot5WHIvpeVG7eftF+TYXEx4r7BFJpDt0qJsds00mqRub3RlxAjqABVHQ2y/lqNyY3bEIHts4k/rW6zAsWTinCIsV/X2PcOH1DkEglhBHF/hD3wCo3NuZMQg5/D4TQaBHfnzHI2HixFV9GcdUaGFwgCQhmf0SVhwaKGkdHlwZaNwYXk="
+	stxBytes := byteFromBase64(golden)
+
+	stxns, err := Decode(stxBytes)
+	require.NoError(t, err)
+	require.Len(t, stxns, 1)
+	require.Equal(t, types.MicroAlgos(1000), stxns[0].Txn.Amount)
+	require.Equal(t, "5FJDJD5LMZC3EHUYYJNH5I23U4X6H2KXABNDGPIL557ZMJ33GZHQ", crypto.GetTxID(stxns[0].Txn))
+
+	// a group, as sent to SendRawTransaction
+	gh := byteFromBase64("JgsgCaCTqIaLeVhyL6XlRu3n7Rfk2FxMeK+wRSaQ7dI=")
+	account := crypto.GenerateAccount()
+	tx1, err := MakePaymentTxnWithFlatFee(account.Address.String(), account.Address.String(), 1000, 1, 1, 1001, nil, "", "", gh)
+	require.NoError(t, err)
+	tx2, err := MakePaymentTxnWithFlatFee(account.Address.String(), account.Address.String(), 1000, 2, 1, 1001, nil, "", "", gh)
+	require.NoError(t, err)
+	txns, err := AssignGroupID([]types.Transaction{tx1, tx2}, "")
+	require.NoError(t, err)
+	var group []byte
+	for _, txn := range txns {
+		_, signed, err := crypto.SignTransaction(account.PrivateKey, txn)
+		require.NoError(t, err)
+		group = append(group, signed...)
+	}
+	stxns, err = Decode(group)
+	require.NoError(t, err)
+	require.Len(t, stxns, 2)
+	require.Equal(t, txns[0], stxns[0].Txn)
+	require.Equal(t, txns[1], stxns[1].Txn)
+
+	_, err = Decode(nil)
+	require.Error(t, err)
+	_, err = Decode(group[:len(group)-1])
+	require.Error(t, err)
+	_, err = Decode(append(stxBytes, 0xc1))
+	require.Error(t, err)
+}
+
 // should fail on a lack of GenesisHash
 func TestMakePaymentTxn2(t *testing.T) {
 	const fromAddress = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
@@ -586,6 +628,107 @@ func TestComputeGroupID(t *testing.T) {
 	result, err = AssignGroupID([]types.Transaction{tx1, tx2}, "")
 	require.NoError(t, err)
 	require.Equal(t, 2, len(result))
+	require.Equal(t, gid, result[0].Group)
+	require.Equal(t, gid, result[1].Group)
+	require.Equal(t, types.Digest{}, tx1.Group)
+
+	// only the filtered party's transactions are returned, still with the whole group's ID
+	tx3, err := MakePaymentTxnWithFlatFee(
+		"BH55E5RMBD4GYWXGX5W5PJ5JAHPGM5OXKDQH5DC4O2MGI7NW4H6VOE4CP4", toAddress, fee, amount, firstRound2, firstRound2+1000,
+		note2, "", genesisID, genesisHash,
+	)
+	require.NoError(t, err)
+	result, err = AssignGroupID([]types.Transaction{tx1, tx3, tx2}, address)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(result))
+	require.Equal(t, tx2.Note, result[1].Note)
+	gid, err = crypto.ComputeGroupID([]types.Transaction{tx1, tx3, tx2})
+	require.NoError(t, err)
+	require.Equal(t, gid, result[0].Group)
+
+	_, err = AssignGroupID([]types.Transaction{tx1, tx2}, "not an address")
+	require.Error(t, err)
+	_, err = AssignGroupID(result, "")
+	require.Error(t, err)
+}
+
+func TestGroupBuilder(t *testing.T) {
+	const address = "UPYAFLHSIPMJOHVXU2MPLQ46GXJKSDCEMZ6RLCQ7GWB5PRDKJUWKKXECXI"
+	const signer = "BH55E5RMBD4GYWXGX5W5PJ5JAHPGM5OXKDQH5DC4O2MGI7NW4H6VOE4CP4"
+	genesisHash := byteFromBase64("sC3P7e2SdbqKJK0tbiCdK9tdSpbe6XeCGKdoNzmlj0E=")
+	tx1, err := MakePaymentTxnWithFlatFee(address, address, 1000, 2000, 710399, 711399, nil, "", "devnet-v1.0", genesisHash)
+	require.NoError(t, err)
+	tx2, err := MakePaymentTxnWithFlatFee(address, address, 1000, 3000, 710399, 711399, nil, "", "devnet-v1.0", genesisHash)
+	require.NoError(t, err)
+	signerAddr, err := types.DecodeAddress(signer)
+	require.NoError(t, err)
+
+	var group GroupBuilder
+	_, err = group.Build()
+	require.Error(t, err)
+
+	require.NoError(t, group.Add(tx1))
+	txns, err := group.Build()
+	require.NoError(t, err)
+	require.Equal(t, []types.Transaction{tx1}, txns)
+
+	require.NoError(t, group.AddWithSigner(tx2, signerAddr))
+	require.Equal(t, 2, group.Count())
+	plan, err := group.BuildSigningPlan()
+	require.NoError(t, err)
+	gid, err := crypto.ComputeGroupID([]types.Transaction{tx1, tx2})
+	require.NoError(t, err)
+	require.Len(t, plan, 2)
+	require.Equal(t, gid, plan[0].Txn.Group)
+	require.Equal(t, gid, plan[1].Txn.Group)
+	require.Equal(t, tx1.Sender, plan[0].Signer)
+	require.Equal(t, signerAddr, plan[1].Signer)
+
+	// transactions of another group are rejected
+	require.Error(t, group.Add(plan[0].Txn))
+
+	for group.Count() < types.MaxTxGroupSize {
+		require.NoError(t, group.Add(tx1))
+	}
+	require.Error(t, group.Add(tx1))
+
+	// so are groups too long for a block
+	var long GroupBuilder
+	tx1.Note = make([]byte, types.MaxTxnBytesPerBlock/2)
+	require.NoError(t, long.Add(tx1, tx1))
+	_, err = long.Build()
+	require.Error(t, err)
+}
+
+func TestPoolFees(t *testing.T) {
+	const address = "UPYAFLHSIPMJOHVXU2MPLQ46GXJKSDCEMZ6RLCQ7GWB5PRDKJUWKKXECXI"
+	genesisHash := byteFromBase64("sC3P7e2SdbqKJK0tbiCdK9tdSpbe6XeCGKdoNzmlj0E=")
+	tx1, err := MakePaymentTxnWithFlatFee(address, address, 1000, 2000, 710399, 711399, nil, "", "devnet-v1.0", genesisHash)
+	require.NoError(t, err)
+	tx2, err := MakePaymentTxnWithFlatFee(address, address, 1500, 3000, 710399, 711399, nil, "", "devnet-v1.0", genesisHash)
+	require.NoError(t, err)
+
+	txns := []types.Transaction{tx1, tx2}
+	require.NoError(t, PoolFees(txns, 1, 0))
+	require.Equal(t, types.MicroAlgos(0), txns[0].Fee)
+	require.Equal(t, types.MicroAlgos(2500), txns[1].Fee)
+
+	// the total must cover the minimum fee of each transaction
+	txns = []types.Transaction{tx1, tx2}
+	require.Error(t, PoolFees(txns, 0, 2000))
+	require.Equal(t, tx1.Fee, txns[0].Fee)
+	require.Error(t, PoolFees(txns, 2, 0))
+
+	var group GroupBuilder
+	require.NoError(t, group.Add(tx1, tx2))
+	require.NoError(t, group.PoolFees(0, 0))
+	built, err := group.Build()
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(2500), built[0].Fee)
+	require.Equal(t, types.MicroAlgos(0), built[1].Fee)
+
+	// fees cannot be moved once the group ID is assigned
+	require.Error(t, PoolFees(built, 1, 0))
 }
 
 func TestLogicSig(t *testing.T) {
@@ -634,3 +777,296 @@ func TestLogicSig(t *testing.T) {
 	require.True(t, verified)
 
 }
+
+func makeTestAppSuggestedParams() types.SuggestedParams {
+	return types.SuggestedParams{
+		Fee:             10,
+		GenesisID:       "devnet-v33.0",
+		GenesisHash:     byteFromBase64("JgsgCaCTqIaLeVhyL6XlRu3n7Rfk2FxMeK+wRSaQ7dI="),
+		FirstRoundValid: 322575,
+		LastRoundValid:  323575,
+	}
+}
+
+func TestMakeApplicationCreateTxn(t *testing.T) {
+	const sender = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	const account = "PNWOET7LLOWMBMLE4KOCELCX6X3D3Q4H2Q4QJASYIEOF7YIPPQBG3YQ5YI"
+	approval := []byte{0x08, 0x81, 0x01, 0x43}
+	clear := []byte{0x08, 0x81, 0x01}
+	global := types.StateSchema{NumUint: 2, NumByteSlice: 1}
+	local := types.StateSchema{NumUint: 1}
+	sp := makeTestAppSuggestedParams()
+
+	tx, err := MakeApplicationCreateTxn(true, approval, clear, global, local, 1,
+		[][]byte{[]byte("arg")}, []string{account}, []uint64{5}, []uint64{6}, sp, sender, []byte("note"))
+	require.NoError(t, err)
+
+	require.Equal(t, types.ApplicationCallTx, tx.Type)
+	require.Equal(t, types.AppIndex(0), tx.ApplicationID)
+	require.Equal(t, types.OptInOC, tx.OnCompletion)
+	require.Equal(t, approval, tx.ApprovalProgram)
+	require.Equal(t, clear, tx.ClearStateProgram)
+	require.Equal(t, global, tx.GlobalStateSchema)
+	require.Equal(t, local, tx.LocalStateSchema)
+	require.Equal(t, uint32(1), tx.ExtraProgramPages)
+	require.Equal(t, [][]byte{[]byte("arg")}, tx.ApplicationArgs)
+	accountAddr, err := types.DecodeAddress(account)
+	require.NoError(t, err)
+	require.Equal(t, []types.Address{accountAddr}, tx.Accounts)
+	require.Equal(t, []types.AppIndex{5}, tx.ForeignApps)
+	require.Equal(t, []types.AssetIndex{6}, tx.ForeignAssets)
+	require.Equal(t, types.Round(322575), tx.FirstValid)
+	require.Equal(t, types.Round(323575), tx.LastValid)
+	require.Equal(t, "devnet-v33.0", tx.GenesisID)
+	require.Equal(t, byte32ArrayFromBase64("JgsgCaCTqIaLeVhyL6XlRu3n7Rfk2FxMeK+wRSaQ7dI="), [32]byte(tx.GenesisHash))
+
+	// the fee is per byte of the transaction without its fee, but at least the minimum fee
+	fee := tx.Fee
+	tx.Fee = 0
+	eSize, err := EstimateSize(tx)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(eSize*10), fee)
+
+	sp.Fee = 0
+	tx, err = MakeApplicationCreateTxn(false, approval, clear, global, local, 0, nil, nil, nil, nil, sp, sender, nil)
+	require.NoError(t, err)
+	require.Equal(t, types.NoOpOC, tx.OnCompletion)
+	require.Equal(t, types.MicroAlgos(protocol.Current.MinTxnFee), tx.Fee)
+
+	sp.Fee = 2500
+	sp.FlatFee = true
+	tx, err = MakeApplicationCreateTxn(false, approval, clear, global, local, 0, nil, nil, nil, nil, sp, sender, nil)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(2500), tx.Fee)
+
+	// the encoding round trips
+	decoded := types.Transaction{}
+	require.NoError(t, msgpack.Decode(msgpack.Encode(tx), &decoded))
+	require.Equal(t, tx, decoded)
+
+	_, err = MakeApplicationCreateTxn(false, nil, clear, global, local, 0, nil, nil, nil, nil, sp, sender, nil)
+	require.Error(t, err)
+	_, err = MakeApplicationCreateTxn(false, approval, clear, global, local, 0, nil, []string{"bad"}, nil, nil, sp, sender, nil)
+	require.Error(t, err)
+	sp.GenesisHash = nil
+	_, err = MakeApplicationCreateTxn(false, approval, clear, global, local, 0, nil, nil, nil, nil, sp, sender, nil)
+	require.Error(t, err)
+}
+
+func TestMakeApplicationCallTxns(t *testing.T) {
+	const sender = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	sp := makeTestAppSuggestedParams()
+	args := [][]byte{[]byte("a"), []byte("b")}
+
+	tx, err := MakeApplicationCallTxn(7, args, nil, nil, nil, types.NoOpOC, sp, sender, nil)
+	require.NoError(t, err)
+	require.Equal(t, types.AppIndex(7), tx.ApplicationID)
+	require.Equal(t, types.NoOpOC, tx.OnCompletion)
+	require.Equal(t, args, tx.ApplicationArgs)
+	require.Empty(t, tx.ApprovalProgram)
+
+	makers := map[types.OnCompletion]func(uint64, [][]byte, []string, []uint64, []uint64, types.SuggestedParams, string, []byte, ...TxnOption) (types.Transaction, error){
+		types.OptInOC:             MakeApplicationOptInTxn,
+		types.CloseOutOC:          MakeApplicationCloseOutTxn,
+		types.ClearStateOC:        MakeApplicationClearStateTxn,
+		types.DeleteApplicationOC: MakeApplicationDeleteTxn,
+	}
+	for onComplete, make := range makers {
+		tx, err := make(7, args, nil, nil, nil, sp, sender, nil)
+		require.NoError(t, err)
+		require.Equal(t, types.AppIndex(7), tx.ApplicationID)
+		require.Equal(t, onComplete, tx.OnCompletion)
+
+		_, err = make(0, args, nil, nil, nil, sp, sender, nil)
+		require.Error(t, err)
+	}
+
+	tx, err = MakeApplicationUpdateTxn(7, nil, nil, nil, nil, []byte{1}, []byte{2}, sp, sender, nil)
+	require.NoError(t, err)
+	require.Equal(t, types.UpdateApplicationOC, tx.OnCompletion)
+	require.Equal(t, []byte{1}, tx.ApprovalProgram)
+	require.Equal(t, []byte{2}, tx.ClearStateProgram)
+
+	_, err = MakeApplicationUpdateTxn(0, nil, nil, nil, nil, []byte{1}, []byte{2}, sp, sender, nil)
+	require.Error(t, err)
+	_, err = MakeApplicationUpdateTxn(7, nil, nil, nil, nil, nil, []byte{2}, sp, sender, nil)
+	require.Error(t, err)
+	_, err = MakeApplicationCallTxn(7, nil, nil, nil, nil, types.UpdateApplicationOC, sp, sender, nil)
+	require.Error(t, err)
+	_, err = MakeApplicationCallTxn(7, nil, nil, nil, nil, types.NoOpOC, sp, "bad", nil)
+	require.Error(t, err)
+
+	lease := [32]byte{1, 2, 3}
+	leased, err := MakeApplicationOptInTxn(7, args, nil, nil, nil, sp, sender, nil, WithLease(lease[:]))
+	require.NoError(t, err)
+	require.Equal(t, lease, leased.Lease)
+	unleased, err := MakeApplicationOptInTxn(7, args, nil, nil, nil, sp, sender, nil)
+	require.NoError(t, err)
+	require.True(t, leased.Fee > unleased.Fee)
+	_, err = MakeApplicationOptInTxn(7, args, nil, nil, nil, sp, sender, nil, WithLease(lease[:31]))
+	require.Error(t, err)
+}
+
+func TestMakeApplicationCallTxnWithBoxes(t *testing.T) {
+	const sender = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	sp := makeTestAppSuggestedParams()
+	boxes := []types.AppBoxReference{
+		{AppID: 7, Name: []byte("own")},
+		{AppID: 0, Name: []byte("also own")},
+		{AppID: 9, Name: []byte("other")},
+	}
+
+	tx, err := MakeApplicationCallTxnWithBoxes(7, nil, nil, []uint64{8, 9}, nil, boxes, types.NoOpOC, sp, sender, nil)
+	require.NoError(t, err)
+	require.Equal(t, []types.BoxReference{
+		{ForeignAppIdx: 0, Name: []byte("own")},
+		{ForeignAppIdx: 0, Name: []byte("also own")},
+		{ForeignAppIdx: 2, Name: []byte("other")},
+	}, tx.BoxReferences)
+
+	// box references are encoded in the transaction, and counted in its fee
+	withoutBoxes, err := MakeApplicationCallTxn(7, nil, nil, []uint64{8, 9}, nil, types.NoOpOC, sp, sender, nil)
+	require.NoError(t, err)
+	require.Contains(t, string(msgpack.Encode(tx)), "apbx")
+	require.True(t, tx.Fee > withoutBoxes.Fee)
+
+	// the application of a box must be called or foreign
+	_, err = MakeApplicationCallTxnWithBoxes(7, nil, nil, []uint64{8}, nil, boxes, types.NoOpOC, sp, sender, nil)
+	require.Error(t, err)
+}
+
+func TestComputeFee(t *testing.T) {
+	const addr = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	gh := byteFromBase64("JgsgCaCTqIaLeVhyL6XlRu3n7Rfk2FxMeK+wRSaQ7dI=")
+	txn, err := MakePaymentTxnWithFlatFee(addr, addr, 0, 1000, 12466, 13466, nil, "", "devnet-v33.0", gh)
+	require.NoError(t, err)
+
+	// the size does not depend on the transaction's current fee
+	txn.Fee = 0
+	size, err := EstimateSize(txn)
+	require.NoError(t, err)
+	txn.Fee = 123456
+	fee, err := ComputeFee(txn, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(size*10), fee)
+
+	fee, err = ComputeFee(txn, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(protocol.Current.MinTxnFee), fee)
+	fee, err = ComputeFee(txn, 1, 2000)
+	require.NoError(t, err)
+	require.Equal(t, types.MicroAlgos(2000), fee)
+
+	require.NoError(t, SetFee(&txn, types.SuggestedParams{Fee: 10}))
+	require.Equal(t, types.MicroAlgos(size*10), txn.Fee)
+	require.NoError(t, SetFee(&txn, types.SuggestedParams{Fee: 0, MinFee: 2000}))
+	require.Equal(t, types.MicroAlgos(2000), txn.Fee)
+	// flat fees may be below the minimum
+	require.NoError(t, SetFee(&txn, types.SuggestedParams{Fee: 0, FlatFee: true}))
+	require.Equal(t, types.MicroAlgos(0), txn.Fee)
+}
+
+func TestValidityWindow(t *testing.T) {
+	// the deprecated constants are those of protocol.Current
+	require.Equal(t, protocol.Current.MaxTxnLife, uint64(MaxTxnLife))
+	require.Equal(t, protocol.Current.MinTxnFee, uint64(MinTxnFee))
+
+	var txn types.Transaction
+	require.NoError(t, SetValidityWindow(&txn, 100, protocol.Current.MaxTxnLife))
+	require.Equal(t, types.Round(100), txn.FirstValid)
+	require.Equal(t, types.Round(1100), txn.LastValid)
+	err := SetValidityWindow(&txn, 100, protocol.Current.MaxTxnLife+1)
+	require.Equal(t, ErrInvalidValidity, err.(interface{ Unwrap() error }).Unwrap())
+	err = SetValidityWindow(&txn, math.MaxUint64, 1)
+	require.Equal(t, ErrInvalidValidity, err.(interface{ Unwrap() error }).Unwrap())
+	// a failed call leaves the transaction unchanged
+	require.Equal(t, types.Round(1100), txn.LastValid)
+
+	require.NoError(t, ApplyOptions(&txn, WithValidityWindow(5, 0)))
+	require.Equal(t, types.Round(5), txn.FirstValid)
+	require.Equal(t, types.Round(5), txn.LastValid)
+
+	require.NoError(t, SetValidityWindow(&txn, 100, 10))
+	require.Equal(t, uint64(10), RoundsUntilExpiry(models.NodeStatusResponse{LastRound: 100}, txn))
+	require.Equal(t, uint64(1), RoundsUntilExpiry(models.NodeStatusResponse{LastRound: 109}, txn))
+	require.Equal(t, uint64(0), RoundsUntilExpiry(models.NodeStatusResponse{LastRound: 110}, txn))
+	require.Equal(t, uint64(0), RoundsUntilExpiry(models.NodeStatusResponse{LastRound: 200}, txn))
+}
+
+func TestValidate(t *testing.T) {
+	params := protocol.Current
+	valid := types.Transaction{
+		Type:   types.PaymentTx,
+		Header: types.Header{Fee: 1000, FirstValid: 100, LastValid: 1100, GenesisHash: types.Digest{1}},
+	}
+	require.NoError(t, Validate(valid, params))
+
+	invalid := map[string]struct {
+		change func(tx *types.Transaction)
+		kind   error
+	}{
+		"zero fee":             {func(tx *types.Transaction) { tx.Fee = 0 }, ErrInvalidFee},
+		"low fee":              {func(tx *types.Transaction) { tx.Fee = 999 }, ErrInvalidFee},
+		"long note":            {func(tx *types.Transaction) { tx.Note = make([]byte, types.MaxTxnNoteBytes+1) }, ErrNoteTooLong},
+		"long validity window": {func(tx *types.Transaction) { tx.LastValid++ }, ErrInvalidValidity},
+		"empty validity window": {func(tx *types.Transaction) {
+			tx.FirstValid, tx.LastValid = tx.LastValid, tx.FirstValid
+		}, ErrInvalidValidity},
+		"no genesis hash": {func(tx *types.Transaction) { tx.GenesisHash = types.Digest{} }, ErrMissingGenesisHash},
+	}
+	for name, c := range invalid {
+		tx := valid
+		c.change(&tx)
+		err := Validate(tx, params)
+		require.Error(t, err, name)
+		require.Equal(t, c.kind, err.(interface{ Unwrap() error }).Unwrap(), name)
+	}
+
+	// a network with a higher minimum fee
+	params.MinTxnFee = 2000
+	require.EqualError(t, Validate(valid, params), "fee of 1000 microAlgos is less than the minimum of 2000")
+
+	// fees are pooled across a group
+	params = protocol.Current
+	paying, free := valid, valid
+	paying.Fee, free.Fee = 2000, 0
+	require.NoError(t, ValidateGroup([]types.Transaction{paying, free}, params))
+	require.EqualError(t, ValidateGroup([]types.Transaction{valid, free}, params), "group pays 1000 microAlgos in fees, less than the minimum of 2000 for 2 transactions")
+	noHash := valid
+	noHash.GenesisHash = types.Digest{}
+	err := ValidateGroup([]types.Transaction{valid, noHash}, params)
+	require.EqualError(t, err, "transaction 1: genesis hash is missing")
+	require.Equal(t, ErrMissingGenesisHash, err.(interface{ Unwrap() error }).Unwrap())
+	err = ValidateGroup(make([]types.Transaction, params.MaxTxGroupSize+1), params)
+	require.Equal(t, ErrGroupTooLarge, err.(interface{ Unwrap() error }).Unwrap())
+}
+
+func TestDump(t *testing.T) {
+	const golden = "gqNzaWfEQPhUAZ3xkDDcc8FvOVo6UinzmKBCqs0woYSfodlmBMfQvGbeUx3Srxy3dyJDzv7rLm26BRv9FnL2/AuT7NYfiAWjdHhui6NhbXTNA+ilY2xvc2XEIEDpNJKIJWTLzpxZpptnVCaJ6aHDoqnqW2Wm6KRCH/xXo2ZlZc0EmKJmds0wsqNnZW6sZGV2bmV0LXYzMy4womdoxCAmCyAJoJOohot5WHIvpeVG7eftF+TYXEx4r7BFJpDt0qJsds00mqRub3RlxAjqABVHQ2y/lqNyY3bEIHts4k/rW6zAsWTinCIsV/X2PcOH1DkEglhBHF/hD3wCo3NuZMQg5/D4TQaBHfnzHI2HixFV9GcdUaGFwgCQhmf0SVhwaKGkdHlwZaNwYXk="
+	stxns, err := Decode(byteFromBase64(golden))
+	require.NoError(t, err)
+
+	dump := DumpSigned(stxns[0])
+	require.Contains(t, dump, "type:              pay\n")
+	require.Contains(t, dump, "txid:              5FJDJD5LMZC3EHUYYJNH5I23U4X6H2KXABNDGPIL557ZMJ33GZHQ\n")
+	require.Contains(t, dump, "sender:            47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU\n")
+	require.Contains(t, dump, "receiver:          PNWOET7LLOWMBMLE4KOCELCX6X3D3Q4H2Q4QJASYIEOF7YIPPQBG3YQ5YI\n")
+	require.Contains(t, dump, "amount:            0.001 Algos\n")
+	require.Contains(t, dump, "fee:               0.001176 Algos\n")
+	require.Contains(t, dump, "valid:             rounds 12466 to 13466\n")
+	require.Contains(t, dump, "close to:          IDUTJEUIEVSMXTU4LGTJWZ2UE2E6TIODUKU6UW3FU3UKIQQ77RLUBBBFLA\n")
+	require.Contains(t, dump, "signature:         single\n")
+	require.NotContains(t, dump, "rekey to")
+	require.Equal(t, Dump(stxns[0].Txn), dump[:len(Dump(stxns[0].Txn))])
+
+	txn, err := MakeApplicationCallTxn(7, [][]byte{[]byte("hello"), {0, 0, 0, 0, 0, 0, 0, 5}, {0xff}}, nil, nil, nil,
+		types.NoOpOC, types.SuggestedParams{Fee: 1000, FlatFee: true, FirstRoundValid: 1, LastRoundValid: 2, GenesisHash: make([]byte, 32)},
+		"47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU", nil)
+	require.NoError(t, err)
+	dump = Dump(txn)
+	require.Contains(t, dump, "application:       7\n")
+	require.Contains(t, dump, "on completion:     noop\n")
+	require.Contains(t, dump, "arg 0:             \"hello\"\n")
+	require.Contains(t, dump, "arg 1:             5 (b64 AAAAAAAAAAU=)\n")
+	require.Contains(t, dump, "arg 2:             b64 /w==\n")
+}
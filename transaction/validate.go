@@ -0,0 +1,62 @@
+package transaction
+
+import (
+	"github.com/algorand/go-algorand-sdk/protocol"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// Validate checks tx against the parameters of a consensus version, returning
+// a descriptive error for the first problem that would make a node reject it:
+// a missing genesis hash, a validity window that is empty or longer than
+// params.MaxTxnLife, a note longer than params.MaxTxnNoteBytes, or a fee below
+// params.MinTxnFee. tx is checked as if sent alone; a transaction whose fee is
+// paid by the others of its group is checked with ValidateGroup.
+func Validate(tx types.Transaction, params protocol.Params) error {
+	if err := validateFields(tx, params); err != nil {
+		return err
+	}
+	if uint64(tx.Fee) < params.MinTxnFee {
+		return errorf(ErrInvalidFee, "fee of %d microAlgos is less than the minimum of %d", tx.Fee, params.MinTxnFee)
+	}
+	return nil
+}
+
+// ValidateGroup checks each transaction of a group as Validate does, except
+// that their fees are pooled: together they must pay params.MinTxnFee for each
+// transaction. The group must also fit in params.MaxTxGroupSize transactions.
+func ValidateGroup(txns []types.Transaction, params protocol.Params) error {
+	if len(txns) > params.MaxTxGroupSize {
+		return errorf(ErrGroupTooLarge, "group of %d transactions is larger than the maximum of %d", len(txns), params.MaxTxGroupSize)
+	}
+	var fees uint64
+	for i, tx := range txns {
+		if err := validateFields(tx, params); err != nil {
+			return prefixf(err, "transaction %d: ", i)
+		}
+		fees += uint64(tx.Fee)
+		if fees < uint64(tx.Fee) {
+			return errorf(ErrInvalidFee, "transaction %d: fees of the group overflow", i)
+		}
+	}
+	if min := params.MinTxnFee * uint64(len(txns)); fees < min {
+		return errorf(ErrInvalidFee, "group pays %d microAlgos in fees, less than the minimum of %d for %d transactions", fees, min, len(txns))
+	}
+	return nil
+}
+
+// validateFields checks the fields of tx other than its fee
+func validateFields(tx types.Transaction, params protocol.Params) error {
+	if tx.GenesisHash == (types.Digest{}) {
+		return errorf(ErrMissingGenesisHash, "genesis hash is missing")
+	}
+	if tx.LastValid < tx.FirstValid {
+		return errorf(ErrInvalidValidity, "last valid round %d is before first valid round %d", tx.LastValid, tx.FirstValid)
+	}
+	if window := uint64(tx.LastValid - tx.FirstValid); window > params.MaxTxnLife {
+		return errorf(ErrInvalidValidity, "validity window of %d rounds exceeds the maximum of %d", window, params.MaxTxnLife)
+	}
+	if len(tx.Note) > params.MaxTxnNoteBytes {
+		return errorf(ErrNoteTooLong, "note of %d bytes is longer than the maximum of %d", len(tx.Note), params.MaxTxnNoteBytes)
+	}
+	return nil
+}
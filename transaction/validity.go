@@ -0,0 +1,48 @@
+package transaction
+
+import (
+	"github.com/algorand/go-algorand-sdk/client/v2/common/models"
+	"github.com/algorand/go-algorand-sdk/protocol"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// MaxTxnLife is the MaxTxnLife of protocol.Current: the largest number of
+// rounds a transaction's last valid round may be after its first valid round.
+//
+// Deprecated: use the MaxTxnLife of the protocol.Params of the network, such
+// as those returned by protocol.ForStatus.
+const MaxTxnLife = 1000
+
+// SetValidityWindow makes tx valid from firstRound to firstRound+windowSize,
+// inclusive. The node rejects transactions valid for longer than the
+// MaxTxnLife of protocol.Current, so an error is returned if windowSize
+// exceeds it.
+func SetValidityWindow(tx *types.Transaction, firstRound, windowSize uint64) error {
+	if windowSize > protocol.Current.MaxTxnLife {
+		return errorf(ErrInvalidValidity, "validity window of %d rounds exceeds the maximum of %d", windowSize, protocol.Current.MaxTxnLife)
+	}
+	if firstRound+windowSize < firstRound {
+		return errorf(ErrInvalidValidity, "last valid round overflows: first round %d, window %d", firstRound, windowSize)
+	}
+	tx.FirstValid = types.Round(firstRound)
+	tx.LastValid = types.Round(firstRound + windowSize)
+	return nil
+}
+
+// WithValidityWindow is a TxnOption setting the validity window of the
+// transaction, as SetValidityWindow does
+func WithValidityWindow(firstRound, windowSize uint64) TxnOption {
+	return func(tx *types.Transaction) error {
+		return SetValidityWindow(tx, firstRound, windowSize)
+	}
+}
+
+// RoundsUntilExpiry returns the number of rounds after the last round seen by
+// the node in which tx can still be confirmed, or 0 if tx can no longer be
+// confirmed because its last valid round has passed
+func RoundsUntilExpiry(status models.NodeStatusResponse, tx types.Transaction) uint64 {
+	if uint64(tx.LastValid) <= status.LastRound {
+		return 0
+	}
+	return uint64(tx.LastValid) - status.LastRound
+}
@@ -14,6 +14,20 @@ const (
 // Address represents an Algorand address.
 type Address [hashLenBytes]byte
 
+// ZeroAddress is the address of all zero bytes, which transactions use to
+// leave an address field unset
+var ZeroAddress = Address{}
+
+// IsZero returns true if the address is the zero address
+func (a Address) IsZero() bool {
+	return a == ZeroAddress
+}
+
+// Equal returns true if the two addresses are the same
+func (a Address) Equal(other Address) bool {
+	return a == other
+}
+
 // String grabs a human-readable representation of the address. This
 // representation includes a 4-byte checksum.
 func (a Address) String() string {
@@ -27,11 +41,13 @@ func (a Address) String() string {
 }
 
 // DecodeAddress turns a checksum address string into an Address object. It
-// checks that the checksum is correct, and returns an error if it's not.
+// checks that the checksum is correct, and returns an error wrapping
+// ErrBadAddress if it's not.
 func DecodeAddress(addr string) (a Address, err error) {
 	// Interpret the address as base32
 	decoded, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(addr)
 	if err != nil {
+		err = badAddressError("address is not base32: " + err.Error())
 		return
 	}
 
@@ -59,3 +75,19 @@ func DecodeAddress(addr string) (a Address, err error) {
 	copy(a[:], addressBytes)
 	return a, nil
 }
+
+// MarshalText returns the checksum address string of the address, so that
+// addresses are encoded in JSON as algod encodes them
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText decodes a checksum address string into the address
+func (a *Address) UnmarshalText(text []byte) error {
+	decoded, err := DecodeAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = decoded
+	return nil
+}
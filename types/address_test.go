@@ -2,6 +2,7 @@ package types
 
 import (
 	"crypto/rand"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -25,6 +26,20 @@ func TestEncodeDecode(t *testing.T) {
 	}
 }
 
+func TestBadAddress(t *testing.T) {
+	for _, addr := range []string{
+		"57YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU", // checksum
+		"47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OP",    // length
+		"47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPAS1", // base32
+	} {
+		_, err := DecodeAddress(addr)
+		require.Error(t, err, addr)
+		unwrapper, ok := err.(interface{ Unwrap() error })
+		require.True(t, ok, addr)
+		require.Equal(t, ErrBadAddress, unwrapper.Unwrap(), addr)
+	}
+}
+
 func TestGoldenValues(t *testing.T) {
 	golden := "7777777777777777777777777777777777777777777777777774MSJUVU"
 	a := Address{}
@@ -33,3 +48,36 @@ func TestGoldenValues(t *testing.T) {
 	}
 	require.Equal(t, golden, a.String())
 }
+
+func TestZeroAddress(t *testing.T) {
+	require.True(t, ZeroAddress.IsZero())
+	require.True(t, Address{}.IsZero())
+	require.Equal(t, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAY5HFKQ", ZeroAddress.String())
+
+	a := Address{}
+	randomBytes(a[:])
+	require.False(t, a.IsZero())
+	b := a
+	require.True(t, a.Equal(b))
+	b[31]++
+	require.False(t, a.Equal(b))
+}
+
+func TestAddressText(t *testing.T) {
+	const addr = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	a, err := DecodeAddress(addr)
+	require.NoError(t, err)
+
+	// addresses can be JSON map keys
+	encoded, err := json.Marshal(map[Address]uint64{a: 5})
+	require.NoError(t, err)
+	require.Equal(t, `{"`+addr+`":5}`, string(encoded))
+	var decoded map[Address]uint64
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, uint64(5), decoded[a])
+
+	// and are validated when decoded
+	var b Address
+	require.Error(t, b.UnmarshalText([]byte(addr[:len(addr)-1]+"A")))
+	require.Error(t, json.Unmarshal([]byte(`{"ABC":5}`), &decoded))
+}
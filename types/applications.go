@@ -0,0 +1,141 @@
+package types
+
+// AppIndex is the unique integer index of an application that can be used to
+// look up the creator of the application, whose balance record contains the
+// AppParams
+type AppIndex uint64
+
+// OnCompletion is an enum representing some layer 1 side effect that an
+// ApplicationCall transaction will have if it is included in a block.
+type OnCompletion uint64
+
+const (
+	// NoOpOC indicates that an application transaction will simply call its
+	// ApprovalProgram
+	NoOpOC OnCompletion = 0
+
+	// OptInOC indicates that an application transaction will allocate some
+	// LocalState for the application in the sender's account
+	OptInOC OnCompletion = 1
+
+	// CloseOutOC indicates that an application transaction will deallocate
+	// some LocalState for the application from the user's account
+	CloseOutOC OnCompletion = 2
+
+	// ClearStateOC is similar to CloseOutOC, but may never fail. This
+	// allows users to reclaim their minimum balance from an application
+	// they no longer wish to opt in to.
+	ClearStateOC OnCompletion = 3
+
+	// UpdateApplicationOC indicates that an application transaction will
+	// update the ApprovalProgram and ClearStateProgram for the application
+	UpdateApplicationOC OnCompletion = 4
+
+	// DeleteApplicationOC indicates that an application transaction will
+	// delete the AppParams for the application from the creator's balance
+	// record
+	DeleteApplicationOC OnCompletion = 5
+)
+
+// StateSchema sets maximums on the number of each type that may be stored
+type StateSchema struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	NumUint      uint64 `codec:"nui"`
+	NumByteSlice uint64 `codec:"nbs"`
+}
+
+// BoxReference names a box that an application call may access, as it is
+// encoded in the transaction
+type BoxReference struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// ForeignAppIdx is the box's application: 0 for the called
+	// application, otherwise 1 plus its position in ForeignApps
+	ForeignAppIdx uint64 `codec:"i"`
+
+	// Name is the box's name
+	Name []byte `codec:"n"`
+}
+
+// AppBoxReference names a box by the ID of its application, as it is given
+// to the application call constructors, which turn it into a BoxReference
+type AppBoxReference struct {
+	// AppID is the box's application, or 0 for the called application
+	AppID uint64
+
+	// Name is the box's name
+	Name []byte
+}
+
+// ApplicationFields captures the transaction fields used for all
+// interactions with applications
+type ApplicationFields struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// ApplicationID is 0 when creating an application, and nonzero when
+	// calling an existing application.
+	ApplicationID AppIndex `codec:"apid"`
+
+	// OnCompletion specifies an optional side-effect that this transaction
+	// will have on the balance record of the sender or the application's
+	// creator. See the documentation for the OnCompletion type for more
+	// information on each possible value.
+	OnCompletion OnCompletion `codec:"apan"`
+
+	// ApplicationArgs are arguments accessible to the executing
+	// ApprovalProgram or ClearStateProgram.
+	ApplicationArgs [][]byte `codec:"apaa"`
+
+	// Accounts are accounts whose balance records are accessible
+	// by the executing ApprovalProgram or ClearStateProgram. To
+	// access LocalState or an ASA balance for an account besides
+	// the sender, that account's address must be listed here.
+	Accounts []Address `codec:"apat"`
+
+	// ForeignApps are application IDs for applications besides
+	// this one whose GlobalState may be read by the executing
+	// ApprovalProgram or ClearStateProgram.
+	ForeignApps []AppIndex `codec:"apfa"`
+
+	// ForeignAssets are asset IDs for assets whose AssetParams
+	// may be read by the executing ApprovalProgram or
+	// ClearStateProgram.
+	ForeignAssets []AssetIndex `codec:"apas"`
+
+	// BoxReferences are the boxes that the executing ApprovalProgram or
+	// ClearStateProgram may read or write, each naming its application
+	// by its position in ForeignApps.
+	BoxReferences []BoxReference `codec:"apbx"`
+
+	// LocalStateSchema specifies the maximum number of each type that may
+	// appear in the local key/value store of users who opt in to this
+	// application. This field is only used during application creation
+	// (when the ApplicationID field is 0),
+	LocalStateSchema StateSchema `codec:"apls"`
+
+	// GlobalStateSchema specifies the maximum number of each type that may
+	// appear in the global key/value store associated with this
+	// application. This field is only used during application creation
+	// (when the ApplicationID field is 0).
+	GlobalStateSchema StateSchema `codec:"apgs"`
+
+	// ApprovalProgram is the stateful TEAL bytecode that executes on all
+	// ApplicationCall transactions associated with this application,
+	// except for those where OnCompletion is equal to ClearStateOC. If
+	// this program fails, the transaction is rejected. This program may
+	// read and write local and global state for this application.
+	ApprovalProgram []byte `codec:"apap"`
+
+	// ClearStateProgram is the stateful TEAL bytecode that executes on
+	// ApplicationCall transactions associated with this application when
+	// OnCompletion is equal to ClearStateOC. This program will not cause
+	// the transaction to be rejected, even if it fails. This program may
+	// read and write local and global state for this application.
+	ClearStateProgram []byte `codec:"apsu"`
+
+	// ExtraProgramPages specifies the additional app program len requested in pages.
+	// A page is 2048 bytes. This field enables execution of app programs
+	// larger than default config, MaxAppProgramLen.
+	ExtraProgramPages uint32 `codec:"apep"`
+}
@@ -0,0 +1,107 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// AssetAmount is an amount of an asset in base units, the units transactions
+// transfer, with the number of decimals of the asset. Its display units are
+// base units divided by 10^Decimals: 1234 base units of an asset with 2
+// decimals display as "12.34".
+type AssetAmount struct {
+	// BaseUnits is the amount in base units
+	BaseUnits uint64
+
+	// Decimals is the number of decimals of the asset, as in its AssetParams
+	Decimals uint32
+}
+
+// MakeAssetAmount returns the amount of baseUnits of an asset with the given
+// number of decimals, which must not exceed AssetMaxNumberOfDecimals
+func MakeAssetAmount(baseUnits uint64, decimals uint32) (AssetAmount, error) {
+	if decimals > AssetMaxNumberOfDecimals {
+		return AssetAmount{}, fmt.Errorf("asset decimals %d exceed the maximum of %d", decimals, AssetMaxNumberOfDecimals)
+	}
+	return AssetAmount{BaseUnits: baseUnits, Decimals: decimals}, nil
+}
+
+// ParseAssetAmount parses an amount in display units, such as "12.34", of an
+// asset with the given number of decimals. It is exact, and returns an error
+// if the amount has more decimal places than the asset or does not fit in a
+// uint64 of base units.
+func ParseAssetAmount(display string, decimals uint32) (AssetAmount, error) {
+	if decimals > AssetMaxNumberOfDecimals {
+		return AssetAmount{}, fmt.Errorf("asset decimals %d exceed the maximum of %d", decimals, AssetMaxNumberOfDecimals)
+	}
+	whole, frac := display, ""
+	if i := strings.IndexByte(display, '.'); i >= 0 {
+		whole, frac = display[:i], display[i+1:]
+	}
+	if whole == "" && frac == "" {
+		return AssetAmount{}, fmt.Errorf("invalid asset amount %q", display)
+	}
+	for _, part := range []string{whole, frac} {
+		if strings.TrimLeft(part, "0123456789") != "" {
+			return AssetAmount{}, fmt.Errorf("invalid asset amount %q", display)
+		}
+	}
+	// trailing zeros are allowed past the asset's decimals
+	frac = strings.TrimRight(frac, "0")
+	if uint32(len(frac)) > decimals {
+		return AssetAmount{}, fmt.Errorf("asset amount %q has more than %d decimal places", display, decimals)
+	}
+
+	digits := whole + frac + strings.Repeat("0", int(decimals)-len(frac))
+	baseUnits, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return AssetAmount{}, fmt.Errorf("invalid asset amount %q", display)
+	}
+	if !baseUnits.IsUint64() {
+		return AssetAmount{}, fmt.Errorf("asset amount %q is too large", display)
+	}
+	return AssetAmount{BaseUnits: baseUnits.Uint64(), Decimals: decimals}, nil
+}
+
+// AssetAmountFromRat returns the amount of an asset with the given number of
+// decimals whose display units are r, which must be a non-negative multiple
+// of 10^-decimals that fits in a uint64 of base units
+func AssetAmountFromRat(r *big.Rat, decimals uint32) (AssetAmount, error) {
+	if decimals > AssetMaxNumberOfDecimals {
+		return AssetAmount{}, fmt.Errorf("asset decimals %d exceed the maximum of %d", decimals, AssetMaxNumberOfDecimals)
+	}
+	if r.Sign() < 0 {
+		return AssetAmount{}, fmt.Errorf("asset amount %s is negative", r.RatString())
+	}
+	scaled := new(big.Rat).Mul(r, new(big.Rat).SetInt(pow10(decimals)))
+	if !scaled.IsInt() {
+		return AssetAmount{}, fmt.Errorf("asset amount %s has more than %d decimal places", r.RatString(), decimals)
+	}
+	if !scaled.Num().IsUint64() {
+		return AssetAmount{}, fmt.Errorf("asset amount %s is too large", r.RatString())
+	}
+	return AssetAmount{BaseUnits: scaled.Num().Uint64(), Decimals: decimals}, nil
+}
+
+// Rat returns the amount in display units
+func (a AssetAmount) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(new(big.Int).SetUint64(a.BaseUnits), pow10(a.Decimals))
+}
+
+// String formats the amount in display units, exactly and without trailing
+// zeros, such as "12.34" for 1234 base units of an asset with 2 decimals
+func (a AssetAmount) String() string {
+	whole, frac := new(big.Int).QuoRem(new(big.Int).SetUint64(a.BaseUnits), pow10(a.Decimals), new(big.Int))
+	if frac.Sign() == 0 {
+		return whole.String()
+	}
+	fracText := frac.String()
+	fracText = strings.Repeat("0", int(a.Decimals)-len(fracText)) + fracText
+	return whole.String() + "." + strings.TrimRight(fracText, "0")
+}
+
+// pow10 returns 10^n
+func pow10(n uint32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -1,7 +1,10 @@
 package types
 
 import (
+	"fmt"
 	"math"
+	"strconv"
+	"strings"
 
 	"golang.org/x/crypto/ed25519"
 )
@@ -20,13 +23,28 @@ const (
 	AssetTransferTx TxType = "axfer"
 	// AssetFreezeTx changes the freeze status of an asset
 	AssetFreezeTx TxType = "afrz"
+	// ApplicationCallTx allows creating, deleting, and interacting with an application
+	ApplicationCallTx TxType = "appl"
+	// StateProofTx records a state proof; the network issues these itself
+	StateProofTx TxType = "stpf"
+	// HeartbeatTx shows that an online account is still participating
+	HeartbeatTx TxType = "hb"
 )
 
 const masterDerivationKeyLenBytes = 32
 
+const merkleVerifierLenBytes = 64
+
 // MaxTxGroupSize is max number of transactions in a single group
 const MaxTxGroupSize = 16
 
+// MaxTxnBytesPerBlock is the max total length of the encoded transactions of a
+// block; the transactions of a group are committed in a single block
+const MaxTxnBytesPerBlock = 1000000
+
+// MaxTxnNoteBytes is the max length of the note field of a transaction
+const MaxTxnNoteBytes = 1024
+
 // LogicSigMaxSize is a max TEAL program size (with args)
 const LogicSigMaxSize = 1000
 
@@ -45,6 +63,9 @@ type VotePK [ed25519.PublicKeySize]byte
 // VRFPK is the VRF public key used in key registration transactions
 type VRFPK [ed25519.PublicKeySize]byte
 
+// MerkleVerifier is the root of the state proof keys, used in key registration transactions
+type MerkleVerifier [merkleVerifierLenBytes]byte
+
 // MasterDerivationKey is the secret key used to derive keys in wallets
 type MasterDerivationKey [masterDerivationKeyLenBytes]byte
 
@@ -53,6 +74,9 @@ type Digest [hashLenBytes]byte
 
 const microAlgoConversionFactor = 1e6
 
+// microAlgoDecimals is the number of decimal places of an amount in Algos
+const microAlgoDecimals = 6
+
 // ToAlgos converts amount in microAlgos to Algos
 func (microalgos MicroAlgos) ToAlgos() float64 {
 	return float64(microalgos) / microAlgoConversionFactor
@@ -62,3 +86,47 @@ func (microalgos MicroAlgos) ToAlgos() float64 {
 func ToMicroAlgos(algos float64) MicroAlgos {
 	return MicroAlgos(math.Round(algos * microAlgoConversionFactor))
 }
+
+// FromAlgos parses a decimal amount of Algos, such as "1.5", into microAlgos.
+// Unlike ToMicroAlgos it does not go through a float64, so it is exact; it
+// returns an error if the amount has more than six decimal places or does not
+// fit in a uint64.
+func FromAlgos(algos string) (MicroAlgos, error) {
+	whole, frac := algos, ""
+	if i := strings.IndexByte(algos, '.'); i >= 0 {
+		whole, frac = algos[:i], algos[i+1:]
+	}
+	if whole == "" && frac == "" {
+		return 0, fmt.Errorf("invalid amount of Algos %q", algos)
+	}
+	if len(frac) > microAlgoDecimals {
+		return 0, fmt.Errorf("amount of Algos %q has more than %d decimal places", algos, microAlgoDecimals)
+	}
+	for _, part := range []string{whole, frac} {
+		if strings.TrimLeft(part, "0123456789") != "" {
+			return 0, fmt.Errorf("invalid amount of Algos %q", algos)
+		}
+	}
+
+	microalgos := strings.TrimLeft(whole+frac+strings.Repeat("0", microAlgoDecimals-len(frac)), "0")
+	if microalgos == "" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseUint(microalgos, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("amount of Algos %q is too large", algos)
+	}
+	return MicroAlgos(amount), nil
+}
+
+// FormatAlgos formats the amount in Algos, exactly and without trailing zeros,
+// such as "1.5" for 1500000 microAlgos
+func (microalgos MicroAlgos) FormatAlgos() string {
+	whole := uint64(microalgos) / microAlgoConversionFactor
+	frac := uint64(microalgos) % microAlgoConversionFactor
+	if frac == 0 {
+		return strconv.FormatUint(whole, 10)
+	}
+	fracText := strings.TrimRight(fmt.Sprintf("%06d", frac), "0")
+	return strconv.FormatUint(whole, 10) + "." + fracText
+}
@@ -0,0 +1,110 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const maxMicroAlgos = MicroAlgos(^uint64(0))
+
+func TestFromAlgos(t *testing.T) {
+	cases := map[string]MicroAlgos{
+		"0":                     0,
+		"0.000000":              0,
+		"1":                     1000000,
+		"1.5":                   1500000,
+		".5":                    500000,
+		"5.":                    5000000,
+		"0.000001":              1,
+		"10000000000":           10000000000000000,
+		"18446744073709.551615": maxMicroAlgos,
+		// 0.29 has no exact float64
+		"0.29":  290000,
+		"00012": 12000000,
+	}
+	for algos, expected := range cases {
+		microalgos, err := FromAlgos(algos)
+		require.NoError(t, err, algos)
+		require.Equal(t, expected, microalgos, algos)
+	}
+
+	for _, algos := range []string{"", ".", "1.0000001", "-1", "1e6", "1,5", " 1", "1.2.3", "18446744073709.551616"} {
+		_, err := FromAlgos(algos)
+		require.Error(t, err, algos)
+	}
+}
+
+func TestFormatAlgos(t *testing.T) {
+	cases := map[MicroAlgos]string{
+		0:             "0",
+		1:             "0.000001",
+		1500000:       "1.5",
+		1000000:       "1",
+		123456789:     "123.456789",
+		290000:        "0.29",
+		maxMicroAlgos: "18446744073709.551615",
+		1000000010:    "1000.00001",
+	}
+	for microalgos, expected := range cases {
+		require.Equal(t, expected, microalgos.FormatAlgos())
+		parsed, err := FromAlgos(expected)
+		require.NoError(t, err)
+		require.Equal(t, microalgos, parsed)
+	}
+}
+
+func TestAssetAmount(t *testing.T) {
+	cases := []struct {
+		display   string
+		decimals  uint32
+		baseUnits uint64
+	}{
+		{"0", 0, 0},
+		{"12", 0, 12},
+		{"12.34", 2, 1234},
+		{"0.01", 2, 1},
+		{"1.5", 6, 1500000},
+		{"18446744073709551615", 0, ^uint64(0)},
+		{"1.8446744073709551615", AssetMaxNumberOfDecimals, ^uint64(0)},
+		{"0.0000000000000000001", AssetMaxNumberOfDecimals, 1},
+	}
+	for _, c := range cases {
+		amount, err := ParseAssetAmount(c.display, c.decimals)
+		require.NoError(t, err, c.display)
+		require.Equal(t, AssetAmount{BaseUnits: c.baseUnits, Decimals: c.decimals}, amount, c.display)
+		require.Equal(t, c.display, amount.String())
+
+		fromRat, err := AssetAmountFromRat(amount.Rat(), c.decimals)
+		require.NoError(t, err, c.display)
+		require.Equal(t, amount, fromRat)
+	}
+
+	// trailing zeros past the asset's decimals are allowed
+	amount, err := ParseAssetAmount("12.3400", 2)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1234), amount.BaseUnits)
+	require.Equal(t, "12.34", amount.String())
+
+	for _, display := range []string{"", ".", "12.345", "-1", "1e2", " 1", "1.2.3", "18446744073709551616"} {
+		_, err := ParseAssetAmount(display, 2)
+		require.Error(t, err, display)
+	}
+	_, err = ParseAssetAmount("1.5", 0)
+	require.Error(t, err)
+	_, err = ParseAssetAmount("1", AssetMaxNumberOfDecimals+1)
+	require.Error(t, err)
+	_, err = MakeAssetAmount(1, AssetMaxNumberOfDecimals+1)
+	require.Error(t, err)
+	_, err = ParseAssetAmount("2", AssetMaxNumberOfDecimals)
+	require.Error(t, err)
+
+	half, err := AssetAmountFromRat(big.NewRat(1, 2), 1)
+	require.NoError(t, err)
+	require.Equal(t, "0.5", half.String())
+	_, err = AssetAmountFromRat(big.NewRat(1, 3), AssetMaxNumberOfDecimals)
+	require.Error(t, err)
+	_, err = AssetAmountFromRat(big.NewRat(-1, 2), 1)
+	require.Error(t, err)
+}
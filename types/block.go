@@ -0,0 +1,269 @@
+package types
+
+// BlockHash is the hash of a block header
+type BlockHash Digest
+
+// Seed is the sortition seed of a block
+type Seed [32]byte
+
+// TxnCommitments are the commitments to the transactions of a block
+type TxnCommitments struct {
+	// NativeSha512_256Commitment is the root of the Merkle tree of the
+	// block's transactions, hashed with SHA-512/256
+	NativeSha512_256Commitment Digest `codec:"txn"`
+	// Sha256Commitment is the root of the Merkle tree of the block's
+	// transactions, hashed with SHA-256
+	Sha256Commitment Digest `codec:"txn256"`
+}
+
+// RewardsState is the state of the rewards pool as of a block
+type RewardsState struct {
+	// FeeSink is the account fees are paid to
+	FeeSink Address `codec:"fees"`
+	// RewardsPool is the account rewards are paid from
+	RewardsPool Address `codec:"rwd"`
+	// RewardsLevel is the number of microAlgos of rewards earned so far by
+	// each whole Algo held since genesis
+	RewardsLevel uint64 `codec:"earn"`
+	// RewardsRate is the number of microAlgos added to the rewards pool
+	// each round
+	RewardsRate uint64 `codec:"rate"`
+	// RewardsResidue is the number of leftover microAlgos of rewards, which
+	// did not divide evenly between the whole Algos
+	RewardsResidue uint64 `codec:"frac"`
+	// RewardsRecalculationRound is the round at which RewardsRate is next
+	// recalculated
+	RewardsRecalculationRound Round `codec:"rwcalr"`
+}
+
+// UpgradeState is the state of protocol upgrades as of a block
+type UpgradeState struct {
+	CurrentProtocol        string `codec:"proto"`
+	NextProtocol           string `codec:"nextproto"`
+	NextProtocolApprovals  uint64 `codec:"nextyes"`
+	NextProtocolVoteBefore Round  `codec:"nextbefore"`
+	NextProtocolSwitchOn   Round  `codec:"nextswitch"`
+}
+
+// UpgradeVote is the vote of a block's proposer on protocol upgrades
+type UpgradeVote struct {
+	UpgradePropose string `codec:"upgradeprop"`
+	UpgradeDelay   Round  `codec:"upgradedelay"`
+	UpgradeApprove bool   `codec:"upgradeyes"`
+}
+
+// StateProofTrackingData tracks the state proofs of one type
+type StateProofTrackingData struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// StateProofVotersCommitment is the root of the Merkle tree of the
+	// voters for the next state proof
+	StateProofVotersCommitment GenericDigest `codec:"v"`
+	// StateProofOnlineTotalWeight is the total stake of the voters for the
+	// next state proof
+	StateProofOnlineTotalWeight MicroAlgos `codec:"t"`
+	// StateProofNextRound is the last round covered by the next state proof
+	StateProofNextRound Round `codec:"n"`
+}
+
+// ParticipationUpdates are the changes to accounts' participation made by a
+// block
+type ParticipationUpdates struct {
+	// ExpiredParticipationAccounts are the accounts whose participation
+	// keys expired, and which were taken offline
+	ExpiredParticipationAccounts []Address `codec:"partupdrmv"`
+	// AbsentParticipationAccounts are the accounts which were taken offline
+	// for not proposing blocks
+	AbsentParticipationAccounts []Address `codec:"partupdabs"`
+}
+
+// BlockHeader is the header of a block
+type BlockHeader struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Round Round `codec:"rnd"`
+	// Branch is the hash of the previous block's header
+	Branch BlockHash `codec:"prev"`
+	Seed   Seed      `codec:"seed"`
+	TxnCommitments
+	// TimeStamp is the time the block was proposed, in seconds since the
+	// Unix epoch
+	TimeStamp   int64  `codec:"ts"`
+	GenesisID   string `codec:"gen"`
+	GenesisHash Digest `codec:"gh"`
+	// Proposer is the account that proposed the block
+	Proposer Address `codec:"prp"`
+	// FeesCollected is the total of the fees of the block's transactions
+	FeesCollected MicroAlgos `codec:"fc"`
+	// Bonus is the bonus paid to the proposer on top of its share of fees
+	Bonus MicroAlgos `codec:"bi"`
+	// ProposerPayout is the amount paid to the proposer
+	ProposerPayout MicroAlgos `codec:"pp"`
+	RewardsState
+	UpgradeState
+	UpgradeVote
+	// TxnCounter is the number of transactions, including inner
+	// transactions, confirmed up to and including this block
+	TxnCounter         uint64                                    `codec:"tc"`
+	StateProofTracking map[StateProofType]StateProofTrackingData `codec:"spt"`
+	ParticipationUpdates
+}
+
+// ValueDelta is a change to one value of an application's state
+type ValueDelta struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Action DeltaAction `codec:"at"`
+	Bytes  string      `codec:"bs"`
+	Uint   uint64      `codec:"ui"`
+}
+
+// DeltaAction is the kind of change a ValueDelta makes
+type DeltaAction uint64
+
+const (
+	// SetBytesAction sets a bytes value
+	SetBytesAction DeltaAction = 1
+	// SetUintAction sets a uint value
+	SetUintAction DeltaAction = 2
+	// DeleteAction deletes a value
+	DeleteAction DeltaAction = 3
+)
+
+// StateDelta is the changes to an application's global or local state, by key
+type StateDelta map[string]ValueDelta
+
+// EvalDelta is the effects of an application call: the changes to state, the
+// logs and the inner transactions
+type EvalDelta struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	GlobalDelta StateDelta `codec:"gd"`
+	// LocalDeltas are the changes to local state, by the index of the
+	// account in the transaction's Accounts, 0 being the sender, or else in
+	// SharedAccts after them
+	LocalDeltas map[uint64]StateDelta `codec:"ld"`
+	// SharedAccts are the accounts whose local state changed which are not
+	// among the transaction's Accounts
+	SharedAccts []Address         `codec:"sa"`
+	Logs        []string          `codec:"lg"`
+	InnerTxns   []SignedTxnWithAD `codec:"itx"`
+}
+
+// ApplyData is the effects of a transaction which are not part of the
+// transaction itself
+type ApplyData struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// ClosingAmount is the amount sent to CloseRemainderTo
+	ClosingAmount MicroAlgos `codec:"ca"`
+	// AssetClosingAmount is the amount of the asset sent to AssetCloseTo
+	AssetClosingAmount uint64     `codec:"aca"`
+	SenderRewards      MicroAlgos `codec:"rs"`
+	ReceiverRewards    MicroAlgos `codec:"rr"`
+	CloseRewards       MicroAlgos `codec:"rc"`
+	EvalDelta          EvalDelta  `codec:"dt"`
+	// ConfigAsset is the ID of the asset created by the transaction, if any
+	ConfigAsset uint64 `codec:"caid"`
+	// ApplicationID is the ID of the application created by the
+	// transaction, if any
+	ApplicationID uint64 `codec:"apid"`
+}
+
+// SignedTxnWithAD is a signed transaction with its ApplyData
+type SignedTxnWithAD struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	SignedTxn
+	ApplyData
+}
+
+// SignedTxnInBlock is a signed transaction as it is stored in a block, with
+// its genesis ID and hash removed
+type SignedTxnInBlock struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	SignedTxnWithAD
+	// HasGenesisID is set if the transaction's GenesisID was the block's
+	HasGenesisID bool `codec:"hgi"`
+	// HasGenesisHash is set if the transaction's GenesisHash was the block's
+	HasGenesisHash bool `codec:"hgh"`
+}
+
+// Payset is the transactions of a block
+type Payset []SignedTxnInBlock
+
+// Block is a block of the chain
+type Block struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	BlockHeader
+	Payset Payset `codec:"txns"`
+}
+
+// Certificate is the agreement certificate of a block, left undecoded
+type Certificate map[string]interface{}
+
+// EncodedBlockCert is a block with its certificate, as returned by
+// algod.BlockRaw
+type EncodedBlockCert struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Block       Block       `codec:"block"`
+	Certificate Certificate `codec:"cert"`
+}
+
+// DecodeSignedTxn restores the genesis ID and hash of a transaction of the
+// block, so that it can be hashed or verified as it was signed
+func (bh BlockHeader) DecodeSignedTxn(stib SignedTxnInBlock) SignedTxnWithAD {
+	stad := stib.SignedTxnWithAD
+	if stib.HasGenesisID {
+		stad.Txn.GenesisID = bh.GenesisID
+	}
+	if stib.HasGenesisHash {
+		stad.Txn.GenesisHash = bh.GenesisHash
+	}
+	return stad
+}
+
+// SignedTxns returns the transactions of the block, with their genesis ID and
+// hash restored
+func (b Block) SignedTxns() []SignedTxnWithAD {
+	stads := make([]SignedTxnWithAD, len(b.Payset))
+	for i, stib := range b.Payset {
+		stads[i] = b.DecodeSignedTxn(stib)
+	}
+	return stads
+}
+
+// FlatTxn is a transaction of a tree of transactions and the inner
+// transactions they issued, flattened by Flatten
+type FlatTxn struct {
+	SignedTxnWithAD
+	// Parent is the position in the flattened list of the transaction that
+	// issued this one, or -1 if this is the root
+	Parent int
+	// Index is the position of this transaction in its parent's inner
+	// transactions, which with the parent's ID determines its ID
+	Index int
+}
+
+// Flatten returns the transaction followed by its inner transactions,
+// recursively, in the order they were executed: each inner transaction
+// follows its parent and its parent's earlier inner transactions and their
+// own inner transactions. The inner transactions of the returned
+// transactions are left in place.
+func (stad SignedTxnWithAD) Flatten() []FlatTxn {
+	flat := []FlatTxn{{SignedTxnWithAD: stad, Parent: -1}}
+	return appendInnerTxns(flat, 0)
+}
+
+// appendInnerTxns appends the inner transactions of flat[parent] to flat,
+// recursively
+func appendInnerTxns(flat []FlatTxn, parent int) []FlatTxn {
+	for i, inner := range flat[parent].EvalDelta.InnerTxns {
+		flat = append(flat, FlatTxn{SignedTxnWithAD: inner, Parent: parent, Index: i})
+		flat = appendInnerTxns(flat, len(flat)-1)
+	}
+	return flat
+}
@@ -0,0 +1,134 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+)
+
+func TestDecodeBlock(t *testing.T) {
+	sender := Address{1}
+	receiver := Address{2}
+	genesisHash := Digest{3}
+	inner := map[string]interface{}{
+		"txn": map[string]interface{}{"type": "axfer", "snd": sender[:], "arcv": receiver[:], "aamt": uint64(5), "xaid": uint64(10)},
+	}
+	// a block and certificate as algod encodes them, written out field by
+	// field so that the codec tags are checked
+	raw := msgpack.Encode(map[string]interface{}{
+		"block": map[string]interface{}{
+			"rnd":   uint64(100),
+			"gen":   "testnet-v1.0",
+			"gh":    genesisHash[:],
+			"ts":    int64(1600000000),
+			"proto": "future",
+			"tc":    uint64(42),
+			"fees":  sender[:],
+			"txn":   []byte{31: 1},
+			"spt":   map[uint64]interface{}{0: map[string]interface{}{"n": uint64(256)}},
+			"txns": []interface{}{
+				map[string]interface{}{
+					"hgi": true,
+					"hgh": true,
+					"sig": []byte{63: 1},
+					"txn": map[string]interface{}{"type": "appl", "snd": sender[:], "apid": uint64(10), "fee": uint64(2000)},
+					"dt": map[string]interface{}{
+						"gd":  map[string]interface{}{"count": map[string]interface{}{"at": uint64(2), "ui": uint64(7)}},
+						"ld":  map[uint64]interface{}{0: map[string]interface{}{"name": map[string]interface{}{"at": uint64(1), "bs": "x"}}},
+						"lg":  []string{"log"},
+						"itx": []interface{}{inner},
+					},
+				},
+				map[string]interface{}{
+					"sig": []byte{63: 2},
+					"txn": map[string]interface{}{"type": "pay", "snd": sender[:], "rcv": receiver[:], "close": receiver[:], "gen": "other"},
+					"ca":  uint64(99),
+					"rs":  uint64(1),
+				},
+			},
+		},
+		"cert": map[string]interface{}{"rnd": uint64(100)},
+	})
+
+	var ebc EncodedBlockCert
+	require.NoError(t, msgpack.Decode(raw, &ebc))
+	require.Equal(t, raw, msgpack.Encode(ebc))
+
+	block := ebc.Block
+	require.Equal(t, Round(100), block.Round)
+	require.Equal(t, "future", block.CurrentProtocol)
+	require.Equal(t, sender, block.FeeSink)
+	require.Equal(t, uint64(42), block.TxnCounter)
+	require.Equal(t, Digest{31: 1}, block.NativeSha512_256Commitment)
+	require.Equal(t, Round(256), block.StateProofTracking[StateProofBasic].StateProofNextRound)
+	require.NotEmpty(t, ebc.Certificate)
+	require.Len(t, block.Payset, 2)
+
+	appl := block.Payset[0]
+	require.Equal(t, ApplicationCallTx, appl.Txn.Type)
+	require.Empty(t, appl.Txn.GenesisID)
+	require.Equal(t, ValueDelta{Action: SetUintAction, Uint: 7}, appl.EvalDelta.GlobalDelta["count"])
+	require.Equal(t, ValueDelta{Action: SetBytesAction, Bytes: "x"}, appl.EvalDelta.LocalDeltas[0]["name"])
+	require.Equal(t, []string{"log"}, appl.EvalDelta.Logs)
+	require.Len(t, appl.EvalDelta.InnerTxns, 1)
+	require.Equal(t, AssetTransferTx, appl.EvalDelta.InnerTxns[0].Txn.Type)
+	require.Equal(t, receiver, appl.EvalDelta.InnerTxns[0].Txn.AssetReceiver)
+	pay := block.Payset[1]
+	require.Equal(t, MicroAlgos(99), pay.ClosingAmount)
+	require.Equal(t, MicroAlgos(1), pay.SenderRewards)
+
+	// the genesis fields are restored only where they were the block's
+	stads := block.SignedTxns()
+	require.Len(t, stads, 2)
+	require.Equal(t, "testnet-v1.0", stads[0].Txn.GenesisID)
+	require.Equal(t, genesisHash, stads[0].Txn.GenesisHash)
+	require.Equal(t, appl.EvalDelta, stads[0].EvalDelta)
+	require.Equal(t, "other", stads[1].Txn.GenesisID)
+	require.Equal(t, Digest{}, stads[1].Txn.GenesisHash)
+
+	// JSON keeps the ApplyData and block flags alongside the signed transaction
+	encoded, err := json.Marshal(appl)
+	require.NoError(t, err)
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(encoded, &fields))
+	require.Contains(t, fields, "txn")
+	require.Contains(t, fields, "dt")
+	require.Contains(t, fields, "hgi")
+	var decoded SignedTxnInBlock
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, msgpack.Encode(appl), msgpack.Encode(decoded))
+
+	encoded, err = json.Marshal(stads[1])
+	require.NoError(t, err)
+	var decodedAD SignedTxnWithAD
+	require.NoError(t, json.Unmarshal(encoded, &decodedAD))
+	require.Equal(t, stads[1], decodedAD)
+}
+
+func TestFlatten(t *testing.T) {
+	txn := func(note string, inner ...SignedTxnWithAD) SignedTxnWithAD {
+		var stad SignedTxnWithAD
+		stad.Txn.Note = []byte(note)
+		stad.EvalDelta.InnerTxns = inner
+		return stad
+	}
+	root := txn("root", txn("a", txn("a0"), txn("a1", txn("a10"))), txn("b"))
+
+	flat := root.Flatten()
+	var notes []string
+	var parents, indexes []int
+	for _, ftx := range flat {
+		notes = append(notes, string(ftx.Txn.Note))
+		parents = append(parents, ftx.Parent)
+		indexes = append(indexes, ftx.Index)
+	}
+	require.Equal(t, []string{"root", "a", "a0", "a1", "a10", "b"}, notes)
+	require.Equal(t, []int{-1, 0, 1, 1, 3, 0}, parents)
+	require.Equal(t, []int{0, 0, 0, 1, 0, 1}, indexes)
+	require.Len(t, flat[1].EvalDelta.InnerTxns, 2)
+
+	require.Len(t, txn("alone").Flatten(), 1)
+}
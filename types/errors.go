@@ -1,8 +1,25 @@
 package types
 
 import (
+	"errors"
 	"fmt"
 )
 
-var errWrongAddressLen = fmt.Errorf("decoded address is the wrong length, should be %d bytes", hashLenBytes+checksumLenBytes)
-var errWrongChecksum = fmt.Errorf("address checksum is incorrect, did you copy the address correctly?")
+// ErrBadAddress is the error malformed addresses are rejected with, wrapped
+// by errors saying why the address is malformed, e.g. those of DecodeAddress
+var ErrBadAddress = errors.New("invalid address")
+
+// badAddressError says why an address is malformed, and unwraps to
+// ErrBadAddress
+type badAddressError string
+
+func (e badAddressError) Error() string {
+	return string(e)
+}
+
+func (e badAddressError) Unwrap() error {
+	return ErrBadAddress
+}
+
+var errWrongAddressLen = badAddressError(fmt.Sprintf("decoded address is the wrong length, should be %d bytes", hashLenBytes+checksumLenBytes))
+var errWrongChecksum = badAddressError("address checksum is incorrect, did you copy the address correctly?")
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+
+	"github.com/algorand/go-codec/codec"
+)
+
+// Genesis IDs and hashes of the public networks
+const (
+	MainNetGenesisID   = "mainnet-v1.0"
+	MainNetGenesisHash = "wGHE2Pwdvd7S12BL5FaOP20EGYesN73ktiC1qzkkit8="
+	TestNetGenesisID   = "testnet-v1.0"
+	TestNetGenesisHash = "SGO1GKSzyE7IEPItTxCByw9x8FmnrCDexi9/cOUJOiI="
+	BetaNetGenesisID   = "betanet-v1.0"
+	BetaNetGenesisHash = "mFgazF+2uRS1tMiL9dsj01hJGySEmPN28B/TjjvpVW0="
+)
+
+// genesisHashPrefix is prepended to the msgpack encoding of a genesis to hash
+// it, as "TX" is to a transaction
+var genesisHashPrefix = []byte("GE")
+
+// msgpackHandle encodes as the msgpack package does, which imports this one
+var msgpackHandle = &codec.MsgpackHandle{}
+
+func init() {
+	msgpackHandle.Canonical = true
+	msgpackHandle.RecursiveEmptyCheck = true
+	msgpackHandle.WriteExt = true
+	msgpackHandle.PositiveIntUnsigned = true
+}
+
+// Genesis is the genesis of a network, as in the genesis.json files of nodes:
+// the initial state of its ledger
+type Genesis struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// SchemaID is the version of the network's genesis, e.g. "v1.0"
+	SchemaID string `codec:"id"`
+
+	// Network is the name of the network, e.g. "mainnet"
+	Network string `codec:"network"`
+
+	// Proto is the consensus version of the first round
+	Proto string `codec:"proto"`
+
+	// Allocation are the accounts holding Algos in the first round
+	Allocation []GenesisAllocation `codec:"alloc"`
+
+	// RewardsPool is the address of the rewards pool
+	RewardsPool string `codec:"rwd"`
+
+	// FeeSink is the address of the fee sink
+	FeeSink string `codec:"fees"`
+
+	// Timestamp is the time of the first round, in seconds since the epoch
+	Timestamp int64 `codec:"timestamp"`
+
+	// Comment is a note on the genesis, purely informational
+	Comment string `codec:"comment"`
+
+	// DevMode makes the network's nodes make a block for each transaction
+	// instead of following the consensus protocol
+	DevMode bool `codec:"devmode"`
+}
+
+// GenesisAllocation is an account holding Algos in the first round of a
+// network
+type GenesisAllocation struct {
+	// the fields of allocations are not omitted when empty, to keep genesis
+	// hashes as go-algorand computes them
+	_struct struct{} `codec:""`
+
+	// Address is the address of the account
+	Address string `codec:"addr"`
+
+	// Comment is a note on the account, purely informational
+	Comment string `codec:"comment"`
+
+	// State is the state of the account
+	State GenesisAccountData `codec:"state"`
+}
+
+// GenesisAccountData is the state of an account in the first round of a
+// network
+type GenesisAccountData struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Status          Status         `codec:"onl"`
+	MicroAlgos      MicroAlgos     `codec:"algo"`
+	VoteID          VotePK         `codec:"vote"`
+	StateProofID    MerkleVerifier `codec:"stprf"`
+	SelectionID     VRFPK          `codec:"sel"`
+	VoteFirstValid  Round          `codec:"voteFst"`
+	VoteLastValid   Round          `codec:"voteLst"`
+	VoteKeyDilution uint64         `codec:"voteKD"`
+}
+
+// DecodeGenesis decodes a genesis.json file, such as those of the go-algorand
+// repository or of a node's data directory, or the response of algod's
+// GetGenesis request. Unknown fields are an error, since Hash would not hash
+// them.
+func DecodeGenesis(data []byte) (Genesis, error) {
+	var genesis Genesis
+	err := decodeJSON(data, &genesis)
+	return genesis, err
+}
+
+// ID returns the genesis ID of the network, e.g. "mainnet-v1.0", which
+// transactions set as their GenesisID
+func (g Genesis) ID() string {
+	return g.Network + "-" + g.SchemaID
+}
+
+// Hash returns the genesis hash of the network, which transactions set as
+// their GenesisHash
+func (g Genesis) Hash() Digest {
+	var encoded []byte
+	codec.NewEncoderBytes(&encoded, msgpackHandle).MustEncode(g)
+	return sha512.Sum512_256(append(append([]byte{}, genesisHashPrefix...), encoded...))
+}
+
+// Network identifies one of the public Algorand networks
+type Network int
+
+// The public networks
+const (
+	// UnknownNetwork is any other network, such as a private network
+	UnknownNetwork Network = iota
+	MainNet
+	TestNet
+	BetaNet
+)
+
+// networkGenesis are the genesis IDs and hashes of the public networks
+var networkGenesis = map[Network]struct{ id, hash string }{
+	MainNet: {MainNetGenesisID, MainNetGenesisHash},
+	TestNet: {TestNetGenesisID, TestNetGenesisHash},
+	BetaNet: {BetaNetGenesisID, BetaNetGenesisHash},
+}
+
+// String returns the name of the network, e.g. "mainnet"
+func (n Network) String() string {
+	switch n {
+	case MainNet:
+		return "mainnet"
+	case TestNet:
+		return "testnet"
+	case BetaNet:
+		return "betanet"
+	}
+	return "unknown"
+}
+
+// GenesisID returns the genesis ID of the network, or "" for UnknownNetwork
+func (n Network) GenesisID() string {
+	return networkGenesis[n].id
+}
+
+// GenesisHash returns the genesis hash of the network, or the zero Digest for
+// UnknownNetwork
+func (n Network) GenesisHash() (hash Digest) {
+	if genesis, ok := networkGenesis[n]; ok {
+		decoded, _ := base64.StdEncoding.DecodeString(genesis.hash)
+		copy(hash[:], decoded)
+	}
+	return
+}
+
+// NetworkFromGenesis identifies the public network with the given genesis
+// hash, and genesis ID unless it is "", since transactions may omit it. It
+// returns UnknownNetwork if the hash and ID are of no public network, or
+// mismatch.
+func NetworkFromGenesis(genesisID string, genesisHash []byte) Network {
+	for n := range networkGenesis {
+		hash := n.GenesisHash()
+		if bytes.Equal(genesisHash, hash[:]) && (genesisID == "" || genesisID == n.GenesisID()) {
+			return n
+		}
+	}
+	return UnknownNetwork
+}
+
+// Network returns the public network the transaction can be confirmed on, or
+// UnknownNetwork
+func (tx Transaction) Network() Network {
+	return NetworkFromGenesis(tx.GenesisID, tx.GenesisHash[:])
+}
+
+// Network returns the public network of the parameters, or UnknownNetwork
+func (sp SuggestedParams) Network() Network {
+	return NetworkFromGenesis(sp.GenesisID, sp.GenesisHash)
+}
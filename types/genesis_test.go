@@ -0,0 +1,101 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+)
+
+const testGenesis = `{
+  "alloc": [
+    {
+      "addr": "7777777777777777777777777777777777777777777777777774MSJUVU",
+      "comment": "RewardsPool",
+      "state": {"algo": 125000000000000, "onl": 2}
+    },
+    {
+      "addr": "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU",
+      "comment": "",
+      "state": {
+        "algo": 5000000000000000,
+        "onl": 1,
+        "sel": "bPgrv4YogPcdaUAxrt1QysYZTVyRAuUMD4zQmCu9llc=",
+        "vote": "Kv7QI7chi1y6axoy+t7wzAVpePqRq/rkjzWh/RMYyLo=",
+        "voteKD": 10000,
+        "voteLst": 3000000
+      }
+    }
+  ],
+  "fees": "A7NMWS3NT3IUDMLVO26ULGXGIIOUQ3ND2TXSER6EBGRZNOBOUIQXHIBGDE",
+  "id": "v1",
+  "network": "devnet",
+  "proto": "future",
+  "rwd": "7777777777777777777777777777777777777777777777777774MSJUVU",
+  "timestamp": 1600000000
+}`
+
+func TestGenesis(t *testing.T) {
+	genesis, err := DecodeGenesis([]byte(testGenesis))
+	require.NoError(t, err)
+	require.Equal(t, "devnet-v1", genesis.ID())
+	require.Equal(t, "future", genesis.Proto)
+	require.Equal(t, int64(1600000000), genesis.Timestamp)
+	require.Len(t, genesis.Allocation, 2)
+	require.Equal(t, "RewardsPool", genesis.Allocation[0].Comment)
+	require.Equal(t, NotParticipating, genesis.Allocation[0].State.Status)
+	state := genesis.Allocation[1].State
+	require.Equal(t, Online, state.Status)
+	require.Equal(t, MicroAlgos(5000000000000000), state.MicroAlgos)
+	require.Equal(t, Round(3000000), state.VoteLastValid)
+	require.Equal(t, "Kv7QI7chi1y6axoy+t7wzAVpePqRq/rkjzWh/RMYyLo=", base64.StdEncoding.EncodeToString(state.VoteID[:]))
+
+	// the hash is of the msgpack encoding, in which the fields of allocations
+	// are not omitted even when empty
+	encoded := msgpack.Encode(genesis)
+	require.Equal(t, 2, bytes.Count(encoded, []byte("comment")))
+	require.Equal(t, Digest(sha512.Sum512_256(append([]byte("GE"), encoded...))), genesis.Hash())
+	reencoded, err := encodeJSON(genesis)
+	require.NoError(t, err)
+	decoded, err := DecodeGenesis(reencoded)
+	require.NoError(t, err)
+	require.Equal(t, genesis.Hash(), decoded.Hash())
+	genesis.Timestamp++
+	require.NotEqual(t, decoded.Hash(), genesis.Hash())
+
+	// unknown fields would not be hashed
+	_, err = DecodeGenesis([]byte(`{"id":"v1","network":"devnet","unknown":1}`))
+	require.Error(t, err)
+}
+
+func TestNetwork(t *testing.T) {
+	for _, n := range []Network{MainNet, TestNet, BetaNet} {
+		hash := n.GenesisHash()
+		require.Equal(t, n, NetworkFromGenesis(n.GenesisID(), hash[:]), n.String())
+		require.Equal(t, n, NetworkFromGenesis("", hash[:]), n.String())
+		require.Equal(t, UnknownNetwork, NetworkFromGenesis("devnet-v1", hash[:]), n.String())
+		require.Equal(t, n.String()+"-v1.0", n.GenesisID())
+	}
+	mainNetHash := MainNet.GenesisHash()
+	require.Equal(t, MainNetGenesisHash, base64.StdEncoding.EncodeToString(mainNetHash[:]))
+	require.Equal(t, "unknown", UnknownNetwork.String())
+	require.Equal(t, "", UnknownNetwork.GenesisID())
+	require.Equal(t, UnknownNetwork, NetworkFromGenesis("", nil))
+
+	var tx Transaction
+	tx.GenesisID = TestNetGenesisID
+	tx.GenesisHash = TestNet.GenesisHash()
+	require.Equal(t, TestNet, tx.Network())
+	tx.GenesisHash = MainNet.GenesisHash()
+	require.Equal(t, UnknownNetwork, tx.Network())
+	tx.GenesisID = ""
+	require.Equal(t, MainNet, tx.Network())
+
+	betaNetHash := BetaNet.GenesisHash()
+	sp := SuggestedParams{GenesisID: BetaNetGenesisID, GenesisHash: betaNetHash[:]}
+	require.Equal(t, BetaNet, sp.Network())
+}
@@ -0,0 +1,30 @@
+package types
+
+// HeartbeatProof is a signature of a heartbeat by an account's participation
+// key, with the chain of signatures from the key's root
+type HeartbeatProof struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Sig    [64]byte `codec:"s"`
+	PK     [32]byte `codec:"p"`
+	PK2    [32]byte `codec:"p2"`
+	PK1Sig [64]byte `codec:"p1s"`
+	PK2Sig [64]byte `codec:"p2s"`
+}
+
+// HeartbeatTxnFields captures the fields used by heartbeat transactions, which
+// show that an online account is still participating
+type HeartbeatTxnFields struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// HbAddress is the account this heartbeat is for
+	HbAddress Address `codec:"hbad"`
+	// HbProof is the account's signature of HbSeed
+	HbProof HeartbeatProof `codec:"hbprf"`
+	// HbSeed is the block seed of the round before the heartbeat's FirstValid
+	HbSeed [32]byte `codec:"hbsd"`
+	// HbVoteID is the account's vote key
+	HbVoteID VotePK `codec:"hbvid"`
+	// HbKeyDilution is the dilution of the account's participation key
+	HbKeyDilution uint64 `codec:"hbkd"`
+}
@@ -0,0 +1,137 @@
+package types
+
+import (
+	"github.com/algorand/go-codec/codec"
+)
+
+// jsonHandle encodes and decodes transactions in JSON the way algod's API
+// does: with the msgpack field names, zero values omitted, byte slices in
+// base64, addresses as checksum address strings and integer map keys as strings
+var jsonHandle *codec.JsonHandle
+
+func init() {
+	jsonHandle = new(codec.JsonHandle)
+	jsonHandle.ErrorIfNoField = true
+	jsonHandle.ErrorIfNoArrayExpand = true
+	jsonHandle.Canonical = true
+	jsonHandle.RecursiveEmptyCheck = true
+	jsonHandle.HTMLCharsAsIs = true
+	jsonHandle.MapKeyAsString = true
+}
+
+func encodeJSON(obj interface{}) ([]byte, error) {
+	var b []byte
+	enc := codec.NewEncoderBytes(&b, jsonHandle)
+	err := enc.Encode(obj)
+	return b, err
+}
+
+func decodeJSON(b []byte, objptr interface{}) error {
+	dec := codec.NewDecoderBytes(b, jsonHandle)
+	return dec.Decode(objptr)
+}
+
+// MarshalJSON encodes the transaction as algod's API does
+func (tx Transaction) MarshalJSON() ([]byte, error) {
+	type transaction Transaction
+	return encodeJSON(transaction(tx))
+}
+
+// UnmarshalJSON decodes a transaction encoded as algod's API does
+func (tx *Transaction) UnmarshalJSON(data []byte) error {
+	type transaction Transaction
+	return decodeJSON(data, (*transaction)(tx))
+}
+
+// signedTxn, signedTxnWithAD and signedTxnInBlock have the fields of
+// SignedTxn, SignedTxnWithAD and SignedTxnInBlock but not their JSON methods.
+// Types embedding SignedTxn would otherwise be encoded by its MarshalJSON,
+// without their own fields.
+type signedTxn SignedTxn
+
+type signedTxnWithAD struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	signedTxn
+	ApplyData
+}
+
+type signedTxnInBlock struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	signedTxnWithAD
+	HasGenesisID   bool `codec:"hgi"`
+	HasGenesisHash bool `codec:"hgh"`
+}
+
+func (stad SignedTxnWithAD) toJSON() signedTxnWithAD {
+	return signedTxnWithAD{signedTxn: signedTxn(stad.SignedTxn), ApplyData: stad.ApplyData}
+}
+
+func (stad signedTxnWithAD) fromJSON() SignedTxnWithAD {
+	return SignedTxnWithAD{SignedTxn: SignedTxn(stad.signedTxn), ApplyData: stad.ApplyData}
+}
+
+// MarshalJSON encodes the signed transaction as algod's API does
+func (stx SignedTxn) MarshalJSON() ([]byte, error) {
+	return encodeJSON(signedTxn(stx))
+}
+
+// UnmarshalJSON decodes a signed transaction encoded as algod's API does
+func (stx *SignedTxn) UnmarshalJSON(data []byte) error {
+	return decodeJSON(data, (*signedTxn)(stx))
+}
+
+// MarshalJSON encodes the signed transaction and its ApplyData as algod's API
+// does
+func (stad SignedTxnWithAD) MarshalJSON() ([]byte, error) {
+	return encodeJSON(stad.toJSON())
+}
+
+// UnmarshalJSON decodes a signed transaction and its ApplyData encoded as
+// algod's API does
+func (stad *SignedTxnWithAD) UnmarshalJSON(data []byte) error {
+	var decoded signedTxnWithAD
+	if err := decodeJSON(data, &decoded); err != nil {
+		return err
+	}
+	*stad = decoded.fromJSON()
+	return nil
+}
+
+// MarshalJSON encodes the transaction of a block as algod's API does
+func (stib SignedTxnInBlock) MarshalJSON() ([]byte, error) {
+	return encodeJSON(signedTxnInBlock{
+		signedTxnWithAD: stib.SignedTxnWithAD.toJSON(),
+		HasGenesisID:    stib.HasGenesisID,
+		HasGenesisHash:  stib.HasGenesisHash,
+	})
+}
+
+// UnmarshalJSON decodes a transaction of a block encoded as algod's API does
+func (stib *SignedTxnInBlock) UnmarshalJSON(data []byte) error {
+	var decoded signedTxnInBlock
+	if err := decodeJSON(data, &decoded); err != nil {
+		return err
+	}
+	*stib = SignedTxnInBlock{
+		SignedTxnWithAD: decoded.signedTxnWithAD.fromJSON(),
+		HasGenesisID:    decoded.HasGenesisID,
+		HasGenesisHash:  decoded.HasGenesisHash,
+	}
+	return nil
+}
+
+// MarshalJSON encodes the state delta as algod's API does, but without its
+// Txleases, whose keys are structs that cannot be JSON object keys
+func (sd LedgerStateDelta) MarshalJSON() ([]byte, error) {
+	type ledgerStateDelta LedgerStateDelta
+	sd.Txleases = nil
+	return encodeJSON(ledgerStateDelta(sd))
+}
+
+// UnmarshalJSON decodes a state delta encoded as algod's API does
+func (sd *LedgerStateDelta) UnmarshalJSON(b []byte) error {
+	type ledgerStateDelta LedgerStateDelta
+	return decodeJSON(b, (*ledgerStateDelta)(sd))
+}
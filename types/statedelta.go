@@ -0,0 +1,306 @@
+package types
+
+// Status is the participation status of an account
+type Status byte
+
+// The participation statuses of accounts
+const (
+	// Offline accounts earn rewards but do not participate in consensus
+	Offline Status = 0
+	// Online accounts participate in consensus with their participation keys
+	Online Status = 1
+	// NotParticipating accounts neither participate nor earn rewards
+	NotParticipating Status = 2
+)
+
+// LedgerStateDelta is the changes a block made to the ledger, as returned by
+// algod's GetLedgerStateDelta in follower mode
+type LedgerStateDelta struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// Accts are the changes to accounts
+	Accts AccountDeltas `codec:"Accts"`
+
+	// KvMods are the changes to the key-value store, such as box contents,
+	// by key
+	KvMods map[string]KvValueDelta `codec:"KvMods"`
+
+	// Txids are the transactions of the block, by ID
+	Txids map[Digest]IncludedTransactions `codec:"Txids"`
+
+	// Txleases are the leases taken by the transactions of the block, with
+	// the last round each is held for
+	Txleases map[Txlease]Round `codec:"Txleases"`
+
+	// Creatables are the assets and applications the block created or
+	// deleted, by ID
+	Creatables map[uint64]ModifiedCreatable `codec:"Creatables"`
+
+	// Hdr is the header of the block
+	Hdr *BlockHeader `codec:"Hdr"`
+
+	// StateProofNext is the next round a state proof is expected for, if
+	// the block changed it
+	StateProofNext Round `codec:"StateProofNext"`
+
+	// PrevTimestamp is the timestamp of the previous block
+	PrevTimestamp int64 `codec:"PrevTimestamp"`
+
+	// Totals are the ledger's account totals after the block
+	Totals AccountTotals `codec:"Totals"`
+}
+
+// AccountDeltas are the changes a block made to accounts
+type AccountDeltas struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// Accts are the new base data of the accounts the block changed
+	Accts []BalanceRecord `codec:"Accts"`
+
+	// AppResources are the changes to the applications accounts created or
+	// opted in to
+	AppResources []AppResourceRecord `codec:"AppResources"`
+
+	// AssetResources are the changes to the assets accounts created or
+	// opted in to
+	AssetResources []AssetResourceRecord `codec:"AssetResources"`
+}
+
+// BalanceRecord is the base data of an account
+type BalanceRecord struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// Addr is the account's address
+	Addr Address `codec:"Addr"`
+
+	AccountData
+}
+
+// AccountData is the data of an account other than its applications and
+// assets, which state deltas record separately
+type AccountData struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	AccountBaseData
+	VotingData
+}
+
+// AccountBaseData is an account's balance, rewards, authorization and the
+// totals of its applications and assets
+type AccountBaseData struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Status             Status     `codec:"Status"`
+	MicroAlgos         MicroAlgos `codec:"MicroAlgos"`
+	RewardsBase        uint64     `codec:"RewardsBase"`
+	RewardedMicroAlgos MicroAlgos `codec:"RewardedMicroAlgos"`
+	AuthAddr           Address    `codec:"AuthAddr"`
+	IncentiveEligible  bool       `codec:"IncentiveEligible"`
+
+	TotalAppSchema      StateSchema `codec:"TotalAppSchema"`
+	TotalExtraAppPages  uint32      `codec:"TotalExtraAppPages"`
+	TotalAppParams      uint64      `codec:"TotalAppParams"`
+	TotalAppLocalStates uint64      `codec:"TotalAppLocalStates"`
+	TotalAssetParams    uint64      `codec:"TotalAssetParams"`
+	TotalAssets         uint64      `codec:"TotalAssets"`
+	TotalBoxes          uint64      `codec:"TotalBoxes"`
+	TotalBoxBytes       uint64      `codec:"TotalBoxBytes"`
+
+	LastProposed  Round `codec:"LastProposed"`
+	LastHeartbeat Round `codec:"LastHeartbeat"`
+}
+
+// VotingData is an account's participation keys
+type VotingData struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	VoteID          VotePK         `codec:"VoteID"`
+	SelectionID     VRFPK          `codec:"SelectionID"`
+	StateProofID    MerkleVerifier `codec:"StateProofID"`
+	VoteFirstValid  Round          `codec:"VoteFirstValid"`
+	VoteLastValid   Round          `codec:"VoteLastValid"`
+	VoteKeyDilution uint64         `codec:"VoteKeyDilution"`
+}
+
+// AccountTotals are the total balances of the accounts of each status
+type AccountTotals struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Online           AlgoCount `codec:"online"`
+	Offline          AlgoCount `codec:"offline"`
+	NotParticipating AlgoCount `codec:"notpart"`
+
+	// RewardsLevel is the rewards level of the ledger
+	RewardsLevel uint64 `codec:"rwdlvl"`
+}
+
+// AlgoCount is a total balance and the reward units it earns
+type AlgoCount struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Money       MicroAlgos `codec:"mon"`
+	RewardUnits uint64     `codec:"rwd"`
+}
+
+// AppResourceRecord is the change to an application of an account: its
+// parameters, if the account created it, or its local state in it
+type AppResourceRecord struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Aidx   AppIndex           `codec:"Aidx"`
+	Addr   Address            `codec:"Addr"`
+	Params AppParamsDelta     `codec:"Params"`
+	State  AppLocalStateDelta `codec:"State"`
+}
+
+// AssetResourceRecord is the change to an asset of an account: its
+// parameters, if the account created it, or the account's holding of it
+type AssetResourceRecord struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Aidx    AssetIndex        `codec:"Aidx"`
+	Addr    Address           `codec:"Addr"`
+	Params  AssetParamsDelta  `codec:"Params"`
+	Holding AssetHoldingDelta `codec:"Holding"`
+}
+
+// AppParamsDelta is the new parameters of an application, or its deletion.
+// Params is nil if the parameters did not change.
+type AppParamsDelta struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Params  *AppParams `codec:"Params"`
+	Deleted bool       `codec:"Deleted"`
+}
+
+// AppLocalStateDelta is the new local state of an account in an
+// application, or its closing out. LocalState is nil if the state did not
+// change.
+type AppLocalStateDelta struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	LocalState *AppLocalState `codec:"LocalState"`
+	Deleted    bool           `codec:"Deleted"`
+}
+
+// AssetParamsDelta is the new parameters of an asset, or its destruction.
+// Params is nil if the parameters did not change.
+type AssetParamsDelta struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Params  *AssetParams `codec:"Params"`
+	Deleted bool         `codec:"Deleted"`
+}
+
+// AssetHoldingDelta is the new holding of an asset by an account, or its
+// opting out. Holding is nil if the holding did not change.
+type AssetHoldingDelta struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Holding *AssetHolding `codec:"Holding"`
+	Deleted bool          `codec:"Deleted"`
+}
+
+// AppParams are the parameters of an application, as stored by the account
+// that created it
+type AppParams struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	ApprovalProgram   []byte       `codec:"approv"`
+	ClearStateProgram []byte       `codec:"clearp"`
+	GlobalState       TealKeyValue `codec:"gs"`
+	LocalStateSchema  StateSchema  `codec:"lsch"`
+	GlobalStateSchema StateSchema  `codec:"gsch"`
+	ExtraProgramPages uint32       `codec:"epp"`
+}
+
+// AppLocalState is the local state of an account in an application
+type AppLocalState struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Schema   StateSchema  `codec:"hsch"`
+	KeyValue TealKeyValue `codec:"tkv"`
+}
+
+// AssetHolding is the holding of an asset by an account
+type AssetHolding struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Amount uint64 `codec:"a"`
+	Frozen bool   `codec:"f"`
+}
+
+// TealType is the type of a TEAL value
+type TealType uint64
+
+// The types of TEAL values
+const (
+	TealBytesType TealType = 1
+	TealUintType  TealType = 2
+)
+
+// TealValue is a value stored in an application's state
+type TealValue struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Type  TealType `codec:"tt"`
+	Bytes string   `codec:"tb"`
+	Uint  uint64   `codec:"ui"`
+}
+
+// TealKeyValue is the state of an application, by key
+type TealKeyValue map[string]TealValue
+
+// KvValueDelta is the change to a value of the key-value store. Data is nil
+// if the value was deleted.
+type KvValueDelta struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Data    []byte `codec:"Data"`
+	OldData []byte `codec:"OldData"`
+}
+
+// IncludedTransactions says where a transaction was included
+type IncludedTransactions struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// LastValid is the last round the transaction was valid for
+	LastValid Round `codec:"LastValid"`
+
+	// Intra is the position of the transaction in the block
+	Intra uint64 `codec:"Intra"`
+}
+
+// Txlease is a lease taken by a transaction: its sender and lease value
+type Txlease struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Sender Address  `codec:"Sender"`
+	Lease  [32]byte `codec:"Lease"`
+}
+
+// CreatableType is the type of a creatable: an asset or an application
+type CreatableType uint64
+
+// The types of creatables
+const (
+	AssetCreatable CreatableType = 0
+	AppCreatable   CreatableType = 1
+)
+
+// ModifiedCreatable is an asset or application a block created or deleted
+type ModifiedCreatable struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// Ctype is the type of the creatable
+	Ctype CreatableType `codec:"Ctype"`
+
+	// Created is true if the creatable was created, false if deleted
+	Created bool `codec:"Created"`
+
+	// Creator is the account that created the creatable
+	Creator Address `codec:"Creator"`
+
+	// Ndeltas is the number of changes to the creatable in the block
+	Ndeltas int `codec:"Ndeltas"`
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+)
+
+func TestDecodeLedgerStateDelta(t *testing.T) {
+	account := Address{1}
+	creator := Address{2}
+	// a state delta as algod encodes it, written out field by field so that
+	// the codec tags are checked
+	encoded := map[string]interface{}{
+		"Accts": map[string]interface{}{
+			"Accts": []interface{}{
+				map[string]interface{}{"Addr": account[:], "Status": uint64(1), "MicroAlgos": uint64(5000000), "TotalAssets": uint64(1), "VoteLastValid": uint64(3000000)},
+			},
+			"AppResources": []interface{}{
+				map[string]interface{}{"Aidx": uint64(7), "Addr": account[:], "State": map[string]interface{}{
+					"LocalState": map[string]interface{}{"hsch": map[string]interface{}{"nui": uint64(1)}, "tkv": map[string]interface{}{"k": map[string]interface{}{"tt": uint64(2), "ui": uint64(9)}}},
+				}},
+			},
+			"AssetResources": []interface{}{
+				map[string]interface{}{"Aidx": uint64(8), "Addr": account[:], "Holding": map[string]interface{}{"Holding": map[string]interface{}{"a": uint64(100)}}},
+				map[string]interface{}{"Aidx": uint64(9), "Addr": creator[:], "Params": map[string]interface{}{"Deleted": true}},
+			},
+		},
+		"KvMods":        map[string]interface{}{"bx:key": map[string]interface{}{"Data": []byte("new"), "OldData": []byte("old")}},
+		"Txids":         map[interface{}]interface{}{[32]byte{3}: map[string]interface{}{"LastValid": uint64(1100), "Intra": uint64(2)}},
+		"Txleases":      map[interface{}]interface{}{Txlease{Sender: account, Lease: [32]byte{4}}: uint64(1100)},
+		"Creatables":    map[interface{}]interface{}{uint64(9): map[string]interface{}{"Ctype": uint64(0), "Creator": creator[:], "Ndeltas": 1}},
+		"Hdr":           map[string]interface{}{"rnd": uint64(100)},
+		"PrevTimestamp": int64(1700000000),
+		"Totals":        map[string]interface{}{"online": map[string]interface{}{"mon": uint64(10)}, "rwdlvl": uint64(3)},
+	}
+
+	var delta LedgerStateDelta
+	require.NoError(t, msgpack.Decode(msgpack.Encode(encoded), &delta))
+	require.Len(t, delta.Accts.Accts, 1)
+	record := delta.Accts.Accts[0]
+	require.Equal(t, account, record.Addr)
+	require.Equal(t, Online, record.Status)
+	require.Equal(t, MicroAlgos(5000000), record.MicroAlgos)
+	require.Equal(t, uint64(1), record.TotalAssets)
+	require.Equal(t, Round(3000000), record.VoteLastValid)
+	require.Equal(t, AppIndex(7), delta.Accts.AppResources[0].Aidx)
+	require.Nil(t, delta.Accts.AppResources[0].Params.Params)
+	require.Equal(t, TealValue{Type: TealUintType, Uint: 9}, delta.Accts.AppResources[0].State.LocalState.KeyValue["k"])
+	require.Equal(t, uint64(100), delta.Accts.AssetResources[0].Holding.Holding.Amount)
+	require.True(t, delta.Accts.AssetResources[1].Params.Deleted)
+	require.Equal(t, KvValueDelta{Data: []byte("new"), OldData: []byte("old")}, delta.KvMods["bx:key"])
+	require.Equal(t, IncludedTransactions{LastValid: 1100, Intra: 2}, delta.Txids[Digest{3}])
+	require.Equal(t, Round(1100), delta.Txleases[Txlease{Sender: account, Lease: [32]byte{4}}])
+	require.Equal(t, ModifiedCreatable{Ctype: AssetCreatable, Creator: creator, Ndeltas: 1}, delta.Creatables[9])
+	require.Equal(t, Round(100), delta.Hdr.Round)
+	require.Equal(t, int64(1700000000), delta.PrevTimestamp)
+	require.Equal(t, MicroAlgos(10), delta.Totals.Online.Money)
+	require.Equal(t, uint64(3), delta.Totals.RewardsLevel)
+
+	// and in JSON, but for the leases
+	b, err := json.Marshal(delta)
+	require.NoError(t, err)
+	var decoded LedgerStateDelta
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	require.Nil(t, decoded.Txleases)
+	decoded.Txleases = delta.Txleases
+	require.Equal(t, delta, decoded)
+}
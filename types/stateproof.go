@@ -0,0 +1,135 @@
+package types
+
+// StateProofType identifies a type of state proof
+type StateProofType uint64
+
+// StateProofBasic is the type of the state proofs the network currently produces
+const StateProofBasic StateProofType = 0
+
+// falconPublicKeyLenBytes is the length of a Falcon public key
+const falconPublicKeyLenBytes = 1793
+
+// GenericDigest is a digest of any length, as produced by the hash functions of state proofs
+type GenericDigest []byte
+
+// HashFactory names the hash function of a Merkle tree
+type HashFactory struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	HashType uint16 `codec:"t"`
+}
+
+// MerkleArrayProof proves that some leaves belong to a Merkle tree
+type MerkleArrayProof struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	// Path is the sibling hashes needed to recompute the root
+	Path        []GenericDigest `codec:"pth"`
+	HashFactory HashFactory     `codec:"hsh"`
+	// TreeDepth is the depth of the tree the leaves belong to
+	TreeDepth uint8 `codec:"td"`
+}
+
+// FalconVerifier is a Falcon public key
+type FalconVerifier struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	PublicKey [falconPublicKeyLenBytes]byte `codec:"k"`
+}
+
+// FalconSignature is a Falcon signature, compressed
+type FalconSignature []byte
+
+// MerkleSignature is a signature by one of the state proof keys of an account,
+// with the proof that the key belongs to the account's MerkleVerifier
+type MerkleSignature struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Signature             FalconSignature `codec:"sig"`
+	VectorCommitmentIndex uint64          `codec:"idx"`
+	// Proof proves that VerifyingKey belongs to the account's MerkleVerifier
+	Proof        MerkleArrayProof `codec:"prf"`
+	VerifyingKey FalconVerifier   `codec:"vkey"`
+}
+
+// StateProofVerifier is the commitment to an account's state proof keys
+type StateProofVerifier struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Commitment  MerkleVerifier `codec:"cmt"`
+	KeyLifetime uint64         `codec:"lf"`
+}
+
+// Participant is an account that signs state proofs, weighted by its stake
+type Participant struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	PK     StateProofVerifier `codec:"p"`
+	Weight uint64             `codec:"w"`
+}
+
+// SigslotCommit is a signature of a participant, with the total weight of the
+// signatures before it
+type SigslotCommit struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Sig MerkleSignature `codec:"s"`
+	L   uint64          `codec:"l"`
+}
+
+// Reveal is a signature revealed by a state proof, and its participant
+type Reveal struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	SigSlot SigslotCommit `codec:"s"`
+	Part    Participant   `codec:"p"`
+}
+
+// StateProof proves that participants holding enough stake signed a message
+type StateProof struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	SigCommit                  GenericDigest     `codec:"c"`
+	SignedWeight               uint64            `codec:"w"`
+	SigProofs                  MerkleArrayProof  `codec:"S"`
+	PartProofs                 MerkleArrayProof  `codec:"P"`
+	MerkleSignatureSaltVersion byte              `codec:"v"`
+	Reveals                    map[uint64]Reveal `codec:"r"`
+	PositionsToReveal          []uint64          `codec:"pr"`
+}
+
+// StateProofMessage is the message a state proof attests to: commitments to
+// the block headers and voters of a range of rounds
+type StateProofMessage struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	BlockHeadersCommitment []byte `codec:"b"`
+	VotersCommitment       []byte `codec:"v"`
+	LnProvenWeight         uint64 `codec:"P"`
+	FirstAttestedRound     uint64 `codec:"f"`
+	LastAttestedRound      uint64 `codec:"l"`
+}
+
+// StateProofTxnFields captures the fields used by state proof transactions,
+// which the network issues itself
+type StateProofTxnFields struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	StateProofType StateProofType    `codec:"sptype"`
+	StateProof     StateProof        `codec:"sp"`
+	Message        StateProofMessage `codec:"spmsg"`
+}
+
+// LightBlockHeader is the part of a block header that state proofs commit to,
+// enough to verify the transactions of the block with SHA-256 alone
+type LightBlockHeader struct {
+	_struct struct{} `codec:",omitempty,omitemptyarray"`
+
+	Seed Seed `codec:"0"`
+	// BlockHash is the hash of the full block header, committed to by
+	// protocol versions which set StateProofBlockHashInLightHeader
+	BlockHash           BlockHash     `codec:"1"`
+	RoundNumber         Round         `codec:"r"`
+	GenesisHash         Digest        `codec:"gh"`
+	Sha256TxnCommitment GenericDigest `codec:"tc"`
+}
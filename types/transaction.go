@@ -1,5 +1,10 @@
 package types
 
+import (
+	"crypto/rand"
+	"fmt"
+)
+
 // Transaction describes a transaction that can appear in a block.
 type Transaction struct {
 	_struct struct{} `codec:",omitempty,omitemptyarray"`
@@ -16,6 +21,26 @@ type Transaction struct {
 	AssetConfigTxnFields
 	AssetTransferTxnFields
 	AssetFreezeTxnFields
+	ApplicationFields
+	StateProofTxnFields
+
+	// HeartbeatTxnFields are set for heartbeat transactions
+	HeartbeatTxnFields *HeartbeatTxnFields `codec:"hb"`
+}
+
+// StateProofFields returns the state proof fields of tx, and whether it is a
+// state proof transaction
+func (tx Transaction) StateProofFields() (StateProofTxnFields, bool) {
+	return tx.StateProofTxnFields, tx.Type == StateProofTx
+}
+
+// Heartbeat returns the heartbeat fields of tx, and whether it is a heartbeat
+// transaction
+func (tx Transaction) Heartbeat() (HeartbeatTxnFields, bool) {
+	if tx.Type != HeartbeatTx || tx.HeartbeatTxnFields == nil {
+		return HeartbeatTxnFields{}, false
+	}
+	return *tx.HeartbeatTxnFields, true
 }
 
 // SignedTxn wraps a transaction and a signature. The encoding of this struct
@@ -27,18 +52,23 @@ type SignedTxn struct {
 	Msig MultisigSig `codec:"msig"`
 	Lsig LogicSig    `codec:"lsig"`
 	Txn  Transaction `codec:"txn"`
+
+	// AuthAddr is the address of the account that signed the transaction,
+	// if it is not the sender, i.e. the account the sender was rekeyed to
+	AuthAddr Address `codec:"sgnr"`
 }
 
 // KeyregTxnFields captures the fields used for key registration transactions.
 type KeyregTxnFields struct {
 	_struct struct{} `codec:",omitempty,omitemptyarray"`
 
-	VotePK           VotePK `codec:"votekey"`
-	SelectionPK      VRFPK  `codec:"selkey"`
-	VoteFirst        Round  `codec:"votefst"`
-	VoteLast         Round  `codec:"votelst"`
-	VoteKeyDilution  uint64 `codec:"votekd"`
-	Nonparticipation bool   `codec:"nonpart"`
+	VotePK           VotePK         `codec:"votekey"`
+	SelectionPK      VRFPK          `codec:"selkey"`
+	StateProofPK     MerkleVerifier `codec:"sprfkey"`
+	VoteFirst        Round          `codec:"votefst"`
+	VoteLast         Round          `codec:"votelst"`
+	VoteKeyDilution  uint64         `codec:"votekd"`
+	Nonparticipation bool           `codec:"nonpart"`
 }
 
 // PaymentTxnFields captures the fields used by payment transactions.
@@ -134,6 +164,11 @@ type Header struct {
 	// the LastValid round passes.  While this transaction possesses the
 	// lease, no other transaction specifying this lease can be confirmed.
 	Lease [32]byte `codec:"lx"`
+
+	// RekeyTo, if nonzero, sets the sender's AuthAddr to the given address.
+	// Once this transaction is confirmed, the sender's transactions must be
+	// signed by the account at that address rather than by the sender.
+	RekeyTo Address `codec:"rekey"`
 }
 
 // TxGroup describes a group of transactions that must appear
@@ -148,14 +183,63 @@ type TxGroup struct {
 	TxGroupHashes []Digest `codec:"txlist"`
 }
 
+// SuggestedParams wraps the transaction parameters common to all transactions,
+// typically received from the SuggestedParams endpoint of algod.
+// This struct itself is not sent over the wire to or from algod: see models.TransactionParametersResponse.
+type SuggestedParams struct {
+	// Fee is the suggested transaction fee
+	// Fee is in units of micro-Algos per byte.
+	// Fee may fall to zero but a group of N atomic transactions must
+	// still have a fee of at least N*MinTxnFee for the current network protocol.
+	Fee MicroAlgos `codec:"fee"`
+
+	// Genesis ID
+	GenesisID string `codec:"genesis-id"`
+
+	// Genesis hash
+	GenesisHash []byte `codec:"genesis-hash"`
+
+	// FirstRoundValid is the first protocol round on which the txn is valid
+	FirstRoundValid Round `codec:"first-round"`
+
+	// LastRoundValid is the final protocol round on which the txn may be committed
+	LastRoundValid Round `codec:"last-round"`
+
+	// ConsensusVersion indicates the consensus protocol version
+	// as of LastRound.
+	ConsensusVersion string `codec:"consensus-version"`
+
+	// FlatFee indicates whether the passed fee is per-byte or per-transaction
+	// If true, txn fee may fall below the MinTxnFee for the current network protocol.
+	FlatFee bool `codec:"flat-fee"`
+
+	// The minimum transaction fee (not per byte) required for the
+	// txn to validate for the current network protocol.
+	MinFee uint64 `codec:"min-fee"`
+}
+
+// MakeLease returns a lease from seed: seed itself if it is 32 bytes, or a
+// random lease if seed is empty. Other lengths are an error.
+func MakeLease(seed []byte) (lease [32]byte, err error) {
+	switch len(seed) {
+	case 0:
+		_, err = rand.Read(lease[:])
+	case len(lease):
+		copy(lease[:], seed)
+	default:
+		err = fmt.Errorf("lease must be %d bytes, not %d", len(lease), len(seed))
+	}
+	return
+}
+
 // AddLease adds the passed lease (see types/transaction.go) to the header of the passed transaction
 // and updates fee accordingly
 // - lease: the [32]byte lease to add to the header
 // - feePerByte: the new feePerByte
 func (tx *Transaction) AddLease(lease [32]byte, feePerByte uint64) {
 	copy(tx.Header.Lease[:], lease[:])
-	// normally we would use estimateSize,
-	// and set fee = feePerByte * estimateSize,
+	// normally we would use transaction.EstimateSize,
+	// and set fee = feePerByte * EstimateSize,
 	// but this would cause a circular import.
 	// we know we are adding 32 bytes (+ a few bytes to hold the 32), so increase fee accordingly.
 	tx.Header.Fee = tx.Header.Fee + MicroAlgos(37*feePerByte)
@@ -169,3 +253,17 @@ func (tx *Transaction) AddLeaseWithFlatFee(lease [32]byte, flatFee uint64) {
 	tx.Header.Lease = lease
 	tx.Header.Fee = MicroAlgos(flatFee)
 }
+
+// Rekey sets the RekeyTo field of the transaction, so that once it is confirmed
+// the sender's transactions must be signed by the account at rekeyTo
+// - rekeyTo: a checksummed, human-readable address
+// The fee is not updated: if it was computed per byte, compute it again, e.g.
+// with transaction.SetFee.
+func (tx *Transaction) Rekey(rekeyTo string) error {
+	addr, err := DecodeAddress(rekeyTo)
+	if err != nil {
+		return err
+	}
+	tx.RekeyTo = addr
+	return nil
+}
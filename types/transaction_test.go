@@ -0,0 +1,128 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+)
+
+func TestRekey(t *testing.T) {
+	const rekeyTo = "47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU"
+	var tx Transaction
+	require.NoError(t, tx.Rekey(rekeyTo))
+	require.Equal(t, rekeyTo, tx.RekeyTo.String())
+
+	require.Error(t, tx.Rekey("not an address"))
+	require.Equal(t, rekeyTo, tx.RekeyTo.String())
+}
+
+func TestMakeLease(t *testing.T) {
+	seed := make([]byte, 32)
+	seed[0] = 1
+	lease, err := MakeLease(seed)
+	require.NoError(t, err)
+	require.Equal(t, seed, lease[:])
+
+	random, err := MakeLease(nil)
+	require.NoError(t, err)
+	require.NotEqual(t, [32]byte{}, random)
+	other, err := MakeLease(nil)
+	require.NoError(t, err)
+	require.NotEqual(t, random, other)
+
+	_, err = MakeLease(seed[:31])
+	require.Error(t, err)
+	_, err = MakeLease(append(seed, 0))
+	require.Error(t, err)
+}
+
+func TestStateProofAndHeartbeatTxns(t *testing.T) {
+	// encoded as algod does, with its field names
+	addr, err := DecodeAddress("47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU")
+	require.NoError(t, err)
+	encoded := msgpack.Encode(map[string]interface{}{
+		"type": "hb",
+		"snd":  addr[:],
+		"fv":   uint64(100),
+		"lv":   uint64(110),
+		"hb": map[string]interface{}{
+			"hbad":  addr[:],
+			"hbkd":  uint64(10000),
+			"hbprf": map[string]interface{}{"s": []byte{63: 2}, "p": []byte{1: 1, 31: 0}},
+		},
+	})
+	var tx Transaction
+	require.NoError(t, msgpack.Decode(encoded, &tx))
+	hb, ok := tx.Heartbeat()
+	require.True(t, ok)
+	require.Equal(t, addr, hb.HbAddress)
+	require.Equal(t, uint64(10000), hb.HbKeyDilution)
+	require.Equal(t, byte(1), hb.HbProof.PK[1])
+	_, ok = tx.StateProofFields()
+	require.False(t, ok)
+	require.Equal(t, encoded, msgpack.Encode(tx))
+
+	encoded = msgpack.Encode(map[string]interface{}{
+		"type": "stpf",
+		"snd":  addr[:],
+		"sp": map[string]interface{}{
+			"c": []byte{1, 2, 3},
+			"w": uint64(5),
+			"S": map[string]interface{}{"pth": [][]byte{{4}, {5}}, "td": uint64(2)},
+			"r": map[uint64]interface{}{
+				3: map[string]interface{}{
+					"p": map[string]interface{}{"w": uint64(7)},
+					"s": map[string]interface{}{"l": uint64(1), "s": map[string]interface{}{"sig": []byte{6}, "idx": uint64(2)}},
+				},
+			},
+			"pr": []uint64{3},
+		},
+		"spmsg": map[string]interface{}{"b": []byte{7}, "f": uint64(256), "l": uint64(511)},
+	})
+	tx = Transaction{}
+	require.NoError(t, msgpack.Decode(encoded, &tx))
+	sp, ok := tx.StateProofFields()
+	require.True(t, ok)
+	require.Equal(t, uint64(5), sp.StateProof.SignedWeight)
+	require.Equal(t, []GenericDigest{{4}, {5}}, sp.StateProof.SigProofs.Path)
+	require.Equal(t, uint64(7), sp.StateProof.Reveals[3].Part.Weight)
+	require.Equal(t, FalconSignature{6}, sp.StateProof.Reveals[3].SigSlot.Sig.Signature)
+	require.Equal(t, uint64(511), sp.Message.LastAttestedRound)
+	_, ok = tx.Heartbeat()
+	require.False(t, ok)
+	require.Equal(t, encoded, msgpack.Encode(tx))
+}
+
+func TestTransactionJSON(t *testing.T) {
+	addr, err := DecodeAddress("47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU")
+	require.NoError(t, err)
+	stx := SignedTxn{
+		Sig: Signature{1},
+		Txn: Transaction{
+			Type:             PaymentTx,
+			Header:           Header{Sender: addr, Fee: 1000, FirstValid: 1, LastValid: 1001, Note: []byte("hi"), GenesisID: "testnet-v1.0"},
+			PaymentTxnFields: PaymentTxnFields{Receiver: addr, Amount: 5},
+		},
+	}
+	encoded, err := json.Marshal(stx)
+	require.NoError(t, err)
+	expected := `{"sig":"AQAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA==",` +
+		`"txn":{"amt":5,"fee":1000,"fv":1,"gen":"testnet-v1.0","lv":1001,"note":"aGk=",` +
+		`"rcv":"47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU",` +
+		`"snd":"47YPQTIGQEO7T4Y4RWDYWEKV6RTR2UNBQXBABEEGM72ESWDQNCQ52OPASU","type":"pay"}}`
+	require.Equal(t, expected, string(encoded))
+
+	var decoded SignedTxn
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+	require.Equal(t, stx, decoded)
+
+	// addresses are still raw bytes in msgpack
+	require.Equal(t, msgpack.Encode(stx), msgpack.Encode(decoded))
+	require.Contains(t, string(msgpack.Encode(stx.Txn)), string(addr[:]))
+
+	require.Error(t, json.Unmarshal([]byte(`{"snd":"not an address"}`), &Transaction{}))
+	require.Error(t, json.Unmarshal([]byte(`{"foo":1}`), &Transaction{}))
+}
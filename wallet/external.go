@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// TransactionPrefix prefixes the msgpack encoding of a transaction in the
+// bytes its signature signs; see crypto.RawTransactionBytesToSign
+var TransactionPrefix = []byte("TX")
+
+// ExternalSigner is a device or service holding an ed25519 key outside the
+// SDK, such as a hardware wallet or a hardware security module. It signs the
+// canonical bytes of transactions: the "TX" prefix followed by their msgpack
+// encoding. Devices that add the prefix themselves, such as Ledger's, can
+// strip it with SplitTransactionPrefix.
+type ExternalSigner interface {
+	// PublicKey returns the public key of the signing key
+	PublicKey(ctx context.Context) (ed25519.PublicKey, error)
+
+	// Sign returns the ed25519 signature of message by the signing key
+	Sign(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// MakeExternalTransactionSigner returns a signer of transactions with an
+// external signer's key, asking the signer for its public key
+func MakeExternalTransactionSigner(ctx context.Context, signer ExternalSigner) (RawTransactionSigner, error) {
+	pk, err := signer.PublicKey(ctx)
+	if err != nil {
+		return RawTransactionSigner{}, err
+	}
+	if len(pk) != ed25519.PublicKeySize {
+		return RawTransactionSigner{}, fmt.Errorf("public key has length %d, expected %d", len(pk), ed25519.PublicKeySize)
+	}
+	return RawTransactionSigner{PublicKey: pk, Sign: signer.Sign}, nil
+}
+
+// SplitTransactionPrefix returns the msgpack encoded transaction in the bytes
+// a transaction's signature signs, or an error if message is not such bytes
+func SplitTransactionPrefix(message []byte) ([]byte, error) {
+	if !bytes.HasPrefix(message, TransactionPrefix) {
+		return nil, fmt.Errorf("message is not a transaction to sign")
+	}
+	return message[len(TransactionPrefix):], nil
+}
+
+// Chunk splits data into chunks of at most size bytes, for devices taking it
+// in several messages, such as the APDUs of smart cards and hardware wallets.
+// Empty data is a single empty chunk.
+func Chunk(data []byte, size int) [][]byte {
+	if size <= 0 {
+		panic("wallet: chunk size must be positive")
+	}
+	chunks := make([][]byte, 0, len(data)/size+1)
+	for len(data) > size {
+		chunks = append(chunks, data[:size])
+		data = data[size:]
+	}
+	return append(chunks, data)
+}
@@ -0,0 +1,116 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// AWSCredentials are the credentials of an AWS identity allowed to use a key:
+// kms:Sign and kms:GetPublicKey
+type AWSCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is the token of temporary credentials, optional
+	SessionToken string
+}
+
+// AWSConfig says how to reach AWS KMS
+type AWSConfig struct {
+	// Region is the region of the keys, e.g. "us-east-1"
+	Region string
+
+	Credentials AWSCredentials
+
+	// Endpoint is the URL of the service, or empty for
+	// https://kms.<region>.amazonaws.com, e.g. to use a VPC endpoint
+	Endpoint string
+
+	// HTTPClient sends the requests, or nil for http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// AWSSigner signs with an ED25519 key of AWS KMS (key spec
+// ECC_NIST_EDWARDS25519), with the ED25519_SHA_512 signing algorithm
+type AWSSigner struct {
+	config AWSConfig
+	keyID  string
+	now    func() time.Time
+}
+
+// MakeAWSSigner returns a signer with the key keyID, its ID, ARN or alias
+func MakeAWSSigner(config AWSConfig, keyID string) *AWSSigner {
+	return &AWSSigner{config: config, keyID: keyID, now: time.Now}
+}
+
+// call calls the KMS action with the given request, decoding its response
+func (s *AWSSigner) call(ctx context.Context, action string, request, response interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	endpoint := s.config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com", s.config.Region)
+	}
+	req, err := http.NewRequest("POST", endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	signV4(req, body, s.config.Credentials, s.config.Region, "kms", s.now())
+	return doJSON(s.config.HTTPClient, req, response, awsErrorMessage)
+}
+
+// awsErrorMessage returns the type and message of an AWS error response
+func awsErrorMessage(body []byte) string {
+	var awsError struct {
+		Type         string `json:"__type"`
+		Message      string `json:"message"`
+		MessageUpper string `json:"Message"`
+	}
+	if json.Unmarshal(body, &awsError) != nil {
+		return string(body)
+	}
+	if awsError.Message == "" {
+		awsError.Message = awsError.MessageUpper
+	}
+	return fmt.Sprintf("%s: %s", awsError.Type, awsError.Message)
+}
+
+// PublicKey returns the public key of the signer's key
+func (s *AWSSigner) PublicKey(ctx context.Context) (ed25519.PublicKey, error) {
+	var response struct {
+		PublicKey []byte
+	}
+	if err := s.call(ctx, "GetPublicKey", map[string]string{"KeyId": s.keyID}, &response); err != nil {
+		return nil, err
+	}
+	return parseEd25519SPKI(response.PublicKey)
+}
+
+// Sign returns the signature of message by the signer's key. KMS signs
+// messages of up to 4096 bytes.
+func (s *AWSSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	request := struct {
+		KeyID            string `json:"KeyId"`
+		Message          []byte
+		MessageType      string
+		SigningAlgorithm string
+	}{s.keyID, message, "RAW", "ED25519_SHA_512"}
+	var response struct {
+		Signature []byte
+	}
+	if err := s.call(ctx, "Sign", request, &response); err != nil {
+		return nil, err
+	}
+	return response.Signature, nil
+}
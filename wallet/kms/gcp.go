@@ -0,0 +1,121 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// GCPTokenSource returns an OAuth 2.0 access token allowed to use a key,
+// e.g. one of golang.org/x/oauth2/google's default token source
+type GCPTokenSource func(ctx context.Context) (string, error)
+
+// GCPConfig says how to reach Google Cloud KMS
+type GCPConfig struct {
+	Token GCPTokenSource
+
+	// Endpoint is the URL of the service, or empty for
+	// https://cloudkms.googleapis.com
+	Endpoint string
+
+	// HTTPClient sends the requests, or nil for http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// GCPSigner signs with an EC_SIGN_ED25519 key version of Google Cloud KMS
+type GCPSigner struct {
+	config     GCPConfig
+	keyVersion string
+}
+
+// MakeGCPSigner returns a signer with the key version with the given resource
+// name, projects/*/locations/*/keyRings/*/cryptoKeys/*/cryptoKeyVersions/*
+func MakeGCPSigner(config GCPConfig, keyVersion string) *GCPSigner {
+	return &GCPSigner{config: config, keyVersion: keyVersion}
+}
+
+// call sends a request for the key version's resource with the given suffix,
+// decoding its response. request is sent as the body of a POST if not nil.
+func (s *GCPSigner) call(ctx context.Context, suffix string, request, response interface{}) error {
+	endpoint := s.config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://cloudkms.googleapis.com"
+	}
+	url := endpoint + "/v1/" + s.keyVersion + suffix
+	method, body := "GET", []byte(nil)
+	if request != nil {
+		encoded, err := json.Marshal(request)
+		if err != nil {
+			return err
+		}
+		method, body = "POST", encoded
+	}
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if request != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.config.Token != nil {
+		token, err := s.config.Token(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return doJSON(s.config.HTTPClient, req.WithContext(ctx), response, gcpErrorMessage)
+}
+
+// gcpErrorMessage returns the status and message of a Google API error
+// response
+func gcpErrorMessage(body []byte) string {
+	var gcpError struct {
+		Error struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &gcpError) != nil {
+		return string(body)
+	}
+	return fmt.Sprintf("%s: %s", gcpError.Error.Status, gcpError.Error.Message)
+}
+
+// PublicKey returns the public key of the signer's key version
+func (s *GCPSigner) PublicKey(ctx context.Context) (ed25519.PublicKey, error) {
+	var response struct {
+		Pem       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}
+	if err := s.call(ctx, "/publicKey", nil, &response); err != nil {
+		return nil, err
+	}
+	if response.Algorithm != "EC_SIGN_ED25519" {
+		return nil, fmt.Errorf("key version has algorithm %s, expected EC_SIGN_ED25519", response.Algorithm)
+	}
+	block, _ := pem.Decode([]byte(response.Pem))
+	if block == nil {
+		return nil, fmt.Errorf("public key is not PEM encoded")
+	}
+	return parseEd25519SPKI(block.Bytes)
+}
+
+// Sign returns the signature of message by the signer's key version
+func (s *GCPSigner) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	request := struct {
+		Data []byte `json:"data"`
+	}{message}
+	var response struct {
+		Signature []byte `json:"signature"`
+	}
+	if err := s.call(ctx, ":asymmetricSign", request, &response); err != nil {
+		return nil, err
+	}
+	return response.Signature, nil
+}
@@ -0,0 +1,51 @@
+// Package kms signs transactions with ed25519 keys held by cloud key
+// management services, AWS KMS and Google Cloud KMS, so that keys never leave
+// the service. Its signers are wallet.ExternalSigners, made into transaction
+// signers by wallet.MakeExternalTransactionSigner, whose Address is the address
+// of the account of the key. The services are called through their REST APIs,
+// so no cloud SDK is needed.
+package kms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"golang.org/x/crypto/ed25519"
+)
+
+// ed25519SPKIPrefix is the DER encoding of an ed25519 SubjectPublicKeyInfo up
+// to the key
+var ed25519SPKIPrefix = []byte{0x30, 0x2a, 0x30, 0x05, 0x06, 0x03, 0x2b, 0x65, 0x70, 0x03, 0x21, 0x00}
+
+// parseEd25519SPKI returns the ed25519 public key of a DER encoded
+// SubjectPublicKeyInfo, as the services return public keys
+func parseEd25519SPKI(der []byte) (ed25519.PublicKey, error) {
+	if len(der) != len(ed25519SPKIPrefix)+ed25519.PublicKeySize || !bytes.HasPrefix(der, ed25519SPKIPrefix) {
+		return nil, fmt.Errorf("public key is not an ed25519 key")
+	}
+	return ed25519.PublicKey(der[len(ed25519SPKIPrefix):]), nil
+}
+
+// doJSON sends req and decodes its JSON response into response, returning an
+// error with the service's message if it failed
+func doJSON(httpClient *http.Client, req *http.Request, response interface{}, errorMessage func(body []byte) string) error {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("kms: HTTP %v: %s", resp.Status, errorMessage(body))
+	}
+	return json.Unmarshal(body, response)
+}
@@ -0,0 +1,152 @@
+package kms
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+	"github.com/algorand/go-algorand-sdk/wallet"
+)
+
+var (
+	_ wallet.ExternalSigner = &AWSSigner{}
+	_ wallet.ExternalSigner = &GCPSigner{}
+)
+
+// the get-vanilla case of the AWS Signature Version 4 test suite
+func TestSignV4(t *testing.T) {
+	req, err := http.NewRequest("GET", "https://example.amazonaws.com/", nil)
+	require.NoError(t, err)
+	credentials := AWSCredentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signV4(req, nil, credentials, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+	require.Equal(t, "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	require.Equal(t, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, "+
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31",
+		req.Header.Get("Authorization"))
+}
+
+func signTestTransaction(t *testing.T, signer wallet.ExternalSigner, account crypto.Account) {
+	txSigner, err := wallet.MakeExternalTransactionSigner(context.Background(), signer)
+	require.NoError(t, err)
+	require.Equal(t, account.Address, txSigner.Address())
+
+	txn := types.Transaction{Type: types.PaymentTx, Header: types.Header{Sender: account.Address, Fee: 1000}}
+	stxs, err := txSigner.SignTransactions(context.Background(), []types.Transaction{txn}, []int{0})
+	require.NoError(t, err)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxs[0], &stx))
+	require.True(t, crypto.VerifySignedTransaction(stx))
+}
+
+func TestAWSSigner(t *testing.T) {
+	account := crypto.GenerateAccount()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "application/x-amz-json-1.1", r.Header.Get("Content-Type"))
+		require.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/"))
+		require.Contains(t, r.Header.Get("Authorization"), "/eu-west-1/kms/aws4_request")
+		require.Equal(t, "token", r.Header.Get("X-Amz-Security-Token"))
+		var request struct {
+			KeyID            string `json:"KeyId"`
+			Message          []byte
+			MessageType      string
+			SigningAlgorithm string
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &request))
+		if request.KeyID != "alias/algorand" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"NotFoundException","message":"alias not found"}`))
+			return
+		}
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			json.NewEncoder(w).Encode(map[string][]byte{"PublicKey": append(append([]byte{}, ed25519SPKIPrefix...), account.PublicKey...)})
+		case "TrentService.Sign":
+			require.Equal(t, "RAW", request.MessageType)
+			require.Equal(t, "ED25519_SHA_512", request.SigningAlgorithm)
+			signature, err := account.PrivateKey.Sign(request.Message)
+			require.NoError(t, err)
+			json.NewEncoder(w).Encode(map[string][]byte{"Signature": signature})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	config := AWSConfig{
+		Region:      "eu-west-1",
+		Credentials: AWSCredentials{AccessKeyID: "AKID", SecretAccessKey: "secret", SessionToken: "token"},
+		Endpoint:    server.URL,
+	}
+	signTestTransaction(t, MakeAWSSigner(config, "alias/algorand"), account)
+
+	_, err := MakeAWSSigner(config, "alias/other").PublicKey(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NotFoundException: alias not found")
+}
+
+func TestGCPSigner(t *testing.T) {
+	account := crypto.GenerateAccount()
+	keyVersion := "projects/p/locations/global/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"code":401,"status":"UNAUTHENTICATED","message":"invalid token"}}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/" + keyVersion + "/publicKey":
+			require.Equal(t, "GET", r.Method)
+			der := append(append([]byte{}, ed25519SPKIPrefix...), account.PublicKey...)
+			json.NewEncoder(w).Encode(map[string]string{
+				"pem":       string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+				"algorithm": "EC_SIGN_ED25519",
+			})
+		case "/v1/" + keyVersion + ":asymmetricSign":
+			require.Equal(t, "POST", r.Method)
+			var request struct {
+				Data []byte `json:"data"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			signature, err := account.PrivateKey.Sign(request.Data)
+			require.NoError(t, err)
+			json.NewEncoder(w).Encode(map[string][]byte{"signature": signature})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	token := "token"
+	config := GCPConfig{
+		Token:    func(ctx context.Context) (string, error) { return token, nil },
+		Endpoint: server.URL,
+	}
+	signTestTransaction(t, MakeGCPSigner(config, keyVersion), account)
+
+	token = "expired"
+	_, err := MakeGCPSigner(config, keyVersion).Sign(context.Background(), []byte("TX"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "UNAUTHENTICATED: invalid token")
+}
+
+func TestParseEd25519SPKI(t *testing.T) {
+	pk := make([]byte, ed25519.PublicKeySize)
+	_, err := parseEd25519SPKI(append(append([]byte{}, ed25519SPKIPrefix...), pk...))
+	require.NoError(t, err)
+	_, err = parseEd25519SPKI(pk)
+	require.Error(t, err)
+}
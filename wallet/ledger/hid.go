@@ -0,0 +1,71 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// The framing of APDUs in the HID packets of Ledger devices: each packet
+// starts with the channel, a tag and a sequence number, and the first packet
+// of an APDU gives its length
+const (
+	hidPacketSize = 64
+	hidChannel    = 0x0101
+	hidTag        = 0x05
+)
+
+// wrapHID splits an APDU into HID packets of hidPacketSize bytes, the last one
+// padded with zeros
+func wrapHID(apdu []byte) [][]byte {
+	data := make([]byte, 2, 2+len(apdu))
+	binary.BigEndian.PutUint16(data, uint16(len(apdu)))
+	data = append(data, apdu...)
+
+	var packets [][]byte
+	for seq := 0; len(data) > 0 || seq == 0; seq++ {
+		packet := make([]byte, hidPacketSize)
+		binary.BigEndian.PutUint16(packet[0:], hidChannel)
+		packet[2] = hidTag
+		binary.BigEndian.PutUint16(packet[3:], uint16(seq))
+		n := copy(packet[5:], data)
+		data = data[n:]
+		packets = append(packets, packet)
+	}
+	return packets
+}
+
+// hidReader reassembles an answer from the HID packets returned by next
+type hidReader struct {
+	next func() ([]byte, error)
+}
+
+// read returns the next answer of the device
+func (r hidReader) read() ([]byte, error) {
+	var answer []byte
+	length := -1
+	for seq := 0; length < 0 || len(answer) < length; seq++ {
+		packet, err := r.next()
+		if err != nil {
+			return nil, err
+		}
+		if len(packet) < 5 {
+			return nil, fmt.Errorf("ledger: HID packet has length %d, too short", len(packet))
+		}
+		if binary.BigEndian.Uint16(packet[0:]) != hidChannel || packet[2] != hidTag {
+			return nil, fmt.Errorf("ledger: unexpected HID packet header")
+		}
+		if got := binary.BigEndian.Uint16(packet[3:]); int(got) != seq {
+			return nil, fmt.Errorf("ledger: HID packet has sequence %d, expected %d", got, seq)
+		}
+		body := packet[5:]
+		if seq == 0 {
+			if len(body) < 2 {
+				return nil, fmt.Errorf("ledger: first HID packet has no answer length")
+			}
+			length = int(binary.BigEndian.Uint16(body))
+			body = body[2:]
+		}
+		answer = append(answer, body...)
+	}
+	return answer[:length], nil
+}
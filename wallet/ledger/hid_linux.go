@@ -0,0 +1,84 @@
+//go:build ledger && linux
+// +build ledger,linux
+
+package ledger
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ledgerVendorID is the USB vendor ID of Ledger devices
+const ledgerVendorID = "00002C97"
+
+// HIDTransport is a Transport over a Linux hidraw device, which the user needs
+// read and write access to, e.g. through Ledger's udev rules
+type HIDTransport struct {
+	device *os.File
+}
+
+// OpenHID opens the first Ledger device found among the hidraw devices
+func OpenHID() (*HIDTransport, error) {
+	uevents, err := filepath.Glob("/sys/class/hidraw/hidraw*/device/uevent")
+	if err != nil {
+		return nil, err
+	}
+	for _, uevent := range uevents {
+		content, err := ioutil.ReadFile(uevent)
+		if err != nil {
+			continue
+		}
+		if !isLedger(string(content)) {
+			continue
+		}
+		name := filepath.Base(filepath.Dir(filepath.Dir(uevent)))
+		return OpenHIDPath(filepath.Join("/dev", name))
+	}
+	return nil, fmt.Errorf("ledger: no device found")
+}
+
+// isLedger reports whether a hidraw device's uevent is a Ledger device's
+func isLedger(uevent string) bool {
+	for _, line := range strings.Split(uevent, "\n") {
+		// HID_ID=bus:vendor:product
+		if strings.HasPrefix(line, "HID_ID=") {
+			parts := strings.Split(strings.TrimPrefix(line, "HID_ID="), ":")
+			return len(parts) == 3 && strings.EqualFold(parts[1], ledgerVendorID)
+		}
+	}
+	return false
+}
+
+// OpenHIDPath opens the hidraw device at path, e.g. "/dev/hidraw0"
+func OpenHIDPath(path string) (*HIDTransport, error) {
+	device, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &HIDTransport{device: device}, nil
+}
+
+// Exchange sends an APDU to the device and returns its answer
+func (t *HIDTransport) Exchange(apdu []byte) ([]byte, error) {
+	for _, packet := range wrapHID(apdu) {
+		// hidraw takes the report ID, 0 for devices without numbered reports,
+		// ahead of the packet
+		if _, err := t.device.Write(append([]byte{0}, packet...)); err != nil {
+			return nil, err
+		}
+	}
+	reader := hidReader{next: func() ([]byte, error) {
+		packet := make([]byte, hidPacketSize)
+		n, err := t.device.Read(packet)
+		return packet[:n], err
+	}}
+	return reader.read()
+}
+
+// Close closes the device
+func (t *HIDTransport) Close() error {
+	return t.device.Close()
+}
@@ -0,0 +1,196 @@
+// Package ledger signs transactions with the Algorand app of Ledger hardware
+// wallets. The device is reached through a Transport exchanging APDUs with it;
+// a transport over Linux hidraw devices is built with the "ledger" build tag.
+package ledger
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/wallet"
+)
+
+// The APDU class and instructions of the Algorand app
+const (
+	CLA             = 0x80
+	InsGetPublicKey = 0x03
+	InsSignMsgpack  = 0x08
+)
+
+// The parameters of the sign instruction, saying which chunk of the
+// transaction an APDU carries
+const (
+	P1First          = 0x00
+	P1FirstAccountID = 0x01
+	P1More           = 0x80
+	P2Last           = 0x00
+	P2More           = 0x80
+)
+
+// ChunkSize is the largest number of transaction bytes sent in one APDU
+const ChunkSize = 250
+
+// The status words the app answers with
+const (
+	StatusOK                = 0x9000
+	StatusRejected          = 0x6985
+	StatusWrongLength       = 0x6700
+	StatusInvalidData       = 0x6a80
+	StatusInsNotSupported   = 0x6d00
+	StatusClaNotSupported   = 0x6e00
+	StatusLocked            = 0x6982
+	StatusAppNotOpen        = 0x6511
+	StatusTransactionTooBig = 0x6a84
+)
+
+// StatusError is the error of an APDU the device did not answer with
+// StatusOK
+type StatusError struct {
+	Code uint16
+}
+
+func (e StatusError) Error() string {
+	var reason string
+	switch e.Code {
+	case StatusRejected:
+		reason = "rejected by the user"
+	case StatusLocked:
+		reason = "device locked"
+	case StatusAppNotOpen, StatusClaNotSupported:
+		reason = "Algorand app not open"
+	case StatusInsNotSupported:
+		reason = "instruction not supported by the app"
+	case StatusWrongLength, StatusInvalidData:
+		reason = "invalid request"
+	case StatusTransactionTooBig:
+		reason = "transaction too big"
+	default:
+		reason = "unknown error"
+	}
+	return fmt.Sprintf("ledger: %s (status 0x%04x)", reason, e.Code)
+}
+
+// APDU is a command sent to the device
+type APDU struct {
+	CLA, INS, P1, P2 byte
+	Data             []byte
+}
+
+// Encode returns the bytes of the APDU
+func (a APDU) Encode() []byte {
+	return append([]byte{a.CLA, a.INS, a.P1, a.P2, byte(len(a.Data))}, a.Data...)
+}
+
+// Transport exchanges APDUs with a device
+type Transport interface {
+	// Exchange sends an encoded APDU and returns the device's answer,
+	// ending with its 2 byte status word
+	Exchange(apdu []byte) ([]byte, error)
+}
+
+// exchange sends apdu through t and returns the answer without its status
+// word, or a StatusError
+func exchange(t Transport, apdu APDU) ([]byte, error) {
+	answer, err := t.Exchange(apdu.Encode())
+	if err != nil {
+		return nil, err
+	}
+	if len(answer) < 2 {
+		return nil, fmt.Errorf("ledger: answer has length %d, too short for a status word", len(answer))
+	}
+	n := len(answer) - 2
+	if code := binary.BigEndian.Uint16(answer[n:]); code != StatusOK {
+		return nil, StatusError{Code: code}
+	}
+	return answer[:n], nil
+}
+
+// GetPublicKeyAPDU returns the APDU asking for the public key of the given
+// account of the device
+func GetPublicKeyAPDU(account uint32) APDU {
+	data := make([]byte, 4)
+	binary.BigEndian.PutUint32(data, account)
+	return APDU{CLA: CLA, INS: InsGetPublicKey, P1: 0x00, P2: 0x00, Data: data}
+}
+
+// SignMsgpackAPDUs returns the APDUs asking the device to sign a msgpack
+// encoded transaction with the given account. The account index is sent
+// ahead of the transaction, which is split in chunks of ChunkSize bytes. The
+// device signs the transaction with the "TX" prefix.
+func SignMsgpackAPDUs(account uint32, msgpackTxn []byte) []APDU {
+	payload := make([]byte, 4, 4+len(msgpackTxn))
+	binary.BigEndian.PutUint32(payload, account)
+	payload = append(payload, msgpackTxn...)
+
+	chunks := wallet.Chunk(payload, ChunkSize)
+	apdus := make([]APDU, len(chunks))
+	for i, chunk := range chunks {
+		apdu := APDU{CLA: CLA, INS: InsSignMsgpack, P1: P1More, P2: P2More, Data: chunk}
+		if i == 0 {
+			apdu.P1 = P1FirstAccountID
+		}
+		if i == len(chunks)-1 {
+			apdu.P2 = P2Last
+		}
+		apdus[i] = apdu
+	}
+	return apdus
+}
+
+// Signer signs with an account of a Ledger device's Algorand app. It is a
+// wallet.ExternalSigner, so it signs transactions through
+// wallet.MakeExternalTransactionSigner. Exchanges with the device are not
+// interrupted when ctx is done: the user has to confirm or reject each
+// transaction on the device.
+type Signer struct {
+	transport Transport
+	account   uint32
+}
+
+// MakeSigner returns a signer with the given account of the device reached
+// through transport
+func MakeSigner(transport Transport, account uint32) Signer {
+	return Signer{transport: transport, account: account}
+}
+
+// PublicKey returns the public key of the signer's account
+func (s Signer) PublicKey(ctx context.Context) (ed25519.PublicKey, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	answer, err := exchange(s.transport, GetPublicKeyAPDU(s.account))
+	if err != nil {
+		return nil, err
+	}
+	if len(answer) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("ledger: public key has length %d, expected %d", len(answer), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(answer), nil
+}
+
+// Sign returns the signature of message, which must be the bytes a
+// transaction's signature signs: the app only signs transactions, which it
+// shows to the user for confirmation.
+func (s Signer) Sign(ctx context.Context, message []byte) ([]byte, error) {
+	msgpackTxn, err := wallet.SplitTransactionPrefix(message)
+	if err != nil {
+		return nil, err
+	}
+	var answer []byte
+	for _, apdu := range SignMsgpackAPDUs(s.account, msgpackTxn) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		answer, err = exchange(s.transport, apdu)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(answer) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("ledger: signature has length %d, expected %d", len(answer), ed25519.SignatureSize)
+	}
+	return answer, nil
+}
@@ -0,0 +1,147 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+	"github.com/algorand/go-algorand-sdk/wallet"
+)
+
+var _ wallet.ExternalSigner = Signer{}
+
+// fakeDevice is an Algorand app holding a single account, framing its
+// exchanges in HID packets as a device does
+type fakeDevice struct {
+	t       *testing.T
+	account crypto.Account
+	reject  bool
+	pending []byte
+}
+
+func (d *fakeDevice) Exchange(apdu []byte) ([]byte, error) {
+	// round-trip through the HID framing
+	var packets [][]byte
+	for _, packet := range wrapHID(apdu) {
+		require.Len(d.t, packet, hidPacketSize)
+		packets = append(packets, packet)
+	}
+	apdu, err := hidReader{next: func() ([]byte, error) {
+		packet := packets[0]
+		packets = packets[1:]
+		return packet, nil
+	}}.read()
+	require.NoError(d.t, err)
+
+	answer := d.answer(apdu)
+	packets = wrapHID(answer)
+	return hidReader{next: func() ([]byte, error) {
+		packet := packets[0]
+		packets = packets[1:]
+		return packet, nil
+	}}.read()
+}
+
+func (d *fakeDevice) answer(apdu []byte) []byte {
+	status := func(code uint16, data []byte) []byte {
+		return append(data, byte(code>>8), byte(code))
+	}
+	require.Equal(d.t, CLA, int(apdu[0]))
+	require.Equal(d.t, int(apdu[4]), len(apdu)-5)
+	data := apdu[5:]
+	switch apdu[1] {
+	case InsGetPublicKey:
+		if binary.BigEndian.Uint32(data) != 0 {
+			return status(StatusInvalidData, nil)
+		}
+		return status(StatusOK, append([]byte{}, d.account.PublicKey...))
+	case InsSignMsgpack:
+		if apdu[2] == P1FirstAccountID {
+			if binary.BigEndian.Uint32(data) != 0 {
+				return status(StatusInvalidData, nil)
+			}
+			d.pending = append([]byte{}, data[4:]...)
+		} else {
+			d.pending = append(d.pending, data...)
+		}
+		if apdu[3] == P2More {
+			return status(StatusOK, nil)
+		}
+		if d.reject {
+			return status(StatusRejected, nil)
+		}
+		return status(StatusOK, ed25519.Sign(ed25519.PrivateKey(d.account.PrivateKey), append([]byte("TX"), d.pending...)))
+	}
+	return status(StatusInsNotSupported, nil)
+}
+
+func TestSignMsgpackAPDUs(t *testing.T) {
+	txn := bytes.Repeat([]byte{7}, 2*ChunkSize)
+	apdus := SignMsgpackAPDUs(1, txn)
+	require.Len(t, apdus, 3)
+	require.Equal(t, []byte{0, 0, 0, 1}, apdus[0].Data[:4])
+	require.Equal(t, []byte{P1FirstAccountID, P1More, P1More}, []byte{apdus[0].P1, apdus[1].P1, apdus[2].P1})
+	require.Equal(t, []byte{P2More, P2More, P2Last}, []byte{apdus[0].P2, apdus[1].P2, apdus[2].P2})
+	require.Len(t, apdus[2].Data, 4)
+
+	encoded := apdus[2].Encode()
+	require.Equal(t, []byte{CLA, InsSignMsgpack, P1More, P2Last, 4, 7, 7, 7, 7}, encoded)
+
+	apdus = SignMsgpackAPDUs(0, []byte{1})
+	require.Len(t, apdus, 1)
+	require.Equal(t, byte(P1FirstAccountID), apdus[0].P1)
+	require.Equal(t, byte(P2Last), apdus[0].P2)
+}
+
+func TestHIDFraming(t *testing.T) {
+	for _, size := range []int{0, 10, 57, 58, 300} {
+		apdu := bytes.Repeat([]byte{1}, size)
+		packets := wrapHID(apdu)
+		answer, err := hidReader{next: func() ([]byte, error) {
+			packet := packets[0]
+			packets = packets[1:]
+			return packet, nil
+		}}.read()
+		require.NoError(t, err)
+		require.Equal(t, apdu, answer)
+		require.Empty(t, packets)
+	}
+
+	packets := wrapHID([]byte{1})
+	packets[0][4] = 1
+	_, err := hidReader{next: func() ([]byte, error) { return packets[0], nil }}.read()
+	require.Error(t, err)
+}
+
+func TestSigner(t *testing.T) {
+	device := &fakeDevice{t: t, account: crypto.GenerateAccount()}
+	signer, err := wallet.MakeExternalTransactionSigner(context.Background(), MakeSigner(device, 0))
+	require.NoError(t, err)
+	require.Equal(t, device.account.PublicKey, signer.PublicKey)
+
+	txn := types.Transaction{
+		Type:   types.PaymentTx,
+		Header: types.Header{Sender: device.account.Address, Note: bytes.Repeat([]byte{1}, 600)},
+	}
+	stxs, err := signer.SignTransactions(context.Background(), []types.Transaction{txn}, []int{0})
+	require.NoError(t, err)
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxs[0], &stx))
+	require.True(t, crypto.VerifySignedTransaction(stx))
+
+	device.reject = true
+	_, err = signer.SignTransactions(context.Background(), []types.Transaction{txn}, []int{0})
+	require.Equal(t, StatusError{Code: StatusRejected}, err)
+
+	_, err = MakeSigner(device, 0).Sign(context.Background(), []byte("not a transaction"))
+	require.Error(t, err)
+	_, err = MakeSigner(device, 1).PublicKey(context.Background())
+	require.Equal(t, StatusError{Code: StatusInvalidData}, err)
+}
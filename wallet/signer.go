@@ -0,0 +1,217 @@
+// Package wallet signs transactions with pluggable signers: the keys of
+// accounts, logic signatures, multisig accounts, kmd wallets and keys held
+// outside the SDK, such as in hardware security modules, all behind the
+// TransactionSigner interface used by the atomic transaction composer and the
+// templates. A Wallet holds the signers of several accounts.
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"golang.org/x/crypto/ed25519"
+
+	"github.com/algorand/go-algorand-sdk/client"
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// TransactionSigner signs some of the transactions in a group.
+type TransactionSigner interface {
+	// SignTransactions signs the transactions of txGroup at indexesToSign, returning
+	// one encoded signed transaction for each index, in the same order.
+	SignTransactions(ctx context.Context, txGroup []types.Transaction, indexesToSign []int) ([][]byte, error)
+	// Equals reports whether other signs with the same keys, so that the
+	// transactions of both can be signed in a single call.
+	Equals(other TransactionSigner) bool
+}
+
+// BasicAccountTransactionSigner signs transactions with a single account's private key.
+type BasicAccountTransactionSigner struct {
+	Account crypto.Account
+}
+
+// SignTransactions signs the transactions of txGroup at indexesToSign with the account's key.
+func (s BasicAccountTransactionSigner) SignTransactions(ctx context.Context, txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	stxs := make([][]byte, len(indexesToSign))
+	for i, pos := range indexesToSign {
+		_, stxBytes, err := crypto.SignTransaction(s.Account.PrivateKey, txGroup[pos])
+		if err != nil {
+			return nil, err
+		}
+		stxs[i] = stxBytes
+	}
+	return stxs, nil
+}
+
+// Equals reports whether other is a BasicAccountTransactionSigner with the same key.
+func (s BasicAccountTransactionSigner) Equals(other TransactionSigner) bool {
+	if o, ok := other.(BasicAccountTransactionSigner); ok {
+		return bytes.Equal(s.Account.PrivateKey, o.Account.PrivateKey)
+	}
+	return false
+}
+
+// LogicSigAccountTransactionSigner signs transactions with a logic signature,
+// either for a contract account or delegated by an account, for transactions
+// sent by that account or by an account rekeyed to it.
+type LogicSigAccountTransactionSigner struct {
+	LogicSigAccount crypto.LogicSigAccount
+}
+
+// SignTransactions attaches the logic signature to the transactions of txGroup at indexesToSign.
+func (s LogicSigAccountTransactionSigner) SignTransactions(ctx context.Context, txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	stxs := make([][]byte, len(indexesToSign))
+	for i, pos := range indexesToSign {
+		_, stxBytes, err := crypto.SignLogicSigAccountTransaction(s.LogicSigAccount, txGroup[pos])
+		if err != nil {
+			return nil, err
+		}
+		stxs[i] = stxBytes
+	}
+	return stxs, nil
+}
+
+// Equals reports whether other is a LogicSigAccountTransactionSigner with the same logic signature account.
+func (s LogicSigAccountTransactionSigner) Equals(other TransactionSigner) bool {
+	if o, ok := other.(LogicSigAccountTransactionSigner); ok {
+		return bytes.Equal(msgpack.Encode(s.LogicSigAccount), msgpack.Encode(o.LogicSigAccount))
+	}
+	return false
+}
+
+// MultiSigAccountTransactionSigner signs transactions for a multisig account
+// with the private keys in Sks, which should meet the account's threshold.
+type MultiSigAccountTransactionSigner struct {
+	Msig crypto.MultisigAccount
+	Sks  []crypto.SecretKey
+}
+
+// SignTransactions signs the transactions of txGroup at indexesToSign with each
+// of the signer's keys and merges the signatures.
+func (s MultiSigAccountTransactionSigner) SignTransactions(ctx context.Context, txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	if len(s.Sks) == 0 {
+		return nil, fmt.Errorf("no keys to sign the multisig transactions with")
+	}
+	stxs := make([][]byte, len(indexesToSign))
+	for i, pos := range indexesToSign {
+		partials := make([][]byte, len(s.Sks))
+		for j, sk := range s.Sks {
+			_, stxBytes, err := crypto.SignMultisigTransaction(sk, s.Msig, txGroup[pos])
+			if err != nil {
+				return nil, err
+			}
+			partials[j] = stxBytes
+		}
+		if len(partials) == 1 {
+			stxs[i] = partials[0]
+			continue
+		}
+		_, merged, err := crypto.MergeMultisigTransactions(partials...)
+		if err != nil {
+			return nil, err
+		}
+		stxs[i] = merged
+	}
+	return stxs, nil
+}
+
+// Equals reports whether other is a MultiSigAccountTransactionSigner for the
+// same multisig account with the same keys.
+func (s MultiSigAccountTransactionSigner) Equals(other TransactionSigner) bool {
+	o, ok := other.(MultiSigAccountTransactionSigner)
+	if !ok || s.Msig.Version != o.Msig.Version || s.Msig.Threshold != o.Msig.Threshold ||
+		len(s.Msig.Pks) != len(o.Msig.Pks) || len(s.Sks) != len(o.Sks) {
+		return false
+	}
+	for i := range s.Msig.Pks {
+		if !bytes.Equal(s.Msig.Pks[i], o.Msig.Pks[i]) {
+			return false
+		}
+	}
+	for i := range s.Sks {
+		if !bytes.Equal(s.Sks[i], o.Sks[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// KMDTransactionSigner signs transactions with the keys held in a kmd wallet.
+// Each transaction is signed by the wallet key of its sender.
+type KMDTransactionSigner struct {
+	Client         client.KmdClient
+	WalletHandle   string
+	WalletPassword string
+}
+
+// SignTransactions asks kmd to sign the transactions of txGroup at indexesToSign.
+func (s KMDTransactionSigner) SignTransactions(ctx context.Context, txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	stxs := make([][]byte, len(indexesToSign))
+	for i, pos := range indexesToSign {
+		resp, err := s.Client.SignTransaction(ctx, s.WalletHandle, s.WalletPassword, txGroup[pos])
+		if err != nil {
+			return nil, err
+		}
+		stxs[i] = resp.SignedTransaction
+	}
+	return stxs, nil
+}
+
+// Equals reports whether other is a KMDTransactionSigner for the same wallet handle.
+func (s KMDTransactionSigner) Equals(other TransactionSigner) bool {
+	if o, ok := other.(KMDTransactionSigner); ok {
+		return s.WalletHandle == o.WalletHandle && s.WalletPassword == o.WalletPassword
+	}
+	return false
+}
+
+// RawTransactionSigner signs transactions with an ed25519 key held outside the
+// SDK, such as in a hardware security module, a cloud KMS or a hardware
+// wallet, given a function signing bytes with it. Transactions sent by other
+// accounts than the key's are signed as sent by an account rekeyed to it.
+type RawTransactionSigner struct {
+	// PublicKey is the public key of the signing key
+	PublicKey ed25519.PublicKey
+
+	// Sign returns the ed25519 signature of message by the signing key
+	Sign func(ctx context.Context, message []byte) ([]byte, error)
+}
+
+// Address returns the address of the account of the signing key
+func (s RawTransactionSigner) Address() (addr types.Address) {
+	copy(addr[:], s.PublicKey)
+	return
+}
+
+// SignTransactions signs the transactions of txGroup at indexesToSign with
+// Sign, checking each signature against the public key.
+func (s RawTransactionSigner) SignTransactions(ctx context.Context, txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	if len(s.PublicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key has length %d, expected %d", len(s.PublicKey), ed25519.PublicKeySize)
+	}
+	addr := s.Address()
+	stxs := make([][]byte, len(indexesToSign))
+	for i, pos := range indexesToSign {
+		tx := txGroup[pos]
+		signature, err := s.Sign(ctx, crypto.RawTransactionBytesToSign(tx))
+		if err != nil {
+			return nil, err
+		}
+		_, stxs[i], err = crypto.AttachSignatureWithSigner(tx, addr, signature)
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %v", pos, err)
+		}
+	}
+	return stxs, nil
+}
+
+// Equals reports whether other is a RawTransactionSigner with the same public key.
+func (s RawTransactionSigner) Equals(other TransactionSigner) bool {
+	if o, ok := other.(RawTransactionSigner); ok {
+		return bytes.Equal(s.PublicKey, o.PublicKey)
+	}
+	return false
+}
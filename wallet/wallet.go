@@ -0,0 +1,115 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// Wallet is a TransactionSigner holding the signers of several accounts. It
+// signs each transaction with the signer of its sender, so that a group sent
+// by several accounts can be signed with a single signer. It is safe for
+// concurrent use.
+type Wallet struct {
+	mu      sync.RWMutex
+	signers map[types.Address]TransactionSigner
+}
+
+// MakeWallet returns an empty wallet
+func MakeWallet() *Wallet {
+	return &Wallet{signers: make(map[types.Address]TransactionSigner)}
+}
+
+// Add sets the signer of the transactions sent by addr, replacing any signer
+// it had. For an account rekeyed to another, it is the signer of the other
+// account, e.g. a RawTransactionSigner of the other account's key.
+func (w *Wallet) Add(addr types.Address, signer TransactionSigner) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.signers[addr] = signer
+}
+
+// Remove removes the signer of addr
+func (w *Wallet) Remove(addr types.Address) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.signers, addr)
+}
+
+// Signer returns the signer of addr, and whether it has one
+func (w *Wallet) Signer(addr types.Address) (TransactionSigner, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	signer, ok := w.signers[addr]
+	return signer, ok
+}
+
+// Addresses returns the addresses of the accounts the wallet has signers of,
+// in order
+func (w *Wallet) Addresses() []types.Address {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	addrs := make([]types.Address, 0, len(w.signers))
+	for addr := range w.signers {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].String() < addrs[j].String() })
+	return addrs
+}
+
+// SignTransactions signs each transaction of txGroup at indexesToSign with the
+// signer of its sender, calling each signer once for all the transactions it
+// signs. It returns an error if a sender has no signer.
+func (w *Wallet) SignTransactions(ctx context.Context, txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	// batches are the indexes each distinct signer signs, in order
+	type batch struct {
+		signer  TransactionSigner
+		indexes []int
+		// positions are the positions of indexes in indexesToSign
+		positions []int
+	}
+	var batches []*batch
+	for i, pos := range indexesToSign {
+		signer, ok := w.Signer(txGroup[pos].Sender)
+		if !ok {
+			return nil, fmt.Errorf("no signer for %s, the sender of transaction %d", txGroup[pos].Sender.String(), pos)
+		}
+		var b *batch
+		for _, existing := range batches {
+			if existing.signer.Equals(signer) {
+				b = existing
+				break
+			}
+		}
+		if b == nil {
+			b = &batch{signer: signer}
+			batches = append(batches, b)
+		}
+		b.indexes = append(b.indexes, pos)
+		b.positions = append(b.positions, i)
+	}
+
+	stxs := make([][]byte, len(indexesToSign))
+	for _, b := range batches {
+		signed, err := b.signer.SignTransactions(ctx, txGroup, b.indexes)
+		if err != nil {
+			return nil, err
+		}
+		if len(signed) != len(b.indexes) {
+			return nil, fmt.Errorf("signer returned %d signed transactions, expected %d", len(signed), len(b.indexes))
+		}
+		for j, i := range b.positions {
+			stxs[i] = signed[j]
+		}
+	}
+	return stxs, nil
+}
+
+// Equals reports whether other is the same wallet
+func (w *Wallet) Equals(other TransactionSigner) bool {
+	o, ok := other.(*Wallet)
+	return ok && o == w
+}
@@ -0,0 +1,118 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/algorand/go-algorand-sdk/crypto"
+	"github.com/algorand/go-algorand-sdk/encoding/msgpack"
+	"github.com/algorand/go-algorand-sdk/types"
+)
+
+// countingSigner counts the calls to the signer it wraps
+type countingSigner struct {
+	TransactionSigner
+	calls *int
+}
+
+func (s countingSigner) SignTransactions(ctx context.Context, txGroup []types.Transaction, indexesToSign []int) ([][]byte, error) {
+	*s.calls++
+	return s.TransactionSigner.SignTransactions(ctx, txGroup, indexesToSign)
+}
+
+func (s countingSigner) Equals(other TransactionSigner) bool {
+	o, ok := other.(countingSigner)
+	return ok && o.calls == s.calls
+}
+
+func TestWallet(t *testing.T) {
+	accounts := []crypto.Account{crypto.GenerateAccount(), crypto.GenerateAccount(), crypto.GenerateAccount()}
+	var calls int
+	w := MakeWallet()
+	w.Add(accounts[0].Address, countingSigner{BasicAccountTransactionSigner{Account: accounts[0]}, &calls})
+	w.Add(accounts[1].Address, BasicAccountTransactionSigner{Account: accounts[1]})
+	require.Len(t, w.Addresses(), 2)
+	_, ok := w.Signer(accounts[2].Address)
+	require.False(t, ok)
+
+	txGroup := []types.Transaction{
+		{Header: types.Header{Sender: accounts[0].Address}},
+		{Header: types.Header{Sender: accounts[1].Address}},
+		{Header: types.Header{Sender: accounts[0].Address, Fee: 1}},
+	}
+	stxs, err := w.SignTransactions(context.Background(), txGroup, []int{2, 1, 0})
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	for i, pos := range []int{2, 1, 0} {
+		var stx types.SignedTxn
+		require.NoError(t, msgpack.Decode(stxs[i], &stx))
+		require.Equal(t, txGroup[pos], stx.Txn)
+		require.True(t, crypto.VerifySignedTransaction(stx))
+	}
+
+	txGroup[1].Sender = accounts[2].Address
+	_, err = w.SignTransactions(context.Background(), txGroup, []int{0, 1})
+	require.Error(t, err)
+	w.Remove(accounts[0].Address)
+	_, err = w.SignTransactions(context.Background(), txGroup, []int{0})
+	require.Error(t, err)
+
+	require.True(t, w.Equals(w))
+	require.False(t, w.Equals(MakeWallet()))
+}
+
+func TestRawTransactionSigner(t *testing.T) {
+	account := crypto.GenerateAccount()
+	other := crypto.GenerateAccount()
+	// a signer whose key is held elsewhere, e.g. in an HSM
+	signer := RawTransactionSigner{
+		PublicKey: account.PublicKey,
+		Sign: func(ctx context.Context, message []byte) ([]byte, error) {
+			return account.PrivateKey.Sign(message)
+		},
+	}
+
+	txGroup := []types.Transaction{
+		{Header: types.Header{Sender: account.Address}},
+		{Header: types.Header{Sender: other.Address}},
+	}
+	stxs, err := signer.SignTransactions(context.Background(), txGroup, []int{0, 1})
+	require.NoError(t, err)
+	_, expected, err := crypto.SignTransaction(account.PrivateKey, txGroup[0])
+	require.NoError(t, err)
+	require.Equal(t, expected, stxs[0])
+	var stx types.SignedTxn
+	require.NoError(t, msgpack.Decode(stxs[1], &stx))
+	require.Equal(t, account.Address, stx.AuthAddr)
+	require.True(t, crypto.VerifySignedTransaction(stx))
+
+	require.True(t, signer.Equals(RawTransactionSigner{PublicKey: account.PublicKey}))
+	require.False(t, signer.Equals(RawTransactionSigner{PublicKey: other.PublicKey}))
+
+	wrongKey := RawTransactionSigner{PublicKey: other.PublicKey, Sign: signer.Sign}
+	_, err = wrongKey.SignTransactions(context.Background(), txGroup, []int{0})
+	require.Error(t, err)
+	failing := RawTransactionSigner{
+		PublicKey: account.PublicKey,
+		Sign: func(ctx context.Context, message []byte) ([]byte, error) {
+			return nil, errors.New("device disconnected")
+		},
+	}
+	_, err = failing.SignTransactions(context.Background(), txGroup, []int{0})
+	require.EqualError(t, err, "device disconnected")
+}
+
+func TestChunk(t *testing.T) {
+	require.Equal(t, [][]byte{{}}, Chunk([]byte{}, 3))
+	require.Equal(t, [][]byte{{1, 2, 3}}, Chunk([]byte{1, 2, 3}, 3))
+	require.Equal(t, [][]byte{{1, 2, 3}, {4}}, Chunk([]byte{1, 2, 3, 4}, 3))
+
+	_, err := SplitTransactionPrefix([]byte("MX1234"))
+	require.Error(t, err)
+	txn, err := SplitTransactionPrefix([]byte("TX1234"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("1234"), txn)
+}